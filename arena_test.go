@@ -0,0 +1,168 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestArenaStore(t *testing.T) {
+	tests := map[string]struct {
+		traits []func(*Traits)
+	}{
+		"directed graph": {
+			traits: []func(*Traits){Directed()},
+		},
+		"undirected graph": {},
+	}
+
+	for name, test := range tests {
+		random := rand.New(rand.NewSource(1))
+
+		g := NewWithStore(IntHash, NewArenaStore[int, int](0, 0), test.traits...)
+		reference := New(IntHash, test.traits...)
+
+		// Apply the same random operations to both graphs, including removals
+		// whose slots are reused by later additions.
+		for i := 0; i < 2000; i++ {
+			source, target := random.Intn(30), random.Intn(30)
+
+			var err, expectedErr error
+
+			switch random.Intn(6) {
+			case 0:
+				err, expectedErr = g.AddVertex(source), reference.AddVertex(source)
+			case 1:
+				err, expectedErr = g.RemoveVertex(source), reference.RemoveVertex(source)
+			case 2:
+				_, err = RemoveVertexWithEdges(g, source)
+				_, expectedErr = RemoveVertexWithEdges(reference, source)
+			case 3:
+				err, expectedErr = g.RemoveEdge(source, target), reference.RemoveEdge(source, target)
+			default:
+				err = g.AddEdge(source, target, EdgeWeight(i))
+				expectedErr = reference.AddEdge(source, target, EdgeWeight(i))
+			}
+
+			if !errors.Is(err, expectedErr) && (err == nil || expectedErr == nil) {
+				t.Fatalf("%s: operation %d: error expectancy doesn't match: expected %v, got %v", name, i, expectedErr, err)
+			}
+		}
+
+		order, _ := g.Order()
+		expectedOrder, _ := reference.Order()
+
+		if order != expectedOrder {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, expectedOrder, order)
+		}
+
+		size, _ := g.Size()
+		expectedSize, _ := reference.Size()
+
+		if size != expectedSize {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, expectedSize, size)
+		}
+
+		adjacencyMap, _ := g.AdjacencyMap()
+		expectedAdjacencyMap, _ := reference.AdjacencyMap()
+
+		if len(adjacencyMap) != len(expectedAdjacencyMap) {
+			t.Fatalf("%s: number of vertices doesn't match: expected %v, got %v", name, len(expectedAdjacencyMap), len(adjacencyMap))
+		}
+
+		for vertex, expectedEdges := range expectedAdjacencyMap {
+			for adjacency, expectedEdge := range expectedEdges {
+				edge, ok := adjacencyMap[vertex][adjacency]
+				if !ok || edge.Properties.Weight != expectedEdge.Properties.Weight {
+					t.Errorf("%s: edge (%v, %v) doesn't match: expected %v, got %v", name, vertex, adjacency, expectedEdge, edge)
+				}
+			}
+			if len(adjacencyMap[vertex]) != len(expectedEdges) {
+				t.Errorf("%s: edges of %v don't match: expected %v, got %v", name, vertex, expectedEdges, adjacencyMap[vertex])
+			}
+		}
+
+		predecessorMap, _ := g.PredecessorMap()
+		expectedPredecessorMap, _ := reference.PredecessorMap()
+
+		for vertex, expectedEdges := range expectedPredecessorMap {
+			if len(predecessorMap[vertex]) != len(expectedEdges) {
+				t.Errorf("%s: predecessors of %v don't match: expected %v, got %v", name, vertex, expectedEdges, predecessorMap[vertex])
+			}
+		}
+
+		for vertex := range expectedAdjacencyMap {
+			var visited, expectedVisited []int
+
+			_ = DFS(g, vertex, func(hash int) bool {
+				visited = append(visited, hash)
+				return false
+			})
+			_ = DFS(reference, vertex, func(hash int) bool {
+				expectedVisited = append(expectedVisited, hash)
+				return false
+			})
+
+			sort.Ints(visited)
+			sort.Ints(expectedVisited)
+
+			if !slicesAreEqual(visited, expectedVisited) {
+				t.Errorf("%s: vertices reachable from %v don't match: expected %v, got %v", name, vertex, expectedVisited, visited)
+			}
+		}
+	}
+}
+
+func TestArenaStore_UpdateEdge(t *testing.T) {
+	store := NewArenaStore[int, int](2, 1)
+	g := NewWithStore(IntHash, store, Directed())
+
+	_ = g.AddVertex(1)
+	_ = g.AddVertex(2)
+	_ = g.AddEdge(1, 2, EdgeWeight(1))
+
+	if err := g.UpdateEdge(1, 2, EdgeWeight(5)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	edge, err := g.Edge(1, 2)
+	if err != nil || edge.Properties.Weight != 5 {
+		t.Errorf("edge doesn't match: got %v (error: %v)", edge, err)
+	}
+
+	if err := store.UpdateEdge(2, 1, Edge[int]{}); !errors.Is(err, ErrEdgeNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrEdgeNotFound, err)
+	}
+
+	if err := store.AddEdge(1, 3, Edge[int]{}); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrVertexNotFound, err)
+	}
+}
+
+func BenchmarkArenaStore(b *testing.B) {
+	const vertices, edges = 10000, 50000
+
+	build := func(g Graph[int, int]) {
+		for i := 0; i < vertices; i++ {
+			_ = g.AddVertex(i)
+		}
+		for i := 0; i < edges; i++ {
+			_ = g.AddEdge(i%vertices, (i*7+1)%vertices)
+		}
+	}
+
+	b.Run("memory store", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			build(New(IntHash, Directed()))
+		}
+	})
+
+	b.Run("arena store", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			build(NewWithStore(IntHash, NewArenaStore[int, int](vertices, edges), Directed()))
+		}
+	})
+}
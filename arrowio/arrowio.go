@@ -0,0 +1,317 @@
+// Package arrowio writes the vertices and edges of a graph as Apache Arrow and
+// Apache Parquet tables, so that graph data can be loaded into analytics
+// engines such as DuckDB, Spark, or pandas without a CSV intermediate:
+//
+//	vertices, _ := os.Create("vertices.parquet")
+//	edges, _ := os.Create("edges.parquet")
+//
+//	_ = arrowio.WriteParquet(g, vertices, edges)
+//
+// The tables have the schemas [VertexSchema] and [EdgeSchema]. Vertex hashes
+// of type string are stored as they are, all other hash types are stored as
+// JSON, which is the same encoding that the database stores in this repository
+// use. Vertex values and edge data are stored as JSON as well. Undirected edges
+// appear once, with an arbitrary one of their vertices as source.
+//
+// The tables are written in record batches, so the memory required for
+// exporting a graph doesn't depend on the size of the graph. Stores that
+// implement graph.PagingStore and graph.EdgeIterator don't even have to load
+// the graph into memory.
+package arrowio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/internal/storage"
+)
+
+var attributesType = arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String)
+
+// VertexSchema is the schema of the vertex table.
+var VertexSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "hash", Type: arrow.BinaryTypes.String},
+	{Name: "value", Type: arrow.BinaryTypes.String},
+	{Name: "weight", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "attributes", Type: attributesType},
+}, nil)
+
+// EdgeSchema is the schema of the edge table. The data column is null for edges
+// without data.
+var EdgeSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "source", Type: arrow.BinaryTypes.String},
+	{Name: "target", Type: arrow.BinaryTypes.String},
+	{Name: "weight", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "attributes", Type: attributesType},
+	{Name: "data", Type: arrow.BinaryTypes.String, Nullable: true},
+}, nil)
+
+type config struct {
+	batchSize int
+	allocator memory.Allocator
+}
+
+func newConfig(options ...func(*config)) config {
+	c := config{
+		batchSize: 64 * 1024,
+		allocator: memory.DefaultAllocator,
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// BatchSize sets the maximum number of rows in a record batch. For Parquet
+// files, each batch becomes a row group. The default is 65536.
+func BatchSize(rows int) func(*config) {
+	return func(c *config) {
+		c.batchSize = rows
+	}
+}
+
+// Allocator sets the memory allocator used for building the record batches.
+// The default is memory.DefaultAllocator.
+func Allocator(allocator memory.Allocator) func(*config) {
+	return func(c *config) {
+		c.allocator = allocator
+	}
+}
+
+// tableWriter is implemented by ipc.FileWriter and pqarrow.FileWriter.
+type tableWriter interface {
+	Write(record arrow.Record) error
+	Close() error
+}
+
+// WriteArrow writes the vertex table to vertices and the edge table to edges,
+// both in the Arrow IPC file format, also known as Feather V2.
+func WriteArrow[K comparable, T any](g graph.Graph[K, T], vertices, edges io.Writer, options ...func(*config)) error {
+	c := newConfig(options...)
+
+	newWriter := func(w io.Writer, schema *arrow.Schema) (tableWriter, error) {
+		return ipc.NewFileWriter(w, ipc.WithSchema(schema), ipc.WithAllocator(c.allocator))
+	}
+
+	return write(g, vertices, edges, c, newWriter)
+}
+
+// WriteParquet writes the vertex table to vertices and the edge table to edges,
+// both as Parquet files.
+func WriteParquet[K comparable, T any](g graph.Graph[K, T], vertices, edges io.Writer, options ...func(*config)) error {
+	c := newConfig(options...)
+
+	newWriter := func(w io.Writer, schema *arrow.Schema) (tableWriter, error) {
+		properties := parquet.NewWriterProperties(parquet.WithAllocator(c.allocator))
+		arrowProperties := pqarrow.NewArrowWriterProperties(pqarrow.WithAllocator(c.allocator))
+		return pqarrow.NewFileWriter(schema, w, properties, arrowProperties)
+	}
+
+	return write(g, vertices, edges, c, newWriter)
+}
+
+func write[K comparable, T any](g graph.Graph[K, T], vertices, edges io.Writer, c config, newWriter func(io.Writer, *arrow.Schema) (tableWriter, error)) error {
+	if c.batchSize <= 0 {
+		return fmt.Errorf("invalid batch size %d", c.batchSize)
+	}
+
+	vertexWriter, err := newWriter(vertices, VertexSchema)
+	if err != nil {
+		return fmt.Errorf("failed to create vertex table writer: %w", err)
+	}
+
+	if err := writeVertices(g, vertexWriter, c); err != nil {
+		_ = vertexWriter.Close()
+		return err
+	}
+
+	if err := vertexWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close vertex table: %w", err)
+	}
+
+	edgeWriter, err := newWriter(edges, EdgeSchema)
+	if err != nil {
+		return fmt.Errorf("failed to create edge table writer: %w", err)
+	}
+
+	if err := writeEdges(g, edgeWriter, c); err != nil {
+		_ = edgeWriter.Close()
+		return err
+	}
+
+	if err := edgeWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close edge table: %w", err)
+	}
+
+	return nil
+}
+
+func writeVertices[K comparable, T any](g graph.Graph[K, T], w tableWriter, c config) error {
+	builder := array.NewRecordBuilder(c.allocator, VertexSchema)
+	defer builder.Release()
+
+	hashes := builder.Field(0).(*array.StringBuilder)
+	values := builder.Field(1).(*array.StringBuilder)
+	weights := builder.Field(2).(*array.Int64Builder)
+	attributes := builder.Field(3).(*array.MapBuilder)
+
+	cursor := ""
+
+	for {
+		page, next, err := graph.VerticesPage(g, cursor, c.batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list vertices: %w", err)
+		}
+
+		for _, hash := range page {
+			value, properties, err := g.VertexWithProperties(hash)
+			if err != nil {
+				return fmt.Errorf("failed to get vertex %v: %w", hash, err)
+			}
+
+			encodedHash, err := storage.EncodeHash(hash)
+			if err != nil {
+				return err
+			}
+
+			encodedValue, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("failed to encode vertex %v: %w", hash, err)
+			}
+
+			hashes.Append(encodedHash)
+			values.Append(string(encodedValue))
+			weights.Append(int64(properties.Weight))
+			appendAttributes(attributes, properties.Attributes)
+		}
+
+		if err := flush(builder, w); err != nil {
+			return fmt.Errorf("failed to write vertices: %w", err)
+		}
+
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func writeEdges[K comparable, T any](g graph.Graph[K, T], w tableWriter, c config) error {
+	builder := array.NewRecordBuilder(c.allocator, EdgeSchema)
+	defer builder.Release()
+
+	sources := builder.Field(0).(*array.StringBuilder)
+	targets := builder.Field(1).(*array.StringBuilder)
+	weights := builder.Field(2).(*array.Int64Builder)
+	attributes := builder.Field(3).(*array.MapBuilder)
+	data := builder.Field(4).(*array.StringBuilder)
+
+	rows := 0
+
+	var writeErr error
+
+	err := graph.IterEdges(g, func(edge graph.Edge[K]) bool {
+		if writeErr = appendEdge(sources, targets, weights, attributes, data, edge); writeErr != nil {
+			return false
+		}
+
+		rows++
+
+		if rows == c.batchSize {
+			if writeErr = flush(builder, w); writeErr != nil {
+				writeErr = fmt.Errorf("failed to write edges: %w", writeErr)
+				return false
+			}
+			rows = 0
+		}
+
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+
+	if err := flush(builder, w); err != nil {
+		return fmt.Errorf("failed to write edges: %w", err)
+	}
+
+	return nil
+}
+
+func appendEdge[K comparable](sources, targets *array.StringBuilder, weights *array.Int64Builder, attributes *array.MapBuilder, data *array.StringBuilder, edge graph.Edge[K]) error {
+	source, err := storage.EncodeHash(edge.Source)
+	if err != nil {
+		return err
+	}
+
+	target, err := storage.EncodeHash(edge.Target)
+	if err != nil {
+		return err
+	}
+
+	sources.Append(source)
+	targets.Append(target)
+	weights.Append(int64(edge.Properties.Weight))
+	appendAttributes(attributes, edge.Properties.Attributes)
+
+	if edge.Properties.Data == nil {
+		data.AppendNull()
+		return nil
+	}
+
+	encoded, err := json.Marshal(edge.Properties.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode data of edge (%v, %v): %w", edge.Source, edge.Target, err)
+	}
+
+	data.Append(string(encoded))
+
+	return nil
+}
+
+// appendAttributes appends the given attributes to the map column, sorted by
+// their keys.
+func appendAttributes(builder *array.MapBuilder, attributes map[string]string) {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	keyBuilder := builder.KeyBuilder().(*array.StringBuilder)
+	itemBuilder := builder.ItemBuilder().(*array.StringBuilder)
+
+	builder.Append(true)
+
+	for _, key := range keys {
+		keyBuilder.Append(key)
+		itemBuilder.Append(attributes[key])
+	}
+}
+
+// flush writes the rows appended to the builder as record batch and resets the
+// builder. Empty batches aren't written.
+func flush(builder *array.RecordBuilder, w tableWriter) error {
+	record := builder.NewRecord()
+	defer record.Release()
+
+	if record.NumRows() == 0 {
+		return nil
+	}
+
+	return w.Write(record)
+}
@@ -0,0 +1,129 @@
+package arrowio
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/dominikbraun/graph"
+)
+
+func newTestGraph() graph.Graph[string, string] {
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	_ = g.AddVertex("A", graph.VertexWeight(2), graph.VertexAttribute("color", "red"))
+	_ = g.AddVertex("B")
+	_ = g.AddVertex("C")
+	_ = g.AddEdge("A", "B", graph.EdgeWeight(3), graph.EdgeData(map[string]int{"lanes": 2}))
+	_ = g.AddEdge("B", "C")
+	_ = g.AddEdge("A", "C")
+
+	return g
+}
+
+func TestWriteArrow(t *testing.T) {
+	allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer allocator.AssertSize(t, 0)
+
+	var vertices, edges bytes.Buffer
+
+	if err := WriteArrow(newTestGraph(), &vertices, &edges, BatchSize(2), Allocator(allocator)); err != nil {
+		t.Fatalf("failed to write tables: %v", err)
+	}
+
+	tests := map[string]struct {
+		data            []byte
+		schema          *arrow.Schema
+		expectedRecords int
+		expectedRows    int64
+	}{
+		"vertices": {
+			data:            vertices.Bytes(),
+			schema:          VertexSchema,
+			expectedRecords: 2,
+			expectedRows:    3,
+		},
+		"edges": {
+			data:            edges.Bytes(),
+			schema:          EdgeSchema,
+			expectedRecords: 2,
+			expectedRows:    3,
+		},
+	}
+
+	for name, test := range tests {
+		reader, err := ipc.NewFileReader(bytes.NewReader(test.data), ipc.WithAllocator(allocator))
+		if err != nil {
+			t.Fatalf("%s: failed to open file: %v", name, err)
+		}
+
+		if !reader.Schema().Equal(test.schema) {
+			t.Errorf("%s: schema doesn't match: expected %v, got %v", name, test.schema, reader.Schema())
+		}
+
+		if reader.NumRecords() != test.expectedRecords {
+			t.Errorf("%s: record count doesn't match: expected %v, got %v", name, test.expectedRecords, reader.NumRecords())
+		}
+
+		var rows int64
+
+		for i := 0; i < reader.NumRecords(); i++ {
+			record, err := reader.Record(i)
+			if err != nil {
+				t.Fatalf("%s: failed to read record %d: %v", name, i, err)
+			}
+			rows += record.NumRows()
+		}
+
+		if rows != test.expectedRows {
+			t.Errorf("%s: row count doesn't match: expected %v, got %v", name, test.expectedRows, rows)
+		}
+
+		_ = reader.Close()
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	var vertices, edges bytes.Buffer
+
+	if err := WriteParquet(newTestGraph(), &vertices, &edges); err != nil {
+		t.Fatalf("failed to write tables: %v", err)
+	}
+
+	table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(edges.Bytes()), nil, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatalf("failed to read edge table: %v", err)
+	}
+	defer table.Release()
+
+	if table.NumRows() != 3 {
+		t.Errorf("row count doesn't match: expected %v, got %v", 3, table.NumRows())
+	}
+
+	reader := array.NewTableReader(table, 0)
+	defer reader.Release()
+
+	data := make(map[string]string)
+
+	for reader.Next() {
+		record := reader.Record()
+		sources := record.Column(0).(*array.String)
+		targets := record.Column(1).(*array.String)
+		column := record.Column(4).(*array.String)
+
+		for i := 0; i < int(record.NumRows()); i++ {
+			if column.IsValid(i) {
+				data[sources.Value(i)+targets.Value(i)] = column.Value(i)
+			}
+		}
+	}
+
+	if len(data) != 1 || data["AB"] != `{"lanes":2}` {
+		t.Errorf("edge data doesn't match: got %v", data)
+	}
+}
@@ -0,0 +1,632 @@
+// Package badgerstore provides a [graph.Store] implementation that persists
+// graphs in BadgerDB, an embeddable key-value store optimized for high write
+// throughput. The store is a good fit for ingesting large streams of edges.
+//
+// All vertices and edges are stored under prefixed keys. Each edge is stored
+// twice, once as an outgoing edge of its source and once as an ingoing edge of
+// its target, so that both directions can be iterated by prefix.
+//
+//	store, _ := badgerstore.Open[string, string]("/path/to/graph")
+//	defer store.Close()
+//
+//	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+//
+// Vertex values are encoded as JSON by default. A different encoding can be
+// configured using the [ValueCodec] option. For ingesting many vertices and
+// edges at once, use [Store.AddVertices] and [Store.AddEdges], which write in
+// batches and skip the existence checks.
+package badgerstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/internal/storage"
+)
+
+var (
+	vertexPrefix  = []byte("v/")
+	outEdgePrefix = []byte("o/")
+	inEdgePrefix  = []byte("i/")
+)
+
+// Codec encodes and decodes vertex values of type T.
+type Codec[T any] interface {
+	Marshal(value T) ([]byte, error)
+	Unmarshal(data []byte) (T, error)
+}
+
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) Marshal(value T) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (jsonCodec[T]) Unmarshal(data []byte) (T, error) {
+	var value T
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+type gobCodec[T any] struct{}
+
+func (gobCodec[T]) Marshal(value T) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(value)
+	return buf.Bytes(), err
+}
+
+func (gobCodec[T]) Unmarshal(data []byte) (T, error) {
+	var value T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value)
+	return value, err
+}
+
+// JSONCodec returns a [Codec] that encodes vertex values as JSON. This is the
+// default codec.
+func JSONCodec[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+// GobCodec returns a [Codec] that encodes vertex values using encoding/gob,
+// which is more compact than JSON for most types.
+func GobCodec[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
+
+type config[T any] struct {
+	codec Codec[T]
+}
+
+// ValueCodec is a functional option for [Open] and [New] that sets the codec
+// used for encoding and decoding vertex values.
+func ValueCodec[T any](codec Codec[T]) func(*config[T]) {
+	return func(c *config[T]) {
+		c.codec = codec
+	}
+}
+
+type vertexRecord struct {
+	Value      []byte             `json:"value"`
+	Weight     int                `json:"weight"`
+	Attributes map[string]string  `json:"attributes"`
+	Weights    map[string]float64 `json:"weights,omitempty"`
+}
+
+// Vertex is a vertex along with its hash and properties, as accepted by the
+// [Store.AddVertices] method for bulk loading vertices.
+type Vertex[K comparable, T any] struct {
+	Hash       K
+	Value      T
+	Properties graph.VertexProperties
+}
+
+// Store is a [graph.Store] implementation backed by BadgerDB.
+type Store[K comparable, T any] struct {
+	db     *badger.DB
+	codec  Codec[T]
+	ownsDB bool
+}
+
+// Open opens the Badger database in the given directory and creates a store
+// that uses this database. Closing the store also closes the database. To use
+// a database with custom options, for example an in-memory database, use New.
+func Open[K comparable, T any](path string, options ...func(*config[T])) (*Store[K, T], error) {
+	db, err := badger.Open(badger.DefaultOptions(path).WithLogger(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	store := New[K, T](db, options...)
+	store.ownsDB = true
+
+	return store, nil
+}
+
+// New creates a store that uses the given Badger database. Closing the store
+// will not close the database.
+func New[K comparable, T any](db *badger.DB, options ...func(*config[T])) *Store[K, T] {
+	c := config[T]{
+		codec: JSONCodec[T](),
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return &Store[K, T]{
+		db:    db,
+		codec: c.codec,
+	}
+}
+
+// Close closes the underlying database if it has been opened using [Open].
+func (s *Store[K, T]) Close() error {
+	if !s.ownsDB {
+		return nil
+	}
+
+	return s.db.Close()
+}
+
+func (s *Store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	key, err := vertexKey(hash)
+	if err != nil {
+		return err
+	}
+
+	record, err := s.encodeVertex(value, properties)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(txn *badger.Txn) error {
+		if exists, err := keyExists(txn, key); err != nil {
+			return err
+		} else if exists {
+			return graph.ErrVertexAlreadyExists
+		}
+
+		return txn.Set(key, record)
+	})
+}
+
+// AddVertices adds all given vertices using a write batch, which is much faster
+// than adding them one by one. Unlike AddVertex, it doesn't check whether the
+// vertices already exist and overwrites existing vertices instead.
+func (s *Store[K, T]) AddVertices(vertices []Vertex[K, T]) error {
+	batch := s.db.NewWriteBatch()
+	defer batch.Cancel()
+
+	for _, vertex := range vertices {
+		key, err := vertexKey(vertex.Hash)
+		if err != nil {
+			return err
+		}
+
+		record, err := s.encodeVertex(vertex.Value, vertex.Properties)
+		if err != nil {
+			return err
+		}
+
+		if err := batch.Set(key, record); err != nil {
+			return fmt.Errorf("failed to add vertex %v: %w", vertex.Hash, err)
+		}
+	}
+
+	return batch.Flush()
+}
+
+func (s *Store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	var (
+		value      T
+		properties graph.VertexProperties
+	)
+
+	key, err := vertexKey(hash)
+	if err != nil {
+		return value, properties, err
+	}
+
+	err = s.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return graph.ErrVertexNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		return item.Value(func(data []byte) error {
+			value, properties, err = s.decodeVertex(data)
+			return err
+		})
+	})
+
+	return value, properties, err
+}
+
+func (s *Store[K, T]) RemoveVertex(hash K) error {
+	key, err := vertexKey(hash)
+	if err != nil {
+		return err
+	}
+
+	encodedHash, err := encodeHash(hash)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(txn *badger.Txn) error {
+		if exists, err := keyExists(txn, key); err != nil {
+			return err
+		} else if !exists {
+			return graph.ErrVertexNotFound
+		}
+
+		for _, prefix := range [][]byte{outEdgePrefix, inEdgePrefix} {
+			if hasKeysWithPrefix(txn, edgePrefix(prefix, encodedHash)) {
+				return graph.ErrVertexHasEdges
+			}
+		}
+
+		return txn.Delete(key)
+	})
+}
+
+func (s *Store[K, T]) ListVertices() ([]K, error) {
+	hashes := make([]K, 0)
+
+	err := s.iterateKeys(vertexPrefix, func(key []byte) error {
+		hash, err := decodeHash[K](key[len(vertexPrefix):])
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, hash)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+func (s *Store[K, T]) VertexCount() (int, error) {
+	count := 0
+
+	err := s.iterateKeys(vertexPrefix, func([]byte) error {
+		count++
+		return nil
+	})
+
+	return count, err
+}
+
+func (s *Store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	sourceKey, err := vertexKey(sourceHash)
+	if err != nil {
+		return err
+	}
+
+	targetKey, err := vertexKey(targetHash)
+	if err != nil {
+		return err
+	}
+
+	outKey, inKey, record, err := encodeEdge(sourceHash, targetHash, edge)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(txn *badger.Txn) error {
+		if exists, err := keyExists(txn, sourceKey); err != nil {
+			return err
+		} else if !exists {
+			return fmt.Errorf("source vertex %v: %w", sourceHash, graph.ErrVertexNotFound)
+		}
+
+		if exists, err := keyExists(txn, targetKey); err != nil {
+			return err
+		} else if !exists {
+			return fmt.Errorf("target vertex %v: %w", targetHash, graph.ErrVertexNotFound)
+		}
+
+		if exists, err := keyExists(txn, outKey); err != nil {
+			return err
+		} else if exists {
+			return graph.ErrEdgeAlreadyExists
+		}
+
+		if err := txn.Set(outKey, record); err != nil {
+			return err
+		}
+
+		return txn.Set(inKey, []byte{})
+	})
+}
+
+// AddEdges adds all given edges using a write batch, which is much faster than
+// adding them one by one and suitable for streamed edge ingestion. Unlike
+// AddEdge, it doesn't check whether the vertices exist or whether an edge
+// already exists. Existing edges will be overwritten.
+//
+// AddEdges implements [graph.BulkStore], so Graph.AddEdgesFrom uses it as well.
+// Note that a write batch isn't atomic: If an error occurs, some of the edges
+// may have been added.
+func (s *Store[K, T]) AddEdges(edges []graph.Edge[K]) error {
+	batch := s.db.NewWriteBatch()
+	defer batch.Cancel()
+
+	for _, edge := range edges {
+		outKey, inKey, record, err := encodeEdge(edge.Source, edge.Target, edge)
+		if err != nil {
+			return err
+		}
+
+		if err := batch.Set(outKey, record); err != nil {
+			return fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+
+		if err := batch.Set(inKey, []byte{}); err != nil {
+			return fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	return batch.Flush()
+}
+
+func (s *Store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	outKey, _, record, err := encodeEdge(sourceHash, targetHash, edge)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(txn *badger.Txn) error {
+		if exists, err := keyExists(txn, outKey); err != nil {
+			return err
+		} else if !exists {
+			return graph.ErrEdgeNotFound
+		}
+
+		return txn.Set(outKey, record)
+	})
+}
+
+func (s *Store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	outKey, inKey, _, err := encodeEdge(sourceHash, targetHash, graph.Edge[K]{})
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(txn *badger.Txn) error {
+		if err := txn.Delete(outKey); err != nil {
+			return err
+		}
+
+		return txn.Delete(inKey)
+	})
+}
+
+func (s *Store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	outKey, _, _, err := encodeEdge(sourceHash, targetHash, graph.Edge[K]{})
+	if err != nil {
+		return graph.Edge[K]{}, err
+	}
+
+	var edge graph.Edge[K]
+
+	err = s.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(outKey)
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return graph.ErrEdgeNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		return item.Value(func(data []byte) error {
+			edge, err = storage.DecodeEdge(sourceHash, targetHash, data)
+			return err
+		})
+	})
+
+	return edge, err
+}
+
+func (s *Store[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	edges := make([]graph.Edge[K], 0)
+
+	err := s.db.View(func(txn *badger.Txn) error {
+		options := badger.DefaultIteratorOptions
+		options.Prefix = outEdgePrefix
+
+		it := txn.NewIterator(options)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+
+			source, target, err := splitEdgeKey(item.Key()[len(outEdgePrefix):])
+			if err != nil {
+				return err
+			}
+
+			sourceHash, err := decodeHash[K](source)
+			if err != nil {
+				return err
+			}
+
+			targetHash, err := decodeHash[K](target)
+			if err != nil {
+				return err
+			}
+
+			err = item.Value(func(data []byte) error {
+				edge, err := storage.DecodeEdge(sourceHash, targetHash, data)
+				if err != nil {
+					return err
+				}
+				edges = append(edges, edge)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return edges, nil
+}
+
+func (s *Store[K, T]) EdgeCount() (int, error) {
+	count := 0
+
+	err := s.iterateKeys(outEdgePrefix, func([]byte) error {
+		count++
+		return nil
+	})
+
+	return count, err
+}
+
+// iterateKeys calls f for each key with the given prefix without fetching
+// the values, which is considerably faster in Badger.
+func (s *Store[K, T]) iterateKeys(prefix []byte, f func(key []byte) error) error {
+	return s.db.View(func(txn *badger.Txn) error {
+		options := badger.DefaultIteratorOptions
+		options.PrefetchValues = false
+		options.Prefix = prefix
+
+		it := txn.NewIterator(options)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			if err := f(it.Item().KeyCopy(nil)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *Store[K, T]) encodeVertex(value T, properties graph.VertexProperties) ([]byte, error) {
+	encodedValue, err := s.codec.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode vertex value: %w", err)
+	}
+
+	record, err := json.Marshal(vertexRecord{
+		Value:      encodedValue,
+		Weight:     properties.Weight,
+		Attributes: properties.Attributes,
+		Weights:    properties.Weights,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode vertex: %w", err)
+	}
+
+	return record, nil
+}
+
+func (s *Store[K, T]) decodeVertex(data []byte) (T, graph.VertexProperties, error) {
+	var record vertexRecord
+
+	if err := json.Unmarshal(data, &record); err != nil {
+		var value T
+		return value, graph.VertexProperties{}, fmt.Errorf("failed to decode vertex: %w", err)
+	}
+
+	value, err := s.codec.Unmarshal(record.Value)
+	if err != nil {
+		return value, graph.VertexProperties{}, fmt.Errorf("failed to decode vertex value: %w", err)
+	}
+
+	return value, graph.VertexProperties{
+		Weight:     record.Weight,
+		Attributes: storage.Attributes(record.Attributes),
+		Weights:    record.Weights,
+	}, nil
+}
+
+func keyExists(txn *badger.Txn, key []byte) (bool, error) {
+	_, err := txn.Get(key)
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func hasKeysWithPrefix(txn *badger.Txn, prefix []byte) bool {
+	options := badger.DefaultIteratorOptions
+	options.PrefetchValues = false
+	options.Prefix = prefix
+
+	it := txn.NewIterator(options)
+	defer it.Close()
+
+	it.Rewind()
+
+	return it.Valid()
+}
+
+func vertexKey[K comparable](hash K) ([]byte, error) {
+	encodedHash, err := encodeHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, vertexPrefix...), encodedHash...), nil
+}
+
+// edgePrefix returns the prefix shared by all edge keys whose first vertex is
+// the given vertex. The vertex hash is prefixed with its length, so that keys
+// can be split unambiguously regardless of the hash contents.
+func edgePrefix(prefix, encodedHash []byte) []byte {
+	key := append([]byte{}, prefix...)
+	key = binary.AppendUvarint(key, uint64(len(encodedHash)))
+
+	return append(key, encodedHash...)
+}
+
+func splitEdgeKey(key []byte) ([]byte, []byte, error) {
+	length, n := binary.Uvarint(key)
+	if n <= 0 || int(length) > len(key)-n {
+		return nil, nil, fmt.Errorf("invalid edge key %q", key)
+	}
+
+	return key[n : n+int(length)], key[n+int(length):], nil
+}
+
+func encodeEdge[K comparable](sourceHash, targetHash K, edge graph.Edge[K]) ([]byte, []byte, []byte, error) {
+	source, err := encodeHash(sourceHash)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	target, err := encodeHash(targetHash)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	outKey := append(edgePrefix(outEdgePrefix, source), target...)
+	inKey := append(edgePrefix(inEdgePrefix, target), source...)
+
+	record, err := storage.EncodeEdge(edge)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return outKey, inKey, record, nil
+}
+
+// encodeHash encodes the given hash for using it within a key, using the
+// encoding shared by all stores.
+func encodeHash[K comparable](hash K) ([]byte, error) {
+	encoded, err := storage.EncodeHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(encoded), nil
+}
+
+func decodeHash[K comparable](encoded []byte) (K, error) {
+	return storage.DecodeHash[K](string(encoded))
+}
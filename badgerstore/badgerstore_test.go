@@ -0,0 +1,300 @@
+package badgerstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func newTestStore(t *testing.T) *Store[string, string] {
+	store, err := Open[string, string](t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return store
+}
+
+func TestStore_Vertex(t *testing.T) {
+	store := newTestStore(t)
+
+	properties := graph.VertexProperties{
+		Weight:     4,
+		Attributes: map[string]string{"color": "red"},
+	}
+
+	if err := store.AddVertex("A", "A", properties); err != nil {
+		t.Fatalf("failed to add vertex: %v", err)
+	}
+
+	if err := store.AddVertex("A", "A", properties); !errors.Is(err, graph.ErrVertexAlreadyExists) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexAlreadyExists, err)
+	}
+
+	value, vertexProperties, err := store.Vertex("A")
+	if err != nil {
+		t.Fatalf("failed to get vertex: %v", err)
+	}
+
+	if value != "A" || vertexProperties.Weight != 4 || vertexProperties.Attributes["color"] != "red" {
+		t.Errorf("vertex doesn't match: expected %v %v, got %v %v", "A", properties, value, vertexProperties)
+	}
+
+	if _, _, err := store.Vertex("B"); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	if count, _ := store.VertexCount(); count != 1 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 1, count)
+	}
+
+	hashes, err := store.ListVertices()
+	if err != nil || len(hashes) != 1 || hashes[0] != "A" {
+		t.Errorf("vertices don't match: expected %v, got %v (error: %v)", []string{"A"}, hashes, err)
+	}
+}
+
+func TestStore_RemoveVertex(t *testing.T) {
+	store := newTestStore(t)
+
+	_ = store.AddVertex("A", "A", graph.VertexProperties{})
+	_ = store.AddVertex("B", "B", graph.VertexProperties{})
+	_ = store.AddEdge("A", "B", graph.Edge[string]{Source: "A", Target: "B"})
+
+	if err := store.RemoveVertex("C"); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	for _, vertex := range []string{"A", "B"} {
+		if err := store.RemoveVertex(vertex); !errors.Is(err, graph.ErrVertexHasEdges) {
+			t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexHasEdges, err)
+		}
+	}
+
+	if err := store.RemoveEdge("A", "B"); err != nil {
+		t.Fatalf("failed to remove edge: %v", err)
+	}
+
+	for _, vertex := range []string{"A", "B"} {
+		if err := store.RemoveVertex(vertex); err != nil {
+			t.Errorf("failed to remove vertex: %v", err)
+		}
+	}
+
+	if count, _ := store.VertexCount(); count != 0 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 0, count)
+	}
+}
+
+func TestStore_Edge(t *testing.T) {
+	store := newTestStore(t)
+
+	_ = store.AddVertex("A", "A", graph.VertexProperties{})
+	_ = store.AddVertex("B", "B", graph.VertexProperties{})
+
+	edge := graph.Edge[string]{
+		Source: "A",
+		Target: "B",
+		Properties: graph.EdgeProperties{
+			Weight:     3,
+			Attributes: map[string]string{"label": "AB"},
+			Data:       "data",
+		},
+	}
+
+	if err := store.AddEdge("A", "C", edge); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	if err := store.AddEdge("A", "B", edge); err != nil {
+		t.Fatalf("failed to add edge: %v", err)
+	}
+
+	if err := store.AddEdge("A", "B", edge); !errors.Is(err, graph.ErrEdgeAlreadyExists) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeAlreadyExists, err)
+	}
+
+	storedEdge, err := store.Edge("A", "B")
+	if err != nil {
+		t.Fatalf("failed to get edge: %v", err)
+	}
+
+	if storedEdge.Properties.Weight != 3 || storedEdge.Properties.Attributes["label"] != "AB" || storedEdge.Properties.Data != "data" {
+		t.Errorf("edge properties don't match: expected %v, got %v", edge.Properties, storedEdge.Properties)
+	}
+
+	if _, err := store.Edge("B", "A"); !errors.Is(err, graph.ErrEdgeNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeNotFound, err)
+	}
+
+	edge.Properties.Weight = 10
+
+	if err := store.UpdateEdge("A", "B", edge); err != nil {
+		t.Fatalf("failed to update edge: %v", err)
+	}
+
+	if err := store.UpdateEdge("B", "A", edge); !errors.Is(err, graph.ErrEdgeNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeNotFound, err)
+	}
+
+	edges, err := store.ListEdges()
+	if err != nil || len(edges) != 1 || edges[0].Properties.Weight != 10 {
+		t.Errorf("edges don't match: got %v (error: %v)", edges, err)
+	}
+
+	if count, _ := store.EdgeCount(); count != 1 {
+		t.Errorf("edge count doesn't match: expected %v, got %v", 1, count)
+	}
+
+	// Removing a non-existent edge must not change the edge count.
+	_ = store.RemoveEdge("B", "A")
+
+	if count, _ := store.EdgeCount(); count != 1 {
+		t.Errorf("edge count doesn't match: expected %v, got %v", 1, count)
+	}
+}
+
+func TestStore_persistence(t *testing.T) {
+	path := t.TempDir()
+
+	store, err := Open[int, int](path)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	g := graph.NewWithStore(graph.IntHash, store, graph.Directed(), graph.Weighted())
+
+	for i := 1; i <= 4; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	_ = g.AddEdge(1, 2, graph.EdgeWeight(1))
+	_ = g.AddEdge(2, 4, graph.EdgeWeight(5))
+	_ = g.AddEdge(1, 3, graph.EdgeWeight(1))
+	_ = g.AddEdge(3, 4, graph.EdgeWeight(1))
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("failed to close store: %v", err)
+	}
+
+	store, err = Open[int, int](path)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer store.Close()
+
+	g = graph.NewWithStore(graph.IntHash, store, graph.Directed(), graph.Weighted())
+
+	size, _ := g.Size()
+	if size != 4 {
+		t.Errorf("size doesn't match: expected %v, got %v", 4, size)
+	}
+
+	shortestPath, err := graph.ShortestPath(g, 1, 4)
+	if err != nil {
+		t.Fatalf("failed to compute shortest path: %v", err)
+	}
+
+	expected := []int{1, 3, 4}
+
+	if len(shortestPath) != len(expected) {
+		t.Fatalf("path doesn't match: expected %v, got %v", expected, shortestPath)
+	}
+
+	for i := range shortestPath {
+		if shortestPath[i] != expected[i] {
+			t.Errorf("path doesn't match: expected %v, got %v", expected, shortestPath)
+		}
+	}
+}
+
+func TestStore_bulk(t *testing.T) {
+	store := newTestStore(t)
+
+	vertices := []Vertex[string, string]{
+		{Hash: "A", Value: "A"},
+		{Hash: "B", Value: "B"},
+		{Hash: "C", Value: "C", Properties: graph.VertexProperties{Weight: 2}},
+	}
+
+	if err := store.AddVertices(vertices); err != nil {
+		t.Fatalf("failed to add vertices: %v", err)
+	}
+
+	edges := []graph.Edge[string]{
+		{Source: "A", Target: "B"},
+		{Source: "A", Target: "C"},
+		{Source: "B", Target: "C", Properties: graph.EdgeProperties{Weight: 5}},
+	}
+
+	if err := store.AddEdges(edges); err != nil {
+		t.Fatalf("failed to add edges: %v", err)
+	}
+
+	if count, _ := store.VertexCount(); count != 3 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 3, count)
+	}
+
+	if count, _ := store.EdgeCount(); count != 3 {
+		t.Errorf("edge count doesn't match: expected %v, got %v", 3, count)
+	}
+
+	if _, properties, _ := store.Vertex("C"); properties.Weight != 2 {
+		t.Errorf("vertex weight doesn't match: expected %v, got %v", 2, properties.Weight)
+	}
+
+	if edge, _ := store.Edge("B", "C"); edge.Properties.Weight != 5 {
+		t.Errorf("edge weight doesn't match: expected %v, got %v", 5, edge.Properties.Weight)
+	}
+
+	if err := store.RemoveVertex("C"); !errors.Is(err, graph.ErrVertexHasEdges) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexHasEdges, err)
+	}
+}
+
+func TestValueCodec(t *testing.T) {
+	type city struct {
+		Name       string
+		Population int
+	}
+
+	tests := map[string]struct {
+		codec Codec[city]
+	}{
+		"JSON codec": {
+			codec: JSONCodec[city](),
+		},
+		"gob codec": {
+			codec: GobCodec[city](),
+		},
+	}
+
+	for name, test := range tests {
+		store, err := Open[string, city](t.TempDir(), ValueCodec(test.codec))
+		if err != nil {
+			t.Fatalf("%s: failed to open store: %v", name, err)
+		}
+
+		expected := city{Name: "Berlin", Population: 3645000}
+
+		if err := store.AddVertex(expected.Name, expected, graph.VertexProperties{}); err != nil {
+			t.Fatalf("%s: failed to add vertex: %v", name, err)
+		}
+
+		value, _, err := store.Vertex(expected.Name)
+		if err != nil {
+			t.Fatalf("%s: failed to get vertex: %v", name, err)
+		}
+
+		if value != expected {
+			t.Errorf("%s: value doesn't match: expected %v, got %v", name, expected, value)
+		}
+
+		_ = store.Close()
+	}
+}
@@ -0,0 +1,219 @@
+package graph
+
+import (
+	"fmt"
+)
+
+// EdgeSpec describes an edge to be added by [AddEdgesChecked]. The options are
+// the same functional options that can be passed to AddEdge, e.g. EdgeWeight.
+type EdgeSpec[K comparable] struct {
+	Source  K
+	Target  K
+	Options []func(*EdgeProperties)
+}
+
+// AddEdgesChecked adds the given edges to the graph as a batch. The batch is
+// validated as a whole before any edge is added: If one of the edges can't be
+// added, for example because one of its vertices doesn't exist or because it
+// already exists, none of the edges will be added and the error is returned.
+//
+// For graphs created with PreventCycles, adding edges one by one runs a DFS
+// for each edge. AddEdgesChecked instead checks the entire batch at once using
+// a single topological sort over the graph and the new edges, or a single
+// union-find pass for undirected graphs. This makes building a large DAG in
+// batches considerably faster:
+//
+//	err := graph.AddEdgesChecked(g, []graph.EdgeSpec[int]{
+//		{Source: 1, Target: 2},
+//		{Source: 2, Target: 3, Options: []func(*graph.EdgeProperties){graph.EdgeWeight(4)}},
+//	})
+//
+// If the batch would create a cycle, ErrEdgeCreatesCycle is returned. For the
+// graphs created by this library, the edges are added using a single store call
+// if the store implements [BulkStore]. Other graph implementations fall back to
+// adding each edge using AddEdge after validating the batch.
+func AddEdgesChecked[K comparable, T any](g Graph[K, T], specs []EdgeSpec[K]) error {
+	if c, ok := g.(*cachedGraph[K, T]); ok {
+		return AddEdgesChecked(c.Graph, specs)
+	}
+
+	edges := make([]Edge[K], len(specs))
+
+	for i, spec := range specs {
+		edges[i] = Edge[K]{
+			Source: spec.Source,
+			Target: spec.Target,
+			Properties: EdgeProperties{
+				Attributes: make(map[string]string),
+			},
+		}
+
+		for _, option := range spec.Options {
+			option(&edges[i].Properties)
+		}
+	}
+
+	if err := validateBatch(g, edges); err != nil {
+		return err
+	}
+
+	if g.Traits().PreventCycles {
+		var createsCycle bool
+		var err error
+
+		if g.Traits().IsDirected {
+			createsCycle, err = batchCreatesDirectedCycle(g, edges)
+		} else {
+			createsCycle, err = batchCreatesUndirectedCycle(g, edges)
+		}
+
+		if err != nil {
+			return fmt.Errorf("check for cycles: %w", err)
+		}
+		if createsCycle {
+			return ErrEdgeCreatesCycle
+		}
+	}
+
+	// All checks that AddEdge would run for each edge have been run for the
+	// entire batch, so the edges can be added without validating them again.
+	if adder, ok := g.(trustedEdgeAdder[K]); ok {
+		return adder.addEdgesTrusted(edges)
+	}
+
+	for _, edge := range edges {
+		if err := g.AddEdge(copyEdge(edge)); err != nil {
+			return fmt.Errorf("failed to add (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	return nil
+}
+
+// validateBatch checks that the vertices of all given edges exist, and that
+// none of the edges exists yet or appears twice in the batch.
+func validateBatch[K comparable, T any](g Graph[K, T], edges []Edge[K]) error {
+	isDirected := g.Traits().IsDirected
+	added := make(map[tuple[K]]struct{}, len(edges))
+
+	for _, edge := range edges {
+		if _, err := g.Vertex(edge.Source); err != nil {
+			return fmt.Errorf("failed to add (%v, %v): source vertex %v: %w", edge.Source, edge.Target, edge.Source, err)
+		}
+
+		if _, err := g.Vertex(edge.Target); err != nil {
+			return fmt.Errorf("failed to add (%v, %v): target vertex %v: %w", edge.Source, edge.Target, edge.Target, err)
+		}
+
+		_, exists := added[tuple[K]{edge.Source, edge.Target}]
+		if !exists && !isDirected {
+			_, exists = added[tuple[K]{edge.Target, edge.Source}]
+		}
+		if !exists {
+			_, err := g.Edge(edge.Source, edge.Target)
+			exists = err == nil
+		}
+		if exists {
+			return &EdgeAlreadyExistsError[K]{Source: edge.Source, Target: edge.Target}
+		}
+
+		added[tuple[K]{edge.Source, edge.Target}] = struct{}{}
+	}
+
+	return nil
+}
+
+// batchCreatesDirectedCycle reports whether adding the given edges to the
+// directed graph g would create a cycle. It runs Kahn's algorithm over the
+// edges of g and the given edges, which visits all vertices if and only if
+// the resulting graph is acyclic.
+func batchCreatesDirectedCycle[K comparable, T any](g Graph[K, T], edges []Edge[K]) (bool, error) {
+	inDegrees, err := inDegrees(g)
+	if err != nil {
+		return false, err
+	}
+
+	successors, err := successorsFunc(g)
+	if err != nil {
+		return false, err
+	}
+
+	batchSuccessors := make(map[K][]K)
+
+	for _, edge := range edges {
+		if edge.Source == edge.Target {
+			return true, nil
+		}
+		batchSuccessors[edge.Source] = append(batchSuccessors[edge.Source], edge.Target)
+		inDegrees[edge.Target]++
+	}
+
+	queue := make([]K, 0)
+
+	for vertex, inDegree := range inDegrees {
+		if inDegree == 0 {
+			queue = append(queue, vertex)
+		}
+	}
+
+	release := func(target K) {
+		inDegrees[target]--
+		if inDegrees[target] == 0 {
+			queue = append(queue, target)
+		}
+	}
+
+	visited := 0
+
+	for len(queue) > 0 {
+		currentVertex := queue[0]
+		queue = queue[1:]
+
+		visited++
+
+		err := successors(currentVertex, func(target K, _ Edge[K]) {
+			release(target)
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to get successors of %v: %w", currentVertex, err)
+		}
+
+		for _, target := range batchSuccessors[currentVertex] {
+			release(target)
+		}
+	}
+
+	return visited != len(inDegrees), nil
+}
+
+// batchCreatesUndirectedCycle reports whether adding the given edges to the
+// undirected graph g would create a cycle. It computes the components of g
+// once and adds the edges to them, which creates a cycle as soon as an edge
+// joins two vertices of the same component.
+func batchCreatesUndirectedCycle[K comparable, T any](g Graph[K, T], edges []Edge[K]) (bool, error) {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return false, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	components := newUnionFind[K]()
+
+	for vertex := range adjacencyMap {
+		components.add(vertex)
+	}
+
+	for _, adjacencies := range adjacencyMap {
+		for _, edge := range adjacencies {
+			components.union(edge.Source, edge.Target)
+		}
+	}
+
+	for _, edge := range edges {
+		if components.find(edge.Source) == components.find(edge.Target) {
+			return true, nil
+		}
+		components.union(edge.Source, edge.Target)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,154 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddEdgesChecked(t *testing.T) {
+	tests := map[string]struct {
+		traits        []func(*Traits)
+		edges         []Edge[int]
+		specs         []EdgeSpec[int]
+		expectedSize  int
+		expectedError error
+	}{
+		"directed acyclic graph": {
+			traits: []func(*Traits){Directed(), PreventCycles()},
+			edges:  []Edge[int]{{Source: 1, Target: 2}},
+			specs: []EdgeSpec[int]{
+				{Source: 2, Target: 3},
+				{Source: 1, Target: 3},
+				{Source: 3, Target: 4},
+			},
+			expectedSize: 4,
+		},
+		"cycle in directed graph": {
+			traits: []func(*Traits){Directed(), PreventCycles()},
+			edges:  []Edge[int]{{Source: 1, Target: 2}},
+			specs: []EdgeSpec[int]{
+				{Source: 2, Target: 3},
+				{Source: 3, Target: 1},
+			},
+			expectedSize:  1,
+			expectedError: ErrEdgeCreatesCycle,
+		},
+		"cycle within batch": {
+			traits: []func(*Traits){Directed(), PreventCycles()},
+			specs: []EdgeSpec[int]{
+				{Source: 3, Target: 4},
+				{Source: 4, Target: 3},
+			},
+			expectedError: ErrEdgeCreatesCycle,
+		},
+		"self-loop": {
+			traits: []func(*Traits){Directed(), PreventCycles()},
+			specs: []EdgeSpec[int]{
+				{Source: 1, Target: 1},
+			},
+			expectedError: ErrEdgeCreatesCycle,
+		},
+		"cycle allowed in directed graph": {
+			traits: []func(*Traits){Directed()},
+			edges:  []Edge[int]{{Source: 1, Target: 2}},
+			specs: []EdgeSpec[int]{
+				{Source: 2, Target: 1},
+			},
+			expectedSize: 2,
+		},
+		"undirected tree": {
+			traits: []func(*Traits){PreventCycles()},
+			edges:  []Edge[int]{{Source: 1, Target: 2}},
+			specs: []EdgeSpec[int]{
+				{Source: 3, Target: 2},
+				{Source: 4, Target: 1},
+			},
+			expectedSize: 3,
+		},
+		"cycle in undirected graph": {
+			traits: []func(*Traits){PreventCycles()},
+			edges:  []Edge[int]{{Source: 1, Target: 2}},
+			specs: []EdgeSpec[int]{
+				{Source: 2, Target: 3},
+				{Source: 3, Target: 1},
+			},
+			expectedSize:  1,
+			expectedError: ErrEdgeCreatesCycle,
+		},
+		"existing edge": {
+			traits: []func(*Traits){Directed()},
+			edges:  []Edge[int]{{Source: 1, Target: 2}},
+			specs: []EdgeSpec[int]{
+				{Source: 2, Target: 3},
+				{Source: 1, Target: 2},
+			},
+			expectedSize:  1,
+			expectedError: ErrEdgeAlreadyExists,
+		},
+		"reversed duplicate in undirected batch": {
+			specs: []EdgeSpec[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 1},
+			},
+			expectedError: ErrEdgeAlreadyExists,
+		},
+		"missing vertex": {
+			traits: []func(*Traits){Directed()},
+			specs: []EdgeSpec[int]{
+				{Source: 1, Target: 2},
+				{Source: 1, Target: 5},
+			},
+			expectedError: ErrVertexNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash, test.traits...)
+
+		for i := 1; i <= 4; i++ {
+			_ = g.AddVertex(i)
+		}
+
+		for _, edge := range test.edges {
+			_ = g.AddEdge(edge.Source, edge.Target)
+		}
+
+		err := AddEdgesChecked(g, test.specs)
+
+		if !errors.Is(err, test.expectedError) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedError, err)
+		}
+
+		if size, _ := g.Size(); size != test.expectedSize {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, test.expectedSize, size)
+		}
+	}
+}
+
+func TestAddEdgesChecked_options(t *testing.T) {
+	g := New(IntHash, Directed(), PreventCycles())
+
+	_ = g.AddVertex(1)
+	_ = g.AddVertex(2)
+
+	var added []Edge[int]
+	_, _ = OnAddEdge(g, func(edge Edge[int]) {
+		added = append(added, edge)
+	})
+
+	err := AddEdgesChecked(g, []EdgeSpec[int]{
+		{Source: 1, Target: 2, Options: []func(*EdgeProperties){EdgeWeight(4), EdgeAttribute("color", "red")}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	edge, err := g.Edge(1, 2)
+	if err != nil || edge.Properties.Weight != 4 || edge.Properties.Attributes["color"] != "red" {
+		t.Errorf("edge doesn't match: got %v (error: %v)", edge, err)
+	}
+
+	if len(added) != 1 {
+		t.Errorf("number of hook calls doesn't match: expected %v, got %v", 1, len(added))
+	}
+}
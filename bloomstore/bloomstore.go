@@ -0,0 +1,308 @@
+// Package bloomstore provides a [graph.Store] wrapper that keeps a Bloom filter
+// of all edges in memory. It is intended to be used in front of slow stores,
+// such as SQL databases or remote stores, to speed up the ingestion of edges.
+//
+//	store, _ := bloomstore.Wrap[string, string](sqlStore, bloomstore.ExpectedEdges(1000000))
+//	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+//
+// Before adding an edge, the graph checks whether the edge already exists. For
+// most edges added during ingestion, the Bloom filter tells that the edge
+// definitely doesn't exist, and the store returns graph.ErrEdgeNotFound without
+// querying the wrapped store. Only if the filter reports that the edge might
+// exist, the wrapped store is queried. This also applies to all other reads of
+// single edges.
+//
+// Bloom filters can't forget entries, so removed edges remain in the filter and
+// lead to a query of the wrapped store if they are looked up again. Writes that
+// bypass the wrapper, for example by another process using the same database,
+// aren't detected, so the wrapper reports edges added this way as missing.
+package bloomstore
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/dominikbraun/graph"
+)
+
+type config struct {
+	expectedEdges     int
+	falsePositiveRate float64
+}
+
+// ExpectedEdges is a functional option for [Wrap] that sets the number of edges
+// the filter is sized for. If the store holds more edges, the false positive
+// rate increases. The default is 100000 edges or the number of edges in the
+// wrapped store, whichever is higher.
+func ExpectedEdges(n int) func(*config) {
+	return func(c *config) {
+		c.expectedEdges = n
+	}
+}
+
+// FalsePositiveRate is a functional option for [Wrap] that sets the rate of
+// lookups of missing edges that still query the wrapped store, as long as the
+// store doesn't hold more than the expected number of edges. The default rate
+// is 0.01. Lower rates require more memory.
+func FalsePositiveRate(rate float64) func(*config) {
+	return func(c *config) {
+		c.falsePositiveRate = rate
+	}
+}
+
+// Wrap returns a store that checks a Bloom filter before looking up an edge in
+// s. The filter is populated with the edges of s, which are listed once.
+//
+// The returned store implements graph.NeighborStore and graph.BulkStore if s
+// does, so that graphs and algorithms access the wrapped store the same way as
+// s. Other optional interfaces aren't forwarded.
+func Wrap[K comparable, T any](s graph.Store[K, T], options ...func(*config)) (graph.Store[K, T], error) {
+	c := config{
+		expectedEdges:     100000,
+		falsePositiveRate: 0.01,
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	if c.falsePositiveRate <= 0 || c.falsePositiveRate >= 1 {
+		return nil, fmt.Errorf("false positive rate must be between 0 and 1, got %v", c.falsePositiveRate)
+	}
+
+	edges, err := s.ListEdges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edges: %w", err)
+	}
+
+	if len(edges) > c.expectedEdges {
+		c.expectedEdges = len(edges)
+	}
+
+	base := &store[K, T]{
+		store:  s,
+		filter: newFilter(c.expectedEdges, c.falsePositiveRate),
+	}
+
+	for _, edge := range edges {
+		base.filter.add(edgeKey(edge.Source, edge.Target))
+	}
+
+	_, isNeighborStore := s.(graph.NeighborStore[K])
+	_, isBulkStore := s.(graph.BulkStore[K])
+
+	switch {
+	case isNeighborStore && isBulkStore:
+		return &neighborBulkStore[K, T]{
+			store:          base,
+			neighborLookup: neighborLookup[K, T]{base},
+			bulkAdder:      bulkAdder[K, T]{base},
+		}, nil
+	case isNeighborStore:
+		return &neighborStore[K, T]{
+			store:          base,
+			neighborLookup: neighborLookup[K, T]{base},
+		}, nil
+	case isBulkStore:
+		return &bulkStore[K, T]{
+			store:     base,
+			bulkAdder: bulkAdder[K, T]{base},
+		}, nil
+	default:
+		return base, nil
+	}
+}
+
+type store[K comparable, T any] struct {
+	lock   sync.RWMutex
+	store  graph.Store[K, T]
+	filter *filter
+}
+
+func (s *store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	return s.store.AddVertex(hash, value, properties)
+}
+
+func (s *store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	return s.store.Vertex(hash)
+}
+
+func (s *store[K, T]) RemoveVertex(hash K) error {
+	return s.store.RemoveVertex(hash)
+}
+
+func (s *store[K, T]) ListVertices() ([]K, error) {
+	return s.store.ListVertices()
+}
+
+func (s *store[K, T]) VertexCount() (int, error) {
+	return s.store.VertexCount()
+}
+
+// AddEdge adds the edge to the filter before adding it to the wrapped store, so
+// that concurrent lookups never miss an edge that exists. If adding the edge
+// fails, it remains in the filter as a false positive.
+func (s *store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	s.add(sourceHash, targetHash)
+	return s.store.AddEdge(sourceHash, targetHash, edge)
+}
+
+func (s *store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	return s.store.UpdateEdge(sourceHash, targetHash, edge)
+}
+
+func (s *store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	return s.store.RemoveEdge(sourceHash, targetHash)
+}
+
+func (s *store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	s.lock.RLock()
+	mayExist := s.filter.mayContain(edgeKey(sourceHash, targetHash))
+	s.lock.RUnlock()
+
+	if !mayExist {
+		return graph.Edge[K]{}, graph.ErrEdgeNotFound
+	}
+
+	return s.store.Edge(sourceHash, targetHash)
+}
+
+func (s *store[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	return s.store.ListEdges()
+}
+
+func (s *store[K, T]) EdgeCount() (int, error) {
+	return s.store.EdgeCount()
+}
+
+func (s *store[K, T]) add(sourceHash, targetHash K) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.filter.add(edgeKey(sourceHash, targetHash))
+}
+
+type neighborLookup[K comparable, T any] struct {
+	s *store[K, T]
+}
+
+func (n neighborLookup[K, T]) EdgesBySource(sourceHash K) ([]graph.Edge[K], error) {
+	return n.s.store.(graph.NeighborStore[K]).EdgesBySource(sourceHash)
+}
+
+func (n neighborLookup[K, T]) EdgesByTarget(targetHash K) ([]graph.Edge[K], error) {
+	return n.s.store.(graph.NeighborStore[K]).EdgesByTarget(targetHash)
+}
+
+type bulkAdder[K comparable, T any] struct {
+	s *store[K, T]
+}
+
+func (b bulkAdder[K, T]) AddEdges(edges []graph.Edge[K]) error {
+	b.s.lock.Lock()
+	for _, edge := range edges {
+		b.s.filter.add(edgeKey(edge.Source, edge.Target))
+	}
+	b.s.lock.Unlock()
+
+	return b.s.store.(graph.BulkStore[K]).AddEdges(edges)
+}
+
+type neighborStore[K comparable, T any] struct {
+	*store[K, T]
+	neighborLookup[K, T]
+}
+
+type bulkStore[K comparable, T any] struct {
+	*store[K, T]
+	bulkAdder[K, T]
+}
+
+type neighborBulkStore[K comparable, T any] struct {
+	*store[K, T]
+	neighborLookup[K, T]
+	bulkAdder[K, T]
+}
+
+// edgeKey returns the key of an edge in the filter. Hashes that are formatted
+// the same way share a key, which only results in false positives.
+func edgeKey[K comparable](sourceHash, targetHash K) []byte {
+	key := make([]byte, 0, 32)
+	key = appendHash(key, sourceHash)
+	key = append(key, 0)
+	key = appendHash(key, targetHash)
+
+	return key
+}
+
+func appendHash[K comparable](key []byte, hash K) []byte {
+	// The most common hash types are formatted without reflection.
+	switch h := any(hash).(type) {
+	case string:
+		return append(key, h...)
+	case int:
+		return strconv.AppendInt(key, int64(h), 10)
+	default:
+		return append(key, fmt.Sprint(hash)...)
+	}
+}
+
+// filter is a Bloom filter whose size and number of hash functions are derived
+// from the expected number of entries and the desired false positive rate.
+type filter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+func newFilter(expected int, falsePositiveRate float64) *filter {
+	if expected < 1 {
+		expected = 1
+	}
+
+	size := math.Ceil(-float64(expected) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := math.Max(1, math.Round(size/float64(expected)*math.Ln2))
+
+	return &filter{
+		bits:   make([]uint64, (uint64(size)+63)/64),
+		size:   uint64(size),
+		hashes: uint64(hashes),
+	}
+}
+
+func (f *filter) add(key []byte) {
+	h1, h2 := f.hash(key)
+
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+func (f *filter) mayContain(key []byte) bool {
+	h1, h2 := f.hash(key)
+
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hash returns the two base hashes for the double hashing scheme by Kirsch and
+// Mitzenmacher, which derives all hash functions from these two.
+func (f *filter) hash(key []byte) (uint64, uint64) {
+	h := fnv.New64a()
+	_, _ = h.Write(key)
+	sum := h.Sum64()
+
+	// The second hash has to be odd, so that it doesn't share a factor with
+	// the size of the filter if the size is a power of two.
+	return sum, (sum>>32 | sum<<32) | 1
+}
@@ -0,0 +1,165 @@
+package bloomstore
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+// countingStore is a backing store that counts the edge lookups that reach it.
+type countingStore struct {
+	graph.Store[string, string]
+	edgeReads int
+}
+
+func (s *countingStore) Edge(sourceHash, targetHash string) (graph.Edge[string], error) {
+	s.edgeReads++
+	return s.Store.Edge(sourceHash, targetHash)
+}
+
+// bulkCountingStore additionally implements graph.BulkStore.
+type bulkCountingStore struct {
+	*countingStore
+}
+
+func (s *bulkCountingStore) AddEdges(edges []graph.Edge[string]) error {
+	for _, edge := range edges {
+		if err := s.AddEdge(edge.Source, edge.Target, edge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newCountingStore() *countingStore {
+	return &countingStore{Store: graph.NewArenaStore[string, string](0, 0)}
+}
+
+func TestWrap_edges(t *testing.T) {
+	backing := newCountingStore()
+	_ = backing.AddVertex("A", "a", graph.VertexProperties{})
+	_ = backing.AddVertex("B", "b", graph.VertexProperties{})
+	_ = backing.AddEdge("A", "B", graph.Edge[string]{Source: "A", Target: "B"})
+
+	store, err := Wrap[string, string](backing, ExpectedEdges(100))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := store.Edge("A", "B"); err != nil {
+		t.Errorf("expected existing edge to be found: %v", err)
+	}
+
+	if _, err := store.Edge("B", "A"); !errors.Is(err, graph.ErrEdgeNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeNotFound, err)
+	}
+
+	if backing.edgeReads != 1 {
+		t.Errorf("edge reads don't match: expected %v, got %v", 1, backing.edgeReads)
+	}
+
+	_ = store.AddEdge("B", "A", graph.Edge[string]{Source: "B", Target: "A"})
+
+	if _, err := store.Edge("B", "A"); err != nil {
+		t.Errorf("expected added edge to be found: %v", err)
+	}
+
+	// Removed edges remain in the filter, so the backing store is queried.
+	_ = store.RemoveEdge("B", "A")
+
+	if _, err := store.Edge("B", "A"); !errors.Is(err, graph.ErrEdgeNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeNotFound, err)
+	}
+
+	if backing.edgeReads != 3 {
+		t.Errorf("edge reads don't match: expected %v, got %v", 3, backing.edgeReads)
+	}
+}
+
+func TestWrap_graph(t *testing.T) {
+	backing := newCountingStore()
+
+	store, err := Wrap[string, string](backing, ExpectedEdges(1000))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+
+	for i := 0; i < 1000; i++ {
+		_ = g.AddVertex(strconv.Itoa(i))
+	}
+
+	for i := 0; i < 999; i++ {
+		if err := g.AddEdge(strconv.Itoa(i), strconv.Itoa(i+1)); err != nil {
+			t.Fatalf("failed to add edge (%v, %v): %v", i, i+1, err)
+		}
+	}
+
+	// With a false positive rate of 1%, only a few duplicate checks reach the
+	// backing store.
+	if backing.edgeReads > 50 {
+		t.Errorf("expected most duplicate checks to skip the backing store, got %v edge reads", backing.edgeReads)
+	}
+
+	if err := g.AddEdge("0", "1"); !errors.Is(err, graph.ErrEdgeAlreadyExists) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeAlreadyExists, err)
+	}
+
+	size, _ := g.Size()
+	if size != 999 {
+		t.Errorf("size expectancy doesn't match: expected %v, got %v", 999, size)
+	}
+}
+
+func TestWrap_bulkStore(t *testing.T) {
+	backing := &bulkCountingStore{newCountingStore()}
+	_ = backing.AddVertex("A", "a", graph.VertexProperties{})
+	_ = backing.AddVertex("B", "b", graph.VertexProperties{})
+
+	store, _ := Wrap[string, string](backing)
+
+	bulkStore, ok := store.(graph.BulkStore[string])
+	if !ok {
+		t.Fatalf("expected store to implement graph.BulkStore")
+	}
+
+	if _, ok := store.(graph.NeighborStore[string]); ok {
+		t.Errorf("expected store not to implement graph.NeighborStore")
+	}
+
+	_ = bulkStore.AddEdges([]graph.Edge[string]{{Source: "A", Target: "B"}})
+
+	if _, err := store.Edge("A", "B"); err != nil {
+		t.Errorf("expected added edge to be found: %v", err)
+	}
+}
+
+func TestWrap_falsePositiveRate(t *testing.T) {
+	tests := map[string]struct {
+		rate          float64
+		expectedError bool
+	}{
+		"valid rate": {
+			rate: 0.001,
+		},
+		"zero": {
+			rate:          0,
+			expectedError: true,
+		},
+		"one": {
+			rate:          1,
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		_, err := Wrap[string, string](newCountingStore(), FalsePositiveRate(test.rate))
+
+		if test.expectedError != (err != nil) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.expectedError, err != nil, err)
+		}
+	}
+}
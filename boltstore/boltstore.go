@@ -0,0 +1,442 @@
+// Package boltstore provides a [graph.Store] implementation that persists
+// graphs in a bbolt database. bbolt is an embedded key-value store with ACID
+// transactions, which makes this store a good fit for embedded, read-heavy
+// applications that need to survive crashes.
+//
+// Vertices are stored in a bucket that maps vertex hashes to their values and
+// properties. Edges are stored in nested buckets: For each source vertex, the
+// outgoing bucket contains a bucket that maps target hashes to the edges, and
+// the ingoing bucket contains the same information in reverse direction.
+//
+//	store, _ := boltstore.Open[string, string]("graph.db")
+//	defer store.Close()
+//
+//	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+//
+// Vertex hashes of type string are stored as they are, all other hash types
+// are stored as JSON. Vertex values and properties are stored as JSON as well.
+package boltstore
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/internal/storage"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	verticesBucket = []byte("vertices")
+	outEdgesBucket = []byte("out_edges")
+	inEdgesBucket  = []byte("in_edges")
+	metaBucket     = []byte("meta")
+	edgeCountKey   = []byte("edge_count")
+)
+
+type vertexRecord[T any] struct {
+	Value      T                  `json:"value"`
+	Weight     int                `json:"weight"`
+	Attributes map[string]string  `json:"attributes"`
+	Weights    map[string]float64 `json:"weights,omitempty"`
+}
+
+// Store is a [graph.Store] implementation backed by a bbolt database. Each
+// operation runs in its own transaction.
+type Store[K comparable, T any] struct {
+	db     *bolt.DB
+	ownsDB bool
+}
+
+// Open opens the bbolt database at the given path and creates a store that
+// uses this database. If the database file doesn't exist, it will be created.
+// Closing the store also closes the database.
+func Open[K comparable, T any](path string) (*Store[K, T], error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	store, err := New[K, T](db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	store.ownsDB = true
+
+	return store, nil
+}
+
+// New creates a store that uses the given bbolt database and creates the
+// required buckets if they don't exist yet. Use this function instead of Open
+// if you need to configure the database yourself, for example to disable
+// syncing. Closing the store will not close the database.
+func New[K comparable, T any](db *bolt.DB) (*Store[K, T], error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{verticesBucket, outEdgesBucket, inEdgesBucket, metaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return fmt.Errorf("failed to create bucket %s: %w", name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store[K, T]{db: db}, nil
+}
+
+// Close closes the underlying database if it has been opened using [Open].
+func (s *Store[K, T]) Close() error {
+	if !s.ownsDB {
+		return nil
+	}
+
+	return s.db.Close()
+}
+
+func (s *Store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	key, err := encodeHash(hash)
+	if err != nil {
+		return err
+	}
+
+	record, err := json.Marshal(vertexRecord[T]{
+		Value:      value,
+		Weight:     properties.Weight,
+		Attributes: properties.Attributes,
+		Weights:    properties.Weights,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode vertex: %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		vertices := tx.Bucket(verticesBucket)
+
+		if vertices.Get(key) != nil {
+			return graph.ErrVertexAlreadyExists
+		}
+
+		return vertices.Put(key, record)
+	})
+}
+
+func (s *Store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	var record vertexRecord[T]
+
+	key, err := encodeHash(hash)
+	if err != nil {
+		return record.Value, graph.VertexProperties{}, err
+	}
+
+	err = s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(verticesBucket).Get(key)
+		if value == nil {
+			return graph.ErrVertexNotFound
+		}
+
+		if err := json.Unmarshal(value, &record); err != nil {
+			return fmt.Errorf("failed to decode vertex: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return record.Value, graph.VertexProperties{}, err
+	}
+
+	return record.Value, graph.VertexProperties{
+		Weight:     record.Weight,
+		Attributes: storage.Attributes(record.Attributes),
+		Weights:    record.Weights,
+	}, nil
+}
+
+func (s *Store[K, T]) RemoveVertex(hash K) error {
+	key, err := encodeHash(hash)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		vertices := tx.Bucket(verticesBucket)
+
+		if vertices.Get(key) == nil {
+			return graph.ErrVertexNotFound
+		}
+
+		for _, name := range [][]byte{outEdgesBucket, inEdgesBucket} {
+			edges := tx.Bucket(name).Bucket(key)
+			if edges == nil {
+				continue
+			}
+
+			if k, _ := edges.Cursor().First(); k != nil {
+				return graph.ErrVertexHasEdges
+			}
+
+			if err := tx.Bucket(name).DeleteBucket(key); err != nil {
+				return fmt.Errorf("failed to delete edge bucket: %w", err)
+			}
+		}
+
+		return vertices.Delete(key)
+	})
+}
+
+func (s *Store[K, T]) ListVertices() ([]K, error) {
+	hashes := make([]K, 0)
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(verticesBucket).ForEach(func(key, _ []byte) error {
+			hash, err := decodeHash[K](key)
+			if err != nil {
+				return err
+			}
+			hashes = append(hashes, hash)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+func (s *Store[K, T]) VertexCount() (int, error) {
+	var count int
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		count = tx.Bucket(verticesBucket).Stats().KeyN
+		return nil
+	})
+
+	return count, err
+}
+
+func (s *Store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	source, err := encodeHash(sourceHash)
+	if err != nil {
+		return err
+	}
+
+	target, err := encodeHash(targetHash)
+	if err != nil {
+		return err
+	}
+
+	record, err := storage.EncodeEdge(edge)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		vertices := tx.Bucket(verticesBucket)
+
+		if vertices.Get(source) == nil {
+			return fmt.Errorf("source vertex %v: %w", sourceHash, graph.ErrVertexNotFound)
+		}
+
+		if vertices.Get(target) == nil {
+			return fmt.Errorf("target vertex %v: %w", targetHash, graph.ErrVertexNotFound)
+		}
+
+		outEdges, err := tx.Bucket(outEdgesBucket).CreateBucketIfNotExists(source)
+		if err != nil {
+			return fmt.Errorf("failed to create edge bucket: %w", err)
+		}
+
+		if outEdges.Get(target) != nil {
+			return graph.ErrEdgeAlreadyExists
+		}
+
+		inEdges, err := tx.Bucket(inEdgesBucket).CreateBucketIfNotExists(target)
+		if err != nil {
+			return fmt.Errorf("failed to create edge bucket: %w", err)
+		}
+
+		if err := outEdges.Put(target, record); err != nil {
+			return err
+		}
+
+		if err := inEdges.Put(source, []byte{}); err != nil {
+			return err
+		}
+
+		return addToEdgeCount(tx, 1)
+	})
+}
+
+func (s *Store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	source, err := encodeHash(sourceHash)
+	if err != nil {
+		return err
+	}
+
+	target, err := encodeHash(targetHash)
+	if err != nil {
+		return err
+	}
+
+	record, err := storage.EncodeEdge(edge)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		outEdges := tx.Bucket(outEdgesBucket).Bucket(source)
+
+		if outEdges == nil || outEdges.Get(target) == nil {
+			return graph.ErrEdgeNotFound
+		}
+
+		return outEdges.Put(target, record)
+	})
+}
+
+func (s *Store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	source, err := encodeHash(sourceHash)
+	if err != nil {
+		return err
+	}
+
+	target, err := encodeHash(targetHash)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		outEdges := tx.Bucket(outEdgesBucket).Bucket(source)
+
+		// Removing a non-existent edge is not an error, but it must not affect
+		// the edge count either.
+		if outEdges == nil || outEdges.Get(target) == nil {
+			return nil
+		}
+
+		if err := outEdges.Delete(target); err != nil {
+			return err
+		}
+
+		if inEdges := tx.Bucket(inEdgesBucket).Bucket(target); inEdges != nil {
+			if err := inEdges.Delete(source); err != nil {
+				return err
+			}
+		}
+
+		return addToEdgeCount(tx, -1)
+	})
+}
+
+func (s *Store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	source, err := encodeHash(sourceHash)
+	if err != nil {
+		return graph.Edge[K]{}, err
+	}
+
+	target, err := encodeHash(targetHash)
+	if err != nil {
+		return graph.Edge[K]{}, err
+	}
+
+	var edge graph.Edge[K]
+
+	err = s.db.View(func(tx *bolt.Tx) error {
+		outEdges := tx.Bucket(outEdgesBucket).Bucket(source)
+		if outEdges == nil {
+			return graph.ErrEdgeNotFound
+		}
+
+		record := outEdges.Get(target)
+		if record == nil {
+			return graph.ErrEdgeNotFound
+		}
+
+		edge, err = storage.DecodeEdge(sourceHash, targetHash, record)
+		return err
+	})
+
+	return edge, err
+}
+
+func (s *Store[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	edges := make([]graph.Edge[K], 0)
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		outEdgesRoot := tx.Bucket(outEdgesBucket)
+
+		return outEdgesRoot.ForEach(func(source, _ []byte) error {
+			sourceHash, err := decodeHash[K](source)
+			if err != nil {
+				return err
+			}
+
+			return outEdgesRoot.Bucket(source).ForEach(func(target, record []byte) error {
+				targetHash, err := decodeHash[K](target)
+				if err != nil {
+					return err
+				}
+
+				edge, err := storage.DecodeEdge(sourceHash, targetHash, record)
+				if err != nil {
+					return err
+				}
+
+				edges = append(edges, edge)
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return edges, nil
+}
+
+func (s *Store[K, T]) EdgeCount() (int, error) {
+	var count int
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		count = int(edgeCount(tx))
+		return nil
+	})
+
+	return count, err
+}
+
+func edgeCount(tx *bolt.Tx) int64 {
+	value := tx.Bucket(metaBucket).Get(edgeCountKey)
+	if value == nil {
+		return 0
+	}
+
+	return int64(binary.BigEndian.Uint64(value))
+}
+
+func addToEdgeCount(tx *bolt.Tx, delta int64) error {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(edgeCount(tx)+delta))
+
+	return tx.Bucket(metaBucket).Put(edgeCountKey, value)
+}
+
+// encodeHash encodes the given hash as a bucket key using the encoding shared
+// by all stores.
+func encodeHash[K comparable](hash K) ([]byte, error) {
+	encoded, err := storage.EncodeHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(encoded), nil
+}
+
+func decodeHash[K comparable](key []byte) (K, error) {
+	return storage.DecodeHash[K](string(key))
+}
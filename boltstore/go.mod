@@ -0,0 +1,12 @@
+module github.com/dominikbraun/graph/boltstore
+
+go 1.22
+
+require (
+	github.com/dominikbraun/graph v0.23.0
+	go.etcd.io/bbolt v1.3.11
+)
+
+require golang.org/x/sys v0.22.0 // indirect
+
+replace github.com/dominikbraun/graph => ../
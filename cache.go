@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Cached returns a graph that memoizes the results of AdjacencyMap and
+// PredecessorMap until the graph is modified. This pays off for stores that are
+// expensive to query, e.g. stores backed by a database, when running several
+// algorithms on an unchanged graph:
+//
+//	cached, release, _ := graph.Cached(g)
+//	defer release()
+//
+//	order, _ := graph.TopologicalSort(cached)
+//	path, _ := graph.ShortestPath(cached, "A", "F")
+//
+// Algorithms that only visit the neighbors of vertices, such as TopologicalSort
+// and ShortestPath, read the cached maps directly. Stores that already provide
+// cheap access to the edges of a vertex, such as the default in-memory store,
+// are queried directly instead, so caching them doesn't gain anything. Since
+// callers may modify the maps returned by AdjacencyMap and PredecessorMap, each
+// call returns a copy of the cached maps.
+//
+// The returned graph reads from and writes to g. Its cache is invalidated using
+// hooks registered on g, so that modifications made through either graph are
+// observed, while modifications made directly to the store of g are not. The
+// returned release function removes these hooks, and the graph doesn't cache
+// any maps afterwards. If g already is a cached graph, it is returned along
+// with a release function that does nothing. Clones of the returned graph
+// aren't cached.
+func Cached[K comparable, T any](g Graph[K, T]) (Graph[K, T], func(), error) {
+	if c, ok := g.(*cachedGraph[K, T]); ok {
+		return c, func() {}, nil
+	}
+
+	c := &cachedGraph[K, T]{Graph: g}
+
+	invalidate := func() {
+		c.lock.Lock()
+		c.invalidate()
+		c.lock.Unlock()
+	}
+
+	unregister, err := registerHook(g, hookFuncs[K, T]{
+		addVertex:    func(K, T, VertexProperties) { invalidate() },
+		removeVertex: func(K) { invalidate() },
+		addEdge:      func(Edge[K]) { invalidate() },
+		updateEdge:   func(Edge[K]) { invalidate() },
+		removeEdge:   func(K, K) { invalidate() },
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to register hooks: %w", err)
+	}
+
+	var once sync.Once
+
+	release := func() {
+		once.Do(func() {
+			unregister()
+
+			c.lock.Lock()
+			c.released = true
+			c.invalidate()
+			c.lock.Unlock()
+		})
+	}
+
+	return c, release, nil
+}
+
+// cachedGraph wraps a graph and caches its adjacency map and predecessor map.
+// The generation is incremented on each modification, so that maps built
+// concurrently to a modification aren't cached. Once the cache is released,
+// no maps are cached anymore.
+type cachedGraph[K comparable, T any] struct {
+	Graph[K, T]
+	lock           sync.Mutex
+	generation     uint64
+	released       bool
+	adjacencyMap   map[K]map[K]Edge[K]
+	predecessorMap map[K]map[K]Edge[K]
+}
+
+func (c *cachedGraph[K, T]) AdjacencyMap() (map[K]map[K]Edge[K], error) {
+	return c.copied(&c.adjacencyMap, c.Graph.AdjacencyMap)
+}
+
+func (c *cachedGraph[K, T]) PredecessorMap() (map[K]map[K]Edge[K], error) {
+	return c.copied(&c.predecessorMap, c.Graph.PredecessorMap)
+}
+
+// sharedAdjacencyMap returns the cached adjacency map without copying it. The
+// caller must not modify the map.
+func (c *cachedGraph[K, T]) sharedAdjacencyMap() (map[K]map[K]Edge[K], error) {
+	m, _, err := c.cached(&c.adjacencyMap, c.Graph.AdjacencyMap)
+	return m, err
+}
+
+// sharedPredecessorMap is the counterpart to sharedAdjacencyMap for the
+// predecessor map.
+func (c *cachedGraph[K, T]) sharedPredecessorMap() (map[K]map[K]Edge[K], error) {
+	m, _, err := c.cached(&c.predecessorMap, c.Graph.PredecessorMap)
+	return m, err
+}
+
+// invalidate drops the cached maps. The caller must hold the lock.
+func (c *cachedGraph[K, T]) invalidate() {
+	c.generation++
+	c.adjacencyMap = nil
+	c.predecessorMap = nil
+}
+
+// copied returns a copy of the map stored in target, which can be modified by
+// the caller. A map that hasn't been cached isn't copied.
+func (c *cachedGraph[K, T]) copied(target *map[K]map[K]Edge[K], build func() (map[K]map[K]Edge[K], error)) (map[K]map[K]Edge[K], error) {
+	m, shared, err := c.cached(target, build)
+	if err != nil || !shared {
+		return m, err
+	}
+
+	return copyEdgeMap(m), nil
+}
+
+// cached returns the map stored in target. If there is no cached map, it is
+// built using the given function and stored in target, unless the cache has
+// been released. The returned bool reports whether the map is stored in the
+// cache and thus must not be modified.
+func (c *cachedGraph[K, T]) cached(target *map[K]map[K]Edge[K], build func() (map[K]map[K]Edge[K], error)) (map[K]map[K]Edge[K], bool, error) {
+	c.lock.Lock()
+	m := *target
+	generation := c.generation
+	released := c.released
+	c.lock.Unlock()
+
+	if m != nil {
+		return m, true, nil
+	}
+
+	m, err := build()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if released {
+		return m, false, nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.generation != generation {
+		return m, false, nil
+	}
+
+	*target = m
+
+	return m, true, nil
+}
@@ -0,0 +1,193 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestCached(t *testing.T) {
+	tests := map[string]struct {
+		traits *Traits
+	}{
+		"directed graph": {
+			traits: &Traits{IsDirected: true},
+		},
+		"undirected graph": {
+			traits: &Traits{},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash, func(traits *Traits) { *traits = *test.traits })
+
+		_ = g.AddVertex(1)
+		_ = g.AddVertex(2)
+		_ = g.AddEdge(1, 2)
+
+		cached, release, err := Cached(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		defer release()
+
+		adjacencyMap, _ := cached.AdjacencyMap()
+		if len(adjacencyMap[1]) != 1 {
+			t.Errorf("%s: adjacencies of 1 don't match: expected %v, got %v", name, 1, len(adjacencyMap[1]))
+		}
+
+		// Modifying the returned map must not affect the cache.
+		delete(adjacencyMap, 1)
+
+		adjacencyMap, _ = cached.AdjacencyMap()
+		if _, ok := adjacencyMap[1]; !ok {
+			t.Errorf("%s: cached adjacency map has been modified by the caller", name)
+		}
+
+		// Modifications through the cached graph invalidate the cache.
+		_ = cached.AddVertex(3)
+		_ = cached.AddEdge(2, 3)
+
+		predecessorMap, _ := cached.PredecessorMap()
+		if len(predecessorMap[3]) != 1 {
+			t.Errorf("%s: predecessors of 3 don't match: expected %v, got %v", name, 1, len(predecessorMap[3]))
+		}
+
+		// Modifications through the original graph invalidate the cache.
+		_ = g.RemoveEdge(2, 3)
+
+		predecessorMap, _ = cached.PredecessorMap()
+		if len(predecessorMap[3]) != 0 {
+			t.Errorf("%s: predecessors of 3 don't match: expected %v, got %v", name, 0, len(predecessorMap[3]))
+		}
+
+		if _, err := RemoveVertexWithEdges(cached, 1); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		adjacencyMap, _ = cached.AdjacencyMap()
+		if len(adjacencyMap) != 2 {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, 2, len(adjacencyMap))
+		}
+
+		if _, ok := storeOf(cached); !ok {
+			t.Errorf("%s: store of the cached graph can't be obtained", name)
+		}
+	}
+}
+
+func TestCached_algorithms(t *testing.T) {
+	g := New(IntHash, Directed(), PreventCycles())
+
+	for i := 1; i <= 5; i++ {
+		_ = g.AddVertex(i)
+	}
+	_ = g.AddEdge(1, 2)
+	_ = g.AddEdge(1, 3)
+	_ = g.AddEdge(2, 4)
+	_ = g.AddEdge(3, 4)
+	_ = g.AddEdge(4, 5)
+
+	cached, release, _ := Cached(g)
+	defer release()
+
+	for i := 0; i < 2; i++ {
+		order, err := StableTopologicalSort(cached, func(a, b int) bool { return a < b })
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []int{1, 2, 3, 4, 5}
+		if !slicesAreEqual(order, expected) {
+			t.Errorf("topological order doesn't match: expected %v, got %v", expected, order)
+		}
+	}
+}
+
+// uncachableGraph is a graph implementation that doesn't support hooks.
+type uncachableGraph struct {
+	Graph[int, int]
+}
+
+func TestCached_unsupportedGraph(t *testing.T) {
+	g := uncachableGraph{Graph: New(IntHash)}
+
+	cached, _, err := Cached[int, int](g)
+	if err == nil {
+		t.Errorf("error expectancy doesn't match: expected error, got %v", cached)
+	}
+}
+
+func TestCached_release(t *testing.T) {
+	g := New(IntHash, Directed())
+
+	_ = g.AddVertex(1)
+	_ = g.AddVertex(2)
+
+	hooks := g.(*directed[int, int]).hooks
+
+	for i := 0; i < 3; i++ {
+		_, release, _ := Cached(g)
+		release()
+		release()
+	}
+
+	if n := len(hooks.registered()); n != 0 {
+		t.Errorf("number of hooks doesn't match: expected %v, got %v", 0, n)
+	}
+
+	cached, release, _ := Cached(g)
+
+	if again, _, _ := Cached(cached); again != cached {
+		t.Errorf("cached graph has been wrapped again")
+	}
+
+	_, _ = cached.AdjacencyMap()
+	release()
+
+	// Once released, the graph isn't cached anymore, so that modifications
+	// are observed even though the hooks have been removed.
+	_ = g.AddEdge(1, 2)
+
+	adjacencyMap, _ := cached.AdjacencyMap()
+	if len(adjacencyMap[1]) != 1 {
+		t.Errorf("adjacencies of 1 don't match: expected %v, got %v", 1, len(adjacencyMap[1]))
+	}
+
+	if n := len(hooks.registered()); n != 0 {
+		t.Errorf("number of hooks doesn't match: expected %v, got %v", 0, n)
+	}
+}
+
+func TestCached_sharedMaps(t *testing.T) {
+	memoryStore := newMemoryStore[int, int]()
+	store := &countingNeighborStore[int, int]{
+		Store:     memoryStore,
+		neighbors: memoryStore.(NeighborStore[int]),
+		calls:     make(map[int]int),
+	}
+
+	g := NewWithStore(IntHash, Store[int, int](store), Directed())
+
+	for i := 1; i <= 4; i++ {
+		_ = g.AddVertex(i)
+	}
+	_ = g.AddEdge(1, 2)
+	_ = g.AddEdge(2, 3)
+	_ = g.AddEdge(3, 4)
+
+	cached, release, _ := Cached(g)
+	defer release()
+
+	for i := 0; i < 3; i++ {
+		if _, err := TopologicalSort(cached); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := ShortestPath(cached, 1, 4); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The successors are read from the cached adjacency map instead.
+	if len(store.calls) != 0 {
+		t.Errorf("number of store queries doesn't match: expected %v, got %v", 0, store.calls)
+	}
+}
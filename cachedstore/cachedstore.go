@@ -0,0 +1,266 @@
+// Package cachedstore provides a [graph.Store] wrapper that caches frequently
+// accessed vertices and adjacency rows in memory. It is intended to be used in
+// front of slow stores, such as SQL databases or remote stores.
+//
+//	store := cachedstore.Wrap[string, string](sqlStore, 10000)
+//	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+//
+// The cache holds up to the given number of entries, where each vertex and each
+// adjacency row counts as one entry. An adjacency row is the list of outgoing
+// or ingoing edges of a single vertex, as returned by the graph.NeighborStore
+// methods. When the cache is full, the least recently used entry is evicted.
+//
+// Writes through the wrapper invalidate the affected entries. Writes that
+// bypass the wrapper, for example by another process using the same database,
+// aren't detected, and the cache may serve stale data until the entries are
+// evicted.
+package cachedstore
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/dominikbraun/graph"
+)
+
+type kind int
+
+const (
+	vertexEntry kind = iota
+	outEdgesEntry
+	inEdgesEntry
+)
+
+type cacheKey[K comparable] struct {
+	kind kind
+	hash K
+}
+
+type cacheEntry[K comparable, T any] struct {
+	key        cacheKey[K]
+	value      T
+	properties graph.VertexProperties
+	edges      []graph.Edge[K]
+}
+
+// store caches the vertices and adjacency rows of the wrapped store in an LRU
+// cache.
+type store[K comparable, T any] struct {
+	lock    sync.Mutex
+	backing graph.Store[K, T]
+	size    int
+	entries map[cacheKey[K]]*list.Element
+	lru     *list.List
+	// version is incremented whenever entries are invalidated. Values read
+	// from the wrapped store are only cached if the version didn't change in
+	// the meantime, so that concurrent writes can't leave stale entries.
+	version uint64
+}
+
+// Wrap returns a store that caches up to size vertices and adjacency rows of
+// the given store.
+//
+// If s implements graph.NeighborStore, the returned store implements it as well
+// and caches the adjacency rows. Otherwise, only vertices are cached. Other
+// optional interfaces of s aren't forwarded.
+func Wrap[K comparable, T any](s graph.Store[K, T], size int) graph.Store[K, T] {
+	cached := newStore(s, size)
+
+	if _, ok := s.(graph.NeighborStore[K]); ok {
+		return &neighborStore[K, T]{cached}
+	}
+
+	return cached
+}
+
+func newStore[K comparable, T any](s graph.Store[K, T], size int) *store[K, T] {
+	return &store[K, T]{
+		backing: s,
+		size:    size,
+		entries: make(map[cacheKey[K]]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+func (s *store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	defer s.invalidate(cacheKey[K]{vertexEntry, hash})
+	return s.backing.AddVertex(hash, value, properties)
+}
+
+func (s *store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	key := cacheKey[K]{vertexEntry, hash}
+
+	entry, version, ok := s.get(key)
+	if ok {
+		return entry.value, entry.properties, nil
+	}
+
+	value, properties, err := s.backing.Vertex(hash)
+	if err != nil {
+		return value, properties, err
+	}
+
+	s.put(version, &cacheEntry[K, T]{
+		key:        key,
+		value:      value,
+		properties: properties,
+	})
+
+	return value, properties, nil
+}
+
+func (s *store[K, T]) RemoveVertex(hash K) error {
+	defer s.invalidate(
+		cacheKey[K]{vertexEntry, hash},
+		cacheKey[K]{outEdgesEntry, hash},
+		cacheKey[K]{inEdgesEntry, hash},
+	)
+	return s.backing.RemoveVertex(hash)
+}
+
+func (s *store[K, T]) ListVertices() ([]K, error) {
+	return s.backing.ListVertices()
+}
+
+func (s *store[K, T]) VertexCount() (int, error) {
+	return s.backing.VertexCount()
+}
+
+func (s *store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	defer s.invalidateEdge(sourceHash, targetHash)
+	return s.backing.AddEdge(sourceHash, targetHash, edge)
+}
+
+func (s *store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	defer s.invalidateEdge(sourceHash, targetHash)
+	return s.backing.UpdateEdge(sourceHash, targetHash, edge)
+}
+
+func (s *store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	defer s.invalidateEdge(sourceHash, targetHash)
+	return s.backing.RemoveEdge(sourceHash, targetHash)
+}
+
+// Edge returns the edge between the given vertices. If the outgoing edges of
+// the source vertex are cached, the edge is looked up in the cache.
+func (s *store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	if entry, _, ok := s.get(cacheKey[K]{outEdgesEntry, sourceHash}); ok {
+		for _, edge := range entry.edges {
+			if edge.Target == targetHash {
+				return edge, nil
+			}
+		}
+		return graph.Edge[K]{}, graph.ErrEdgeNotFound
+	}
+
+	return s.backing.Edge(sourceHash, targetHash)
+}
+
+func (s *store[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	return s.backing.ListEdges()
+}
+
+func (s *store[K, T]) EdgeCount() (int, error) {
+	return s.backing.EdgeCount()
+}
+
+// edges returns the adjacency row with the given key, using fetch to read it
+// from the wrapped store if it isn't cached.
+func (s *store[K, T]) edges(key cacheKey[K], fetch func(K) ([]graph.Edge[K], error)) ([]graph.Edge[K], error) {
+	entry, version, ok := s.get(key)
+	if !ok {
+		edges, err := fetch(key.hash)
+		if err != nil {
+			return nil, err
+		}
+
+		entry = &cacheEntry[K, T]{
+			key:   key,
+			edges: edges,
+		}
+
+		s.put(version, entry)
+	}
+
+	edges := make([]graph.Edge[K], len(entry.edges))
+	copy(edges, entry.edges)
+
+	return edges, nil
+}
+
+// get returns the cached entry with the given key and marks it as recently
+// used. It also returns the current version for caching a value read from the
+// wrapped store using put.
+func (s *store[K, T]) get(key cacheKey[K]) (*cacheEntry[K, T], uint64, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return nil, s.version, false
+	}
+
+	s.lru.MoveToFront(element)
+
+	return element.Value.(*cacheEntry[K, T]), s.version, true
+}
+
+// put caches the given entry, unless entries have been invalidated since the
+// given version has been obtained.
+func (s *store[K, T]) put(version uint64, entry *cacheEntry[K, T]) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if version != s.version || s.size <= 0 {
+		return
+	}
+
+	if element, ok := s.entries[entry.key]; ok {
+		element.Value = entry
+		s.lru.MoveToFront(element)
+		return
+	}
+
+	s.entries[entry.key] = s.lru.PushFront(entry)
+
+	for s.lru.Len() > s.size {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.entries, oldest.Value.(*cacheEntry[K, T]).key)
+	}
+}
+
+func (s *store[K, T]) invalidate(keys ...cacheKey[K]) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, key := range keys {
+		if element, ok := s.entries[key]; ok {
+			s.lru.Remove(element)
+			delete(s.entries, key)
+		}
+	}
+
+	s.version++
+}
+
+func (s *store[K, T]) invalidateEdge(sourceHash, targetHash K) {
+	s.invalidate(
+		cacheKey[K]{outEdgesEntry, sourceHash},
+		cacheKey[K]{inEdgesEntry, targetHash},
+	)
+}
+
+// neighborStore is the Store returned by Wrap if the wrapped store implements
+// graph.NeighborStore.
+type neighborStore[K comparable, T any] struct {
+	*store[K, T]
+}
+
+func (n *neighborStore[K, T]) EdgesBySource(sourceHash K) ([]graph.Edge[K], error) {
+	return n.edges(cacheKey[K]{outEdgesEntry, sourceHash}, n.backing.(graph.NeighborStore[K]).EdgesBySource)
+}
+
+func (n *neighborStore[K, T]) EdgesByTarget(targetHash K) ([]graph.Edge[K], error) {
+	return n.edges(cacheKey[K]{inEdgesEntry, targetHash}, n.backing.(graph.NeighborStore[K]).EdgesByTarget)
+}
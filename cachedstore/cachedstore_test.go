@@ -0,0 +1,172 @@
+package cachedstore
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/filestore"
+)
+
+// countingStore is a backing store that implements graph.NeighborStore and
+// counts the reads that reach it.
+type countingStore struct {
+	*filestore.Store[string, string]
+	vertexReads int
+	edgeReads   int
+}
+
+func (s *countingStore) Vertex(hash string) (string, graph.VertexProperties, error) {
+	s.vertexReads++
+	return s.Store.Vertex(hash)
+}
+
+func (s *countingStore) EdgesBySource(sourceHash string) ([]graph.Edge[string], error) {
+	return s.edges(func(edge graph.Edge[string]) bool { return edge.Source == sourceHash })
+}
+
+func (s *countingStore) EdgesByTarget(targetHash string) ([]graph.Edge[string], error) {
+	return s.edges(func(edge graph.Edge[string]) bool { return edge.Target == targetHash })
+}
+
+func (s *countingStore) edges(match func(graph.Edge[string]) bool) ([]graph.Edge[string], error) {
+	s.edgeReads++
+
+	edges, err := s.ListEdges()
+	if err != nil {
+		return nil, err
+	}
+
+	matching := make([]graph.Edge[string], 0)
+	for _, edge := range edges {
+		if match(edge) {
+			matching = append(matching, edge)
+		}
+	}
+
+	return matching, nil
+}
+
+func newCountingStore(t *testing.T) *countingStore {
+	store, err := filestore.Open[string, string](filepath.Join(t.TempDir(), "graph.log"))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return &countingStore{Store: store}
+}
+
+func TestWrap_vertices(t *testing.T) {
+	backing := newCountingStore(t)
+	store := Wrap[string, string](backing, 2)
+
+	_ = store.AddVertex("A", "a", graph.VertexProperties{Weight: 1})
+	_ = store.AddVertex("B", "b", graph.VertexProperties{})
+	_ = store.AddVertex("C", "c", graph.VertexProperties{})
+
+	for i := 0; i < 3; i++ {
+		value, properties, err := store.Vertex("A")
+		if err != nil || value != "a" || properties.Weight != 1 {
+			t.Fatalf("vertex doesn't match: got %v, %v (error: %v)", value, properties, err)
+		}
+	}
+
+	if backing.vertexReads != 1 {
+		t.Errorf("vertex reads don't match: expected %v, got %v", 1, backing.vertexReads)
+	}
+
+	// Reading B and C evicts A, which has been used least recently.
+	_, _, _ = store.Vertex("B")
+	_, _, _ = store.Vertex("C")
+	_, _, _ = store.Vertex("A")
+
+	if backing.vertexReads != 4 {
+		t.Errorf("vertex reads don't match: expected %v, got %v", 4, backing.vertexReads)
+	}
+
+	if _, _, err := store.Vertex("D"); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	if _, ok := store.(graph.NeighborStore[string]); !ok {
+		t.Errorf("expected wrapped store to implement NeighborStore")
+	}
+}
+
+func TestWrap_edges(t *testing.T) {
+	backing := newCountingStore(t)
+	store := Wrap[string, string](backing, 10)
+	neighborStore := store.(graph.NeighborStore[string])
+
+	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddVertex("C")
+	_ = g.AddEdge("A", "B")
+
+	edges, _ := neighborStore.EdgesBySource("A")
+	_, _ = neighborStore.EdgesBySource("A")
+
+	if len(edges) != 1 || backing.edgeReads != 1 {
+		t.Errorf("expected 1 edge and 1 read, got %v edges and %v reads", len(edges), backing.edgeReads)
+	}
+
+	// The cached row is used for looking up single edges as well.
+	if _, err := store.Edge("A", "C"); !errors.Is(err, graph.ErrEdgeNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeNotFound, err)
+	}
+
+	// Adding an edge invalidates the rows of its source and target.
+	_ = g.AddEdge("A", "C")
+
+	edges, _ = neighborStore.EdgesBySource("A")
+
+	if len(edges) != 2 || backing.edgeReads != 2 {
+		t.Errorf("expected 2 edges and 2 reads, got %v edges and %v reads", len(edges), backing.edgeReads)
+	}
+
+	_ = g.UpdateEdge("A", "B", graph.EdgeWeight(5))
+
+	edge, err := store.Edge("A", "B")
+	if err != nil || edge.Properties.Weight != 5 {
+		t.Errorf("expected updated edge, got %v (error: %v)", edge, err)
+	}
+
+	_ = g.RemoveEdge("A", "B")
+
+	edges, _ = neighborStore.EdgesByTarget("B")
+
+	if len(edges) != 0 {
+		t.Errorf("expected no ingoing edges, got %v", edges)
+	}
+}
+
+func TestWrap_withoutNeighborStore(t *testing.T) {
+	backing := newCountingStore(t)
+	store := Wrap[string, string](backing.Store, 10)
+
+	if _, ok := store.(graph.NeighborStore[string]); ok {
+		t.Errorf("expected wrapped store not to implement NeighborStore")
+	}
+}
+
+func TestStore_put(t *testing.T) {
+	s := newStore[string, string](nil, 10)
+
+	_, version, _ := s.get(cacheKey[string]{vertexEntry, "A"})
+
+	// An invalidation between reading the version and caching the entry must
+	// prevent the possibly stale entry from being cached.
+	s.invalidate(cacheKey[string]{vertexEntry, "A"})
+	s.put(version, &cacheEntry[string, string]{key: cacheKey[string]{vertexEntry, "A"}})
+
+	if _, _, ok := s.get(cacheKey[string]{vertexEntry, "A"}); ok {
+		t.Errorf("expected stale entry not to be cached")
+	}
+}
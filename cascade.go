@@ -0,0 +1,98 @@
+package graph
+
+import "fmt"
+
+// RemoveVertexWithEdges removes the vertex with the given hash along with all
+// edges joining it, and returns the removed edges. For undirected graphs, each
+// removed edge is returned once with the removed vertex as its source.
+//
+// If the store of the graph implements [CascadeStore], the vertex and its edges
+// are removed in a single store call, which allows the store to remove them
+// atomically. Otherwise, each edge is removed separately before removing the
+// vertex, and an error may leave some of the edges removed.
+func RemoveVertexWithEdges[K comparable, T any](g Graph[K, T], hash K) ([]Edge[K], error) {
+	var h *hooks[K, T]
+
+	switch g := g.(type) {
+	case *directed[K, T]:
+		h = g.hooks
+	case *undirected[K, T]:
+		h = g.hooks
+	case *cachedGraph[K, T]:
+		return RemoveVertexWithEdges(g.Graph, hash)
+	}
+
+	if store, ok := storeOf(g); ok && h != nil {
+		if cascadeStore, ok := store.(CascadeStore[K]); ok {
+			removed, err := cascadeStore.RemoveVertexWithEdges(hash)
+			if err != nil {
+				return nil, vertexError(hash, err)
+			}
+
+			// Undirected graphs store each edge in both directions.
+			if !g.Traits().IsDirected {
+				outgoing := removed[:0]
+				for _, edge := range removed {
+					if edge.Source == hash {
+						outgoing = append(outgoing, edge)
+					}
+				}
+				removed = outgoing
+			}
+
+			for _, edge := range removed {
+				h.edgeRemoved(edge.Source, edge.Target)
+			}
+			h.vertexRemoved(hash)
+
+			return removed, nil
+		}
+	}
+
+	if _, err := g.Vertex(hash); err != nil {
+		return nil, fmt.Errorf("could not get vertex with hash %v: %w", hash, err)
+	}
+
+	successorsOf, err := successorsFunc(g)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := make([]Edge[K], 0)
+
+	err = successorsOf(hash, func(_ K, edge Edge[K]) {
+		removed = append(removed, edge)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get successors of %v: %w", hash, err)
+	}
+
+	if g.Traits().IsDirected {
+		predecessorsOf, err := predecessorsFunc(g)
+		if err != nil {
+			return nil, err
+		}
+
+		err = predecessorsOf(hash, func(predecessor K, edge Edge[K]) {
+			// A self-loop has already been collected as an outgoing edge.
+			if predecessor != hash {
+				removed = append(removed, edge)
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get predecessors of %v: %w", hash, err)
+		}
+	}
+
+	for _, edge := range removed {
+		if err := g.RemoveEdge(edge.Source, edge.Target); err != nil {
+			return nil, fmt.Errorf("failed to remove edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	if err := g.RemoveVertex(hash); err != nil {
+		return nil, fmt.Errorf("failed to remove vertex %v: %w", hash, err)
+	}
+
+	return removed, nil
+}
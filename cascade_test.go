@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+// plainStore is a store that hides the optional interfaces of the wrapped
+// store, including CascadeStore.
+type plainStore[K comparable, T any] struct {
+	Store[K, T]
+}
+
+func TestRemoveVertexWithEdges(t *testing.T) {
+	tests := map[string]struct {
+		traits        []func(*Traits)
+		store         func() Store[int, int]
+		expectedEdges int
+		expectedSize  int
+	}{
+		"directed graph": {
+			traits:        []func(*Traits){Directed()},
+			store:         newMemoryStore[int, int],
+			expectedEdges: 4,
+			expectedSize:  1,
+		},
+		"directed graph without CascadeStore": {
+			traits: []func(*Traits){Directed()},
+			store: func() Store[int, int] {
+				return plainStore[int, int]{newMemoryStore[int, int]()}
+			},
+			expectedEdges: 4,
+			expectedSize:  1,
+		},
+		"undirected graph": {
+			store:         newMemoryStore[int, int],
+			expectedEdges: 4,
+			expectedSize:  1,
+		},
+		"undirected graph without CascadeStore": {
+			store: func() Store[int, int] {
+				return plainStore[int, int]{newMemoryStore[int, int]()}
+			},
+			expectedEdges: 4,
+			expectedSize:  1,
+		},
+	}
+
+	for name, test := range tests {
+		g := NewWithStore(IntHash, test.store(), test.traits...)
+
+		for i := 1; i <= 4; i++ {
+			_ = g.AddVertex(i)
+		}
+
+		_ = g.AddEdge(1, 2)
+		_ = g.AddEdge(2, 3)
+		_ = g.AddEdge(3, 1)
+		_ = g.AddEdge(4, 2)
+		_ = g.AddEdge(2, 2)
+
+		var removedEdges, removedVertices int
+
+		_, _ = OnRemoveEdge(g, func(_, _ int) { removedEdges++ })
+		_, _ = OnRemoveVertex(g, func(_ int) { removedVertices++ })
+
+		edges, err := RemoveVertexWithEdges(g, 2)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		for _, edge := range edges {
+			if edge.Source != 2 && edge.Target != 2 {
+				t.Errorf("%s: unexpected edge (%v, %v)", name, edge.Source, edge.Target)
+			}
+		}
+
+		// The self-loop is removed as well, but only once.
+		if len(edges) != test.expectedEdges {
+			t.Errorf("%s: number of removed edges doesn't match: expected %v, got %v", name, test.expectedEdges, len(edges))
+		}
+
+		if removedEdges != len(edges) || removedVertices != 1 {
+			t.Errorf("%s: expected %v edge hooks and 1 vertex hook, got %v and %v", name, len(edges), removedEdges, removedVertices)
+		}
+
+		if _, err := g.Vertex(2); !errors.Is(err, ErrVertexNotFound) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrVertexNotFound, err)
+		}
+
+		if size, _ := g.Size(); size != test.expectedSize {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, test.expectedSize, size)
+		}
+
+		if _, err := RemoveVertexWithEdges(g, 2); !errors.Is(err, ErrVertexNotFound) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrVertexNotFound, err)
+		}
+	}
+}
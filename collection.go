@@ -3,6 +3,7 @@ package graph
 import (
 	"container/heap"
 	"errors"
+	"sort"
 )
 
 // priorityQueue implements a minimum priority queue using a minimum binary heap
@@ -160,6 +161,71 @@ func (s *stack[T]) contains(element T) bool {
 	return ok
 }
 
+// reset removes all elements from the stack while retaining the allocated
+// memory, so that the stack can be reused.
+func (s *stack[T]) reset() {
+	var zero T
+
+	for i := range s.elements {
+		s.elements[i] = zero
+	}
+
+	s.elements = s.elements[:0]
+
+	for element := range s.registry {
+		delete(s.registry, element)
+	}
+}
+
+// queue is a FIFO queue. Unlike re-slicing a plain slice, it keeps track of
+// its head so that the underlying array can be reused once the queue has been
+// reset.
+type queue[T any] struct {
+	elements []T
+	head     int
+}
+
+func newQueue[T any]() *queue[T] {
+	return &queue[T]{
+		elements: make([]T, 0),
+	}
+}
+
+func (q *queue[T]) push(t T) {
+	q.elements = append(q.elements, t)
+}
+
+func (q *queue[T]) pop() (T, bool) {
+	var zero T
+
+	if q.isEmpty() {
+		return zero, false
+	}
+
+	element := q.elements[q.head]
+	q.elements[q.head] = zero
+	q.head++
+
+	return element, true
+}
+
+func (q *queue[T]) isEmpty() bool {
+	return q.head == len(q.elements)
+}
+
+// reset removes all elements from the queue while retaining the allocated
+// memory, so that the queue can be reused.
+func (q *queue[T]) reset() {
+	var zero T
+
+	for i := q.head; i < len(q.elements); i++ {
+		q.elements[i] = zero
+	}
+
+	q.elements = q.elements[:0]
+	q.head = 0
+}
+
 type stackOfStacks[T comparable] struct {
 	stacks []*stack[T]
 }
@@ -195,3 +261,142 @@ func (s *stackOfStacks[T]) top() (*stack[T], error) {
 func (s *stackOfStacks[T]) isEmpty() bool {
 	return len(s.stacks) == 0
 }
+
+// sequence keeps track of the order in which elements have been added. Each
+// element is assigned an increasing sequence number, so that iterating over the
+// elements can be resumed after a given sequence number even if elements have
+// been added or removed in the meantime.
+//
+// Removed elements are only marked as removed and are dropped once they make up
+// more than half of the entries, which keeps removals at amortized O(log n).
+type sequence[T comparable] struct {
+	entries  []sequenceEntry[T]
+	numbers  map[T]uint64
+	next     uint64
+	removals int
+}
+
+type sequenceEntry[T comparable] struct {
+	number  uint64
+	element T
+	removed bool
+}
+
+func newSequence[T comparable]() *sequence[T] {
+	return newSequenceWithCapacity[T](0)
+}
+
+// newSequenceWithCapacity creates a sequence with enough space for the given
+// number of elements.
+func newSequenceWithCapacity[T comparable](capacity int) *sequence[T] {
+	return &sequence[T]{
+		entries: make([]sequenceEntry[T], 0, capacity),
+		numbers: make(map[T]uint64, capacity),
+	}
+}
+
+// add appends the given element to the sequence unless it is already part of
+// the sequence.
+func (s *sequence[T]) add(element T) {
+	if _, ok := s.numbers[element]; ok {
+		return
+	}
+
+	s.next++
+	s.entries = append(s.entries, sequenceEntry[T]{
+		number:  s.next,
+		element: element,
+	})
+	s.numbers[element] = s.next
+}
+
+// remove marks the given element as removed. If the element isn't part of the
+// sequence, nothing happens.
+func (s *sequence[T]) remove(element T) {
+	number, ok := s.numbers[element]
+	if !ok {
+		return
+	}
+
+	delete(s.numbers, element)
+
+	s.entries[s.search(number)].removed = true
+	s.removals++
+
+	if s.removals > len(s.entries)/2 {
+		s.compact()
+	}
+}
+
+// after returns at most limit elements whose sequence numbers are greater than
+// the given number, along with the sequence number of the last returned
+// element. The returned bool reports whether there are more elements left.
+func (s *sequence[T]) after(number uint64, limit int) ([]T, uint64, bool) {
+	return s.afterFunc(number, limit, nil)
+}
+
+// afterFunc works just as after, but skips the elements for which keep returns
+// false. These elements don't count towards the limit. A nil keep function
+// keeps all elements.
+func (s *sequence[T]) afterFunc(number uint64, limit int, keep func(element T) bool) ([]T, uint64, bool) {
+	elements := make([]T, 0, limit)
+	last := number
+
+	for i := s.search(number + 1); i < len(s.entries); i++ {
+		if s.entries[i].removed {
+			continue
+		}
+
+		if keep != nil && !keep(s.entries[i].element) {
+			continue
+		}
+
+		if len(elements) == limit {
+			return elements, last, true
+		}
+
+		elements = append(elements, s.entries[i].element)
+		last = s.entries[i].number
+	}
+
+	return elements, last, false
+}
+
+// clone returns an independent copy of the sequence.
+func (s *sequence[T]) clone() *sequence[T] {
+	c := &sequence[T]{
+		entries:  make([]sequenceEntry[T], len(s.entries)),
+		numbers:  make(map[T]uint64, len(s.numbers)),
+		next:     s.next,
+		removals: s.removals,
+	}
+
+	copy(c.entries, s.entries)
+
+	for element, number := range s.numbers {
+		c.numbers[element] = number
+	}
+
+	return c
+}
+
+// search returns the index of the first entry whose sequence number is equal
+// to or greater than the given number.
+func (s *sequence[T]) search(number uint64) int {
+	return sort.Search(len(s.entries), func(i int) bool {
+		return s.entries[i].number >= number
+	})
+}
+
+func (s *sequence[T]) compact() {
+	entries := make([]sequenceEntry[T], 0, len(s.entries)-s.removals)
+
+	for _, entry := range s.entries {
+		if !entry.removed {
+			entries = append(entries, entry)
+		}
+	}
+
+	s.entries = entries
+	s.removals = 0
+}
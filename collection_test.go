@@ -440,3 +440,135 @@ func TestStack_contains(t *testing.T) {
 		})
 	}
 }
+
+func TestStack_reset(t *testing.T) {
+	s := newStack[int]()
+
+	for _, element := range []int{1, 2, 3} {
+		s.push(element)
+	}
+
+	s.reset()
+
+	if !s.isEmpty() {
+		t.Errorf("isEmpty() = %v, want %v", false, true)
+	}
+
+	if s.contains(1) {
+		t.Errorf("contains() = %v, want %v", true, false)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	tests := map[string]struct {
+		elements []int
+		reset    bool
+		expected []int
+	}{
+		"empty queue": {
+			elements: []int{},
+			expected: []int{},
+		},
+		"FIFO order": {
+			elements: []int{1, 2, 3, 4},
+			expected: []int{1, 2, 3, 4},
+		},
+		"reset queue": {
+			elements: []int{1, 2, 3, 4},
+			reset:    true,
+			expected: []int{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q := newQueue[int]()
+
+			for _, element := range test.elements {
+				q.push(element)
+			}
+
+			if test.reset {
+				q.reset()
+			}
+
+			popped := make([]int, 0)
+
+			for !q.isEmpty() {
+				element, _ := q.pop()
+				popped = append(popped, element)
+			}
+
+			if !slicesAreEqual(popped, test.expected) {
+				t.Errorf("%s: popped elements don't match: expected %v, got %v", name, test.expected, popped)
+			}
+
+			if _, ok := q.pop(); ok {
+				t.Errorf("%s: expected pop on empty queue to fail", name)
+			}
+		})
+	}
+}
+
+func TestPooledCollections(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		s := getStack[int]()
+		if !s.isEmpty() {
+			t.Fatalf("expected pooled stack to be empty")
+		}
+		s.push(1)
+		putStack(s)
+
+		q := getQueue[int]()
+		if !q.isEmpty() {
+			t.Fatalf("expected pooled queue to be empty")
+		}
+		q.push(1)
+		putQueue(q)
+
+		m := getMap[int, struct{}]()
+		if len(m) != 0 {
+			t.Fatalf("expected pooled map to be empty, got %v", m)
+		}
+		m[1] = struct{}{}
+		putMap(m)
+
+		other := getMap[string, int]()
+		if len(other) != 0 {
+			t.Fatalf("expected pooled map to be empty, got %v", other)
+		}
+		other["a"] = 1
+		putMap(other)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	s := newSequence[string]()
+
+	for _, element := range []string{"A", "B", "C", "D", "E"} {
+		s.add(element)
+	}
+
+	// Adding an existing element must not change its position.
+	s.add("A")
+
+	elements, last, more := s.after(0, 2)
+
+	if !reflect.DeepEqual(elements, []string{"A", "B"}) || !more {
+		t.Fatalf("elements don't match: expected %v, got %v (more: %v)", []string{"A", "B"}, elements, more)
+	}
+
+	s.remove("A")
+	s.remove("C")
+	s.remove("D")
+
+	elements, _, more = s.after(last, 2)
+
+	if !reflect.DeepEqual(elements, []string{"E"}) || more {
+		t.Errorf("elements don't match: expected %v, got %v (more: %v)", []string{"E"}, elements, more)
+	}
+
+	if len(s.entries) != 2 {
+		t.Errorf("expected removed entries to be compacted, got %d entries", len(s.entries))
+	}
+}
@@ -0,0 +1,432 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// EdgeCentrality is an edge along with its edge betweenness as returned by
+// [EdgeBetweenness].
+type EdgeCentrality[K comparable] struct {
+	Source      K
+	Target      K
+	Betweenness float64
+}
+
+// EdgeBetweenness computes the edge betweenness of all edges in the graph. The
+// betweenness of an edge is the number of shortest paths between any two
+// vertices that run through the edge, where vertex pairs with multiple shortest
+// paths contribute to each of them proportionally. Edges with a high
+// betweenness are bottlenecks that connect different parts of the graph:
+//
+//	edges, _ := graph.EdgeBetweenness(g)
+//	bottleneck := edges[0]
+//
+// The edges are sorted by their betweenness in descending order, and edges with
+// the same betweenness are sorted as described in [SortedEdges]. In undirected
+// graphs, each vertex pair is only counted once, and Source is the vertex of the
+// edge whose hash is sorted first. Self-loops never are on a shortest path and
+// aren't included.
+//
+// The betweenness is computed using Brandes' algorithm in O(|V||E|log(|V|))
+// time. Shortest paths are determined under consideration of the edge weights,
+// where each edge has a weight of 1 for unweighted graphs. Edge weights must be
+// positive. Instead of the stored edge weights, the weights can be derived from
+// the edges by passing WeightedBy with a [WeightFunc].
+func EdgeBetweenness[K comparable, T any](g Graph[K, T], options ...func(*WeightOptions[K])) ([]EdgeCentrality[K], error) {
+	return EdgeBetweennessCtx(context.Background(), g, options...)
+}
+
+// EdgeBetweennessCtx works just as [EdgeBetweenness], but accepts a context
+// that is checked before computing the shortest paths from each vertex.
+func EdgeBetweennessCtx[K comparable, T any](ctx context.Context, g Graph[K, T], options ...func(*WeightOptions[K])) ([]EdgeCentrality[K], error) {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	isDirected := g.Traits().IsDirected
+
+	scores, err := edgeBetweenness(ctx, sortedNeighbors(adjacencyMap), isDirected, pathWeightFunc(g, options))
+	if err != nil {
+		return nil, err
+	}
+
+	return rankEdges(scores), nil
+}
+
+// GirvanNewman detects communities in an undirected graph using the algorithm
+// by Girvan and Newman. It repeatedly removes the edge with the highest edge
+// betweenness, which splits the graph into more and more components. Each
+// community is a component at some point:
+//
+//	communities, _ := graph.GirvanNewman(g, 0)
+//
+// If k is positive, the communities are returned as soon as there are at least
+// k of them. Otherwise, the communities with the highest [Modularity] among all
+// components encountered are returned. k must not exceed the number of
+// vertices. Each community is sorted as described in [SortedVertices], and the
+// communities are sorted by their first vertex.
+//
+// The edge betweenness is recomputed after each removed edge, which results in
+// a time complexity of O(|E|²|V|log(|V|)). Therefore, GirvanNewman is suitable
+// for small and medium graphs. The weight options are used for computing the
+// edge betweenness as described in [EdgeBetweenness].
+func GirvanNewman[K comparable, T any](g Graph[K, T], k int, options ...func(*WeightOptions[K])) ([][]K, error) {
+	return GirvanNewmanCtx(context.Background(), g, k, options...)
+}
+
+// GirvanNewmanCtx works just as [GirvanNewman], but accepts a context that is
+// checked before computing the shortest paths from each vertex.
+func GirvanNewmanCtx[K comparable, T any](ctx context.Context, g Graph[K, T], k int, options ...func(*WeightOptions[K])) ([][]K, error) {
+	if g.Traits().IsDirected {
+		return nil, errors.New("communities can only be detected in undirected graphs")
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	if k > len(adjacencyMap) {
+		return nil, fmt.Errorf("k must not exceed the number of vertices %d, got %d", len(adjacencyMap), k)
+	}
+
+	edgeWeight := pathWeightFunc(g, options)
+
+	// Self-loops never are on a shortest path, so they aren't removed.
+	remaining := make(map[K]map[K]Edge[K], len(adjacencyMap))
+	for hash, adjacencies := range adjacencyMap {
+		remaining[hash] = make(map[K]Edge[K], len(adjacencies))
+		for adjacency, edge := range adjacencies {
+			if adjacency != hash {
+				remaining[hash][adjacency] = edge
+			}
+		}
+	}
+
+	var (
+		best           [][]K
+		bestModularity = math.Inf(-1)
+	)
+
+	for {
+		neighbors := sortedNeighbors(remaining)
+		communities := components(neighbors)
+
+		if k > 0 && len(communities) >= k {
+			return communities, nil
+		}
+
+		if k <= 0 {
+			if q := modularity(adjacencyMap, communities); q > bestModularity {
+				best, bestModularity = communities, q
+			}
+		}
+
+		scores, err := edgeBetweenness(ctx, neighbors, false, edgeWeight)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(scores) == 0 {
+			return best, nil
+		}
+
+		edge := rankEdges(scores)[0]
+
+		delete(remaining[edge.Source], edge.Target)
+		delete(remaining[edge.Target], edge.Source)
+	}
+}
+
+// Modularity computes the modularity of a division of an undirected graph into
+// communities, which measures how many more edges run within the communities
+// than would be expected in a random graph with the same vertex degrees. The
+// modularity ranges from -0.5 to 1, where higher values indicate a stronger
+// community structure:
+//
+//	q, _ := graph.Modularity(g, [][]string{{"A", "B"}, {"C", "D"}})
+//
+// Each vertex must be part of exactly one community. Edge weights aren't taken
+// into account.
+func Modularity[K comparable, T any](g Graph[K, T], communities [][]K) (float64, error) {
+	if g.Traits().IsDirected {
+		return 0, errors.New("modularity can only be computed for undirected graphs")
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	seen := make(map[K]struct{}, len(adjacencyMap))
+
+	for _, community := range communities {
+		for _, hash := range community {
+			if _, ok := adjacencyMap[hash]; !ok {
+				return 0, fmt.Errorf("community vertex %v: %w", hash, ErrVertexNotFound)
+			}
+			if _, ok := seen[hash]; ok {
+				return 0, fmt.Errorf("vertex %v is part of multiple communities", hash)
+			}
+			seen[hash] = struct{}{}
+		}
+	}
+
+	if len(seen) != len(adjacencyMap) {
+		return 0, fmt.Errorf("communities contain %d of %d vertices", len(seen), len(adjacencyMap))
+	}
+
+	return modularity(adjacencyMap, communities), nil
+}
+
+// modularity computes the modularity of the given communities, which must cover
+// all vertices of the undirected graph represented by the adjacency map.
+func modularity[K comparable](adjacencyMap map[K]map[K]Edge[K], communities [][]K) float64 {
+	communityOf := make(map[K]int, len(adjacencyMap))
+	for i, community := range communities {
+		for _, hash := range community {
+			communityOf[hash] = i
+		}
+	}
+
+	internal := make([]float64, len(communities))
+	degrees := make([]float64, len(communities))
+	edges := 0.0
+
+	for hash, adjacencies := range adjacencyMap {
+		c := communityOf[hash]
+
+		for adjacency := range adjacencies {
+			// Each edge is contained in both directions except for self-loops,
+			// which add 2 to the degree of their vertex.
+			if adjacency == hash {
+				degrees[c] += 2
+				internal[c]++
+				edges++
+				continue
+			}
+
+			degrees[c]++
+			edges += 0.5
+
+			if communityOf[adjacency] == c {
+				internal[c] += 0.5
+			}
+		}
+	}
+
+	if edges == 0 {
+		return 0
+	}
+
+	q := 0.0
+	for c := range communities {
+		share := degrees[c] / (2 * edges)
+		q += internal[c]/edges - share*share
+	}
+
+	return q
+}
+
+// neighborList is a vertex along with the edges to its neighbors, sorted by
+// the neighbor hashes.
+type neighborList[K comparable] struct {
+	hash  K
+	edges []Edge[K]
+}
+
+// sortedNeighbors turns an adjacency map into sorted neighbor lists, so that
+// the computations based on them don't depend on the order of the map. This
+// also keeps the floating-point sums of the edge betweenness deterministic.
+func sortedNeighbors[K comparable](adjacencyMap map[K]map[K]Edge[K]) []neighborList[K] {
+	hashes := make([]K, 0, len(adjacencyMap))
+	for hash := range adjacencyMap {
+		hashes = append(hashes, hash)
+	}
+
+	sortHashes(hashes)
+
+	lists := make([]neighborList[K], len(hashes))
+
+	for i, hash := range hashes {
+		edges := make([]Edge[K], 0, len(adjacencyMap[hash]))
+		for adjacency, edge := range adjacencyMap[hash] {
+			edge.Source, edge.Target = hash, adjacency
+			edges = append(edges, edge)
+		}
+
+		sort.Slice(edges, func(i, j int) bool {
+			return compareHashes(edges[i].Target, edges[j].Target) < 0
+		})
+
+		lists[i] = neighborList[K]{hash: hash, edges: edges}
+	}
+
+	return lists
+}
+
+// edgeBetweenness computes the edge betweenness using Brandes' algorithm. For
+// undirected graphs, the keys of the returned map are the vertex pairs with the
+// source sorted first.
+func edgeBetweenness[K comparable](ctx context.Context, neighbors []neighborList[K], isDirected bool, edgeWeight WeightFunc[K]) (map[vertexPair[K]]float64, error) {
+	edgesOf := make(map[K][]Edge[K], len(neighbors))
+	scores := make(map[vertexPair[K]]float64)
+
+	key := func(source, target K) vertexPair[K] {
+		if !isDirected && compareHashes(target, source) < 0 {
+			source, target = target, source
+		}
+		return vertexPair[K]{source: source, target: target}
+	}
+
+	for _, list := range neighbors {
+		edgesOf[list.hash] = list.edges
+		for _, edge := range list.edges {
+			if edge.Source != edge.Target {
+				scores[key(edge.Source, edge.Target)] = 0
+			}
+		}
+	}
+
+	for _, list := range neighbors {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		source := list.hash
+
+		// The vertices are settled in the order of their distance from the
+		// source, and their dependencies are accumulated in reverse order.
+		settled := make([]K, 0, len(neighbors))
+		isSettled := make(map[K]struct{}, len(neighbors))
+		predecessors := make(map[K][]K)
+		paths := map[K]float64{source: 1}
+		distances := map[K]float64{source: 0}
+
+		queue := newPriorityQueue[K]()
+		queue.Push(source, 0)
+
+		for queue.Len() > 0 {
+			vertex, _ := queue.Pop()
+
+			settled = append(settled, vertex)
+			isSettled[vertex] = struct{}{}
+
+			for _, edge := range edgesOf[vertex] {
+				adjacency := edge.Target
+
+				if _, ok := isSettled[adjacency]; ok {
+					continue
+				}
+
+				distance := distances[vertex] + edgeWeight(edge)
+				current, reached := distances[adjacency]
+
+				switch {
+				case !reached || distance < current:
+					distances[adjacency] = distance
+					paths[adjacency] = paths[vertex]
+					predecessors[adjacency] = []K{vertex}
+					if reached {
+						queue.UpdatePriority(adjacency, distance)
+					} else {
+						queue.Push(adjacency, distance)
+					}
+				case distance == current:
+					paths[adjacency] += paths[vertex]
+					predecessors[adjacency] = append(predecessors[adjacency], vertex)
+				}
+			}
+		}
+
+		dependencies := make(map[K]float64, len(settled))
+
+		for i := len(settled) - 1; i >= 0; i-- {
+			vertex := settled[i]
+
+			for _, predecessor := range predecessors[vertex] {
+				dependency := paths[predecessor] / paths[vertex] * (1 + dependencies[vertex])
+				scores[key(predecessor, vertex)] += dependency
+				dependencies[predecessor] += dependency
+			}
+		}
+	}
+
+	// In undirected graphs, the shortest paths between each vertex pair have
+	// been counted in both directions.
+	if !isDirected {
+		for pair := range scores {
+			scores[pair] /= 2
+		}
+	}
+
+	return scores, nil
+}
+
+// rankEdges sorts the edges by their betweenness in descending order.
+func rankEdges[K comparable](scores map[vertexPair[K]]float64) []EdgeCentrality[K] {
+	ranked := make([]EdgeCentrality[K], 0, len(scores))
+
+	for pair, score := range scores {
+		ranked = append(ranked, EdgeCentrality[K]{
+			Source:      pair.source,
+			Target:      pair.target,
+			Betweenness: score,
+		})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Betweenness != ranked[j].Betweenness {
+			return ranked[i].Betweenness > ranked[j].Betweenness
+		}
+		if c := compareHashes(ranked[i].Source, ranked[j].Source); c != 0 {
+			return c < 0
+		}
+		return compareHashes(ranked[i].Target, ranked[j].Target) < 0
+	})
+
+	return ranked
+}
+
+// components returns the connected components of an undirected graph given by
+// its sorted neighbor lists. Each component is sorted, and the components are
+// sorted by their first vertex.
+func components[K comparable](neighbors []neighborList[K]) [][]K {
+	hashes := make([]K, len(neighbors))
+	for i, list := range neighbors {
+		hashes[i] = list.hash
+	}
+
+	unions := newUnionFind(hashes...)
+
+	for _, list := range neighbors {
+		for _, edge := range list.edges {
+			unions.union(edge.Source, edge.Target)
+		}
+	}
+
+	indexes := make(map[K]int)
+	result := make([][]K, 0)
+
+	// The hashes are sorted, so each component is sorted as well, and the
+	// components are created in the order of their first vertex.
+	for _, hash := range hashes {
+		root := unions.find(hash)
+
+		i, ok := indexes[root]
+		if !ok {
+			i = len(result)
+			indexes[root] = i
+			result = append(result, nil)
+		}
+
+		result[i] = append(result[i], hash)
+	}
+
+	return result
+}
@@ -0,0 +1,198 @@
+package graph
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// bridgedTriangles creates two triangles A-B-C and D-E-F joined by the edge
+// C-D, which is the classic example of a graph with two communities.
+func bridgedTriangles() Graph[string, string] {
+	g := New(StringHash)
+
+	for _, vertex := range []string{"A", "B", "C", "D", "E", "F"} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge("A", "B")
+	_ = g.AddEdge("B", "C")
+	_ = g.AddEdge("C", "A")
+	_ = g.AddEdge("C", "D")
+	_ = g.AddEdge("D", "E")
+	_ = g.AddEdge("E", "F")
+	_ = g.AddEdge("F", "D")
+
+	return g
+}
+
+func TestEdgeBetweenness(t *testing.T) {
+	tests := map[string]struct {
+		graph    func() Graph[string, string]
+		expected []EdgeCentrality[string]
+	}{
+		"undirected graph": {
+			graph: bridgedTriangles,
+			expected: []EdgeCentrality[string]{
+				{Source: "C", Target: "D", Betweenness: 9},
+				{Source: "A", Target: "C", Betweenness: 4},
+				{Source: "B", Target: "C", Betweenness: 4},
+				{Source: "D", Target: "E", Betweenness: 4},
+				{Source: "D", Target: "F", Betweenness: 4},
+				{Source: "A", Target: "B", Betweenness: 1},
+				{Source: "E", Target: "F", Betweenness: 1},
+			},
+		},
+		"directed graph": {
+			graph: func() Graph[string, string] {
+				g := New(StringHash, Directed())
+				for _, vertex := range []string{"A", "B", "C"} {
+					_ = g.AddVertex(vertex)
+				}
+				_ = g.AddEdge("A", "B")
+				_ = g.AddEdge("B", "C")
+				_ = g.AddEdge("C", "C")
+				return g
+			},
+			expected: []EdgeCentrality[string]{
+				{Source: "A", Target: "B", Betweenness: 2},
+				{Source: "B", Target: "C", Betweenness: 2},
+			},
+		},
+		"multiple shortest paths": {
+			graph: func() Graph[string, string] {
+				g := New(StringHash)
+				for _, vertex := range []string{"A", "B", "C", "D"} {
+					_ = g.AddVertex(vertex)
+				}
+				_ = g.AddEdge("A", "B")
+				_ = g.AddEdge("B", "D")
+				_ = g.AddEdge("A", "C")
+				_ = g.AddEdge("C", "D")
+				return g
+			},
+			expected: []EdgeCentrality[string]{
+				{Source: "A", Target: "B", Betweenness: 2},
+				{Source: "A", Target: "C", Betweenness: 2},
+				{Source: "B", Target: "D", Betweenness: 2},
+				{Source: "C", Target: "D", Betweenness: 2},
+			},
+		},
+		"weighted graph": {
+			graph: func() Graph[string, string] {
+				g := New(StringHash, Weighted())
+				for _, vertex := range []string{"A", "B", "C"} {
+					_ = g.AddVertex(vertex)
+				}
+				_ = g.AddEdge("A", "B", EdgeWeight(1))
+				_ = g.AddEdge("B", "C", EdgeWeight(1))
+				_ = g.AddEdge("A", "C", EdgeWeight(5))
+				return g
+			},
+			expected: []EdgeCentrality[string]{
+				{Source: "A", Target: "B", Betweenness: 2},
+				{Source: "B", Target: "C", Betweenness: 2},
+				{Source: "A", Target: "C", Betweenness: 0},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		edges, err := EdgeBetweenness(test.graph())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if !reflect.DeepEqual(edges, test.expected) {
+			t.Errorf("%s: edge betweenness expectancy doesn't match: expected %v, got %v", name, test.expected, edges)
+		}
+	}
+}
+
+func TestGirvanNewman(t *testing.T) {
+	tests := map[string]struct {
+		k             int
+		expected      [][]string
+		expectedError bool
+	}{
+		"highest modularity": {
+			k:        0,
+			expected: [][]string{{"A", "B", "C"}, {"D", "E", "F"}},
+		},
+		"fixed number of communities": {
+			k:        3,
+			expected: [][]string{{"A"}, {"B", "C"}, {"D", "E", "F"}},
+		},
+		"single community": {
+			k:        1,
+			expected: [][]string{{"A", "B", "C", "D", "E", "F"}},
+		},
+		"too many communities": {
+			k:             7,
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		communities, err := GirvanNewman(bridgedTriangles(), test.k)
+
+		if test.expectedError != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.expectedError, err != nil, err)
+		}
+
+		if test.expectedError {
+			continue
+		}
+
+		if !reflect.DeepEqual(communities, test.expected) {
+			t.Errorf("%s: communities expectancy doesn't match: expected %v, got %v", name, test.expected, communities)
+		}
+	}
+}
+
+func TestModularity(t *testing.T) {
+	tests := map[string]struct {
+		communities   [][]string
+		expected      float64
+		expectedError bool
+	}{
+		"triangles": {
+			communities: [][]string{{"A", "B", "C"}, {"D", "E", "F"}},
+			expected:    6.0/7.0 - 0.5,
+		},
+		"single community": {
+			communities: [][]string{{"A", "B", "C", "D", "E", "F"}},
+			expected:    0,
+		},
+		"missing vertex": {
+			communities:   [][]string{{"A", "B", "C"}, {"D", "E"}},
+			expectedError: true,
+		},
+		"vertex in multiple communities": {
+			communities:   [][]string{{"A", "B", "C"}, {"C", "D", "E", "F"}},
+			expectedError: true,
+		},
+		"unknown vertex": {
+			communities:   [][]string{{"A", "B", "C"}, {"D", "E", "F", "G"}},
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		q, err := Modularity(bridgedTriangles(), test.communities)
+
+		if test.expectedError != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.expectedError, err != nil, err)
+		}
+
+		if math.Abs(q-test.expected) > 1e-9 {
+			t.Errorf("%s: modularity expectancy doesn't match: expected %v, got %v", name, test.expected, q)
+		}
+	}
+}
+
+func TestGirvanNewman_directed(t *testing.T) {
+	if _, err := GirvanNewman(New(StringHash, Directed()), 0); err == nil {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", true, false)
+	}
+}
@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// SetSubgraph nests a subgraph into the given vertex, turning it into a
+// compound vertex. Compound graphs model hierarchies such as systems of
+// systems, where a vertex can be expanded into a graph of its own:
+//
+//	system := graph.New(graph.StringHash, graph.Directed())
+//	_ = system.AddVertex("backend")
+//	_ = system.AddVertex("frontend")
+//	_ = system.AddEdge("frontend", "backend")
+//
+//	backend := graph.New(graph.StringHash, graph.Directed())
+//	_ = backend.AddVertex("api")
+//	_ = backend.AddVertex("db")
+//	_ = backend.AddEdge("api", "db")
+//
+//	_ = graph.SetSubgraph(system, "backend", backend)
+//
+// The subgraph remains an independent graph that can be modified and queried
+// as usual. Algorithms operate on a single level of the hierarchy, while
+// [WalkHierarchy] visits all levels, and the draw package renders subgraphs as
+// nested clusters. Passing a nil subgraph removes the subgraph from the vertex.
+//
+// The vertex must exist, and a graph can't be nested into itself, neither
+// directly nor through its subgraphs. When the vertex is removed, its subgraph
+// is removed as well. Just like hooks, subgraphs belong to the graph instance,
+// so clones of the graph don't inherit them.
+func SetSubgraph[K comparable, T any](g Graph[K, T], hash K, subgraph Graph[K, T]) error {
+	subgraphs, err := subgraphsOf(g)
+	if err != nil {
+		return err
+	}
+
+	if _, err := g.Vertex(hash); err != nil {
+		return fmt.Errorf("could not get vertex %v: %w", hash, err)
+	}
+
+	if subgraph == nil {
+		subgraphs.remove(hash)
+		return nil
+	}
+
+	if containsGraph(subgraph, subgraphs) {
+		return fmt.Errorf("nesting the subgraph into vertex %v would create a cycle in the hierarchy", hash)
+	}
+
+	// The hook removing the subgraph of a removed vertex is only registered
+	// once a subgraph is set, so that graphs without subgraphs don't pay for
+	// it.
+	subgraphs.registered.Do(func() {
+		_, err = registerHook(g, hookFuncs[K, T]{
+			removeVertex: subgraphs.remove,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register hooks: %w", err)
+	}
+
+	subgraphs.set(hash, subgraph)
+
+	return nil
+}
+
+// Subgraph returns the subgraph nested into the given vertex using
+// [SetSubgraph], or false if the vertex isn't a compound vertex.
+func Subgraph[K comparable, T any](g Graph[K, T], hash K) (Graph[K, T], bool) {
+	subgraphs, err := subgraphsOf(g)
+	if err != nil {
+		return nil, false
+	}
+
+	return subgraphs.get(hash)
+}
+
+// WalkHierarchy visits all vertices of a compound graph in depth-first order.
+// The vertices of each level are visited in the order described in
+// [SortedVertices], and the vertices of a subgraph are visited right after its
+// compound vertex. The visit function receives the hashes of the compound
+// vertices enclosing the vertex, starting with the outermost one, along with
+// the vertex hash. Returning true stops the walk:
+//
+//	_ = graph.WalkHierarchy(system, func(path []string, hash string) bool {
+//		fmt.Println(strings.Repeat("  ", len(path)) + hash)
+//		return false
+//	})
+//
+// The path slice is reused between calls and must be copied if it is retained.
+func WalkHierarchy[K comparable, T any](g Graph[K, T], visit func(path []K, hash K) bool) error {
+	_, err := walkHierarchy(g, make([]K, 0), visit)
+	return err
+}
+
+func walkHierarchy[K comparable, T any](g Graph[K, T], path []K, visit func([]K, K) bool) (bool, error) {
+	hashes, err := SortedVertices(g, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for _, hash := range hashes {
+		if visit(path, hash) {
+			return true, nil
+		}
+
+		subgraph, ok := Subgraph(g, hash)
+		if !ok {
+			continue
+		}
+
+		stopped, err := walkHierarchy(subgraph, append(path, hash), visit)
+		if err != nil {
+			return false, fmt.Errorf("failed to walk subgraph of %v: %w", hash, err)
+		}
+		if stopped {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// containsGraph reports whether the graph holding the target subgraphs is g
+// itself or nested somewhere in the hierarchy of g. The graphs are identified
+// by their subgraphs, which also works for graphs wrapped using Cached.
+func containsGraph[K comparable, T any](g Graph[K, T], target *subgraphs[K, T]) bool {
+	subgraphs, err := subgraphsOf(g)
+	if err != nil {
+		return false
+	}
+
+	if subgraphs == target {
+		return true
+	}
+
+	for _, subgraph := range subgraphs.all() {
+		if containsGraph(subgraph, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subgraphs holds the subgraphs nested into the vertices of a graph. The entry
+// of a vertex is removed by a hook once the vertex is removed.
+type subgraphs[K comparable, T any] struct {
+	lock       sync.RWMutex
+	registered sync.Once
+	byVertex   map[K]Graph[K, T]
+}
+
+func newSubgraphs[K comparable, T any]() *subgraphs[K, T] {
+	return &subgraphs[K, T]{
+		byVertex: make(map[K]Graph[K, T]),
+	}
+}
+
+func (s *subgraphs[K, T]) set(hash K, subgraph Graph[K, T]) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.byVertex[hash] = subgraph
+}
+
+func (s *subgraphs[K, T]) get(hash K) (Graph[K, T], bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	subgraph, ok := s.byVertex[hash]
+	return subgraph, ok
+}
+
+func (s *subgraphs[K, T]) remove(hash K) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.byVertex, hash)
+}
+
+func (s *subgraphs[K, T]) all() []Graph[K, T] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	all := make([]Graph[K, T], 0, len(s.byVertex))
+	for _, subgraph := range s.byVertex {
+		all = append(all, subgraph)
+	}
+
+	return all
+}
+
+func subgraphsOf[K comparable, T any](g Graph[K, T]) (*subgraphs[K, T], error) {
+	switch g := g.(type) {
+	case *directed[K, T]:
+		return g.subgraphs, nil
+	case *undirected[K, T]:
+		return g.subgraphs, nil
+	case *cachedGraph[K, T]:
+		return subgraphsOf(g.Graph)
+	default:
+		return nil, errors.New("graph doesn't support subgraphs")
+	}
+}
@@ -0,0 +1,158 @@
+package graph
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// compoundGraph creates the following hierarchy, where each level is a graph
+// of its own:
+//
+//	system: frontend -> backend
+//	  backend: api -> storage
+//	    storage: db
+func compoundGraph() (system, backend, storage Graph[string, string]) {
+	system = New(StringHash, Directed())
+	_ = system.AddVertex("frontend")
+	_ = system.AddVertex("backend")
+	_ = system.AddEdge("frontend", "backend")
+
+	backend = New(StringHash, Directed())
+	_ = backend.AddVertex("api")
+	_ = backend.AddVertex("storage")
+	_ = backend.AddEdge("api", "storage")
+
+	storage = New(StringHash, Directed())
+	_ = storage.AddVertex("db")
+
+	_ = SetSubgraph(backend, "storage", storage)
+	_ = SetSubgraph(system, "backend", backend)
+
+	return system, backend, storage
+}
+
+func TestSetSubgraph(t *testing.T) {
+	system, backend, storage := compoundGraph()
+	cachedSystem, _, _ := Cached(system)
+
+	tests := map[string]struct {
+		graph         Graph[string, string]
+		hash          string
+		subgraph      Graph[string, string]
+		expectedError bool
+	}{
+		"missing vertex": {
+			graph:         system,
+			hash:          "database",
+			subgraph:      New(StringHash),
+			expectedError: true,
+		},
+		"graph nested into itself": {
+			graph:         system,
+			hash:          "frontend",
+			subgraph:      system,
+			expectedError: true,
+		},
+		"graph nested into its subgraph": {
+			graph:         backend,
+			hash:          "api",
+			subgraph:      system,
+			expectedError: true,
+		},
+		"graph nested into a cached subgraph": {
+			graph:         storage,
+			hash:          "db",
+			subgraph:      cachedSystem,
+			expectedError: true,
+		},
+		"subgraph nested into another vertex": {
+			graph:    system,
+			hash:     "frontend",
+			subgraph: New(StringHash),
+		},
+	}
+
+	for name, test := range tests {
+		err := SetSubgraph(test.graph, test.hash, test.subgraph)
+
+		if test.expectedError != (err != nil) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.expectedError, err != nil, err)
+		}
+	}
+}
+
+func TestSubgraph(t *testing.T) {
+	system, backend, _ := compoundGraph()
+
+	subgraph, ok := Subgraph(system, "backend")
+	if !ok {
+		t.Fatalf("expected backend to have a subgraph")
+	}
+	if subgraph != backend {
+		t.Errorf("subgraph expectancy doesn't match: expected %v, got %v", backend, subgraph)
+	}
+
+	if _, ok := Subgraph(system, "frontend"); ok {
+		t.Errorf("expected frontend not to have a subgraph")
+	}
+
+	// Removing the subgraph using a nil graph.
+	if err := SetSubgraph(system, "backend", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := Subgraph(system, "backend"); ok {
+		t.Errorf("expected the subgraph of backend to be removed")
+	}
+
+	// Removing the subgraph by removing the compound vertex.
+	_ = SetSubgraph(system, "backend", backend)
+	_ = system.RemoveEdge("frontend", "backend")
+	if err := system.RemoveVertex("backend"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = system.AddVertex("backend")
+	if _, ok := Subgraph(system, "backend"); ok {
+		t.Errorf("expected the subgraph of the removed vertex to be removed")
+	}
+
+	// Clones don't inherit subgraphs.
+	_, backend, _ = compoundGraph()
+	clone, _ := backend.Clone()
+	if _, ok := Subgraph(clone, "storage"); ok {
+		t.Errorf("expected the clone not to have subgraphs")
+	}
+}
+
+func TestWalkHierarchy(t *testing.T) {
+	system, _, _ := compoundGraph()
+
+	tests := map[string]struct {
+		stopAt        string
+		expectedOrder []string
+	}{
+		"entire hierarchy": {
+			expectedOrder: []string{"backend", "backend/api", "backend/storage", "backend/storage/db", "frontend"},
+		},
+		"stop in subgraph": {
+			stopAt:        "storage",
+			expectedOrder: []string{"backend", "backend/api", "backend/storage"},
+		},
+	}
+
+	for name, test := range tests {
+		var order []string
+
+		err := WalkHierarchy(system, func(path []string, hash string) bool {
+			order = append(order, strings.Join(append(append([]string{}, path...), hash), "/"))
+			return hash == test.stopAt
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if !reflect.DeepEqual(order, test.expectedOrder) {
+			t.Errorf("%s: order expectancy doesn't match: expected %v, got %v", name, test.expectedOrder, order)
+		}
+	}
+}
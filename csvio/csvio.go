@@ -0,0 +1,263 @@
+// Package csvio reads and writes graphs as CSV edge lists, where each record
+// represents an edge. Edge lists are a common denominator for exchanging graph
+// data with spreadsheets and data analysis tools:
+//
+//	source,target,weight,label
+//	A,B,3,road
+//	B,C,5,rail
+//
+// Because edge lists only contain edges, vertices without edges aren't written
+// and can't be read.
+package csvio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/dominikbraun/graph"
+)
+
+type config struct {
+	comma        rune
+	header       bool
+	sourceColumn int
+	targetColumn int
+	weightColumn int
+	traits       []func(*graph.Traits)
+}
+
+func newConfig(options ...func(*config)) config {
+	c := config{
+		comma:        ',',
+		sourceColumn: 0,
+		targetColumn: 1,
+		weightColumn: -1,
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// Comma sets the field delimiter. The default is ','.
+func Comma(comma rune) func(*config) {
+	return func(c *config) {
+		c.comma = comma
+	}
+}
+
+// Header specifies that the first record is a header. When reading, all columns
+// except the source, target, and weight columns are read as edge attributes
+// named after their header. When writing, a header and the edge attributes are
+// written.
+func Header() func(*config) {
+	return func(c *config) {
+		c.header = true
+	}
+}
+
+// SourceColumn sets the index of the column containing the source vertex when
+// reading an edge list. The default is 0.
+func SourceColumn(index int) func(*config) {
+	return func(c *config) {
+		c.sourceColumn = index
+	}
+}
+
+// TargetColumn sets the index of the column containing the target vertex when
+// reading an edge list. The default is 1.
+func TargetColumn(index int) func(*config) {
+	return func(c *config) {
+		c.targetColumn = index
+	}
+}
+
+// WeightColumn sets the index of the column containing the edge weight when
+// reading an edge list. By default, edges are read without weights.
+func WeightColumn(index int) func(*config) {
+	return func(c *config) {
+		c.weightColumn = index
+	}
+}
+
+// GraphTraits sets the traits of the graph created by [ReadEdgeList], for
+// example graph.Directed().
+func GraphTraits(options ...func(*graph.Traits)) func(*config) {
+	return func(c *config) {
+		c.traits = append(c.traits, options...)
+	}
+}
+
+// ReadEdgeList reads a CSV edge list from r and creates a graph containing its
+// edges. The vertex values are the strings in the source and target columns,
+// and their hashes are computed using the given hash function:
+//
+//	g, _ := csvio.ReadEdgeList(file, graph.StringHash, csvio.Header(), csvio.WeightColumn(2))
+//
+// Each vertex is added when it occurs for the first time. An edge that occurs
+// twice results in an error.
+func ReadEdgeList[K comparable](r io.Reader, hash graph.Hash[K, string], options ...func(*config)) (graph.Graph[K, string], error) {
+	c := newConfig(options...)
+	g := graph.New(hash, c.traits...)
+
+	reader := csv.NewReader(r)
+	reader.Comma = c.comma
+	reader.TrimLeadingSpace = true
+
+	var header []string
+
+	if c.header {
+		record, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read header: %w", err)
+		}
+		header = record
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read record: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if err := addRecord(g, hash, c, header, record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	return g, nil
+}
+
+func addRecord[K comparable](g graph.Graph[K, string], hash graph.Hash[K, string], c config, header, record []string) error {
+	for _, column := range []int{c.sourceColumn, c.targetColumn, c.weightColumn} {
+		if column >= len(record) {
+			return fmt.Errorf("column %d doesn't exist", column)
+		}
+	}
+
+	source, target := record[c.sourceColumn], record[c.targetColumn]
+
+	for _, value := range []string{source, target} {
+		if err := g.AddVertex(value); err != nil && !errors.Is(err, graph.ErrVertexAlreadyExists) {
+			return fmt.Errorf("failed to add vertex %v: %w", value, err)
+		}
+	}
+
+	properties := []func(*graph.EdgeProperties){}
+
+	if c.weightColumn >= 0 {
+		weight, err := strconv.Atoi(record[c.weightColumn])
+		if err != nil {
+			return fmt.Errorf("invalid weight %q: %w", record[c.weightColumn], err)
+		}
+		properties = append(properties, graph.EdgeWeight(weight))
+	}
+
+	for i, name := range header {
+		if i == c.sourceColumn || i == c.targetColumn || i == c.weightColumn || i >= len(record) {
+			continue
+		}
+		properties = append(properties, graph.EdgeAttribute(name, record[i]))
+	}
+
+	if err := g.AddEdge(hash(source), hash(target), properties...); err != nil {
+		return fmt.Errorf("failed to add edge (%v, %v): %w", source, target, err)
+	}
+
+	return nil
+}
+
+// WriteEdgeList writes the edges of the given graph to w as CSV edge list. The
+// source and target columns contain the vertex hashes formatted using
+// fmt.Sprint, followed by a weight column for weighted graphs.
+//
+// If the [Header] option is passed, a header is written, and each edge
+// attribute that occurs in the graph is written to its own column, ordered by
+// name. Edges that don't have an attribute have an empty value in its column.
+//
+// The edges are ordered by their source and target hashes, so that writing the
+// same graph always produces the same output.
+func WriteEdgeList[K comparable, T any](g graph.Graph[K, T], w io.Writer, options ...func(*config)) error {
+	c := newConfig(options...)
+
+	edges, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		si, sj := fmt.Sprint(edges[i].Source), fmt.Sprint(edges[j].Source)
+		if si != sj {
+			return si < sj
+		}
+		return fmt.Sprint(edges[i].Target) < fmt.Sprint(edges[j].Target)
+	})
+
+	writer := csv.NewWriter(w)
+	writer.Comma = c.comma
+
+	isWeighted := g.Traits().IsWeighted
+
+	var attributes []string
+
+	if c.header {
+		attributes = attributeNames(edges)
+
+		header := []string{"source", "target"}
+		if isWeighted {
+			header = append(header, "weight")
+		}
+		header = append(header, attributes...)
+
+		if err := writer.Write(header); err != nil {
+			return fmt.Errorf("failed to write header: %w", err)
+		}
+	}
+
+	for _, edge := range edges {
+		record := []string{fmt.Sprint(edge.Source), fmt.Sprint(edge.Target)}
+		if isWeighted {
+			record = append(record, strconv.Itoa(edge.Properties.Weight))
+		}
+		for _, name := range attributes {
+			record = append(record, edge.Properties.Attributes[name])
+		}
+
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func attributeNames[K comparable](edges []graph.Edge[K]) []string {
+	seen := make(map[string]struct{})
+	names := make([]string, 0)
+
+	for _, edge := range edges {
+		for name := range edge.Properties.Attributes {
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,135 @@
+package csvio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestReadEdgeList(t *testing.T) {
+	tests := map[string]struct {
+		input         string
+		options       []func(*config)
+		expectedOrder int
+		expectedSize  int
+		expectedError bool
+	}{
+		"plain edge list": {
+			input:         "A,B\nB,C\n",
+			expectedOrder: 3,
+			expectedSize:  2,
+		},
+		"header, weights, and attributes": {
+			input:         "label,from,to,weight\nroad,A,B,3\nrail,B,C,5\n",
+			options:       []func(*config){Header(), SourceColumn(1), TargetColumn(2), WeightColumn(3)},
+			expectedOrder: 3,
+			expectedSize:  2,
+		},
+		"custom delimiter": {
+			input:         "A;B\nA;C\n",
+			options:       []func(*config){Comma(';')},
+			expectedOrder: 3,
+			expectedSize:  2,
+		},
+		"invalid weight": {
+			input:         "A,B,x\n",
+			options:       []func(*config){WeightColumn(2)},
+			expectedError: true,
+		},
+		"missing column": {
+			input:         "A,B\n",
+			options:       []func(*config){WeightColumn(2)},
+			expectedError: true,
+		},
+		"duplicate edge": {
+			input:         "A,B\nA,B\n",
+			options:       []func(*config){GraphTraits(graph.Directed())},
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		g, err := ReadEdgeList(strings.NewReader(test.input), graph.StringHash, test.options...)
+
+		if test.expectedError != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedError, err)
+		}
+
+		if test.expectedError {
+			continue
+		}
+
+		if order, _ := g.Order(); order != test.expectedOrder {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, test.expectedOrder, order)
+		}
+
+		if size, _ := g.Size(); size != test.expectedSize {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, test.expectedSize, size)
+		}
+	}
+}
+
+func TestReadEdgeList_properties(t *testing.T) {
+	input := "from,to,weight,label\nA,B,3,road\n"
+
+	g, err := ReadEdgeList(strings.NewReader(input), graph.StringHash, Header(), WeightColumn(2), GraphTraits(graph.Directed()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	edge, err := g.Edge("A", "B")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if edge.Properties.Weight != 3 {
+		t.Errorf("weight doesn't match: expected %v, got %v", 3, edge.Properties.Weight)
+	}
+
+	if len(edge.Properties.Attributes) != 1 || edge.Properties.Attributes["label"] != "road" {
+		t.Errorf("attributes don't match: expected %v, got %v", map[string]string{"label": "road"}, edge.Properties.Attributes)
+	}
+}
+
+func TestWriteEdgeList(t *testing.T) {
+	tests := map[string]struct {
+		graph    graph.Graph[string, string]
+		options  []func(*config)
+		expected string
+	}{
+		"directed graph": {
+			graph:    graph.New(graph.StringHash, graph.Directed()),
+			expected: "A,B\nB,C\n",
+		},
+		"weighted graph with header": {
+			graph:    graph.New(graph.StringHash, graph.Directed(), graph.Weighted()),
+			options:  []func(*config){Header()},
+			expected: "source,target,weight,color,label\nA,B,3,,road\nB,C,0,red,\n",
+		},
+		"custom delimiter": {
+			graph:    graph.New(graph.StringHash, graph.Directed()),
+			options:  []func(*config){Comma(';')},
+			expected: "A;B\nB;C\n",
+		},
+	}
+
+	for name, test := range tests {
+		_ = test.graph.AddVertex("A")
+		_ = test.graph.AddVertex("B")
+		_ = test.graph.AddVertex("C")
+		_ = test.graph.AddEdge("A", "B", graph.EdgeWeight(3), graph.EdgeAttribute("label", "road"))
+		_ = test.graph.AddEdge("B", "C", graph.EdgeAttribute("color", "red"))
+
+		var buf bytes.Buffer
+
+		if err := WriteEdgeList(test.graph, &buf, test.options...); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if buf.String() != test.expected {
+			t.Errorf("%s: output doesn't match: expected %q, got %q", name, test.expected, buf.String())
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
@@ -18,142 +19,181 @@ import (
 // TopologicalSort only works for directed acyclic graphs. This implementation
 // works non-recursively and utilizes Kahn's algorithm.
 func TopologicalSort[K comparable, T any](g Graph[K, T]) ([]K, error) {
+	return topologicalSort(context.Background(), g, nil)
+}
+
+// TopologicalSortCtx works just as [TopologicalSort], but accepts a context that
+// is checked before visiting each vertex. Once the context is cancelled or its
+// deadline is exceeded, TopologicalSortCtx returns the context's error.
+func TopologicalSortCtx[K comparable, T any](ctx context.Context, g Graph[K, T]) ([]K, error) {
+	return topologicalSort(ctx, g, nil)
+}
+
+// StableTopologicalSort does the same as [TopologicalSort], but takes a function
+// for comparing (and then ordering) two given vertices. This allows for a stable
+// and deterministic output even for graphs with multiple topological orderings.
+func StableTopologicalSort[K comparable, T any](g Graph[K, T], less func(K, K) bool) ([]K, error) {
+	return topologicalSort(context.Background(), g, less)
+}
+
+// StableTopologicalSortCtx works just as [StableTopologicalSort], but accepts a
+// context that is checked before visiting each vertex.
+func StableTopologicalSortCtx[K comparable, T any](ctx context.Context, g Graph[K, T], less func(K, K) bool) ([]K, error) {
+	return topologicalSort(ctx, g, less)
+}
+
+// topologicalSort implements Kahn's algorithm for TopologicalSort and
+// StableTopologicalSort. Instead of building the adjacency map and predecessor
+// map of the graph, it counts the ingoing edges of each vertex and queries the
+// successors of the visited vertices from the store. If less is not nil, the
+// initial vertices and the vertices released by each visited vertex are sorted
+// using less.
+func topologicalSort[K comparable, T any](ctx context.Context, g Graph[K, T], less func(K, K) bool) ([]K, error) {
 	if !g.Traits().IsDirected {
 		return nil, fmt.Errorf("topological sort cannot be computed on undirected graph")
 	}
 
-	gOrder, err := g.Order()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get graph order: %w", err)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	adjacencyMap, err := g.AdjacencyMap()
+	inDegrees, err := inDegrees(g)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
+		return nil, err
 	}
 
-	predecessorMap, err := g.PredecessorMap()
+	successors, err := successorsFunc(g)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get predecessor map: %w", err)
+		return nil, err
+	}
+
+	sortVertices := func(vertices []K) {
+		if less != nil {
+			sort.Slice(vertices, func(i, j int) bool {
+				return less(vertices[i], vertices[j])
+			})
+		}
 	}
 
 	queue := make([]K, 0)
 
-	for vertex, predecessors := range predecessorMap {
-		if len(predecessors) == 0 {
+	for vertex, inDegree := range inDegrees {
+		if inDegree == 0 {
 			queue = append(queue, vertex)
-			delete(predecessorMap, vertex)
 		}
 	}
 
-	order := make([]K, 0, gOrder)
+	sortVertices(queue)
+
+	order := make([]K, 0, len(inDegrees))
 
 	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		currentVertex := queue[0]
 		queue = queue[1:]
 
 		order = append(order, currentVertex)
 
-		edgeMap := adjacencyMap[currentVertex]
-
-		for target := range edgeMap {
-
-			predecessors := predecessorMap[target]
-			delete(predecessors, currentVertex)
+		frontier := make([]K, 0)
 
-			if len(predecessors) == 0 {
-				queue = append(queue, target)
-				delete(predecessorMap, target)
+		err := successors(currentVertex, func(target K, _ Edge[K]) {
+			inDegrees[target]--
+			if inDegrees[target] == 0 {
+				frontier = append(frontier, target)
 			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get successors of %v: %w", currentVertex, err)
 		}
+
+		sortVertices(frontier)
+
+		queue = append(queue, frontier...)
 	}
 
-	if len(order) != gOrder {
+	if len(order) != len(inDegrees) {
 		return nil, errors.New("topological sort cannot be computed on graph with cycles")
 	}
 
 	return order, nil
 }
 
-// StableTopologicalSort does the same as [TopologicalSort], but takes a function
-// for comparing (and then ordering) two given vertices. This allows for a stable
-// and deterministic output even for graphs with multiple topological orderings.
-func StableTopologicalSort[K comparable, T any](g Graph[K, T], less func(K, K) bool) ([]K, error) {
-	if !g.Traits().IsDirected {
-		return nil, fmt.Errorf("topological sort cannot be computed on undirected graph")
-	}
+// inDegrees returns the number of ingoing edges for each vertex. If possible,
+// they are counted by iterating over the edges of the store, so that only a
+// single integer per vertex is allocated.
+func inDegrees[K comparable, T any](g Graph[K, T]) (map[K]int, error) {
+	// Cached graphs provide their predecessor map without copying it.
+	if c, ok := g.(*cachedGraph[K, T]); ok {
+		predecessorMap, err := c.sharedPredecessorMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get predecessor map: %w", err)
+		}
 
-	gOrder, err := g.Order()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get graph order: %w", err)
+		return countPredecessors(predecessorMap), nil
 	}
 
-	adjacencyMap, err := g.AdjacencyMap()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
-	}
+	store, ok := storeOf(g)
+	if !ok {
+		predecessorMap, err := g.PredecessorMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get predecessor map: %w", err)
+		}
 
-	predecessorMap, err := g.PredecessorMap()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get predecessor map: %w", err)
+		return countPredecessors(predecessorMap), nil
 	}
 
-	queue := make([]K, 0)
+	inDegrees := make(map[K]int)
 
-	for vertex, predecessors := range predecessorMap {
-		if len(predecessors) == 0 {
-			queue = append(queue, vertex)
-			delete(predecessorMap, vertex)
-		}
+	err := iterVertexHashes(store, func(hash K) bool {
+		inDegrees[hash] = 0
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vertices: %w", err)
 	}
 
-	order := make([]K, 0, gOrder)
-
-	sort.Slice(queue, func(i, j int) bool {
-		return less(queue[i], queue[j])
+	err = iterEdges(store, func(edge Edge[K]) bool {
+		inDegrees[edge.Target]++
+		return true
 	})
-
-	for len(queue) > 0 {
-		currentVertex := queue[0]
-		queue = queue[1:]
-
-		order = append(order, currentVertex)
-
-		frontier := make([]K, 0)
-
-		edgeMap := adjacencyMap[currentVertex]
-
-		for target := range edgeMap {
-
-			predecessors := predecessorMap[target]
-			delete(predecessors, currentVertex)
-
-			if len(predecessors) == 0 {
-				frontier = append(frontier, target)
-				delete(predecessorMap, target)
-			}
-		}
-
-		sort.Slice(frontier, func(i, j int) bool {
-			return less(frontier[i], frontier[j])
-		})
-
-		queue = append(queue, frontier...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edges: %w", err)
 	}
 
-	if len(order) != gOrder {
-		return nil, errors.New("topological sort cannot be computed on graph with cycles")
+	return inDegrees, nil
+}
+
+func countPredecessors[K comparable](predecessorMap map[K]map[K]Edge[K]) map[K]int {
+	inDegrees := make(map[K]int, len(predecessorMap))
+	for vertex, predecessors := range predecessorMap {
+		inDegrees[vertex] = len(predecessors)
 	}
 
-	return order, nil
+	return inDegrees
 }
 
 // TransitiveReduction returns a new graph with the same vertices and the same
 // reachability as the given graph, but with as few edges as possible. The graph
 // must be a directed acyclic graph.
 //
-// TransitiveReduction is a very expensive operation scaling with O(V(V+E)).
-func TransitiveReduction[K comparable, T any](g Graph[K, T]) (Graph[K, T], error) {
+// TransitiveReduction is a very expensive operation scaling with O(V(V+E)). To
+// bound the work done by a single call, limits like MaxVisited and Timeout can
+// be passed. If one of them is exceeded, an error wrapping ErrLimitExceeded is
+// returned.
+func TransitiveReduction[K comparable, T any](g Graph[K, T], limits ...func(*Limits)) (Graph[K, T], error) {
+	return TransitiveReductionCtx(context.Background(), g, limits...)
+}
+
+// TransitiveReductionCtx works just as [TransitiveReduction], but accepts a
+// context that is checked before visiting each vertex. Once the context is
+// cancelled or its deadline is exceeded, TransitiveReductionCtx returns the
+// context's error.
+func TransitiveReductionCtx[K comparable, T any](ctx context.Context, g Graph[K, T], limits ...func(*Limits)) (Graph[K, T], error) {
+	guard := newGuard(limits)
+
 	if !g.Traits().IsDirected {
 		return nil, fmt.Errorf("transitive reduction cannot be performed on undirected graph")
 	}
@@ -174,15 +214,22 @@ func TransitiveReduction[K comparable, T any](g Graph[K, T]) (Graph[K, T], error
 	// set of the top-level vertex and target the current vertex. These edges
 	// are redundant because their targets apparently are not only reachable
 	// from the top-level vertex, but also through a DFS.
-	for vertex, successors := range adjacencyMap {
-		tOrder, err := transitiveReduction.Order()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get graph order: %w", err)
-		}
+	//
+	// The stack and the visited set are shared by all of these searches and
+	// only reset before each search.
+	stack := getStack[K]()
+	defer putStack(stack)
 
+	visited := getMap[K, struct{}]()
+	defer putMap(visited)
+
+	for vertex, successors := range adjacencyMap {
 		for successor := range successors {
-			stack := newStack[K]()
-			visited := make(map[K]struct{}, tOrder)
+			stack.reset()
+
+			for hash := range visited {
+				delete(visited, hash)
+			}
 
 			stack.push(successor)
 
@@ -193,6 +240,14 @@ func TransitiveReduction[K comparable, T any](g Graph[K, T]) (Graph[K, T], error
 					continue
 				}
 
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+
+				if err := guard.visit(); err != nil {
+					return nil, err
+				}
+
 				visited[current] = struct{}{}
 				stack.push(current)
 
@@ -1,6 +1,8 @@
 package graph
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -479,3 +481,88 @@ func buildGraph[K comparable, T any](g *Graph[K, T], vertices []T, edges []Edge[
 
 	return nil
 }
+
+func TestTopologicalSort_withoutStore(t *testing.T) {
+	g := New(IntHash, Directed())
+
+	for i := 1; i <= 4; i++ {
+		_ = g.AddVertex(i)
+	}
+	_ = g.AddEdge(1, 2)
+	_ = g.AddEdge(1, 3)
+	_ = g.AddEdge(3, 2)
+	_ = g.AddEdge(2, 4)
+
+	// uncachableGraph hides the store, so that the maps of the graph are used.
+	order, err := StableTopologicalSort[int, int](uncachableGraph{Graph: g}, func(a, b int) bool {
+		return a < b
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []int{1, 3, 2, 4}
+
+	if !slicesAreEqual(order, expected) {
+		t.Errorf("order doesn't match: expected %v, got %v", expected, order)
+	}
+
+	_ = g.AddEdge(4, 1)
+
+	if _, err := TopologicalSort[int, int](uncachableGraph{Graph: g}); err == nil {
+		t.Error("error expectancy doesn't match: expected error for graph with cycle, got nil")
+	}
+}
+
+func TestTopologicalSortCtx(t *testing.T) {
+	tests := map[string]struct {
+		ctx           context.Context
+		expectedOrder []int
+		expectedErr   error
+	}{
+		"no cancellation": {
+			ctx:           context.Background(),
+			expectedOrder: []int{1, 2, 3, 4},
+		},
+		"cancelled before the sort": {
+			ctx:         newCountdownContext(0),
+			expectedErr: context.Canceled,
+		},
+		"cancelled during the sort": {
+			ctx:         newCountdownContext(2),
+			expectedErr: context.Canceled,
+		},
+	}
+
+	for name, test := range tests {
+		graph := New(IntHash, Directed())
+
+		for _, vertex := range []int{1, 2, 3, 4} {
+			_ = graph.AddVertex(vertex)
+		}
+
+		_ = graph.AddEdge(1, 2)
+		_ = graph.AddEdge(2, 3)
+		_ = graph.AddEdge(3, 4)
+
+		order, err := StableTopologicalSortCtx(test.ctx, graph, func(a, b int) bool {
+			return a < b
+		})
+
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+		}
+
+		if !slicesAreEqual(order, test.expectedOrder) {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, test.expectedOrder, order)
+		}
+	}
+}
+
+func TestTransitiveReductionCtx(t *testing.T) {
+	_, err := TransitiveReductionCtx(newCountdownContext(5), completeDAG(6))
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,168 @@
+// Package dagrun provides a helper for executing the vertices of a directed
+// acyclic graph concurrently. Each vertex is executed as soon as all of its
+// predecessors have finished successfully, which makes dagrun suitable for
+// building task runners on top of dependency graphs.
+package dagrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+)
+
+// Options configures how [Run] executes the vertices. Instead of populating
+// Options directly, use functional options like MaxParallel.
+type Options struct {
+	// MaxParallel is the maximum number of workers running at the same time.
+	// If it is zero or negative, there is no such limit.
+	MaxParallel int
+}
+
+type result[K comparable] struct {
+	hash K
+	err  error
+}
+
+// Run executes the given worker function for each vertex in the graph. A vertex
+// is executed as soon as all of its predecessors have been executed without an
+// error, so that an edge (A,B) means that B depends on A. The graph has to be a
+// directed acyclic graph.
+//
+// The following example builds a graph where B and C depend on A and D depends
+// on both B and C. B and C will run in parallel once A has finished:
+//
+//	g := graph.New(graph.StringHash, graph.Directed(), graph.Acyclic())
+//
+//	_ = g.AddVertex("A")
+//	_ = g.AddVertex("B")
+//	_ = g.AddVertex("C")
+//	_ = g.AddVertex("D")
+//
+//	_ = g.AddEdge("A", "B")
+//	_ = g.AddEdge("A", "C")
+//	_ = g.AddEdge("B", "D")
+//	_ = g.AddEdge("C", "D")
+//
+//	err := dagrun.Run(ctx, g, func(ctx context.Context, task string) error {
+//		fmt.Println("running", task)
+//		return nil
+//	}, dagrun.MaxParallel(4))
+//
+// If a worker returns an error, the context passed to all other workers will be
+// cancelled and no further vertices will be executed. Run waits for all running
+// workers to return and then returns the first error. The same applies if the
+// given context is cancelled, in which case the context's error is returned.
+func Run[K comparable, T any](ctx context.Context, g graph.Graph[K, T], worker func(context.Context, K) error, options ...func(*Options)) error {
+	if !g.Traits().IsDirected {
+		return errors.New("vertices can only be executed in directed graphs")
+	}
+
+	var opts Options
+
+	for _, option := range options {
+		option(&opts)
+	}
+
+	// A topological sort is only possible for acyclic graphs, so this check
+	// makes sure that the graph doesn't contain any cycles before executing
+	// any vertex. Otherwise, the vertices in a cycle would wait forever.
+	if _, err := graph.TopologicalSort(g); err != nil {
+		return fmt.Errorf("failed to verify the graph is acyclic: %w", err)
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	predecessorMap, err := g.PredecessorMap()
+	if err != nil {
+		return fmt.Errorf("failed to get predecessor map: %w", err)
+	}
+
+	// pending stores the number of unfinished predecessors for each vertex. A
+	// vertex is ready to be executed once this number has dropped to zero.
+	pending := make(map[K]int, len(predecessorMap))
+	ready := make([]K, 0)
+
+	for vertex, predecessors := range predecessorMap {
+		pending[vertex] = len(predecessors)
+		if len(predecessors) == 0 {
+			ready = append(ready, vertex)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan result[K])
+	running := 0
+	finished := 0
+
+	var firstErr error
+
+	for {
+		for firstErr == nil && len(ready) > 0 && (opts.MaxParallel <= 0 || running < opts.MaxParallel) {
+			if err := ctx.Err(); err != nil {
+				firstErr = err
+				break
+			}
+
+			hash := ready[0]
+			ready = ready[1:]
+			running++
+
+			go func(hash K) {
+				results <- result[K]{
+					hash: hash,
+					err:  worker(ctx, hash),
+				}
+			}(hash)
+		}
+
+		if running == 0 {
+			break
+		}
+
+		res := <-results
+		running--
+
+		if res.err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to execute vertex %v: %w", res.hash, res.err)
+				cancel()
+			}
+			continue
+		}
+
+		finished++
+
+		for successor := range adjacencyMap[res.hash] {
+			pending[successor]--
+			if pending[successor] == 0 {
+				ready = append(ready, successor)
+			}
+		}
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if finished != len(predecessorMap) {
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// MaxParallel is a functional option for [Run] that limits the number of workers
+// running at the same time to n. By default, or if n is zero or negative, there
+// is no such limit and all vertices that are ready will be executed at once.
+func MaxParallel(n int) func(*Options) {
+	return func(o *Options) {
+		o.MaxParallel = n
+	}
+}
@@ -0,0 +1,224 @@
+package dagrun
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		vertices    []string
+		edges       []graph.Edge[string]
+		maxParallel int
+	}{
+		"diamond": {
+			vertices: []string{"A", "B", "C", "D"},
+			edges: []graph.Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "A", Target: "C"},
+				{Source: "B", Target: "D"},
+				{Source: "C", Target: "D"},
+			},
+		},
+		"diamond with sequential execution": {
+			vertices: []string{"A", "B", "C", "D"},
+			edges: []graph.Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "A", Target: "C"},
+				{Source: "B", Target: "D"},
+				{Source: "C", Target: "D"},
+			},
+			maxParallel: 1,
+		},
+		"disconnected graph": {
+			vertices: []string{"A", "B", "C", "D", "E"},
+			edges: []graph.Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "C", Target: "D"},
+			},
+			maxParallel: 2,
+		},
+	}
+
+	for name, test := range tests {
+		g := graph.New(graph.StringHash, graph.Directed(), graph.Acyclic())
+
+		for _, vertex := range test.vertices {
+			_ = g.AddVertex(vertex)
+		}
+
+		for _, edge := range test.edges {
+			if err := g.AddEdge(edge.Source, edge.Target); err != nil {
+				t.Fatalf("%s: failed to add edge: %s", name, err.Error())
+			}
+		}
+
+		var (
+			lock     sync.Mutex
+			done     = make(map[string]bool)
+			running  int32
+			maxSeen  int32
+			failures []string
+		)
+
+		worker := func(_ context.Context, vertex string) error {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+
+			lock.Lock()
+			if current > maxSeen {
+				maxSeen = current
+			}
+			for _, edge := range test.edges {
+				if edge.Target == vertex && !done[edge.Source] {
+					failures = append(failures, vertex)
+				}
+			}
+			lock.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			lock.Lock()
+			done[vertex] = true
+			lock.Unlock()
+
+			return nil
+		}
+
+		options := []func(*Options){MaxParallel(test.maxParallel)}
+
+		if err := Run(context.Background(), g, worker, options...); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if len(failures) > 0 {
+			t.Errorf("%s: vertices executed before their predecessors: %v", name, failures)
+		}
+
+		if len(done) != len(test.vertices) {
+			t.Errorf("%s: number of executed vertices doesn't match: expected %v, got %v", name, len(test.vertices), len(done))
+		}
+
+		if test.maxParallel > 0 && int(maxSeen) > test.maxParallel {
+			t.Errorf("%s: parallelism exceeded: expected at most %v, got %v", name, test.maxParallel, maxSeen)
+		}
+	}
+}
+
+func TestRun_failure(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	for _, vertex := range []string{"A", "B", "C"} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge("A", "B")
+	_ = g.AddEdge("B", "C")
+
+	errFailed := errors.New("failed")
+
+	var lock sync.Mutex
+	executed := make(map[string]bool)
+
+	err := Run(context.Background(), g, func(_ context.Context, vertex string) error {
+		lock.Lock()
+		executed[vertex] = true
+		lock.Unlock()
+
+		if vertex == "B" {
+			return errFailed
+		}
+		return nil
+	})
+
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("error expectancy doesn't match: expected %v, got %v", errFailed, err)
+	}
+
+	if executed["C"] {
+		t.Errorf("expected vertex C not to be executed after B failed")
+	}
+}
+
+func TestRun_cancellation(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	for _, vertex := range []string{"A", "B"} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge("A", "B")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var executedB bool
+
+	err := Run(ctx, g, func(_ context.Context, vertex string) error {
+		if vertex == "A" {
+			cancel()
+		}
+		if vertex == "B" {
+			executedB = true
+		}
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error expectancy doesn't match: expected %v, got %v", context.Canceled, err)
+	}
+
+	if executedB {
+		t.Errorf("expected vertex B not to be executed after cancellation")
+	}
+}
+
+func TestRun_invalidGraphs(t *testing.T) {
+	tests := map[string]struct {
+		graph graph.Graph[string, string]
+		edges []graph.Edge[string]
+	}{
+		"undirected graph": {
+			graph: graph.New(graph.StringHash),
+		},
+		"directed graph with cycle": {
+			graph: graph.New(graph.StringHash, graph.Directed()),
+			edges: []graph.Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "B", Target: "A"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		_ = test.graph.AddVertex("A")
+		_ = test.graph.AddVertex("B")
+
+		for _, edge := range test.edges {
+			if err := test.graph.AddEdge(edge.Source, edge.Target); err != nil {
+				t.Fatalf("%s: failed to add edge: %s", name, err.Error())
+			}
+		}
+
+		var executed int32
+
+		err := Run(context.Background(), test.graph, func(context.Context, string) error {
+			atomic.AddInt32(&executed, 1)
+			return nil
+		})
+
+		if err == nil {
+			t.Errorf("%s: expected error, got none", name)
+		}
+
+		if executed != 0 {
+			t.Errorf("%s: expected no vertices to be executed, got %v", name, executed)
+		}
+	}
+}
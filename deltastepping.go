@@ -0,0 +1,228 @@
+package graph
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// ShortestPathTree contains the shortest paths from a source vertex to all
+// vertices reachable from it, as computed by [DeltaStepping].
+type ShortestPathTree[K comparable] struct {
+	// Source is the hash of the source vertex.
+	Source K
+	// Distances maps each reachable vertex to the sum of the edge weights of
+	// the shortest path from the source. For unweighted graphs, each edge has
+	// a weight of 1.
+	Distances map[K]int
+	// Predecessors maps each reachable vertex except for the source to its
+	// predecessor on the shortest path from the source.
+	Predecessors map[K]K
+}
+
+// PathTo returns the shortest path from the source to the given target vertex,
+// including both vertices. If the target is not reachable from the source,
+// ErrTargetNotReachable is returned.
+func (t ShortestPathTree[K]) PathTo(target K) ([]K, error) {
+	if _, ok := t.Distances[target]; !ok {
+		return nil, ErrTargetNotReachable
+	}
+
+	path := []K{target}
+
+	for current := target; current != t.Source; {
+		current = t.Predecessors[current]
+		path = append(path, current)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path, nil
+}
+
+// deltaRequest is a request to relax the distance of a vertex, which is created
+// by the workers of DeltaStepping and applied sequentially afterwards.
+type deltaRequest[K comparable] struct {
+	target      K
+	distance    int
+	predecessor K
+}
+
+// DeltaStepping computes the shortest paths from the source vertex to all
+// reachable vertices using the parallel delta-stepping algorithm by Meyer and
+// Sanders. The vertices are put into buckets of width delta by their tentative
+// distance, and the edges of all vertices in the current bucket are relaxed by
+// the given number of worker goroutines in parallel. This makes DeltaStepping
+// suitable for large, sparse graphs such as road networks:
+//
+//	tree, _ := graph.DeltaStepping(g, "berlin", 1000, 0)
+//	path, _ := tree.PathTo("munich")
+//
+// A good choice for delta is in the magnitude of the average edge weight: A
+// small delta results in many buckets with few vertices, a large delta results
+// in vertices being relaxed multiple times. If workers is 0, GOMAXPROCS workers
+// are used. Edge weights must not be negative.
+//
+// The store of the graph has to support concurrent reads, which is the case for
+// the default in-memory store. If there are multiple shortest paths to a vertex,
+// an arbitrary one is returned.
+func DeltaStepping[K comparable, T any](g Graph[K, T], source K, delta, workers int) (ShortestPathTree[K], error) {
+	tree := ShortestPathTree[K]{
+		Source:       source,
+		Distances:    map[K]int{source: 0},
+		Predecessors: make(map[K]K),
+	}
+
+	if delta <= 0 {
+		return tree, fmt.Errorf("delta must be positive, got %d", delta)
+	}
+
+	if workers < 0 {
+		return tree, fmt.Errorf("number of workers must not be negative, got %d", workers)
+	}
+
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if _, err := g.Vertex(source); err != nil {
+		return tree, fmt.Errorf("could not get source vertex: %w", err)
+	}
+
+	successorsOf, err := successorsFunc(g)
+	if err != nil {
+		return tree, err
+	}
+
+	isWeighted := g.Traits().IsWeighted
+
+	buckets := map[int]map[K]struct{}{0: {source: {}}}
+
+	relax := func(requests []deltaRequest[K]) {
+		for _, request := range requests {
+			current, ok := tree.Distances[request.target]
+			if ok && request.distance >= current {
+				continue
+			}
+			if ok {
+				delete(buckets[current/delta], request.target)
+			}
+			tree.Distances[request.target] = request.distance
+			tree.Predecessors[request.target] = request.predecessor
+
+			index := request.distance / delta
+			if buckets[index] == nil {
+				buckets[index] = make(map[K]struct{})
+			}
+			buckets[index][request.target] = struct{}{}
+		}
+	}
+
+	// requests relaxes the light or heavy edges of the given vertices in
+	// parallel and returns the resulting requests. The distances are only read
+	// by the workers, since they are only modified by relax.
+	requests := func(vertices []K, light bool) ([]deltaRequest[K], error) {
+		results := make([][]deltaRequest[K], workers)
+		errs := make([]error, workers)
+
+		var wg sync.WaitGroup
+
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+
+				for i := w; i < len(vertices); i += workers {
+					vertex := vertices[i]
+					distance := tree.Distances[vertex]
+
+					err := successorsOf(vertex, func(adjacency K, edge Edge[K]) {
+						weight := edge.Properties.Weight
+						if !isWeighted {
+							weight = 1
+						}
+						if weight < 0 {
+							errs[w] = fmt.Errorf("edge (%v, %v) has negative weight %d", vertex, adjacency, weight)
+							return
+						}
+						if (weight <= delta) != light {
+							return
+						}
+						results[w] = append(results[w], deltaRequest[K]{
+							target:      adjacency,
+							distance:    distance + weight,
+							predecessor: vertex,
+						})
+					})
+					if err != nil {
+						errs[w] = fmt.Errorf("could not get successors of %v: %w", vertex, err)
+					}
+					if errs[w] != nil {
+						return
+					}
+				}
+			}(w)
+		}
+
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var all []deltaRequest[K]
+		for _, result := range results {
+			all = append(all, result...)
+		}
+
+		return all, nil
+	}
+
+	for len(buckets) > 0 {
+		index := -1
+		for i := range buckets {
+			if index < 0 || i < index {
+				index = i
+			}
+		}
+
+		// settled contains all vertices that have been removed from the
+		// current bucket. Their heavy edges are relaxed once the bucket
+		// remains empty.
+		settled := make(map[K]struct{})
+
+		for bucket := buckets[index]; len(bucket) > 0; bucket = buckets[index] {
+			vertices := make([]K, 0, len(bucket))
+			for vertex := range bucket {
+				vertices = append(vertices, vertex)
+				settled[vertex] = struct{}{}
+			}
+			buckets[index] = make(map[K]struct{})
+
+			lightRequests, err := requests(vertices, true)
+			if err != nil {
+				return tree, err
+			}
+			relax(lightRequests)
+		}
+
+		delete(buckets, index)
+
+		vertices := make([]K, 0, len(settled))
+		for vertex := range settled {
+			vertices = append(vertices, vertex)
+		}
+
+		heavyRequests, err := requests(vertices, false)
+		if err != nil {
+			return tree, err
+		}
+		relax(heavyRequests)
+	}
+
+	return tree, nil
+}
@@ -0,0 +1,174 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestDeltaStepping(t *testing.T) {
+	tests := map[string]struct {
+		traits     []func(*Traits)
+		edges      []Edge[string]
+		source     string
+		delta      int
+		workers    int
+		expected   map[string]int
+		shouldFail bool
+	}{
+		"weighted directed graph": {
+			traits: []func(*Traits){Directed(), Weighted()},
+			edges: []Edge[string]{
+				{Source: "A", Target: "B", Properties: EdgeProperties{Weight: 10}},
+				{Source: "A", Target: "C", Properties: EdgeProperties{Weight: 3}},
+				{Source: "C", Target: "B", Properties: EdgeProperties{Weight: 4}},
+				{Source: "B", Target: "D", Properties: EdgeProperties{Weight: 2}},
+				{Source: "E", Target: "A", Properties: EdgeProperties{Weight: 1}},
+			},
+			source:   "A",
+			delta:    3,
+			workers:  2,
+			expected: map[string]int{"A": 0, "B": 7, "C": 3, "D": 9},
+		},
+		"unweighted undirected graph": {
+			edges: []Edge[string]{
+				{Source: "A", Target: "B", Properties: EdgeProperties{Weight: 10}},
+				{Source: "B", Target: "C"},
+				{Source: "E", Target: "A"},
+			},
+			source:   "A",
+			delta:    1,
+			expected: map[string]int{"A": 0, "B": 1, "C": 2, "E": 1},
+		},
+		"negative weight": {
+			traits: []func(*Traits){Directed(), Weighted()},
+			edges: []Edge[string]{
+				{Source: "A", Target: "B", Properties: EdgeProperties{Weight: -1}},
+			},
+			source:     "A",
+			delta:      1,
+			shouldFail: true,
+		},
+		"invalid delta": {
+			source:     "A",
+			delta:      0,
+			shouldFail: true,
+		},
+		"unknown source": {
+			source:     "X",
+			delta:      1,
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		g := New(StringHash, test.traits...)
+
+		for _, vertex := range []string{"A", "B", "C", "D", "E"} {
+			_ = g.AddVertex(vertex)
+		}
+		for _, edge := range test.edges {
+			_ = g.AddEdge(edge.Source, edge.Target, EdgeWeight(edge.Properties.Weight))
+		}
+
+		tree, err := DeltaStepping(g, test.source, test.delta, test.workers)
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		if test.shouldFail {
+			continue
+		}
+
+		if len(tree.Distances) != len(test.expected) {
+			t.Errorf("%s: number of distances doesn't match: expected %v, got %v", name, len(test.expected), tree.Distances)
+		}
+
+		for vertex, expected := range test.expected {
+			if tree.Distances[vertex] != expected {
+				t.Errorf("%s: distance of %v doesn't match: expected %v, got %v", name, vertex, expected, tree.Distances[vertex])
+			}
+		}
+	}
+}
+
+func TestDeltaStepping_randomGraphs(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 20; i++ {
+		g := New(IntHash, Directed(), Weighted())
+
+		for v := 0; v < 200; v++ {
+			_ = g.AddVertex(v)
+		}
+		for e := 0; e < 800; e++ {
+			_ = g.AddEdge(random.Intn(200), random.Intn(200), EdgeWeight(random.Intn(20)))
+		}
+
+		tree, err := DeltaStepping(g, 0, 1+random.Intn(30), 1+random.Intn(4))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for target := 0; target < 200; target++ {
+			path, err := ShortestPath(g, 0, target)
+			if errors.Is(err, ErrTargetNotReachable) {
+				if _, ok := tree.Distances[target]; ok {
+					t.Errorf("vertex %v should not be reachable", target)
+				}
+				continue
+			}
+
+			expected := pathWeight(t, g, path)
+
+			if tree.Distances[target] != expected {
+				t.Errorf("distance of %v doesn't match: expected %v, got %v", target, expected, tree.Distances[target])
+			}
+
+			treePath, err := tree.PathTo(target)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if weight := pathWeight(t, g, treePath); weight != expected {
+				t.Errorf("weight of path to %v doesn't match: expected %v, got %v", target, expected, weight)
+			}
+		}
+	}
+}
+
+func TestShortestPathTree_PathTo(t *testing.T) {
+	tree := ShortestPathTree[string]{
+		Source:       "A",
+		Distances:    map[string]int{"A": 0, "B": 1, "C": 2},
+		Predecessors: map[string]string{"B": "A", "C": "B"},
+	}
+
+	path, err := tree.PathTo("C")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := []string{"A", "B", "C"}; !slicesAreEqual(path, expected) {
+		t.Errorf("path doesn't match: expected %v, got %v", expected, path)
+	}
+
+	if _, err := tree.PathTo("D"); !errors.Is(err, ErrTargetNotReachable) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrTargetNotReachable, err)
+	}
+}
+
+func pathWeight(t *testing.T, g Graph[int, int], path []int) int {
+	weight := 0
+
+	for i := 1; i < len(path); i++ {
+		edge, err := g.Edge(path[i-1], path[i])
+		if err != nil {
+			t.Fatalf("failed to get edge: %v", err)
+		}
+		weight += edge.Properties.Weight
+	}
+
+	return weight
+}
@@ -9,13 +9,20 @@ type directed[K comparable, T any] struct {
 	hash   Hash[K, T]
 	traits *Traits
 	store  Store[K, T]
+	hooks  *hooks[K, T]
+
+	indexes   *vertexIndexes[K, T]
+	subgraphs *subgraphs[K, T]
 }
 
 func newDirected[K comparable, T any](hash Hash[K, T], traits *Traits, store Store[K, T]) *directed[K, T] {
 	return &directed[K, T]{
-		hash:   hash,
-		traits: traits,
-		store:  store,
+		hash:      hash,
+		traits:    traits,
+		store:     store,
+		hooks:     newHooks[K, T](),
+		indexes:   newVertexIndexes[K, T](),
+		subgraphs: newSubgraphs[K, T](),
 	}
 }
 
@@ -34,7 +41,13 @@ func (d *directed[K, T]) AddVertex(value T, options ...func(*VertexProperties))
 		option(&properties)
 	}
 
-	return d.store.AddVertex(hash, value, properties)
+	if err := d.store.AddVertex(hash, value, properties); err != nil {
+		return vertexError(hash, err)
+	}
+
+	d.hooks.vertexAdded(hash, value, properties)
+
+	return nil
 }
 
 func (d *directed[K, T]) AddVerticesFrom(g Graph[K, T]) error {
@@ -59,45 +72,51 @@ func (d *directed[K, T]) AddVerticesFrom(g Graph[K, T]) error {
 
 func (d *directed[K, T]) Vertex(hash K) (T, error) {
 	vertex, _, err := d.store.Vertex(hash)
-	return vertex, err
+	return vertex, vertexError(hash, err)
 }
 
 func (d *directed[K, T]) VertexWithProperties(hash K) (T, VertexProperties, error) {
 	vertex, properties, err := d.store.Vertex(hash)
 	if err != nil {
-		return vertex, VertexProperties{}, err
+		return vertex, VertexProperties{}, vertexError(hash, err)
 	}
 
 	return vertex, properties, nil
 }
 
 func (d *directed[K, T]) RemoveVertex(hash K) error {
-	return d.store.RemoveVertex(hash)
+	if err := d.store.RemoveVertex(hash); err != nil {
+		return vertexError(hash, err)
+	}
+
+	d.hooks.vertexRemoved(hash)
+
+	return nil
 }
 
 func (d *directed[K, T]) AddEdge(sourceHash, targetHash K, options ...func(*EdgeProperties)) error {
 	_, _, err := d.store.Vertex(sourceHash)
 	if err != nil {
-		return fmt.Errorf("source vertex %v: %w", sourceHash, err)
+		return fmt.Errorf("source vertex %v: %w", sourceHash, vertexError(sourceHash, err))
 	}
 
 	_, _, err = d.store.Vertex(targetHash)
 	if err != nil {
-		return fmt.Errorf("target vertex %v: %w", targetHash, err)
+		return fmt.Errorf("target vertex %v: %w", targetHash, vertexError(targetHash, err))
 	}
 
 	if _, err := d.Edge(sourceHash, targetHash); !errors.Is(err, ErrEdgeNotFound) {
-		return ErrEdgeAlreadyExists
+		return &EdgeAlreadyExistsError[K]{Source: sourceHash, Target: targetHash}
 	}
 
 	// If the user opted in to preventing cycles, run a cycle check.
 	if d.traits.PreventCycles {
-		createsCycle, err := d.createsCycle(sourceHash, targetHash)
+		createsCycle, err := CreatesCycle[K, T](d, sourceHash, targetHash)
 		if err != nil {
 			return fmt.Errorf("check for cycles: %w", err)
 		}
 		if createsCycle {
-			return ErrEdgeCreatesCycle
+			return &EdgeCreatesCycleError[K]{Source: sourceHash, Target: targetHash}
 		}
 	}
 
@@ -113,7 +132,13 @@ func (d *directed[K, T]) AddEdge(sourceHash, targetHash K, options ...func(*Edge
 		option(&edge.Properties)
 	}
 
-	return d.addEdge(sourceHash, targetHash, edge)
+	if err := d.addEdge(sourceHash, targetHash, edge); err != nil {
+		return edgeError(sourceHash, targetHash, err)
+	}
+
+	d.hooks.edgeAdded(edge)
+
+	return nil
 }
 
 func (d *directed[K, T]) AddEdgesFrom(g Graph[K, T]) error {
@@ -122,6 +147,26 @@ func (d *directed[K, T]) AddEdgesFrom(g Graph[K, T]) error {
 		return fmt.Errorf("failed to get edges: %w", err)
 	}
 
+	// If the store supports adding all edges at once, validate them upfront
+	// and add them in bulk. This isn't possible if cycles have to be prevented,
+	// because each edge could create a cycle with the edges added before.
+	if bulkStore, ok := d.store.(BulkStore[K]); ok && !d.traits.PreventCycles {
+		bulk, err := bulkEdges(d.store, edges, false)
+		if err != nil {
+			return err
+		}
+
+		if err := bulkStore.AddEdges(bulk); err != nil {
+			return fmt.Errorf("failed to add edges: %w", err)
+		}
+
+		for _, edge := range bulk {
+			d.hooks.edgeAdded(edge)
+		}
+
+		return nil
+	}
+
 	for _, edge := range edges {
 		if err := d.AddEdge(copyEdge(edge)); err != nil {
 			return fmt.Errorf("failed to add (%v, %v): %w", edge.Source, edge.Target, err)
@@ -131,20 +176,54 @@ func (d *directed[K, T]) AddEdgesFrom(g Graph[K, T]) error {
 	return nil
 }
 
+// addEdgesTrusted adds copies of the given edges without validating them, which
+// makes copying the edges of another graph considerably faster. The caller has
+// to guarantee that
+//
+//   - the source and target vertices of all edges exist,
+//   - none of the edges exists yet or appears twice in the given edges, and
+//   - the edges don't create a cycle if the graph prevents cycles.
+//
+// This is the case when copying the edges of a graph with the same traits into
+// a graph that contains the same vertices but no edges, as Clone does, or into
+// a graph whose vertices are disjoint from the vertices of the copied graph, as
+// Union does.
+func (d *directed[K, T]) addEdgesTrusted(edges []Edge[K]) error {
+	copied := trustedEdges(edges, false)
+
+	if bulkStore, ok := d.store.(BulkStore[K]); ok {
+		if err := bulkStore.AddEdges(copied); err != nil {
+			return fmt.Errorf("failed to add edges: %w", err)
+		}
+	} else {
+		for _, edge := range copied {
+			if err := d.addEdge(edge.Source, edge.Target, edge); err != nil {
+				return fmt.Errorf("failed to add (%v, %v): %w", edge.Source, edge.Target, err)
+			}
+		}
+	}
+
+	for _, edge := range copied {
+		d.hooks.edgeAdded(edge)
+	}
+
+	return nil
+}
+
 func (d *directed[K, T]) Edge(sourceHash, targetHash K) (Edge[T], error) {
 	edge, err := d.store.Edge(sourceHash, targetHash)
 	if err != nil {
-		return Edge[T]{}, err
+		return Edge[T]{}, edgeError(sourceHash, targetHash, err)
 	}
 
 	sourceVertex, _, err := d.store.Vertex(sourceHash)
 	if err != nil {
-		return Edge[T]{}, err
+		return Edge[T]{}, vertexError(sourceHash, err)
 	}
 
 	targetVertex, _, err := d.store.Vertex(targetHash)
 	if err != nil {
-		return Edge[T]{}, err
+		return Edge[T]{}, vertexError(targetHash, err)
 	}
 
 	return Edge[T]{
@@ -159,20 +238,40 @@ func (d *directed[K, T]) Edge(sourceHash, targetHash K) (Edge[T], error) {
 }
 
 func (d *directed[K, T]) Edges() ([]Edge[K], error) {
-	return d.store.ListEdges()
+	if _, ok := d.store.(EdgeIterator[K]); !ok {
+		return d.store.ListEdges()
+	}
+
+	edges := make([]Edge[K], 0)
+
+	err := iterEdges(d.store, func(edge Edge[K]) bool {
+		edges = append(edges, edge)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	return edges, nil
 }
 
 func (d *directed[K, T]) UpdateEdge(source, target K, options ...func(properties *EdgeProperties)) error {
 	existingEdge, err := d.store.Edge(source, target)
 	if err != nil {
-		return err
+		return edgeError(source, target, err)
 	}
 
 	for _, option := range options {
 		option(&existingEdge.Properties)
 	}
 
-	return d.store.UpdateEdge(source, target, existingEdge)
+	if err := d.store.UpdateEdge(source, target, existingEdge); err != nil {
+		return edgeError(source, target, err)
+	}
+
+	d.hooks.edgeUpdated(existingEdge)
+
+	return nil
 }
 
 func (d *directed[K, T]) RemoveEdge(source, target K) error {
@@ -184,55 +283,53 @@ func (d *directed[K, T]) RemoveEdge(source, target K) error {
 		return fmt.Errorf("failed to remove edge from %v to %v: %w", source, target, err)
 	}
 
+	d.hooks.edgeRemoved(source, target)
+
 	return nil
 }
 
 func (d *directed[K, T]) AdjacencyMap() (map[K]map[K]Edge[K], error) {
-	vertices, err := d.store.ListVertices()
+	m := make(map[K]map[K]Edge[K])
+
+	err := iterVertexHashes(d.store, func(hash K) bool {
+		m[hash] = make(map[K]Edge[K])
+		return true
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list vertices: %w", err)
 	}
 
-	edges, err := d.store.ListEdges()
+	err = iterEdges(d.store, func(edge Edge[K]) bool {
+		m[edge.Source][edge.Target] = edge
+		return true
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list edges: %w", err)
 	}
 
-	m := make(map[K]map[K]Edge[K], len(vertices))
-
-	for _, vertex := range vertices {
-		m[vertex] = make(map[K]Edge[K])
-	}
-
-	for _, edge := range edges {
-		m[edge.Source][edge.Target] = edge
-	}
-
 	return m, nil
 }
 
 func (d *directed[K, T]) PredecessorMap() (map[K]map[K]Edge[K], error) {
-	vertices, err := d.store.ListVertices()
-	if err != nil {
-		return nil, fmt.Errorf("failed to list vertices: %w", err)
-	}
+	m := make(map[K]map[K]Edge[K])
 
-	edges, err := d.store.ListEdges()
+	err := iterVertexHashes(d.store, func(hash K) bool {
+		m[hash] = make(map[K]Edge[K])
+		return true
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list edges: %w", err)
-	}
-
-	m := make(map[K]map[K]Edge[K], len(vertices))
-
-	for _, vertex := range vertices {
-		m[vertex] = make(map[K]Edge[K])
+		return nil, fmt.Errorf("failed to list vertices: %w", err)
 	}
 
-	for _, edge := range edges {
+	err = iterEdges(d.store, func(edge Edge[K]) bool {
 		if _, ok := m[edge.Target]; !ok {
 			m[edge.Target] = make(map[K]Edge[K])
 		}
 		m[edge.Target][edge.Source] = edge
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edges: %w", err)
 	}
 
 	return m, nil
@@ -252,16 +349,31 @@ func (d *directed[K, T]) Clone() (Graph[K, T], error) {
 	}
 
 	clone := &directed[K, T]{
-		hash:   d.hash,
-		traits: traits,
-		store:  newMemoryStore[K, T](),
+		hash:      d.hash,
+		traits:    traits,
+		store:     newMemoryStore[K, T](),
+		hooks:     newHooks[K, T](),
+		indexes:   newVertexIndexes[K, T](),
+		subgraphs: newSubgraphs[K, T](),
+	}
+
+	if store, ok := d.store.(*memoryStore[K, T]); ok {
+		clone.store = store.fork()
+		return clone, nil
 	}
 
 	if err := clone.AddVerticesFrom(d); err != nil {
 		return nil, fmt.Errorf("failed to add vertices: %w", err)
 	}
 
-	if err := clone.AddEdgesFrom(d); err != nil {
+	// The clone has the same traits and vertices as d but no edges yet, so the
+	// edges of d can be added without validating them.
+	edges, err := d.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	if err := clone.addEdgesTrusted(edges); err != nil {
 		return nil, fmt.Errorf("failed to add edges: %w", err)
 	}
 
@@ -285,18 +397,6 @@ func (d *directed[K, T]) edgesAreEqual(a, b Edge[T]) bool {
 	return aSourceHash == bSourceHash && aTargetHash == bTargetHash
 }
 
-func (d *directed[K, T]) createsCycle(source, target K) (bool, error) {
-	// If the underlying store implements CreatesCycle, use that fast path.
-	if cc, ok := d.store.(interface {
-		CreatesCycle(source, target K) (bool, error)
-	}); ok {
-		return cc.CreatesCycle(source, target)
-	}
-
-	// Slow path.
-	return CreatesCycle(Graph[K, T](d), source, target)
-}
-
 // copyEdge returns an argument list suitable for the Graph.AddEdge method. This
 // argument list is derived from the given edge, hence the name copyEdge.
 //
@@ -909,7 +909,7 @@ func TestDirected_RemoveEdge(t *testing.T) {
 			}
 			// After removing the edge, verify that it can't be retrieved using
 			// Edge anymore.
-			if _, err := graph.Edge(removeEdge.Source, removeEdge.Target); err != ErrEdgeNotFound {
+			if _, err := graph.Edge(removeEdge.Source, removeEdge.Target); !errors.Is(err, ErrEdgeNotFound) {
 				t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrEdgeNotFound, err)
 			}
 		}
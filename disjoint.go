@@ -0,0 +1,326 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DisjointShortestPaths computes k paths from source to target that don't share
+// an edge and whose total weight is minimal, e.g. for routing traffic over
+// redundant links that don't fail together:
+//
+//	paths, _ := graph.DisjointShortestPaths(network, "A", "F", 2)
+//
+// The paths can't be determined by calling ShortestPath repeatedly and removing
+// the edges of each path, because the shortest path may block the only way to
+// find a second path. Instead, the algorithm by Suurballe and Tarjan is used:
+// each further path may traverse edges of the previous paths backwards, which
+// reroutes these paths. This takes O(k * (V+E) * log(V)) time.
+//
+// The paths are sorted by their weight. Just as with ShortestPath, the edges of
+// unweighted graphs have a weight of 1, and a [WeightFunc] can be passed using
+// WeightedBy. Negative weights aren't supported. If there are fewer than k
+// edge-disjoint paths, the paths that have been found are returned along with
+// an error wrapping ErrTargetNotReachable.
+func DisjointShortestPaths[K comparable, T any](g Graph[K, T], source, target K, k int, options ...func(*WeightOptions[K])) ([][]K, error) {
+	return DisjointShortestPathsCtx(context.Background(), g, source, target, k, options...)
+}
+
+// DisjointShortestPathsCtx works just as [DisjointShortestPaths], but accepts a
+// context that is checked before visiting each vertex. Once the context is
+// cancelled or its deadline is exceeded, the context's error is returned.
+func DisjointShortestPathsCtx[K comparable, T any](ctx context.Context, g Graph[K, T], source, target K, k int, options ...func(*WeightOptions[K])) ([][]K, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("number of paths must be positive, got %d", k)
+	}
+
+	if source == target {
+		return nil, errors.New("source and target must be different vertices")
+	}
+
+	if _, err := g.Vertex(source); err != nil {
+		return nil, fmt.Errorf("could not get source vertex: %w", err)
+	}
+
+	if _, err := g.Vertex(target); err != nil {
+		return nil, fmt.Errorf("could not get target vertex: %w", err)
+	}
+
+	network, err := newFlowNetwork(g, pathWeightFunc(g, options))
+	if err != nil {
+		return nil, err
+	}
+
+	found := 0
+
+	for ; found < k; found++ {
+		augmented, err := network.augment(ctx, source, target)
+		if err != nil {
+			return nil, err
+		}
+		if !augmented {
+			break
+		}
+	}
+
+	paths := network.paths(source, target, g.Traits().IsDirected)
+
+	if found < k {
+		return paths, fmt.Errorf("found %d of %d edge-disjoint paths: %w", found, k, ErrTargetNotReachable)
+	}
+
+	return paths, nil
+}
+
+// flowArc is an arc of the residual network used by DisjointShortestPaths.
+// Each edge of the graph is represented by an arc with a capacity of 1 and a
+// residual arc in the opposite direction, which can be traversed once the edge
+// is used by a path and has a negative cost.
+type flowArc[K comparable] struct {
+	target   K
+	cost     float64
+	capacity int
+	// reverse is the index of the opposite arc in the arcs of the target.
+	reverse  int
+	residual bool
+}
+
+// flowPredecessor is the vertex from which a vertex has been reached, along
+// with the index of the arc that has been traversed.
+type flowPredecessor[K comparable] struct {
+	hash K
+	arc  int
+}
+
+type weightedPath[K comparable] struct {
+	hashes []K
+	weight float64
+}
+
+type flowNetwork[K comparable] struct {
+	arcs map[K][]flowArc[K]
+	// potentials make the reduced cost of each arc with a capacity left
+	// non-negative, so that Dijkstra's algorithm can be used even though
+	// residual arcs have negative costs.
+	potentials map[K]float64
+}
+
+func newFlowNetwork[K comparable, T any](g Graph[K, T], edgeWeight WeightFunc[K]) (*flowNetwork[K], error) {
+	hashes, err := vertexHashes(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vertices: %w", err)
+	}
+
+	successorsOf, err := successorsFunc(g)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &flowNetwork[K]{
+		arcs:       make(map[K][]flowArc[K], len(hashes)),
+		potentials: make(map[K]float64, len(hashes)),
+	}
+
+	for _, hash := range hashes {
+		// The successors are collected first, so that the weight function
+		// may access the graph.
+		successors, err := collectNeighbors(successorsOf, hash)
+		if err != nil {
+			return nil, fmt.Errorf("could not get successors of %v: %w", hash, err)
+		}
+
+		for _, successor := range successors {
+			neighbor, edge := successor.hash, successor.edge
+
+			// Self-loops are never part of a shortest path.
+			if neighbor == hash {
+				continue
+			}
+
+			weight := edgeWeight(edge)
+			if weight < 0 {
+				return nil, fmt.Errorf("edge (%v, %v) has a negative weight", edge.Source, edge.Target)
+			}
+
+			n.arcs[hash] = append(n.arcs[hash], flowArc[K]{
+				target:   neighbor,
+				cost:     weight,
+				capacity: 1,
+				reverse:  len(n.arcs[neighbor]),
+			})
+			n.arcs[neighbor] = append(n.arcs[neighbor], flowArc[K]{
+				target:   hash,
+				cost:     -weight,
+				reverse:  len(n.arcs[hash]) - 1,
+				residual: true,
+			})
+		}
+	}
+
+	return n, nil
+}
+
+// augment finds the cheapest path from source to target in the residual network
+// and sends one unit of flow along it. It returns false if there's no path.
+func (n *flowNetwork[K]) augment(ctx context.Context, source, target K) (bool, error) {
+	distances := map[K]float64{source: 0}
+	predecessors := make(map[K]flowPredecessor[K])
+	settled := make(map[K]struct{})
+
+	queue := newPriorityQueue[K]()
+	queue.Push(source, 0)
+
+	for queue.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		vertex, _ := queue.Pop()
+		settled[vertex] = struct{}{}
+
+		for i, arc := range n.arcs[vertex] {
+			if arc.capacity == 0 {
+				continue
+			}
+			if _, ok := settled[arc.target]; ok {
+				continue
+			}
+
+			// Rounding errors may make a reduced cost slightly negative.
+			reducedCost := arc.cost + n.potentials[vertex] - n.potentials[arc.target]
+			if reducedCost < 0 {
+				reducedCost = 0
+			}
+
+			distance := distances[vertex] + reducedCost
+
+			switch current, reached := distances[arc.target]; {
+			case !reached:
+				distances[arc.target] = distance
+				predecessors[arc.target] = flowPredecessor[K]{hash: vertex, arc: i}
+				queue.Push(arc.target, distance)
+			case distance < current:
+				distances[arc.target] = distance
+				predecessors[arc.target] = flowPredecessor[K]{hash: vertex, arc: i}
+				queue.UpdatePriority(arc.target, distance)
+			}
+		}
+	}
+
+	if _, ok := distances[target]; !ok {
+		return false, nil
+	}
+
+	// Vertices that haven't been reached can't be reached in later iterations
+	// either, since new residual arcs only join vertices on the path.
+	for hash, distance := range distances {
+		n.potentials[hash] += distance
+	}
+
+	for current := target; current != source; {
+		p := predecessors[current]
+		arc := &n.arcs[p.hash][p.arc]
+
+		arc.capacity--
+		n.arcs[current][arc.reverse].capacity++
+
+		current = p.hash
+	}
+
+	return true, nil
+}
+
+// paths decomposes the flow into paths from source to target.
+func (n *flowNetwork[K]) paths(source, target K, isDirected bool) [][]K {
+	used := make(map[K]map[K]struct{})
+
+	for hash, arcs := range n.arcs {
+		for _, arc := range arcs {
+			if arc.residual || arc.capacity > 0 {
+				continue
+			}
+			if _, ok := used[hash]; !ok {
+				used[hash] = make(map[K]struct{})
+			}
+			used[hash][arc.target] = struct{}{}
+		}
+	}
+
+	// An undirected edge used in both directions by different paths cancels
+	// out, which can only happen for edges with a weight of 0.
+	if !isDirected {
+		for hash, targets := range used {
+			for neighbor := range targets {
+				if _, ok := used[neighbor][hash]; ok {
+					delete(used[hash], neighbor)
+					delete(used[neighbor], hash)
+				}
+			}
+		}
+	}
+
+	paths := make([]weightedPath[K], 0)
+
+	for len(used[source]) > 0 {
+		path := weightedPath[K]{hashes: []K{source}}
+		positions := map[K]int{source: 0}
+
+		for current := source; current != target; {
+			var next K
+			for neighbor := range used[current] {
+				next = neighbor
+				break
+			}
+
+			delete(used[current], next)
+
+			// Cycles of edges with a weight of 0 may be part of the flow, and
+			// are removed from the path.
+			if position, ok := positions[next]; ok {
+				for _, hash := range path.hashes[position+1:] {
+					delete(positions, hash)
+				}
+				path.hashes = path.hashes[:position+1]
+			} else {
+				positions[next] = len(path.hashes)
+				path.hashes = append(path.hashes, next)
+			}
+
+			current = next
+		}
+
+		paths = append(paths, path)
+	}
+
+	for i := range paths {
+		for j := 1; j < len(paths[i].hashes); j++ {
+			paths[i].weight += n.cost(paths[i].hashes[j-1], paths[i].hashes[j])
+		}
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		if paths[i].weight != paths[j].weight {
+			return paths[i].weight < paths[j].weight
+		}
+		return len(paths[i].hashes) < len(paths[j].hashes)
+	})
+
+	result := make([][]K, len(paths))
+	for i, path := range paths {
+		result[i] = path.hashes
+	}
+
+	return result
+}
+
+func (n *flowNetwork[K]) cost(source, target K) float64 {
+	for _, arc := range n.arcs[source] {
+		if arc.target == target && !arc.residual {
+			return arc.cost
+		}
+	}
+
+	return 0
+}
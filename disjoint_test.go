@@ -0,0 +1,207 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// trapGraph creates a graph whose shortest path A-B-C-F blocks the only way to
+// find a second path if its edges were removed.
+func trapGraph(options ...func(*Traits)) Graph[string, string] {
+	g := New(StringHash, append(options, Weighted())...)
+
+	for _, vertex := range []string{"A", "B", "C", "D", "E", "F"} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge("A", "B", EdgeWeight(1))
+	_ = g.AddEdge("B", "C", EdgeWeight(1))
+	_ = g.AddEdge("C", "F", EdgeWeight(1))
+	_ = g.AddEdge("A", "D", EdgeWeight(2))
+	_ = g.AddEdge("D", "C", EdgeWeight(3))
+	_ = g.AddEdge("B", "E", EdgeWeight(2))
+	_ = g.AddEdge("E", "F", EdgeWeight(2))
+
+	return g
+}
+
+func TestDisjointShortestPaths(t *testing.T) {
+	tests := map[string]struct {
+		graph         Graph[string, string]
+		source        string
+		target        string
+		k             int
+		expectedPaths [][]string
+		expectedErr   error
+		expectedError bool
+	}{
+		"single path": {
+			graph:         trapGraph(Directed()),
+			source:        "A",
+			target:        "F",
+			k:             1,
+			expectedPaths: [][]string{{"A", "B", "C", "F"}},
+		},
+		"rerouted shortest path in directed graph": {
+			graph:         trapGraph(Directed()),
+			source:        "A",
+			target:        "F",
+			k:             2,
+			expectedPaths: [][]string{{"A", "B", "E", "F"}, {"A", "D", "C", "F"}},
+		},
+		"rerouted shortest path in undirected graph": {
+			graph:         trapGraph(),
+			source:        "A",
+			target:        "F",
+			k:             2,
+			expectedPaths: [][]string{{"A", "B", "E", "F"}, {"A", "D", "C", "F"}},
+		},
+		"fewer paths than requested": {
+			graph:         trapGraph(Directed()),
+			source:        "A",
+			target:        "F",
+			k:             3,
+			expectedPaths: [][]string{{"A", "B", "E", "F"}, {"A", "D", "C", "F"}},
+			expectedErr:   ErrTargetNotReachable,
+		},
+		"unreachable target": {
+			graph:         trapGraph(Directed()),
+			source:        "F",
+			target:        "A",
+			k:             1,
+			expectedPaths: [][]string{},
+			expectedErr:   ErrTargetNotReachable,
+		},
+		"invalid number of paths": {
+			graph:         trapGraph(),
+			source:        "A",
+			target:        "F",
+			k:             0,
+			expectedError: true,
+		},
+		"same source and target": {
+			graph:         trapGraph(),
+			source:        "A",
+			target:        "A",
+			k:             1,
+			expectedError: true,
+		},
+		"missing target": {
+			graph:         trapGraph(),
+			source:        "A",
+			target:        "X",
+			k:             1,
+			expectedErr:   ErrVertexNotFound,
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		paths, err := DisjointShortestPaths(test.graph, test.source, test.target, test.k)
+
+		if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+		}
+
+		if test.expectedError || test.expectedErr != nil {
+			if err == nil {
+				t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, true, false)
+			}
+			if test.expectedError {
+				continue
+			}
+		} else if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if !reflect.DeepEqual(paths, test.expectedPaths) {
+			t.Errorf("%s: paths expectancy doesn't match: expected %v, got %v", name, test.expectedPaths, paths)
+		}
+	}
+}
+
+func TestDisjointShortestPaths_negativeWeight(t *testing.T) {
+	g := New(StringHash, Directed(), Weighted())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddEdge("A", "B", EdgeWeight(-1))
+
+	if _, err := DisjointShortestPaths(g, "A", "B", 1); err == nil {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", true, false)
+	}
+}
+
+func TestDisjointShortestPaths_weightFuncAccessingGraph(t *testing.T) {
+	g := trapGraph(Directed())
+
+	weight := func(edge Edge[string]) float64 {
+		_ = g.AddVertex("G")
+		return float64(edge.Properties.Weight)
+	}
+
+	paths, err := DisjointShortestPaths(g, "A", "F", 2, WeightedBy(weight))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(paths) != 2 {
+		t.Errorf("number of paths doesn't match: expected %v, got %v", 2, len(paths))
+	}
+}
+
+func TestDisjointShortestPaths_random(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 50; i++ {
+		g := New(IntHash, Weighted())
+
+		for v := 0; v < 12; v++ {
+			_ = g.AddVertex(v)
+		}
+
+		for e := 0; e < 30; e++ {
+			_ = g.AddEdge(random.Intn(12), random.Intn(12), EdgeWeight(random.Intn(10)))
+		}
+
+		paths, err := DisjointShortestPaths(g, 0, 11, 3)
+		if err != nil && !errors.Is(err, ErrTargetNotReachable) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		used := make(map[[2]int]struct{})
+
+		for _, path := range paths {
+			if path[0] != 0 || path[len(path)-1] != 11 {
+				t.Fatalf("path %v doesn't lead from %v to %v", path, 0, 11)
+			}
+
+			for j := 1; j < len(path); j++ {
+				if _, err := g.Edge(path[j-1], path[j]); err != nil {
+					t.Fatalf("path %v contains a missing edge (%v, %v)", path, path[j-1], path[j])
+				}
+
+				key := [2]int{path[j-1], path[j]}
+				if key[1] < key[0] {
+					key[0], key[1] = key[1], key[0]
+				}
+
+				if _, ok := used[key]; ok {
+					t.Fatalf("paths %v share the edge (%v, %v)", paths, key[0], key[1])
+				}
+				used[key] = struct{}{}
+			}
+		}
+
+		// A single path is a shortest path.
+		if len(paths) > 0 {
+			single, _ := DisjointShortestPaths(g, 0, 11, 1)
+			shortest, _ := ShortestPath(g, 0, 11)
+			if pathWeight(t, g, single[0]) != pathWeight(t, g, shortest) {
+				t.Errorf("path %v isn't a shortest path like %v", single[0], shortest)
+			}
+		}
+	}
+}
@@ -1,11 +1,14 @@
 // Package draw provides functions for visualizing graph structures. At this
 // time, draw supports the DOT language which can be interpreted by Graphviz,
-// Grappa, and others.
+// Grappa, and others, as well as the GEXF format used by Gephi and the Pajek
+// NET format used by social network analysis tools. Graphs can also be rendered
+// as SVG or PNG images directly if Graphviz is installed.
 package draw
 
 import (
 	"fmt"
 	"io"
+	"sort"
 	"text/template"
 
 	"github.com/dominikbraun/graph"
@@ -16,17 +19,115 @@ const dotTemplate = `strict {{.GraphType}} {
 {{range $k, $v := .Attributes}}
 	{{$k}}="{{$v}}";
 {{end}}
+{{if .NodeAttributes}}
+	node [ {{range $k, $v := .NodeAttributes}}{{$k}}="{{$v}}", {{end}}];
+{{end}}
+{{if .EdgeDefaultAttributes}}
+	edge [ {{range $k, $v := .EdgeDefaultAttributes}}{{$k}}="{{$v}}", {{end}}];
+{{end}}
+{{range $c := .Clusters}}
+	subgraph "cluster_{{$c.Name}}" {
+		label="{{$c.Name}}";
+{{range $c.Statements}}
+		"{{.Source}}" {{template "vertexAttributes" .}};
+{{end}}
+	}
+{{end}}
+{{range .Subgraphs}}{{template "subgraph" .}}{{end}}
+{{if .Legend}}
+	subgraph "cluster_legend" {
+		label="Legend";
+{{range .Legend}}
+{{if .Edge}}
+		"{{.ID}}_source" [ shape="point", style="invis" ];
+		"{{.ID}}_target" [ shape="point", style="invis" ];
+		"{{.ID}}_source" {{$.EdgeOperator}} "{{.ID}}_target" [ label="{{.Label}}", {{range $k, $v := .Attributes}}{{$k}}="{{$v}}", {{end}}];
+{{else}}
+		"{{.ID}}" [ label="{{.Label}}", {{range $k, $v := .Attributes}}{{$k}}="{{$v}}", {{end}}];
+{{end}}
+{{end}}
+	}
+{{end}}
+{{range $r := .Ranks}}
+	{ rank={{$r.Type}};{{range $r.Vertices}} "{{.}}";{{end}} }
+{{end}}
 {{range $s := .Statements}}
-	"{{.Source}}" {{if .Target}}{{$.EdgeOperator}} "{{.Target}}" [ {{range $k, $v := .EdgeAttributes}}{{$k}}="{{$v}}", {{end}} weight={{.EdgeWeight}} ]{{else}}[ {{range $k, $v := .SourceAttributes}}{{$k}}="{{$v}}", {{end}} weight={{.SourceWeight}} ]{{end}};
+	"{{.Source}}" {{if .Target}}{{$.EdgeOperator}} "{{.Target}}" {{template "edgeAttributes" .}}{{else}}{{template "vertexAttributes" .}}{{end}};
 {{end}}
 }
-`
+{{define "subgraph"}}
+	subgraph "cluster_subgraph_{{.ID}}" {
+		label="{{.Label}}";
+{{range .Statements}}
+		"{{.Source}}" {{if .Target}}{{$.EdgeOperator}} "{{.Target}}" {{template "edgeAttributes" .}}{{else}}{{template "vertexAttributes" .}}{{end}};
+{{end}}
+{{range .Subgraphs}}{{template "subgraph" .}}{{end}}
+	}
+{{end}}
+{{define "edgeAttributes"}}[ {{if .EdgeLabel}}label={{if .EdgeLabelHTML}}<{{.EdgeLabel}}>{{else}}"{{.EdgeLabel}}"{{end}}, {{end}}{{range $k, $v := .EdgeAttributes}}{{$k}}="{{$v}}", {{end}} weight={{.EdgeWeight}} ]{{end}}
+{{define "vertexAttributes"}}[ {{if .SourceLabel}}label={{if .SourceLabelHTML}}<{{.SourceLabel}}>{{else}}"{{.SourceLabel}}"{{end}}, {{end}}{{range $k, $v := .SourceAttributes}}{{$k}}="{{$v}}", {{end}} weight={{.SourceWeight}} ]{{end}}`
 
 type description struct {
-	GraphType    string
-	Attributes   map[string]string
+	GraphType        string
+	Attributes       map[string]string
+	EdgeOperator     string
+	Statements       []statement
+	ClusterAttribute string
+	Clusters         []cluster
+	Subgraphs        []subgraphCluster
+	Ranks            []rank
+	Legend           []legendEntry
+	// NodeAttributes and EdgeDefaultAttributes are the default attributes
+	// for all vertices and edges, generated from the default styles.
+	NodeAttributes        map[string]string
+	EdgeDefaultAttributes map[string]string
+	nodeStyle             Style
+	edgeStyle             Style
+	theme                 Theme
+	// vertexLabel, edgeLabel, vertexStyle, and edgeStyleFunc are the
+	// functions passed to [VertexLabel], [EdgeLabel], [VertexStyle], and
+	// [EdgeStyle]. They are stored as an interface{} because description
+	// isn't generic, and are asserted to the function types for K in
+	// generateDOT.
+	vertexLabel   interface{}
+	edgeLabel     interface{}
+	vertexStyle   interface{}
+	edgeStyleFunc interface{}
+	// htmlVertexLabels and htmlEdgeLabels indicate whether the labels are
+	// HTML-like labels set using [VertexHTMLLabel] and [EdgeHTMLLabel].
+	htmlVertexLabels bool
+	htmlEdgeLabels   bool
+	// around and aroundDepth are the start vertex and the number of hops
+	// passed to [Around].
+	around      interface{}
+	aroundDepth int
+	highlights  []highlight
+	legend      *Legend
+}
+
+// cluster is a group of vertices rendered as a cluster subgraph. Its statements
+// only contain vertices, the edges are rendered outside of the cluster.
+type cluster struct {
+	Name       string
+	Statements []statement
+}
+
+// subgraphCluster is a subgraph nested into a compound vertex using
+// [graph.SetSubgraph]. It is rendered as a cluster containing the compound
+// vertex itself, the vertices and edges of the subgraph, and the clusters of
+// further nested subgraphs.
+type subgraphCluster struct {
+	ID           string
+	Label        string
 	EdgeOperator string
 	Statements   []statement
+	Subgraphs    []subgraphCluster
+}
+
+// rank is a rank constraint for a group of vertices, such as rank=same.
+type rank struct {
+	Type     string
+	Vertices []interface{}
 }
 
 type statement struct {
@@ -36,6 +137,10 @@ type statement struct {
 	SourceAttributes map[string]string
 	EdgeWeight       int
 	EdgeAttributes   map[string]string
+	SourceLabel      string
+	SourceLabelHTML  bool
+	EdgeLabel        string
+	EdgeLabelHTML    bool
 }
 
 // DOT renders the given graph structure in DOT language into an io.Writer, for
@@ -70,6 +175,20 @@ type statement struct {
 // add global attributes when rendering the graph:
 //
 //	_ = draw.DOT(g, file, draw.GraphAttribute("label", "my-graph"))
+//
+// Vertex and edge labels can be rendered using the [VertexLabel] and [EdgeLabel]
+// options, or as HTML-like labels using [VertexHTMLLabel] and [EdgeHTMLLabel]. The layout can be
+// controlled using the [RankDir], [SameRank], [MinRank], and [MaxRank] options.
+// Vertices and edges can be styled using typed options such as [NodeShape] or
+// [EdgeStyleDashed], or individually using [VertexStyle] and [EdgeStyle].
+// Default styles can be bundled as [Theme] and applied using [WithTheme]. Paths
+// and edges can be emphasized using [Highlight] and [HighlightEdges], and
+// [WithLegend] explains the styles used.
+//
+// Subgraphs nested into compound vertices using [graph.SetSubgraph] are
+// rendered as nested clusters containing the compound vertex. Since all
+// vertices end up in the same DOT graph, vertex hashes have to be unique across
+// the hierarchy.
 func DOT[K comparable, T any](g graph.Graph[K, T], w io.Writer, options ...func(*description)) error {
 	desc, err := generateDOT(g, options...)
 	if err != nil {
@@ -86,6 +205,155 @@ func GraphAttribute(key, value string) func(*description) {
 	}
 }
 
+// ClusterBy is a functional option for the [DOT] method that groups vertices
+// by the value of the given vertex attribute. Each group is rendered as cluster
+// subgraph labeled with the attribute value, which Graphviz draws as a box
+// around the vertices:
+//
+//	_ = g.AddVertex("api", graph.VertexAttribute("module", "backend"))
+//	_ = g.AddVertex("db", graph.VertexAttribute("module", "backend"))
+//	_ = g.AddVertex("ui", graph.VertexAttribute("module", "frontend"))
+//
+//	_ = draw.DOT(g, file, draw.ClusterBy("module"))
+//
+// Vertices without the attribute aren't part of any cluster.
+func ClusterBy(attribute string) func(*description) {
+	return func(d *description) {
+		d.ClusterAttribute = attribute
+	}
+}
+
+// EdgeLabel is a functional option for the [DOT] method that renders a label
+// for each edge. The label is computed by the given function, which receives
+// the edge including its properties. For example, edge weights can be rendered
+// as follows:
+//
+//	_ = draw.DOT(g, file, draw.EdgeLabel(func(edge graph.Edge[string]) string {
+//		return strconv.Itoa(edge.Properties.Weight)
+//	}))
+//
+// The label takes precedence over a "label" attribute of the edge. Empty labels
+// aren't rendered. The type parameter K has to match the hash type of the graph
+// passed to [DOT], otherwise DOT returns an error.
+func EdgeLabel[K comparable](label func(edge graph.Edge[K]) string) func(*description) {
+	return func(d *description) {
+		d.edgeLabel = label
+		d.htmlEdgeLabels = false
+	}
+}
+
+// EdgeHTMLLabel is like [EdgeLabel], but renders the labels as Graphviz
+// HTML-like labels. The returned labels are written as label=<...> without any
+// escaping, so they have to be valid HTML-like labels as documented at
+// https://graphviz.org/doc/info/shapes.html#html.
+func EdgeHTMLLabel[K comparable](label func(edge graph.Edge[K]) string) func(*description) {
+	return func(d *description) {
+		d.edgeLabel = label
+		d.htmlEdgeLabels = true
+	}
+}
+
+// VertexLabel is a functional option for the [DOT] method that renders a label
+// for each vertex, which is computed by the given function. By default,
+// Graphviz uses the vertex hash as label. The label takes precedence over a
+// "label" attribute of the vertex, and empty labels aren't rendered. The type
+// parameter K has to match the hash type of the graph passed to [DOT],
+// otherwise DOT returns an error.
+func VertexLabel[K comparable](label func(vertex K, properties graph.VertexProperties) string) func(*description) {
+	return func(d *description) {
+		d.vertexLabel = label
+		d.htmlVertexLabels = false
+	}
+}
+
+// VertexHTMLLabel is like [VertexLabel], but renders the labels as Graphviz
+// HTML-like labels without any escaping. This allows rich vertices such as
+// tables with multiple rows:
+//
+//	_ = draw.DOT(g, file, draw.NodeShape(draw.ShapePlaintext), draw.VertexHTMLLabel(func(vertex string, properties graph.VertexProperties) string {
+//		return fmt.Sprintf(`<table><tr><td><b>%s</b></td></tr><tr><td>%s</td></tr></table>`, vertex, properties.Attributes["type"])
+//	}))
+//
+// Note that the hash and attributes have to be escaped by the caller if they
+// may contain characters such as < or &.
+func VertexHTMLLabel[K comparable](label func(vertex K, properties graph.VertexProperties) string) func(*description) {
+	return func(d *description) {
+		d.vertexLabel = label
+		d.htmlVertexLabels = true
+	}
+}
+
+// RankDir is a functional option for the [DOT] method that sets the direction
+// in which the graph is laid out. Valid directions are "TB" (top to bottom),
+// "LR" (left to right), "BT" (bottom to top), and "RL" (right to left). For
+// other directions, DOT returns an error.
+//
+//	_ = draw.DOT(g, file, draw.RankDir("LR"))
+func RankDir(direction string) func(*description) {
+	return func(d *description) {
+		d.Attributes["rankdir"] = direction
+	}
+}
+
+// SameRank is a functional option for the [DOT] method that places the vertices
+// with the given hashes on the same rank, e.g. on the same row for the default
+// top-to-bottom layout:
+//
+//	_ = draw.DOT(g, file, draw.SameRank("api", "worker"))
+//
+// The option may be passed multiple times to create multiple groups. The type
+// parameter K has to match the hash type of the graph, and all vertices have to
+// exist in the graph, otherwise DOT returns an error.
+func SameRank[K comparable](vertices ...K) func(*description) {
+	return rankOption("same", vertices)
+}
+
+// MinRank is a functional option for the [DOT] method that places the vertices
+// with the given hashes on the minimum rank, e.g. on the top row for the default
+// top-to-bottom layout. The same constraints as for [SameRank] apply.
+func MinRank[K comparable](vertices ...K) func(*description) {
+	return rankOption("min", vertices)
+}
+
+// MaxRank is a functional option for the [DOT] method that places the vertices
+// with the given hashes on the maximum rank, e.g. on the bottom row for the
+// default top-to-bottom layout. The same constraints as for [SameRank] apply.
+func MaxRank[K comparable](vertices ...K) func(*description) {
+	return rankOption("max", vertices)
+}
+
+func rankOption[K comparable](rankType string, vertices []K) func(*description) {
+	return func(d *description) {
+		r := rank{
+			Type:     rankType,
+			Vertices: make([]interface{}, len(vertices)),
+		}
+		for i, vertex := range vertices {
+			r.Vertices[i] = vertex
+		}
+		d.Ranks = append(d.Ranks, r)
+	}
+}
+
+// Around is a functional option for the [DOT] method that only renders the
+// neighborhood of the given vertex, which consists of all vertices reachable
+// within the given number of hops and the edges between them. This is useful
+// for large graphs, where rendering the entire graph isn't feasible:
+//
+//	_ = draw.DOT(g, file, draw.Around("api", 2))
+//
+// The neighborhood is determined using [graph.Neighborhood], so only outgoing
+// edges are followed in directed graphs. Vertices outside the neighborhood that
+// have been passed to rank options such as [SameRank] are omitted. The type
+// parameter K has to match the hash type of the graph passed to [DOT], otherwise
+// DOT returns an error.
+func Around[K comparable](vertex K, depth int) func(*description) {
+	return func(d *description) {
+		d.around = vertex
+		d.aroundDepth = depth
+	}
+}
+
 func generateDOT[K comparable, T any](g graph.Graph[K, T], options ...func(*description)) (description, error) {
 	desc := description{
 		GraphType:    "graph",
@@ -98,7 +366,69 @@ func generateDOT[K comparable, T any](g graph.Graph[K, T], options ...func(*desc
 		option(&desc)
 	}
 
-	if g.Traits().IsDirected {
+	var (
+		vertexLabel func(K, graph.VertexProperties) string
+		edgeLabel   func(graph.Edge[K]) string
+		vertexStyle func(K, graph.VertexProperties) Style
+		edgeStyle   func(graph.Edge[K]) Style
+	)
+
+	if desc.vertexLabel != nil {
+		var ok bool
+		if vertexLabel, ok = desc.vertexLabel.(func(K, graph.VertexProperties) string); !ok {
+			return desc, fmt.Errorf("vertex label function has type %T, expected %T", desc.vertexLabel, vertexLabel)
+		}
+	}
+
+	if desc.edgeLabel != nil {
+		var ok bool
+		if edgeLabel, ok = desc.edgeLabel.(func(graph.Edge[K]) string); !ok {
+			return desc, fmt.Errorf("edge label function has type %T, expected %T", desc.edgeLabel, edgeLabel)
+		}
+	}
+
+	if desc.vertexStyle != nil {
+		var ok bool
+		if vertexStyle, ok = desc.vertexStyle.(func(K, graph.VertexProperties) Style); !ok {
+			return desc, fmt.Errorf("vertex style function has type %T, expected %T", desc.vertexStyle, vertexStyle)
+		}
+	}
+
+	if desc.edgeStyleFunc != nil {
+		var ok bool
+		if edgeStyle, ok = desc.edgeStyleFunc.(func(graph.Edge[K]) Style); !ok {
+			return desc, fmt.Errorf("edge style function has type %T, expected %T", desc.edgeStyleFunc, edgeStyle)
+		}
+	}
+
+	for key, value := range desc.theme.Graph {
+		if _, ok := desc.Attributes[key]; !ok {
+			desc.Attributes[key] = value
+		}
+	}
+
+	desc.nodeStyle = desc.theme.Node.overlay(desc.nodeStyle)
+	desc.edgeStyle = desc.theme.Edge.overlay(desc.edgeStyle)
+
+	if attributes := desc.nodeStyle.attributes(); len(attributes) > 0 {
+		desc.NodeAttributes = attributes
+	}
+
+	if attributes := desc.edgeStyle.attributes(); len(attributes) > 0 {
+		desc.EdgeDefaultAttributes = attributes
+	}
+
+	if direction, ok := desc.Attributes["rankdir"]; ok {
+		switch direction {
+		case "TB", "LR", "BT", "RL":
+		default:
+			return desc, fmt.Errorf("invalid rank direction %q", direction)
+		}
+	}
+
+	isDirected := g.Traits().IsDirected
+
+	if isDirected {
 		desc.GraphType = "digraph"
 		desc.EdgeOperator = "->"
 	}
@@ -108,6 +438,58 @@ func generateDOT[K comparable, T any](g graph.Graph[K, T], options ...func(*desc
 		return desc, err
 	}
 
+	for _, r := range desc.Ranks {
+		for _, vertex := range r.Vertices {
+			hash, ok := vertex.(K)
+			if !ok {
+				return desc, fmt.Errorf("vertex %v in rank=%s has type %T, expected %T", vertex, r.Type, vertex, hash)
+			}
+			if _, ok := adjacencyMap[hash]; !ok {
+				return desc, fmt.Errorf("vertex %v in rank=%s: %w", vertex, r.Type, graph.ErrVertexNotFound)
+			}
+		}
+	}
+
+	vertexHighlights, edgeHighlights, err := highlightStyles(desc.highlights, adjacencyMap, g.Traits().IsDirected)
+	if err != nil {
+		return desc, err
+	}
+
+	if desc.around != nil {
+		start, ok := desc.around.(K)
+		if !ok {
+			return desc, fmt.Errorf("start vertex %v has type %T, expected %T", desc.around, desc.around, start)
+		}
+		if adjacencyMap, err = neighborhood(g, adjacencyMap, start, desc.aroundDepth); err != nil {
+			return desc, err
+		}
+		ranks := make([]rank, 0, len(desc.Ranks))
+		for _, r := range desc.Ranks {
+			vertices := make([]interface{}, 0, len(r.Vertices))
+			for _, vertex := range r.Vertices {
+				if _, ok := adjacencyMap[vertex.(K)]; ok {
+					vertices = append(vertices, vertex)
+				}
+			}
+			if len(vertices) > 0 {
+				ranks = append(ranks, rank{Type: r.Type, Vertices: vertices})
+			}
+		}
+		desc.Ranks = ranks
+	}
+
+	vertexColors, edgeColors, err := themeColors(g, adjacencyMap, desc.theme)
+	if err != nil {
+		return desc, err
+	}
+
+	if desc.legend != nil {
+		desc.Legend = legendEntries(*desc.legend, desc.theme, vertexColors, edgeColors)
+	}
+
+	clusters := make(map[string]int)
+	emitted := make(map[edgeKey[K]]struct{})
+
 	for vertex, adjacencies := range adjacencyMap {
 		_, sourceProperties, err := g.VertexWithProperties(vertex)
 		if err != nil {
@@ -119,22 +501,275 @@ func generateDOT[K comparable, T any](g graph.Graph[K, T], options ...func(*desc
 			SourceWeight:     sourceProperties.Weight,
 			SourceAttributes: sourceProperties.Attributes,
 		}
-		desc.Statements = append(desc.Statements, stmt)
+
+		if color, ok := desc.theme.VertexColors.lookup(vertexColors, sourceProperties.Attributes); ok {
+			stmt.SourceAttributes = Style{FillColor: color}.merge(nil)
+			for key, value := range sourceProperties.Attributes {
+				stmt.SourceAttributes[key] = value
+			}
+		}
+
+		if vertexStyle != nil {
+			stmt.SourceAttributes = vertexStyle(vertex, sourceProperties).merge(stmt.SourceAttributes)
+		}
+
+		if style, ok := vertexHighlights[vertex]; ok {
+			stmt.SourceAttributes = style.merge(stmt.SourceAttributes)
+		}
+
+		if vertexLabel != nil {
+			if label := vertexLabel(vertex, sourceProperties); label != "" {
+				stmt.SourceLabel, stmt.SourceLabelHTML = label, desc.htmlVertexLabels
+				if !desc.htmlVertexLabels {
+					stmt.SourceLabel = escape(label)
+				}
+				stmt.SourceAttributes = withoutLabel(stmt.SourceAttributes)
+			}
+		}
+
+		if subgraph, ok := graph.Subgraph(g, vertex); ok {
+			c, err := generateSubgraph(subgraph, vertex, stmt, desc)
+			if err != nil {
+				return desc, err
+			}
+			desc.Subgraphs = append(desc.Subgraphs, c)
+		} else if name, ok := sourceProperties.Attributes[desc.ClusterAttribute]; ok && desc.ClusterAttribute != "" {
+			i, ok := clusters[name]
+			if !ok {
+				i = len(desc.Clusters)
+				clusters[name] = i
+				desc.Clusters = append(desc.Clusters, cluster{Name: name})
+			}
+			desc.Clusters[i].Statements = append(desc.Clusters[i].Statements, stmt)
+		} else {
+			desc.Statements = append(desc.Statements, stmt)
+		}
 
 		for adjacency, edge := range adjacencies {
+			// For undirected graphs, the adjacency map contains each edge in
+			// both directions, but it is only rendered once.
+			if !isDirected {
+				var ok bool
+				if edge, ok = undirectedEdge(adjacencyMap, vertex, adjacency, emitted); !ok {
+					continue
+				}
+				adjacency = edge.Target
+			}
 			stmt := statement{
 				Source:         vertex,
 				Target:         adjacency,
 				EdgeWeight:     edge.Properties.Weight,
 				EdgeAttributes: edge.Properties.Attributes,
 			}
+			if color, ok := desc.theme.EdgeColors.lookup(edgeColors, edge.Properties.Attributes); ok {
+				stmt.EdgeAttributes = Style{Color: color}.merge(nil)
+				for key, value := range edge.Properties.Attributes {
+					stmt.EdgeAttributes[key] = value
+				}
+			}
+			if edgeStyle != nil {
+				stmt.EdgeAttributes = edgeStyle(edge).merge(stmt.EdgeAttributes)
+			}
+			if style, ok := edgeHighlights[edgeKey[K]{source: vertex, target: adjacency}]; ok {
+				stmt.EdgeAttributes = style.merge(stmt.EdgeAttributes)
+			}
+			if edgeLabel != nil {
+				if label := edgeLabel(edge); label != "" {
+					stmt.EdgeLabel, stmt.EdgeLabelHTML = label, desc.htmlEdgeLabels
+					if !desc.htmlEdgeLabels {
+						stmt.EdgeLabel = escape(label)
+					}
+					stmt.EdgeAttributes = withoutLabel(stmt.EdgeAttributes)
+				}
+			}
 			desc.Statements = append(desc.Statements, stmt)
 		}
 	}
 
+	sort.Slice(desc.Clusters, func(i, j int) bool {
+		return desc.Clusters[i].Name < desc.Clusters[j].Name
+	})
+
+	for _, c := range desc.Clusters {
+		sort.Slice(c.Statements, func(i, j int) bool {
+			return fmt.Sprint(c.Statements[i].Source) < fmt.Sprint(c.Statements[j].Source)
+		})
+	}
+
+	sort.Slice(desc.Subgraphs, func(i, j int) bool {
+		return desc.Subgraphs[i].ID < desc.Subgraphs[j].ID
+	})
+
 	return desc, nil
 }
 
+// generateSubgraph generates the cluster for the subgraph nested into the given
+// compound vertex, whose statement is rendered as part of the cluster. The
+// subgraph inherits the labels and styles of the parent description, but not
+// the options that refer to specific vertices, such as ranks or highlights.
+func generateSubgraph[K comparable, T any](subgraph graph.Graph[K, T], vertex K, stmt statement, parent description) (subgraphCluster, error) {
+	desc, err := generateDOT(subgraph, func(d *description) {
+		d.vertexLabel = parent.vertexLabel
+		d.edgeLabel = parent.edgeLabel
+		d.vertexStyle = parent.vertexStyle
+		d.edgeStyleFunc = parent.edgeStyleFunc
+		d.htmlVertexLabels = parent.htmlVertexLabels
+		d.htmlEdgeLabels = parent.htmlEdgeLabels
+		d.theme = parent.theme
+	})
+	if err != nil {
+		return subgraphCluster{}, fmt.Errorf("failed to generate subgraph of %v: %w", vertex, err)
+	}
+
+	// The statements of a graph are generated in random order, so they are
+	// sorted to keep the output stable. The compound vertex always comes first.
+	sort.SliceStable(desc.Statements, func(i, j int) bool {
+		a, b := desc.Statements[i], desc.Statements[j]
+		if source := fmt.Sprint(a.Source); source != fmt.Sprint(b.Source) {
+			return source < fmt.Sprint(b.Source)
+		}
+		if a.Target == nil || b.Target == nil {
+			return a.Target == nil && b.Target != nil
+		}
+		return fmt.Sprint(a.Target) < fmt.Sprint(b.Target)
+	})
+
+	return subgraphCluster{
+		ID:           fmt.Sprint(vertex),
+		Label:        fmt.Sprint(vertex),
+		EdgeOperator: parent.EdgeOperator,
+		Statements:   append([]statement{stmt}, desc.Statements...),
+		Subgraphs:    desc.Subgraphs,
+	}, nil
+}
+
+// undirectedEdge returns the undirected edge between source and target if it
+// should be rendered when processing the source vertex. Each edge is rendered
+// from the vertex whose hash formatted using fmt.Sprint is lower, or from the
+// vertex processed first if both are equal. The returned edge has the
+// properties of that direction, complemented with the properties of the other
+// direction in case a store holds different properties for both.
+func undirectedEdge[K comparable](adjacencyMap map[K]map[K]graph.Edge[K], source, target K, emitted map[edgeKey[K]]struct{}) (graph.Edge[K], bool) {
+	edge := adjacencyMap[source][target]
+
+	reverse, ok := adjacencyMap[target][source]
+	if !ok || source == target {
+		return edge, true
+	}
+
+	sourceLabel, targetLabel := fmt.Sprint(source), fmt.Sprint(target)
+
+	if targetLabel < sourceLabel {
+		return edge, false
+	}
+
+	if targetLabel == sourceLabel {
+		if _, ok := emitted[edgeKey[K]{source: target, target: source}]; ok {
+			return edge, false
+		}
+		emitted[edgeKey[K]{source: source, target: target}] = struct{}{}
+	}
+
+	if len(reverse.Properties.Attributes) > 0 {
+		attributes := make(map[string]string, len(edge.Properties.Attributes)+len(reverse.Properties.Attributes))
+		for key, value := range reverse.Properties.Attributes {
+			attributes[key] = value
+		}
+		for key, value := range edge.Properties.Attributes {
+			attributes[key] = value
+		}
+		edge.Properties.Attributes = attributes
+	}
+
+	if edge.Properties.Weight == 0 {
+		edge.Properties.Weight = reverse.Properties.Weight
+	}
+
+	if edge.Properties.Data == nil {
+		edge.Properties.Data = reverse.Properties.Data
+	}
+
+	return edge, true
+}
+
+// neighborhood returns the adjacency map reduced to the neighborhood of the
+// given start vertex within the given number of hops.
+func neighborhood[K comparable, T any](g graph.Graph[K, T], adjacencyMap map[K]map[K]graph.Edge[K], start K, depth int) (map[K]map[K]graph.Edge[K], error) {
+	hashes, err := graph.Neighborhood(g, start, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get neighborhood: %w", err)
+	}
+
+	result := make(map[K]map[K]graph.Edge[K], len(hashes))
+
+	for _, hash := range hashes {
+		result[hash] = make(map[K]graph.Edge[K])
+	}
+
+	for hash := range result {
+		for adjacency, edge := range adjacencyMap[hash] {
+			if _, ok := result[adjacency]; ok {
+				result[hash][adjacency] = edge
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// themeColors computes the colors of the vertex and edge color maps of the
+// given theme. The returned maps are keyed by attribute value and are nil if
+// the theme has no such color map.
+func themeColors[K comparable, T any](g graph.Graph[K, T], adjacencyMap map[K]map[K]graph.Edge[K], theme Theme) (map[string]string, map[string]string, error) {
+	var vertexColors, edgeColors map[string]string
+
+	if theme.VertexColors != nil {
+		values := make([]string, 0)
+		for vertex := range adjacencyMap {
+			_, properties, err := g.VertexWithProperties(vertex)
+			if err != nil {
+				return nil, nil, err
+			}
+			if value, ok := properties.Attributes[theme.VertexColors.Attribute]; ok {
+				values = append(values, value)
+			}
+		}
+		vertexColors = theme.VertexColors.colors(values)
+	}
+
+	if theme.EdgeColors != nil {
+		values := make([]string, 0)
+		for _, adjacencies := range adjacencyMap {
+			for _, edge := range adjacencies {
+				if value, ok := edge.Properties.Attributes[theme.EdgeColors.Attribute]; ok {
+					values = append(values, value)
+				}
+			}
+		}
+		edgeColors = theme.EdgeColors.colors(values)
+	}
+
+	return vertexColors, edgeColors, nil
+}
+
+// withoutLabel returns a copy of the given attributes without the "label"
+// attribute, so that it doesn't conflict with a label set using [VertexLabel]
+// or [EdgeLabel].
+func withoutLabel(attributes map[string]string) map[string]string {
+	if _, ok := attributes["label"]; !ok {
+		return attributes
+	}
+
+	result := make(map[string]string, len(attributes)-1)
+	for key, value := range attributes {
+		if key != "label" {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
 func renderDOT(w io.Writer, d description) error {
 	tpl, err := template.New("dotTemplate").Parse(dotTemplate)
 	if err != nil {
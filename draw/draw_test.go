@@ -2,6 +2,7 @@ package draw
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -397,6 +398,204 @@ func TestGraphAttribute(t *testing.T) {
 
 }
 
+func TestClusterBy(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	_ = g.AddVertex("ui", graph.VertexAttribute("module", "frontend"))
+	_ = g.AddVertex("db", graph.VertexAttribute("module", "backend"))
+	_ = g.AddVertex("api", graph.VertexAttribute("module", "backend"))
+	_ = g.AddVertex("user")
+	_ = g.AddEdge("ui", "api")
+
+	desc, err := generateDOT(g, ClusterBy("module"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(desc.Clusters) != 2 {
+		t.Fatalf("cluster count doesn't match: expected %v, got %v", 2, len(desc.Clusters))
+	}
+
+	// Only the edge and the vertex without cluster remain at the top level.
+	if len(desc.Statements) != 2 {
+		t.Errorf("statement count doesn't match: expected %v, got %v", 2, len(desc.Statements))
+	}
+
+	buf := new(bytes.Buffer)
+	_ = renderDOT(buf, description{
+		GraphType:    desc.GraphType,
+		Attributes:   desc.Attributes,
+		EdgeOperator: desc.EdgeOperator,
+		Clusters:     desc.Clusters,
+	})
+
+	expected := `strict digraph {
+		subgraph "cluster_backend" {
+			label="backend";
+			"api" [ module="backend", weight=0 ];
+			"db" [ module="backend", weight=0 ];
+		}
+		subgraph "cluster_frontend" {
+			label="frontend";
+			"ui" [ module="frontend", weight=0 ];
+		}
+	}`
+
+	if output := normalizeOutput(buf.String()); output != normalizeOutput(expected) {
+		t.Errorf("DOT output expectancy doesn't match: expected %v, got %v", normalizeOutput(expected), output)
+	}
+}
+
+func TestSubgraphs(t *testing.T) {
+	system := graph.New(graph.StringHash, graph.Directed())
+	_ = system.AddVertex("frontend")
+	_ = system.AddVertex("backend")
+	_ = system.AddEdge("frontend", "backend")
+
+	backend := graph.New(graph.StringHash, graph.Directed())
+	_ = backend.AddVertex("api")
+	_ = backend.AddVertex("storage")
+	_ = backend.AddEdge("api", "storage")
+
+	storage := graph.New(graph.StringHash, graph.Directed())
+	_ = storage.AddVertex("db")
+
+	_ = graph.SetSubgraph(backend, "storage", storage)
+	_ = graph.SetSubgraph(system, "backend", backend)
+
+	desc, err := generateDOT(system)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The compound vertex is rendered inside its cluster, so only the other
+	// vertex and the edge remain at the top level.
+	if len(desc.Statements) != 2 {
+		t.Errorf("statement count doesn't match: expected %v, got %v", 2, len(desc.Statements))
+	}
+
+	buf := new(bytes.Buffer)
+	_ = renderDOT(buf, description{
+		GraphType:    desc.GraphType,
+		Attributes:   desc.Attributes,
+		EdgeOperator: desc.EdgeOperator,
+		Subgraphs:    desc.Subgraphs,
+	})
+
+	expected := `strict digraph {
+		subgraph "cluster_subgraph_backend" {
+			label="backend";
+			"backend" [ weight=0 ];
+			"api" [ weight=0 ];
+			"api" -> "storage" [ weight=0 ];
+			subgraph "cluster_subgraph_storage" {
+				label="storage";
+				"storage" [ weight=0 ];
+				"db" [ weight=0 ];
+			}
+		}
+	}`
+
+	if output := normalizeOutput(buf.String()); output != normalizeOutput(expected) {
+		t.Errorf("DOT output expectancy doesn't match: expected %v, got %v", normalizeOutput(expected), output)
+	}
+}
+
+func TestEdgeLabel(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed(), graph.Weighted())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddVertex("C")
+	_ = g.AddEdge("A", "B", graph.EdgeWeight(4), graph.EdgeAttribute("label", "ignored"))
+	_ = g.AddEdge("B", "C", graph.EdgeAttribute("color", "red"))
+
+	label := func(edge graph.Edge[string]) string {
+		if edge.Properties.Weight == 0 {
+			return ""
+		}
+		return fmt.Sprintf(`"%d"`, edge.Properties.Weight)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := DOT(g, buf, EdgeLabel(label)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := normalizeOutput(buf.String())
+
+	for _, expected := range []string{
+		`"A"->"B"[label="\"4\"",weight=4]`,
+		`"B"->"C"[color="red",weight=0]`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected statement %v in DOT output %v", expected, output)
+		}
+	}
+
+	if err := DOT(g, buf, EdgeLabel(func(graph.Edge[int]) string { return "" })); err == nil {
+		t.Error("expected error for mismatched hash type, got nil")
+	}
+}
+
+func TestRanks(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddVertex("C")
+	_ = g.AddVertex("D")
+
+	tests := map[string]struct {
+		options    []func(*description)
+		expected   []string
+		shouldFail bool
+	}{
+		"rank direction": {
+			options:  []func(*description){RankDir("LR")},
+			expected: []string{`rankdir="LR";`},
+		},
+		"rank groups": {
+			options: []func(*description){SameRank("B", "C"), MinRank("A"), MaxRank("D")},
+			expected: []string{
+				`{rank=same;"B";"C";}`,
+				`{rank=min;"A";}`,
+				`{rank=max;"D";}`,
+			},
+		},
+		"invalid rank direction": {
+			options:    []func(*description){RankDir("XY")},
+			shouldFail: true,
+		},
+		"unknown vertex": {
+			options:    []func(*description){SameRank("A", "E")},
+			shouldFail: true,
+		},
+		"mismatched hash type": {
+			options:    []func(*description){SameRank(1, 2)},
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		buf := new(bytes.Buffer)
+
+		err := DOT(g, buf, test.options...)
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		output := normalizeOutput(buf.String())
+
+		for _, expected := range test.expected {
+			if !strings.Contains(output, normalizeOutput(expected)) {
+				t.Errorf("%s: expected %v in DOT output %v", name, expected, output)
+			}
+		}
+	}
+}
+
 func slicesAreEqual[T any](a, b []T, equals func(a, b T) bool) bool {
 	if len(a) != len(b) {
 		return false
@@ -475,3 +674,432 @@ func statementsAreEqual(a, b statement) bool {
 		a.EdgeWeight == b.EdgeWeight &&
 		a.SourceWeight == b.SourceWeight
 }
+
+func TestStyles(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed(), graph.Weighted())
+
+	_ = g.AddVertex("A", graph.VertexWeight(20), graph.VertexAttribute("color", "blue"))
+	_ = g.AddVertex("B", graph.VertexAttribute("color", "blue"))
+	_ = g.AddEdge("A", "B", graph.EdgeWeight(5))
+
+	vertexStyle := func(vertex string, properties graph.VertexProperties) Style {
+		if properties.Weight > 10 {
+			return Style{Color: "red", FillColor: "orange", LineStyle: LineBold}
+		}
+		return Style{}
+	}
+
+	edgeStyle := func(edge graph.Edge[string]) Style {
+		return Style{PenWidth: float64(edge.Properties.Weight) / 2, FontColor: `dark"red`}
+	}
+
+	buf := new(bytes.Buffer)
+
+	err := DOT(g, buf,
+		NodeShape(ShapeBox),
+		EdgeStyleDashed(),
+		FontSize(10.5),
+		VertexStyle(vertexStyle),
+		EdgeStyle(edgeStyle),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := normalizeOutput(buf.String())
+
+	for _, expected := range []string{
+		`fontsize="10.5";`,
+		`node[fontsize="10.5",shape="box",];`,
+		`edge[fontsize="10.5",style="dashed",];`,
+		`"A"[color="red",fillcolor="orange",style="filled,bold",weight=20]`,
+		`"B"[color="blue",weight=0]`,
+		`"A"->"B"[fontcolor="dark\"red",penwidth="2.5",weight=5]`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %v in DOT output %v", expected, output)
+		}
+	}
+
+	if err := DOT(g, buf, VertexStyle(func(int, graph.VertexProperties) Style { return Style{} })); err == nil {
+		t.Error("expected error for mismatched hash type, got nil")
+	}
+
+	// The styles must not modify the attributes stored in the graph.
+	_, properties, _ := g.VertexWithProperties("A")
+	if properties.Attributes["color"] != "blue" {
+		t.Errorf("vertex attribute has been modified: expected %v, got %v", "blue", properties.Attributes["color"])
+	}
+}
+
+func TestWithTheme(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	_ = g.AddVertex("A", graph.VertexAttribute("team", "core"))
+	_ = g.AddVertex("B", graph.VertexAttribute("team", "web"))
+	_ = g.AddVertex("C", graph.VertexAttribute("team", "web"), graph.VertexAttribute("fillcolor", "black"))
+	_ = g.AddVertex("D")
+	_ = g.AddEdge("A", "B", graph.EdgeAttribute("kind", "sync"))
+	_ = g.AddEdge("B", "C")
+
+	theme := Theme{
+		Graph: map[string]string{"bgcolor": "white", "label": "theme"},
+		Node:  Style{Shape: ShapeBox, FontName: "Helvetica"},
+		Edge:  Style{Color: "gray40"},
+		VertexColors: &ColorMap{
+			Attribute: "team",
+			Colors:    map[string]string{"core": "lightblue"},
+			Palette:   []string{"yellow"},
+		},
+		EdgeColors: &ColorMap{
+			Attribute: "kind",
+		},
+	}
+
+	buf := new(bytes.Buffer)
+
+	err := DOT(g, buf, WithTheme(theme), GraphAttribute("label", "custom"), NodeShape(ShapeCircle))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := normalizeOutput(buf.String())
+
+	for _, expected := range []string{
+		`bgcolor="white";`,
+		`label="custom";`,
+		`node[fontname="Helvetica",shape="circle",];`,
+		`edge[color="gray40",];`,
+		`"A"[fillcolor="lightblue",style="filled",team="core",weight=0]`,
+		`"B"[fillcolor="yellow",style="filled",team="web",weight=0]`,
+		`"C"[fillcolor="black",style="filled",team="web",weight=0]`,
+		`"D"[weight=0]`,
+		`"A"->"B"[color="` + DefaultPalette[0] + `",kind="sync",weight=0]`,
+		`"B"->"C"[weight=0]`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %v in DOT output %v", expected, output)
+		}
+	}
+}
+
+func TestHTMLLabels(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	_ = g.AddVertex("A", graph.VertexAttribute("type", "service"), graph.VertexAttribute("label", "ignored"))
+	_ = g.AddVertex("B", graph.VertexAttribute("type", "database"))
+	_ = g.AddEdge("A", "B")
+
+	tests := map[string]struct {
+		options  []func(*description)
+		expected []string
+	}{
+		"HTML-like labels": {
+			options: []func(*description){
+				VertexHTMLLabel(func(vertex string, properties graph.VertexProperties) string {
+					return fmt.Sprintf(`<table><tr><td>%s</td></tr><tr><td>%s</td></tr></table>`, vertex, properties.Attributes["type"])
+				}),
+				EdgeHTMLLabel(func(edge graph.Edge[string]) string {
+					return `<i>"uses"</i>`
+				}),
+			},
+			expected: []string{
+				`"A"[label=<<table><tr><td>A</td></tr><tr><td>service</td></tr></table>>,type="service",weight=0]`,
+				`"B"[label=<<table><tr><td>B</td></tr><tr><td>database</td></tr></table>>,type="database",weight=0]`,
+				`"A"->"B"[label=<<i>"uses"</i>>,weight=0]`,
+			},
+		},
+		"plain labels": {
+			options: []func(*description){
+				VertexLabel(func(vertex string, properties graph.VertexProperties) string {
+					return fmt.Sprintf(`<%s>`, properties.Attributes["type"])
+				}),
+			},
+			expected: []string{
+				`"A"[label="<service>",type="service",weight=0]`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		buf := new(bytes.Buffer)
+
+		if err := DOT(g, buf, test.options...); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		output := normalizeOutput(buf.String())
+
+		for _, expected := range test.expected {
+			if !strings.Contains(output, normalizeOutput(expected)) {
+				t.Errorf("%s: expected %v in DOT output %v", name, expected, output)
+			}
+		}
+	}
+}
+
+func TestAround(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	for _, vertex := range []string{"A", "B", "C", "D", "E"} {
+		_ = g.AddVertex(vertex)
+	}
+	_ = g.AddEdge("A", "B")
+	_ = g.AddEdge("B", "C")
+	_ = g.AddEdge("C", "D")
+	_ = g.AddEdge("E", "A")
+
+	tests := map[string]struct {
+		options    []func(*description)
+		expected   []string
+		unexpected []string
+		shouldFail bool
+	}{
+		"2 hops": {
+			options:    []func(*description){Around("A", 2), SameRank("B", "E")},
+			expected:   []string{`"A"->"B"`, `"B"->"C"`, `"C"[weight=0]`, `{rank=same;"B";}`},
+			unexpected: []string{`"D"`, `"E"`},
+		},
+		"0 hops": {
+			options:    []func(*description){Around("C", 0), SameRank("A", "B")},
+			expected:   []string{`"C"[weight=0]`},
+			unexpected: []string{`->`, `rank`},
+		},
+		"unknown vertex": {
+			options:    []func(*description){Around("F", 1)},
+			shouldFail: true,
+		},
+		"negative depth": {
+			options:    []func(*description){Around("A", -1)},
+			shouldFail: true,
+		},
+		"mismatched hash type": {
+			options:    []func(*description){Around(1, 1)},
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		buf := new(bytes.Buffer)
+
+		err := DOT(g, buf, test.options...)
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		output := normalizeOutput(buf.String())
+
+		for _, expected := range test.expected {
+			if !strings.Contains(output, expected) {
+				t.Errorf("%s: expected %v in DOT output %v", name, expected, output)
+			}
+		}
+
+		for _, unexpected := range test.unexpected {
+			if strings.Contains(output, unexpected) {
+				t.Errorf("%s: unexpected %v in DOT output %v", name, unexpected, output)
+			}
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	g := graph.New(graph.StringHash)
+
+	for _, vertex := range []string{"A", "B", "C", "D"} {
+		_ = g.AddVertex(vertex)
+	}
+	_ = g.AddEdge("A", "B")
+	_ = g.AddEdge("B", "C")
+	_ = g.AddEdge("C", "D", graph.EdgeAttribute("color", "blue"))
+
+	tests := map[string]struct {
+		options    []func(*description)
+		expected   []string
+		shouldFail bool
+	}{
+		"path": {
+			options: []func(*description){Highlight([]string{"C", "B", "A"}, Style{Color: "red", PenWidth: 2})},
+			expected: []string{
+				`"A"[color="red",penwidth="2",weight=0]`,
+				`"C"[color="red",penwidth="2",weight=0]`,
+				`"D"[weight=0]`,
+				`"A"--"B"[color="red",penwidth="2",weight=0]`,
+				`"C"--"D"[color="blue",weight=0]`,
+			},
+		},
+		"edges with later highlight taking precedence": {
+			options: []func(*description){
+				HighlightEdges([]graph.Edge[string]{{Source: "D", Target: "C"}}, DefaultHighlight),
+				HighlightEdges([]graph.Edge[string]{{Source: "C", Target: "D"}}, Style{Color: "green"}),
+			},
+			expected: []string{
+				`"C"--"D"[color="green",fontcolor="red",penwidth="2.5",weight=0]`,
+				`"D"[color="green",fontcolor="red",penwidth="2.5",weight=0]`,
+				`"A"[weight=0]`,
+			},
+		},
+		"path with missing edge": {
+			options:    []func(*description){Highlight([]string{"A", "C"}, DefaultHighlight)},
+			shouldFail: true,
+		},
+		"path with missing vertex": {
+			options:    []func(*description){Highlight([]string{"E"}, DefaultHighlight)},
+			shouldFail: true,
+		},
+		"mismatched hash type": {
+			options:    []func(*description){Highlight([]int{1}, DefaultHighlight)},
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		buf := new(bytes.Buffer)
+
+		err := DOT(g, buf, test.options...)
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		output := normalizeOutput(buf.String())
+
+		for _, expected := range test.expected {
+			if !strings.Contains(output, expected) {
+				t.Errorf("%s: expected %v in DOT output %v", name, expected, output)
+			}
+		}
+	}
+}
+
+func TestGenerateDOT_undirected(t *testing.T) {
+	g := graph.New(graph.StringHash)
+
+	for _, vertex := range []string{"A", "B", "C"} {
+		_ = g.AddVertex(vertex)
+	}
+	_ = g.AddEdge("B", "A", graph.EdgeAttribute("color", "red"))
+	_ = g.AddEdge("B", "C")
+	_ = g.AddEdge("C", "C")
+
+	desc, err := generateDOT(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	edges := make([]string, 0)
+	for _, stmt := range desc.Statements {
+		if stmt.Target != nil {
+			edges = append(edges, fmt.Sprintf("%v-%v", stmt.Source, stmt.Target))
+		}
+	}
+
+	expected := []string{"A-B", "B-C", "C-C"}
+
+	if !slicesAreEqual(edges, expected, func(a, b string) bool { return a == b }) {
+		t.Errorf("edges expectancy doesn't match: expected %v, got %v", expected, edges)
+	}
+}
+
+func TestUndirectedEdge(t *testing.T) {
+	adjacencyMap := map[string]map[string]graph.Edge[string]{
+		"A": {
+			"B": {Source: "A", Target: "B", Properties: graph.EdgeProperties{
+				Attributes: map[string]string{"color": "red"},
+			}},
+		},
+		"B": {
+			"A": {Source: "B", Target: "A", Properties: graph.EdgeProperties{
+				Attributes: map[string]string{"color": "blue", "style": "dashed"},
+				Weight:     3,
+				Data:       "data",
+			}},
+		},
+	}
+
+	emitted := make(map[edgeKey[string]]struct{})
+
+	if _, ok := undirectedEdge(adjacencyMap, "B", "A", emitted); ok {
+		t.Errorf("edge (B, A) should not be rendered")
+	}
+
+	edge, ok := undirectedEdge(adjacencyMap, "A", "B", emitted)
+	if !ok {
+		t.Fatalf("edge (A, B) should be rendered")
+	}
+
+	expected := graph.EdgeProperties{
+		Attributes: map[string]string{"color": "red", "style": "dashed"},
+		Weight:     3,
+		Data:       "data",
+	}
+
+	if !mapsAreEqual(edge.Properties.Attributes, expected.Attributes, func(a, b string) bool { return a == b }) {
+		t.Errorf("attributes expectancy doesn't match: expected %v, got %v", expected.Attributes, edge.Properties.Attributes)
+	}
+
+	if edge.Properties.Weight != expected.Weight || edge.Properties.Data != expected.Data {
+		t.Errorf("properties expectancy doesn't match: expected %v, got %v", expected, edge.Properties)
+	}
+
+	// The attributes of the stored edge must not be modified.
+	if len(adjacencyMap["A"]["B"].Properties.Attributes) != 1 {
+		t.Errorf("stored attributes have been modified: %v", adjacencyMap["A"]["B"].Properties.Attributes)
+	}
+}
+
+func TestWithLegend(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	_ = g.AddVertex("A", graph.VertexAttribute("team", "core"))
+	_ = g.AddVertex("B", graph.VertexAttribute("team", "web"))
+	_ = g.AddEdge("A", "B", graph.EdgeAttribute("kind", "sync"))
+
+	theme := Theme{
+		Node: Style{Shape: ShapeBox},
+		VertexColors: &ColorMap{
+			Attribute: "team",
+			Colors:    map[string]string{"core": "lightblue", "ops": "gray"},
+			Palette:   []string{"yellow"},
+		},
+		EdgeColors: &ColorMap{
+			Attribute: "kind",
+			Palette:   []string{"green"},
+		},
+	}
+
+	legend := Legend{
+		Vertices: map[string]Style{"web": {FillColor: "orange"}},
+		Edges:    map[string]Style{"async": {LineStyle: LineDashed}},
+	}
+
+	buf := new(bytes.Buffer)
+
+	if err := DOT(g, buf, WithTheme(theme), WithLegend(legend)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := normalizeOutput(buf.String())
+
+	expected := normalizeOutput(`subgraph "cluster_legend" {
+		label="Legend";
+		"__legend_0" [ label="core", fillcolor="lightblue", shape="box", style="filled", ];
+		"__legend_1" [ label="web", fillcolor="orange", style="filled", ];
+		"__legend_2_source" [ shape="point", style="invis" ];
+		"__legend_2_target" [ shape="point", style="invis" ];
+		"__legend_2_source" -> "__legend_2_target" [ label="async", style="dashed", ];
+		"__legend_3_source" [ shape="point", style="invis" ];
+		"__legend_3_target" [ shape="point", style="invis" ];
+		"__legend_3_source" -> "__legend_3_target" [ label="sync", color="green", ];
+	}`)
+
+	if !strings.Contains(output, expected) {
+		t.Errorf("legend expectancy doesn't match: expected %v in %v", expected, output)
+	}
+
+	// The theme is applied to the graph regardless of the explicit legend.
+	if !strings.Contains(output, `"B"[fillcolor="yellow",style="filled",team="web",weight=0]`) {
+		t.Errorf("vertex B has not been colored by the theme: %v", output)
+	}
+}
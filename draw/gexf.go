@@ -0,0 +1,324 @@
+package draw
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dominikbraun/graph"
+)
+
+type gexfConfig struct {
+	startKey   string
+	endKey     string
+	timeFormat string
+}
+
+// GEXFTimestamps is a functional option for the [GEXF] function that turns the
+// output into a dynamic graph. The vertex and edge attributes with the given
+// keys are used as the start and end of their lifetime. Either key may be left
+// empty, and vertices and edges without these attributes exist all the time.
+//
+// The timestamp attributes aren't exported as regular attributes. Their format
+// is specified using [GEXFTimeFormat].
+func GEXFTimestamps(startKey, endKey string) func(*gexfConfig) {
+	return func(c *gexfConfig) {
+		c.startKey = startKey
+		c.endKey = endKey
+	}
+}
+
+// GEXFTimeFormat is a functional option for the [GEXF] function that specifies
+// the format of the timestamps set with [GEXFTimestamps]. Valid formats are
+// "integer", "double", "date", and "dateTime". The default is "double".
+func GEXFTimeFormat(format string) func(*gexfConfig) {
+	return func(c *gexfConfig) {
+		c.timeFormat = format
+	}
+}
+
+type gexfDocument struct {
+	XMLName xml.Name  `xml:"gexf"`
+	XMLNS   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+	Mode            string           `xml:"mode,attr"`
+	TimeFormat      string           `xml:"timeformat,attr,omitempty"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    int    `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	ID        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr"`
+	Start     string         `xml:"start,attr,omitempty"`
+	End       string         `xml:"end,attr,omitempty"`
+	AttValues *gexfAttValues `xml:"attvalues"`
+}
+
+type gexfEdge struct {
+	ID        int            `xml:"id,attr"`
+	Source    string         `xml:"source,attr"`
+	Target    string         `xml:"target,attr"`
+	Weight    *int           `xml:"weight,attr,omitempty"`
+	Start     string         `xml:"start,attr,omitempty"`
+	End       string         `xml:"end,attr,omitempty"`
+	AttValues *gexfAttValues `xml:"attvalues"`
+}
+
+type gexfAttValues struct {
+	Values []gexfAttValue `xml:"attvalue"`
+}
+
+type gexfAttValue struct {
+	For   int    `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// GEXF renders the given graph structure as GEXF 1.3 document into an
+// io.Writer. GEXF is the native format of Gephi, so the generated file can be
+// opened in Gephi for visual exploration:
+//
+//	file, _ := os.Create("./my-graph.gexf")
+//	_ = draw.GEXF(g, file)
+//
+// Vertex and edge attributes are exported as GEXF attributes of type string,
+// which are declared once for all vertices and once for all edges. Edge weights
+// are exported for weighted graphs only. The vertex hashes are formatted using
+// fmt.Sprint and used as IDs and labels.
+//
+// Passing the [GEXFTimestamps] functional option exports a dynamic graph whose
+// vertices and edges have lifetimes taken from their attributes:
+//
+//	_ = g.AddVertex(1, graph.VertexAttribute("since", "2020"))
+//	_ = draw.GEXF(g, file, draw.GEXFTimestamps("since", ""), draw.GEXFTimeFormat("integer"))
+func GEXF[K comparable, T any](g graph.Graph[K, T], w io.Writer, options ...func(*gexfConfig)) error {
+	doc, err := generateGEXF(g, options...)
+	if err != nil {
+		return fmt.Errorf("failed to generate GEXF document: %w", err)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode GEXF document: %w", err)
+	}
+
+	_, err = io.WriteString(w, "\n")
+
+	return err
+}
+
+func generateGEXF[K comparable, T any](g graph.Graph[K, T], options ...func(*gexfConfig)) (gexfDocument, error) {
+	config := gexfConfig{
+		timeFormat: "double",
+	}
+
+	for _, option := range options {
+		option(&config)
+	}
+
+	dynamic := config.startKey != "" || config.endKey != ""
+
+	doc := gexfDocument{
+		XMLNS:   "http://gexf.net/1.3",
+		Version: "1.3",
+		Graph: gexfGraph{
+			DefaultEdgeType: "undirected",
+			Mode:            "static",
+			Nodes:           make([]gexfNode, 0),
+			Edges:           make([]gexfEdge, 0),
+		},
+	}
+
+	if g.Traits().IsDirected {
+		doc.Graph.DefaultEdgeType = "directed"
+	}
+
+	if dynamic {
+		doc.Graph.Mode = "dynamic"
+		doc.Graph.TimeFormat = config.timeFormat
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return doc, err
+	}
+
+	// Sort the vertices by their IDs for a deterministic output.
+	ids := make(map[K]string, len(adjacencyMap))
+	hashes := make([]K, 0, len(adjacencyMap))
+
+	for hash := range adjacencyMap {
+		ids[hash] = fmt.Sprint(hash)
+		hashes = append(hashes, hash)
+	}
+
+	sort.Slice(hashes, func(i, j int) bool {
+		return ids[hashes[i]] < ids[hashes[j]]
+	})
+
+	nodeAttributes := newGEXFAttributeSet(config)
+
+	for _, hash := range hashes {
+		_, properties, err := g.VertexWithProperties(hash)
+		if err != nil {
+			return doc, err
+		}
+
+		doc.Graph.Nodes = append(doc.Graph.Nodes, gexfNode{
+			ID:        ids[hash],
+			Label:     ids[hash],
+			Start:     timestamp(properties.Attributes, config.startKey),
+			End:       timestamp(properties.Attributes, config.endKey),
+			AttValues: nodeAttributes.values(properties.Attributes),
+		})
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return doc, err
+	}
+
+	// Undirected edges are returned in an arbitrary direction, so they are
+	// normalized and all edges are sorted for a deterministic output.
+	if !g.Traits().IsDirected {
+		for i, edge := range edges {
+			if ids[edge.Target] < ids[edge.Source] {
+				edges[i].Source, edges[i].Target = edge.Target, edge.Source
+			}
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if ids[edges[i].Source] != ids[edges[j].Source] {
+			return ids[edges[i].Source] < ids[edges[j].Source]
+		}
+		return ids[edges[i].Target] < ids[edges[j].Target]
+	})
+
+	edgeAttributes := newGEXFAttributeSet(config)
+
+	for i, edge := range edges {
+		gexfEdge := gexfEdge{
+			ID:        i,
+			Source:    ids[edge.Source],
+			Target:    ids[edge.Target],
+			Start:     timestamp(edge.Properties.Attributes, config.startKey),
+			End:       timestamp(edge.Properties.Attributes, config.endKey),
+			AttValues: edgeAttributes.values(edge.Properties.Attributes),
+		}
+
+		if g.Traits().IsWeighted {
+			weight := edge.Properties.Weight
+			gexfEdge.Weight = &weight
+		}
+
+		doc.Graph.Edges = append(doc.Graph.Edges, gexfEdge)
+	}
+
+	for _, set := range []struct {
+		class      string
+		attributes *gexfAttributeSet
+	}{
+		{"node", nodeAttributes},
+		{"edge", edgeAttributes},
+	} {
+		if len(set.attributes.declarations) == 0 {
+			continue
+		}
+		doc.Graph.Attributes = append(doc.Graph.Attributes, gexfAttributes{
+			Class:      set.class,
+			Attributes: set.attributes.declarations,
+		})
+	}
+
+	return doc, nil
+}
+
+// gexfAttributeSet assigns IDs to attribute keys in the order they occur and
+// collects the corresponding declarations.
+type gexfAttributeSet struct {
+	config       gexfConfig
+	ids          map[string]int
+	declarations []gexfAttribute
+}
+
+func newGEXFAttributeSet(config gexfConfig) *gexfAttributeSet {
+	return &gexfAttributeSet{
+		config:       config,
+		ids:          make(map[string]int),
+		declarations: make([]gexfAttribute, 0),
+	}
+}
+
+// values returns the attribute values for the given attributes, declaring keys
+// that haven't been declared yet. The timestamp attributes are skipped.
+func (s *gexfAttributeSet) values(attributes map[string]string) *gexfAttValues {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		if key == s.config.startKey || key == s.config.endKey {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	// Returning nil omits the attvalues element entirely.
+	if len(keys) == 0 {
+		return nil
+	}
+
+	sort.Strings(keys)
+
+	values := make([]gexfAttValue, 0, len(keys))
+
+	for _, key := range keys {
+		id, ok := s.ids[key]
+		if !ok {
+			id = len(s.declarations)
+			s.ids[key] = id
+			s.declarations = append(s.declarations, gexfAttribute{
+				ID:    id,
+				Title: key,
+				Type:  "string",
+			})
+		}
+
+		values = append(values, gexfAttValue{
+			For:   id,
+			Value: attributes[key],
+		})
+	}
+
+	return &gexfAttValues{Values: values}
+}
+
+func timestamp(attributes map[string]string, key string) string {
+	if key == "" {
+		return ""
+	}
+
+	return attributes[key]
+}
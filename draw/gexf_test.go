@@ -0,0 +1,89 @@
+package draw
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestGEXF(t *testing.T) {
+	tests := map[string]struct {
+		graph    graph.Graph[string, string]
+		options  []func(*gexfConfig)
+		expected string
+	}{
+		"directed, weighted graph": {
+			graph: graph.New(graph.StringHash, graph.Directed(), graph.Weighted()),
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<gexf xmlns="http://gexf.net/1.3" version="1.3">
+  <graph defaultedgetype="directed" mode="static">
+    <attributes class="node">
+      <attribute id="0" title="color" type="string"></attribute>
+      <attribute id="1" title="since" type="string"></attribute>
+    </attributes>
+    <attributes class="edge">
+      <attribute id="0" title="since" type="string"></attribute>
+    </attributes>
+    <nodes>
+      <node id="A" label="A">
+        <attvalues>
+          <attvalue for="0" value="red"></attvalue>
+          <attvalue for="1" value="2"></attvalue>
+        </attvalues>
+      </node>
+      <node id="B" label="B"></node>
+    </nodes>
+    <edges>
+      <edge id="0" source="A" target="B" weight="3">
+        <attvalues>
+          <attvalue for="0" value="4"></attvalue>
+        </attvalues>
+      </edge>
+    </edges>
+  </graph>
+</gexf>
+`,
+		},
+		"undirected, dynamic graph": {
+			graph:   graph.New(graph.StringHash),
+			options: []func(*gexfConfig){GEXFTimestamps("since", ""), GEXFTimeFormat("integer")},
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<gexf xmlns="http://gexf.net/1.3" version="1.3">
+  <graph defaultedgetype="undirected" mode="dynamic" timeformat="integer">
+    <attributes class="node">
+      <attribute id="0" title="color" type="string"></attribute>
+    </attributes>
+    <nodes>
+      <node id="A" label="A" start="2">
+        <attvalues>
+          <attvalue for="0" value="red"></attvalue>
+        </attvalues>
+      </node>
+      <node id="B" label="B"></node>
+    </nodes>
+    <edges>
+      <edge id="0" source="A" target="B" start="4"></edge>
+    </edges>
+  </graph>
+</gexf>
+`,
+		},
+	}
+
+	for name, test := range tests {
+		_ = test.graph.AddVertex("A", graph.VertexAttribute("color", "red"), graph.VertexAttribute("since", "2"))
+		_ = test.graph.AddVertex("B")
+		_ = test.graph.AddEdge("A", "B", graph.EdgeWeight(3), graph.EdgeAttribute("since", "4"))
+
+		var buf bytes.Buffer
+
+		if err := GEXF(test.graph, &buf, test.options...); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if buf.String() != test.expected {
+			t.Errorf("%s: GEXF output doesn't match: expected\n%v\ngot\n%v", name, test.expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package draw
+
+import (
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+)
+
+// DefaultHighlight is a style suitable for highlighting vertices and edges with
+// [Highlight] and [HighlightEdges].
+var DefaultHighlight = Style{
+	Color:     "red",
+	PenWidth:  2.5,
+	FontColor: "red",
+}
+
+// highlight is a set of vertices and edges rendered with a distinct style. The
+// vertices and edges are stored as interface{} because description isn't
+// generic, and are asserted to []K and []graph.Edge[K] in generateDOT.
+type highlight struct {
+	path  interface{}
+	edges interface{}
+	style Style
+}
+
+// Highlight is a functional option for the [DOT] method that renders the given
+// path with the given style, while the rest of the graph is rendered normally.
+// The path is a sequence of vertex hashes, such as a path returned by
+// [graph.ShortestPath], and all of its vertices and the edges between
+// consecutive vertices are highlighted:
+//
+//	path, _ := graph.ShortestPath(g, "A", "B")
+//
+//	_ = draw.DOT(g, file, draw.Highlight(path, draw.DefaultHighlight))
+//
+// The highlight style takes precedence over all other styles and attributes.
+// The option may be passed multiple times, in which case later highlights take
+// precedence. If the path contains vertices or edges that don't exist, or if the
+// type parameter K doesn't match the hash type of the graph, DOT returns an
+// error.
+func Highlight[K comparable](path []K, style Style) func(*description) {
+	return func(d *description) {
+		d.highlights = append(d.highlights, highlight{path: path, style: style})
+	}
+}
+
+// HighlightEdges works like [Highlight], but highlights an arbitrary set of
+// edges and their vertices, such as the edges of a minimum spanning tree. In
+// undirected graphs, the direction of the edges doesn't matter.
+func HighlightEdges[K comparable](edges []graph.Edge[K], style Style) func(*description) {
+	return func(d *description) {
+		d.highlights = append(d.highlights, highlight{edges: edges, style: style})
+	}
+}
+
+type edgeKey[K comparable] struct {
+	source K
+	target K
+}
+
+// highlightStyles computes the highlight styles of the vertices and edges. The
+// edge styles are keyed by source and target, and for undirected graphs, they
+// contain both directions.
+func highlightStyles[K comparable](highlights []highlight, adjacencyMap map[K]map[K]graph.Edge[K], isDirected bool) (map[K]Style, map[edgeKey[K]]Style, error) {
+	vertices := make(map[K]Style)
+	edges := make(map[edgeKey[K]]Style)
+
+	for _, h := range highlights {
+		var highlighted []graph.Edge[K]
+
+		if h.path != nil {
+			path, ok := h.path.([]K)
+			if !ok {
+				return nil, nil, fmt.Errorf("highlighted path has type %T, expected %T", h.path, path)
+			}
+			for _, hash := range path {
+				if _, ok := adjacencyMap[hash]; !ok {
+					return nil, nil, fmt.Errorf("highlighted vertex %v: %w", hash, graph.ErrVertexNotFound)
+				}
+				vertices[hash] = vertices[hash].overlay(h.style)
+			}
+			for i := 1; i < len(path); i++ {
+				highlighted = append(highlighted, graph.Edge[K]{Source: path[i-1], Target: path[i]})
+			}
+		}
+
+		if h.edges != nil {
+			var ok bool
+			if highlighted, ok = h.edges.([]graph.Edge[K]); !ok {
+				return nil, nil, fmt.Errorf("highlighted edges have type %T, expected %T", h.edges, highlighted)
+			}
+		}
+
+		for _, edge := range highlighted {
+			if _, ok := adjacencyMap[edge.Source][edge.Target]; !ok {
+				return nil, nil, fmt.Errorf("highlighted edge (%v, %v): %w", edge.Source, edge.Target, graph.ErrEdgeNotFound)
+			}
+
+			vertices[edge.Source] = vertices[edge.Source].overlay(h.style)
+			vertices[edge.Target] = vertices[edge.Target].overlay(h.style)
+
+			key := edgeKey[K]{source: edge.Source, target: edge.Target}
+			edges[key] = edges[key].overlay(h.style)
+
+			if !isDirected {
+				key = edgeKey[K]{source: edge.Target, target: edge.Source}
+				edges[key] = edges[key].overlay(h.style)
+			}
+		}
+	}
+
+	return vertices, edges, nil
+}
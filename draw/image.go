@@ -0,0 +1,66 @@
+package draw
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/dominikbraun/graph"
+)
+
+// ErrGraphvizNotFound is returned by [SVG] and [PNG] if the Graphviz dot binary
+// can't be found in the PATH.
+var ErrGraphvizNotFound = errors.New("graphviz dot binary not found")
+
+// SVG renders the given graph as SVG image into an io.Writer. It generates the
+// DOT description of the graph and lays it out using the Graphviz dot binary,
+// which has to be installed and found in the PATH. Otherwise, SVG returns an
+// error wrapping [ErrGraphvizNotFound].
+//
+//	file, _ := os.Create("./my-graph.svg")
+//	_ = draw.SVG(g, file)
+//
+// SVG accepts the same functional options as [DOT].
+func SVG[K comparable, T any](g graph.Graph[K, T], w io.Writer, options ...func(*description)) error {
+	return renderImage(g, w, "svg", options...)
+}
+
+// PNG renders the given graph as PNG image into an io.Writer. The same
+// requirements as for [SVG] apply.
+func PNG[K comparable, T any](g graph.Graph[K, T], w io.Writer, options ...func(*description)) error {
+	return renderImage(g, w, "png", options...)
+}
+
+func renderImage[K comparable, T any](g graph.Graph[K, T], w io.Writer, format string, options ...func(*description)) error {
+	path, err := exec.LookPath("dot")
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGraphvizNotFound, err)
+	}
+
+	desc, err := generateDOT(g, options...)
+	if err != nil {
+		return fmt.Errorf("failed to generate DOT description: %w", err)
+	}
+
+	var input bytes.Buffer
+
+	if err := renderDOT(&input, desc); err != nil {
+		return fmt.Errorf("failed to render DOT description: %w", err)
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(path, "-T"+format)
+	cmd.Stdin = &input
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %s: %w: %s", path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
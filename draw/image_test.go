@@ -0,0 +1,53 @@
+package draw
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestSVG(t *testing.T) {
+	if _, err := exec.LookPath("dot"); err != nil {
+		t.Skip("graphviz dot binary not installed")
+	}
+
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddEdge("A", "B")
+
+	buf := new(bytes.Buffer)
+
+	if err := SVG(g, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "<svg") {
+		t.Errorf("output is no SVG image: %v", buf.String())
+	}
+
+	buf.Reset()
+
+	if err := PNG(g, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.HasPrefix(buf.Bytes(), []byte("\x89PNG")) {
+		t.Errorf("output is no PNG image")
+	}
+}
+
+func TestSVG_graphvizNotFound(t *testing.T) {
+	t.Setenv("PATH", "")
+
+	g := graph.New(graph.StringHash)
+
+	if err := SVG(g, new(bytes.Buffer)); !errors.Is(err, ErrGraphvizNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrGraphvizNotFound, err)
+	}
+}
@@ -0,0 +1,93 @@
+package draw
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Legend describes the meaning of the styles used in a graph. Vertices maps a
+// description to the style of the vertices it applies to, and Edges maps a
+// description to the style of the edges it applies to.
+type Legend struct {
+	Vertices map[string]Style
+	Edges    map[string]Style
+}
+
+// legendEntry is a vertex or edge rendered in the legend. For edges, two
+// invisible vertices are rendered and connected with the edge.
+type legendEntry struct {
+	ID         string
+	Label      string
+	Edge       bool
+	Attributes map[string]string
+}
+
+// WithLegend is a functional option for the [DOT] method that renders a legend
+// as a cluster subgraph labeled "Legend", so that exported diagrams are
+// self-explanatory. Each vertex entry is rendered as a vertex with the given
+// style, and each edge entry is rendered as an edge with the given style. The
+// entries are labeled with their descriptions:
+//
+//	legend := draw.Legend{
+//		Vertices: map[string]draw.Style{"service": {Shape: draw.ShapeBox}},
+//		Edges:    map[string]draw.Style{"async call": {LineStyle: draw.LineDashed}},
+//	}
+//
+//	_ = draw.DOT(g, file, draw.WithLegend(legend))
+//
+// If a theme with color maps is applied using [WithTheme], the legend also
+// contains an entry for each color used in the graph, labeled with the
+// corresponding attribute value. Explicit entries take precedence over these.
+func WithLegend(legend Legend) func(*description) {
+	return func(d *description) {
+		d.legend = &legend
+	}
+}
+
+// legendEntries builds the entries of the given legend, complemented with the
+// colors of the theme. Vertex entries come before edge entries, and both are
+// sorted by their labels.
+func legendEntries(legend Legend, theme Theme, vertexColors, edgeColors map[string]string) []legendEntry {
+	vertices := make(map[string]Style, len(vertexColors)+len(legend.Vertices))
+	edges := make(map[string]Style, len(edgeColors)+len(legend.Edges))
+
+	for value, color := range vertexColors {
+		vertices[value] = Style{Shape: theme.Node.Shape, FillColor: color}
+	}
+	for value, color := range edgeColors {
+		edges[value] = Style{Color: color}
+	}
+	for label, style := range legend.Vertices {
+		vertices[label] = style
+	}
+	for label, style := range legend.Edges {
+		edges[label] = style
+	}
+
+	entries := make([]legendEntry, 0, len(vertices)+len(edges))
+
+	for _, group := range []struct {
+		styles map[string]Style
+		edge   bool
+	}{
+		{vertices, false},
+		{edges, true},
+	} {
+		labels := make([]string, 0, len(group.styles))
+		for label := range group.styles {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+
+		for _, label := range labels {
+			entries = append(entries, legendEntry{
+				ID:         fmt.Sprintf("__legend_%d", len(entries)),
+				Label:      escape(label),
+				Edge:       group.edge,
+				Attributes: group.styles[label].attributes(),
+			})
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,105 @@
+package draw
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dominikbraun/graph"
+)
+
+// Pajek renders the given graph structure in the Pajek NET format into an
+// io.Writer. The NET format is supported by Pajek and many other social network
+// analysis tools, such as igraph, NetworkX, or UCINET:
+//
+//	file, _ := os.Create("./my-graph.net")
+//	_ = draw.Pajek(g, file)
+//
+// The vertices are numbered starting with 1 in the order of their hashes
+// formatted using fmt.Sprint, which are also used as labels. Edges are written
+// as *Arcs for directed graphs and as *Edges for undirected graphs. Edge weights
+// are written for weighted graphs only. Vertex and edge attributes aren't
+// exported.
+func Pajek[K comparable, T any](g graph.Graph[K, T], w io.Writer) error {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	labels := make(map[K]string, len(adjacencyMap))
+	hashes := make([]K, 0, len(adjacencyMap))
+
+	for hash := range adjacencyMap {
+		labels[hash] = fmt.Sprint(hash)
+		hashes = append(hashes, hash)
+	}
+
+	sort.Slice(hashes, func(i, j int) bool {
+		return labels[hashes[i]] < labels[hashes[j]]
+	})
+
+	numbers := make(map[K]int, len(hashes))
+
+	buf := bufio.NewWriter(w)
+
+	fmt.Fprintf(buf, "*Vertices %d\n", len(hashes))
+
+	for i, hash := range hashes {
+		numbers[hash] = i + 1
+		// Pajek labels can't contain double quotes, so they are replaced.
+		fmt.Fprintf(buf, "%d \"%s\"\n", i+1, strings.ReplaceAll(labels[hash], `"`, `'`))
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	isDirected := g.Traits().IsDirected
+
+	if isDirected {
+		fmt.Fprintln(buf, "*Arcs")
+	} else {
+		fmt.Fprintln(buf, "*Edges")
+	}
+
+	lines := make([]pajekLine, 0, len(edges))
+
+	for _, edge := range edges {
+		line := pajekLine{
+			source: numbers[edge.Source],
+			target: numbers[edge.Target],
+			weight: edge.Properties.Weight,
+		}
+		// Undirected edges are written with the lower vertex number first.
+		if !isDirected && line.source > line.target {
+			line.source, line.target = line.target, line.source
+		}
+		lines = append(lines, line)
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].source != lines[j].source {
+			return lines[i].source < lines[j].source
+		}
+		return lines[i].target < lines[j].target
+	})
+
+	for _, line := range lines {
+		if g.Traits().IsWeighted {
+			fmt.Fprintf(buf, "%d %d %d\n", line.source, line.target, line.weight)
+		} else {
+			fmt.Fprintf(buf, "%d %d\n", line.source, line.target)
+		}
+	}
+
+	return buf.Flush()
+}
+
+type pajekLine struct {
+	source int
+	target int
+	weight int
+}
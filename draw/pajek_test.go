@@ -0,0 +1,42 @@
+package draw
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestPajek(t *testing.T) {
+	tests := map[string]struct {
+		graph    graph.Graph[string, string]
+		expected string
+	}{
+		"directed graph": {
+			graph:    graph.New(graph.StringHash, graph.Directed()),
+			expected: "*Vertices 3\n1 \"A\"\n2 \"B\"\n3 \"C 'x'\"\n*Arcs\n1 2\n2 3\n",
+		},
+		"undirected, weighted graph": {
+			graph:    graph.New(graph.StringHash, graph.Weighted()),
+			expected: "*Vertices 3\n1 \"A\"\n2 \"B\"\n3 \"C 'x'\"\n*Edges\n1 2 4\n2 3 0\n",
+		},
+	}
+
+	for name, test := range tests {
+		_ = test.graph.AddVertex("C \"x\"")
+		_ = test.graph.AddVertex("B")
+		_ = test.graph.AddVertex("A")
+		_ = test.graph.AddEdge("A", "B", graph.EdgeWeight(4))
+		_ = test.graph.AddEdge("B", "C \"x\"")
+
+		var buf bytes.Buffer
+
+		if err := Pajek(test.graph, &buf); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if buf.String() != test.expected {
+			t.Errorf("%s: output doesn't match: expected %q, got %q", name, test.expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package draw
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dominikbraun/graph"
+)
+
+// Shape is the shape of a vertex in the DOT output. See the Graphviz
+// documentation for a visual overview: https://graphviz.org/doc/info/shapes.html
+type Shape string
+
+const (
+	ShapeBox       Shape = "box"
+	ShapeCircle    Shape = "circle"
+	ShapeDiamond   Shape = "diamond"
+	ShapeEllipse   Shape = "ellipse"
+	ShapeHexagon   Shape = "hexagon"
+	ShapePlaintext Shape = "plaintext"
+	ShapePoint     Shape = "point"
+	ShapeRecord    Shape = "record"
+)
+
+// LineStyle is the style of the line of an edge or of the outline of a vertex.
+type LineStyle string
+
+const (
+	LineSolid  LineStyle = "solid"
+	LineDashed LineStyle = "dashed"
+	LineDotted LineStyle = "dotted"
+	LineBold   LineStyle = "bold"
+)
+
+// Style is a typed set of Graphviz attributes for vertices and edges. Only
+// fields with a non-zero value are rendered, and Shape only applies to
+// vertices. Setting a FillColor also sets the "filled" style, which is required
+// by Graphviz to actually fill a vertex.
+type Style struct {
+	Shape     Shape
+	Color     string
+	FillColor string
+	LineStyle LineStyle
+	PenWidth  float64
+	FontName  string
+	FontSize  float64
+	FontColor string
+}
+
+// attributes returns the Graphviz attributes for the style. The values are
+// already escaped for usage in a quoted DOT string.
+func (s Style) attributes() map[string]string {
+	attributes := make(map[string]string)
+
+	set := func(key, value string) {
+		if value != "" {
+			attributes[key] = escape(value)
+		}
+	}
+
+	setFloat := func(key string, value float64) {
+		if value != 0 {
+			attributes[key] = strconv.FormatFloat(value, 'f', -1, 64)
+		}
+	}
+
+	styles := make([]string, 0, 2)
+	if s.FillColor != "" {
+		styles = append(styles, "filled")
+	}
+	if s.LineStyle != "" {
+		styles = append(styles, string(s.LineStyle))
+	}
+
+	set("shape", string(s.Shape))
+	set("color", s.Color)
+	set("fillcolor", s.FillColor)
+	set("style", strings.Join(styles, ","))
+	setFloat("penwidth", s.PenWidth)
+	set("fontname", s.FontName)
+	setFloat("fontsize", s.FontSize)
+	set("fontcolor", s.FontColor)
+
+	return attributes
+}
+
+// merge overlays the given style on top of the given attributes. The returned
+// map is a copy and may be modified, while the input attributes stay untouched.
+func (s Style) merge(attributes map[string]string) map[string]string {
+	result := make(map[string]string, len(attributes))
+
+	for key, value := range attributes {
+		result[key] = value
+	}
+
+	for key, value := range s.attributes() {
+		result[key] = value
+	}
+
+	return result
+}
+
+// NodeShape is a functional option for the [DOT] method that sets the default
+// shape of all vertices.
+func NodeShape(shape Shape) func(*description) {
+	return func(d *description) {
+		d.nodeStyle.Shape = shape
+	}
+}
+
+// NodeColor is a functional option for the [DOT] method that sets the default
+// outline color of all vertices. Colors may be given as name, such as "red", or
+// as RGB value, such as "#ff0000".
+func NodeColor(color string) func(*description) {
+	return func(d *description) {
+		d.nodeStyle.Color = color
+	}
+}
+
+// NodeFillColor is a functional option for the [DOT] method that sets the
+// default fill color of all vertices.
+func NodeFillColor(color string) func(*description) {
+	return func(d *description) {
+		d.nodeStyle.FillColor = color
+	}
+}
+
+// EdgeColor is a functional option for the [DOT] method that sets the default
+// color of all edges.
+func EdgeColor(color string) func(*description) {
+	return func(d *description) {
+		d.edgeStyle.Color = color
+	}
+}
+
+// EdgeStyleDashed is a functional option for the [DOT] method that renders all
+// edges as dashed lines by default.
+func EdgeStyleDashed() func(*description) {
+	return func(d *description) {
+		d.edgeStyle.LineStyle = LineDashed
+	}
+}
+
+// EdgeStyleDotted is a functional option for the [DOT] method that renders all
+// edges as dotted lines by default.
+func EdgeStyleDotted() func(*description) {
+	return func(d *description) {
+		d.edgeStyle.LineStyle = LineDotted
+	}
+}
+
+// EdgeStyleBold is a functional option for the [DOT] method that renders all
+// edges as bold lines by default.
+func EdgeStyleBold() func(*description) {
+	return func(d *description) {
+		d.edgeStyle.LineStyle = LineBold
+	}
+}
+
+// FontName is a functional option for the [DOT] method that sets the font of
+// the graph label, the vertices, and the edges.
+func FontName(name string) func(*description) {
+	return func(d *description) {
+		d.Attributes["fontname"] = escape(name)
+		d.nodeStyle.FontName = name
+		d.edgeStyle.FontName = name
+	}
+}
+
+// FontSize is a functional option for the [DOT] method that sets the font size
+// of the graph label, the vertices, and the edges in points.
+func FontSize(size float64) func(*description) {
+	return func(d *description) {
+		d.Attributes["fontsize"] = strconv.FormatFloat(size, 'f', -1, 64)
+		d.nodeStyle.FontSize = size
+		d.edgeStyle.FontSize = size
+	}
+}
+
+// VertexStyle is a functional option for the [DOT] method that computes a style
+// for each vertex. The style takes precedence over the default styles and the
+// vertex attributes. For example, vertices with a high weight could be
+// highlighted as follows:
+//
+//	_ = draw.DOT(g, file, draw.VertexStyle(func(vertex string, properties graph.VertexProperties) draw.Style {
+//		if properties.Weight > 10 {
+//			return draw.Style{FillColor: "orange"}
+//		}
+//		return draw.Style{}
+//	}))
+//
+// The type parameter K has to match the hash type of the graph passed to [DOT],
+// otherwise DOT returns an error.
+func VertexStyle[K comparable](style func(vertex K, properties graph.VertexProperties) Style) func(*description) {
+	return func(d *description) {
+		d.vertexStyle = style
+	}
+}
+
+// EdgeStyle is a functional option for the [DOT] method that computes a style
+// for each edge. The style takes precedence over the default styles and the
+// edge attributes. The type parameter K has to match the hash type of the graph
+// passed to [DOT], otherwise DOT returns an error.
+func EdgeStyle[K comparable](style func(edge graph.Edge[K]) Style) func(*description) {
+	return func(d *description) {
+		d.edgeStyleFunc = style
+	}
+}
+
+// escape escapes double quotes so that the given value can be used in a quoted
+// DOT string.
+func escape(value string) string {
+	return strings.ReplaceAll(value, `"`, `\"`)
+}
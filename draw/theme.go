@@ -0,0 +1,137 @@
+package draw
+
+import (
+	"sort"
+)
+
+// DefaultPalette is a palette of eight qualitative colors suitable for
+// distinguishing categories. It is used by a [ColorMap] without palette.
+var DefaultPalette = []string{
+	"#66c2a5", "#fc8d62", "#8da0cb", "#e78ac3", "#a6d854", "#ffd92f", "#e5c494", "#b3b3b3",
+}
+
+// Theme is a set of default styles applied when rendering a graph using the
+// [WithTheme] option. Themes allow consistent styling without setting
+// attributes on every vertex and edge at construction time:
+//
+//	theme := draw.Theme{
+//		Graph: map[string]string{"bgcolor": "white"},
+//		Node:  draw.Style{Shape: draw.ShapeBox, FontName: "Helvetica"},
+//		Edge:  draw.Style{Color: "gray40"},
+//		VertexColors: &draw.ColorMap{
+//			Attribute: "team",
+//			Colors:    map[string]string{"core": "lightblue"},
+//		},
+//	}
+//
+//	_ = draw.DOT(g, file, draw.WithTheme(theme))
+//
+// The theme has the lowest precedence: Graph attributes set using
+// [GraphAttribute], typed options such as [NodeShape], attributes of individual
+// vertices and edges, as well as [VertexStyle] and [EdgeStyle] take precedence.
+type Theme struct {
+	// Graph contains the default graph attributes.
+	Graph map[string]string
+	// Node and Edge are the default styles for all vertices and edges.
+	Node Style
+	Edge Style
+	// VertexColors and EdgeColors optionally map an attribute value of a
+	// vertex or edge to its fill color or line color, respectively.
+	VertexColors *ColorMap
+	EdgeColors   *ColorMap
+}
+
+// ColorMap maps the values of an attribute to colors. Values contained in Colors
+// are mapped to the corresponding color. All other values are assigned a color
+// from Palette in the sorted order of the values, starting over if there are
+// more values than colors. If Palette is empty, [DefaultPalette] is used.
+// Vertices and edges without the attribute aren't colored.
+type ColorMap struct {
+	Attribute string
+	Colors    map[string]string
+	Palette   []string
+}
+
+// WithTheme is a functional option for the [DOT] method that applies the given
+// theme. See [Theme] for an example.
+func WithTheme(theme Theme) func(*description) {
+	return func(d *description) {
+		d.theme = theme
+	}
+}
+
+// colors returns the mapping from attribute values to colors for the given
+// attribute values, which may contain duplicates. Only the given values are
+// contained in the mapping.
+func (c *ColorMap) colors(values []string) map[string]string {
+	palette := c.Palette
+	if len(palette) == 0 {
+		palette = DefaultPalette
+	}
+
+	colors := make(map[string]string)
+
+	sort.Strings(values)
+
+	i := 0
+	for _, value := range values {
+		if _, ok := colors[value]; ok {
+			continue
+		}
+		if color, ok := c.Colors[value]; ok {
+			colors[value] = color
+			continue
+		}
+		colors[value] = palette[i%len(palette)]
+		i++
+	}
+
+	return colors
+}
+
+// lookup returns the color for the given attributes from the colors computed
+// using [ColorMap.colors]. It is safe to call lookup on a nil ColorMap.
+func (c *ColorMap) lookup(colors, attributes map[string]string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	value, ok := attributes[c.Attribute]
+	if !ok {
+		return "", false
+	}
+
+	color, ok := colors[value]
+	return color, ok
+}
+
+// overlay returns a copy of s in which all fields that are set in other are
+// replaced with the values from other.
+func (s Style) overlay(other Style) Style {
+	if other.Shape != "" {
+		s.Shape = other.Shape
+	}
+	if other.Color != "" {
+		s.Color = other.Color
+	}
+	if other.FillColor != "" {
+		s.FillColor = other.FillColor
+	}
+	if other.LineStyle != "" {
+		s.LineStyle = other.LineStyle
+	}
+	if other.PenWidth != 0 {
+		s.PenWidth = other.PenWidth
+	}
+	if other.FontName != "" {
+		s.FontName = other.FontName
+	}
+	if other.FontSize != 0 {
+		s.FontSize = other.FontSize
+	}
+	if other.FontColor != "" {
+		s.FontColor = other.FontColor
+	}
+
+	return s
+}
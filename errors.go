@@ -0,0 +1,127 @@
+package graph
+
+import "fmt"
+
+// VertexNotFoundError is returned if the vertex with the given hash doesn't
+// exist. It wraps ErrVertexNotFound, so errors.Is(err, ErrVertexNotFound)
+// still holds. Use errors.As to find out which vertex was involved:
+//
+//	var notFound *graph.VertexNotFoundError[int]
+//	if errors.As(err, &notFound) {
+//		fmt.Println("missing vertex:", notFound.Hash)
+//	}
+type VertexNotFoundError[K comparable] struct {
+	Hash K
+}
+
+func (e *VertexNotFoundError[K]) Error() string {
+	return fmt.Sprintf("vertex %v not found", e.Hash)
+}
+
+func (e *VertexNotFoundError[K]) Unwrap() error {
+	return ErrVertexNotFound
+}
+
+// VertexAlreadyExistsError is returned if a vertex with the given hash already
+// exists. It wraps ErrVertexAlreadyExists.
+type VertexAlreadyExistsError[K comparable] struct {
+	Hash K
+}
+
+func (e *VertexAlreadyExistsError[K]) Error() string {
+	return fmt.Sprintf("vertex %v already exists", e.Hash)
+}
+
+func (e *VertexAlreadyExistsError[K]) Unwrap() error {
+	return ErrVertexAlreadyExists
+}
+
+// VertexHasEdgesError is returned if the vertex with the given hash can't be
+// removed because it still has edges. It wraps ErrVertexHasEdges.
+type VertexHasEdgesError[K comparable] struct {
+	Hash K
+}
+
+func (e *VertexHasEdgesError[K]) Error() string {
+	return fmt.Sprintf("vertex %v has edges", e.Hash)
+}
+
+func (e *VertexHasEdgesError[K]) Unwrap() error {
+	return ErrVertexHasEdges
+}
+
+// EdgeNotFoundError is returned if the edge between the given source and target
+// vertices doesn't exist. It wraps ErrEdgeNotFound.
+type EdgeNotFoundError[K comparable] struct {
+	Source K
+	Target K
+}
+
+func (e *EdgeNotFoundError[K]) Error() string {
+	return fmt.Sprintf("edge (%v, %v) not found", e.Source, e.Target)
+}
+
+func (e *EdgeNotFoundError[K]) Unwrap() error {
+	return ErrEdgeNotFound
+}
+
+// EdgeAlreadyExistsError is returned if the edge between the given source and
+// target vertices already exists. It wraps ErrEdgeAlreadyExists.
+type EdgeAlreadyExistsError[K comparable] struct {
+	Source K
+	Target K
+}
+
+func (e *EdgeAlreadyExistsError[K]) Error() string {
+	return fmt.Sprintf("edge (%v, %v) already exists", e.Source, e.Target)
+}
+
+func (e *EdgeAlreadyExistsError[K]) Unwrap() error {
+	return ErrEdgeAlreadyExists
+}
+
+// EdgeCreatesCycleError is returned if the edge between the given source and
+// target vertices can't be added because it would create a cycle. It wraps
+// ErrEdgeCreatesCycle.
+type EdgeCreatesCycleError[K comparable] struct {
+	Source K
+	Target K
+}
+
+func (e *EdgeCreatesCycleError[K]) Error() string {
+	return fmt.Sprintf("edge (%v, %v) would create a cycle", e.Source, e.Target)
+}
+
+func (e *EdgeCreatesCycleError[K]) Unwrap() error {
+	return ErrEdgeCreatesCycle
+}
+
+// vertexError replaces the bare vertex-related sentinel errors returned by
+// stores that don't report the hash with the corresponding typed error. Other
+// errors, including typed errors, are returned unchanged.
+func vertexError[K comparable](hash K, err error) error {
+	switch err {
+	case ErrVertexNotFound:
+		return &VertexNotFoundError[K]{Hash: hash}
+	case ErrVertexAlreadyExists:
+		return &VertexAlreadyExistsError[K]{Hash: hash}
+	case ErrVertexHasEdges:
+		return &VertexHasEdgesError[K]{Hash: hash}
+	}
+	return err
+}
+
+// edgeError replaces the bare edge-related sentinel errors returned by stores
+// that don't report the vertices of the edge with the corresponding typed
+// error. Other errors, including typed errors, are returned unchanged.
+func edgeError[K comparable](source, target K, err error) error {
+	switch err {
+	case ErrEdgeNotFound:
+		return &EdgeNotFoundError[K]{Source: source, Target: target}
+	case ErrEdgeAlreadyExists:
+		return &EdgeAlreadyExistsError[K]{Source: source, Target: target}
+	case ErrEdgeCreatesCycle:
+		return &EdgeCreatesCycleError[K]{Source: source, Target: target}
+	}
+	return err
+}
@@ -0,0 +1,206 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+// sentinelStore is a store that returns the bare sentinel errors instead of the
+// typed errors, like stores that haven't adopted the typed errors yet.
+type sentinelStore[K comparable, T any] struct {
+	Store[K, T]
+}
+
+func (s sentinelStore[K, T]) Vertex(hash K) (T, VertexProperties, error) {
+	vertex, properties, err := s.Store.Vertex(hash)
+	if errors.Is(err, ErrVertexNotFound) {
+		return vertex, properties, ErrVertexNotFound
+	}
+	return vertex, properties, err
+}
+
+func (s sentinelStore[K, T]) Edge(sourceHash, targetHash K) (Edge[K], error) {
+	edge, err := s.Store.Edge(sourceHash, targetHash)
+	if errors.Is(err, ErrEdgeNotFound) {
+		return edge, ErrEdgeNotFound
+	}
+	return edge, err
+}
+
+func TestTypedErrors(t *testing.T) {
+	tests := map[string]struct {
+		store       func() Store[int, int]
+		directed    bool
+		operation   func(g Graph[int, int]) error
+		sentinel    error
+		expectedErr error
+	}{
+		"vertex not found": {
+			operation: func(g Graph[int, int]) error {
+				_, err := g.Vertex(42)
+				return err
+			},
+			sentinel:    ErrVertexNotFound,
+			expectedErr: &VertexNotFoundError[int]{Hash: 42},
+		},
+		"vertex already exists": {
+			operation: func(g Graph[int, int]) error {
+				return g.AddVertex(1)
+			},
+			sentinel:    ErrVertexAlreadyExists,
+			expectedErr: &VertexAlreadyExistsError[int]{Hash: 1},
+		},
+		"vertex has edges": {
+			directed: true,
+			operation: func(g Graph[int, int]) error {
+				return g.RemoveVertex(1)
+			},
+			sentinel:    ErrVertexHasEdges,
+			expectedErr: &VertexHasEdgesError[int]{Hash: 1},
+		},
+		"source vertex of new edge not found": {
+			operation: func(g Graph[int, int]) error {
+				return g.AddEdge(42, 1)
+			},
+			sentinel:    ErrVertexNotFound,
+			expectedErr: &VertexNotFoundError[int]{Hash: 42},
+		},
+		"vertex not found in cycle check of store": {
+			directed: true,
+			operation: func(g Graph[int, int]) error {
+				store, _ := storeOf(g)
+				_, err := store.(CycleStore[int]).CreatesCycle(1, 42)
+				return err
+			},
+			sentinel:    ErrVertexNotFound,
+			expectedErr: &VertexNotFoundError[int]{Hash: 42},
+		},
+		"edge not found in directed graph": {
+			directed: true,
+			operation: func(g Graph[int, int]) error {
+				_, err := g.Edge(2, 1)
+				return err
+			},
+			sentinel:    ErrEdgeNotFound,
+			expectedErr: &EdgeNotFoundError[int]{Source: 2, Target: 1},
+		},
+		"edge not found in undirected graph": {
+			operation: func(g Graph[int, int]) error {
+				return g.RemoveEdge(1, 3)
+			},
+			sentinel:    ErrEdgeNotFound,
+			expectedErr: &EdgeNotFoundError[int]{Source: 1, Target: 3},
+		},
+		"edge already exists": {
+			operation: func(g Graph[int, int]) error {
+				return g.AddEdge(2, 1)
+			},
+			sentinel:    ErrEdgeAlreadyExists,
+			expectedErr: &EdgeAlreadyExistsError[int]{Source: 2, Target: 1},
+		},
+		"store returning sentinel errors": {
+			store: func() Store[int, int] {
+				return sentinelStore[int, int]{Store: newMemoryStore[int, int]()}
+			},
+			operation: func(g Graph[int, int]) error {
+				_, err := g.Edge(1, 3)
+				return err
+			},
+			sentinel:    ErrEdgeNotFound,
+			expectedErr: &EdgeNotFoundError[int]{Source: 1, Target: 3},
+		},
+		"store returning sentinel errors for vertices": {
+			store: func() Store[int, int] {
+				return sentinelStore[int, int]{Store: newMemoryStore[int, int]()}
+			},
+			operation: func(g Graph[int, int]) error {
+				_, _, err := g.VertexWithProperties(42)
+				return err
+			},
+			sentinel:    ErrVertexNotFound,
+			expectedErr: &VertexNotFoundError[int]{Hash: 42},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var options []func(*Traits)
+			if test.directed {
+				options = append(options, Directed())
+			}
+
+			var g Graph[int, int]
+			if test.store != nil {
+				g = NewWithStore(IntHash, test.store(), options...)
+			} else {
+				g = New(IntHash, options...)
+			}
+
+			for _, vertex := range []int{1, 2, 3} {
+				_ = g.AddVertex(vertex)
+			}
+			_ = g.AddEdge(1, 2)
+
+			err := test.operation(g)
+
+			if !errors.Is(err, test.sentinel) {
+				t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v", name, test.sentinel, err)
+			}
+
+			if err.Error() == test.sentinel.Error() {
+				t.Errorf("%s: expected error to report the involved vertices, got %v", name, err)
+			}
+
+			if !errorMatches(err, test.expectedErr) {
+				t.Errorf("%s: error doesn't match: expected %v, got %v", name, test.expectedErr, err)
+			}
+		})
+	}
+}
+
+// errorMatches reports whether err wraps a typed error that equals expected.
+func errorMatches(err, expected error) bool {
+	switch expected := expected.(type) {
+	case *VertexNotFoundError[int]:
+		var target *VertexNotFoundError[int]
+		return errors.As(err, &target) && *target == *expected
+	case *VertexAlreadyExistsError[int]:
+		var target *VertexAlreadyExistsError[int]
+		return errors.As(err, &target) && *target == *expected
+	case *VertexHasEdgesError[int]:
+		var target *VertexHasEdgesError[int]
+		return errors.As(err, &target) && *target == *expected
+	case *EdgeNotFoundError[int]:
+		var target *EdgeNotFoundError[int]
+		return errors.As(err, &target) && *target == *expected
+	case *EdgeAlreadyExistsError[int]:
+		var target *EdgeAlreadyExistsError[int]
+		return errors.As(err, &target) && *target == *expected
+	}
+	return false
+}
+
+func TestEdgeCreatesCycleError(t *testing.T) {
+	g := New(IntHash, Directed(), PreventCycles())
+
+	for _, vertex := range []int{1, 2, 3} {
+		_ = g.AddVertex(vertex)
+	}
+	_ = g.AddEdge(1, 2)
+	_ = g.AddEdge(2, 3)
+
+	err := g.AddEdge(3, 1)
+
+	var cycleErr *EdgeCreatesCycleError[int]
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("error expectancy doesn't match: expected %T, got %v", cycleErr, err)
+	}
+
+	if !errors.Is(err, ErrEdgeCreatesCycle) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrEdgeCreatesCycle, err)
+	}
+
+	if cycleErr.Source != 3 || cycleErr.Target != 1 {
+		t.Errorf("edge doesn't match: expected (%v, %v), got (%v, %v)", 3, 1, cycleErr.Source, cycleErr.Target)
+	}
+}
@@ -0,0 +1,478 @@
+// Package expiringstore provides a [graph.Store] wrapper whose vertices and
+// edges expire after a time to live (TTL). This is useful for session graphs
+// or sliding-window interaction graphs, where old vertices and edges become
+// irrelevant over time.
+//
+//	store := expiringstore.Wrap[string, string](backingStore,
+//		expiringstore.EdgeTTL(10*time.Minute),
+//		expiringstore.EvictionInterval(time.Minute),
+//	)
+//	defer store.Close()
+//
+//	g := graph.NewWithStore(graph.StringHash, graph.Store[string, string](store), graph.Directed())
+//
+//	_ = g.AddVertex("session")
+//	_ = store.ExpireVertex("session", 30*time.Minute)
+//
+// The TTLs set using [VertexTTL] and [EdgeTTL] apply to all vertices and edges
+// added to the store, and [Store.ExpireVertex] and [Store.ExpireEdge] set the
+// TTL of a single vertex or edge. Updating an edge restarts its TTL.
+//
+// Expired vertices and edges are evicted lazily when they are read, and all of
+// them are evicted when listing or counting the vertices or edges. Using
+// [EvictionInterval], they are also evicted periodically in the background.
+// When a vertex is evicted, all of its edges are evicted as well.
+//
+// Note that undirected graphs store each edge in both directions, so that
+// ExpireEdge has to be called for both directions.
+package expiringstore
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dominikbraun/graph"
+)
+
+type config struct {
+	vertexTTL        time.Duration
+	edgeTTL          time.Duration
+	evictionInterval time.Duration
+}
+
+// VertexTTL is a functional option for [Wrap] that sets the TTL of all vertices
+// added to the store. By default, vertices don't expire.
+func VertexTTL(ttl time.Duration) func(*config) {
+	return func(c *config) {
+		c.vertexTTL = ttl
+	}
+}
+
+// EdgeTTL is a functional option for [Wrap] that sets the TTL of all edges
+// added to the store. By default, edges don't expire.
+func EdgeTTL(ttl time.Duration) func(*config) {
+	return func(c *config) {
+		c.edgeTTL = ttl
+	}
+}
+
+// EvictionInterval is a functional option for [Wrap] that evicts all expired
+// vertices and edges in the given interval. The eviction runs in a background
+// goroutine that is stopped by [Store.Close].
+func EvictionInterval(interval time.Duration) func(*config) {
+	return func(c *config) {
+		c.evictionInterval = interval
+	}
+}
+
+type edgeKey[K comparable] struct {
+	source, target K
+}
+
+// Store is a [graph.Store] wrapper that evicts vertices and edges once they
+// have expired.
+type Store[K comparable, T any] struct {
+	lock         sync.Mutex
+	store        graph.Store[K, T]
+	config       config
+	now          func() time.Time
+	vertexExpiry map[K]time.Time
+	edgeExpiry   map[edgeKey[K]]time.Time
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+// Wrap returns a Store that adds expiry to the given store. The vertices and
+// edges in s when calling Wrap don't expire until a TTL is set for them.
+func Wrap[K comparable, T any](s graph.Store[K, T], options ...func(*config)) *Store[K, T] {
+	var c config
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	store := &Store[K, T]{
+		store:        s,
+		config:       c,
+		now:          time.Now,
+		vertexExpiry: make(map[K]time.Time),
+		edgeExpiry:   make(map[edgeKey[K]]time.Time),
+		done:         make(chan struct{}),
+	}
+
+	if c.evictionInterval > 0 {
+		go store.evictPeriodically(c.evictionInterval)
+	}
+
+	return store
+}
+
+// Close stops the background eviction. It doesn't close the wrapped store.
+func (s *Store[K, T]) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+
+	return nil
+}
+
+// ExpireVertex sets the TTL of the vertex with the given hash, replacing its
+// previous TTL. A TTL of 0 or less removes the expiry.
+func (s *Store[K, T]) ExpireVertex(hash K, ttl time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := s.vertex(hash); err != nil {
+		return err
+	}
+
+	setExpiry(s.vertexExpiry, hash, s.now(), ttl)
+
+	return nil
+}
+
+// ExpireEdge sets the TTL of the edge between the given vertices, replacing its
+// previous TTL. A TTL of 0 or less removes the expiry.
+func (s *Store[K, T]) ExpireEdge(sourceHash, targetHash K, ttl time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := s.edge(sourceHash, targetHash); err != nil {
+		return err
+	}
+
+	setExpiry(s.edgeExpiry, edgeKey[K]{sourceHash, targetHash}, s.now(), ttl)
+
+	return nil
+}
+
+// Evict removes all vertices and edges that have expired.
+func (s *Store[K, T]) Evict() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.evictExpired()
+}
+
+func (s *Store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// An expired vertex that hasn't been evicted yet must not prevent adding
+	// the vertex again.
+	if err := s.evictVertexIfExpired(hash); err != nil {
+		return err
+	}
+
+	if err := s.store.AddVertex(hash, value, properties); err != nil {
+		return err
+	}
+
+	setExpiry(s.vertexExpiry, hash, s.now(), s.config.vertexTTL)
+
+	return nil
+}
+
+func (s *Store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.evictVertexIfExpired(hash); err != nil {
+		var value T
+		return value, graph.VertexProperties{}, err
+	}
+
+	return s.store.Vertex(hash)
+}
+
+func (s *Store[K, T]) RemoveVertex(hash K) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.evictVertexIfExpired(hash); err != nil {
+		return err
+	}
+
+	if err := s.store.RemoveVertex(hash); err != nil {
+		return err
+	}
+
+	delete(s.vertexExpiry, hash)
+
+	return nil
+}
+
+func (s *Store[K, T]) ListVertices() ([]K, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.evictExpired(); err != nil {
+		return nil, err
+	}
+
+	return s.store.ListVertices()
+}
+
+func (s *Store[K, T]) VertexCount() (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.evictExpired(); err != nil {
+		return 0, err
+	}
+
+	return s.store.VertexCount()
+}
+
+func (s *Store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.evictVertexIfExpired(sourceHash); err != nil {
+		return err
+	}
+
+	if err := s.evictVertexIfExpired(targetHash); err != nil {
+		return err
+	}
+
+	if err := s.evictEdgeIfExpired(sourceHash, targetHash); err != nil {
+		return err
+	}
+
+	if err := s.store.AddEdge(sourceHash, targetHash, edge); err != nil {
+		return err
+	}
+
+	setExpiry(s.edgeExpiry, edgeKey[K]{sourceHash, targetHash}, s.now(), s.config.edgeTTL)
+
+	return nil
+}
+
+func (s *Store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := s.edge(sourceHash, targetHash); err != nil {
+		return err
+	}
+
+	if err := s.store.UpdateEdge(sourceHash, targetHash, edge); err != nil {
+		return err
+	}
+
+	setExpiry(s.edgeExpiry, edgeKey[K]{sourceHash, targetHash}, s.now(), s.config.edgeTTL)
+
+	return nil
+}
+
+func (s *Store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := s.edge(sourceHash, targetHash); err != nil {
+		return err
+	}
+
+	if err := s.store.RemoveEdge(sourceHash, targetHash); err != nil {
+		return err
+	}
+
+	delete(s.edgeExpiry, edgeKey[K]{sourceHash, targetHash})
+
+	return nil
+}
+
+func (s *Store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.edge(sourceHash, targetHash)
+}
+
+func (s *Store[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.evictExpired(); err != nil {
+		return nil, err
+	}
+
+	return s.store.ListEdges()
+}
+
+func (s *Store[K, T]) EdgeCount() (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.evictExpired(); err != nil {
+		return 0, err
+	}
+
+	return s.store.EdgeCount()
+}
+
+// vertex returns the vertex with the given hash, or ErrVertexNotFound if it
+// has expired.
+func (s *Store[K, T]) vertex(hash K) (T, error) {
+	if err := s.evictVertexIfExpired(hash); err != nil {
+		var value T
+		return value, err
+	}
+
+	value, _, err := s.store.Vertex(hash)
+
+	return value, err
+}
+
+// edge returns the edge between the given vertices, or ErrEdgeNotFound if the
+// edge or one of its vertices has expired.
+func (s *Store[K, T]) edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	if err := s.evictVertexIfExpired(sourceHash); err != nil {
+		return graph.Edge[K]{}, err
+	}
+
+	if err := s.evictVertexIfExpired(targetHash); err != nil {
+		return graph.Edge[K]{}, err
+	}
+
+	if err := s.evictEdgeIfExpired(sourceHash, targetHash); err != nil {
+		return graph.Edge[K]{}, err
+	}
+
+	return s.store.Edge(sourceHash, targetHash)
+}
+
+func (s *Store[K, T]) evictPeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = s.Evict()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *Store[K, T]) evictExpired() error {
+	now := s.now()
+
+	for key, expiry := range s.edgeExpiry {
+		if now.Before(expiry) {
+			continue
+		}
+		if err := s.evictEdge(key.source, key.target); err != nil {
+			return err
+		}
+	}
+
+	for hash, expiry := range s.vertexExpiry {
+		if now.Before(expiry) {
+			continue
+		}
+		if err := s.evictVertex(hash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) evictVertexIfExpired(hash K) error {
+	expiry, ok := s.vertexExpiry[hash]
+	if !ok || s.now().Before(expiry) {
+		return nil
+	}
+
+	return s.evictVertex(hash)
+}
+
+func (s *Store[K, T]) evictEdgeIfExpired(sourceHash, targetHash K) error {
+	expiry, ok := s.edgeExpiry[edgeKey[K]{sourceHash, targetHash}]
+	if !ok || s.now().Before(expiry) {
+		return nil
+	}
+
+	return s.evictEdge(sourceHash, targetHash)
+}
+
+// evictVertex removes the vertex with the given hash along with all of its
+// edges, which would prevent removing the vertex otherwise.
+func (s *Store[K, T]) evictVertex(hash K) error {
+	edges, err := s.incidentEdges(hash)
+	if err != nil {
+		return fmt.Errorf("failed to get edges of vertex %v: %w", hash, err)
+	}
+
+	for _, edge := range edges {
+		if err := s.evictEdge(edge.Source, edge.Target); err != nil {
+			return err
+		}
+	}
+
+	if err := s.store.RemoveVertex(hash); err != nil && !errors.Is(err, graph.ErrVertexNotFound) {
+		return fmt.Errorf("failed to evict vertex %v: %w", hash, err)
+	}
+
+	delete(s.vertexExpiry, hash)
+
+	return nil
+}
+
+func (s *Store[K, T]) evictEdge(sourceHash, targetHash K) error {
+	if err := s.store.RemoveEdge(sourceHash, targetHash); err != nil && !errors.Is(err, graph.ErrEdgeNotFound) {
+		return fmt.Errorf("failed to evict edge (%v, %v): %w", sourceHash, targetHash, err)
+	}
+
+	delete(s.edgeExpiry, edgeKey[K]{sourceHash, targetHash})
+
+	return nil
+}
+
+// incidentEdges returns all edges whose source or target is the vertex with
+// the given hash. If the wrapped store implements graph.NeighborStore, the
+// edges are queried directly. Otherwise, all edges are listed.
+func (s *Store[K, T]) incidentEdges(hash K) ([]graph.Edge[K], error) {
+	if neighborStore, ok := s.store.(graph.NeighborStore[K]); ok {
+		outEdges, err := neighborStore.EdgesBySource(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		inEdges, err := neighborStore.EdgesByTarget(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(outEdges, inEdges...), nil
+	}
+
+	edges, err := s.store.ListEdges()
+	if err != nil {
+		return nil, err
+	}
+
+	incident := make([]graph.Edge[K], 0)
+
+	for _, edge := range edges {
+		if edge.Source == hash || edge.Target == hash {
+			incident = append(incident, edge)
+		}
+	}
+
+	return incident, nil
+}
+
+func setExpiry[H comparable](expiry map[H]time.Time, key H, now time.Time, ttl time.Duration) {
+	if ttl <= 0 {
+		delete(expiry, key)
+		return
+	}
+
+	expiry[key] = now.Add(ttl)
+}
@@ -0,0 +1,174 @@
+package expiringstore
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/filestore"
+)
+
+// clock is a manually advanced clock for testing expiry.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestStore(t *testing.T, options ...func(*config)) (*Store[string, string], *clock) {
+	backing, err := filestore.Open[string, string](filepath.Join(t.TempDir(), "graph.log"))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	store := Wrap[string, string](backing, options...)
+	c := &clock{now: time.Unix(0, 0)}
+
+	store.lock.Lock()
+	store.now = c.Now
+	store.lock.Unlock()
+
+	t.Cleanup(func() {
+		_ = store.Close()
+		_ = backing.Close()
+	})
+
+	return store, c
+}
+
+func TestStore_edgeTTL(t *testing.T) {
+	store, c := newTestStore(t, EdgeTTL(time.Minute))
+	g := graph.NewWithStore(graph.StringHash, graph.Store[string, string](store), graph.Directed())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddVertex("C")
+	_ = g.AddEdge("A", "B")
+
+	c.advance(30 * time.Second)
+	_ = g.AddEdge("B", "C")
+
+	c.advance(40 * time.Second)
+
+	if _, err := g.Edge("A", "B"); !errors.Is(err, graph.ErrEdgeNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeNotFound, err)
+	}
+
+	if _, err := g.Edge("B", "C"); err != nil {
+		t.Errorf("expected edge (B, C) not to be expired, got error %v", err)
+	}
+
+	// Updating an edge restarts its TTL.
+	_ = g.UpdateEdge("B", "C", graph.EdgeWeight(2))
+	c.advance(40 * time.Second)
+
+	if size, _ := g.Size(); size != 1 {
+		t.Errorf("size doesn't match: expected %v, got %v", 1, size)
+	}
+
+	// Vertices don't expire without a vertex TTL.
+	if order, _ := g.Order(); order != 3 {
+		t.Errorf("order doesn't match: expected %v, got %v", 3, order)
+	}
+
+	// The expired edge can be added again.
+	if err := g.AddEdge("A", "B"); err != nil {
+		t.Errorf("failed to add expired edge again: %v", err)
+	}
+}
+
+func TestStore_ExpireVertex(t *testing.T) {
+	store, c := newTestStore(t)
+	g := graph.NewWithStore(graph.StringHash, graph.Store[string, string](store), graph.Directed())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddVertex("C")
+	_ = g.AddEdge("A", "B")
+	_ = g.AddEdge("C", "A")
+
+	if err := store.ExpireVertex("A", time.Minute); err != nil {
+		t.Fatalf("failed to set TTL: %v", err)
+	}
+
+	if err := store.ExpireVertex("D", time.Minute); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	c.advance(time.Minute)
+
+	if _, err := g.Vertex("A"); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	// Evicting a vertex evicts its edges as well.
+	if size, _ := g.Size(); size != 0 {
+		t.Errorf("size doesn't match: expected %v, got %v", 0, size)
+	}
+
+	if err := g.AddVertex("A"); err != nil {
+		t.Errorf("failed to add expired vertex again: %v", err)
+	}
+
+	c.advance(time.Hour)
+
+	if _, err := g.Vertex("A"); err != nil {
+		t.Errorf("expected re-added vertex not to expire, got error %v", err)
+	}
+}
+
+func TestStore_Evict(t *testing.T) {
+	store, c := newTestStore(t, VertexTTL(time.Minute), EdgeTTL(time.Minute))
+	g := graph.NewWithStore(graph.StringHash, graph.Store[string, string](store))
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddEdge("A", "B")
+
+	c.advance(time.Minute)
+
+	if err := store.Evict(); err != nil {
+		t.Fatalf("failed to evict: %v", err)
+	}
+
+	if n := len(store.vertexExpiry) + len(store.edgeExpiry); n != 0 {
+		t.Errorf("expected all expiries to be removed, got %v", n)
+	}
+
+	if order, _ := store.store.VertexCount(); order != 0 {
+		t.Errorf("order of backing store doesn't match: expected %v, got %v", 0, order)
+	}
+
+	if size, _ := store.store.EdgeCount(); size != 0 {
+		t.Errorf("size of backing store doesn't match: expected %v, got %v", 0, size)
+	}
+}
+
+func TestEvictionInterval(t *testing.T) {
+	store, _ := newTestStore(t, VertexTTL(time.Millisecond), EvictionInterval(time.Millisecond))
+
+	store.lock.Lock()
+	store.now = time.Now
+	store.lock.Unlock()
+
+	_ = store.AddVertex("A", "A", graph.VertexProperties{})
+
+	deadline := time.Now().Add(time.Second)
+
+	for time.Now().Before(deadline) {
+		if count, _ := store.store.VertexCount(); count == 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Errorf("expected vertex to be evicted in the background")
+}
@@ -0,0 +1,575 @@
+// Package filestore provides a [graph.Store] implementation that persists
+// graphs in an append-only log file. It enables durable graphs for CLIs and
+// small tools without requiring a database.
+//
+//	store, _ := filestore.Open[string, string]("graph.log")
+//	defer store.Close()
+//
+//	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+//
+// Every mutation is appended to the file as a JSON line. When the file is
+// opened, all mutations are replayed to rebuild the graph in memory, so that
+// reads never touch the file. Because the log grows with every mutation, it
+// can be rewritten to the minimal set of mutations using [Store.Compact].
+//
+// Vertex hashes, values, and properties are encoded as JSON, so they need to
+// survive a JSON round trip. Edge data is decoded into the generic JSON types
+// such as map[string]any when the log is replayed.
+//
+// If the process crashes while a mutation is written, the log may end with an
+// incomplete line. This line is discarded when the file is opened again.
+package filestore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dominikbraun/graph"
+)
+
+type operation string
+
+const (
+	addVertex    operation = "add_vertex"
+	removeVertex operation = "remove_vertex"
+	addEdge      operation = "add_edge"
+	updateEdge   operation = "update_edge"
+	removeEdge   operation = "remove_edge"
+)
+
+// record is a single mutation in the log. Which fields are set depends on the
+// operation.
+type record[K comparable, T any] struct {
+	Op         operation          `json:"op"`
+	Hash       K                  `json:"hash,omitempty"`
+	Value      T                  `json:"value,omitempty"`
+	Source     K                  `json:"source,omitempty"`
+	Target     K                  `json:"target,omitempty"`
+	Weight     int                `json:"weight,omitempty"`
+	Attributes map[string]string  `json:"attributes,omitempty"`
+	Weights    map[string]float64 `json:"weights,omitempty"`
+	Data       any                `json:"data,omitempty"`
+}
+
+type config struct {
+	sync bool
+}
+
+// SyncWrites is a functional option for [Open] that makes the store call fsync
+// after each mutation. This guarantees that a mutation survives a crash of the
+// operating system once it has returned, at the cost of write performance.
+func SyncWrites() func(*config) {
+	return func(c *config) {
+		c.sync = true
+	}
+}
+
+// Store is a [graph.Store] implementation backed by an append-only log file.
+// All vertices and edges are held in memory as well.
+type Store[K comparable, T any] struct {
+	lock     sync.RWMutex
+	file     *os.File
+	path     string
+	sync     bool
+	vertices map[K]vertex[T]
+	outEdges map[K]map[K]graph.Edge[K]
+	inEdges  map[K]map[K]struct{}
+	edges    int
+	// size is the length of the log up to the last complete mutation.
+	size int64
+}
+
+type vertex[T any] struct {
+	value      T
+	properties graph.VertexProperties
+}
+
+// Open opens the log file at the given path and replays all mutations stored
+// in it. If the file doesn't exist, it will be created.
+func Open[K comparable, T any](path string, options ...func(*config)) (*Store[K, T], error) {
+	var c config
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	s := &Store[K, T]{
+		file:     file,
+		path:     path,
+		sync:     c.sync,
+		vertices: make(map[K]vertex[T]),
+		outEdges: make(map[K]map[K]graph.Edge[K]),
+		inEdges:  make(map[K]map[K]struct{}),
+	}
+
+	if err := s.replay(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Close closes the log file. The store must not be used afterwards.
+func (s *Store[K, T]) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.file.Close()
+}
+
+// Compact rewrites the log file so that it only contains the mutations needed
+// to build the current graph. The new log is written to a temporary file that
+// replaces the original file once it is complete.
+func (s *Store[K, T]) Compact() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	temp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	// Remove the temporary file if anything goes wrong. After a successful
+	// rename, this is a no-op.
+	defer os.Remove(temp.Name())
+
+	w := bufio.NewWriter(temp)
+
+	for hash, v := range s.vertices {
+		if err := writeRecord(w, record[K, T]{
+			Op:         addVertex,
+			Hash:       hash,
+			Value:      v.value,
+			Weight:     v.properties.Weight,
+			Attributes: v.properties.Attributes,
+			Weights:    v.properties.Weights,
+		}); err != nil {
+			_ = temp.Close()
+			return err
+		}
+	}
+
+	for _, targets := range s.outEdges {
+		for _, edge := range targets {
+			if err := writeRecord(w, edgeRecord[K, T](addEdge, edge)); err != nil {
+				_ = temp.Close()
+				return err
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		_ = temp.Close()
+		return fmt.Errorf("failed to write log: %w", err)
+	}
+
+	if err := temp.Sync(); err != nil {
+		_ = temp.Close()
+		return fmt.Errorf("failed to sync log: %w", err)
+	}
+
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to close log: %w", err)
+	}
+
+	if err := os.Rename(temp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace log: %w", err)
+	}
+
+	file, err := os.OpenFile(s.path, os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+
+	_ = s.file.Close()
+	s.file = file
+	s.size = size
+
+	return nil
+}
+
+func (s *Store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	r := record[K, T]{
+		Op:         addVertex,
+		Hash:       hash,
+		Value:      value,
+		Weight:     properties.Weight,
+		Attributes: properties.Attributes,
+		Weights:    properties.Weights,
+	}
+
+	return s.commit(r)
+}
+
+func (s *Store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	v, ok := s.vertices[hash]
+	if !ok {
+		return v.value, graph.VertexProperties{}, graph.ErrVertexNotFound
+	}
+
+	return v.value, v.properties, nil
+}
+
+func (s *Store[K, T]) RemoveVertex(hash K) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	r := record[K, T]{
+		Op:   removeVertex,
+		Hash: hash,
+	}
+
+	return s.commit(r)
+}
+
+func (s *Store[K, T]) ListVertices() ([]K, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	hashes := make([]K, 0, len(s.vertices))
+
+	for hash := range s.vertices {
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+func (s *Store[K, T]) VertexCount() (int, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.vertices), nil
+}
+
+func (s *Store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	edge.Source = sourceHash
+	edge.Target = targetHash
+
+	r := edgeRecord[K, T](addEdge, edge)
+
+	return s.commit(r)
+}
+
+func (s *Store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	edge.Source = sourceHash
+	edge.Target = targetHash
+
+	r := edgeRecord[K, T](updateEdge, edge)
+
+	return s.commit(r)
+}
+
+func (s *Store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.outEdges[sourceHash][targetHash]; !ok {
+		return nil
+	}
+
+	r := record[K, T]{
+		Op:     removeEdge,
+		Source: sourceHash,
+		Target: targetHash,
+	}
+
+	return s.commit(r)
+}
+
+func (s *Store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	edge, ok := s.outEdges[sourceHash][targetHash]
+	if !ok {
+		return graph.Edge[K]{}, graph.ErrEdgeNotFound
+	}
+
+	return edge, nil
+}
+
+func (s *Store[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	edges := make([]graph.Edge[K], 0, s.edges)
+
+	for _, targets := range s.outEdges {
+		for _, edge := range targets {
+			edges = append(edges, edge)
+		}
+	}
+
+	return edges, nil
+}
+
+func (s *Store[K, T]) EdgeCount() (int, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.edges, nil
+}
+
+// commit validates the given mutation, appends it to the log, and applies it
+// to the in-memory state. The in-memory state is only changed if the mutation
+// has been written to the log successfully.
+func (s *Store[K, T]) commit(r record[K, T]) error {
+	if err := s.validate(r); err != nil {
+		return err
+	}
+
+	if err := s.append(r); err != nil {
+		return err
+	}
+
+	s.apply(r)
+
+	return nil
+}
+
+// validate checks whether the given mutation can be applied to the in-memory
+// state without changing it.
+func (s *Store[K, T]) validate(r record[K, T]) error {
+	switch r.Op {
+	case addVertex:
+		if _, ok := s.vertices[r.Hash]; ok {
+			return graph.ErrVertexAlreadyExists
+		}
+
+	case removeVertex:
+		if _, ok := s.vertices[r.Hash]; !ok {
+			return graph.ErrVertexNotFound
+		}
+		if len(s.outEdges[r.Hash]) > 0 || len(s.inEdges[r.Hash]) > 0 {
+			return graph.ErrVertexHasEdges
+		}
+
+	case addEdge:
+		if _, ok := s.vertices[r.Source]; !ok {
+			return fmt.Errorf("source vertex %v: %w", r.Source, graph.ErrVertexNotFound)
+		}
+		if _, ok := s.vertices[r.Target]; !ok {
+			return fmt.Errorf("target vertex %v: %w", r.Target, graph.ErrVertexNotFound)
+		}
+		if _, ok := s.outEdges[r.Source][r.Target]; ok {
+			return graph.ErrEdgeAlreadyExists
+		}
+
+	case updateEdge, removeEdge:
+		if _, ok := s.outEdges[r.Source][r.Target]; !ok {
+			return graph.ErrEdgeNotFound
+		}
+
+	default:
+		return fmt.Errorf("unknown operation %q", r.Op)
+	}
+
+	return nil
+}
+
+// apply applies the given mutation to the in-memory state. It is used both for
+// new mutations and for mutations replayed from the log, and expects that the
+// mutation has been validated using validate.
+func (s *Store[K, T]) apply(r record[K, T]) {
+	switch r.Op {
+	case addVertex:
+		s.vertices[r.Hash] = vertex[T]{
+			value: r.Value,
+			properties: graph.VertexProperties{
+				Weight:     r.Weight,
+				Attributes: attributes(r.Attributes),
+				Weights:    r.Weights,
+			},
+		}
+
+	case removeVertex:
+		delete(s.vertices, r.Hash)
+		delete(s.outEdges, r.Hash)
+		delete(s.inEdges, r.Hash)
+
+	case addEdge:
+		if _, ok := s.outEdges[r.Source]; !ok {
+			s.outEdges[r.Source] = make(map[K]graph.Edge[K])
+		}
+		if _, ok := s.inEdges[r.Target]; !ok {
+			s.inEdges[r.Target] = make(map[K]struct{})
+		}
+		s.outEdges[r.Source][r.Target] = r.edge()
+		s.inEdges[r.Target][r.Source] = struct{}{}
+		s.edges++
+
+	case updateEdge:
+		s.outEdges[r.Source][r.Target] = r.edge()
+
+	case removeEdge:
+		delete(s.outEdges[r.Source], r.Target)
+		delete(s.inEdges[r.Target], r.Source)
+		s.edges--
+	}
+}
+
+// append writes the given mutation to the end of the log file. The mutation is
+// encoded completely before writing it. If writing fails, the log is truncated
+// to its previous size so that no partial line is left behind.
+func (s *Store[K, T]) append(r record[K, T]) error {
+	var buf bytes.Buffer
+
+	if err := writeRecord(&buf, r); err != nil {
+		return err
+	}
+
+	_, err := s.file.Write(buf.Bytes())
+	if err != nil {
+		err = fmt.Errorf("failed to write log: %w", err)
+	} else if s.sync {
+		if err = s.file.Sync(); err != nil {
+			err = fmt.Errorf("failed to sync log: %w", err)
+		}
+	}
+
+	if err != nil {
+		return s.rollback(err)
+	}
+
+	s.size += int64(buf.Len())
+
+	return nil
+}
+
+// rollback truncates the log to the last complete mutation after a failed
+// write and returns the given error.
+func (s *Store[K, T]) rollback(err error) error {
+	if truncErr := s.file.Truncate(s.size); truncErr != nil {
+		return fmt.Errorf("%w (failed to truncate log: %v)", err, truncErr)
+	}
+
+	if _, seekErr := s.file.Seek(s.size, io.SeekStart); seekErr != nil {
+		return fmt.Errorf("%w (failed to seek log: %v)", err, seekErr)
+	}
+
+	return err
+}
+
+// replay reads all mutations from the log file and applies them. A trailing
+// incomplete line, which is left behind by an interrupted write, is truncated.
+func (s *Store[K, T]) replay() error {
+	reader := bufio.NewReader(s.file)
+
+	var offset int64
+
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				if err := s.file.Truncate(offset); err != nil {
+					return fmt.Errorf("failed to truncate incomplete line %d: %w", lineNumber, err)
+				}
+			}
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read log: %w", err)
+		}
+
+		var r record[K, T]
+
+		if err := json.Unmarshal(line, &r); err != nil {
+			return fmt.Errorf("failed to decode line %d: %w", lineNumber, err)
+		}
+
+		if err := s.validate(r); err != nil {
+			return fmt.Errorf("failed to replay line %d: %w", lineNumber, err)
+		}
+
+		s.apply(r)
+
+		offset += int64(len(line))
+	}
+
+	if _, err := s.file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read log: %w", err)
+	}
+
+	s.size = offset
+
+	return nil
+}
+
+func (r record[K, T]) edge() graph.Edge[K] {
+	return graph.Edge[K]{
+		Source: r.Source,
+		Target: r.Target,
+		Properties: graph.EdgeProperties{
+			Weight:     r.Weight,
+			Attributes: attributes(r.Attributes),
+			Data:       r.Data,
+		},
+	}
+}
+
+func edgeRecord[K comparable, T any](op operation, edge graph.Edge[K]) record[K, T] {
+	return record[K, T]{
+		Op:         op,
+		Source:     edge.Source,
+		Target:     edge.Target,
+		Weight:     edge.Properties.Weight,
+		Attributes: edge.Properties.Attributes,
+		Data:       edge.Properties.Data,
+	}
+}
+
+func writeRecord[K comparable, T any](w io.Writer, r record[K, T]) error {
+	encoded, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", r.Op, err)
+	}
+
+	encoded = append(encoded, '\n')
+
+	if _, err := w.Write(encoded); err != nil {
+		return fmt.Errorf("failed to write log: %w", err)
+	}
+
+	return nil
+}
+
+func attributes(a map[string]string) map[string]string {
+	if a == nil {
+		return make(map[string]string)
+	}
+
+	return a
+}
@@ -0,0 +1,258 @@
+package filestore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func newTestStore(t *testing.T, path string) *Store[string, string] {
+	store, err := Open[string, string](path)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return store
+}
+
+func TestStore(t *testing.T) {
+	store := newTestStore(t, filepath.Join(t.TempDir(), "graph.log"))
+
+	properties := graph.VertexProperties{
+		Weight:     4,
+		Attributes: map[string]string{"color": "red"},
+	}
+
+	if err := store.AddVertex("A", "A", properties); err != nil {
+		t.Fatalf("failed to add vertex: %v", err)
+	}
+
+	if err := store.AddVertex("A", "A", properties); !errors.Is(err, graph.ErrVertexAlreadyExists) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexAlreadyExists, err)
+	}
+
+	_ = store.AddVertex("B", "B", graph.VertexProperties{})
+
+	edge := graph.Edge[string]{
+		Source:     "A",
+		Target:     "B",
+		Properties: graph.EdgeProperties{Weight: 3},
+	}
+
+	if err := store.AddEdge("A", "C", edge); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	if err := store.AddEdge("A", "B", edge); err != nil {
+		t.Fatalf("failed to add edge: %v", err)
+	}
+
+	if err := store.AddEdge("A", "B", edge); !errors.Is(err, graph.ErrEdgeAlreadyExists) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeAlreadyExists, err)
+	}
+
+	if err := store.RemoveVertex("A"); !errors.Is(err, graph.ErrVertexHasEdges) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexHasEdges, err)
+	}
+
+	if err := store.UpdateEdge("B", "A", edge); !errors.Is(err, graph.ErrEdgeNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeNotFound, err)
+	}
+
+	if count, _ := store.EdgeCount(); count != 1 {
+		t.Errorf("edge count doesn't match: expected %v, got %v", 1, count)
+	}
+
+	if err := store.RemoveEdge("A", "B"); err != nil {
+		t.Fatalf("failed to remove edge: %v", err)
+	}
+
+	if err := store.RemoveVertex("A"); err != nil {
+		t.Errorf("failed to remove vertex: %v", err)
+	}
+
+	if count, _ := store.VertexCount(); count != 1 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 1, count)
+	}
+}
+
+func TestStore_Replay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.log")
+
+	store, err := Open[string, string](path, SyncWrites())
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	g := graph.NewWithStore(graph.StringHash, graph.Store[string, string](store), graph.Directed())
+
+	for _, vertex := range []string{"A", "B", "C"} {
+		_ = g.AddVertex(vertex, graph.VertexWeight(2), graph.VertexWeightNamed("cpu", 1.5))
+	}
+
+	_ = g.AddEdge("A", "B", graph.EdgeWeight(3), graph.EdgeAttribute("color", "red"))
+	_ = g.AddEdge("B", "C")
+	_ = g.UpdateEdge("A", "B", graph.EdgeWeight(5))
+	_ = g.RemoveEdge("B", "C")
+	_ = g.RemoveVertex("C")
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("failed to close store: %v", err)
+	}
+
+	reopened := newTestStore(t, path)
+
+	assertGraph(t, reopened)
+}
+
+func TestStore_Compact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.log")
+	store := newTestStore(t, path)
+
+	for _, vertex := range []string{"A", "B", "C"} {
+		_ = store.AddVertex(vertex, vertex, graph.VertexProperties{Weight: 2, Weights: map[string]float64{"cpu": 1.5}})
+	}
+
+	_ = store.AddEdge("A", "B", graph.Edge[string]{Properties: graph.EdgeProperties{Weight: 3}})
+	_ = store.AddEdge("B", "C", graph.Edge[string]{})
+	_ = store.UpdateEdge("A", "B", graph.Edge[string]{Properties: graph.EdgeProperties{
+		Weight:     5,
+		Attributes: map[string]string{"color": "red"},
+	}})
+	_ = store.RemoveEdge("B", "C")
+	_ = store.RemoveVertex("C")
+
+	before, _ := os.Stat(path)
+
+	if err := store.Compact(); err != nil {
+		t.Fatalf("failed to compact store: %v", err)
+	}
+
+	after, _ := os.Stat(path)
+
+	if after.Size() >= before.Size() {
+		t.Errorf("expected compacted log to be smaller than %d bytes, got %d bytes", before.Size(), after.Size())
+	}
+
+	// The store must still be writable after compacting.
+	if err := store.AddVertex("D", "D", graph.VertexProperties{}); err != nil {
+		t.Fatalf("failed to add vertex: %v", err)
+	}
+
+	_ = store.RemoveVertex("D")
+
+	reopened := newTestStore(t, path)
+
+	assertGraph(t, reopened)
+}
+
+func TestStore_IncompleteLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.log")
+
+	log := `{"op":"add_vertex","hash":"A","value":"A"}` + "\n" + `{"op":"add_vertex","hash":"B","va`
+
+	if err := os.WriteFile(path, []byte(log), 0o600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	store := newTestStore(t, path)
+
+	if count, _ := store.VertexCount(); count != 1 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 1, count)
+	}
+
+	if err := store.AddVertex("B", "B", graph.VertexProperties{}); err != nil {
+		t.Fatalf("failed to add vertex: %v", err)
+	}
+
+	reopened := newTestStore(t, path)
+
+	if count, _ := reopened.VertexCount(); count != 2 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 2, count)
+	}
+}
+
+func TestStore_FailedWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.log")
+
+	store, err := Open[string, any](path)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	// A mutation that can't be encoded must neither be written nor applied.
+	if err := store.AddVertex("A", make(chan int), graph.VertexProperties{}); err == nil {
+		t.Errorf("error expectancy doesn't match: expected error, got %v", err)
+	}
+
+	if count, _ := store.VertexCount(); count != 0 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 0, count)
+	}
+
+	_ = store.AddVertex("B", "B", graph.VertexProperties{})
+
+	// A mutation that can't be written must not be applied either.
+	_ = store.file.Close()
+
+	if err := store.AddVertex("C", "C", graph.VertexProperties{}); err == nil {
+		t.Errorf("error expectancy doesn't match: expected error, got %v", err)
+	}
+
+	if _, _, err := store.Vertex("C"); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	reopened, err := Open[string, any](path)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = reopened.Close()
+	})
+
+	if count, _ := reopened.VertexCount(); count != 1 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 1, count)
+	}
+}
+
+// assertGraph checks that the store contains the vertices A and B, both with a
+// weight of 2 and a "cpu" weight of 1.5, and the edge (A, B) with a weight of 5
+// and a color attribute.
+func assertGraph(t *testing.T, store *Store[string, string]) {
+	if count, _ := store.VertexCount(); count != 2 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 2, count)
+	}
+
+	if _, properties, err := store.Vertex("A"); err != nil || properties.Weight != 2 || properties.Weights["cpu"] != 1.5 {
+		t.Errorf("vertex properties don't match: got %v (error: %v)", properties, err)
+	}
+
+	if _, _, err := store.Vertex("C"); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	if count, _ := store.EdgeCount(); count != 1 {
+		t.Errorf("edge count doesn't match: expected %v, got %v", 1, count)
+	}
+
+	edge, err := store.Edge("A", "B")
+	if err != nil {
+		t.Fatalf("failed to get edge: %v", err)
+	}
+
+	if edge.Properties.Weight != 5 || edge.Properties.Attributes["color"] != "red" {
+		t.Errorf("edge properties don't match: got %v", edge.Properties)
+	}
+}
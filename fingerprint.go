@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+)
+
+// Fingerprint computes a 64-bit hash over the traits, vertices, edges, and
+// their properties of the given graph. Two graphs with the same contents have
+// the same fingerprint, regardless of the order in which their vertices and
+// edges have been added and regardless of their stores. This allows to cheaply
+// detect whether a graph has changed between two runs:
+//
+//	fingerprint, _ := graph.Fingerprint(g)
+//
+//	if fingerprint == cachedFingerprint {
+//		return cachedResult
+//	}
+//
+// Hashes, vertex values, and edge data are hashed using their Go-syntax
+// representation as printed by fmt with the %#v verb. Therefore, the
+// fingerprint is only stable across runs if this representation is stable,
+// which isn't the case for values containing pointers, for instance. Since the
+// fingerprint is a 64-bit hash, different graphs may have the same fingerprint
+// with a very low probability.
+func Fingerprint[K comparable, T any](g Graph[K, T]) (uint64, error) {
+	traits := g.Traits()
+
+	// The fingerprints of the individual vertices and edges are combined using
+	// addition, which is independent of their order.
+	fingerprint := fingerprintOf(func(w io.Writer) {
+		fmt.Fprintf(w, "traits\x00%t\x00%t\x00%t\x00%t\x00%t",
+			traits.IsDirected, traits.IsAcyclic, traits.IsWeighted, traits.IsRooted, traits.PreventCycles)
+	})
+
+	hashes, err := vertexHashes(g)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list vertices: %w", err)
+	}
+
+	for _, hash := range hashes {
+		value, properties, err := g.VertexWithProperties(hash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+
+		fingerprint += fingerprintOf(func(w io.Writer) {
+			fmt.Fprintf(w, "vertex\x00%#v\x00%#v\x00%d", hash, value, properties.Weight)
+			writeAttributes(w, properties.Attributes)
+			writeWeights(w, properties.Weights)
+		})
+	}
+
+	err = IterEdges(g, func(edge Edge[K]) bool {
+		source, target := fmt.Sprintf("%#v", edge.Source), fmt.Sprintf("%#v", edge.Target)
+
+		// An undirected edge has to have the same fingerprint in both
+		// directions, so its vertices are ordered.
+		if !traits.IsDirected && target < source {
+			source, target = target, source
+		}
+
+		fingerprint += fingerprintOf(func(w io.Writer) {
+			fmt.Fprintf(w, "edge\x00%s\x00%s\x00%d\x00%#v", source, target, edge.Properties.Weight, edge.Properties.Data)
+			writeAttributes(w, edge.Properties.Attributes)
+		})
+
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return fingerprint, nil
+}
+
+// fingerprintOf hashes the data written by the given function using FNV-1a
+// and mixes the result, so that the sum of multiple fingerprints is well
+// distributed.
+func fingerprintOf(write func(w io.Writer)) uint64 {
+	h := fnv.New64a()
+	write(h)
+
+	// This is the finalizer of SplitMix64.
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
+
+// writeWeights writes the named weights of a vertex. Vertices without named
+// weights keep the fingerprint they had before named weights were introduced.
+func writeWeights(w io.Writer, weights map[string]float64) {
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(w, "\x00weight:%q=%v", name, weights[name])
+	}
+}
+
+func writeAttributes(w io.Writer, attributes map[string]string) {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(w, "\x00%q=%q", key, attributes[key])
+	}
+}
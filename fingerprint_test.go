@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	build := func(options []func(*Traits), modify func(g Graph[string, string])) Graph[string, string] {
+		g := New(StringHash, options...)
+
+		_ = g.AddVertex("A", VertexAttribute("color", "red"))
+		_ = g.AddVertex("B")
+		_ = g.AddVertex("C")
+		_ = g.AddEdge("A", "B", EdgeWeight(2))
+		_ = g.AddEdge("B", "C", EdgeData([]int{1, 2}))
+
+		if modify != nil {
+			modify(g)
+		}
+
+		return g
+	}
+
+	base := build(nil, nil)
+
+	tests := map[string]struct {
+		g             Graph[string, string]
+		expectedEqual bool
+	}{
+		"same graph": {
+			g:             build(nil, nil),
+			expectedEqual: true,
+		},
+		"different insertion order": {
+			g: func() Graph[string, string] {
+				g := New(StringHash)
+				_ = g.AddVertex("C")
+				_ = g.AddVertex("B")
+				_ = g.AddVertex("A", VertexAttribute("color", "red"))
+				_ = g.AddEdge("C", "B", EdgeData([]int{1, 2}))
+				_ = g.AddEdge("B", "A", EdgeWeight(2))
+				return g
+			}(),
+			expectedEqual: true,
+		},
+		"different traits": {
+			g:             build([]func(*Traits){Directed()}, nil),
+			expectedEqual: false,
+		},
+		"different vertex attribute": {
+			g: build(nil, func(g Graph[string, string]) {
+				_ = g.AddVertex("D", VertexAttribute("color", "blue"))
+			}),
+			expectedEqual: false,
+		},
+		"different edge weight": {
+			g: build(nil, func(g Graph[string, string]) {
+				_ = g.UpdateEdge("A", "B", EdgeWeight(3))
+			}),
+			expectedEqual: false,
+		},
+		"different edge data": {
+			g: build(nil, func(g Graph[string, string]) {
+				_ = g.UpdateEdge("B", "C", EdgeData([]int{2, 1}))
+			}),
+			expectedEqual: false,
+		},
+		"additional edge": {
+			g: build(nil, func(g Graph[string, string]) {
+				_ = g.AddEdge("A", "C")
+			}),
+			expectedEqual: false,
+		},
+	}
+
+	expected, err := Fingerprint(base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, test := range tests {
+		fingerprint, err := Fingerprint(test.g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if (fingerprint == expected) != test.expectedEqual {
+			t.Errorf("%s: fingerprint equality doesn't match: expected %v, got %v", name, test.expectedEqual, !test.expectedEqual)
+		}
+	}
+}
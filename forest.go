@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"fmt"
+	"sync"
+)
+
+// forest keeps track of the connected components of an undirected graph that
+// prevents cycles. Such a graph always is a forest, so adding an edge creates a
+// cycle if and only if both of its vertices already are in the same component.
+//
+// The components are maintained incrementally using a union-find structure,
+// which makes checking an edge for cycles nearly O(1) instead of running a DFS
+// for each added edge. Union-find doesn't support splitting components, so
+// removing an edge or a vertex discards the components, and they are rebuilt
+// from the store on the next check.
+type forest[K comparable, T any] struct {
+	lock       sync.Mutex
+	store      Store[K, T]
+	components *unionFind[K]
+}
+
+// newForest creates a forest for the given store and registers the hooks that
+// keep it up to date.
+func newForest[K comparable, T any](store Store[K, T], h *hooks[K, T]) *forest[K, T] {
+	f := &forest[K, T]{
+		store: store,
+	}
+
+	h.register(hookFuncs[K, T]{
+		addEdge: func(edge Edge[K]) {
+			f.join(edge.Source, edge.Target)
+		},
+		removeEdge: func(K, K) {
+			f.invalidate()
+		},
+		removeVertex: func(K) {
+			f.invalidate()
+		},
+	})
+
+	return f
+}
+
+// createsCycle reports whether the given vertices already are in the same
+// component, i.e. whether an edge joining them would create a cycle.
+func (f *forest[K, T]) createsCycle(source, target K) (bool, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if source == target {
+		return true, nil
+	}
+
+	if f.components == nil {
+		if err := f.build(); err != nil {
+			return false, err
+		}
+	}
+
+	return f.find(source) == f.find(target), nil
+}
+
+// join merges the components of the given vertices after an edge joining them
+// has been added. If the components have been discarded, nothing happens since
+// they will be rebuilt including the new edge.
+func (f *forest[K, T]) join(source, target K) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.components == nil {
+		return
+	}
+
+	f.components.union(f.find(source), f.find(target))
+}
+
+func (f *forest[K, T]) invalidate() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.components = nil
+}
+
+// find returns the root of the component of the given vertex. Vertices that
+// have been added after the components have been built form a component of
+// their own.
+func (f *forest[K, T]) find(hash K) K {
+	if _, ok := f.components.parents[hash]; !ok {
+		f.components.add(hash)
+	}
+
+	return f.components.find(hash)
+}
+
+func (f *forest[K, T]) build() error {
+	components := newUnionFind[K]()
+
+	err := iterVertexHashes(f.store, func(hash K) bool {
+		components.add(hash)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list vertices: %w", err)
+	}
+
+	err = iterEdges(f.store, func(edge Edge[K]) bool {
+		components.union(edge.Source, edge.Target)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list edges: %w", err)
+	}
+
+	f.components = components
+
+	return nil
+}
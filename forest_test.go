@@ -0,0 +1,146 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestForest(t *testing.T) {
+	type step struct {
+		add         [2]int
+		remove      bool
+		expectedErr error
+	}
+
+	tests := map[string]struct {
+		vertices []int
+		steps    []step
+	}{
+		"cycle of three vertices": {
+			vertices: []int{1, 2, 3},
+			steps: []step{
+				{add: [2]int{1, 2}},
+				{add: [2]int{2, 3}},
+				{add: [2]int{3, 1}, expectedErr: ErrEdgeCreatesCycle},
+			},
+		},
+		"self-loop": {
+			vertices: []int{1},
+			steps: []step{
+				{add: [2]int{1, 1}, expectedErr: ErrEdgeCreatesCycle},
+			},
+		},
+		"removed edge splits a component": {
+			vertices: []int{1, 2, 3},
+			steps: []step{
+				{add: [2]int{1, 2}},
+				{add: [2]int{2, 3}},
+				{add: [2]int{3, 1}, expectedErr: ErrEdgeCreatesCycle},
+				{add: [2]int{1, 2}, remove: true},
+				{add: [2]int{3, 1}},
+				{add: [2]int{1, 2}, expectedErr: ErrEdgeCreatesCycle},
+			},
+		},
+		"joined components": {
+			vertices: []int{1, 2, 3, 4},
+			steps: []step{
+				{add: [2]int{1, 2}},
+				{add: [2]int{3, 4}},
+				{add: [2]int{2, 3}},
+				{add: [2]int{4, 1}, expectedErr: ErrEdgeCreatesCycle},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash, PreventCycles())
+
+		for _, vertex := range test.vertices {
+			_ = g.AddVertex(vertex)
+		}
+
+		for i, step := range test.steps {
+			var err error
+			if step.remove {
+				err = g.RemoveEdge(step.add[0], step.add[1])
+			} else {
+				err = g.AddEdge(step.add[0], step.add[1])
+			}
+
+			if !errors.Is(err, step.expectedErr) {
+				t.Errorf("%s: step %d: error expectancy doesn't match: expected %v, got %v", name, i, step.expectedErr, err)
+			}
+		}
+	}
+}
+
+func TestForest_vertexAddedLater(t *testing.T) {
+	g := New(IntHash, PreventCycles())
+
+	_ = g.AddVertex(1)
+	_ = g.AddVertex(2)
+	_ = g.AddEdge(1, 2)
+
+	// The components are built by the first check, so vertex 3 is unknown to
+	// them until it is joined with another vertex.
+	_ = g.AddVertex(3)
+
+	createsCycle, err := CreatesCycle(g, 3, 1)
+	if err != nil || createsCycle {
+		t.Fatalf("expected no cycle, got %v (error: %v)", createsCycle, err)
+	}
+
+	if err := g.AddEdge(3, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := g.AddEdge(2, 3); !errors.Is(err, ErrEdgeCreatesCycle) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrEdgeCreatesCycle, err)
+	}
+}
+
+func TestForest_matchesDFS(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	g := New(IntHash, PreventCycles())
+	reference := New(IntHash)
+
+	for i := 0; i < 50; i++ {
+		_ = g.AddVertex(i)
+		_ = reference.AddVertex(i)
+	}
+
+	for i := 0; i < 500; i++ {
+		source, target := random.Intn(50), random.Intn(50)
+
+		if random.Intn(5) == 0 {
+			if g.RemoveEdge(source, target) == nil {
+				_ = reference.RemoveEdge(source, target)
+			}
+			continue
+		}
+
+		if _, err := reference.Edge(source, target); err == nil {
+			continue
+		}
+
+		expected := source == target
+		if !expected {
+			_ = DFS(reference, source, func(hash int) bool {
+				expected = hash == target
+				return expected
+			})
+		}
+
+		err := g.AddEdge(source, target)
+
+		if expected != errors.Is(err, ErrEdgeCreatesCycle) {
+			t.Fatalf("cycle expectancy for (%v, %v) doesn't match: expected %v, got %v", source, target, expected, err)
+		}
+
+		if err == nil {
+			_ = reference.AddEdge(source, target)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrGraphFrozen is returned when attempting to modify a graph created by
+// [Freeze].
+var ErrGraphFrozen = errors.New("graph is frozen")
+
+// Freeze creates an immutable copy of the given graph that is optimized for
+// reading. Instead of nested maps, the vertices and edges are stored in
+// contiguous slices in compressed sparse row (CSR) format, which speeds up
+// traversals and reduces the pressure on the garbage collector.
+//
+// Freeze is intended for analytics workloads that build a graph once and run
+// many algorithms on it afterwards:
+//
+//	frozen, _ := graph.Freeze(g)
+//
+//	_ = graph.DFS(frozen, 1, func(value int) bool {
+//		return false
+//	})
+//
+// The frozen graph has the same traits as g. Attempting to add, update, or
+// remove vertices or edges of the frozen graph returns ErrGraphFrozen.
+func Freeze[K comparable, T any](g Graph[K, T]) (Graph[K, T], error) {
+	var hash Hash[K, T]
+
+	switch g := g.(type) {
+	case *directed[K, T]:
+		hash = g.hash
+	case *undirected[K, T]:
+		hash = g.hash
+	case *cachedGraph[K, T]:
+		return Freeze(g.Graph)
+	default:
+		return nil, errors.New("graph can't be frozen")
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	// The attributes of the edges and vertices may be shared with the store of
+	// g, which would make later modifications of g visible in the copy.
+	adjacencyMap = copyEdgeMap(adjacencyMap)
+
+	store := &csrStore[K, T]{
+		index:      make(map[K]int, len(adjacencyMap)),
+		hashes:     make([]K, 0, len(adjacencyMap)),
+		values:     make([]T, 0, len(adjacencyMap)),
+		properties: make([]VertexProperties, 0, len(adjacencyMap)),
+	}
+
+	for hash := range adjacencyMap {
+		value, properties, err := g.VertexWithProperties(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+
+		properties.Attributes = copyAttributes(properties.Attributes)
+		properties.Weights = copyWeights(properties.Weights)
+
+		store.index[hash] = len(store.hashes)
+		store.hashes = append(store.hashes, hash)
+		store.values = append(store.values, value)
+		store.properties = append(store.properties, properties)
+	}
+
+	store.build(adjacencyMap)
+
+	traits := *g.Traits()
+
+	return NewWithStore(hash, Store[K, T](store), func(t *Traits) {
+		*t = traits
+	}), nil
+}
+
+// csrStore is an immutable store that keeps the vertices and edges in compressed
+// sparse row format. Each vertex is identified by its index in hashes. The
+// outgoing edges of vertex i are stored in outEdges[outOffsets[i]:outOffsets[i+1]],
+// sorted by the indices of their targets. The ingoing edges are stored the same
+// way, referring to positions in outEdges.
+type csrStore[K comparable, T any] struct {
+	index      map[K]int
+	hashes     []K
+	values     []T
+	properties []VertexProperties
+
+	outOffsets []int
+	outTargets []int
+	outEdges   []Edge[K]
+
+	inOffsets   []int
+	inSources   []int
+	inPositions []int
+
+	// pairCount is the number of vertex pairs joined by an edge in either
+	// direction, see memoryStore.
+	pairCount int
+}
+
+func (s *csrStore[K, T]) build(adjacencyMap map[K]map[K]Edge[K]) {
+	n := len(s.hashes)
+
+	s.outOffsets = make([]int, n+1)
+	s.inOffsets = make([]int, n+1)
+
+	edgeCount := 0
+
+	for source, edges := range adjacencyMap {
+		s.outOffsets[s.index[source]+1] = len(edges)
+		for target := range edges {
+			s.inOffsets[s.index[target]+1]++
+
+			// A pair joined by edges in both directions is only counted for
+			// one of them.
+			if _, ok := adjacencyMap[target][source]; !ok || s.index[source] <= s.index[target] {
+				s.pairCount++
+			}
+		}
+		edgeCount += len(edges)
+	}
+
+	for i := 0; i < n; i++ {
+		s.outOffsets[i+1] += s.outOffsets[i]
+		s.inOffsets[i+1] += s.inOffsets[i]
+	}
+
+	s.outTargets = make([]int, edgeCount)
+	s.outEdges = make([]Edge[K], edgeCount)
+
+	for i, source := range s.hashes {
+		position := s.outOffsets[i]
+
+		for target, edge := range adjacencyMap[source] {
+			s.outTargets[position] = s.index[target]
+			s.outEdges[position] = edge
+			position++
+		}
+
+		sort.Sort(csrRow[K]{
+			targets: s.outTargets[s.outOffsets[i]:s.outOffsets[i+1]],
+			edges:   s.outEdges[s.outOffsets[i]:s.outOffsets[i+1]],
+		})
+	}
+
+	// Iterating the sources in ascending order yields the ingoing edges of each
+	// vertex sorted by their sources.
+	s.inSources = make([]int, edgeCount)
+	s.inPositions = make([]int, edgeCount)
+
+	next := make([]int, n)
+	copy(next, s.inOffsets[:n])
+
+	for source := 0; source < n; source++ {
+		for position := s.outOffsets[source]; position < s.outOffsets[source+1]; position++ {
+			target := s.outTargets[position]
+			s.inSources[next[target]] = source
+			s.inPositions[next[target]] = position
+			next[target]++
+		}
+	}
+}
+
+// csrRow sorts the outgoing edges of a vertex by the indices of their targets.
+type csrRow[K comparable] struct {
+	targets []int
+	edges   []Edge[K]
+}
+
+func (r csrRow[K]) Len() int {
+	return len(r.targets)
+}
+
+func (r csrRow[K]) Less(i, j int) bool {
+	return r.targets[i] < r.targets[j]
+}
+
+func (r csrRow[K]) Swap(i, j int) {
+	r.targets[i], r.targets[j] = r.targets[j], r.targets[i]
+	r.edges[i], r.edges[j] = r.edges[j], r.edges[i]
+}
+
+func (s *csrStore[K, T]) AddVertex(K, T, VertexProperties) error {
+	return ErrGraphFrozen
+}
+
+func (s *csrStore[K, T]) Vertex(hash K) (T, VertexProperties, error) {
+	i, ok := s.index[hash]
+	if !ok {
+		var value T
+		return value, VertexProperties{}, &VertexNotFoundError[K]{Hash: hash}
+	}
+
+	return s.values[i], s.properties[i], nil
+}
+
+// vertexIndex implements vertexIndexer, so that traversals of a frozen graph can
+// keep track of the visited vertices using a bitset.
+func (s *csrStore[K, T]) vertexIndex(hash K) (int, bool) {
+	i, ok := s.index[hash]
+	return i, ok
+}
+
+func (s *csrStore[K, T]) vertexIndexLimit() int {
+	return len(s.hashes)
+}
+
+func (s *csrStore[K, T]) RemoveVertex(K) error {
+	return ErrGraphFrozen
+}
+
+func (s *csrStore[K, T]) ListVertices() ([]K, error) {
+	hashes := make([]K, len(s.hashes))
+	copy(hashes, s.hashes)
+
+	return hashes, nil
+}
+
+func (s *csrStore[K, T]) VertexCount() (int, error) {
+	return len(s.hashes), nil
+}
+
+func (s *csrStore[K, T]) AddEdge(K, K, Edge[K]) error {
+	return ErrGraphFrozen
+}
+
+func (s *csrStore[K, T]) UpdateEdge(K, K, Edge[K]) error {
+	return ErrGraphFrozen
+}
+
+func (s *csrStore[K, T]) RemoveEdge(K, K) error {
+	return ErrGraphFrozen
+}
+
+func (s *csrStore[K, T]) Edge(sourceHash, targetHash K) (Edge[K], error) {
+	source, ok := s.index[sourceHash]
+	if !ok {
+		return Edge[K]{}, &EdgeNotFoundError[K]{Source: sourceHash, Target: targetHash}
+	}
+
+	target, ok := s.index[targetHash]
+	if !ok {
+		return Edge[K]{}, &EdgeNotFoundError[K]{Source: sourceHash, Target: targetHash}
+	}
+
+	start, end := s.outOffsets[source], s.outOffsets[source+1]
+	targets := s.outTargets[start:end]
+
+	i := sort.SearchInts(targets, target)
+	if i == len(targets) || targets[i] != target {
+		return Edge[K]{}, &EdgeNotFoundError[K]{Source: sourceHash, Target: targetHash}
+	}
+
+	return s.outEdges[start+i], nil
+}
+
+func (s *csrStore[K, T]) ListEdges() ([]Edge[K], error) {
+	edges := make([]Edge[K], len(s.outEdges))
+	copy(edges, s.outEdges)
+
+	return edges, nil
+}
+
+func (s *csrStore[K, T]) EdgeCount() (int, error) {
+	return len(s.outEdges), nil
+}
+
+func (s *csrStore[K, T]) edgePairCount() (int, error) {
+	return s.pairCount, nil
+}
+
+func (s *csrStore[K, T]) IterVertices(yield func(hash K, value T, properties VertexProperties) bool) error {
+	for i, hash := range s.hashes {
+		if !yield(hash, s.values[i], s.properties[i]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *csrStore[K, T]) IterEdges(yield func(edge Edge[K]) bool) error {
+	for _, edge := range s.outEdges {
+		if !yield(edge) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *csrStore[K, T]) EdgesBySource(sourceHash K) ([]Edge[K], error) {
+	source, ok := s.index[sourceHash]
+	if !ok {
+		return nil, &VertexNotFoundError[K]{Hash: sourceHash}
+	}
+
+	edges := make([]Edge[K], s.outOffsets[source+1]-s.outOffsets[source])
+	copy(edges, s.outEdges[s.outOffsets[source]:s.outOffsets[source+1]])
+
+	return edges, nil
+}
+
+func (s *csrStore[K, T]) EdgesByTarget(targetHash K) ([]Edge[K], error) {
+	target, ok := s.index[targetHash]
+	if !ok {
+		return nil, &VertexNotFoundError[K]{Hash: targetHash}
+	}
+
+	edges := make([]Edge[K], 0, s.inOffsets[target+1]-s.inOffsets[target])
+	for _, position := range s.inPositions[s.inOffsets[target]:s.inOffsets[target+1]] {
+		edges = append(edges, s.outEdges[position])
+	}
+
+	return edges, nil
+}
+
+func (s *csrStore[K, T]) visitOutEdges(sourceHash K, yield func(K, Edge[K])) error {
+	source, ok := s.index[sourceHash]
+	if !ok {
+		return &VertexNotFoundError[K]{Hash: sourceHash}
+	}
+
+	for position := s.outOffsets[source]; position < s.outOffsets[source+1]; position++ {
+		yield(s.hashes[s.outTargets[position]], s.outEdges[position])
+	}
+
+	return nil
+}
+
+func (s *csrStore[K, T]) visitInEdges(targetHash K, yield func(K, Edge[K])) error {
+	target, ok := s.index[targetHash]
+	if !ok {
+		return &VertexNotFoundError[K]{Hash: targetHash}
+	}
+
+	for i := s.inOffsets[target]; i < s.inOffsets[target+1]; i++ {
+		yield(s.hashes[s.inSources[i]], s.outEdges[s.inPositions[i]])
+	}
+
+	return nil
+}
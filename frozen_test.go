@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	tests := map[string]struct {
+		traits []func(*Traits)
+		edges  [][2]int
+	}{
+		"directed graph": {
+			traits: []func(*Traits){Directed()},
+			edges:  [][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 4}, {4, 1}},
+		},
+		"undirected graph": {
+			edges: [][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 4}},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash, test.traits...)
+
+		for i := 1; i <= 5; i++ {
+			_ = g.AddVertex(i, VertexWeight(i))
+		}
+
+		for _, edge := range test.edges {
+			_ = g.AddEdge(edge[0], edge[1], EdgeWeight(edge[0]+edge[1]))
+		}
+
+		frozen, err := Freeze(g)
+		if err != nil {
+			t.Fatalf("%s: failed to freeze graph: %v", name, err)
+		}
+
+		if !reflect.DeepEqual(frozen.Traits(), g.Traits()) {
+			t.Errorf("%s: traits don't match: expected %v, got %v", name, g.Traits(), frozen.Traits())
+		}
+
+		expectedAdjacencyMap, _ := g.AdjacencyMap()
+		adjacencyMap, _ := frozen.AdjacencyMap()
+
+		if !reflect.DeepEqual(adjacencyMap, expectedAdjacencyMap) {
+			t.Errorf("%s: adjacency maps don't match: expected %v, got %v", name, expectedAdjacencyMap, adjacencyMap)
+		}
+
+		expectedPredecessorMap, _ := g.PredecessorMap()
+		predecessorMap, _ := frozen.PredecessorMap()
+
+		if !reflect.DeepEqual(predecessorMap, expectedPredecessorMap) {
+			t.Errorf("%s: predecessor maps don't match: expected %v, got %v", name, expectedPredecessorMap, predecessorMap)
+		}
+
+		expectedSize, _ := g.Size()
+		if size, _ := frozen.Size(); size != expectedSize {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, expectedSize, size)
+		}
+
+		_, properties, _ := frozen.VertexWithProperties(3)
+		if properties.Weight != 3 {
+			t.Errorf("%s: vertex weight doesn't match: expected %v, got %v", name, 3, properties.Weight)
+		}
+
+		edge, err := frozen.Edge(2, 4)
+		if err != nil || edge.Properties.Weight != 6 {
+			t.Errorf("%s: edge doesn't match: got %v (error: %v)", name, edge, err)
+		}
+
+		if _, err := frozen.Edge(1, 5); !errors.Is(err, ErrEdgeNotFound) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrEdgeNotFound, err)
+		}
+
+		expectedPath, _ := ShortestPath(g, 1, 4)
+		path, err := ShortestPath(frozen, 1, 4)
+		if err != nil || len(path) != len(expectedPath) {
+			t.Errorf("%s: shortest path length doesn't match: expected %v, got %v (error: %v)", name, expectedPath, path, err)
+		}
+
+		if err := frozen.AddVertex(6); !errors.Is(err, ErrGraphFrozen) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrGraphFrozen, err)
+		}
+
+		if err := frozen.AddEdge(1, 5); !errors.Is(err, ErrGraphFrozen) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrGraphFrozen, err)
+		}
+
+		if err := frozen.RemoveEdge(1, 2); !errors.Is(err, ErrGraphFrozen) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrGraphFrozen, err)
+		}
+
+		// Modifying the original graph must not affect the frozen graph.
+		_ = g.AddEdge(1, 5)
+
+		if _, err := frozen.Edge(1, 5); !errors.Is(err, ErrEdgeNotFound) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrEdgeNotFound, err)
+		}
+	}
+}
+
+func BenchmarkDFS_frozen(b *testing.B) {
+	graph := New(IntHash, Directed())
+
+	for i := 0; i < 1000; i++ {
+		_ = graph.AddVertex(i)
+	}
+
+	for i := 0; i < 1000; i++ {
+		_ = graph.AddEdge(i, (i+1)%1000)
+		_ = graph.AddEdge(i, (i*7)%1000)
+	}
+
+	frozen, _ := Freeze(graph)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = DFS(frozen, 0, func(int) bool {
+			return false
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// GobGraph wraps a graph so that it can be encoded and decoded using the
+// encoding/gob package. This allows to cache a computed graph on disk between
+// runs of a program without a custom file format:
+//
+//	file, _ := os.Create("graph.gob")
+//	_ = gob.NewEncoder(file).Encode(graph.Gob(g))
+//
+// Decoding requires a graph with the same hash function and directedness as
+// the encoded graph. The remaining traits are taken from the encoded graph:
+//
+//	h := graph.New(graph.StringHash, graph.Directed())
+//	_ = gob.NewDecoder(file).Decode(graph.Gob(h))
+//
+// The vertex hashes, values, and edge data need to be encodable by gob. Edge
+// data of an interface type has to be registered using gob.Register. The
+// vertices and edges are read from the store one after another, so concurrent
+// mutations may lead to an inconsistent encoding.
+type GobGraph[K comparable, T any] struct {
+	g Graph[K, T]
+}
+
+// Gob returns a [GobGraph] that encodes the given graph or decodes into it.
+func Gob[K comparable, T any](g Graph[K, T]) *GobGraph[K, T] {
+	return &GobGraph[K, T]{g: g}
+}
+
+// Graph returns the wrapped graph.
+func (g *GobGraph[K, T]) Graph() Graph[K, T] {
+	return g.g
+}
+
+// gobSnapshot is the gob representation of a graph. Like snapshot, it contains
+// undirected edges in both directions.
+type gobSnapshot[K comparable, T any] struct {
+	Traits   Traits
+	Vertices []snapshotVertex[K, T]
+	Edges    []snapshotEdge[K]
+}
+
+// GobEncode implements gob.GobEncoder.
+func (g *GobGraph[K, T]) GobEncode() ([]byte, error) {
+	store, ok := storeOf(g.g)
+	if !ok {
+		return nil, errors.New("graph doesn't support gob encoding")
+	}
+
+	s, err := takeSnapshot(g.g, store)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	err = gob.NewEncoder(&buf).Encode(gobSnapshot[K, T]{
+		Traits:   *g.g.Traits(),
+		Vertices: s.Vertices,
+		Edges:    s.Edges,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode graph: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder. The vertices and edges are added to the
+// store of the wrapped graph, which has to be empty.
+func (g *GobGraph[K, T]) GobDecode(data []byte) error {
+	store, ok := storeOf(g.g)
+	if !ok {
+		return errors.New("graph doesn't support gob decoding")
+	}
+
+	var s gobSnapshot[K, T]
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return fmt.Errorf("failed to decode graph: %w", err)
+	}
+
+	if s.Traits.IsDirected != g.g.Traits().IsDirected {
+		return errors.New("directedness of the encoded graph doesn't match")
+	}
+
+	if err := restoreSnapshot(store, snapshot[K, T]{Vertices: s.Vertices, Edges: s.Edges}); err != nil {
+		return err
+	}
+
+	*g.g.Traits() = s.Traits
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestGobGraph(t *testing.T) {
+	tests := map[string]struct {
+		traits        []func(*Traits)
+		decodeTraits  []func(*Traits)
+		expectedError bool
+	}{
+		"directed graph": {
+			traits:       []func(*Traits){Directed(), Acyclic(), Weighted()},
+			decodeTraits: []func(*Traits){Directed()},
+		},
+		"undirected graph": {
+			traits:       []func(*Traits){Weighted()},
+			decodeTraits: []func(*Traits){},
+		},
+		"mismatching directedness": {
+			traits:        []func(*Traits){Directed()},
+			decodeTraits:  []func(*Traits){},
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		g := New(StringHash, test.traits...)
+
+		_ = g.AddVertex("A", VertexWeight(2), VertexAttribute("color", "red"))
+		_ = g.AddVertex("B")
+		_ = g.AddVertex("C")
+		_ = g.AddEdge("A", "B", EdgeWeight(3), EdgeData(42))
+		_ = g.AddEdge("B", "C", EdgeAttribute("label", "x"))
+
+		var buf bytes.Buffer
+
+		if err := gob.NewEncoder(&buf).Encode(Gob(g)); err != nil {
+			t.Fatalf("%s: failed to encode graph: %v", name, err)
+		}
+
+		h := New(StringHash, test.decodeTraits...)
+
+		err := gob.NewDecoder(&buf).Decode(Gob(h))
+
+		if test.expectedError != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedError, err)
+		}
+
+		if test.expectedError {
+			continue
+		}
+
+		if *h.Traits() != *g.Traits() {
+			t.Errorf("%s: traits don't match: expected %v, got %v", name, g.Traits(), h.Traits())
+		}
+
+		if size, _ := h.Size(); size != 2 {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, 2, size)
+		}
+
+		_, properties, _ := h.VertexWithProperties("A")
+		if properties.Weight != 2 || properties.Attributes["color"] != "red" {
+			t.Errorf("%s: vertex properties don't match: got %v", name, properties)
+		}
+
+		edge, _ := h.Edge("A", "B")
+		if edge.Properties.Weight != 3 || edge.Properties.Data != 42 {
+			t.Errorf("%s: edge properties don't match: got %v", name, edge.Properties)
+		}
+
+		edge, _ = h.Edge("C", "B")
+		if !g.Traits().IsDirected && edge.Properties.Attributes["label"] != "x" {
+			t.Errorf("%s: edge properties don't match: got %v", name, edge.Properties)
+		}
+	}
+}
@@ -49,8 +49,15 @@
 // For detailed usage examples, take a look at the README.
 package graph
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
+// The errors returned by graphs and the built-in stores wrap these sentinel
+// errors in typed errors like [VertexNotFoundError] or [EdgeNotFoundError],
+// which report the vertices involved. Use errors.Is to check for a sentinel
+// error and errors.As to obtain the typed error.
 var (
 	ErrVertexNotFound      = errors.New("vertex not found")
 	ErrVertexAlreadyExists = errors.New("vertex already exists")
@@ -201,6 +208,10 @@ type Graph[K comparable, T any] interface {
 	// The cloned graph will use the default in-memory store for storing the
 	// vertices and edges. If you want to utilize a custom store instead, create
 	// a new graph using NewWithStore and use AddVerticesFrom and AddEdgesFrom.
+	//
+	// If the graph itself uses the default in-memory store, cloning is an O(1)
+	// operation: The original and the cloned graph share their vertices and
+	// edges until one of them is modified, which copies them once.
 	Clone() (Graph[K, T], error)
 
 	// Order returns the number of vertices in the graph.
@@ -256,6 +267,25 @@ func New[K comparable, T any](hash Hash[K, T], options ...func(*Traits)) Graph[K
 	return NewWithStore(hash, newMemoryStore[K, T](), options...)
 }
 
+// NewWithCapacity creates a new graph same as [New], but presizes the in-memory
+// store for the given number of vertices and edges. This speeds up building
+// large graphs whose size is known in advance. See [NewMemoryStoreWithCapacity]
+// for details.
+func NewWithCapacity[K comparable, T any](hash Hash[K, T], vertices, edges int, options ...func(*Traits)) Graph[K, T] {
+	var p Traits
+
+	for _, option := range options {
+		option(&p)
+	}
+
+	// Undirected graphs store each edge in both directions.
+	if !p.IsDirected {
+		edges *= 2
+	}
+
+	return NewWithStore(hash, NewMemoryStoreWithCapacity[K, T](vertices, edges), options...)
+}
+
 // NewWithStore creates a new graph same as [New] but uses the provided store
 // instead of the default memory store.
 func NewWithStore[K comparable, T any](hash Hash[K, T], store Store[K, T], options ...func(*Traits)) Graph[K, T] {
@@ -300,6 +330,103 @@ func NewLike[K comparable, T any](g Graph[K, T]) Graph[K, T] {
 	return New(hash, copyTraits)
 }
 
+// NewFromAdjacencyMap creates a graph from an adjacency map literal, which maps
+// each vertex to its adjacent vertices and the properties of the edges leading
+// to them. The vertices are their own hashes, so this works with StringHash and
+// IntHash:
+//
+//	g, _ := graph.NewFromAdjacencyMap(graph.StringHash, map[string]map[string]graph.EdgeProperties{
+//		"A": {"B": {Weight: 2}, "C": {}},
+//		"B": {"C": {}},
+//		"D": {},
+//	}, graph.Directed())
+//
+// Vertices that only appear as adjacent vertices are added as well. For
+// undirected graphs, an edge may be listed for both of its vertices, in which
+// case the properties of one of them are used. The vertices and edges are added
+// in the order of their hashes.
+func NewFromAdjacencyMap[K comparable](hash Hash[K, K], m map[K]map[K]EdgeProperties, options ...func(*Traits)) (Graph[K, K], error) {
+	g := New(hash, options...)
+
+	sources := make([]K, 0, len(m))
+	for source := range m {
+		sources = append(sources, source)
+	}
+	sortHashes(sources)
+
+	targets := make(map[K][]K, len(m))
+
+	for _, source := range sources {
+		if err := addVertexIfMissing(g, source); err != nil {
+			return nil, err
+		}
+
+		for target := range m[source] {
+			targets[source] = append(targets[source], target)
+		}
+		sortHashes(targets[source])
+
+		for _, target := range targets[source] {
+			if err := addVertexIfMissing(g, target); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, source := range sources {
+		for _, target := range targets[source] {
+			edge := Edge[K]{Source: source, Target: target, Properties: m[source][target]}
+
+			err := g.AddEdge(copyEdge(edge))
+			if errors.Is(err, ErrEdgeAlreadyExists) && !g.Traits().IsDirected {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to add edge (%v, %v): %w", source, target, err)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// NewFromEdges creates a graph containing the given edges. The vertices are
+// their own hashes, so this works with StringHash and IntHash:
+//
+//	g, _ := graph.NewFromEdges(graph.IntHash, []graph.Edge[int]{
+//		{Source: 1, Target: 2},
+//		{Source: 2, Target: 3, Properties: graph.EdgeProperties{Weight: 4}},
+//	})
+//
+// The vertices are added in the order of their first occurrence. An edge that
+// occurs twice results in an error.
+func NewFromEdges[K comparable](hash Hash[K, K], edges []Edge[K], options ...func(*Traits)) (Graph[K, K], error) {
+	g := New(hash, options...)
+
+	for _, edge := range edges {
+		if err := addVertexIfMissing(g, edge.Source); err != nil {
+			return nil, err
+		}
+		if err := addVertexIfMissing(g, edge.Target); err != nil {
+			return nil, err
+		}
+
+		if err := g.AddEdge(copyEdge(edge)); err != nil {
+			return nil, fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	return g, nil
+}
+
+func addVertexIfMissing[K comparable](g Graph[K, K], hash K) error {
+	if err := g.AddVertex(hash); err != nil && !errors.Is(err, ErrVertexAlreadyExists) {
+		return fmt.Errorf("failed to add vertex %v: %w", hash, err)
+	}
+
+	return nil
+}
+
 // StringHash is a hashing function that accepts a string and uses that exact
 // string as a hash value. Using it as Hash will yield a Graph[string, string].
 func StringHash(v string) string {
@@ -357,9 +484,16 @@ func EdgeData(data any) func(*EdgeProperties) {
 //
 // The example above will create a vertex with a weight of 2 and an attribute
 // "color" with value "red".
+//
+// In addition to its integer weight, a vertex may have any number of named
+// weights, which are floating-point numbers. They allow storing multiple
+// capacities or costs per vertex, e.g. for scheduling:
+//
+//	_ = g.AddVertex("node-1", graph.VertexWeightNamed("cpu", 1.5), graph.VertexWeightNamed("memory", 4))
 type VertexProperties struct {
 	Attributes map[string]string
 	Weight     int
+	Weights    map[string]float64
 }
 
 // VertexWeight returns a function that sets the weight of a vertex to the given
@@ -371,6 +505,30 @@ func VertexWeight(weight int) func(*VertexProperties) {
 	}
 }
 
+// VertexWeightNamed returns a function that sets the weight of a vertex in the
+// given dimension, e.g. "cpu" or "memory". Named weights are independent of
+// the weight set using [VertexWeight]. This is a functional option for the
+// [graph.Graph.AddVertex] methods.
+func VertexWeightNamed(name string, weight float64) func(*VertexProperties) {
+	return func(e *VertexProperties) {
+		if e.Weights == nil {
+			e.Weights = make(map[string]float64)
+		}
+		e.Weights[name] = weight
+	}
+}
+
+// VertexWeights returns a function that sets the given named weights of a
+// vertex, as described in [VertexWeightNamed]. This is a functional option for
+// the [graph.Graph.AddVertex] methods.
+func VertexWeights(weights map[string]float64) func(*VertexProperties) {
+	return func(e *VertexProperties) {
+		for name, weight := range weights {
+			VertexWeightNamed(name, weight)(e)
+		}
+	}
+}
+
 // VertexAttribute returns a function that adds the given key-value pair to the
 // vertex attributes. This is a functional option for the [graph.Graph.Vertex]
 // and [graph.Graph.AddVertex] methods.
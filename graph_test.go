@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -296,3 +297,200 @@ func TestVertexAttributes(t *testing.T) {
 		})
 	}
 }
+
+func TestVertexWeightNamed(t *testing.T) {
+	tests := map[string]struct {
+		options  []func(*VertexProperties)
+		expected map[string]float64
+	}{
+		"single weight": {
+			options:  []func(*VertexProperties){VertexWeightNamed("cpu", 1.5)},
+			expected: map[string]float64{"cpu": 1.5},
+		},
+		"multiple weights": {
+			options: []func(*VertexProperties){
+				VertexWeightNamed("cpu", 1.5),
+				VertexWeightNamed("memory", 4),
+			},
+			expected: map[string]float64{"cpu": 1.5, "memory": 4},
+		},
+		"overwritten weight": {
+			options: []func(*VertexProperties){
+				VertexWeightNamed("cpu", 1.5),
+				VertexWeights(map[string]float64{"cpu": 2, "gpu": 0.5}),
+			},
+			expected: map[string]float64{"cpu": 2, "gpu": 0.5},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(StringHash)
+
+		if err := g.AddVertex("A", test.options...); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		_, properties, _ := g.VertexWithProperties("A")
+
+		if !reflect.DeepEqual(properties.Weights, test.expected) {
+			t.Errorf("%s: weights expectancy doesn't match: expected %v, got %v", name, test.expected, properties.Weights)
+		}
+
+		if properties.Weight != 0 {
+			t.Errorf("%s: weight expectancy doesn't match: expected %v, got %v", name, 0, properties.Weight)
+		}
+	}
+}
+
+func TestNewWithCapacity(t *testing.T) {
+	tests := map[string]struct {
+		options        []func(*Traits)
+		expectedDegree int
+	}{
+		"directed graph": {
+			options:        []func(*Traits){Directed()},
+			expectedDegree: 2,
+		},
+		"undirected graph": {
+			expectedDegree: 4,
+		},
+	}
+
+	for name, test := range tests {
+		g := NewWithCapacity(IntHash, 10, 20, test.options...)
+
+		store, ok := storeOf(g)
+		if !ok {
+			t.Fatalf("%s: failed to get store", name)
+		}
+
+		memoryStore := store.(*memoryStore[int, int])
+
+		if memoryStore.degree != test.expectedDegree {
+			t.Errorf("%s: degree doesn't match: expected %v, got %v", name, test.expectedDegree, memoryStore.degree)
+		}
+
+		if g.Traits().IsDirected != (len(test.options) > 0) {
+			t.Errorf("%s: traits don't match: got %+v", name, g.Traits())
+		}
+	}
+}
+
+func TestNewFromAdjacencyMap(t *testing.T) {
+	tests := map[string]struct {
+		m             map[string]map[string]EdgeProperties
+		options       []func(*Traits)
+		expectedOrder int
+		expectedSize  int
+		expectedError error
+	}{
+		"directed graph": {
+			m: map[string]map[string]EdgeProperties{
+				"A": {"B": {Weight: 2}, "C": {}},
+				"B": {"C": {}},
+				"D": {},
+			},
+			options:       []func(*Traits){Directed()},
+			expectedOrder: 4,
+			expectedSize:  3,
+		},
+		"undirected graph with mirrored edges": {
+			m: map[string]map[string]EdgeProperties{
+				"A": {"B": {Weight: 2}},
+				"B": {"A": {Weight: 2}},
+			},
+			expectedOrder: 2,
+			expectedSize:  1,
+		},
+		"cycle in acyclic graph": {
+			m: map[string]map[string]EdgeProperties{
+				"A": {"B": {}},
+				"B": {"A": {}},
+			},
+			options:       []func(*Traits){Directed(), PreventCycles()},
+			expectedError: ErrEdgeCreatesCycle,
+		},
+	}
+
+	for name, test := range tests {
+		g, err := NewFromAdjacencyMap(StringHash, test.m, test.options...)
+
+		if !errors.Is(err, test.expectedError) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedError, err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if order, _ := g.Order(); order != test.expectedOrder {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, test.expectedOrder, order)
+		}
+
+		if size, _ := g.Size(); size != test.expectedSize {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, test.expectedSize, size)
+		}
+
+		edge, err := g.Edge("A", "B")
+		if err != nil {
+			t.Fatalf("%s: failed to get edge: %v", name, err)
+		}
+
+		if edge.Properties.Weight != 2 {
+			t.Errorf("%s: weight doesn't match: expected %v, got %v", name, 2, edge.Properties.Weight)
+		}
+	}
+}
+
+func TestNewFromEdges(t *testing.T) {
+	tests := map[string]struct {
+		edges            []Edge[int]
+		options          []func(*Traits)
+		expectedVertices []int
+		expectedError    error
+	}{
+		"directed graph": {
+			edges: []Edge[int]{
+				{Source: 3, Target: 1},
+				{Source: 1, Target: 2, Properties: EdgeProperties{Weight: 4}},
+			},
+			options:          []func(*Traits){Directed()},
+			expectedVertices: []int{3, 1, 2},
+		},
+		"duplicate edge": {
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 1},
+			},
+			expectedError: ErrEdgeAlreadyExists,
+		},
+	}
+
+	for name, test := range tests {
+		g, err := NewFromEdges(IntHash, test.edges, test.options...)
+
+		if !errors.Is(err, test.expectedError) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedError, err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		vertices, _, _ := VerticesPage(g, "", 10)
+
+		if !slicesAreEqual(vertices, test.expectedVertices) {
+			t.Errorf("%s: vertices don't match: expected %v, got %v", name, test.expectedVertices, vertices)
+		}
+
+		for _, expected := range test.edges {
+			edge, err := g.Edge(expected.Source, expected.Target)
+			if err != nil {
+				t.Fatalf("%s: failed to get edge: %v", name, err)
+			}
+			if edge.Properties.Weight != expected.Properties.Weight {
+				t.Errorf("%s: weight doesn't match: expected %v, got %v", name, expected.Properties.Weight, edge.Properties.Weight)
+			}
+		}
+	}
+}
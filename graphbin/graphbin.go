@@ -0,0 +1,552 @@
+// Package graphbin encodes graphs in a compact, versioned binary format. It is
+// considerably faster and produces smaller output than JSON, which makes it
+// suitable for caching large graphs or shipping them between services:
+//
+//	var buf bytes.Buffer
+//
+//	_ = graphbin.Encode(&buf, g)
+//	h, _ := graphbin.Decode(&buf, graph.IntHash)
+//
+// The format stores the vertex values, from which the hashes are re-computed
+// when decoding. Edges refer to their vertices by varint-encoded indices, and
+// all attribute keys and values are stored once in a string table.
+//
+// Vertex values of a type whose underlying type is a string, bool, integer, or
+// floating-point type are encoded out of the box. For other types, a custom
+// codec has to be passed using [ValueCodec]. Edge data is only encoded if a
+// codec is passed using [DataCodec].
+package graphbin
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/dominikbraun/graph"
+)
+
+// version is the version of the binary format written by Encode.
+const version = 1
+
+// magic identifies the binary format.
+var magic = [4]byte{'G', 'B', 'I', 'N'}
+
+var (
+	// ErrInvalidFormat is returned when decoding data that hasn't been created
+	// by Encode.
+	ErrInvalidFormat = errors.New("invalid format")
+
+	// ErrUnsupportedVersion is returned when decoding data that has been
+	// created by a newer version of Encode.
+	ErrUnsupportedVersion = errors.New("unsupported format version")
+)
+
+const (
+	directedTrait = 1 << iota
+	acyclicTrait
+	weightedTrait
+	rootedTrait
+	preventCyclesTrait
+)
+
+type config[T any] struct {
+	encodeValue func(T) ([]byte, error)
+	decodeValue func([]byte) (T, error)
+	encodeData  func(any) ([]byte, error)
+	decodeData  func([]byte) (any, error)
+}
+
+func newConfig[T any](options ...func(*config[T])) config[T] {
+	var c config[T]
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	if c.encodeValue == nil {
+		c.encodeValue = encodeValue[T]
+	}
+
+	if c.decodeValue == nil {
+		c.decodeValue = decodeValue[T]
+	}
+
+	return c
+}
+
+// ValueCodec is a functional option for [Encode] and [Decode] that sets the
+// functions used for encoding and decoding vertex values.
+func ValueCodec[T any](encode func(value T) ([]byte, error), decode func(data []byte) (T, error)) func(*config[T]) {
+	return func(c *config[T]) {
+		c.encodeValue = encode
+		c.decodeValue = decode
+	}
+}
+
+// DataCodec is a functional option for [Encode] and [Decode] that sets the
+// functions used for encoding and decoding edge data. Without a DataCodec,
+// encoding a graph with edge data fails.
+func DataCodec[T any](encode func(data any) ([]byte, error), decode func(data []byte) (any, error)) func(*config[T]) {
+	return func(c *config[T]) {
+		c.encodeData = encode
+		c.decodeData = decode
+	}
+}
+
+// stringTable assigns indices to strings in the order they are added.
+type stringTable struct {
+	indices map[string]uint64
+	strings []string
+}
+
+func (s *stringTable) index(str string) uint64 {
+	if index, ok := s.indices[str]; ok {
+		return index
+	}
+
+	index := uint64(len(s.strings))
+	s.indices[str] = index
+	s.strings = append(s.strings, str)
+
+	return index
+}
+
+// Encode writes the given graph to w in the binary format. The vertices are
+// written in the order defined by graph.VerticesPage.
+func Encode[K comparable, T any](w io.Writer, g graph.Graph[K, T], options ...func(*config[T])) error {
+	c := newConfig(options...)
+
+	// The vertices and edges are encoded into a buffer first, because the
+	// string table containing all attributes has to be written before them.
+	table := &stringTable{indices: make(map[string]uint64)}
+	indices := make(map[K]uint64)
+	body := &encoder{}
+
+	var hashes []K
+	cursor := ""
+
+	for {
+		page, next, err := graph.VerticesPage(g, cursor, 1000)
+		if err != nil {
+			return fmt.Errorf("failed to list vertices: %w", err)
+		}
+		hashes = append(hashes, page...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	body.uvarint(uint64(len(hashes)))
+
+	for i, hash := range hashes {
+		value, properties, err := g.VertexWithProperties(hash)
+		if err != nil {
+			return fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+
+		encoded, err := c.encodeValue(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode vertex %v: %w", hash, err)
+		}
+
+		indices[hash] = uint64(i)
+
+		body.bytes(encoded)
+		body.varint(int64(properties.Weight))
+		body.attributes(table, properties.Attributes)
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	body.uvarint(uint64(len(edges)))
+
+	for _, edge := range edges {
+		body.uvarint(indices[edge.Source])
+		body.uvarint(indices[edge.Target])
+		body.varint(int64(edge.Properties.Weight))
+		body.attributes(table, edge.Properties.Attributes)
+
+		if edge.Properties.Data == nil {
+			body.uvarint(0)
+			continue
+		}
+
+		if c.encodeData == nil {
+			return fmt.Errorf("failed to encode edge (%v, %v): edge data requires a DataCodec", edge.Source, edge.Target)
+		}
+
+		data, err := c.encodeData(edge.Properties.Data)
+		if err != nil {
+			return fmt.Errorf("failed to encode data of edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+
+		body.uvarint(1)
+		body.bytes(data)
+	}
+
+	header := &encoder{}
+	header.buf = append(header.buf, magic[:]...)
+	header.buf = append(header.buf, version, encodeTraits(g.Traits()))
+	header.uvarint(uint64(len(table.strings)))
+
+	for _, str := range table.strings {
+		header.bytes([]byte(str))
+	}
+
+	if _, err := w.Write(header.buf); err != nil {
+		return err
+	}
+
+	_, err = w.Write(body.buf)
+
+	return err
+}
+
+// Decode reads a graph written by [Encode] from r. The hashes of the vertices
+// are computed using the given hash function.
+func Decode[K comparable, T any](r io.Reader, hash graph.Hash[K, T], options ...func(*config[T])) (graph.Graph[K, T], error) {
+	c := newConfig(options...)
+	d := &decoder{r: bufio.NewReader(r)}
+
+	var header [6]byte
+
+	if _, err := io.ReadFull(d.r, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	if [4]byte{header[0], header[1], header[2], header[3]} != magic {
+		return nil, ErrInvalidFormat
+	}
+
+	if header[4] > version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, header[4])
+	}
+
+	traits := decodeTraits(header[5])
+
+	// The counts read from the data aren't trusted for allocating memory, so
+	// that corrupt data can't cause huge allocations.
+	tableSize := d.uvarint()
+	table := make([]string, 0, minUint64(tableSize, 4096))
+
+	for i := uint64(0); i < tableSize && d.err == nil; i++ {
+		table = append(table, string(d.bytes()))
+	}
+
+	vertexCount := d.uvarint()
+	if d.err != nil {
+		return nil, d.error()
+	}
+
+	g := graph.NewWithCapacity(hash, int(minUint64(vertexCount, 4096)), 0, func(t *graph.Traits) {
+		*t = traits
+	})
+
+	hashes := make([]K, 0, minUint64(vertexCount, 4096))
+
+	for i := uint64(0); i < vertexCount; i++ {
+		encoded := d.bytes()
+		weight := d.varint()
+		attributes := d.attributes(table)
+
+		if d.err != nil {
+			return nil, d.error()
+		}
+
+		value, err := c.decodeValue(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode vertex %d: %w", i, err)
+		}
+
+		hashes = append(hashes, hash(value))
+
+		if err := g.AddVertex(value, graph.VertexWeight(int(weight)), graph.VertexAttributes(attributes)); err != nil {
+			return nil, fmt.Errorf("failed to add vertex %v: %w", hash(value), err)
+		}
+	}
+
+	edgeCount := d.uvarint()
+
+	for i := uint64(0); i < edgeCount && d.err == nil; i++ {
+		source := d.index(uint64(len(hashes)))
+		target := d.index(uint64(len(hashes)))
+		weight := d.varint()
+		attributes := d.attributes(table)
+
+		properties := []func(*graph.EdgeProperties){
+			graph.EdgeWeight(int(weight)),
+			graph.EdgeAttributes(attributes),
+		}
+
+		if hasData := d.uvarint(); hasData == 1 {
+			encoded := d.bytes()
+			if d.err != nil {
+				break
+			}
+
+			if c.decodeData == nil {
+				return nil, fmt.Errorf("failed to decode edge %d: edge data requires a DataCodec", i)
+			}
+
+			data, err := c.decodeData(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode data of edge %d: %w", i, err)
+			}
+
+			properties = append(properties, graph.EdgeData(data))
+		}
+
+		if d.err != nil {
+			break
+		}
+
+		if err := g.AddEdge(hashes[source], hashes[target], properties...); err != nil {
+			return nil, fmt.Errorf("failed to add edge (%v, %v): %w", hashes[source], hashes[target], err)
+		}
+	}
+
+	if d.err != nil {
+		return nil, d.error()
+	}
+
+	return g, nil
+}
+
+func encodeTraits(traits *graph.Traits) byte {
+	var b byte
+
+	for _, trait := range []struct {
+		set  bool
+		mask byte
+	}{
+		{traits.IsDirected, directedTrait},
+		{traits.IsAcyclic, acyclicTrait},
+		{traits.IsWeighted, weightedTrait},
+		{traits.IsRooted, rootedTrait},
+		{traits.PreventCycles, preventCyclesTrait},
+	} {
+		if trait.set {
+			b |= trait.mask
+		}
+	}
+
+	return b
+}
+
+func decodeTraits(b byte) graph.Traits {
+	return graph.Traits{
+		IsDirected:    b&directedTrait != 0,
+		IsAcyclic:     b&acyclicTrait != 0,
+		IsWeighted:    b&weightedTrait != 0,
+		IsRooted:      b&rootedTrait != 0,
+		PreventCycles: b&preventCyclesTrait != 0,
+	}
+}
+
+// encoder appends encoded values to a buffer.
+type encoder struct {
+	buf     []byte
+	scratch [binary.MaxVarintLen64]byte
+}
+
+func (e *encoder) uvarint(x uint64) {
+	n := binary.PutUvarint(e.scratch[:], x)
+	e.buf = append(e.buf, e.scratch[:n]...)
+}
+
+func (e *encoder) varint(x int64) {
+	n := binary.PutVarint(e.scratch[:], x)
+	e.buf = append(e.buf, e.scratch[:n]...)
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.uvarint(uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *encoder) attributes(table *stringTable, attributes map[string]string) {
+	e.uvarint(uint64(len(attributes)))
+
+	// The keys are sorted so that encoding the same graph twice yields the
+	// same output.
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		e.uvarint(table.index(key))
+		e.uvarint(table.index(attributes[key]))
+	}
+}
+
+// decoder reads encoded values. After the first error, all reads return zero
+// values, and the error is kept in err.
+type decoder struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	x, err := binary.ReadUvarint(d.r)
+	d.err = err
+
+	return x
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+
+	x, err := binary.ReadVarint(d.r)
+	d.err = err
+
+	return x
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.uvarint()
+	if d.err != nil {
+		return nil
+	}
+
+	if n <= 4096 {
+		b := make([]byte, n)
+		_, d.err = io.ReadFull(d.r, b)
+		return b
+	}
+
+	// Reading large values in chunks prevents allocating huge buffers for
+	// corrupt lengths.
+	b := make([]byte, 0, 4096)
+
+	for uint64(len(b)) < n && d.err == nil {
+		chunk := make([]byte, minUint64(n-uint64(len(b)), 4096))
+		_, d.err = io.ReadFull(d.r, chunk)
+		b = append(b, chunk...)
+	}
+
+	return b
+}
+
+// index reads an index and checks that it is less than n.
+func (d *decoder) index(n uint64) uint64 {
+	i := d.uvarint()
+	if d.err == nil && i >= n {
+		d.err = fmt.Errorf("%w: index %d out of range", ErrInvalidFormat, i)
+		return 0
+	}
+
+	return i
+}
+
+func (d *decoder) attributes(table []string) map[string]string {
+	n := d.uvarint()
+	attributes := make(map[string]string, minUint64(n, 64))
+
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		key := d.index(uint64(len(table)))
+		value := d.index(uint64(len(table)))
+		if d.err == nil {
+			attributes[table[key]] = table[value]
+		}
+	}
+
+	return attributes
+}
+
+func (d *decoder) error() error {
+	if errors.Is(d.err, io.EOF) {
+		return fmt.Errorf("%w: unexpected end of data", ErrInvalidFormat)
+	}
+
+	return fmt.Errorf("failed to decode graph: %w", d.err)
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// encodeValue encodes values whose underlying type is a basic type.
+func encodeValue[T any](value T) ([]byte, error) {
+	v := reflect.ValueOf(&value).Elem()
+
+	switch v.Kind() {
+	case reflect.String:
+		return []byte(v.String()), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf := make([]byte, binary.MaxVarintLen64)
+		return buf[:binary.PutVarint(buf, v.Int())], nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		buf := make([]byte, binary.MaxVarintLen64)
+		return buf[:binary.PutUvarint(buf, v.Uint())], nil
+	case reflect.Float32, reflect.Float64:
+		buf := make([]byte, 8)
+		binary.LittleEndian.PutUint64(buf, math.Float64bits(v.Float()))
+		return buf, nil
+	default:
+		return nil, fmt.Errorf("values of type %v require a ValueCodec", v.Type())
+	}
+}
+
+// decodeValue decodes values encoded by encodeValue.
+func decodeValue[T any](data []byte) (T, error) {
+	var value T
+	v := reflect.ValueOf(&value).Elem()
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(string(data))
+	case reflect.Bool:
+		if len(data) != 1 {
+			return value, ErrInvalidFormat
+		}
+		v.SetBool(data[0] == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, n := binary.Varint(data)
+		if n <= 0 || n != len(data) {
+			return value, ErrInvalidFormat
+		}
+		v.SetInt(x)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, n := binary.Uvarint(data)
+		if n <= 0 || n != len(data) {
+			return value, ErrInvalidFormat
+		}
+		v.SetUint(x)
+	case reflect.Float32, reflect.Float64:
+		if len(data) != 8 {
+			return value, ErrInvalidFormat
+		}
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+	default:
+		return value, fmt.Errorf("values of type %v require a ValueCodec", v.Type())
+	}
+
+	return value, nil
+}
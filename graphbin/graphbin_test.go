@@ -0,0 +1,259 @@
+package graphbin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/graphjson"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := map[string]struct {
+		traits []func(*graph.Traits)
+	}{
+		"directed graph": {
+			traits: []func(*graph.Traits){graph.Directed(), graph.Acyclic(), graph.Weighted()},
+		},
+		"undirected graph": {
+			traits: []func(*graph.Traits){graph.Rooted()},
+		},
+	}
+
+	for name, test := range tests {
+		g := graph.New(graph.IntHash, test.traits...)
+
+		_ = g.AddVertex(-1, graph.VertexWeight(7), graph.VertexAttribute("color", "red"))
+		_ = g.AddVertex(300)
+		_ = g.AddVertex(2, graph.VertexAttribute("color", "blue"))
+		_ = g.AddEdge(-1, 300, graph.EdgeWeight(-4), graph.EdgeAttribute("color", "red"))
+		_ = g.AddEdge(300, 2)
+
+		var buf bytes.Buffer
+
+		if err := Encode(&buf, g); err != nil {
+			t.Fatalf("%s: failed to encode graph: %v", name, err)
+		}
+
+		h, err := Decode(&buf, graph.IntHash)
+		if err != nil {
+			t.Fatalf("%s: failed to decode graph: %v", name, err)
+		}
+
+		if *h.Traits() != *g.Traits() {
+			t.Errorf("%s: traits don't match: expected %v, got %v", name, g.Traits(), h.Traits())
+		}
+
+		if order, _ := h.Order(); order != 3 {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, 3, order)
+		}
+
+		if size, _ := h.Size(); size != 2 {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, 2, size)
+		}
+
+		_, properties, _ := h.VertexWithProperties(-1)
+		if properties.Weight != 7 || properties.Attributes["color"] != "red" {
+			t.Errorf("%s: vertex properties don't match: got %v", name, properties)
+		}
+
+		edge, _ := h.Edge(-1, 300)
+		if edge.Properties.Weight != -4 || edge.Properties.Attributes["color"] != "red" {
+			t.Errorf("%s: edge properties don't match: got %v", name, edge.Properties)
+		}
+	}
+}
+
+func TestEncodeDecode_values(t *testing.T) {
+	type id uint16
+
+	g := graph.New(func(v id) id { return v })
+	_ = g.AddVertex(65535)
+
+	var buf bytes.Buffer
+
+	if err := Encode(&buf, g); err != nil {
+		t.Fatalf("failed to encode graph: %v", err)
+	}
+
+	h, err := Decode(&buf, func(v id) id { return v })
+	if err != nil {
+		t.Fatalf("failed to decode graph: %v", err)
+	}
+
+	if _, err := h.Vertex(65535); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	type city struct {
+		Name string
+	}
+
+	cityHash := func(c city) string { return c.Name }
+	cities := graph.New(cityHash)
+	_ = cities.AddVertex(city{Name: "London"})
+
+	if err := Encode(&buf, cities); err == nil {
+		t.Errorf("expected error for struct values without codec")
+	}
+
+	codec := ValueCodec(func(c city) ([]byte, error) {
+		return []byte(c.Name), nil
+	}, func(data []byte) (city, error) {
+		return city{Name: string(data)}, nil
+	})
+
+	buf.Reset()
+
+	if err := Encode(&buf, cities, codec); err != nil {
+		t.Fatalf("failed to encode graph: %v", err)
+	}
+
+	decoded, err := Decode(&buf, cityHash, codec)
+	if err != nil {
+		t.Fatalf("failed to decode graph: %v", err)
+	}
+
+	if _, err := decoded.Vertex("London"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestEncodeDecode_data(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed())
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddEdge("A", "B", graph.EdgeData("payload"))
+
+	var buf bytes.Buffer
+
+	if err := Encode(&buf, g); err == nil {
+		t.Fatalf("expected error for edge data without codec")
+	}
+
+	codec := DataCodec[string](json.Marshal, func(data []byte) (any, error) {
+		var v any
+		err := json.Unmarshal(data, &v)
+		return v, err
+	})
+
+	buf.Reset()
+
+	if err := Encode(&buf, g, codec); err != nil {
+		t.Fatalf("failed to encode graph: %v", err)
+	}
+
+	h, err := Decode(&buf, graph.StringHash, codec)
+	if err != nil {
+		t.Fatalf("failed to decode graph: %v", err)
+	}
+
+	if edge, _ := h.Edge("A", "B"); edge.Properties.Data != "payload" {
+		t.Errorf("edge data doesn't match: expected %v, got %v", "payload", edge.Properties.Data)
+	}
+}
+
+func TestDecode_invalidData(t *testing.T) {
+	g := graph.New(graph.StringHash)
+	_ = g.AddVertex("A", graph.VertexAttribute("key", "value"))
+	_ = g.AddVertex("B")
+	_ = g.AddEdge("A", "B")
+
+	var buf bytes.Buffer
+	_ = Encode(&buf, g)
+	valid := buf.Bytes()
+
+	tests := map[string]struct {
+		data          []byte
+		expectedError error
+	}{
+		"invalid magic": {
+			data:          []byte("JSON{}"),
+			expectedError: ErrInvalidFormat,
+		},
+		"newer version": {
+			data:          append([]byte("GBIN\x02\x00"), valid[6:]...),
+			expectedError: ErrUnsupportedVersion,
+		},
+		"truncated data": {
+			data:          valid[:len(valid)-2],
+			expectedError: ErrInvalidFormat,
+		},
+	}
+
+	for name, test := range tests {
+		_, err := Decode(bytes.NewReader(test.data), graph.StringHash)
+
+		if !errors.Is(err, test.expectedError) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedError, err)
+		}
+	}
+
+	for i := 0; i < len(valid); i++ {
+		// Decoding any prefix must fail without panicking.
+		if _, err := Decode(bytes.NewReader(valid[:i]), graph.StringHash); err == nil {
+			t.Errorf("expected error for %d bytes", i)
+		}
+	}
+}
+
+func benchmarkGraph() graph.Graph[int, int] {
+	g := graph.New(graph.IntHash, graph.Directed(), graph.Weighted())
+
+	for i := 0; i < 10000; i++ {
+		_ = g.AddVertex(i, graph.VertexAttribute("kind", "node"))
+	}
+
+	for i := 0; i < 10000; i++ {
+		for j := 1; j <= 10; j++ {
+			_ = g.AddEdge(i, (i+j*7)%10000, graph.EdgeWeight(j))
+		}
+	}
+
+	return g
+}
+
+func BenchmarkEncode(b *testing.B) {
+	g := benchmarkGraph()
+
+	b.Run("graphbin", func(b *testing.B) {
+		var buf bytes.Buffer
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			_ = Encode(&buf, g)
+		}
+		b.ReportMetric(float64(buf.Len()), "bytes")
+	})
+
+	b.Run("graphjson", func(b *testing.B) {
+		var data []byte
+		for i := 0; i < b.N; i++ {
+			data, _ = graphjson.Marshal(g)
+		}
+		b.ReportMetric(float64(len(data)), "bytes")
+	})
+}
+
+func BenchmarkDecode(b *testing.B) {
+	g := benchmarkGraph()
+
+	var buf bytes.Buffer
+	_ = Encode(&buf, g)
+	encoded := buf.Bytes()
+
+	data, _ := graphjson.Marshal(g)
+
+	b.Run("graphbin", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = Decode(bytes.NewReader(encoded), graph.IntHash)
+		}
+	})
+
+	b.Run("graphjson", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = graphjson.Unmarshal(data, graph.IntHash)
+		}
+	})
+}
@@ -0,0 +1,417 @@
+// Package graphhttp exposes a graph as a read-only JSON API, so that services
+// can make their in-memory graphs available to dashboards and other services:
+//
+//	http.Handle("/graph/", http.StripPrefix("/graph", graphhttp.Handler(g)))
+//
+// The handler serves the following endpoints:
+//
+//	GET /vertices?cursor=&limit=               lists the vertices page by page
+//	GET /vertices/{id}                         returns a single vertex
+//	GET /edges?cursor=&limit=                  lists the edges page by page
+//	GET /neighbors/{id}?depth=&cursor=&limit=  lists the vertices within depth hops
+//	GET /path?from=&to=                        returns the shortest path between two vertices
+//
+// Vertex IDs are the vertex hashes. Hashes of type string are used as they are,
+// all other hash types are parsed as JSON, e.g. /vertices/42 for a graph of
+// integers. Lists are paginated using graph.VerticesPage and graph.EdgesPage:
+// Each response contains the cursor for the next page in the next field, which
+// is empty for the last page.
+//
+// Vertex values are encoded using encoding/json. Errors are returned as JSON
+// object with an error field and the corresponding status code, for example
+// 404 for vertices that don't exist.
+package graphhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/internal/storage"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type config struct {
+	maxDepth int
+}
+
+func newConfig(options ...func(*config)) config {
+	c := config{
+		maxDepth: 3,
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// MaxDepth sets the maximum depth accepted by the neighbors endpoint, which
+// limits the cost of a single request. The default is 3.
+func MaxDepth(depth int) func(*config) {
+	return func(c *config) {
+		c.maxDepth = depth
+	}
+}
+
+// Vertex is the JSON representation of a vertex.
+type Vertex[K comparable, T any] struct {
+	Hash       K                 `json:"hash"`
+	Value      T                 `json:"value"`
+	Weight     int               `json:"weight"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// Edge is the JSON representation of an edge.
+type Edge[K comparable] struct {
+	Source     K                 `json:"source"`
+	Target     K                 `json:"target"`
+	Weight     int               `json:"weight"`
+	Attributes map[string]string `json:"attributes"`
+	Data       interface{}       `json:"data,omitempty"`
+}
+
+// Page is the JSON representation of a page of items.
+type Page[E any] struct {
+	Items []E    `json:"items"`
+	Next  string `json:"next"`
+}
+
+// Path is the JSON representation of a path.
+type Path[K comparable] struct {
+	Vertices []K `json:"vertices"`
+}
+
+type handler[K comparable, T any] struct {
+	g      graph.Graph[K, T]
+	config config
+}
+
+// Handler returns an http.Handler serving the endpoints described in the
+// package description for the given graph. The handler only reads from the
+// graph, so it is safe to use it while the graph is modified as long as the
+// store of the graph is safe for concurrent use.
+func Handler[K comparable, T any](g graph.Graph[K, T], options ...func(*config)) http.Handler {
+	return &handler[K, T]{
+		g:      g,
+		config: newConfig(options...),
+	}
+}
+
+// statusError is an error that is reported with the given status code.
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &statusError{status: http.StatusBadRequest, err: fmt.Errorf(format, args...)}
+}
+
+func (h *handler[K, T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, &statusError{status: http.StatusMethodNotAllowed, err: errors.New("method not allowed")})
+		return
+	}
+
+	var (
+		response interface{}
+		err      error
+	)
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(segments) == 1 && segments[0] == "vertices":
+		response, err = h.vertices(r)
+	case len(segments) == 2 && segments[0] == "vertices":
+		response, err = h.vertex(segments[1])
+	case len(segments) == 1 && segments[0] == "edges":
+		response, err = h.edges(r)
+	case len(segments) == 2 && segments[0] == "neighbors":
+		response, err = h.neighbors(segments[1], r)
+	case len(segments) == 1 && segments[0] == "path":
+		response, err = h.path(r)
+	default:
+		err = &statusError{status: http.StatusNotFound, err: errors.New("not found")}
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *handler[K, T]) vertices(r *http.Request) (interface{}, error) {
+	cursor, limit, err := pagination(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes, next, err := graph.VerticesPage(h.g, cursor, limit)
+	if errors.Is(err, graph.ErrInvalidCursor) {
+		return nil, badRequest("%w", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	page := Page[Vertex[K, T]]{Items: make([]Vertex[K, T], 0, len(hashes)), Next: next}
+
+	for _, hash := range hashes {
+		vertex, err := h.vertexByHash(hash)
+		if err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, vertex)
+	}
+
+	return page, nil
+}
+
+func (h *handler[K, T]) vertex(id string) (interface{}, error) {
+	hash, err := parseID[K](id)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.vertexByHash(hash)
+}
+
+func (h *handler[K, T]) vertexByHash(hash K) (Vertex[K, T], error) {
+	value, properties, err := h.g.VertexWithProperties(hash)
+	if errors.Is(err, graph.ErrVertexNotFound) {
+		return Vertex[K, T]{}, &statusError{status: http.StatusNotFound, err: fmt.Errorf("vertex %v not found", hash)}
+	}
+	if err != nil {
+		return Vertex[K, T]{}, err
+	}
+
+	return Vertex[K, T]{
+		Hash:       hash,
+		Value:      value,
+		Weight:     properties.Weight,
+		Attributes: properties.Attributes,
+	}, nil
+}
+
+func (h *handler[K, T]) edges(r *http.Request) (interface{}, error) {
+	cursor, limit, err := pagination(r)
+	if err != nil {
+		return nil, err
+	}
+
+	edges, next, err := graph.EdgesPage(h.g, cursor, limit)
+	if errors.Is(err, graph.ErrInvalidCursor) {
+		return nil, badRequest("%w", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	page := Page[Edge[K]]{Items: make([]Edge[K], 0, len(edges)), Next: next}
+
+	for _, edge := range edges {
+		page.Items = append(page.Items, Edge[K]{
+			Source:     edge.Source,
+			Target:     edge.Target,
+			Weight:     edge.Properties.Weight,
+			Attributes: edge.Properties.Attributes,
+			Data:       edge.Properties.Data,
+		})
+	}
+
+	return page, nil
+}
+
+// neighbors returns the vertices within the requested depth around the given
+// vertex, excluding the vertex itself. The vertices are ordered by their
+// distance, and vertices with the same distance by their hashes formatted
+// using fmt.Sprint. The cursor is the offset of the next page.
+func (h *handler[K, T]) neighbors(id string, r *http.Request) (interface{}, error) {
+	hash, err := parseID[K](id)
+	if err != nil {
+		return nil, err
+	}
+
+	depth := 1
+
+	if value := r.URL.Query().Get("depth"); value != "" {
+		if depth, err = strconv.Atoi(value); err != nil || depth < 1 || depth > h.config.maxDepth {
+			return nil, badRequest("depth has to be between 1 and %d", h.config.maxDepth)
+		}
+	}
+
+	cursor, limit, err := pagination(r)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := 0
+
+	if cursor != "" {
+		if offset, err = strconv.Atoi(cursor); err != nil || offset < 0 {
+			return nil, badRequest("%w: %q", graph.ErrInvalidCursor, cursor)
+		}
+	}
+
+	if _, err := h.vertexByHash(hash); err != nil {
+		return nil, err
+	}
+
+	neighbors, err := h.neighborsWithin(hash, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	page := Page[Vertex[K, T]]{Items: make([]Vertex[K, T], 0)}
+
+	if offset < len(neighbors) {
+		end := len(neighbors)
+		if offset+limit < end {
+			end = offset + limit
+			page.Next = strconv.Itoa(end)
+		}
+
+		for _, neighbor := range neighbors[offset:end] {
+			vertex, err := h.vertexByHash(neighbor)
+			if err != nil {
+				return nil, err
+			}
+			page.Items = append(page.Items, vertex)
+		}
+	}
+
+	return page, nil
+}
+
+// neighborsWithin performs a breadth-first search and returns the vertices
+// within the given depth in a deterministic order, so that pages requested one
+// after another fit together.
+func (h *handler[K, T]) neighborsWithin(start K, depth int) ([]K, error) {
+	adjacencyMap, err := h.g.AdjacencyMap()
+	if err != nil {
+		return nil, err
+	}
+
+	visited := map[K]bool{start: true}
+	frontier := []K{start}
+	neighbors := make([]K, 0)
+
+	for d := 0; d < depth && len(frontier) > 0; d++ {
+		next := make([]K, 0)
+
+		for _, hash := range frontier {
+			for adjacency := range adjacencyMap[hash] {
+				if !visited[adjacency] {
+					visited[adjacency] = true
+					next = append(next, adjacency)
+				}
+			}
+		}
+
+		sort.Slice(next, func(i, j int) bool {
+			return fmt.Sprint(next[i]) < fmt.Sprint(next[j])
+		})
+
+		neighbors = append(neighbors, next...)
+		frontier = next
+	}
+
+	return neighbors, nil
+}
+
+func (h *handler[K, T]) path(r *http.Request) (interface{}, error) {
+	query := r.URL.Query()
+
+	if query.Get("from") == "" || query.Get("to") == "" {
+		return nil, badRequest("from and to are required")
+	}
+
+	from, err := parseID[K](query.Get("from"))
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := parseID[K](query.Get("to"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hash := range []K{from, to} {
+		if _, err := h.vertexByHash(hash); err != nil {
+			return nil, err
+		}
+	}
+
+	path, err := graph.ShortestPath(h.g, from, to)
+	if errors.Is(err, graph.ErrTargetNotReachable) {
+		return nil, &statusError{status: http.StatusNotFound, err: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return Path[K]{Vertices: path}, nil
+}
+
+func parseID[K comparable](id string) (K, error) {
+	hash, err := storage.DecodeHash[K](id)
+	if err != nil {
+		return hash, badRequest("invalid vertex ID %q", id)
+	}
+
+	return hash, nil
+}
+
+func pagination(r *http.Request) (string, int, error) {
+	query := r.URL.Query()
+	limit := defaultLimit
+
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > maxLimit {
+			return "", 0, badRequest("limit has to be between 1 and %d", maxLimit)
+		}
+	}
+
+	return query.Get("cursor"), limit, nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		status = statusErr.status
+	}
+
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
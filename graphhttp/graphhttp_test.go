@@ -0,0 +1,129 @@
+package graphhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func newTestGraph() graph.Graph[int, int] {
+	g := graph.New(graph.IntHash, graph.Directed())
+
+	for i := 1; i <= 5; i++ {
+		_ = g.AddVertex(i, graph.VertexAttribute("name", "v"))
+	}
+
+	_ = g.AddEdge(1, 2)
+	_ = g.AddEdge(1, 3)
+	_ = g.AddEdge(2, 4)
+	_ = g.AddEdge(4, 5)
+
+	return g
+}
+
+func TestHandler(t *testing.T) {
+	tests := map[string]struct {
+		method         string
+		url            string
+		expectedStatus int
+		expectedBody   string
+	}{
+		"vertex": {
+			url:            "/vertices/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"hash":1,"value":1,"weight":0,"attributes":{"name":"v"}}`,
+		},
+		"missing vertex": {
+			url:            "/vertices/9",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"vertex 9 not found"}`,
+		},
+		"invalid vertex ID": {
+			url:            "/vertices/x",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid vertex ID \"x\""}`,
+		},
+		"first vertex page": {
+			url:            "/vertices?limit=2",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"items":[{"hash":1,"value":1,"weight":0,"attributes":{"name":"v"}},{"hash":2,"value":2,"weight":0,"attributes":{"name":"v"}}],"next":"2"}`,
+		},
+		"invalid limit": {
+			url:            "/vertices?limit=0",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"limit has to be between 1 and 1000"}`,
+		},
+		"neighbors": {
+			url:            "/neighbors/1?depth=2&limit=2",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"items":[{"hash":2,"value":2,"weight":0,"attributes":{"name":"v"}},{"hash":3,"value":3,"weight":0,"attributes":{"name":"v"}}],"next":"2"}`,
+		},
+		"last neighbors page": {
+			url:            "/neighbors/1?depth=2&limit=2&cursor=2",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"items":[{"hash":4,"value":4,"weight":0,"attributes":{"name":"v"}}],"next":""}`,
+		},
+		"depth too large": {
+			url:            "/neighbors/1?depth=4",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"depth has to be between 1 and 3"}`,
+		},
+		"path": {
+			url:            "/path?from=1&to=5",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"vertices":[1,2,4,5]}`,
+		},
+		"unreachable target": {
+			url:            "/path?from=5&to=1",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"target vertex not reachable from source"}`,
+		},
+		"edges": {
+			url:            "/edges?limit=1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"items":[{"source":1,"target":2,"weight":0,"attributes":{}}],"next":"1"}`,
+		},
+		"unknown endpoint": {
+			url:            "/unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"not found"}`,
+		},
+		"method not allowed": {
+			method:         http.MethodPost,
+			url:            "/vertices",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   `{"error":"method not allowed"}`,
+		},
+	}
+
+	handler := Handler(newTestGraph())
+
+	for name, test := range tests {
+		method := test.method
+		if method == "" {
+			method = http.MethodGet
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, test.url, nil))
+
+		if recorder.Code != test.expectedStatus {
+			t.Errorf("%s: status doesn't match: expected %v, got %v", name, test.expectedStatus, recorder.Code)
+		}
+
+		var expected, actual interface{}
+		_ = json.Unmarshal([]byte(test.expectedBody), &expected)
+
+		if err := json.Unmarshal(recorder.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("%s: failed to decode body: %v", name, err)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: body doesn't match: expected %v, got %v", name, test.expectedBody, recorder.Body.String())
+		}
+	}
+}
@@ -0,0 +1,257 @@
+// Package graphjson encodes graphs as JSON documents and decodes them again, so
+// that graphs can be stored in document databases or sent over HTTP APIs.
+//
+//	data, _ := graphjson.Marshal(g)
+//	h, _ := graphjson.Unmarshal(data, graph.StringHash)
+//
+// A document has the following schema, where each vertex and edge is an object
+// as described by [Vertex] and [Edge]:
+//
+//	{
+//		"traits": {
+//			"directed": true,
+//			"acyclic": false,
+//			"weighted": true,
+//			"rooted": false,
+//			"preventCycles": false
+//		},
+//		"vertices": [
+//			{"hash": "A", "value": "A", "weight": 1, "attributes": {"color": "red"}},
+//			{"hash": "B", "value": "B"}
+//		],
+//		"edges": [
+//			{"source": "A", "target": "B", "weight": 5, "attributes": {"label": "x"}, "data": 1}
+//		]
+//	}
+//
+// Undirected edges appear once, with an arbitrary one of their vertices as the
+// source. The vertex hashes and values are encoded using encoding/json, so
+// vertex types may implement json.Marshaler and json.Unmarshaler. Alternatively,
+// custom encoding functions can be passed using [VertexEncoder] and
+// [VertexDecoder]. Edge data is decoded into the generic JSON types such as
+// map[string]interface{}.
+package graphjson
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+)
+
+// pageSize is the number of vertices read from the graph at once.
+const pageSize = 1000
+
+// Document is the JSON representation of a graph.
+type Document[K comparable] struct {
+	Traits   Traits      `json:"traits"`
+	Vertices []Vertex[K] `json:"vertices"`
+	Edges    []Edge[K]   `json:"edges"`
+}
+
+// Traits is the JSON representation of the graph traits.
+type Traits struct {
+	IsDirected    bool `json:"directed"`
+	IsAcyclic     bool `json:"acyclic"`
+	IsWeighted    bool `json:"weighted"`
+	IsRooted      bool `json:"rooted"`
+	PreventCycles bool `json:"preventCycles"`
+}
+
+// Vertex is the JSON representation of a vertex. Value contains the encoded
+// vertex value.
+type Vertex[K comparable] struct {
+	Hash       K                  `json:"hash"`
+	Value      json.RawMessage    `json:"value"`
+	Weight     int                `json:"weight,omitempty"`
+	Attributes map[string]string  `json:"attributes,omitempty"`
+	Weights    map[string]float64 `json:"weights,omitempty"`
+}
+
+// Edge is the JSON representation of an edge.
+type Edge[K comparable] struct {
+	Source     K                 `json:"source"`
+	Target     K                 `json:"target"`
+	Weight     int               `json:"weight,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Data       interface{}       `json:"data,omitempty"`
+}
+
+type config[T any] struct {
+	encode func(T) ([]byte, error)
+	decode func([]byte) (T, error)
+}
+
+func newConfig[T any](options ...func(*config[T])) config[T] {
+	c := config[T]{
+		encode: func(value T) ([]byte, error) {
+			return json.Marshal(value)
+		},
+		decode: func(data []byte) (T, error) {
+			var value T
+			err := json.Unmarshal(data, &value)
+			return value, err
+		},
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// VertexEncoder is a functional option for [Marshal] that sets the function
+// used for encoding vertex values. The function has to return valid JSON.
+func VertexEncoder[T any](encode func(value T) ([]byte, error)) func(*config[T]) {
+	return func(c *config[T]) {
+		c.encode = encode
+	}
+}
+
+// VertexDecoder is a functional option for [Unmarshal] that sets the function
+// used for decoding vertex values. It is the counterpart to [VertexEncoder].
+func VertexDecoder[T any](decode func(data []byte) (T, error)) func(*config[T]) {
+	return func(c *config[T]) {
+		c.decode = decode
+	}
+}
+
+// Marshal encodes the given graph as JSON document. The vertices are encoded in
+// the order defined by graph.VerticesPage, which is the insertion order for the
+// default in-memory store.
+func Marshal[K comparable, T any](g graph.Graph[K, T], options ...func(*config[T])) ([]byte, error) {
+	c := newConfig(options...)
+
+	traits := g.Traits()
+
+	doc := Document[K]{
+		Traits: Traits{
+			IsDirected:    traits.IsDirected,
+			IsAcyclic:     traits.IsAcyclic,
+			IsWeighted:    traits.IsWeighted,
+			IsRooted:      traits.IsRooted,
+			PreventCycles: traits.PreventCycles,
+		},
+		Vertices: make([]Vertex[K], 0),
+		Edges:    make([]Edge[K], 0),
+	}
+
+	cursor := ""
+
+	for {
+		hashes, next, err := graph.VerticesPage(g, cursor, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list vertices: %w", err)
+		}
+
+		for _, hash := range hashes {
+			value, properties, err := g.VertexWithProperties(hash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+			}
+
+			encoded, err := c.encode(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode vertex %v: %w", hash, err)
+			}
+
+			doc.Vertices = append(doc.Vertices, Vertex[K]{
+				Hash:       hash,
+				Value:      encoded,
+				Weight:     properties.Weight,
+				Attributes: properties.Attributes,
+				Weights:    properties.Weights,
+			})
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	for _, edge := range edges {
+		doc.Edges = append(doc.Edges, Edge[K]{
+			Source:     edge.Source,
+			Target:     edge.Target,
+			Weight:     edge.Properties.Weight,
+			Attributes: edge.Properties.Attributes,
+			Data:       edge.Properties.Data,
+		})
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+
+	return data, nil
+}
+
+// Unmarshal decodes a JSON document created by [Marshal] into a new graph with
+// the traits stored in the document. The given hash function has to compute
+// the same hashes as the hash function of the encoded graph, otherwise an error
+// is returned.
+func Unmarshal[K comparable, T any](data []byte, hash graph.Hash[K, T], options ...func(*config[T])) (graph.Graph[K, T], error) {
+	c := newConfig(options...)
+
+	var doc Document[K]
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	g := graph.NewWithCapacity(hash, len(doc.Vertices), len(doc.Edges), func(t *graph.Traits) {
+		t.IsDirected = doc.Traits.IsDirected
+		t.IsAcyclic = doc.Traits.IsAcyclic
+		t.IsWeighted = doc.Traits.IsWeighted
+		t.IsRooted = doc.Traits.IsRooted
+		t.PreventCycles = doc.Traits.PreventCycles
+	})
+
+	for _, vertex := range doc.Vertices {
+		value, err := c.decode(vertex.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode vertex %v: %w", vertex.Hash, err)
+		}
+
+		if h := hash(value); h != vertex.Hash {
+			return nil, fmt.Errorf("hash of vertex %v doesn't match the hash %v of its value", vertex.Hash, h)
+		}
+
+		err = g.AddVertex(value, graph.VertexWeight(vertex.Weight), graph.VertexAttributes(copyAttributes(vertex.Attributes)), graph.VertexWeights(vertex.Weights))
+		if err != nil {
+			return nil, fmt.Errorf("failed to add vertex %v: %w", vertex.Hash, err)
+		}
+	}
+
+	for _, edge := range doc.Edges {
+		err := g.AddEdge(edge.Source, edge.Target,
+			graph.EdgeWeight(edge.Weight),
+			graph.EdgeAttributes(copyAttributes(edge.Attributes)),
+			graph.EdgeData(edge.Data),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	return g, nil
+}
+
+// copyAttributes returns a non-nil copy of the given attributes, because
+// attributes that are omitted in the document are decoded as nil map.
+func copyAttributes(attributes map[string]string) map[string]string {
+	c := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		c[key] = value
+	}
+
+	return c
+}
@@ -0,0 +1,136 @@
+package graphjson
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/graphtest"
+)
+
+func TestMarshal(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed(), graph.Weighted())
+
+	_ = g.AddVertex("A", graph.VertexWeight(1), graph.VertexAttribute("color", "red"))
+	_ = g.AddVertex("B")
+	_ = g.AddEdge("A", "B", graph.EdgeWeight(5), graph.EdgeAttribute("label", "x"), graph.EdgeData(1))
+
+	data, err := Marshal(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"traits":{"directed":true,"acyclic":false,"weighted":true,"rooted":false,"preventCycles":false},` +
+		`"vertices":[{"hash":"A","value":"A","weight":1,"attributes":{"color":"red"}},{"hash":"B","value":"B"}],` +
+		`"edges":[{"source":"A","target":"B","weight":5,"attributes":{"label":"x"},"data":1}]}`
+
+	if string(data) != expected {
+		t.Errorf("document doesn't match: expected %v, got %v", expected, string(data))
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := map[string]struct {
+		traits []func(*graph.Traits)
+	}{
+		"directed graph": {
+			traits: []func(*graph.Traits){graph.Directed(), graph.Weighted()},
+		},
+		"undirected graph": {
+			traits: []func(*graph.Traits){},
+		},
+	}
+
+	for name, test := range tests {
+		g := graph.New(graph.IntHash, test.traits...)
+
+		for i := 1; i <= 3; i++ {
+			_ = g.AddVertex(i, graph.VertexAttribute("index", strconv.Itoa(i)))
+		}
+
+		_ = g.AddEdge(1, 2, graph.EdgeWeight(2))
+		_ = g.AddEdge(2, 3, graph.EdgeAttribute("color", "red"))
+
+		data, err := Marshal(g)
+		if err != nil {
+			t.Fatalf("%s: failed to marshal graph: %v", name, err)
+		}
+
+		h, err := Unmarshal(data, graph.IntHash)
+		if err != nil {
+			t.Fatalf("%s: failed to unmarshal graph: %v", name, err)
+		}
+
+		if !graphtest.AssertSameGraph(t, g, h) {
+			t.Errorf("%s: unmarshaled graph doesn't match", name)
+		}
+	}
+}
+
+type city struct {
+	Name string
+}
+
+func TestVertexEncoder(t *testing.T) {
+	cityHash := func(c city) string {
+		return c.Name
+	}
+
+	g := graph.New(cityHash)
+	_ = g.AddVertex(city{Name: "London"})
+
+	data, err := Marshal(g, VertexEncoder(func(c city) ([]byte, error) {
+		return json.Marshal(c.Name)
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"value":"London"`) {
+		t.Errorf("expected custom encoding, got %v", string(data))
+	}
+
+	decoder := VertexDecoder(func(data []byte) (city, error) {
+		var name string
+		err := json.Unmarshal(data, &name)
+		return city{Name: name}, err
+	})
+
+	h, err := Unmarshal(data, cityHash, decoder)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := h.Vertex("London"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// The default decoder can't decode a string into a city.
+	if _, err := Unmarshal(data, cityHash); err == nil {
+		t.Errorf("expected error for default decoder")
+	}
+}
+
+func TestUnmarshal_invalidDocument(t *testing.T) {
+	tests := map[string]struct {
+		data string
+	}{
+		"invalid JSON": {
+			data: `{"vertices":`,
+		},
+		"mismatching hash": {
+			data: `{"vertices":[{"hash":"X","value":"A"}]}`,
+		},
+		"missing vertex": {
+			data: `{"vertices":[{"hash":"A","value":"A"}],"edges":[{"source":"A","target":"B"}]}`,
+		},
+	}
+
+	for name, test := range tests {
+		if _, err := Unmarshal([]byte(test.data), graph.StringHash); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/dominikbraun/graph/graphpb
+
+go 1.21
+
+require (
+	github.com/dominikbraun/graph v0.23.0
+	google.golang.org/protobuf v1.34.2
+)
+
+replace github.com/dominikbraun/graph => ../
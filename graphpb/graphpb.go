@@ -0,0 +1,220 @@
+// Package graphpb converts graphs to and from their protobuf representation
+// defined in graph.proto, so that graphs can be sent over gRPC or stored in any
+// protobuf-based format:
+//
+//	message, _ := graphpb.ToProto(g)
+//	data, _ := proto.Marshal(message)
+//
+//	_ = proto.Unmarshal(data, message)
+//	h, _ := graphpb.FromProto(message, graph.StringHash)
+//
+// The vertex values and edge data are JSON-encoded by default, which makes the
+// messages readable by services written in other languages. Custom encodings
+// can be set using [ValueCodec] and [DataCodec]. JSON-encoded edge data is
+// decoded into the generic JSON types such as map[string]interface{}.
+package graphpb
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+)
+
+type config[T any] struct {
+	encodeValue func(T) ([]byte, error)
+	decodeValue func([]byte) (T, error)
+	encodeData  func(any) ([]byte, error)
+	decodeData  func([]byte) (any, error)
+}
+
+func newConfig[T any](options ...func(*config[T])) config[T] {
+	c := config[T]{
+		encodeValue: func(value T) ([]byte, error) {
+			return json.Marshal(value)
+		},
+		decodeValue: func(data []byte) (T, error) {
+			var value T
+			err := json.Unmarshal(data, &value)
+			return value, err
+		},
+		encodeData: json.Marshal,
+		decodeData: func(data []byte) (any, error) {
+			var value any
+			err := json.Unmarshal(data, &value)
+			return value, err
+		},
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// ValueCodec is a functional option for [ToProto] and [FromProto] that sets the
+// functions used for encoding and decoding vertex values.
+func ValueCodec[T any](encode func(value T) ([]byte, error), decode func(data []byte) (T, error)) func(*config[T]) {
+	return func(c *config[T]) {
+		c.encodeValue = encode
+		c.decodeValue = decode
+	}
+}
+
+// DataCodec is a functional option for [ToProto] and [FromProto] that sets the
+// functions used for encoding and decoding edge data.
+func DataCodec[T any](encode func(data any) ([]byte, error), decode func(data []byte) (any, error)) func(*config[T]) {
+	return func(c *config[T]) {
+		c.encodeData = encode
+		c.decodeData = decode
+	}
+}
+
+// ToProto converts the given graph into its protobuf representation. The
+// vertices are converted in the order defined by graph.VerticesPage.
+func ToProto[K comparable, T any](g graph.Graph[K, T], options ...func(*config[T])) (*Graph, error) {
+	c := newConfig(options...)
+	traits := g.Traits()
+
+	message := &Graph{
+		Traits: &Traits{
+			Directed:      traits.IsDirected,
+			Acyclic:       traits.IsAcyclic,
+			Weighted:      traits.IsWeighted,
+			Rooted:        traits.IsRooted,
+			PreventCycles: traits.PreventCycles,
+		},
+	}
+
+	indices := make(map[K]uint64)
+	cursor := ""
+
+	for {
+		hashes, next, err := graph.VerticesPage(g, cursor, 1000)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list vertices: %w", err)
+		}
+
+		for _, hash := range hashes {
+			value, properties, err := g.VertexWithProperties(hash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+			}
+
+			encoded, err := c.encodeValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode vertex %v: %w", hash, err)
+			}
+
+			indices[hash] = uint64(len(message.Vertices))
+
+			message.Vertices = append(message.Vertices, &Vertex{
+				Value:      encoded,
+				Weight:     int64(properties.Weight),
+				Attributes: properties.Attributes,
+			})
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	for _, edge := range edges {
+		e := &Edge{
+			Source:     indices[edge.Source],
+			Target:     indices[edge.Target],
+			Weight:     int64(edge.Properties.Weight),
+			Attributes: edge.Properties.Attributes,
+		}
+
+		if edge.Properties.Data != nil {
+			if e.Data, err = c.encodeData(edge.Properties.Data); err != nil {
+				return nil, fmt.Errorf("failed to encode data of edge (%v, %v): %w", edge.Source, edge.Target, err)
+			}
+		}
+
+		message.Edges = append(message.Edges, e)
+	}
+
+	return message, nil
+}
+
+// FromProto creates a new graph from the given protobuf representation. The
+// hashes of the vertices are computed using the given hash function.
+func FromProto[K comparable, T any](message *Graph, hash graph.Hash[K, T], options ...func(*config[T])) (graph.Graph[K, T], error) {
+	c := newConfig(options...)
+	traits := message.GetTraits()
+
+	g := graph.NewWithCapacity(hash, len(message.GetVertices()), len(message.GetEdges()), func(t *graph.Traits) {
+		t.IsDirected = traits.GetDirected()
+		t.IsAcyclic = traits.GetAcyclic()
+		t.IsWeighted = traits.GetWeighted()
+		t.IsRooted = traits.GetRooted()
+		t.PreventCycles = traits.GetPreventCycles()
+	})
+
+	hashes := make([]K, 0, len(message.GetVertices()))
+
+	for i, vertex := range message.GetVertices() {
+		value, err := c.decodeValue(vertex.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode vertex %d: %w", i, err)
+		}
+
+		err = g.AddVertex(value,
+			graph.VertexWeight(int(vertex.GetWeight())),
+			graph.VertexAttributes(copyAttributes(vertex.GetAttributes())),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add vertex %v: %w", hash(value), err)
+		}
+
+		hashes = append(hashes, hash(value))
+	}
+
+	for i, edge := range message.GetEdges() {
+		if edge.GetSource() >= uint64(len(hashes)) || edge.GetTarget() >= uint64(len(hashes)) {
+			return nil, fmt.Errorf("edge %d refers to a vertex that doesn't exist", i)
+		}
+
+		source, target := hashes[edge.GetSource()], hashes[edge.GetTarget()]
+
+		properties := []func(*graph.EdgeProperties){
+			graph.EdgeWeight(int(edge.GetWeight())),
+			graph.EdgeAttributes(copyAttributes(edge.GetAttributes())),
+		}
+
+		if len(edge.GetData()) > 0 {
+			data, err := c.decodeData(edge.GetData())
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode data of edge (%v, %v): %w", source, target, err)
+			}
+			properties = append(properties, graph.EdgeData(data))
+		}
+
+		if err := g.AddEdge(source, target, properties...); err != nil {
+			return nil, fmt.Errorf("failed to add edge (%v, %v): %w", source, target, err)
+		}
+	}
+
+	return g, nil
+}
+
+// copyAttributes returns a non-nil copy of the given attributes, because empty
+// maps are decoded as nil.
+func copyAttributes(attributes map[string]string) map[string]string {
+	c := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		c[key] = value
+	}
+
+	return c
+}
@@ -0,0 +1,97 @@
+package graphpb
+
+import (
+	"testing"
+
+	"github.com/dominikbraun/graph"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestToProtoFromProto(t *testing.T) {
+	tests := map[string]struct {
+		traits []func(*graph.Traits)
+	}{
+		"directed graph": {
+			traits: []func(*graph.Traits){graph.Directed(), graph.Weighted()},
+		},
+		"undirected graph": {
+			traits: []func(*graph.Traits){graph.Rooted()},
+		},
+	}
+
+	for name, test := range tests {
+		g := graph.New(graph.StringHash, test.traits...)
+
+		_ = g.AddVertex("A", graph.VertexWeight(2), graph.VertexAttribute("color", "red"))
+		_ = g.AddVertex("B")
+		_ = g.AddVertex("C")
+		_ = g.AddEdge("A", "B", graph.EdgeWeight(3), graph.EdgeData(map[string]any{"lanes": 2.0}))
+		_ = g.AddEdge("B", "C", graph.EdgeAttribute("label", "x"))
+
+		message, err := ToProto(g)
+		if err != nil {
+			t.Fatalf("%s: failed to convert graph: %v", name, err)
+		}
+
+		data, err := proto.Marshal(message)
+		if err != nil {
+			t.Fatalf("%s: failed to marshal message: %v", name, err)
+		}
+
+		var decoded Graph
+
+		if err := proto.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%s: failed to unmarshal message: %v", name, err)
+		}
+
+		h, err := FromProto(&decoded, graph.StringHash)
+		if err != nil {
+			t.Fatalf("%s: failed to convert message: %v", name, err)
+		}
+
+		if *h.Traits() != *g.Traits() {
+			t.Errorf("%s: traits don't match: expected %v, got %v", name, g.Traits(), h.Traits())
+		}
+
+		if size, _ := h.Size(); size != 2 {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, 2, size)
+		}
+
+		_, properties, _ := h.VertexWithProperties("A")
+		if properties.Weight != 2 || properties.Attributes["color"] != "red" {
+			t.Errorf("%s: vertex properties don't match: got %v", name, properties)
+		}
+
+		edge, _ := h.Edge("A", "B")
+		if edge.Properties.Weight != 3 || edge.Properties.Data.(map[string]any)["lanes"] != 2.0 {
+			t.Errorf("%s: edge properties don't match: got %v", name, edge.Properties)
+		}
+
+		edge, _ = h.Edge("B", "C")
+		if edge.Properties.Attributes["label"] != "x" || edge.Properties.Data != nil {
+			t.Errorf("%s: edge properties don't match: got %v", name, edge.Properties)
+		}
+	}
+}
+
+func TestFromProto_invalidMessage(t *testing.T) {
+	tests := map[string]struct {
+		message *Graph
+	}{
+		"invalid value": {
+			message: &Graph{Vertices: []*Vertex{{Value: []byte("A")}}},
+		},
+		"missing vertex": {
+			message: &Graph{
+				Vertices: []*Vertex{{Value: []byte(`"A"`)}},
+				Edges:    []*Edge{{Source: 0, Target: 1}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		if _, err := FromProto(test.message, graph.StringHash); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
@@ -0,0 +1,502 @@
+// Package graphquery provides a fluent API for multi-hop queries over a graph,
+// inspired by Gremlin. A query starts at a set of vertices and moves along the
+// edges of the graph step by step, filtering the visited vertices on the way:
+//
+//	conditions, err := graphquery.Q(g).
+//		V("patient-1").
+//		Out("hasEncounter").
+//		Out("diagnosed").
+//		Where(func(v graphquery.Vertex[string, Resource]) bool {
+//			return v.Properties.Attributes["status"] == "active"
+//		}).
+//		Dedup().
+//		Values()
+//
+// Edges are selected by their label, which is read from the "label" attribute
+// of each edge by default. A different attribute can be set using
+// [LabelAttribute].
+//
+// Each step returns a new query, so that a partial query can be reused as the
+// starting point for multiple queries. Errors are deferred to the terminal
+// steps Hashes, Values, and Count.
+//
+// The edges of the graph are read once per call to Q, when the first step that
+// needs them is evaluated. Changes made to the graph afterwards are not seen by
+// the query, so create a new query using Q after modifying the graph.
+package graphquery
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dominikbraun/graph"
+)
+
+const defaultLabelAttribute = "label"
+
+type config struct {
+	labelAttribute string
+}
+
+// LabelAttribute sets the edge attribute that holds the labels of the edges,
+// which are matched by Out, In, and Both. The default is "label".
+func LabelAttribute(key string) func(*config) {
+	return func(c *config) {
+		c.labelAttribute = key
+	}
+}
+
+// Vertex is a vertex visited by a query, as passed to the predicate of Where.
+type Vertex[K comparable, T any] struct {
+	Hash       K
+	Value      T
+	Properties graph.VertexProperties
+}
+
+// index holds the sorted incoming and outgoing edges of each vertex. It is
+// built once per call to Q and shared by all queries derived from it.
+type index[K comparable] struct {
+	once     sync.Once
+	err      error
+	vertices []K
+	out      map[K][]graph.Edge[K]
+	in       map[K][]graph.Edge[K]
+}
+
+// Query is a traversal over a graph. It holds the vertices reached by the steps
+// evaluated so far, which may contain the same vertex multiple times if it has
+// been reached via multiple paths. Use Dedup to remove duplicates.
+type Query[K comparable, T any] struct {
+	g      graph.Graph[K, T]
+	config config
+	index  *index[K]
+	hashes []K
+	err    error
+}
+
+// Q creates a new query for the given graph. The query doesn't contain any
+// vertices until V is called.
+func Q[K comparable, T any](g graph.Graph[K, T], options ...func(*config)) *Query[K, T] {
+	c := config{
+		labelAttribute: defaultLabelAttribute,
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return &Query[K, T]{
+		g:      g,
+		config: c,
+		index:  &index[K]{},
+	}
+}
+
+// V starts the traversal at the vertices with the given hashes. If no hashes
+// are given, the traversal starts at all vertices of the graph. If one of the
+// vertices doesn't exist, the terminal step returns an error.
+func (q *Query[K, T]) V(hashes ...K) *Query[K, T] {
+	if q.err != nil {
+		return q
+	}
+
+	if len(hashes) == 0 {
+		idx, err := q.edges()
+		if err != nil {
+			return q.fail(err)
+		}
+		return q.with(append([]K(nil), idx.vertices...))
+	}
+
+	for _, hash := range hashes {
+		if _, err := q.g.Vertex(hash); err != nil {
+			return q.fail(fmt.Errorf("failed to get vertex %v: %w", hash, err))
+		}
+	}
+
+	return q.with(append([]K(nil), hashes...))
+}
+
+// Out moves the traversal along the outgoing edges of the current vertices. If
+// labels are given, only edges with one of these labels are followed. For
+// undirected graphs, Out, In, and Both are equivalent.
+func (q *Query[K, T]) Out(labels ...string) *Query[K, T] {
+	return q.step(labels, true, false)
+}
+
+// In moves the traversal along the incoming edges of the current vertices. If
+// labels are given, only edges with one of these labels are followed.
+func (q *Query[K, T]) In(labels ...string) *Query[K, T] {
+	return q.step(labels, false, true)
+}
+
+// Both moves the traversal along the outgoing and incoming edges of the current
+// vertices. If labels are given, only edges with one of these labels are
+// followed.
+func (q *Query[K, T]) Both(labels ...string) *Query[K, T] {
+	return q.step(labels, true, true)
+}
+
+// Where keeps the current vertices for which the given predicate returns true.
+func (q *Query[K, T]) Where(predicate func(vertex Vertex[K, T]) bool) *Query[K, T] {
+	if q.err != nil {
+		return q
+	}
+
+	hashes := make([]K, 0, len(q.hashes))
+
+	for _, hash := range q.hashes {
+		value, properties, err := q.g.VertexWithProperties(hash)
+		if err != nil {
+			return q.fail(fmt.Errorf("failed to get vertex %v: %w", hash, err))
+		}
+
+		vertex := Vertex[K, T]{
+			Hash:       hash,
+			Value:      value,
+			Properties: properties,
+		}
+
+		if predicate(vertex) {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	return q.with(hashes)
+}
+
+// Has keeps the current vertices whose attribute with the given key has the
+// given value.
+func (q *Query[K, T]) Has(key, value string) *Query[K, T] {
+	return q.Where(func(vertex Vertex[K, T]) bool {
+		v, ok := vertex.Properties.Attributes[key]
+		return ok && v == value
+	})
+}
+
+// Dedup removes duplicate vertices, keeping the first occurrence of each one.
+func (q *Query[K, T]) Dedup() *Query[K, T] {
+	if q.err != nil {
+		return q
+	}
+
+	seen := make(map[K]struct{}, len(q.hashes))
+	hashes := make([]K, 0, len(q.hashes))
+
+	for _, hash := range q.hashes {
+		if _, ok := seen[hash]; ok {
+			continue
+		}
+		seen[hash] = struct{}{}
+		hashes = append(hashes, hash)
+	}
+
+	return q.with(hashes)
+}
+
+// Limit keeps the first n current vertices.
+func (q *Query[K, T]) Limit(n int) *Query[K, T] {
+	if q.err != nil || n >= len(q.hashes) {
+		return q
+	}
+
+	if n < 0 {
+		n = 0
+	}
+
+	return q.with(append([]K(nil), q.hashes[:n]...))
+}
+
+// VertexFilter reports whether a vertex should be included in the result of
+// Neighborhood.
+type VertexFilter[K comparable, T any] func(vertex Vertex[K, T]) bool
+
+// EdgeFilter reports whether Neighborhood may follow an edge.
+type EdgeFilter[K comparable] func(edge graph.Edge[K]) bool
+
+// Neighborhood replaces the current vertices with all vertices reachable from
+// them within at most depth hops, including the current vertices themselves.
+// Only edges accepted by the edge filter are followed, and only vertices
+// accepted by the vertex filter are kept. A nil filter accepts everything.
+// In a directed graph, only outgoing edges are followed.
+//
+// The vertex filter doesn't restrict the traversal, so that vertices can be
+// reached via vertices that don't match the filter themselves. This answers
+// questions like "find all conditions within two hops via edges labeled Y":
+//
+//	conditions, _ := graphquery.Q(g).V("patient-1").Neighborhood(2,
+//		func(v graphquery.Vertex[string, Resource]) bool {
+//			return v.Properties.Attributes["type"] == "Condition"
+//		},
+//		graphquery.Labeled[string]("hasEncounter", "diagnosed"),
+//	).Values()
+//
+// The vertices are returned in BFS order without duplicates, so vertices closer
+// to the current vertices appear first.
+func (q *Query[K, T]) Neighborhood(depth int, vertexFilter VertexFilter[K, T], edgeFilter EdgeFilter[K]) *Query[K, T] {
+	if q.err != nil {
+		return q
+	}
+
+	if depth < 0 {
+		return q.fail(fmt.Errorf("depth must not be negative, got %d", depth))
+	}
+
+	idx, err := q.edges()
+	if err != nil {
+		return q.fail(err)
+	}
+
+	visited := make(map[K]struct{}, len(q.hashes))
+	layer := make([]K, 0, len(q.hashes))
+
+	for _, hash := range q.hashes {
+		if _, ok := visited[hash]; ok {
+			continue
+		}
+		visited[hash] = struct{}{}
+		layer = append(layer, hash)
+	}
+
+	reached := append([]K(nil), layer...)
+
+	for hop := 0; hop < depth && len(layer) > 0; hop++ {
+		next := make([]K, 0)
+
+		for _, hash := range layer {
+			for _, edge := range idx.out[hash] {
+				if edgeFilter != nil && !edgeFilter(edge) {
+					continue
+				}
+				if _, ok := visited[edge.Target]; ok {
+					continue
+				}
+				visited[edge.Target] = struct{}{}
+				next = append(next, edge.Target)
+			}
+		}
+
+		reached = append(reached, next...)
+		layer = next
+	}
+
+	result := q.with(reached)
+
+	if vertexFilter == nil {
+		return result
+	}
+
+	return result.Where(vertexFilter)
+}
+
+// Labeled returns an edge filter that accepts the edges with one of the given
+// labels. The labels are read from the "label" attribute regardless of the
+// LabelAttribute option.
+func Labeled[K comparable](labels ...string) EdgeFilter[K] {
+	return func(edge graph.Edge[K]) bool {
+		return hasLabel(edge, defaultLabelAttribute, labels)
+	}
+}
+
+// hasLabel reports whether the label stored in the given attribute of the edge
+// is one of the given labels.
+func hasLabel[K comparable](edge graph.Edge[K], attribute string, labels []string) bool {
+	label := edge.Properties.Attributes[attribute]
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// Subgraph returns the current vertices as a new graph with the same traits as
+// the queried graph. It contains the vertices along with all edges joining
+// them, i.e. the subgraph induced by the current vertices.
+func (q *Query[K, T]) Subgraph() (graph.Graph[K, T], error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	idx, err := q.edges()
+	if err != nil {
+		return nil, err
+	}
+
+	subgraph := graph.NewLike(q.g)
+	contained := make(map[K]struct{}, len(q.hashes))
+
+	for _, hash := range q.hashes {
+		if _, ok := contained[hash]; ok {
+			continue
+		}
+		contained[hash] = struct{}{}
+
+		value, properties, err := q.g.VertexWithProperties(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+
+		err = subgraph.AddVertex(value, graph.VertexWeight(properties.Weight), graph.VertexAttributes(copyAttributes(properties.Attributes)), graph.VertexWeights(properties.Weights))
+		if err != nil {
+			return nil, fmt.Errorf("failed to add vertex %v: %w", hash, err)
+		}
+	}
+
+	for hash := range contained {
+		for _, edge := range idx.out[hash] {
+			if _, ok := contained[edge.Target]; !ok {
+				continue
+			}
+
+			err := subgraph.AddEdge(edge.Source, edge.Target,
+				graph.EdgeWeight(edge.Properties.Weight),
+				graph.EdgeAttributes(copyAttributes(edge.Properties.Attributes)),
+				graph.EdgeData(edge.Properties.Data),
+			)
+			// Undirected edges are contained in the outgoing edges of both of
+			// their vertices, so they are added twice.
+			if err != nil && !errors.Is(err, graph.ErrEdgeAlreadyExists) {
+				return nil, fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+			}
+		}
+	}
+
+	return subgraph, nil
+}
+
+// Hashes returns the hashes of the current vertices.
+func (q *Query[K, T]) Hashes() ([]K, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	return append([]K{}, q.hashes...), nil
+}
+
+// Values returns the values of the current vertices.
+func (q *Query[K, T]) Values() ([]T, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	values := make([]T, 0, len(q.hashes))
+
+	for _, hash := range q.hashes {
+		value, err := q.g.Vertex(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// Count returns the number of current vertices.
+func (q *Query[K, T]) Count() (int, error) {
+	if q.err != nil {
+		return 0, q.err
+	}
+
+	return len(q.hashes), nil
+}
+
+func (q *Query[K, T]) step(labels []string, out, in bool) *Query[K, T] {
+	if q.err != nil {
+		return q
+	}
+
+	idx, err := q.edges()
+	if err != nil {
+		return q.fail(err)
+	}
+
+	// In undirected graphs, each edge is contained in the outgoing edges of
+	// both of its vertices.
+	if !q.g.Traits().IsDirected {
+		out, in = true, false
+	}
+
+	matches := func(edge graph.Edge[K]) bool {
+		return len(labels) == 0 || hasLabel(edge, q.config.labelAttribute, labels)
+	}
+
+	hashes := make([]K, 0, len(q.hashes))
+
+	for _, hash := range q.hashes {
+		if out {
+			for _, edge := range idx.out[hash] {
+				if matches(edge) {
+					hashes = append(hashes, edge.Target)
+				}
+			}
+		}
+		if in {
+			for _, edge := range idx.in[hash] {
+				if matches(edge) {
+					hashes = append(hashes, edge.Source)
+				}
+			}
+		}
+	}
+
+	return q.with(hashes)
+}
+
+// edges builds the shared index of the query on first use. The index is built
+// from the sorted adjacency map, so that queries yield their vertices in a
+// deterministic order.
+func (q *Query[K, T]) edges() (*index[K], error) {
+	idx := q.index
+
+	idx.once.Do(func() {
+		adjacencies, err := graph.SortedAdjacencyMap(q.g, nil)
+		if err != nil {
+			idx.err = fmt.Errorf("failed to get adjacency map: %w", err)
+			return
+		}
+
+		idx.vertices = make([]K, 0, len(adjacencies))
+		idx.out = make(map[K][]graph.Edge[K], len(adjacencies))
+		idx.in = make(map[K][]graph.Edge[K], len(adjacencies))
+
+		for _, adjacency := range adjacencies {
+			idx.vertices = append(idx.vertices, adjacency.Hash)
+			idx.out[adjacency.Hash] = adjacency.Edges
+
+			for _, edge := range adjacency.Edges {
+				idx.in[edge.Target] = append(idx.in[edge.Target], edge)
+			}
+		}
+	})
+
+	return idx, idx.err
+}
+
+func (q *Query[K, T]) with(hashes []K) *Query[K, T] {
+	return &Query[K, T]{
+		g:      q.g,
+		config: q.config,
+		index:  q.index,
+		hashes: hashes,
+	}
+}
+
+func (q *Query[K, T]) fail(err error) *Query[K, T] {
+	return &Query[K, T]{
+		g:      q.g,
+		config: q.config,
+		index:  q.index,
+		err:    err,
+	}
+}
+
+func copyAttributes(attributes map[string]string) map[string]string {
+	copied := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		copied[key] = value
+	}
+	return copied
+}
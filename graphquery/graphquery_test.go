@@ -0,0 +1,282 @@
+package graphquery
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+// records creates a directed graph of medical records, where patients have
+// encounters, and encounters have diagnoses and observations.
+func records() graph.Graph[string, string] {
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	_ = g.AddVertex("patient-1", graph.VertexAttribute("type", "Patient"))
+	_ = g.AddVertex("patient-2", graph.VertexAttribute("type", "Patient"))
+	_ = g.AddVertex("encounter-1", graph.VertexAttribute("type", "Encounter"))
+	_ = g.AddVertex("encounter-2", graph.VertexAttribute("type", "Encounter"))
+	_ = g.AddVertex("encounter-3", graph.VertexAttribute("type", "Encounter"))
+	_ = g.AddVertex("asthma", graph.VertexAttribute("type", "Condition"), graph.VertexAttribute("status", "active"))
+	_ = g.AddVertex("flu", graph.VertexAttribute("type", "Condition"), graph.VertexAttribute("status", "resolved"))
+	_ = g.AddVertex("heart-rate", graph.VertexAttribute("type", "Observation"))
+
+	_ = g.AddEdge("patient-1", "encounter-1", graph.EdgeAttribute("label", "hasEncounter"))
+	_ = g.AddEdge("patient-1", "encounter-2", graph.EdgeAttribute("label", "hasEncounter"))
+	_ = g.AddEdge("patient-2", "encounter-3", graph.EdgeAttribute("label", "hasEncounter"))
+	_ = g.AddEdge("encounter-1", "asthma", graph.EdgeAttribute("label", "diagnosed"))
+	_ = g.AddEdge("encounter-1", "flu", graph.EdgeAttribute("label", "diagnosed"))
+	_ = g.AddEdge("encounter-2", "asthma", graph.EdgeAttribute("label", "diagnosed"))
+	_ = g.AddEdge("encounter-2", "heart-rate", graph.EdgeAttribute("label", "observed"))
+	_ = g.AddEdge("encounter-3", "flu", graph.EdgeAttribute("label", "diagnosed"))
+
+	return g
+}
+
+func TestQuery(t *testing.T) {
+	g := records()
+
+	tests := map[string]struct {
+		query          func() *Query[string, string]
+		expectedHashes []string
+	}{
+		"all vertices": {
+			query: func() *Query[string, string] {
+				return Q(g).V().Has("type", "Patient")
+			},
+			expectedHashes: []string{"patient-1", "patient-2"},
+		},
+		"out with label": {
+			query: func() *Query[string, string] {
+				return Q(g).V("patient-1").Out("hasEncounter").Out("diagnosed")
+			},
+			expectedHashes: []string{"asthma", "flu", "asthma"},
+		},
+		"out without label": {
+			query: func() *Query[string, string] {
+				return Q(g).V("encounter-2").Out()
+			},
+			expectedHashes: []string{"asthma", "heart-rate"},
+		},
+		"dedup": {
+			query: func() *Query[string, string] {
+				return Q(g).V("patient-1").Out("hasEncounter").Out("diagnosed").Dedup()
+			},
+			expectedHashes: []string{"asthma", "flu"},
+		},
+		"where": {
+			query: func() *Query[string, string] {
+				return Q(g).V("patient-1").Out().Out().Where(func(v Vertex[string, string]) bool {
+					return v.Properties.Attributes["status"] == "active"
+				}).Dedup()
+			},
+			expectedHashes: []string{"asthma"},
+		},
+		"in": {
+			query: func() *Query[string, string] {
+				return Q(g).V("flu").In("diagnosed").In("hasEncounter")
+			},
+			expectedHashes: []string{"patient-1", "patient-2"},
+		},
+		"both": {
+			query: func() *Query[string, string] {
+				return Q(g).V("encounter-1").Both()
+			},
+			expectedHashes: []string{"asthma", "flu", "patient-1"},
+		},
+		"limit": {
+			query: func() *Query[string, string] {
+				return Q(g).V().Limit(2)
+			},
+			expectedHashes: []string{"asthma", "encounter-1"},
+		},
+		"no matching edges": {
+			query: func() *Query[string, string] {
+				return Q(g).V("patient-1").Out("unknown")
+			},
+			expectedHashes: []string{},
+		},
+	}
+
+	for name, test := range tests {
+		hashes, err := test.query().Hashes()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if !reflect.DeepEqual(hashes, test.expectedHashes) {
+			t.Errorf("%s: hashes don't match: expected %v, got %v", name, test.expectedHashes, hashes)
+		}
+	}
+}
+
+func TestQuery_undirected(t *testing.T) {
+	g := graph.New(graph.IntHash)
+
+	for i := 1; i <= 4; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	_ = g.AddEdge(1, 2, graph.EdgeAttribute("kind", "friend"))
+	_ = g.AddEdge(3, 1, graph.EdgeAttribute("kind", "friend"))
+	_ = g.AddEdge(1, 4, graph.EdgeAttribute("kind", "colleague"))
+
+	for name, query := range map[string]*Query[int, int]{
+		"out":  Q(g, LabelAttribute("kind")).V(1).Out("friend"),
+		"in":   Q(g, LabelAttribute("kind")).V(1).In("friend"),
+		"both": Q(g, LabelAttribute("kind")).V(1).Both("friend"),
+	} {
+		values, err := query.Values()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if expected := []int{2, 3}; !reflect.DeepEqual(values, expected) {
+			t.Errorf("%s: values don't match: expected %v, got %v", name, expected, values)
+		}
+	}
+}
+
+func TestQuery_reuse(t *testing.T) {
+	g := records()
+
+	encounters := Q(g).V("patient-1").Out("hasEncounter")
+
+	diagnoses, _ := encounters.Out("diagnosed").Count()
+	observations, _ := encounters.Out("observed").Count()
+
+	if diagnoses != 3 {
+		t.Errorf("number of diagnoses doesn't match: expected %v, got %v", 3, diagnoses)
+	}
+
+	if observations != 1 {
+		t.Errorf("number of observations doesn't match: expected %v, got %v", 1, observations)
+	}
+}
+
+func TestQuery_missingVertex(t *testing.T) {
+	g := records()
+
+	_, err := Q(g).V("patient-3").Out().Dedup().Values()
+
+	if !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+}
+
+func TestQuery_Neighborhood(t *testing.T) {
+	g := records()
+
+	isCondition := func(v Vertex[string, string]) bool {
+		return v.Properties.Attributes["type"] == "Condition"
+	}
+
+	tests := map[string]struct {
+		start          []string
+		depth          int
+		vertexFilter   VertexFilter[string, string]
+		edgeFilter     EdgeFilter[string]
+		expectedHashes []string
+		shouldFail     bool
+	}{
+		"depth 0": {
+			start:          []string{"patient-1"},
+			depth:          0,
+			expectedHashes: []string{"patient-1"},
+		},
+		"without filters": {
+			start:          []string{"patient-1"},
+			depth:          2,
+			expectedHashes: []string{"patient-1", "encounter-1", "encounter-2", "asthma", "flu", "heart-rate"},
+		},
+		"vertex filter": {
+			start:          []string{"patient-1"},
+			depth:          2,
+			vertexFilter:   isCondition,
+			expectedHashes: []string{"asthma", "flu"},
+		},
+		"edge filter": {
+			start:          []string{"patient-1"},
+			depth:          5,
+			edgeFilter:     Labeled[string]("hasEncounter", "observed"),
+			expectedHashes: []string{"patient-1", "encounter-1", "encounter-2", "heart-rate"},
+		},
+		"multiple start vertices": {
+			start:          []string{"encounter-1", "encounter-3"},
+			depth:          1,
+			vertexFilter:   isCondition,
+			expectedHashes: []string{"asthma", "flu"},
+		},
+		"negative depth": {
+			start:      []string{"patient-1"},
+			depth:      -1,
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		hashes, err := Q(g).V(test.start...).Neighborhood(test.depth, test.vertexFilter, test.edgeFilter).Hashes()
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		if test.shouldFail {
+			continue
+		}
+
+		if !reflect.DeepEqual(hashes, test.expectedHashes) {
+			t.Errorf("%s: hashes don't match: expected %v, got %v", name, test.expectedHashes, hashes)
+		}
+	}
+}
+
+func TestQuery_Subgraph(t *testing.T) {
+	tests := map[string]struct {
+		traits []func(*graph.Traits)
+	}{
+		"directed graph": {
+			traits: []func(*graph.Traits){graph.Directed()},
+		},
+		"undirected graph": {},
+	}
+
+	for name, test := range tests {
+		g := graph.New(graph.IntHash, test.traits...)
+
+		for i := 1; i <= 4; i++ {
+			_ = g.AddVertex(i, graph.VertexAttribute("n", "x"))
+		}
+
+		_ = g.AddEdge(1, 2, graph.EdgeWeight(3), graph.EdgeAttribute("label", "a"))
+		_ = g.AddEdge(2, 3, graph.EdgeAttribute("label", "a"))
+		_ = g.AddEdge(3, 4, graph.EdgeAttribute("label", "b"))
+
+		subgraph, err := Q(g).V(1).Neighborhood(3, nil, Labeled[int]("a")).Subgraph()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if order, _ := subgraph.Order(); order != 3 {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, 3, order)
+		}
+
+		if size, _ := subgraph.Size(); size != 2 {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, 2, size)
+		}
+
+		edge, err := subgraph.Edge(1, 2)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if edge.Properties.Weight != 3 || edge.Properties.Attributes["label"] != "a" {
+			t.Errorf("%s: edge properties don't match: got %v", name, edge.Properties)
+		}
+
+		if subgraph.Traits().IsDirected != g.Traits().IsDirected {
+			t.Errorf("%s: traits don't match: expected %v, got %v", name, g.Traits().IsDirected, subgraph.Traits().IsDirected)
+		}
+	}
+}
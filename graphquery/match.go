@@ -0,0 +1,378 @@
+package graphquery
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+)
+
+// NodePattern describes a vertex of a [Pattern]. The name identifies the vertex
+// within the pattern and is used as key in the bindings returned by Match. A
+// vertex matches if it has all of the given attributes with the given values.
+type NodePattern struct {
+	Name       string
+	Attributes map[string]string
+}
+
+// EdgePattern describes an edge of a [Pattern] between the nodes with the given
+// names. If Label is not empty, only edges with this label match. The label is
+// read from the "label" attribute unless another attribute has been set using
+// [LabelAttribute]. Additionally, an edge matches only if it has all of the
+// given attributes with the given values.
+type EdgePattern struct {
+	Source     string
+	Target     string
+	Label      string
+	Attributes map[string]string
+}
+
+// Pattern is a small declarative description of a subgraph, similar to the
+// pattern of a MATCH clause in Cypher.
+type Pattern struct {
+	Nodes []NodePattern
+	Edges []EdgePattern
+}
+
+// Binding maps the node names of a pattern to the hashes of the vertices they
+// have been bound to.
+type Binding[K comparable] map[string]K
+
+// Match finds all tuples of vertices that satisfy the given pattern and returns
+// a binding for each of them. For example, this finds all pairs of patients who
+// have been diagnosed with the same active condition:
+//
+//	bindings, _ := graphquery.Match(g, graphquery.Pattern{
+//		Nodes: []graphquery.NodePattern{
+//			{Name: "p1", Attributes: map[string]string{"type": "Patient"}},
+//			{Name: "p2", Attributes: map[string]string{"type": "Patient"}},
+//			{Name: "c", Attributes: map[string]string{"status": "active"}},
+//		},
+//		Edges: []graphquery.EdgePattern{
+//			{Source: "p1", Target: "c", Label: "diagnosed"},
+//			{Source: "p2", Target: "c", Label: "diagnosed"},
+//		},
+//	})
+//
+//	for _, binding := range bindings {
+//		fmt.Println(binding["p1"], binding["p2"], binding["c"])
+//	}
+//
+// Just like in Cypher, different nodes of the pattern may be bound to the same
+// vertex, but different edges of the pattern are always bound to different
+// edges. In the example above, this ensures that p1 and p2 are different
+// patients. For undirected graphs, edge patterns match in both directions.
+//
+// The bindings are returned in a deterministic order. The number of matches
+// can grow exponentially with the size of the pattern, so patterns should be
+// kept small and their nodes as restrictive as possible.
+func Match[K comparable, T any](g graph.Graph[K, T], pattern Pattern, options ...func(*config)) ([]Binding[K], error) {
+	if err := validatePattern(pattern); err != nil {
+		return nil, err
+	}
+
+	q := Q(g, options...)
+
+	idx, err := q.edges()
+	if err != nil {
+		return nil, err
+	}
+
+	m := matcher[K, T]{
+		g:          g,
+		pattern:    pattern,
+		label:      q.config.labelAttribute,
+		idx:        idx,
+		edges:      make(map[edgeKey[K]]graph.Edge[K]),
+		candidates: make(map[string]map[K]struct{}, len(pattern.Nodes)),
+		binding:    make(Binding[K], len(pattern.Nodes)),
+		used:       make(map[edgeKey[K]]struct{}, len(pattern.Edges)),
+		results:    make([]Binding[K], 0),
+	}
+
+	// In undirected graphs, the positions of the vertices determine a unique
+	// key for both directions of an edge.
+	if !g.Traits().IsDirected {
+		m.positions = make(map[K]int, len(idx.vertices))
+		for i, hash := range idx.vertices {
+			m.positions[hash] = i
+		}
+	}
+
+	for _, edges := range idx.out {
+		for _, edge := range edges {
+			m.edges[edgeKey[K]{edge.Source, edge.Target}] = edge
+		}
+	}
+
+	if err := m.computeCandidates(); err != nil {
+		return nil, err
+	}
+
+	m.order = m.nodeOrder()
+	m.match(0)
+
+	return m.results, nil
+}
+
+// validatePattern checks that the node names of the pattern are unique and not
+// empty, and that the edges of the pattern only refer to existing nodes.
+func validatePattern(pattern Pattern) error {
+	if len(pattern.Nodes) == 0 {
+		return errors.New("pattern must contain at least one node")
+	}
+
+	names := make(map[string]struct{}, len(pattern.Nodes))
+
+	for _, node := range pattern.Nodes {
+		if node.Name == "" {
+			return errors.New("pattern contains node without name")
+		}
+		if _, ok := names[node.Name]; ok {
+			return fmt.Errorf("pattern contains node %q more than once", node.Name)
+		}
+		names[node.Name] = struct{}{}
+	}
+
+	for _, edge := range pattern.Edges {
+		if _, ok := names[edge.Source]; !ok {
+			return fmt.Errorf("edge (%s, %s) refers to unknown node %q", edge.Source, edge.Target, edge.Source)
+		}
+		if _, ok := names[edge.Target]; !ok {
+			return fmt.Errorf("edge (%s, %s) refers to unknown node %q", edge.Source, edge.Target, edge.Target)
+		}
+	}
+
+	return nil
+}
+
+type edgeKey[K comparable] struct {
+	source K
+	target K
+}
+
+// matcher holds the state of a single call to Match, which binds the nodes of
+// the pattern one after another and backtracks if a node can't be bound.
+type matcher[K comparable, T any] struct {
+	g          graph.Graph[K, T]
+	pattern    Pattern
+	label      string
+	idx        *index[K]
+	edges      map[edgeKey[K]]graph.Edge[K]
+	candidates map[string]map[K]struct{}
+	order      []int
+	binding    Binding[K]
+	used       map[edgeKey[K]]struct{}
+	positions  map[K]int
+	results    []Binding[K]
+}
+
+// computeCandidates determines the vertices that satisfy the attribute
+// constraints of each node.
+func (m *matcher[K, T]) computeCandidates() error {
+	for _, node := range m.pattern.Nodes {
+		m.candidates[node.Name] = make(map[K]struct{})
+	}
+
+	for _, hash := range m.idx.vertices {
+		_, properties, err := m.g.VertexWithProperties(hash)
+		if err != nil {
+			return fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+
+		for _, node := range m.pattern.Nodes {
+			if hasAttributes(properties.Attributes, node.Attributes) {
+				m.candidates[node.Name][hash] = struct{}{}
+			}
+		}
+	}
+
+	return nil
+}
+
+// nodeOrder determines the order in which the nodes are bound. It starts with
+// the node that has the fewest candidates and then prefers nodes connected to
+// nodes that have already been bound, so that their candidates can be taken
+// from the edges of the bound vertices.
+func (m *matcher[K, T]) nodeOrder() []int {
+	order := make([]int, 0, len(m.pattern.Nodes))
+	bound := make(map[string]bool, len(m.pattern.Nodes))
+
+	for len(order) < len(m.pattern.Nodes) {
+		best := -1
+		bestConnected := false
+
+		for i, node := range m.pattern.Nodes {
+			if bound[node.Name] {
+				continue
+			}
+
+			connected := false
+			for _, edge := range m.pattern.Edges {
+				if (edge.Source == node.Name && bound[edge.Target]) || (edge.Target == node.Name && bound[edge.Source]) {
+					connected = true
+					break
+				}
+			}
+
+			if best == -1 ||
+				(connected && !bestConnected) ||
+				(connected == bestConnected && len(m.candidates[node.Name]) < len(m.candidates[m.pattern.Nodes[best].Name])) {
+				best = i
+				bestConnected = connected
+			}
+		}
+
+		order = append(order, best)
+		bound[m.pattern.Nodes[best].Name] = true
+	}
+
+	return order
+}
+
+func (m *matcher[K, T]) match(position int) {
+	if position == len(m.order) {
+		result := make(Binding[K], len(m.binding))
+		for name, hash := range m.binding {
+			result[name] = hash
+		}
+		m.results = append(m.results, result)
+		return
+	}
+
+	node := m.pattern.Nodes[m.order[position]]
+
+	for _, hash := range m.nodeCandidates(node.Name) {
+		m.binding[node.Name] = hash
+
+		if bound, ok := m.bindEdges(node.Name); ok {
+			m.match(position + 1)
+
+			for _, key := range bound {
+				delete(m.used, key)
+			}
+		}
+
+		delete(m.binding, node.Name)
+	}
+}
+
+// nodeCandidates returns the candidates for the given node in a deterministic
+// order. If the node is connected to a bound node, only the neighbors of the
+// bound vertex are considered.
+func (m *matcher[K, T]) nodeCandidates(name string) []K {
+	candidates := m.candidates[name]
+
+	for _, edge := range m.pattern.Edges {
+		var neighbors []K
+
+		if source, ok := m.binding[edge.Source]; ok && edge.Target == name {
+			for _, e := range m.idx.out[source] {
+				neighbors = append(neighbors, e.Target)
+			}
+		} else if target, ok := m.binding[edge.Target]; ok && edge.Source == name {
+			for _, e := range m.idx.in[target] {
+				neighbors = append(neighbors, e.Source)
+			}
+		} else {
+			continue
+		}
+
+		hashes := make([]K, 0, len(neighbors))
+		seen := make(map[K]struct{}, len(neighbors))
+
+		for _, neighbor := range neighbors {
+			if _, ok := candidates[neighbor]; !ok {
+				continue
+			}
+			if _, ok := seen[neighbor]; ok {
+				continue
+			}
+			seen[neighbor] = struct{}{}
+			hashes = append(hashes, neighbor)
+		}
+
+		return hashes
+	}
+
+	hashes := make([]K, 0, len(candidates))
+	for _, hash := range m.idx.vertices {
+		if _, ok := candidates[hash]; ok {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	return hashes
+}
+
+// bindEdges binds all edges of the pattern between the given node and the nodes
+// that have already been bound. It returns the keys of the bound edges, or false
+// if one of the edges can't be bound, in which case no edge remains bound.
+func (m *matcher[K, T]) bindEdges(name string) ([]edgeKey[K], bool) {
+	bound := make([]edgeKey[K], 0)
+
+	release := func() {
+		for _, key := range bound {
+			delete(m.used, key)
+		}
+	}
+
+	for _, edgePattern := range m.pattern.Edges {
+		if edgePattern.Source != name && edgePattern.Target != name {
+			continue
+		}
+
+		source, sourceBound := m.binding[edgePattern.Source]
+		target, targetBound := m.binding[edgePattern.Target]
+
+		if !sourceBound || !targetBound {
+			continue
+		}
+
+		edge, ok := m.edges[edgeKey[K]{source, target}]
+		if !ok || !m.edgeMatches(edge, edgePattern) {
+			release()
+			return nil, false
+		}
+
+		key := m.usageKey(source, target)
+		if _, ok := m.used[key]; ok {
+			release()
+			return nil, false
+		}
+
+		m.used[key] = struct{}{}
+		bound = append(bound, key)
+	}
+
+	return bound, true
+}
+
+func (m *matcher[K, T]) edgeMatches(edge graph.Edge[K], pattern EdgePattern) bool {
+	if pattern.Label != "" && edge.Properties.Attributes[m.label] != pattern.Label {
+		return false
+	}
+
+	return hasAttributes(edge.Properties.Attributes, pattern.Attributes)
+}
+
+// usageKey returns the key used for ensuring that an edge is only bound once.
+// In undirected graphs, both directions of an edge are the same edge, so the
+// vertex that comes first in the sorted vertices is used as source.
+func (m *matcher[K, T]) usageKey(source, target K) edgeKey[K] {
+	if m.positions != nil && m.positions[target] < m.positions[source] {
+		return edgeKey[K]{target, source}
+	}
+	return edgeKey[K]{source, target}
+}
+
+// hasAttributes reports whether attributes contains all required attributes
+// with the required values.
+func hasAttributes(attributes, required map[string]string) bool {
+	for key, value := range required {
+		if v, ok := attributes[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,217 @@
+package graphquery
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestMatch(t *testing.T) {
+	g := records()
+
+	tests := map[string]struct {
+		pattern          Pattern
+		expectedBindings []Binding[string]
+		shouldFail       bool
+	}{
+		"single node": {
+			pattern: Pattern{
+				Nodes: []NodePattern{
+					{Name: "c", Attributes: map[string]string{"status": "active"}},
+				},
+			},
+			expectedBindings: []Binding[string]{
+				{"c": "asthma"},
+			},
+		},
+		"path with labels": {
+			pattern: Pattern{
+				Nodes: []NodePattern{
+					{Name: "p", Attributes: map[string]string{"type": "Patient"}},
+					{Name: "e"},
+					{Name: "o", Attributes: map[string]string{"type": "Observation"}},
+				},
+				Edges: []EdgePattern{
+					{Source: "p", Target: "e", Label: "hasEncounter"},
+					{Source: "e", Target: "o", Label: "observed"},
+				},
+			},
+			expectedBindings: []Binding[string]{
+				{"p": "patient-1", "e": "encounter-2", "o": "heart-rate"},
+			},
+		},
+		"distinct edges": {
+			pattern: Pattern{
+				Nodes: []NodePattern{
+					{Name: "e1"},
+					{Name: "e2"},
+					{Name: "c", Attributes: map[string]string{"type": "Condition"}},
+				},
+				Edges: []EdgePattern{
+					{Source: "e1", Target: "c", Label: "diagnosed"},
+					{Source: "e2", Target: "c", Label: "diagnosed"},
+				},
+			},
+			expectedBindings: []Binding[string]{
+				{"e1": "encounter-1", "e2": "encounter-2", "c": "asthma"},
+				{"e1": "encounter-2", "e2": "encounter-1", "c": "asthma"},
+				{"e1": "encounter-1", "e2": "encounter-3", "c": "flu"},
+				{"e1": "encounter-3", "e2": "encounter-1", "c": "flu"},
+			},
+		},
+		"edge attributes": {
+			pattern: Pattern{
+				Nodes: []NodePattern{
+					{Name: "a"},
+					{Name: "b"},
+				},
+				Edges: []EdgePattern{
+					{Source: "a", Target: "b", Attributes: map[string]string{"label": "observed"}},
+				},
+			},
+			expectedBindings: []Binding[string]{
+				{"a": "encounter-2", "b": "heart-rate"},
+			},
+		},
+		"no match": {
+			pattern: Pattern{
+				Nodes: []NodePattern{
+					{Name: "c", Attributes: map[string]string{"type": "Condition"}},
+					{Name: "p", Attributes: map[string]string{"type": "Patient"}},
+				},
+				Edges: []EdgePattern{
+					{Source: "c", Target: "p"},
+				},
+			},
+			expectedBindings: []Binding[string]{},
+		},
+		"empty pattern": {
+			pattern:    Pattern{},
+			shouldFail: true,
+		},
+		"duplicate node": {
+			pattern: Pattern{
+				Nodes: []NodePattern{{Name: "a"}, {Name: "a"}},
+			},
+			shouldFail: true,
+		},
+		"unknown node": {
+			pattern: Pattern{
+				Nodes: []NodePattern{{Name: "a"}},
+				Edges: []EdgePattern{{Source: "a", Target: "b"}},
+			},
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		bindings, err := Match(g, test.pattern)
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		if test.shouldFail {
+			continue
+		}
+
+		if !sameBindings(bindings, test.expectedBindings) {
+			t.Errorf("%s: bindings don't match: expected %v, got %v", name, test.expectedBindings, bindings)
+		}
+	}
+}
+
+func TestMatch_undirected(t *testing.T) {
+	g := graph.New(graph.IntHash)
+
+	for i := 1; i <= 4; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	// A triangle 1-2-3 and a pendant vertex 4.
+	_ = g.AddEdge(1, 2)
+	_ = g.AddEdge(2, 3)
+	_ = g.AddEdge(3, 1)
+	_ = g.AddEdge(3, 4)
+
+	triangle := Pattern{
+		Nodes: []NodePattern{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		Edges: []EdgePattern{
+			{Source: "a", Target: "b"},
+			{Source: "b", Target: "c"},
+			{Source: "c", Target: "a"},
+		},
+	}
+
+	bindings, err := Match(g, triangle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Each of the 3 rotations in both directions.
+	if len(bindings) != 6 {
+		t.Errorf("number of bindings doesn't match: expected %v, got %v (%v)", 6, len(bindings), bindings)
+	}
+
+	// An edge pattern traversing the same undirected edge twice must not match.
+	backAndForth := Pattern{
+		Nodes: []NodePattern{{Name: "a"}, {Name: "b"}},
+		Edges: []EdgePattern{
+			{Source: "a", Target: "b"},
+			{Source: "b", Target: "a"},
+		},
+	}
+
+	bindings, err = Match(g, backAndForth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(bindings) != 0 {
+		t.Errorf("number of bindings doesn't match: expected %v, got %v (%v)", 0, len(bindings), bindings)
+	}
+}
+
+func TestMatch_deterministic(t *testing.T) {
+	g := records()
+
+	pattern := Pattern{
+		Nodes: []NodePattern{{Name: "a"}, {Name: "b"}},
+		Edges: []EdgePattern{{Source: "a", Target: "b"}},
+	}
+
+	expected, _ := Match(g, pattern)
+
+	for i := 0; i < 10; i++ {
+		bindings, _ := Match(g, pattern)
+		if !reflect.DeepEqual(bindings, expected) {
+			t.Fatalf("bindings don't match: expected %v, got %v", expected, bindings)
+		}
+	}
+}
+
+// sameBindings reports whether both slices contain the same bindings,
+// regardless of their order.
+func sameBindings(a, b []Binding[string]) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int)
+	for _, binding := range a {
+		counts[fmt.Sprint(binding)]++
+	}
+	for _, binding := range b {
+		counts[fmt.Sprint(binding)]--
+	}
+
+	for _, count := range counts {
+		if count != 0 {
+			return false
+		}
+	}
+
+	return true
+}
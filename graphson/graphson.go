@@ -0,0 +1,516 @@
+// Package graphson reads and writes graphs in the GraphSON 3.0 format used by
+// Apache TinkerPop, so that graphs can be exchanged with TinkerPop-compatible
+// databases such as JanusGraph or Amazon Neptune.
+//
+//	_ = graphson.Write(g, file)
+//	h, _ := graphson.Read(file, graph.StringHash, graphson.GraphTraits(graph.Directed()))
+//
+// The graph is written as adjacency list, which is the format produced and
+// consumed by TinkerPop's GraphSONWriter and GraphSONReader: Each line contains
+// a vertex together with its outgoing and incoming edges.
+//
+//	{"id":"A","label":"vertex","outE":{"edge":[{"id":{"@type":"g:Int64","@value":0},"inV":"B"}]},"properties":{...}}
+//	{"id":"B","label":"vertex","inE":{"edge":[{"id":{"@type":"g:Int64","@value":0},"outV":"A"}]},"properties":{...}}
+//
+// The vertex hashes become the vertex IDs. The vertex values, weights, and the
+// edge data are stored in the "value", "weight", and "data" properties, and
+// attributes are stored as string properties. By default, vertex values and
+// edge data are converted to GraphSON via their JSON representation. Custom
+// conversions can be set using [ValueCodec] and [DataCodec].
+//
+// TinkerPop graphs are directed and don't have any other traits, so undirected
+// edges are written once, with an arbitrary one of their vertices as source.
+package graphson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dominikbraun/graph"
+)
+
+const (
+	valueProperty  = "value"
+	weightProperty = "weight"
+	dataProperty   = "data"
+
+	defaultVertexLabel = "vertex"
+	defaultEdgeLabel   = "edge"
+
+	// pageSize is the number of vertices read from the graph at once.
+	pageSize = 1000
+)
+
+type config struct {
+	encodeValue    func(interface{}) (interface{}, error)
+	decodeValue    func(interface{}) (interface{}, error)
+	encodeData     func(interface{}) (interface{}, error)
+	decodeData     func(interface{}) (interface{}, error)
+	labelAttribute string
+	traits         []func(*graph.Traits)
+}
+
+func newConfig(options ...func(*config)) config {
+	c := config{
+		encodeValue: toGeneric,
+		encodeData:  toGeneric,
+		decodeData:  fromGeneric,
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// ValueCodec sets the functions used for converting vertex values to GraphSON
+// property values and back. encode has to return one of the types supported by
+// GraphSON as listed in [Write], and decode receives one of those types.
+func ValueCodec[T any](encode func(value T) (interface{}, error), decode func(value interface{}) (T, error)) func(*config) {
+	return func(c *config) {
+		c.encodeValue = func(value interface{}) (interface{}, error) {
+			v, ok := value.(T)
+			if !ok {
+				return nil, fmt.Errorf("value codec doesn't support values of type %T", value)
+			}
+			return encode(v)
+		}
+		c.decodeValue = func(value interface{}) (interface{}, error) {
+			return decode(value)
+		}
+	}
+}
+
+// DataCodec sets the functions used for converting edge data to GraphSON
+// property values and back. By default, edge data is converted via its JSON
+// representation and read as the generic JSON types such as
+// map[string]interface{}.
+func DataCodec(encode func(data interface{}) (interface{}, error), decode func(value interface{}) (interface{}, error)) func(*config) {
+	return func(c *config) {
+		c.encodeData = encode
+		c.decodeData = decode
+	}
+}
+
+// LabelAttribute sets the attribute that holds the labels of vertices and
+// edges. When writing, the attribute is used as label instead of a property.
+// When reading, the label is stored in this attribute. Without this option,
+// all vertices are labeled "vertex" and all edges are labeled "edge".
+func LabelAttribute(key string) func(*config) {
+	return func(c *config) {
+		c.labelAttribute = key
+	}
+}
+
+// GraphTraits sets the traits of the graph created by [Read], for example
+// graph.Directed().
+func GraphTraits(options ...func(*graph.Traits)) func(*config) {
+	return func(c *config) {
+		c.traits = append(c.traits, options...)
+	}
+}
+
+// vertexLine is a vertex in the adjacency list format.
+type vertexLine struct {
+	ID         interface{}                 `json:"id"`
+	Label      string                      `json:"label"`
+	OutE       map[string][]edgeEntry      `json:"outE,omitempty"`
+	InE        map[string][]edgeEntry      `json:"inE,omitempty"`
+	Properties map[string][]vertexProperty `json:"properties,omitempty"`
+}
+
+// edgeEntry is an edge of a vertex. Outgoing edges have an inV field, incoming
+// edges have an outV field.
+type edgeEntry struct {
+	ID         interface{}            `json:"id"`
+	InV        interface{}            `json:"inV,omitempty"`
+	OutV       interface{}            `json:"outV,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type vertexProperty struct {
+	ID    interface{} `json:"id"`
+	Value interface{} `json:"value"`
+}
+
+// Write writes the given graph to w in the GraphSON 3.0 adjacency list format.
+// The vertices are written in the order defined by graph.VerticesPage.
+//
+// Vertex hashes and the values returned by the codecs have to be nil, strings,
+// booleans, integers, floats, json.Number, slices of interface{}, or maps with
+// string keys, which are written as the corresponding GraphSON types.
+func Write[K comparable, T any](g graph.Graph[K, T], w io.Writer, options ...func(*config)) error {
+	c := newConfig(options...)
+
+	edges, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	outEdges := make(map[K][]int)
+	inEdges := make(map[K][]int)
+
+	for i, edge := range edges {
+		outEdges[edge.Source] = append(outEdges[edge.Source], i)
+		inEdges[edge.Target] = append(inEdges[edge.Target], i)
+	}
+
+	// The edge entries are created upfront, because each edge is written twice:
+	// once as outgoing edge of its source and once as incoming edge of its target.
+	entries := make([]edgeEntry, len(edges))
+	labels := make([]string, len(edges))
+
+	for i, edge := range edges {
+		properties, label, err := edgeProperties(c, edge)
+		if err != nil {
+			return fmt.Errorf("failed to convert edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+		entries[i] = edgeEntry{
+			ID:         typed("g:Int64", int64(i)),
+			Properties: properties,
+		}
+		labels[i] = label
+	}
+
+	encoder := json.NewEncoder(w)
+	propertyID := int64(0)
+	cursor := ""
+
+	for {
+		hashes, next, err := graph.VerticesPage(g, cursor, pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list vertices: %w", err)
+		}
+
+		for _, hash := range hashes {
+			line, err := vertex(c, g, hash, &propertyID)
+			if err != nil {
+				return fmt.Errorf("failed to convert vertex %v: %w", hash, err)
+			}
+
+			for _, i := range outEdges[hash] {
+				entry := entries[i]
+				if entry.InV, err = toGraphSON(edges[i].Target); err != nil {
+					return fmt.Errorf("failed to convert vertex %v: %w", edges[i].Target, err)
+				}
+				if line.OutE == nil {
+					line.OutE = make(map[string][]edgeEntry)
+				}
+				line.OutE[labels[i]] = append(line.OutE[labels[i]], entry)
+			}
+
+			for _, i := range inEdges[hash] {
+				entry := entries[i]
+				if entry.OutV, err = toGraphSON(edges[i].Source); err != nil {
+					return fmt.Errorf("failed to convert vertex %v: %w", edges[i].Source, err)
+				}
+				if line.InE == nil {
+					line.InE = make(map[string][]edgeEntry)
+				}
+				line.InE[labels[i]] = append(line.InE[labels[i]], entry)
+			}
+
+			if err := encoder.Encode(line); err != nil {
+				return fmt.Errorf("failed to write vertex %v: %w", hash, err)
+			}
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	return nil
+}
+
+func vertex[K comparable, T any](c config, g graph.Graph[K, T], hash K, propertyID *int64) (vertexLine, error) {
+	value, properties, err := g.VertexWithProperties(hash)
+	if err != nil {
+		return vertexLine{}, err
+	}
+
+	id, err := toGraphSON(hash)
+	if err != nil {
+		return vertexLine{}, err
+	}
+
+	line := vertexLine{
+		ID:         id,
+		Label:      defaultVertexLabel,
+		Properties: make(map[string][]vertexProperty),
+	}
+
+	add := func(key string, value interface{}) error {
+		converted, err := toGraphSON(value)
+		if err != nil {
+			return fmt.Errorf("failed to convert property %s: %w", key, err)
+		}
+		line.Properties[key] = []vertexProperty{{ID: typed("g:Int64", *propertyID), Value: converted}}
+		*propertyID++
+		return nil
+	}
+
+	encoded, err := c.encodeValue(value)
+	if err != nil {
+		return vertexLine{}, fmt.Errorf("failed to encode value: %w", err)
+	}
+	if err := add(valueProperty, encoded); err != nil {
+		return vertexLine{}, err
+	}
+
+	if properties.Weight != 0 {
+		if err := add(weightProperty, properties.Weight); err != nil {
+			return vertexLine{}, err
+		}
+	}
+
+	for _, key := range sortedKeys(properties.Attributes) {
+		if key == c.labelAttribute {
+			line.Label = properties.Attributes[key]
+			continue
+		}
+		if key == valueProperty || key == weightProperty {
+			return vertexLine{}, fmt.Errorf("attribute %s conflicts with the reserved property of the same name", key)
+		}
+		if err := add(key, properties.Attributes[key]); err != nil {
+			return vertexLine{}, err
+		}
+	}
+
+	return line, nil
+}
+
+func edgeProperties[K comparable](c config, edge graph.Edge[K]) (map[string]interface{}, string, error) {
+	properties := make(map[string]interface{})
+	label := defaultEdgeLabel
+
+	if edge.Properties.Weight != 0 {
+		properties[weightProperty] = typed("g:Int64", int64(edge.Properties.Weight))
+	}
+
+	if edge.Properties.Data != nil {
+		encoded, err := c.encodeData(edge.Properties.Data)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode data: %w", err)
+		}
+		if properties[dataProperty], err = toGraphSON(encoded); err != nil {
+			return nil, "", fmt.Errorf("failed to convert data: %w", err)
+		}
+	}
+
+	for key, value := range edge.Properties.Attributes {
+		if key == c.labelAttribute {
+			label = value
+			continue
+		}
+		if key == weightProperty || key == dataProperty {
+			return nil, "", fmt.Errorf("attribute %s conflicts with the reserved property of the same name", key)
+		}
+		properties[key] = value
+	}
+
+	return properties, label, nil
+}
+
+// rawVertexLine and rawEdgeEntry are the counterparts to vertexLine and
+// edgeEntry used for reading.
+type rawVertexLine struct {
+	ID         interface{}                    `json:"id"`
+	Label      string                         `json:"label"`
+	OutE       map[string][]rawEdgeEntry      `json:"outE"`
+	Properties map[string][]rawVertexProperty `json:"properties"`
+}
+
+type rawEdgeEntry struct {
+	InV        interface{}            `json:"inV"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type rawVertexProperty struct {
+	Value interface{} `json:"value"`
+}
+
+// Read reads a graph in the GraphSON 3.0 adjacency list format from r, as
+// written by [Write] or TinkerPop's GraphSONWriter. The hashes of the vertices
+// are computed from their values using the given hash function. Only the
+// outgoing edges of each vertex are read, since the incoming edges are the
+// outgoing edges of other vertices.
+//
+// If a vertex doesn't have a "value" property, which usually is the case for
+// graphs written by other systems, its ID is decoded as value instead.
+// Properties other than "value", "weight", and "data" are read as attributes,
+// converting non-string values using fmt.Sprint.
+func Read[K comparable, T any](r io.Reader, hash graph.Hash[K, T], options ...func(*config)) (graph.Graph[K, T], error) {
+	c := newConfig(options...)
+	g := graph.New(hash, c.traits...)
+
+	decodeValue := func(value interface{}) (T, error) {
+		if c.decodeValue != nil {
+			decoded, err := c.decodeValue(value)
+			if err != nil {
+				var zero T
+				return zero, err
+			}
+			v, ok := decoded.(T)
+			if !ok {
+				var zero T
+				return zero, fmt.Errorf("value codec returned %T instead of %T", decoded, zero)
+			}
+			return v, nil
+		}
+		return fromGenericAs[T](value)
+	}
+
+	// Edges can refer to vertices that come later in the input, so they're
+	// added after all vertices have been read.
+	type pendingEdge struct {
+		source K
+		target interface{}
+		label  string
+		entry  rawEdgeEntry
+	}
+
+	hashes := make(map[string]K)
+	var pending []pendingEdge
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	for n := 1; ; n++ {
+		var line rawVertexLine
+
+		if err := decoder.Decode(&line); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read vertex %d: %w", n, err)
+		}
+
+		id, err := fromGraphSON(line.ID)
+		if err != nil {
+			return nil, fmt.Errorf("vertex %d: invalid ID: %w", n, err)
+		}
+
+		hash, err := addVertex(c, g, hash, id, line, decodeValue)
+		if err != nil {
+			return nil, fmt.Errorf("vertex %v: %w", id, err)
+		}
+
+		hashes[idKey(id)] = hash
+
+		for _, label := range sortedKeys(line.OutE) {
+			for _, entry := range line.OutE[label] {
+				pending = append(pending, pendingEdge{source: hash, target: entry.InV, label: label, entry: entry})
+			}
+		}
+	}
+
+	for _, edge := range pending {
+		id, err := fromGraphSON(edge.target)
+		if err != nil {
+			return nil, fmt.Errorf("edge from %v: invalid target ID: %w", edge.source, err)
+		}
+
+		target, ok := hashes[idKey(id)]
+		if !ok {
+			return nil, fmt.Errorf("edge from %v: target vertex %v doesn't exist", edge.source, id)
+		}
+
+		properties, err := readEdgeProperties(c, edge.label, edge.entry)
+		if err != nil {
+			return nil, fmt.Errorf("edge (%v, %v): %w", edge.source, target, err)
+		}
+
+		if err := g.AddEdge(edge.source, target, properties...); err != nil {
+			return nil, fmt.Errorf("failed to add edge (%v, %v): %w", edge.source, target, err)
+		}
+	}
+
+	return g, nil
+}
+
+func addVertex[K comparable, T any](c config, g graph.Graph[K, T], hash graph.Hash[K, T], id interface{}, line rawVertexLine, decodeValue func(interface{}) (T, error)) (K, error) {
+	var zero K
+
+	source := id
+	weight := 0
+	attributes := make(map[string]string)
+
+	if c.labelAttribute != "" && line.Label != "" {
+		attributes[c.labelAttribute] = line.Label
+	}
+
+	for key, values := range line.Properties {
+		// Multi-properties aren't supported, only their first value is read.
+		if len(values) == 0 {
+			continue
+		}
+
+		value, err := fromGraphSON(values[0].Value)
+		if err != nil {
+			return zero, fmt.Errorf("invalid property %s: %w", key, err)
+		}
+
+		switch key {
+		case valueProperty:
+			source = value
+		case weightProperty:
+			if weight, err = toInt(value); err != nil {
+				return zero, fmt.Errorf("invalid weight: %w", err)
+			}
+		default:
+			attributes[key] = toString(value)
+		}
+	}
+
+	value, err := decodeValue(source)
+	if err != nil {
+		return zero, fmt.Errorf("failed to decode value: %w", err)
+	}
+
+	if err := g.AddVertex(value, graph.VertexWeight(weight), graph.VertexAttributes(attributes)); err != nil {
+		return zero, fmt.Errorf("failed to add vertex: %w", err)
+	}
+
+	return hash(value), nil
+}
+
+func readEdgeProperties(c config, label string, entry rawEdgeEntry) ([]func(*graph.EdgeProperties), error) {
+	attributes := make(map[string]string)
+	properties := []func(*graph.EdgeProperties){
+		graph.EdgeAttributes(attributes),
+	}
+
+	if c.labelAttribute != "" && label != "" {
+		attributes[c.labelAttribute] = label
+	}
+
+	for key, raw := range entry.Properties {
+		value, err := fromGraphSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid property %s: %w", key, err)
+		}
+
+		switch key {
+		case weightProperty:
+			weight, err := toInt(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid weight: %w", err)
+			}
+			properties = append(properties, graph.EdgeWeight(weight))
+		case dataProperty:
+			data, err := c.decodeData(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode data: %w", err)
+			}
+			properties = append(properties, graph.EdgeData(data))
+		default:
+			attributes[key] = toString(value)
+		}
+	}
+
+	return properties, nil
+}
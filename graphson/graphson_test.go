@@ -0,0 +1,187 @@
+package graphson
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestWriteRead(t *testing.T) {
+	tests := map[string]struct {
+		traits []func(*graph.Traits)
+	}{
+		"directed graph": {
+			traits: []func(*graph.Traits){graph.Directed()},
+		},
+		"undirected graph": {
+			traits: []func(*graph.Traits){},
+		},
+	}
+
+	for name, test := range tests {
+		g := graph.New(graph.StringHash, test.traits...)
+
+		_ = g.AddVertex("A", graph.VertexWeight(2), graph.VertexAttribute("type", "person"))
+		_ = g.AddVertex("B", graph.VertexAttribute("color", "red"))
+		_ = g.AddVertex("C")
+		_ = g.AddEdge("A", "B", graph.EdgeWeight(3), graph.EdgeAttribute("type", "knows"))
+		_ = g.AddEdge("B", "C", graph.EdgeData(map[string]interface{}{"lanes": 2, "names": []interface{}{"x", "y"}}))
+
+		var buf bytes.Buffer
+
+		if err := Write(g, &buf, LabelAttribute("type")); err != nil {
+			t.Fatalf("%s: failed to write graph: %v", name, err)
+		}
+
+		if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+			t.Errorf("%s: line count doesn't match: expected %v, got %v", name, 3, lines)
+		}
+
+		h, err := Read(&buf, graph.StringHash, LabelAttribute("type"), GraphTraits(test.traits...))
+		if err != nil {
+			t.Fatalf("%s: failed to read graph: %v", name, err)
+		}
+
+		if order, _ := h.Order(); order != 3 {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, 3, order)
+		}
+
+		if size, _ := h.Size(); size != 2 {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, 2, size)
+		}
+
+		_, properties, _ := h.VertexWithProperties("A")
+		if properties.Weight != 2 || properties.Attributes["type"] != "person" {
+			t.Errorf("%s: properties of A don't match: got %v", name, properties)
+		}
+
+		_, properties, _ = h.VertexWithProperties("B")
+		if properties.Attributes["color"] != "red" || properties.Attributes["type"] != "vertex" {
+			t.Errorf("%s: properties of B don't match: got %v", name, properties)
+		}
+
+		edge, err := h.Edge("A", "B")
+		if err != nil {
+			t.Fatalf("%s: failed to get edge: %v", name, err)
+		}
+		if edge.Properties.Weight != 3 || edge.Properties.Attributes["type"] != "knows" {
+			t.Errorf("%s: properties of (A, B) don't match: got %v", name, edge.Properties)
+		}
+
+		edge, err = h.Edge("B", "C")
+		if err != nil {
+			t.Fatalf("%s: failed to get edge: %v", name, err)
+		}
+		data := fmt.Sprint(edge.Properties.Data)
+		if expected := "map[lanes:2 names:[x y]]"; data != expected {
+			t.Errorf("%s: data doesn't match: expected %v, got %v", name, expected, data)
+		}
+	}
+}
+
+func TestWrite_reservedAttribute(t *testing.T) {
+	g := graph.New(graph.StringHash)
+	_ = g.AddVertex("A", graph.VertexAttribute("weight", "heavy"))
+
+	if err := Write(g, &bytes.Buffer{}); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestRead(t *testing.T) {
+	// An excerpt of TinkerPop's "modern" graph as written by GraphSONWriter.
+	input := `{"id":{"@type":"g:Int32","@value":1},"label":"person","outE":{"created":[{"id":{"@type":"g:Int32","@value":9},"inV":{"@type":"g:Int32","@value":3},"properties":{"weight":{"@type":"g:Double","@value":0.4}}}],"knows":[{"id":{"@type":"g:Int32","@value":7},"inV":{"@type":"g:Int32","@value":2},"properties":{"weight":{"@type":"g:Double","@value":0.5}}}]},"properties":{"name":[{"id":{"@type":"g:Int64","@value":0},"value":"marko"}],"age":[{"id":{"@type":"g:Int64","@value":1},"value":{"@type":"g:Int32","@value":29}}]}}
+{"id":{"@type":"g:Int32","@value":2},"label":"person","inE":{"knows":[{"id":{"@type":"g:Int32","@value":7},"outV":{"@type":"g:Int32","@value":1},"properties":{"weight":{"@type":"g:Double","@value":0.5}}}]},"properties":{"name":[{"id":{"@type":"g:Int64","@value":2},"value":"vadas"}]}}
+{"id":{"@type":"g:Int64","@value":3},"label":"software","inE":{"created":[{"id":{"@type":"g:Int32","@value":9},"outV":{"@type":"g:Int32","@value":1}}]},"properties":{"name":[{"id":{"@type":"g:Int64","@value":4},"value":"lop"}],"lang":[{"id":{"@type":"g:Int64","@value":5},"value":"java"}]}}
+`
+
+	g, err := Read(strings.NewReader(input), graph.IntHash, GraphTraits(graph.Directed()), LabelAttribute("label"))
+	if err != nil {
+		t.Fatalf("failed to read graph: %v", err)
+	}
+
+	if size, _ := g.Size(); size != 2 {
+		t.Errorf("size doesn't match: expected %v, got %v", 2, size)
+	}
+
+	_, properties, _ := g.VertexWithProperties(1)
+	if properties.Attributes["name"] != "marko" || properties.Attributes["age"] != "29" || properties.Attributes["label"] != "person" {
+		t.Errorf("properties of 1 don't match: got %v", properties)
+	}
+
+	edge, err := g.Edge(1, 3)
+	if err != nil {
+		t.Fatalf("failed to get edge: %v", err)
+	}
+	if edge.Properties.Attributes["label"] != "created" {
+		t.Errorf("label of (1, 3) doesn't match: expected %v, got %v", "created", edge.Properties.Attributes["label"])
+	}
+}
+
+func TestRead_invalidInput(t *testing.T) {
+	tests := map[string]struct {
+		input string
+	}{
+		"invalid JSON": {
+			input: `{"id":`,
+		},
+		"missing target": {
+			input: `{"id":"A","label":"vertex","outE":{"edge":[{"id":{"@type":"g:Int64","@value":0},"inV":"B"}]}}`,
+		},
+		"unknown type": {
+			input: `{"id":{"@type":"g:UUID","@value":"x"},"label":"vertex"}`,
+		},
+	}
+
+	for name, test := range tests {
+		if _, err := Read(strings.NewReader(test.input), graph.StringHash); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestValueCodec(t *testing.T) {
+	type city struct {
+		Name       string
+		Population int
+	}
+
+	cityHash := func(c city) string {
+		return c.Name
+	}
+
+	g := graph.New(cityHash)
+	_ = g.AddVertex(city{Name: "Berlin", Population: 3})
+
+	encode := func(c city) (interface{}, error) {
+		return fmt.Sprintf("%s %d", c.Name, c.Population), nil
+	}
+	decode := func(value interface{}) (city, error) {
+		var c city
+		_, err := fmt.Sscanf(value.(string), "%s %d", &c.Name, &c.Population)
+		return c, err
+	}
+
+	var buf bytes.Buffer
+
+	if err := Write(g, &buf, ValueCodec(encode, decode)); err != nil {
+		t.Fatalf("failed to write graph: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"value":"Berlin 3"`) {
+		t.Errorf("encoded value not found in %s", buf.String())
+	}
+
+	h, err := Read(&buf, cityHash, ValueCodec(encode, decode))
+	if err != nil {
+		t.Fatalf("failed to read graph: %v", err)
+	}
+
+	value, _ := h.Vertex("Berlin")
+	if value.Population != 3 {
+		t.Errorf("population doesn't match: expected %v, got %v", 3, value.Population)
+	}
+}
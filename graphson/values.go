@@ -0,0 +1,289 @@
+package graphson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// toGraphSON converts a Go value into its GraphSON 3.0 representation. Strings,
+// booleans, and nil are untyped in GraphSON, while all other values are wrapped
+// in an object with a @type and a @value field.
+func toGraphSON(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, string, bool:
+		return v, nil
+	case int:
+		return typed("g:Int64", int64(v)), nil
+	case int8:
+		return typed("g:Int32", int32(v)), nil
+	case int16:
+		return typed("g:Int32", int32(v)), nil
+	case int32:
+		return typed("g:Int32", v), nil
+	case int64:
+		return typed("g:Int64", v), nil
+	case uint8:
+		return typed("g:Int32", int32(v)), nil
+	case uint16:
+		return typed("g:Int32", int32(v)), nil
+	case uint32:
+		return typed("g:Int64", int64(v)), nil
+	case uint:
+		return toGraphSON(uint64(v))
+	case uint64:
+		if v > math.MaxInt64 {
+			return nil, fmt.Errorf("integer %d overflows g:Int64", v)
+		}
+		return typed("g:Int64", int64(v)), nil
+	case float32:
+		return typed("g:Float", v), nil
+	case float64:
+		return typed("g:Double", v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return typed("g:Int64", i), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s: %w", v, err)
+		}
+		return typed("g:Double", f), nil
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, element := range v {
+			converted, err := toGraphSON(element)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = converted
+		}
+		return typed("g:List", list), nil
+	case map[string]interface{}:
+		// A g:Map is a flat list of alternating keys and values.
+		entries := make([]interface{}, 0, 2*len(v))
+		for _, key := range sortedKeys(v) {
+			converted, err := toGraphSON(v[key])
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, key, converted)
+		}
+		return typed("g:Map", entries), nil
+	case map[string]string:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[key] = value
+		}
+		return toGraphSON(m)
+	default:
+		return nil, fmt.Errorf("values of type %T aren't supported", value)
+	}
+}
+
+// fromGraphSON converts a GraphSON 3.0 value decoded by encoding/json with
+// UseNumber enabled into a Go value. Integers are returned as int32 or int64,
+// floats as float32 or float64, lists and sets as []interface{}, and maps as
+// map[string]interface{}.
+func fromGraphSON(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, string, bool:
+		return v, nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	case []interface{}:
+		return fromList(v)
+	case map[string]interface{}:
+		t, ok := v["@type"].(string)
+		if !ok {
+			return nil, fmt.Errorf("object without @type")
+		}
+		return fromTyped(t, v["@value"])
+	default:
+		return nil, fmt.Errorf("unexpected value of type %T", value)
+	}
+}
+
+func fromTyped(t string, value interface{}) (interface{}, error) {
+	switch t {
+	case "g:Int32", "g:Int64":
+		n, ok := value.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("%s value isn't a number", t)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value: %w", t, err)
+		}
+		if t == "g:Int32" {
+			if i < math.MinInt32 || i > math.MaxInt32 {
+				return nil, fmt.Errorf("integer %d overflows g:Int32", i)
+			}
+			return int32(i), nil
+		}
+		return i, nil
+	case "g:Float", "g:Double":
+		var f float64
+		switch v := value.(type) {
+		case json.Number:
+			var err error
+			if f, err = v.Float64(); err != nil {
+				return nil, fmt.Errorf("invalid %s value: %w", t, err)
+			}
+		case string:
+			// NaN and infinity are encoded as strings.
+			switch v {
+			case "NaN":
+				f = math.NaN()
+			case "Infinity":
+				f = math.Inf(1)
+			case "-Infinity":
+				f = math.Inf(-1)
+			default:
+				return nil, fmt.Errorf("invalid %s value %q", t, v)
+			}
+		default:
+			return nil, fmt.Errorf("%s value isn't a number", t)
+		}
+		if t == "g:Float" {
+			return float32(f), nil
+		}
+		return f, nil
+	case "g:List", "g:Set":
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s value isn't a list", t)
+		}
+		return fromList(list)
+	case "g:Map":
+		entries, ok := value.([]interface{})
+		if !ok || len(entries)%2 != 0 {
+			return nil, fmt.Errorf("g:Map value isn't a list of key-value pairs")
+		}
+		m := make(map[string]interface{}, len(entries)/2)
+		for i := 0; i < len(entries); i += 2 {
+			key, err := fromGraphSON(entries[i])
+			if err != nil {
+				return nil, err
+			}
+			value, err := fromGraphSON(entries[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[toString(key)] = value
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("type %s isn't supported", t)
+	}
+}
+
+func fromList(list []interface{}) ([]interface{}, error) {
+	converted := make([]interface{}, len(list))
+	for i, element := range list {
+		value, err := fromGraphSON(element)
+		if err != nil {
+			return nil, err
+		}
+		converted[i] = value
+	}
+
+	return converted, nil
+}
+
+func typed(t string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"@type":  t,
+		"@value": value,
+	}
+}
+
+// toGeneric converts a value into the generic types produced by encoding/json
+// with UseNumber enabled, using the JSON representation of the value.
+func toGeneric(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := unmarshalWithNumbers(data, &generic); err != nil {
+		return nil, err
+	}
+
+	return generic, nil
+}
+
+// fromGeneric converts a value returned by fromGraphSON into the generic types
+// produced by encoding/json.
+func fromGeneric(value interface{}) (interface{}, error) {
+	return fromGenericAs[interface{}](value)
+}
+
+// fromGenericAs converts a value returned by fromGraphSON into a value of type T
+// using its JSON representation.
+func fromGenericAs[T any](value interface{}) (T, error) {
+	var v T
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return v, err
+	}
+
+	err = json.Unmarshal(data, &v)
+	return v, err
+}
+
+func unmarshalWithNumbers(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// idKey returns a key that identifies a vertex ID returned by fromGraphSON.
+// Integer IDs are considered equal regardless of their size.
+func idKey(id interface{}) string {
+	if i, ok := id.(int32); ok {
+		id = int64(i)
+	}
+
+	return fmt.Sprintf("%T:%v", id, id)
+}
+
+func toInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int32:
+		return int(v), nil
+	case int64:
+		return int(v), nil
+	case float32:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("%v isn't a number", value)
+	}
+}
+
+func toString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	return fmt.Sprint(value)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
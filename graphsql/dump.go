@@ -0,0 +1,153 @@
+package graphsql
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/internal/storage"
+)
+
+// DumpSchema is a functional option for [Dump] that writes the CREATE TABLE
+// statements documented in the package description before the INSERT
+// statements.
+func DumpSchema() func(*config) {
+	return func(c *config) {
+		c.dumpSchema = true
+	}
+}
+
+// Dump writes the vertices and edges of the given graph as SQL INSERT
+// statements for the tables documented in the package description. The dump
+// can be loaded into any relational database for ad-hoc querying, for example
+// using psql or the sqlite3 shell, and the resulting tables can be used by a
+// [Store] as well:
+//
+//	file, _ := os.Create("graph.sql")
+//	_ = graphsql.Dump(g, file, graphsql.Postgres(), graphsql.DumpSchema())
+//
+// The graph can use any store, it doesn't have to be backed by a database. The
+// values are encoded the same way as by a [Store], and like a store, the dump
+// contains the edges of undirected graphs in both directions. Dump accepts the
+// same options as [New], where the dialect options determine how string
+// literals are escaped. The statements aren't wrapped in a transaction.
+func Dump[K comparable, T any](g graph.Graph[K, T], w io.Writer, options ...func(*config)) error {
+	c := newConfig(options...)
+	buf := bufio.NewWriter(w)
+
+	if c.dumpSchema {
+		buf.WriteString(strings.TrimSpace(fmt.Sprintf(schemaTemplate, c.verticesTable, c.edgesTable)))
+		buf.WriteString("\n\n")
+	}
+
+	cursor := ""
+
+	for {
+		hashes, next, err := graph.VerticesPage(g, cursor, 1000)
+		if err != nil {
+			return fmt.Errorf("failed to list vertices: %w", err)
+		}
+
+		for _, hash := range hashes {
+			if err := dumpVertex(buf, c, g, hash); err != nil {
+				return fmt.Errorf("failed to dump vertex %v: %w", hash, err)
+			}
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	isDirected := g.Traits().IsDirected
+
+	var dumpErr error
+
+	err := graph.IterEdges(g, func(edge graph.Edge[K]) bool {
+		if dumpErr = dumpEdge(buf, c, edge); dumpErr != nil {
+			return false
+		}
+
+		if !isDirected && edge.Source != edge.Target {
+			reversed := edge
+			reversed.Source, reversed.Target = edge.Target, edge.Source
+			dumpErr = dumpEdge(buf, c, reversed)
+		}
+
+		return dumpErr == nil
+	})
+	if err != nil {
+		return err
+	}
+	if dumpErr != nil {
+		return dumpErr
+	}
+
+	return buf.Flush()
+}
+
+func dumpVertex[K comparable, T any](w *bufio.Writer, c config, g graph.Graph[K, T], hash K) error {
+	value, properties, err := g.VertexWithProperties(hash)
+	if err != nil {
+		return err
+	}
+
+	encodedHash, err := storage.EncodeHash(hash)
+	if err != nil {
+		return err
+	}
+
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode vertex value: %w", err)
+	}
+
+	attributes, err := encodeAttributes(properties.Attributes)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "INSERT INTO %s (hash, value, weight, attributes) VALUES (%s, %s, %d, %s);\n",
+		c.verticesTable, c.quote(encodedHash), c.quote(string(encodedValue)), properties.Weight, c.quote(attributes))
+
+	return err
+}
+
+func dumpEdge[K comparable](w *bufio.Writer, c config, edge graph.Edge[K]) error {
+	source, err := storage.EncodeHash(edge.Source)
+	if err != nil {
+		return err
+	}
+
+	target, err := storage.EncodeHash(edge.Target)
+	if err != nil {
+		return err
+	}
+
+	attributes, data, err := encodeEdgeProperties(edge.Properties)
+	if err != nil {
+		return fmt.Errorf("failed to dump edge (%v, %v): %w", edge.Source, edge.Target, err)
+	}
+
+	_, err = fmt.Fprintf(w, "INSERT INTO %s (source_hash, target_hash, weight, attributes, data) VALUES (%s, %s, %d, %s, %s);\n",
+		c.edgesTable, c.quote(source), c.quote(target), edge.Properties.Weight, c.quote(attributes), c.quote(data))
+
+	return err
+}
+
+// quote returns the given string as SQL string literal. MySQL treats
+// backslashes in string literals as escape characters by default, so they are
+// escaped for MySQL as well.
+func (c config) quote(s string) string {
+	s = strings.ReplaceAll(s, "'", "''")
+
+	if c.backslashEscapes {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+
+	return "'" + s + "'"
+}
@@ -0,0 +1,98 @@
+package graphsql
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestDump(t *testing.T) {
+	tests := map[string]struct {
+		traits       []func(*graph.Traits)
+		expectedRows int
+	}{
+		"directed graph": {
+			traits:       []func(*graph.Traits){graph.Directed()},
+			expectedRows: 2,
+		},
+		"undirected graph": {
+			traits:       []func(*graph.Traits){},
+			expectedRows: 4,
+		},
+	}
+
+	for name, test := range tests {
+		g := graph.New(graph.StringHash, test.traits...)
+
+		_ = g.AddVertex("A", graph.VertexWeight(2), graph.VertexAttribute("note", `it's a \ test`))
+		_ = g.AddVertex("B")
+		_ = g.AddVertex("C")
+		_ = g.AddEdge("A", "B", graph.EdgeWeight(3), graph.EdgeData("data"))
+		_ = g.AddEdge("B", "C")
+
+		var buf bytes.Buffer
+
+		if err := Dump(g, &buf, SQLite(), DumpSchema()); err != nil {
+			t.Fatalf("%s: failed to dump graph: %v", name, err)
+		}
+
+		store := newTestStore(t)
+
+		for _, statement := range strings.Split(buf.String(), ";\n") {
+			if strings.TrimSpace(statement) == "" {
+				continue
+			}
+			if _, err := store.db.Exec(statement); err != nil {
+				t.Fatalf("%s: failed to execute %q: %v", name, statement, err)
+			}
+		}
+
+		if count, _ := store.EdgeCount(); count != test.expectedRows {
+			t.Errorf("%s: edge count doesn't match: expected %v, got %v", name, test.expectedRows, count)
+		}
+
+		h := graph.NewWithStore(graph.StringHash, graph.Store[string, string](store), test.traits...)
+
+		_, properties, err := h.VertexWithProperties("A")
+		if err != nil {
+			t.Fatalf("%s: failed to get vertex: %v", name, err)
+		}
+		if properties.Weight != 2 || properties.Attributes["note"] != `it's a \ test` {
+			t.Errorf("%s: vertex properties don't match: got %v", name, properties)
+		}
+
+		edge, err := h.Edge("A", "B")
+		if err != nil {
+			t.Fatalf("%s: failed to get edge: %v", name, err)
+		}
+		if edge.Properties.Weight != 3 || edge.Properties.Data != "data" {
+			t.Errorf("%s: edge properties don't match: got %v", name, edge.Properties)
+		}
+	}
+}
+
+func TestConfig_quote(t *testing.T) {
+	tests := map[string]struct {
+		options  []func(*config)
+		expected string
+	}{
+		"MySQL": {
+			options:  []func(*config){MySQL()},
+			expected: `'it''s a \\ test'`,
+		},
+		"PostgreSQL": {
+			options:  []func(*config){Postgres()},
+			expected: `'it''s a \ test'`,
+		},
+	}
+
+	for name, test := range tests {
+		c := newConfig(test.options...)
+
+		if quoted := c.quote(`it's a \ test`); quoted != test.expected {
+			t.Errorf("%s: quoted string doesn't match: expected %v, got %v", name, test.expected, quoted)
+		}
+	}
+}
@@ -0,0 +1,924 @@
+// Package graphsql provides a [graph.Store] implementation on top of
+// database/sql, which allows graphs to be persisted in relational databases
+// such as PostgreSQL, MySQL, or SQLite.
+//
+// The store works with two tables, one for vertices and one for edges. They
+// can be created using [SetupTables] and look as follows:
+//
+//	CREATE TABLE IF NOT EXISTS vertices (
+//		hash       VARCHAR(255) NOT NULL PRIMARY KEY,
+//		value      TEXT         NOT NULL,
+//		weight     INTEGER      NOT NULL,
+//		attributes TEXT         NOT NULL
+//	);
+//
+//	CREATE TABLE IF NOT EXISTS edges (
+//		source_hash VARCHAR(255) NOT NULL,
+//		target_hash VARCHAR(255) NOT NULL,
+//		weight      INTEGER      NOT NULL,
+//		attributes  TEXT         NOT NULL,
+//		data        TEXT         NOT NULL,
+//		PRIMARY KEY (source_hash, target_hash),
+//		FOREIGN KEY (source_hash) REFERENCES vertices (hash),
+//		FOREIGN KEY (target_hash) REFERENCES vertices (hash)
+//	);
+//
+// The foreign keys prevent edges from referring to vertices that don't exist,
+// even if edges are added using [Store.AddEdges]. Note that SQLite only
+// enforces foreign keys if the foreign_keys pragma is enabled. Tables that have
+// been created by an earlier version of SetupTables don't have foreign keys.
+//
+// Vertex hashes of type string are stored as they are, all other hash types
+// are stored as JSON. Vertex values, attributes, and edge data are stored as
+// JSON as well. Note that edge data of an interface type will be decoded into
+// the corresponding generic JSON type, e.g. map[string]interface{}.
+//
+// graphsql doesn't ship any database driver. Import the driver of your choice
+// and pass the opened database to [New]:
+//
+//	db, _ := sql.Open("postgres", "postgres://localhost/graphs")
+//
+//	_ = graphsql.SetupTables(db, graphsql.Postgres())
+//	store, _ := graphsql.New[string, string](db, graphsql.Postgres())
+//
+//	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+package graphsql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/internal/storage"
+)
+
+const schemaTemplate = `
+CREATE TABLE IF NOT EXISTS %[1]s (
+	hash       VARCHAR(255) NOT NULL PRIMARY KEY,
+	value      TEXT         NOT NULL,
+	weight     INTEGER      NOT NULL,
+	attributes TEXT         NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS %[2]s (
+	source_hash VARCHAR(255) NOT NULL,
+	target_hash VARCHAR(255) NOT NULL,
+	weight      INTEGER      NOT NULL,
+	attributes  TEXT         NOT NULL,
+	data        TEXT         NOT NULL,
+	PRIMARY KEY (source_hash, target_hash),
+	FOREIGN KEY (source_hash) REFERENCES %[1]s (hash),
+	FOREIGN KEY (target_hash) REFERENCES %[1]s (hash)
+);
+`
+
+type config struct {
+	numberedPlaceholders bool
+	backslashEscapes     bool
+	verticesTable        string
+	edgesTable           string
+	dumpSchema           bool
+}
+
+func newConfig(options ...func(*config)) config {
+	c := config{
+		backslashEscapes: true,
+		verticesTable:    "vertices",
+		edgesTable:       "edges",
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// bind replaces the ? placeholders in the given query with numbered $n
+// placeholders if the configured dialect requires it.
+func (c config) bind(query string) string {
+	query = fmt.Sprintf(query, c.verticesTable, c.edgesTable)
+
+	if !c.numberedPlaceholders {
+		return query
+	}
+
+	var builder strings.Builder
+	n := 0
+
+	for _, r := range query {
+		if r == '?' {
+			n++
+			builder.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+// Postgres is a functional option for [New] and [SetupTables] that configures
+// the store for PostgreSQL, which uses numbered placeholders like $1.
+func Postgres() func(*config) {
+	return func(c *config) {
+		c.numberedPlaceholders = true
+		c.backslashEscapes = false
+	}
+}
+
+// MySQL is a functional option for [New] and [SetupTables] that configures the
+// store for MySQL. This is the default.
+func MySQL() func(*config) {
+	return func(c *config) {
+		c.numberedPlaceholders = false
+		c.backslashEscapes = true
+	}
+}
+
+// SQLite is a functional option for [New] and [SetupTables] that configures
+// the store for SQLite.
+func SQLite() func(*config) {
+	return func(c *config) {
+		c.numberedPlaceholders = false
+		c.backslashEscapes = false
+	}
+}
+
+// VerticesTable is a functional option for [New] and [SetupTables] that sets
+// the name of the vertices table, which is "vertices" by default. The name is
+// used in queries as it is and must not originate from untrusted input.
+func VerticesTable(name string) func(*config) {
+	return func(c *config) {
+		c.verticesTable = name
+	}
+}
+
+// EdgesTable is a functional option for [New] and [SetupTables] that sets the
+// name of the edges table, which is "edges" by default. The name is used in
+// queries as it is and must not originate from untrusted input.
+func EdgesTable(name string) func(*config) {
+	return func(c *config) {
+		c.edgesTable = name
+	}
+}
+
+// SetupTables creates the vertices and edges tables documented in the package
+// description if they don't exist yet. It accepts the same options as [New].
+func SetupTables(db *sql.DB, options ...func(*config)) error {
+	c := newConfig(options...)
+	schema := fmt.Sprintf(schemaTemplate, c.verticesTable, c.edgesTable)
+
+	for _, statement := range strings.Split(schema, ";") {
+		if strings.TrimSpace(statement) == "" {
+			continue
+		}
+		if _, err := db.Exec(statement); err != nil {
+			return fmt.Errorf("failed to create table: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Vertex is a vertex along with its hash and properties, as accepted by the
+// [Store.AddVertices] method for bulk loading vertices.
+type Vertex[K comparable, T any] struct {
+	Hash       K
+	Value      T
+	Properties graph.VertexProperties
+}
+
+// Store is a [graph.Store] implementation backed by an SQL database. All
+// queries are prepared once when creating the store using [New].
+type Store[K comparable, T any] struct {
+	db *sql.DB
+
+	insertVertex     *sql.Stmt
+	selectVertex     *sql.Stmt
+	deleteVertex     *sql.Stmt
+	listVertices     *sql.Stmt
+	firstVertices    *sql.Stmt
+	nextVertices     *sql.Stmt
+	countVertices    *sql.Stmt
+	countVertexEdges *sql.Stmt
+	vertexEdges      *sql.Stmt
+	insertEdge       *sql.Stmt
+	updateEdge       *sql.Stmt
+	deleteEdge       *sql.Stmt
+	deleteEdges      *sql.Stmt
+	selectEdge       *sql.Stmt
+	listEdges        *sql.Stmt
+	firstEdges       *sql.Stmt
+	nextEdges        *sql.Stmt
+	countEdges       *sql.Stmt
+}
+
+// New creates a new store that uses the given database. The vertices and edges
+// tables need to exist already, see [SetupTables].
+//
+// By default, the store uses ? as placeholder, which works for MySQL and SQLite.
+// For PostgreSQL, pass the [Postgres] functional option.
+func New[K comparable, T any](db *sql.DB, options ...func(*config)) (*Store[K, T], error) {
+	c := newConfig(options...)
+	s := &Store[K, T]{db: db}
+
+	statements := []struct {
+		stmt  **sql.Stmt
+		query string
+	}{
+		{&s.insertVertex, `INSERT INTO %[1]s (hash, value, weight, attributes) VALUES (?, ?, ?, ?)`},
+		{&s.selectVertex, `SELECT value, weight, attributes FROM %[1]s WHERE hash = ?`},
+		{&s.deleteVertex, `DELETE FROM %[1]s WHERE hash = ?`},
+		{&s.listVertices, `SELECT hash FROM %[1]s`},
+		{&s.firstVertices, `SELECT hash FROM %[1]s ORDER BY hash LIMIT ?`},
+		{&s.nextVertices, `SELECT hash FROM %[1]s WHERE hash > ? ORDER BY hash LIMIT ?`},
+		{&s.countVertices, `SELECT COUNT(*) FROM %[1]s`},
+		{&s.countVertexEdges, `SELECT COUNT(*) FROM %[2]s WHERE source_hash = ? OR target_hash = ?`},
+		{&s.vertexEdges, `SELECT source_hash, target_hash, weight, attributes, data FROM %[2]s WHERE source_hash = ? OR target_hash = ?`},
+		{&s.insertEdge, `INSERT INTO %[2]s (source_hash, target_hash, weight, attributes, data) VALUES (?, ?, ?, ?, ?)`},
+		{&s.updateEdge, `UPDATE %[2]s SET weight = ?, attributes = ?, data = ? WHERE source_hash = ? AND target_hash = ?`},
+		{&s.deleteEdge, `DELETE FROM %[2]s WHERE source_hash = ? AND target_hash = ?`},
+		{&s.deleteEdges, `DELETE FROM %[2]s WHERE source_hash = ? OR target_hash = ?`},
+		{&s.selectEdge, `SELECT weight, attributes, data FROM %[2]s WHERE source_hash = ? AND target_hash = ?`},
+		{&s.listEdges, `SELECT source_hash, target_hash, weight, attributes, data FROM %[2]s`},
+		{&s.firstEdges, `SELECT source_hash, target_hash, weight, attributes, data FROM %[2]s ORDER BY source_hash, target_hash LIMIT ?`},
+		{&s.nextEdges, `SELECT source_hash, target_hash, weight, attributes, data FROM %[2]s WHERE source_hash > ? OR (source_hash = ? AND target_hash > ?) ORDER BY source_hash, target_hash LIMIT ?`},
+		{&s.countEdges, `SELECT COUNT(*) FROM %[2]s`},
+	}
+
+	for _, statement := range statements {
+		stmt, err := db.Prepare(c.bind(statement.query))
+		if err != nil {
+			_ = s.Close()
+			return nil, fmt.Errorf("failed to prepare statement %q: %w", statement.query, err)
+		}
+		*statement.stmt = stmt
+	}
+
+	return s, nil
+}
+
+// Close closes all prepared statements of the store. It doesn't close the
+// underlying database.
+func (s *Store[K, T]) Close() error {
+	var firstErr error
+
+	for _, stmt := range []*sql.Stmt{
+		s.insertVertex, s.selectVertex, s.deleteVertex, s.listVertices,
+		s.firstVertices, s.nextVertices, s.countVertices, s.countVertexEdges,
+		s.vertexEdges, s.insertEdge, s.updateEdge, s.deleteEdge, s.deleteEdges,
+		s.selectEdge, s.listEdges,
+		s.firstEdges, s.nextEdges, s.countEdges,
+	} {
+		if stmt == nil {
+			continue
+		}
+		if err := stmt.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (s *Store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	if _, _, err := s.Vertex(hash); err == nil {
+		return graph.ErrVertexAlreadyExists
+	} else if !errors.Is(err, graph.ErrVertexNotFound) {
+		return err
+	}
+
+	return s.addVertex(s.insertVertex, hash, value, properties)
+}
+
+// AddVertices adds all given vertices within a single transaction, which is
+// considerably faster than adding them one by one. Unlike AddVertex, it doesn't
+// check whether a vertex already exists and relies on the primary key instead,
+// so the database error will be returned for existing vertices. If an error
+// occurs, none of the vertices will be added.
+func (s *Store[K, T]) AddVertices(vertices []Vertex[K, T]) error {
+	return s.inTransaction(func(tx *sql.Tx) error {
+		stmt := tx.Stmt(s.insertVertex)
+		defer stmt.Close()
+
+		for _, vertex := range vertices {
+			if err := s.addVertex(stmt, vertex.Hash, vertex.Value, vertex.Properties); err != nil {
+				return fmt.Errorf("failed to add vertex %v: %w", vertex.Hash, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *Store[K, T]) addVertex(stmt *sql.Stmt, hash K, value T, properties graph.VertexProperties) error {
+	encodedHash, err := storage.EncodeHash(hash)
+	if err != nil {
+		return err
+	}
+
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode vertex value: %w", err)
+	}
+
+	attributes, err := encodeAttributes(properties.Attributes)
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.Exec(encodedHash, string(encodedValue), properties.Weight, attributes); err != nil {
+		return fmt.Errorf("failed to insert vertex: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	var (
+		value      T
+		properties graph.VertexProperties
+		rawValue   string
+		attributes string
+	)
+
+	encodedHash, err := storage.EncodeHash(hash)
+	if err != nil {
+		return value, properties, err
+	}
+
+	err = s.selectVertex.QueryRow(encodedHash).Scan(&rawValue, &properties.Weight, &attributes)
+	if errors.Is(err, sql.ErrNoRows) {
+		return value, properties, graph.ErrVertexNotFound
+	}
+	if err != nil {
+		return value, properties, fmt.Errorf("failed to query vertex: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(rawValue), &value); err != nil {
+		return value, properties, fmt.Errorf("failed to decode vertex value: %w", err)
+	}
+
+	if properties.Attributes, err = decodeAttributes(attributes); err != nil {
+		return value, properties, err
+	}
+
+	return value, properties, nil
+}
+
+func (s *Store[K, T]) RemoveVertex(hash K) error {
+	if _, _, err := s.Vertex(hash); err != nil {
+		return err
+	}
+
+	encodedHash, err := storage.EncodeHash(hash)
+	if err != nil {
+		return err
+	}
+
+	var edgeCount int
+
+	if err := s.countVertexEdges.QueryRow(encodedHash, encodedHash).Scan(&edgeCount); err != nil {
+		return fmt.Errorf("failed to count edges: %w", err)
+	}
+
+	if edgeCount > 0 {
+		return graph.ErrVertexHasEdges
+	}
+
+	if _, err := s.deleteVertex.Exec(encodedHash); err != nil {
+		return fmt.Errorf("failed to delete vertex: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveVertexWithEdges removes the vertex with the given hash and all of its
+// edges within a single transaction and returns the removed edges. It
+// implements [graph.CascadeStore], so graph.RemoveVertexWithEdges uses it.
+func (s *Store[K, T]) RemoveVertexWithEdges(hash K) ([]graph.Edge[K], error) {
+	encodedHash, err := storage.EncodeHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []graph.Edge[K]
+
+	err = s.inTransaction(func(tx *sql.Tx) error {
+		exists, err := rowExists(tx.Stmt(s.selectVertex), encodedHash)
+		if err != nil {
+			return fmt.Errorf("failed to query vertex: %w", err)
+		}
+		if !exists {
+			return graph.ErrVertexNotFound
+		}
+
+		rows, err := tx.Stmt(s.vertexEdges).Query(encodedHash, encodedHash)
+		if err != nil {
+			return fmt.Errorf("failed to query edges: %w", err)
+		}
+
+		if removed, err = scanEdges[K](rows); err != nil {
+			return err
+		}
+
+		if _, err := tx.Stmt(s.deleteEdges).Exec(encodedHash, encodedHash); err != nil {
+			return fmt.Errorf("failed to delete edges: %w", err)
+		}
+
+		if _, err := tx.Stmt(s.deleteVertex).Exec(encodedHash); err != nil {
+			return fmt.Errorf("failed to delete vertex: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return removed, nil
+}
+
+func (s *Store[K, T]) ListVertices() ([]K, error) {
+	rows, err := s.listVertices.Query()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vertices: %w", err)
+	}
+
+	return scanVertices[K](rows)
+}
+
+// ListVerticesPage returns at most limit vertices that follow the given cursor,
+// along with the cursor for the next page. It implements the optional
+// [graph.PagingStore] interface.
+//
+// The vertices are ordered by their encoded hashes as stored in the database.
+// For hashes that aren't strings, this is the order of their JSON encoding, so
+// a vertex with the hash 10 comes before a vertex with the hash 9.
+func (s *Store[K, T]) ListVerticesPage(cursor string, limit int) ([]K, string, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	// One additional row is queried to find out whether there is a next page.
+	if cursor == "" {
+		rows, err = s.firstVertices.Query(limit + 1)
+	} else {
+		var after string
+		if err := decodeCursor(cursor, &after); err != nil {
+			return nil, "", err
+		}
+		rows, err = s.nextVertices.Query(after, limit+1)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query vertices: %w", err)
+	}
+
+	hashes, err := scanVertices[K](rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(hashes) <= limit {
+		return hashes, "", nil
+	}
+
+	hashes = hashes[:limit]
+
+	last, err := storage.EncodeHash(hashes[limit-1])
+	if err != nil {
+		return nil, "", err
+	}
+
+	next, err := encodeCursor(last)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return hashes, next, nil
+}
+
+func scanVertices[K comparable](rows *sql.Rows) ([]K, error) {
+	defer rows.Close()
+
+	hashes := make([]K, 0)
+
+	for rows.Next() {
+		var encodedHash string
+		if err := rows.Scan(&encodedHash); err != nil {
+			return nil, fmt.Errorf("failed to scan vertex: %w", err)
+		}
+
+		hash, err := storage.DecodeHash[K](encodedHash)
+		if err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, rows.Err()
+}
+
+func (s *Store[K, T]) VertexCount() (int, error) {
+	var count int
+
+	if err := s.countVertices.QueryRow().Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count vertices: %w", err)
+	}
+
+	return count, nil
+}
+
+// AddEdge adds the given edge. Checking whether both vertices exist and whether
+// the edge exists already happens in the same transaction as the insert.
+func (s *Store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	source, err := storage.EncodeHash(sourceHash)
+	if err != nil {
+		return err
+	}
+
+	target, err := storage.EncodeHash(targetHash)
+	if err != nil {
+		return err
+	}
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		selectVertex := tx.Stmt(s.selectVertex)
+		defer selectVertex.Close()
+
+		if ok, err := rowExists(selectVertex, source); err != nil {
+			return fmt.Errorf("failed to get vertex %v: %w", sourceHash, err)
+		} else if !ok {
+			return fmt.Errorf("source vertex %v: %w", sourceHash, graph.ErrVertexNotFound)
+		}
+
+		if ok, err := rowExists(selectVertex, target); err != nil {
+			return fmt.Errorf("failed to get vertex %v: %w", targetHash, err)
+		} else if !ok {
+			return fmt.Errorf("target vertex %v: %w", targetHash, graph.ErrVertexNotFound)
+		}
+
+		selectEdge := tx.Stmt(s.selectEdge)
+		defer selectEdge.Close()
+
+		if ok, err := rowExists(selectEdge, source, target); err != nil {
+			return fmt.Errorf("failed to get edge (%v, %v): %w", sourceHash, targetHash, err)
+		} else if ok {
+			return graph.ErrEdgeAlreadyExists
+		}
+
+		insertEdge := tx.Stmt(s.insertEdge)
+		defer insertEdge.Close()
+
+		return s.addEdge(insertEdge, sourceHash, targetHash, edge)
+	})
+}
+
+// AddEdges adds all given edges within a single transaction, which is
+// considerably faster than adding them one by one. Unlike AddEdge, it doesn't
+// check whether the vertices exist or whether an edge already exists. If an
+// error occurs, none of the edges will be added.
+//
+// AddEdges implements [graph.BulkStore], so Graph.AddEdgesFrom uses it as well.
+func (s *Store[K, T]) AddEdges(edges []graph.Edge[K]) error {
+	return s.inTransaction(func(tx *sql.Tx) error {
+		stmt := tx.Stmt(s.insertEdge)
+		defer stmt.Close()
+
+		for _, edge := range edges {
+			if err := s.addEdge(stmt, edge.Source, edge.Target, edge); err != nil {
+				return fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *Store[K, T]) addEdge(stmt *sql.Stmt, sourceHash, targetHash K, edge graph.Edge[K]) error {
+	source, err := storage.EncodeHash(sourceHash)
+	if err != nil {
+		return err
+	}
+
+	target, err := storage.EncodeHash(targetHash)
+	if err != nil {
+		return err
+	}
+
+	attributes, data, err := encodeEdgeProperties(edge.Properties)
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.Exec(source, target, edge.Properties.Weight, attributes, data); err != nil {
+		return fmt.Errorf("failed to insert edge: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	if _, err := s.Edge(sourceHash, targetHash); err != nil {
+		return err
+	}
+
+	source, err := storage.EncodeHash(sourceHash)
+	if err != nil {
+		return err
+	}
+
+	target, err := storage.EncodeHash(targetHash)
+	if err != nil {
+		return err
+	}
+
+	attributes, data, err := encodeEdgeProperties(edge.Properties)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.updateEdge.Exec(edge.Properties.Weight, attributes, data, source, target); err != nil {
+		return fmt.Errorf("failed to update edge: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	source, err := storage.EncodeHash(sourceHash)
+	if err != nil {
+		return err
+	}
+
+	target, err := storage.EncodeHash(targetHash)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.deleteEdge.Exec(source, target); err != nil {
+		return fmt.Errorf("failed to delete edge: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	source, err := storage.EncodeHash(sourceHash)
+	if err != nil {
+		return graph.Edge[K]{}, err
+	}
+
+	target, err := storage.EncodeHash(targetHash)
+	if err != nil {
+		return graph.Edge[K]{}, err
+	}
+
+	var (
+		weight     int
+		attributes string
+		data       string
+	)
+
+	err = s.selectEdge.QueryRow(source, target).Scan(&weight, &attributes, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return graph.Edge[K]{}, graph.ErrEdgeNotFound
+	}
+	if err != nil {
+		return graph.Edge[K]{}, fmt.Errorf("failed to query edge: %w", err)
+	}
+
+	properties, err := decodeEdgeProperties(weight, attributes, data)
+	if err != nil {
+		return graph.Edge[K]{}, err
+	}
+
+	return graph.Edge[K]{
+		Source:     sourceHash,
+		Target:     targetHash,
+		Properties: properties,
+	}, nil
+}
+
+func (s *Store[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	rows, err := s.listEdges.Query()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query edges: %w", err)
+	}
+
+	return scanEdges[K](rows)
+}
+
+// ListEdgesPage returns at most limit edges that follow the given cursor, along
+// with the cursor for the next page. It implements the optional
+// [graph.PagingStore] interface.
+//
+// The edges are ordered by their encoded source and target hashes, using the
+// same order as [Store.ListVerticesPage].
+func (s *Store[K, T]) ListEdgesPage(cursor string, limit int) ([]graph.Edge[K], string, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if cursor == "" {
+		rows, err = s.firstEdges.Query(limit + 1)
+	} else {
+		var after [2]string
+		if err := decodeCursor(cursor, &after); err != nil {
+			return nil, "", err
+		}
+		rows, err = s.nextEdges.Query(after[0], after[0], after[1], limit+1)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query edges: %w", err)
+	}
+
+	edges, err := scanEdges[K](rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(edges) <= limit {
+		return edges, "", nil
+	}
+
+	edges = edges[:limit]
+
+	source, err := storage.EncodeHash(edges[limit-1].Source)
+	if err != nil {
+		return nil, "", err
+	}
+
+	target, err := storage.EncodeHash(edges[limit-1].Target)
+	if err != nil {
+		return nil, "", err
+	}
+
+	next, err := encodeCursor([2]string{source, target})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return edges, next, nil
+}
+
+func scanEdges[K comparable](rows *sql.Rows) ([]graph.Edge[K], error) {
+	defer rows.Close()
+
+	edges := make([]graph.Edge[K], 0)
+
+	for rows.Next() {
+		var (
+			source     string
+			target     string
+			weight     int
+			attributes string
+			data       string
+		)
+
+		if err := rows.Scan(&source, &target, &weight, &attributes, &data); err != nil {
+			return nil, fmt.Errorf("failed to scan edge: %w", err)
+		}
+
+		sourceHash, err := storage.DecodeHash[K](source)
+		if err != nil {
+			return nil, err
+		}
+
+		targetHash, err := storage.DecodeHash[K](target)
+		if err != nil {
+			return nil, err
+		}
+
+		properties, err := decodeEdgeProperties(weight, attributes, data)
+		if err != nil {
+			return nil, err
+		}
+
+		edges = append(edges, graph.Edge[K]{
+			Source:     sourceHash,
+			Target:     targetHash,
+			Properties: properties,
+		})
+	}
+
+	return edges, rows.Err()
+}
+
+func (s *Store[K, T]) EdgeCount() (int, error) {
+	var count int
+
+	if err := s.countEdges.QueryRow().Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count edges: %w", err)
+	}
+
+	return count, nil
+}
+
+// rowExists reports whether the given query statement returns at least one row.
+func rowExists(stmt *sql.Stmt, args ...any) (bool, error) {
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	return rows.Next(), rows.Err()
+}
+
+func (s *Store[K, T]) inTransaction(f func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// encodeCursor encodes the encoded hashes of the last item of a page as cursor.
+// The cursor is JSON-encoded so that an empty hash can be told apart from the
+// empty cursor that requests the first page.
+func encodeCursor(after any) (string, error) {
+	cursor, err := json.Marshal(after)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	return string(cursor), nil
+}
+
+func decodeCursor(cursor string, after any) error {
+	if err := json.Unmarshal([]byte(cursor), after); err != nil {
+		return fmt.Errorf("%w: %q", graph.ErrInvalidCursor, cursor)
+	}
+
+	return nil
+}
+
+func encodeAttributes(attributes map[string]string) (string, error) {
+	encoded, err := json.Marshal(storage.Attributes(attributes))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode attributes: %w", err)
+	}
+
+	return string(encoded), nil
+}
+
+func decodeAttributes(encoded string) (map[string]string, error) {
+	attributes := make(map[string]string)
+
+	if err := json.Unmarshal([]byte(encoded), &attributes); err != nil {
+		return nil, fmt.Errorf("failed to decode attributes: %w", err)
+	}
+
+	return attributes, nil
+}
+
+func encodeEdgeProperties(properties graph.EdgeProperties) (string, string, error) {
+	attributes, err := encodeAttributes(properties.Attributes)
+	if err != nil {
+		return "", "", err
+	}
+
+	data, err := json.Marshal(properties.Data)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode edge data: %w", err)
+	}
+
+	return attributes, string(data), nil
+}
+
+func decodeEdgeProperties(weight int, attributes, data string) (graph.EdgeProperties, error) {
+	properties := graph.EdgeProperties{
+		Weight: weight,
+	}
+
+	var err error
+
+	if properties.Attributes, err = decodeAttributes(attributes); err != nil {
+		return properties, err
+	}
+
+	if err := json.Unmarshal([]byte(data), &properties.Data); err != nil {
+		return properties, fmt.Errorf("failed to decode edge data: %w", err)
+	}
+
+	return properties, nil
+}
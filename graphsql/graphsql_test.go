@@ -0,0 +1,406 @@
+package graphsql
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+	_ "modernc.org/sqlite"
+)
+
+func newTestStore(t *testing.T, options ...func(*config)) *Store[string, string] {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	// Each connection to an in-memory database gets its own database, so the
+	// pool is limited to a single connection.
+	db.SetMaxOpenConns(1)
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		t.Fatalf("failed to enable foreign keys: %v", err)
+	}
+
+	if err := SetupTables(db, options...); err != nil {
+		t.Fatalf("failed to set up tables: %v", err)
+	}
+
+	store, err := New[string, string](db, options...)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return store
+}
+
+func TestStore_Vertex(t *testing.T) {
+	store := newTestStore(t)
+
+	properties := graph.VertexProperties{
+		Weight:     4,
+		Attributes: map[string]string{"color": "red"},
+	}
+
+	if err := store.AddVertex("A", "A", properties); err != nil {
+		t.Fatalf("failed to add vertex: %v", err)
+	}
+
+	if err := store.AddVertex("A", "A", properties); !errors.Is(err, graph.ErrVertexAlreadyExists) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexAlreadyExists, err)
+	}
+
+	value, vertexProperties, err := store.Vertex("A")
+	if err != nil {
+		t.Fatalf("failed to get vertex: %v", err)
+	}
+
+	if value != "A" {
+		t.Errorf("vertex value doesn't match: expected %v, got %v", "A", value)
+	}
+
+	if vertexProperties.Weight != 4 || vertexProperties.Attributes["color"] != "red" {
+		t.Errorf("vertex properties don't match: expected %v, got %v", properties, vertexProperties)
+	}
+
+	if _, _, err := store.Vertex("B"); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	count, err := store.VertexCount()
+	if err != nil || count != 1 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v (error: %v)", 1, count, err)
+	}
+
+	hashes, err := store.ListVertices()
+	if err != nil || len(hashes) != 1 || hashes[0] != "A" {
+		t.Errorf("vertices don't match: expected %v, got %v (error: %v)", []string{"A"}, hashes, err)
+	}
+}
+
+func TestStore_RemoveVertex(t *testing.T) {
+	store := newTestStore(t)
+
+	_ = store.AddVertex("A", "A", graph.VertexProperties{})
+	_ = store.AddVertex("B", "B", graph.VertexProperties{})
+	_ = store.AddEdge("A", "B", graph.Edge[string]{Source: "A", Target: "B"})
+
+	if err := store.RemoveVertex("C"); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	if err := store.RemoveVertex("B"); !errors.Is(err, graph.ErrVertexHasEdges) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexHasEdges, err)
+	}
+
+	if err := store.RemoveEdge("A", "B"); err != nil {
+		t.Fatalf("failed to remove edge: %v", err)
+	}
+
+	if err := store.RemoveVertex("B"); err != nil {
+		t.Errorf("failed to remove vertex: %v", err)
+	}
+
+	if count, _ := store.VertexCount(); count != 1 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 1, count)
+	}
+}
+
+func TestStore_RemoveVertexWithEdges(t *testing.T) {
+	store := newTestStore(t)
+	g := graph.NewWithStore(graph.StringHash, graph.Store[string, string](store), graph.Directed())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddVertex("C")
+	_ = g.AddEdge("A", "B")
+	_ = g.AddEdge("B", "C")
+	_ = g.AddEdge("C", "A")
+
+	edges, err := graph.RemoveVertexWithEdges(g, "B")
+	if err != nil {
+		t.Fatalf("failed to remove vertex: %v", err)
+	}
+
+	if len(edges) != 2 {
+		t.Errorf("number of removed edges doesn't match: expected %v, got %v", 2, len(edges))
+	}
+
+	if count, _ := store.EdgeCount(); count != 1 {
+		t.Errorf("edge count doesn't match: expected %v, got %v", 1, count)
+	}
+
+	if count, _ := store.VertexCount(); count != 2 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 2, count)
+	}
+
+	if _, err := store.RemoveVertexWithEdges("B"); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+}
+
+func TestStore_Edge(t *testing.T) {
+	store := newTestStore(t)
+
+	_ = store.AddVertex("A", "A", graph.VertexProperties{})
+	_ = store.AddVertex("B", "B", graph.VertexProperties{})
+
+	edge := graph.Edge[string]{
+		Source: "A",
+		Target: "B",
+		Properties: graph.EdgeProperties{
+			Weight:     3,
+			Attributes: map[string]string{"label": "AB"},
+			Data:       "data",
+		},
+	}
+
+	if err := store.AddEdge("A", "C", edge); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	if err := store.AddEdge("A", "B", edge); err != nil {
+		t.Fatalf("failed to add edge: %v", err)
+	}
+
+	if err := store.AddEdge("A", "B", edge); !errors.Is(err, graph.ErrEdgeAlreadyExists) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeAlreadyExists, err)
+	}
+
+	storedEdge, err := store.Edge("A", "B")
+	if err != nil {
+		t.Fatalf("failed to get edge: %v", err)
+	}
+
+	if storedEdge.Properties.Weight != 3 || storedEdge.Properties.Attributes["label"] != "AB" || storedEdge.Properties.Data != "data" {
+		t.Errorf("edge properties don't match: expected %v, got %v", edge.Properties, storedEdge.Properties)
+	}
+
+	if _, err := store.Edge("B", "A"); !errors.Is(err, graph.ErrEdgeNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeNotFound, err)
+	}
+
+	edge.Properties.Weight = 10
+
+	if err := store.UpdateEdge("A", "B", edge); err != nil {
+		t.Fatalf("failed to update edge: %v", err)
+	}
+
+	if err := store.UpdateEdge("B", "A", edge); !errors.Is(err, graph.ErrEdgeNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeNotFound, err)
+	}
+
+	edges, err := store.ListEdges()
+	if err != nil || len(edges) != 1 || edges[0].Properties.Weight != 10 {
+		t.Errorf("edges don't match: got %v (error: %v)", edges, err)
+	}
+
+	if count, _ := store.EdgeCount(); count != 1 {
+		t.Errorf("edge count doesn't match: expected %v, got %v", 1, count)
+	}
+}
+
+func TestStore_bulk(t *testing.T) {
+	store := newTestStore(t)
+
+	vertices := []Vertex[string, string]{
+		{Hash: "A", Value: "A"},
+		{Hash: "B", Value: "B"},
+		{Hash: "C", Value: "C"},
+	}
+
+	if err := store.AddVertices(vertices); err != nil {
+		t.Fatalf("failed to add vertices: %v", err)
+	}
+
+	edges := []graph.Edge[string]{
+		{Source: "A", Target: "B"},
+		{Source: "B", Target: "C"},
+	}
+
+	if err := store.AddEdges(edges); err != nil {
+		t.Fatalf("failed to add edges: %v", err)
+	}
+
+	// Adding an existing vertex should roll back the entire transaction.
+	err := store.AddVertices([]Vertex[string, string]{
+		{Hash: "D", Value: "D"},
+		{Hash: "A", Value: "A"},
+	})
+	if err == nil {
+		t.Errorf("expected error when adding existing vertex, got none")
+	}
+
+	if count, _ := store.VertexCount(); count != 3 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 3, count)
+	}
+
+	if count, _ := store.EdgeCount(); count != 2 {
+		t.Errorf("edge count doesn't match: expected %v, got %v", 2, count)
+	}
+}
+
+func TestStore_graph(t *testing.T) {
+	store, err := func() (*Store[int, int], error) {
+		db, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			return nil, err
+		}
+		db.SetMaxOpenConns(1)
+		t.Cleanup(func() {
+			_ = db.Close()
+		})
+
+		options := []func(*config){SQLite(), VerticesTable("nodes"), EdgesTable("links")}
+
+		if err := SetupTables(db, options...); err != nil {
+			return nil, err
+		}
+		return New[int, int](db, options...)
+	}()
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	g := graph.NewWithStore(graph.IntHash, store, graph.Directed(), graph.Weighted())
+
+	for i := 1; i <= 4; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	_ = g.AddEdge(1, 2, graph.EdgeWeight(1))
+	_ = g.AddEdge(2, 4, graph.EdgeWeight(5))
+	_ = g.AddEdge(1, 3, graph.EdgeWeight(1))
+	_ = g.AddEdge(3, 4, graph.EdgeWeight(1))
+
+	path, err := graph.ShortestPath(g, 1, 4)
+	if err != nil {
+		t.Fatalf("failed to compute shortest path: %v", err)
+	}
+
+	expected := []int{1, 3, 4}
+
+	if len(path) != len(expected) {
+		t.Fatalf("path doesn't match: expected %v, got %v", expected, path)
+	}
+
+	for i := range path {
+		if path[i] != expected[i] {
+			t.Errorf("path doesn't match: expected %v, got %v", expected, path)
+		}
+	}
+}
+
+func TestStore_foreignKeys(t *testing.T) {
+	store := newTestStore(t)
+
+	_ = store.AddVertex("A", "A", graph.VertexProperties{})
+
+	// AddEdges doesn't check the vertices itself, so the foreign keys have to
+	// reject the edge.
+	if err := store.AddEdges([]graph.Edge[string]{{Source: "A", Target: "B"}}); err == nil {
+		t.Errorf("error expectancy doesn't match: expected error, got %v", err)
+	}
+
+	if count, _ := store.EdgeCount(); count != 0 {
+		t.Errorf("edge count doesn't match: expected %v, got %v", 0, count)
+	}
+}
+
+func TestStore_paging(t *testing.T) {
+	store := newTestStore(t)
+
+	for _, vertex := range []string{"C", "A", "D", "B"} {
+		_ = store.AddVertex(vertex, vertex, graph.VertexProperties{})
+	}
+
+	_ = store.AddEdge("B", "C", graph.Edge[string]{Source: "B", Target: "C"})
+	_ = store.AddEdge("A", "C", graph.Edge[string]{Source: "A", Target: "C"})
+	_ = store.AddEdge("A", "B", graph.Edge[string]{Source: "A", Target: "B"})
+
+	hashes, cursor, err := store.ListVerticesPage("", 2)
+	if err != nil {
+		t.Fatalf("failed to list vertices: %v", err)
+	}
+
+	if len(hashes) != 2 || hashes[0] != "A" || hashes[1] != "B" || cursor == "" {
+		t.Errorf("vertices don't match: expected %v, got %v (cursor: %q)", []string{"A", "B"}, hashes, cursor)
+	}
+
+	// Vertices that are removed or added before the cursor don't shift the page.
+	_ = store.RemoveVertex("D")
+	_ = store.AddVertex("AA", "AA", graph.VertexProperties{})
+
+	hashes, cursor, err = store.ListVerticesPage(cursor, 2)
+	if err != nil {
+		t.Fatalf("failed to list vertices: %v", err)
+	}
+
+	if len(hashes) != 1 || hashes[0] != "C" || cursor != "" {
+		t.Errorf("vertices don't match: expected %v, got %v (cursor: %q)", []string{"C"}, hashes, cursor)
+	}
+
+	edges, cursor, err := store.ListEdgesPage("", 1)
+	if err != nil {
+		t.Fatalf("failed to list edges: %v", err)
+	}
+
+	if len(edges) != 1 || edges[0].Source != "A" || edges[0].Target != "B" {
+		t.Errorf("edges don't match: expected (A, B), got %v", edges)
+	}
+
+	edges, cursor, err = store.ListEdgesPage(cursor, 5)
+	if err != nil {
+		t.Fatalf("failed to list edges: %v", err)
+	}
+
+	if len(edges) != 2 || edges[0].Target != "C" || edges[1].Source != "B" || cursor != "" {
+		t.Errorf("edges don't match: expected (A, C) and (B, C), got %v", edges)
+	}
+
+	if _, _, err := store.ListEdgesPage("A", 5); !errors.Is(err, graph.ErrInvalidCursor) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrInvalidCursor, err)
+	}
+
+	g := graph.NewWithStore(graph.StringHash, graph.Store[string, string](store), graph.Directed())
+
+	if hashes, _, _ := graph.VerticesPage(g, "", 10); len(hashes) != 4 || hashes[1] != "AA" {
+		t.Errorf("vertices don't match: expected %v, got %v", []string{"A", "AA", "B", "C"}, hashes)
+	}
+}
+
+func TestConfig_bind(t *testing.T) {
+	tests := map[string]struct {
+		options  []func(*config)
+		query    string
+		expected string
+	}{
+		"mysql": {
+			options:  []func(*config){MySQL()},
+			query:    "SELECT * FROM %[1]s WHERE a = ? AND b = ?",
+			expected: "SELECT * FROM vertices WHERE a = ? AND b = ?",
+		},
+		"postgres": {
+			options:  []func(*config){Postgres()},
+			query:    "SELECT * FROM %[2]s WHERE a = ? AND b = ?",
+			expected: "SELECT * FROM edges WHERE a = $1 AND b = $2",
+		},
+	}
+
+	for name, test := range tests {
+		c := newConfig(test.options...)
+
+		if query := c.bind(test.query); query != test.expected {
+			t.Errorf("%s: query doesn't match: expected %v, got %v", name, test.expected, query)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Package graphtest provides helpers for testing code that builds or transforms
+// graphs. The assertions compare graphs structurally and report the
+// differences in a readable form instead of a single "graphs don't match":
+//
+//	func TestTransitiveReduction(t *testing.T) {
+//		g := buildGraph()
+//		want := buildExpectedGraph()
+//
+//		got, _ := graph.TransitiveReduction(g)
+//
+//		graphtest.AssertSameGraph(t, want, got)
+//	}
+//
+// The assertions report failures using t.Errorf, so that a test continues and
+// reports all failed assertions. Each assertion returns whether it succeeded.
+//
+// Graphs used as fixtures can be built from a compact description using
+// [Parse], e.g. graphtest.Parse("A->B->C; B->D[w=3]").
+package graphtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+// AssertSameGraph checks that both graphs have the same traits, vertices, and
+// edges, including the values of the vertices and the properties of vertices
+// and edges. If they differ, the differences are reported as described in
+// [Diff].
+func AssertSameGraph[K comparable, T any](t testing.TB, want, got graph.Graph[K, T]) bool {
+	t.Helper()
+
+	diff, err := Diff(want, got)
+	if err != nil {
+		t.Errorf("failed to compare graphs: %v", err)
+		return false
+	}
+
+	if diff != "" {
+		t.Errorf("graphs differ (-want +got):\n%s", diff)
+		return false
+	}
+
+	return true
+}
+
+// Diff compares both graphs and returns their differences, one per line, or an
+// empty string if the graphs are the same. Lines starting with "-" describe
+// vertices or edges that only exist in want, and lines starting with "+"
+// describe those that only exist in got. Lines starting with "~" describe
+// vertices or edges that exist in both graphs but differ in their values or
+// properties:
+//
+//	~ traits: want {IsDirected:true ...}, got {IsDirected:false ...}
+//	- vertex 3
+//	+ edge (1, 4)
+//	~ edge (1, 2): weight: want 2, got 3
+//
+// The lines are sorted by the vertex hashes, so that the diff is deterministic.
+func Diff[K comparable, T any](want, got graph.Graph[K, T]) (string, error) {
+	var lines []string
+
+	if *want.Traits() != *got.Traits() {
+		lines = append(lines, fmt.Sprintf("~ traits: want %+v, got %+v", *want.Traits(), *got.Traits()))
+	}
+
+	vertexLines, err := diffVertices(want, got)
+	if err != nil {
+		return "", err
+	}
+
+	edgeLines, err := diffEdges(want, got)
+	if err != nil {
+		return "", err
+	}
+
+	lines = append(lines, vertexLines...)
+	lines = append(lines, edgeLines...)
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func diffVertices[K comparable, T any](want, got graph.Graph[K, T]) ([]string, error) {
+	wantHashes, err := graph.SortedVertices(want, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vertices of want: %w", err)
+	}
+
+	gotHashes, err := graph.SortedVertices(got, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vertices of got: %w", err)
+	}
+
+	gotSet := make(map[K]struct{}, len(gotHashes))
+	for _, hash := range gotHashes {
+		gotSet[hash] = struct{}{}
+	}
+
+	wantSet := make(map[K]struct{}, len(wantHashes))
+	lines := make([]string, 0)
+
+	for _, hash := range wantHashes {
+		wantSet[hash] = struct{}{}
+
+		if _, ok := gotSet[hash]; !ok {
+			lines = append(lines, fmt.Sprintf("- vertex %v", hash))
+			continue
+		}
+
+		wantValue, wantProperties, err := want.VertexWithProperties(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vertex %v of want: %w", hash, err)
+		}
+
+		gotValue, gotProperties, err := got.VertexWithProperties(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vertex %v of got: %w", hash, err)
+		}
+
+		subject := fmt.Sprintf("vertex %v", hash)
+
+		if !reflect.DeepEqual(wantValue, gotValue) {
+			lines = append(lines, fmt.Sprintf("~ %s: value: want %v, got %v", subject, wantValue, gotValue))
+		}
+		if wantProperties.Weight != gotProperties.Weight {
+			lines = append(lines, fmt.Sprintf("~ %s: weight: want %v, got %v", subject, wantProperties.Weight, gotProperties.Weight))
+		}
+		if !sameAttributes(wantProperties.Attributes, gotProperties.Attributes) {
+			lines = append(lines, fmt.Sprintf("~ %s: attributes: want %v, got %v", subject, wantProperties.Attributes, gotProperties.Attributes))
+		}
+		if !sameWeights(wantProperties.Weights, gotProperties.Weights) {
+			lines = append(lines, fmt.Sprintf("~ %s: weights: want %v, got %v", subject, wantProperties.Weights, gotProperties.Weights))
+		}
+	}
+
+	for _, hash := range gotHashes {
+		if _, ok := wantSet[hash]; !ok {
+			lines = append(lines, fmt.Sprintf("+ vertex %v", hash))
+		}
+	}
+
+	return lines, nil
+}
+
+func diffEdges[K comparable, T any](want, got graph.Graph[K, T]) ([]string, error) {
+	wantEdges, err := graph.SortedEdges(want, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges of want: %w", err)
+	}
+
+	gotEdges, err := graph.SortedEdges(got, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges of got: %w", err)
+	}
+
+	gotByEndpoints := make(map[endpoints[K]]graph.Edge[K], len(gotEdges))
+	for _, edge := range gotEdges {
+		gotByEndpoints[endpoints[K]{edge.Source, edge.Target}] = edge
+	}
+
+	wantByEndpoints := make(map[endpoints[K]]graph.Edge[K], len(wantEdges))
+	lines := make([]string, 0)
+
+	for _, wantEdge := range wantEdges {
+		key := endpoints[K]{wantEdge.Source, wantEdge.Target}
+		wantByEndpoints[key] = wantEdge
+
+		gotEdge, ok := gotByEndpoints[key]
+		if !ok {
+			lines = append(lines, fmt.Sprintf("- edge (%v, %v)", wantEdge.Source, wantEdge.Target))
+			continue
+		}
+
+		subject := fmt.Sprintf("edge (%v, %v)", wantEdge.Source, wantEdge.Target)
+		wantProperties, gotProperties := wantEdge.Properties, gotEdge.Properties
+
+		if wantProperties.Weight != gotProperties.Weight {
+			lines = append(lines, fmt.Sprintf("~ %s: weight: want %v, got %v", subject, wantProperties.Weight, gotProperties.Weight))
+		}
+		if !sameAttributes(wantProperties.Attributes, gotProperties.Attributes) {
+			lines = append(lines, fmt.Sprintf("~ %s: attributes: want %v, got %v", subject, wantProperties.Attributes, gotProperties.Attributes))
+		}
+		if !reflect.DeepEqual(wantProperties.Data, gotProperties.Data) {
+			lines = append(lines, fmt.Sprintf("~ %s: data: want %v, got %v", subject, wantProperties.Data, gotProperties.Data))
+		}
+	}
+
+	for _, gotEdge := range gotEdges {
+		if _, ok := wantByEndpoints[endpoints[K]{gotEdge.Source, gotEdge.Target}]; !ok {
+			lines = append(lines, fmt.Sprintf("+ edge (%v, %v)", gotEdge.Source, gotEdge.Target))
+		}
+	}
+
+	return lines, nil
+}
+
+// AssertPath checks that the given path exists in the graph, i.e. that all of
+// its vertices exist and that each vertex is joined with the next one by an
+// edge. For undirected graphs, edges may be traversed in both directions. An
+// empty path is considered a failure.
+func AssertPath[K comparable, T any](t testing.TB, g graph.Graph[K, T], path []K) bool {
+	t.Helper()
+
+	if len(path) == 0 {
+		t.Errorf("path is empty")
+		return false
+	}
+
+	ok := true
+
+	for i, hash := range path {
+		if _, err := g.Vertex(hash); err != nil {
+			t.Errorf("path %v: vertex %v at position %d: %v", path, hash, i, err)
+			ok = false
+		}
+	}
+
+	if !ok {
+		return false
+	}
+
+	for i := 1; i < len(path); i++ {
+		if _, err := g.Edge(path[i-1], path[i]); err != nil {
+			t.Errorf("path %v: hop %d from %v to %v: %v", path, i, path[i-1], path[i], err)
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+// AssertTopologicalOrder checks that the given order is a topological order of
+// the graph: It must contain each vertex of the graph exactly once, and the
+// source of each edge must come before its target. All violations are reported.
+func AssertTopologicalOrder[K comparable, T any](t testing.TB, g graph.Graph[K, T], order []K) bool {
+	t.Helper()
+
+	if !g.Traits().IsDirected {
+		t.Errorf("topological orders are only defined for directed graphs")
+		return false
+	}
+
+	hashes, err := graph.SortedVertices(g, nil)
+	if err != nil {
+		t.Errorf("failed to get vertices: %v", err)
+		return false
+	}
+
+	edges, err := graph.SortedEdges(g, nil)
+	if err != nil {
+		t.Errorf("failed to get edges: %v", err)
+		return false
+	}
+
+	ok := true
+	positions := make(map[K]int, len(order))
+
+	for i, hash := range order {
+		if previous, exists := positions[hash]; exists {
+			t.Errorf("order %v: vertex %v appears at positions %d and %d", order, hash, previous, i)
+			ok = false
+			continue
+		}
+		positions[hash] = i
+	}
+
+	vertices := make(map[K]struct{}, len(hashes))
+
+	for _, hash := range hashes {
+		vertices[hash] = struct{}{}
+
+		if _, exists := positions[hash]; !exists {
+			t.Errorf("order %v: vertex %v is missing", order, hash)
+			ok = false
+		}
+	}
+
+	for _, hash := range order {
+		if _, exists := vertices[hash]; !exists {
+			t.Errorf("order %v: vertex %v doesn't exist in the graph", order, hash)
+			ok = false
+		}
+	}
+
+	for _, edge := range edges {
+		source, sourceExists := positions[edge.Source]
+		target, targetExists := positions[edge.Target]
+
+		if sourceExists && targetExists && source >= target {
+			t.Errorf("order %v: edge (%v, %v) points backwards from position %d to %d", order, edge.Source, edge.Target, source, target)
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+type endpoints[K comparable] struct {
+	source K
+	target K
+}
+
+// sameAttributes reports whether both attribute maps contain the same entries.
+// A nil map and an empty map are considered the same.
+func sameAttributes(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, value := range a {
+		if v, ok := b[key]; !ok || v != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameWeights(a, b map[string]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for name, weight := range a {
+		if w, ok := b[name]; !ok || w != weight {
+			return false
+		}
+	}
+
+	return true
+}
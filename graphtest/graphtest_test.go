@@ -0,0 +1,225 @@
+package graphtest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+// recorder records the failures reported by an assertion instead of failing
+// the test.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestDiff(t *testing.T) {
+	build := func(modify func(g graph.Graph[int, int])) graph.Graph[int, int] {
+		g := graph.New(graph.IntHash, graph.Directed())
+
+		for i := 1; i <= 3; i++ {
+			_ = g.AddVertex(i, graph.VertexAttribute("color", "red"))
+		}
+
+		_ = g.AddEdge(1, 2, graph.EdgeWeight(2))
+		_ = g.AddEdge(2, 3)
+
+		if modify != nil {
+			modify(g)
+		}
+
+		return g
+	}
+
+	tests := map[string]struct {
+		got          graph.Graph[int, int]
+		expectedDiff string
+	}{
+		"same graph": {
+			got:          build(nil),
+			expectedDiff: "",
+		},
+		"different traits": {
+			got: func() graph.Graph[int, int] {
+				g := graph.New(graph.IntHash)
+				_ = g.AddVerticesFrom(build(nil))
+				_ = g.AddEdgesFrom(build(nil))
+				return g
+			}(),
+			expectedDiff: "~ traits: want {IsDirected:true IsAcyclic:false IsWeighted:false IsRooted:false PreventCycles:false}, " +
+				"got {IsDirected:false IsAcyclic:false IsWeighted:false IsRooted:false PreventCycles:false}",
+		},
+		"missing and unexpected elements": {
+			got: build(func(g graph.Graph[int, int]) {
+				_ = g.RemoveEdge(2, 3)
+				_ = g.RemoveVertex(3)
+				_ = g.AddVertex(4, graph.VertexAttribute("color", "red"))
+				_ = g.AddEdge(1, 4)
+			}),
+			expectedDiff: "- vertex 3\n+ vertex 4\n- edge (2, 3)\n+ edge (1, 4)",
+		},
+		"different properties": {
+			got: build(func(g graph.Graph[int, int]) {
+				_ = g.RemoveEdge(2, 3)
+				_ = g.RemoveVertex(3)
+				_ = g.AddVertex(3, graph.VertexWeight(1))
+				_ = g.AddEdge(2, 3, graph.EdgeData("x"))
+				_ = g.UpdateEdge(1, 2, graph.EdgeWeight(3))
+			}),
+			expectedDiff: "~ vertex 3: weight: want 0, got 1\n" +
+				"~ vertex 3: attributes: want map[color:red], got map[]\n" +
+				"~ edge (1, 2): weight: want 2, got 3\n" +
+				"~ edge (2, 3): data: want <nil>, got x",
+		},
+	}
+
+	for name, test := range tests {
+		diff, err := Diff(build(nil), test.got)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if diff != test.expectedDiff {
+			t.Errorf("%s: diff doesn't match: expected\n%v\ngot\n%v", name, test.expectedDiff, diff)
+		}
+	}
+}
+
+func TestAssertSameGraph(t *testing.T) {
+	want := graph.New(graph.StringHash)
+	_ = want.AddVertex("A")
+	_ = want.AddVertex("B")
+	_ = want.AddEdge("A", "B")
+
+	same := graph.New(graph.StringHash)
+	_ = same.AddVertex("B")
+	_ = same.AddVertex("A")
+	_ = same.AddEdge("B", "A")
+
+	r := &recorder{TB: t}
+
+	if !AssertSameGraph(r, want, same) {
+		t.Errorf("assertion failed unexpectedly: %v", r.failures)
+	}
+
+	different := graph.New(graph.StringHash)
+	_ = different.AddVertex("A")
+
+	if AssertSameGraph(r, want, different) {
+		t.Errorf("assertion succeeded unexpectedly")
+	}
+
+	expected := []string{"graphs differ (-want +got):\n- vertex B\n- edge (A, B)"}
+
+	if !reflect.DeepEqual(r.failures, expected) {
+		t.Errorf("failures don't match: expected %q, got %q", expected, r.failures)
+	}
+}
+
+func TestAssertPath(t *testing.T) {
+	g := graph.New(graph.IntHash, graph.Directed())
+
+	for i := 1; i <= 3; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	_ = g.AddEdge(1, 2)
+	_ = g.AddEdge(2, 3)
+
+	tests := map[string]struct {
+		path             []int
+		expectedOK       bool
+		expectedFailures int
+	}{
+		"valid path": {
+			path:       []int{1, 2, 3},
+			expectedOK: true,
+		},
+		"single vertex": {
+			path:       []int{2},
+			expectedOK: true,
+		},
+		"empty path": {
+			path:             []int{},
+			expectedFailures: 1,
+		},
+		"missing vertex": {
+			path:             []int{1, 4},
+			expectedFailures: 1,
+		},
+		"missing edges": {
+			path:             []int{3, 2, 1},
+			expectedFailures: 2,
+		},
+	}
+
+	for name, test := range tests {
+		r := &recorder{TB: t}
+
+		if ok := AssertPath(r, g, test.path); ok != test.expectedOK {
+			t.Errorf("%s: result doesn't match: expected %v, got %v", name, test.expectedOK, ok)
+		}
+
+		if len(r.failures) != test.expectedFailures {
+			t.Errorf("%s: number of failures doesn't match: expected %v, got %v (%q)", name, test.expectedFailures, len(r.failures), r.failures)
+		}
+	}
+}
+
+func TestAssertTopologicalOrder(t *testing.T) {
+	g := graph.New(graph.IntHash, graph.Directed())
+
+	for i := 1; i <= 4; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	_ = g.AddEdge(1, 2)
+	_ = g.AddEdge(1, 3)
+	_ = g.AddEdge(3, 4)
+
+	tests := map[string]struct {
+		order            []int
+		expectedFailures []string
+	}{
+		"valid order": {
+			order: []int{1, 3, 2, 4},
+		},
+		"edge pointing backwards": {
+			order:            []int{1, 2, 4, 3},
+			expectedFailures: []string{"order [1 2 4 3]: edge (3, 4) points backwards from position 3 to 2"},
+		},
+		"missing and unknown vertex": {
+			order: []int{1, 2, 3, 5},
+			expectedFailures: []string{
+				"order [1 2 3 5]: vertex 4 is missing",
+				"order [1 2 3 5]: vertex 5 doesn't exist in the graph",
+			},
+		},
+		"duplicate vertex": {
+			order:            []int{1, 2, 3, 4, 2},
+			expectedFailures: []string{"order [1 2 3 4 2]: vertex 2 appears at positions 1 and 4"},
+		},
+	}
+
+	for name, test := range tests {
+		r := &recorder{TB: t}
+
+		ok := AssertTopologicalOrder(r, g, test.order)
+
+		if ok != (len(test.expectedFailures) == 0) {
+			t.Errorf("%s: result doesn't match: expected %v, got %v", name, len(test.expectedFailures) == 0, ok)
+		}
+
+		if len(test.expectedFailures) > 0 && !reflect.DeepEqual(r.failures, test.expectedFailures) {
+			t.Errorf("%s: failures don't match: expected %q, got %q", name, test.expectedFailures, r.failures)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package graphtest
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dominikbraun/graph"
+)
+
+// Parse builds a graph of strings from a compact text description, so that
+// test fixtures fit into a single readable line:
+//
+//	g, _ := graphtest.Parse("A->B->C; B->D[w=3]")
+//
+// The description consists of statements separated by semicolons or newlines.
+// Each statement is either a chain of vertices joined by "->" for directed
+// edges or "--" for undirected edges, or a single vertex. Vertices are created
+// as they appear, so each vertex only needs to be declared once. Whitespace
+// around vertices and edges is ignored.
+//
+// Attributes in square brackets after a vertex apply to the edge leading to
+// that vertex. In a statement consisting of a single vertex, they apply to the
+// vertex itself. Since vertices can't be updated, a vertex with attributes has
+// to be declared before it is used in an edge:
+//
+//	g, _ := graphtest.Parse(`
+//		C[color=red]
+//		A -> B[w=3, label=x] -> C
+//	`)
+//
+// The "w" attribute sets the weight of an edge or vertex, while all other
+// attributes are stored as attributes of the edge or vertex.
+//
+// The graph is directed if the description uses "->" and undirected otherwise.
+// It is weighted if any edge has a weight. Additional traits can be passed as
+// usual. Mixing "->" and "--" or declaring an edge twice is an error.
+func Parse(description string, traits ...func(*graph.Traits)) (graph.Graph[string, string], error) {
+	statements, err := parseStatements(description)
+	if err != nil {
+		return nil, err
+	}
+
+	directed, weighted := false, false
+
+	for _, statement := range statements {
+		if statement.operator == "->" {
+			directed = true
+		}
+		for _, element := range statement.elements[1:] {
+			if _, ok := element.attributes["w"]; ok {
+				weighted = true
+			}
+		}
+	}
+
+	if directed {
+		traits = append([]func(*graph.Traits){graph.Directed()}, traits...)
+	}
+	if weighted {
+		traits = append([]func(*graph.Traits){graph.Weighted()}, traits...)
+	}
+
+	g := graph.New(graph.StringHash, traits...)
+
+	for _, statement := range statements {
+		if err := statement.apply(g); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+// MustParse is like [Parse] but panics if the description is invalid. It is
+// intended for fixtures in tests.
+func MustParse(description string, traits ...func(*graph.Traits)) graph.Graph[string, string] {
+	g, err := Parse(description, traits...)
+	if err != nil {
+		panic(err)
+	}
+
+	return g
+}
+
+// statement is a single chain of vertices. The attributes of the first element
+// belong to the vertex if it's the only element, and the attributes of all other
+// elements belong to the edge leading to them.
+type statement struct {
+	line     int
+	operator string
+	elements []element
+}
+
+type element struct {
+	name       string
+	attributes map[string]string
+}
+
+func parseStatements(description string) ([]statement, error) {
+	var statements []statement
+	operator := ""
+
+	for i, line := range strings.Split(description, "\n") {
+		for _, text := range strings.Split(line, ";") {
+			text = strings.TrimSpace(text)
+			if text == "" {
+				continue
+			}
+
+			s, err := parseStatement(text)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %q: %w", i+1, text, err)
+			}
+			s.line = i + 1
+
+			if s.operator != "" {
+				if operator != "" && s.operator != operator {
+					return nil, fmt.Errorf("line %d: %q: can't mix \"->\" and \"--\"", i+1, text)
+				}
+				operator = s.operator
+			}
+
+			statements = append(statements, s)
+		}
+	}
+
+	return statements, nil
+}
+
+func parseStatement(text string) (statement, error) {
+	var s statement
+
+	directed := strings.Contains(text, "->")
+	undirected := strings.Contains(text, "--")
+
+	switch {
+	case directed && undirected:
+		return s, errors.New("can't mix \"->\" and \"--\"")
+	case directed:
+		s.operator = "->"
+	case undirected:
+		s.operator = "--"
+	}
+
+	parts := []string{text}
+	if s.operator != "" {
+		parts = strings.Split(text, s.operator)
+	}
+
+	for i, part := range parts {
+		e, err := parseElement(strings.TrimSpace(part))
+		if err != nil {
+			return s, err
+		}
+
+		if i == 0 && len(parts) > 1 && len(e.attributes) > 0 {
+			return s, fmt.Errorf("attributes of vertex %s must be declared in a separate statement", e.name)
+		}
+
+		s.elements = append(s.elements, e)
+	}
+
+	return s, nil
+}
+
+// parseElement parses a vertex name that is optionally followed by attributes,
+// such as "B[w=3, label=x]".
+func parseElement(text string) (element, error) {
+	e := element{
+		name:       text,
+		attributes: make(map[string]string),
+	}
+
+	if open := strings.Index(text, "["); open >= 0 {
+		if !strings.HasSuffix(text, "]") {
+			return e, fmt.Errorf("missing \"]\" after attributes of %s", text[:open])
+		}
+
+		e.name = strings.TrimSpace(text[:open])
+
+		for _, attribute := range strings.Split(text[open+1:len(text)-1], ",") {
+			attribute = strings.TrimSpace(attribute)
+			if attribute == "" {
+				continue
+			}
+
+			key, value, ok := strings.Cut(attribute, "=")
+			if !ok {
+				return e, fmt.Errorf("attribute %q of %s has no value", attribute, e.name)
+			}
+
+			e.attributes[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	if e.name == "" {
+		return e, errors.New("missing vertex name")
+	}
+
+	if strings.ContainsAny(e.name, "[] \t") {
+		return e, fmt.Errorf("invalid vertex name %q", e.name)
+	}
+
+	return e, nil
+}
+
+func (s statement) apply(g graph.Graph[string, string]) error {
+	if len(s.elements) == 1 {
+		options, err := vertexOptions(s.elements[0].attributes)
+		if err != nil {
+			return fmt.Errorf("line %d: vertex %s: %w", s.line, s.elements[0].name, err)
+		}
+
+		if err := addVertex(g, s.elements[0].name, options...); err != nil {
+			return fmt.Errorf("line %d: %w", s.line, err)
+		}
+
+		return nil
+	}
+
+	for i, e := range s.elements {
+		if err := addVertex(g, e.name); err != nil {
+			return fmt.Errorf("line %d: %w", s.line, err)
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		source := s.elements[i-1].name
+
+		options, err := edgeOptions(e.attributes)
+		if err != nil {
+			return fmt.Errorf("line %d: edge (%s, %s): %w", s.line, source, e.name, err)
+		}
+
+		if err := g.AddEdge(source, e.name, options...); err != nil {
+			return fmt.Errorf("line %d: failed to add edge (%s, %s): %w", s.line, source, e.name, err)
+		}
+	}
+
+	return nil
+}
+
+// addVertex adds the given vertex unless it already exists. If attributes are
+// given for an existing vertex, an error is returned since vertices can't be
+// updated.
+func addVertex(g graph.Graph[string, string], name string, options ...func(*graph.VertexProperties)) error {
+	err := g.AddVertex(name, options...)
+
+	if errors.Is(err, graph.ErrVertexAlreadyExists) && len(options) == 0 {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add vertex %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func vertexOptions(attributes map[string]string) ([]func(*graph.VertexProperties), error) {
+	var options []func(*graph.VertexProperties)
+
+	for key, value := range attributes {
+		if key != "w" {
+			options = append(options, graph.VertexAttribute(key, value))
+			continue
+		}
+
+		weight, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q", value)
+		}
+		options = append(options, graph.VertexWeight(weight))
+	}
+
+	return options, nil
+}
+
+func edgeOptions(attributes map[string]string) ([]func(*graph.EdgeProperties), error) {
+	var options []func(*graph.EdgeProperties)
+
+	for key, value := range attributes {
+		if key != "w" {
+			options = append(options, graph.EdgeAttribute(key, value))
+			continue
+		}
+
+		weight, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q", value)
+		}
+		options = append(options, graph.EdgeWeight(weight))
+	}
+
+	return options, nil
+}
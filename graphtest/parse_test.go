@@ -0,0 +1,118 @@
+package graphtest
+
+import (
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		description string
+		expected    func() graph.Graph[string, string]
+		shouldFail  bool
+	}{
+		"directed chains": {
+			description: "A->B->C; B->D[w=3]",
+			expected: func() graph.Graph[string, string] {
+				g := graph.New(graph.StringHash, graph.Directed(), graph.Weighted())
+				for _, vertex := range []string{"A", "B", "C", "D"} {
+					_ = g.AddVertex(vertex)
+				}
+				_ = g.AddEdge("A", "B")
+				_ = g.AddEdge("B", "C")
+				_ = g.AddEdge("B", "D", graph.EdgeWeight(3))
+				return g
+			},
+		},
+		"undirected edges and attributes": {
+			description: `
+				E[color=red, w=2]
+				A -- B[label=x]
+				B -- E
+			`,
+			expected: func() graph.Graph[string, string] {
+				g := graph.New(graph.StringHash)
+				_ = g.AddVertex("A")
+				_ = g.AddVertex("B")
+				_ = g.AddVertex("E", graph.VertexAttribute("color", "red"), graph.VertexWeight(2))
+				_ = g.AddEdge("A", "B", graph.EdgeAttribute("label", "x"))
+				_ = g.AddEdge("B", "E")
+				return g
+			},
+		},
+		"isolated vertices": {
+			description: "A; B",
+			expected: func() graph.Graph[string, string] {
+				g := graph.New(graph.StringHash)
+				_ = g.AddVertex("A")
+				_ = g.AddVertex("B")
+				return g
+			},
+		},
+		"mixed edge types": {
+			description: "A->B; B--C",
+			shouldFail:  true,
+		},
+		"duplicate edge": {
+			description: "A->B; A->B",
+			shouldFail:  true,
+		},
+		"attributes of first vertex": {
+			description: "A[color=red]->B",
+			shouldFail:  true,
+		},
+		"attributes of existing vertex": {
+			description: "A->B; A[color=red]",
+			shouldFail:  true,
+		},
+		"invalid weight": {
+			description: "A->B[w=x]",
+			shouldFail:  true,
+		},
+		"missing vertex name": {
+			description: "A->->B",
+			shouldFail:  true,
+		},
+		"unclosed attributes": {
+			description: "A->B[w=3",
+			shouldFail:  true,
+		},
+	}
+
+	for name, test := range tests {
+		g, err := Parse(test.description)
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		if test.shouldFail {
+			continue
+		}
+
+		if !AssertSameGraph(t, test.expected(), g) {
+			t.Errorf("%s: parsed graph doesn't match", name)
+		}
+	}
+}
+
+func TestParse_traits(t *testing.T) {
+	g := MustParse("A->B->C", graph.Acyclic())
+
+	expected := graph.Traits{IsDirected: true, IsAcyclic: true}
+
+	if *g.Traits() != expected {
+		t.Errorf("traits don't match: expected %+v, got %+v", expected, *g.Traits())
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected MustParse to panic")
+		}
+	}()
+
+	MustParse("A->B--C")
+}
@@ -0,0 +1,467 @@
+// Package graphwal provides a write-ahead log that persistent [graph.Store]
+// implementations can use to make mutations atomic and to recover from
+// crashes.
+//
+// A store appends a batch of encoded mutations to the log before applying them
+// to its actual storage. Once the batch has been appended, the mutations are
+// durable: If the process crashes before the store has applied all of them,
+// the store replays the log when it is opened again. A batch is written as a
+// single record, so either all or none of its mutations are replayed.
+//
+//	wal, _ := graphwal.Open("graph.wal", graphwal.SyncInterval(time.Second))
+//	defer wal.Close()
+//
+//	_ = wal.Replay(func(seq uint64, entries [][]byte) error {
+//		// Apply the entries to the storage.
+//		return nil
+//	})
+//
+//	seq, _ := wal.Append(addVertex, addEdge)
+//	// Apply the mutations to the storage, then mark them as applied.
+//	_ = wal.Checkpoint(seq)
+//
+// Each record consists of its length, a CRC-32 checksum, a sequence number,
+// and the entries. A record that is incomplete or whose checksum doesn't match,
+// which is left behind by an interrupted write, ends the log and is truncated
+// when the log is opened.
+//
+// [graph.Store]: https://pkg.go.dev/github.com/dominikbraun/graph#Store
+package graphwal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned when using a log that has been closed.
+var ErrClosed = errors.New("log is closed")
+
+// headerSize is the size of the length and the checksum preceding a record.
+const headerSize = 8
+
+var table = crc32.MakeTable(crc32.Castagnoli)
+
+type syncPolicy int
+
+const (
+	syncAlways syncPolicy = iota
+	syncInterval
+	syncNever
+)
+
+type config struct {
+	policy   syncPolicy
+	interval time.Duration
+}
+
+// SyncAlways is a functional option for [Open] that makes the log call fsync
+// after each appended batch. A batch survives a crash of the operating system
+// as soon as Append returns. This is the default.
+func SyncAlways() func(*config) {
+	return func(c *config) {
+		c.policy = syncAlways
+	}
+}
+
+// SyncInterval is a functional option for [Open] that makes the log call fsync
+// periodically instead of after each batch. This is considerably faster, but
+// batches appended within the last interval may be lost if the operating
+// system crashes. A crash of the process alone doesn't lose any batches.
+func SyncInterval(interval time.Duration) func(*config) {
+	return func(c *config) {
+		c.policy = syncInterval
+		c.interval = interval
+	}
+}
+
+// SyncNever is a functional option for [Open] that leaves flushing the log to
+// the operating system. Only use this if losing recent batches is acceptable.
+func SyncNever() func(*config) {
+	return func(c *config) {
+		c.policy = syncNever
+	}
+}
+
+// Log is a write-ahead log stored in a single file. It is safe for concurrent
+// use.
+type Log struct {
+	lock   sync.Mutex
+	file   *os.File
+	policy syncPolicy
+	size   int64
+	seq    uint64
+	dirty  bool
+	closed bool
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// Open opens the log file at the given path. If the file doesn't exist, it will
+// be created. An incomplete or corrupted record at the end of the log will be
+// truncated.
+func Open(path string, options ...func(*config)) (*Log, error) {
+	var c config
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	if c.policy == syncInterval && c.interval <= 0 {
+		return nil, fmt.Errorf("invalid sync interval %v", c.interval)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	l := &Log{
+		file:   file,
+		policy: c.policy,
+		done:   make(chan struct{}),
+	}
+
+	if err := l.recover(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	if l.policy == syncInterval {
+		l.wg.Add(1)
+		go l.syncPeriodically(c.interval)
+	}
+
+	return l, nil
+}
+
+// Append writes the given entries to the log as a single batch and returns the
+// sequence number of the batch. Either all or none of the entries are replayed
+// after a crash. Sequence numbers increase with each batch and start over once
+// the log has been truncated by Checkpoint.
+func (l *Log) Append(entries ...[]byte) (uint64, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.closed {
+		return 0, ErrClosed
+	}
+
+	seq := l.seq + 1
+	record := encodeRecord(seq, entries)
+
+	if _, err := l.file.Write(record); err != nil {
+		return 0, l.rollback(fmt.Errorf("failed to write log: %w", err))
+	}
+
+	switch l.policy {
+	case syncAlways:
+		if err := l.file.Sync(); err != nil {
+			return 0, l.rollback(fmt.Errorf("failed to sync log: %w", err))
+		}
+	default:
+		l.dirty = true
+	}
+
+	l.size += int64(len(record))
+	l.seq = seq
+
+	return seq, nil
+}
+
+// Replay calls apply for each batch in the log, in the order in which the
+// batches have been appended. If apply returns an error, Replay stops and
+// returns that error.
+func (l *Log) Replay(apply func(seq uint64, entries [][]byte) error) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.closed {
+		return ErrClosed
+	}
+
+	_, err := l.scan(apply)
+
+	return err
+}
+
+// Checkpoint marks all batches up to and including the batch with the given
+// sequence number as applied. If no newer batches exist, the log is truncated.
+// Stores should call Checkpoint once they have applied the batches durably.
+func (l *Log) Checkpoint(seq uint64) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.closed {
+		return ErrClosed
+	}
+
+	// Newer batches still have to be replayed, so the log can't be truncated.
+	if seq < l.seq {
+		return nil
+	}
+
+	if err := l.file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate log: %w", err)
+	}
+
+	if _, err := l.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to truncate log: %w", err)
+	}
+
+	l.size = 0
+	l.dirty = false
+
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync log: %w", err)
+	}
+
+	return nil
+}
+
+// Sync flushes the log to disk, regardless of the sync policy.
+func (l *Log) Sync() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.closed {
+		return ErrClosed
+	}
+
+	return l.sync()
+}
+
+// Close flushes the log to disk and closes the file. The log must not be used
+// afterwards.
+func (l *Log) Close() error {
+	l.lock.Lock()
+
+	if l.closed {
+		l.lock.Unlock()
+		return nil
+	}
+
+	l.closed = true
+	close(l.done)
+	l.lock.Unlock()
+
+	l.wg.Wait()
+
+	syncErr := l.file.Sync()
+
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log: %w", err)
+	}
+
+	if syncErr != nil {
+		return fmt.Errorf("failed to sync log: %w", syncErr)
+	}
+
+	return nil
+}
+
+func (l *Log) sync() error {
+	if !l.dirty {
+		return nil
+	}
+
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync log: %w", err)
+	}
+
+	l.dirty = false
+
+	return nil
+}
+
+func (l *Log) syncPeriodically(interval time.Duration) {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-ticker.C:
+			l.lock.Lock()
+			_ = l.sync()
+			l.lock.Unlock()
+		}
+	}
+}
+
+// rollback truncates the log to the end of the last complete record after a
+// failed write and returns the given error.
+func (l *Log) rollback(err error) error {
+	if truncErr := l.file.Truncate(l.size); truncErr != nil {
+		return fmt.Errorf("%w (failed to truncate log: %v)", err, truncErr)
+	}
+
+	if _, seekErr := l.file.Seek(l.size, io.SeekStart); seekErr != nil {
+		return fmt.Errorf("%w (failed to seek log: %v)", err, seekErr)
+	}
+
+	return err
+}
+
+// recover scans the log to find the end of the last complete record, truncates
+// everything behind it, and positions the file for appending.
+func (l *Log) recover() error {
+	size, err := l.scan(func(seq uint64, _ [][]byte) error {
+		l.seq = seq
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := l.file.Truncate(size); err != nil {
+		return fmt.Errorf("failed to truncate incomplete record: %w", err)
+	}
+
+	if _, err := l.file.Seek(size, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read log: %w", err)
+	}
+
+	l.size = size
+
+	return nil
+}
+
+// scan reads all complete records from the start of the log and returns the
+// offset behind the last complete record. Afterwards, the file is positioned
+// at the end of the log again.
+func (l *Log) scan(apply func(seq uint64, entries [][]byte) error) (int64, error) {
+	if _, err := l.file.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to read log: %w", err)
+	}
+
+	info, err := l.file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read log: %w", err)
+	}
+
+	reader := bufio.NewReader(l.file)
+
+	var offset int64
+
+	for {
+		seq, entries, n, err := readRecord(reader, info.Size()-offset)
+		if errors.Is(err, io.EOF) || errors.Is(err, errCorrupted) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read log: %w", err)
+		}
+
+		if err := apply(seq, entries); err != nil {
+			return 0, err
+		}
+
+		offset += n
+	}
+
+	if _, err := l.file.Seek(l.size, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to read log: %w", err)
+	}
+
+	return offset, nil
+}
+
+var errCorrupted = errors.New("corrupted record")
+
+// encodeRecord encodes the given batch as a record:
+//
+//	length (uint32) | checksum (uint32) | seq (uint64) | count (uint32) | entries
+//
+// Each entry is prefixed with its length as uint32. The checksum covers all
+// bytes following it.
+func encodeRecord(seq uint64, entries [][]byte) []byte {
+	size := 12
+	for _, entry := range entries {
+		size += 4 + len(entry)
+	}
+
+	record := make([]byte, headerSize+size)
+	payload := record[headerSize:]
+
+	binary.BigEndian.PutUint64(payload, seq)
+	binary.BigEndian.PutUint32(payload[8:], uint32(len(entries)))
+
+	offset := 12
+	for _, entry := range entries {
+		binary.BigEndian.PutUint32(payload[offset:], uint32(len(entry)))
+		offset += 4
+		offset += copy(payload[offset:], entry)
+	}
+
+	binary.BigEndian.PutUint32(record, uint32(size))
+	binary.BigEndian.PutUint32(record[4:], crc32.Checksum(payload, table))
+
+	return record
+}
+
+// readRecord reads the next record, given the number of remaining bytes in the
+// log. It returns io.EOF at the end of the log and errCorrupted for incomplete
+// records or records with a wrong checksum.
+func readRecord(r io.Reader, remaining int64) (uint64, [][]byte, int64, error) {
+	var header [headerSize]byte
+
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, nil, 0, io.EOF
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, 0, errCorrupted
+		}
+		return 0, nil, 0, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	checksum := binary.BigEndian.Uint32(header[4:])
+
+	if size < 12 || int64(headerSize+size) > remaining {
+		return 0, nil, 0, errCorrupted
+	}
+
+	payload := make([]byte, size)
+
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, 0, errCorrupted
+		}
+		return 0, nil, 0, err
+	}
+
+	if crc32.Checksum(payload, table) != checksum {
+		return 0, nil, 0, errCorrupted
+	}
+
+	seq := binary.BigEndian.Uint64(payload)
+	count := binary.BigEndian.Uint32(payload[8:])
+	entries := make([][]byte, 0, count)
+
+	offset := 12
+	for i := uint32(0); i < count; i++ {
+		if offset+4 > len(payload) {
+			return 0, nil, 0, errCorrupted
+		}
+		length := int(binary.BigEndian.Uint32(payload[offset:]))
+		offset += 4
+		if offset+length > len(payload) {
+			return 0, nil, 0, errCorrupted
+		}
+		entries = append(entries, payload[offset:offset+length])
+		offset += length
+	}
+
+	return seq, entries, int64(headerSize + size), nil
+}
@@ -0,0 +1,188 @@
+package graphwal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type batch struct {
+	seq     uint64
+	entries []string
+}
+
+func replay(t *testing.T, l *Log) []batch {
+	var batches []batch
+
+	err := l.Replay(func(seq uint64, entries [][]byte) error {
+		b := batch{seq: seq}
+		for _, entry := range entries {
+			b.entries = append(b.entries, string(entry))
+		}
+		batches = append(batches, b)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to replay log: %v", err)
+	}
+
+	return batches
+}
+
+func TestLog(t *testing.T) {
+	tests := map[string]struct {
+		options []func(*config)
+	}{
+		"sync always": {
+			options: []func(*config){SyncAlways()},
+		},
+		"sync interval": {
+			options: []func(*config){SyncInterval(time.Millisecond)},
+		},
+		"sync never": {
+			options: []func(*config){SyncNever()},
+		},
+	}
+
+	for name, test := range tests {
+		path := filepath.Join(t.TempDir(), "graph.wal")
+
+		l, err := Open(path, test.options...)
+		if err != nil {
+			t.Fatalf("%s: failed to open log: %v", name, err)
+		}
+
+		_, _ = l.Append([]byte("add_vertex A"), []byte("add_vertex B"))
+		_, _ = l.Append([]byte("add_edge A B"))
+
+		if err := l.Close(); err != nil {
+			t.Fatalf("%s: failed to close log: %v", name, err)
+		}
+
+		if _, err := l.Append([]byte("add_vertex C")); !errors.Is(err, ErrClosed) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrClosed, err)
+		}
+
+		reopened, err := Open(path, test.options...)
+		if err != nil {
+			t.Fatalf("%s: failed to open log: %v", name, err)
+		}
+
+		expected := []batch{
+			{seq: 1, entries: []string{"add_vertex A", "add_vertex B"}},
+			{seq: 2, entries: []string{"add_edge A B"}},
+		}
+
+		if batches := replay(t, reopened); !reflect.DeepEqual(batches, expected) {
+			t.Errorf("%s: batches don't match: expected %v, got %v", name, expected, batches)
+		}
+
+		// Appending after replaying must continue the sequence.
+		if seq, _ := reopened.Append([]byte("add_vertex C")); seq != 3 {
+			t.Errorf("%s: sequence number doesn't match: expected %v, got %v", name, 3, seq)
+		}
+
+		_ = reopened.Close()
+	}
+}
+
+func TestLog_Checkpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.wal")
+
+	l, err := Open(path)
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	defer l.Close()
+
+	first, _ := l.Append([]byte("A"))
+	second, _ := l.Append([]byte("B"))
+
+	// The log must not be truncated while newer batches exist.
+	_ = l.Checkpoint(first)
+
+	if batches := replay(t, l); len(batches) != 2 {
+		t.Errorf("number of batches doesn't match: expected %v, got %v", 2, len(batches))
+	}
+
+	_ = l.Checkpoint(second)
+
+	if batches := replay(t, l); len(batches) != 0 {
+		t.Errorf("number of batches doesn't match: expected %v, got %v", 0, len(batches))
+	}
+
+	_, _ = l.Append([]byte("C"))
+
+	if batches := replay(t, l); len(batches) != 1 || batches[0].entries[0] != "C" {
+		t.Errorf("batches don't match: expected %v, got %v", []string{"C"}, batches)
+	}
+}
+
+func TestLog_recover(t *testing.T) {
+	tests := map[string]struct {
+		corrupt  func(data []byte) []byte
+		expected int
+	}{
+		"incomplete record": {
+			corrupt: func(data []byte) []byte {
+				return data[:len(data)-3]
+			},
+			expected: 1,
+		},
+		"wrong checksum": {
+			corrupt: func(data []byte) []byte {
+				data[len(data)-1] ^= 0xff
+				return data
+			},
+			expected: 1,
+		},
+		"huge length": {
+			corrupt: func(data []byte) []byte {
+				return append(data, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0)
+			},
+			expected: 2,
+		},
+	}
+
+	for name, test := range tests {
+		path := filepath.Join(t.TempDir(), "graph.wal")
+
+		l, _ := Open(path)
+		_, _ = l.Append([]byte("A"))
+		_, _ = l.Append([]byte("B"))
+		_ = l.Close()
+
+		data, _ := os.ReadFile(path)
+		_ = os.WriteFile(path, test.corrupt(data), 0o600)
+
+		reopened, err := Open(path)
+		if err != nil {
+			t.Fatalf("%s: failed to open log: %v", name, err)
+		}
+
+		batches := replay(t, reopened)
+
+		if len(batches) != test.expected {
+			t.Errorf("%s: number of batches doesn't match: expected %v, got %v", name, test.expected, len(batches))
+		}
+
+		// The corrupted record must have been truncated, so new batches are
+		// appended right behind the last complete record.
+		_, _ = reopened.Append([]byte("C"))
+
+		if batches := replay(t, reopened); batches[len(batches)-1].entries[0] != "C" {
+			t.Errorf("%s: expected last batch to be %v, got %v", name, "C", batches[len(batches)-1])
+		}
+
+		_ = reopened.Close()
+	}
+}
+
+func TestOpen_invalidInterval(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "graph.wal"), SyncInterval(0)); err == nil {
+		t.Errorf("error expectancy doesn't match: expected error, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package graph
+
+import (
+	"errors"
+	"sync"
+)
+
+// OnAddVertex registers a function that is called after a vertex has been added
+// to the graph. Hooks allow applications to maintain derived indexes, to write
+// audit logs, or to invalidate caches when the graph changes:
+//
+//	unregister, _ := graph.OnAddVertex(g, func(hash string, value City, _ graph.VertexProperties) {
+//		index.Add(hash, value)
+//	})
+//	defer unregister()
+//
+// Hooks are called synchronously by the goroutine that modified the graph, in
+// the order they have been registered, and only if the modification succeeded.
+// They may read the graph, but must not modify it. The returned function
+// removes the hook again.
+//
+// Hooks are registered on the graph instance rather than its store, so they
+// don't observe modifications made directly to the store or to clones of the
+// graph.
+func OnAddVertex[K comparable, T any](g Graph[K, T], hook func(hash K, value T, properties VertexProperties)) (func(), error) {
+	return registerHook(g, hookFuncs[K, T]{addVertex: hook})
+}
+
+// OnRemoveVertex registers a function that is called after a vertex has been
+// removed from the graph. See [OnAddVertex] for details on hooks.
+func OnRemoveVertex[K comparable, T any](g Graph[K, T], hook func(hash K)) (func(), error) {
+	return registerHook(g, hookFuncs[K, T]{removeVertex: hook})
+}
+
+// OnAddEdge registers a function that is called after an edge has been added to
+// the graph. For undirected graphs, the hook is called once per edge with the
+// source and target passed to AddEdge. See [OnAddVertex] for details on hooks.
+func OnAddEdge[K comparable, T any](g Graph[K, T], hook func(edge Edge[K])) (func(), error) {
+	return registerHook(g, hookFuncs[K, T]{addEdge: hook})
+}
+
+// OnUpdateEdge registers a function that is called after an edge has been
+// updated. The hook receives the edge with its updated properties. See
+// [OnAddVertex] for details on hooks.
+func OnUpdateEdge[K comparable, T any](g Graph[K, T], hook func(edge Edge[K])) (func(), error) {
+	return registerHook(g, hookFuncs[K, T]{updateEdge: hook})
+}
+
+// OnRemoveEdge registers a function that is called after an edge has been
+// removed from the graph. See [OnAddVertex] for details on hooks.
+func OnRemoveEdge[K comparable, T any](g Graph[K, T], hook func(source, target K)) (func(), error) {
+	return registerHook(g, hookFuncs[K, T]{removeEdge: hook})
+}
+
+// hookFuncs is a single registered hook. Hooks registered by the On functions
+// only set one of the functions, while Watch sets all of them.
+type hookFuncs[K comparable, T any] struct {
+	id           int
+	addVertex    func(K, T, VertexProperties)
+	removeVertex func(K)
+	addEdge      func(Edge[K])
+	updateEdge   func(Edge[K])
+	removeEdge   func(K, K)
+}
+
+// hooks holds the hooks registered on a graph. Registering or removing a hook
+// replaces the slice of hooks, so that the hooks can be called without holding
+// the lock.
+type hooks[K comparable, T any] struct {
+	lock   sync.RWMutex
+	nextID int
+	funcs  []hookFuncs[K, T]
+}
+
+func newHooks[K comparable, T any]() *hooks[K, T] {
+	return &hooks[K, T]{}
+}
+
+func registerHook[K comparable, T any](g Graph[K, T], funcs hookFuncs[K, T]) (func(), error) {
+	var h *hooks[K, T]
+
+	switch g := g.(type) {
+	case *directed[K, T]:
+		h = g.hooks
+	case *undirected[K, T]:
+		h = g.hooks
+	case *cachedGraph[K, T]:
+		return registerHook(g.Graph, funcs)
+	default:
+		return nil, errors.New("graph doesn't support hooks")
+	}
+
+	return h.register(funcs), nil
+}
+
+func (h *hooks[K, T]) register(funcs hookFuncs[K, T]) func() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	funcs.id = h.nextID
+	h.nextID++
+
+	registered := make([]hookFuncs[K, T], len(h.funcs), len(h.funcs)+1)
+	copy(registered, h.funcs)
+	h.funcs = append(registered, funcs)
+
+	return func() {
+		h.unregister(funcs.id)
+	}
+}
+
+func (h *hooks[K, T]) unregister(id int) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	remaining := make([]hookFuncs[K, T], 0, len(h.funcs))
+	for _, funcs := range h.funcs {
+		if funcs.id != id {
+			remaining = append(remaining, funcs)
+		}
+	}
+
+	h.funcs = remaining
+}
+
+func (h *hooks[K, T]) registered() []hookFuncs[K, T] {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.funcs
+}
+
+func (h *hooks[K, T]) vertexAdded(hash K, value T, properties VertexProperties) {
+	for _, funcs := range h.registered() {
+		if funcs.addVertex != nil {
+			funcs.addVertex(hash, value, properties)
+		}
+	}
+}
+
+func (h *hooks[K, T]) vertexRemoved(hash K) {
+	for _, funcs := range h.registered() {
+		if funcs.removeVertex != nil {
+			funcs.removeVertex(hash)
+		}
+	}
+}
+
+func (h *hooks[K, T]) edgeAdded(edge Edge[K]) {
+	for _, funcs := range h.registered() {
+		if funcs.addEdge != nil {
+			funcs.addEdge(edge)
+		}
+	}
+}
+
+func (h *hooks[K, T]) edgeUpdated(edge Edge[K]) {
+	for _, funcs := range h.registered() {
+		if funcs.updateEdge != nil {
+			funcs.updateEdge(edge)
+		}
+	}
+}
+
+func (h *hooks[K, T]) edgeRemoved(source, target K) {
+	for _, funcs := range h.registered() {
+		if funcs.removeEdge != nil {
+			funcs.removeEdge(source, target)
+		}
+	}
+}
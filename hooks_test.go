@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	tests := map[string]struct {
+		traits   []func(*Traits)
+		expected []string
+	}{
+		"directed graph": {
+			traits: []func(*Traits){Directed()},
+			expected: []string{
+				"add vertex 1 (weight 2)",
+				"add vertex 2 (weight 0)",
+				"add edge (1, 2)",
+				"update edge (1, 2) (weight 5)",
+				"remove edge (1, 2)",
+				"remove vertex 2",
+			},
+		},
+		"undirected graph": {
+			expected: []string{
+				"add vertex 1 (weight 2)",
+				"add vertex 2 (weight 0)",
+				"add edge (1, 2)",
+				"update edge (1, 2) (weight 5)",
+				"remove edge (1, 2)",
+				"remove vertex 2",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash, test.traits...)
+
+		var events []string
+
+		_, _ = OnAddVertex(g, func(hash int, _ int, properties VertexProperties) {
+			events = append(events, fmt.Sprintf("add vertex %v (weight %v)", hash, properties.Weight))
+		})
+		_, _ = OnRemoveVertex(g, func(hash int) {
+			events = append(events, fmt.Sprintf("remove vertex %v", hash))
+		})
+		_, _ = OnAddEdge(g, func(edge Edge[int]) {
+			events = append(events, fmt.Sprintf("add edge (%v, %v)", edge.Source, edge.Target))
+		})
+		_, _ = OnUpdateEdge(g, func(edge Edge[int]) {
+			events = append(events, fmt.Sprintf("update edge (%v, %v) (weight %v)", edge.Source, edge.Target, edge.Properties.Weight))
+		})
+		_, _ = OnRemoveEdge(g, func(source, target int) {
+			events = append(events, fmt.Sprintf("remove edge (%v, %v)", source, target))
+		})
+
+		_ = g.AddVertex(1, VertexWeight(2))
+		_ = g.AddVertex(2)
+		_ = g.AddEdge(1, 2)
+
+		// Failed modifications must not call the hooks.
+		_ = g.AddVertex(1)
+		_ = g.AddEdge(1, 2)
+		_ = g.RemoveVertex(1)
+		_ = g.RemoveEdge(2, 3)
+
+		_ = g.UpdateEdge(1, 2, EdgeWeight(5))
+		_ = g.RemoveEdge(1, 2)
+		_ = g.RemoveVertex(2)
+
+		if !reflect.DeepEqual(events, test.expected) {
+			t.Errorf("%s: events don't match: expected %v, got %v", name, test.expected, events)
+		}
+	}
+}
+
+func TestHooks_unregister(t *testing.T) {
+	g := New(IntHash)
+
+	var first, second int
+
+	unregister, err := OnAddVertex(g, func(int, int, VertexProperties) {
+		first++
+	})
+	if err != nil {
+		t.Fatalf("failed to register hook: %v", err)
+	}
+
+	_, _ = OnAddVertex(g, func(int, int, VertexProperties) {
+		second++
+	})
+
+	_ = g.AddVertex(1)
+	unregister()
+	_ = g.AddVertex(2)
+
+	if first != 1 {
+		t.Errorf("calls of first hook don't match: expected %v, got %v", 1, first)
+	}
+
+	if second != 2 {
+		t.Errorf("calls of second hook don't match: expected %v, got %v", 2, second)
+	}
+
+	clone, _ := g.Clone()
+	_ = clone.AddVertex(3)
+
+	if second != 2 {
+		t.Errorf("expected hooks not to be called for clones, got %v calls", second)
+	}
+}
@@ -0,0 +1,296 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrIndexNotFound is returned when looking up vertices using an index that
+// hasn't been created.
+var ErrIndexNotFound = errors.New("index not found")
+
+// IndexOptions configures a vertex index created using [CreateVertexIndex].
+type IndexOptions[K comparable, T any] struct {
+	IndexFunc func(hash K, value T, properties VertexProperties) []string
+}
+
+// IndexBy makes an index use the given function instead of an attribute value
+// to determine the keys a vertex is indexed by. A vertex may have any number of
+// keys, and vertices without keys aren't indexed. This allows for composite
+// indexes, e.g. over a label and a type, or for simple full-text lookups:
+//
+//	words := func(_ string, _ Note, properties graph.VertexProperties) []string {
+//		return strings.Fields(strings.ToLower(properties.Attributes["text"]))
+//	}
+//
+//	_ = graph.CreateVertexIndex(g, "words", graph.IndexBy(words))
+//
+//	notes, _ := graph.VerticesByIndex(g, "words", "graph")
+//
+// The index function is called by the mutation hooks of the graph and thus
+// must not modify the graph.
+func IndexBy[K comparable, T any](indexFunc func(hash K, value T, properties VertexProperties) []string) func(*IndexOptions[K, T]) {
+	return func(o *IndexOptions[K, T]) {
+		o.IndexFunc = indexFunc
+	}
+}
+
+// CreateVertexIndex creates a secondary index with the given name. By default,
+// the index maps the values of the vertex attribute with the same name to the
+// hashes of the vertices that have these values. Once created, the index is kept
+// up to date when vertices are added or removed, and VerticesByAttribute uses it
+// instead of scanning all vertices:
+//
+//	_ = graph.CreateVertexIndex(g, "type")
+//
+//	patients, _ := graph.VerticesByAttribute(g, "type", "Patient")
+//
+// Vertices without the attribute aren't indexed. Custom indexes can be created
+// using the [IndexBy] option and are queried using VerticesByIndex. Creating an
+// index with a name that already exists has no effect.
+//
+// Just like hooks, indexes belong to the graph instance rather than its store.
+// They don't observe modifications made directly to the store, and clones of
+// the graph don't inherit them.
+func CreateVertexIndex[K comparable, T any](g Graph[K, T], name string, options ...func(*IndexOptions[K, T])) error {
+	indexes, err := indexesOf(g)
+	if err != nil {
+		return err
+	}
+
+	var indexOptions IndexOptions[K, T]
+
+	for _, option := range options {
+		option(&indexOptions)
+	}
+
+	indexes.lock.Lock()
+	defer indexes.lock.Unlock()
+
+	if _, ok := indexes.byName[name]; ok {
+		return nil
+	}
+
+	var index *vertexIndex[K, T]
+
+	if indexOptions.IndexFunc != nil {
+		index = newVertexIndex(indexOptions.IndexFunc)
+	} else {
+		index = newVertexIndex(attributeIndexFunc[K, T](name))
+		index.attribute = true
+	}
+
+	// The hooks are registered before the existing vertices are indexed, so
+	// that vertices added in the meantime aren't missed.
+	unregister, err := registerHook(g, hookFuncs[K, T]{
+		addVertex:    index.add,
+		removeVertex: index.remove,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register hooks: %w", err)
+	}
+
+	if err := index.build(g); err != nil {
+		unregister()
+		return fmt.Errorf("failed to build index %q: %w", name, err)
+	}
+
+	indexes.byName[name] = index
+
+	return nil
+}
+
+// VerticesByIndex returns the hashes of all vertices that have been indexed by
+// the given key in the index with the given name. The hashes are sorted as
+// described in [SortedVertices]. If the index doesn't exist, ErrIndexNotFound
+// is returned.
+func VerticesByIndex[K comparable, T any](g Graph[K, T], name, key string) ([]K, error) {
+	indexes, err := indexesOf(g)
+	if err != nil {
+		return nil, err
+	}
+
+	index, ok := indexes.get(name)
+	if !ok {
+		return nil, fmt.Errorf("failed to look up index %q: %w", name, ErrIndexNotFound)
+	}
+
+	return index.lookup(key), nil
+}
+
+// VerticesByAttribute returns the hashes of all vertices whose attribute with
+// the given key has the given value. The hashes are sorted as described in
+// [SortedVertices]. If an index has been created for the attribute using
+// [CreateVertexIndex], the index is used. Otherwise, all vertices are scanned.
+func VerticesByAttribute[K comparable, T any](g Graph[K, T], key, value string) ([]K, error) {
+	if indexes, err := indexesOf(g); err == nil {
+		if index, ok := indexes.get(key); ok && index.attribute {
+			return index.lookup(value), nil
+		}
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	hashes := make([]K, 0)
+
+	for hash := range adjacencyMap {
+		_, properties, err := g.VertexWithProperties(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+
+		if v, ok := properties.Attributes[key]; ok && v == value {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	sortHashes(hashes)
+
+	return hashes, nil
+}
+
+// vertexIndexes holds the indexes created for a graph by their names.
+type vertexIndexes[K comparable, T any] struct {
+	lock   sync.RWMutex
+	byName map[string]*vertexIndex[K, T]
+}
+
+func newVertexIndexes[K comparable, T any]() *vertexIndexes[K, T] {
+	return &vertexIndexes[K, T]{
+		byName: make(map[string]*vertexIndex[K, T]),
+	}
+}
+
+func (v *vertexIndexes[K, T]) get(name string) (*vertexIndex[K, T], bool) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	index, ok := v.byName[name]
+	return index, ok
+}
+
+func indexesOf[K comparable, T any](g Graph[K, T]) (*vertexIndexes[K, T], error) {
+	switch g := g.(type) {
+	case *directed[K, T]:
+		return g.indexes, nil
+	case *undirected[K, T]:
+		return g.indexes, nil
+	case *cachedGraph[K, T]:
+		return indexesOf(g.Graph)
+	default:
+		return nil, errors.New("graph doesn't support indexes")
+	}
+}
+
+// vertexIndex maps index keys to the hashes of the vertices that have them. The
+// keys of each vertex are stored as well, because the hook for removed vertices
+// only receives the vertex hash. Only attribute indexes are used by
+// VerticesByAttribute, since a custom index may share its name with an
+// attribute.
+type vertexIndex[K comparable, T any] struct {
+	lock      sync.RWMutex
+	indexFunc func(K, T, VertexProperties) []string
+	attribute bool
+	entries   map[string]map[K]struct{}
+	keys      map[K][]string
+}
+
+func newVertexIndex[K comparable, T any](indexFunc func(K, T, VertexProperties) []string) *vertexIndex[K, T] {
+	return &vertexIndex[K, T]{
+		indexFunc: indexFunc,
+		entries:   make(map[string]map[K]struct{}),
+		keys:      make(map[K][]string),
+	}
+}
+
+// attributeIndexFunc returns an index function that indexes vertices by the
+// value of the given attribute.
+func attributeIndexFunc[K comparable, T any](key string) func(K, T, VertexProperties) []string {
+	return func(_ K, _ T, properties VertexProperties) []string {
+		if value, ok := properties.Attributes[key]; ok {
+			return []string{value}
+		}
+		return nil
+	}
+}
+
+// build indexes all vertices that currently exist in the graph.
+func (v *vertexIndex[K, T]) build(g Graph[K, T]) error {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	for hash := range adjacencyMap {
+		value, properties, err := g.VertexWithProperties(hash)
+		if errors.Is(err, ErrVertexNotFound) {
+			// The vertex has been removed after the adjacency map was built.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+
+		v.add(hash, value, properties)
+	}
+
+	return nil
+}
+
+func (v *vertexIndex[K, T]) add(hash K, value T, properties VertexProperties) {
+	keys := v.indexFunc(hash, value, properties)
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.removeLocked(hash)
+
+	if len(keys) == 0 {
+		return
+	}
+
+	for _, key := range keys {
+		if _, ok := v.entries[key]; !ok {
+			v.entries[key] = make(map[K]struct{})
+		}
+		v.entries[key][hash] = struct{}{}
+	}
+
+	v.keys[hash] = keys
+}
+
+func (v *vertexIndex[K, T]) remove(hash K) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.removeLocked(hash)
+}
+
+func (v *vertexIndex[K, T]) removeLocked(hash K) {
+	for _, key := range v.keys[hash] {
+		delete(v.entries[key], hash)
+		if len(v.entries[key]) == 0 {
+			delete(v.entries, key)
+		}
+	}
+
+	delete(v.keys, hash)
+}
+
+// lookup returns the sorted hashes of the vertices with the given index key.
+func (v *vertexIndex[K, T]) lookup(key string) []K {
+	v.lock.RLock()
+	hashes := make([]K, 0, len(v.entries[key]))
+	for hash := range v.entries[key] {
+		hashes = append(hashes, hash)
+	}
+	v.lock.RUnlock()
+
+	sortHashes(hashes)
+
+	return hashes
+}
@@ -0,0 +1,217 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCreateVertexIndex(t *testing.T) {
+	tests := map[string]struct {
+		traits []func(*Traits)
+	}{
+		"directed graph": {
+			traits: []func(*Traits){Directed()},
+		},
+		"undirected graph": {},
+	}
+
+	for name, test := range tests {
+		g := New(StringHash, test.traits...)
+
+		_ = g.AddVertex("alice", VertexAttribute("type", "Patient"))
+		_ = g.AddVertex("asthma", VertexAttribute("type", "Condition"))
+
+		if err := CreateVertexIndex(g, "type"); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		// Creating the index a second time has no effect.
+		if err := CreateVertexIndex(g, "type"); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		_ = g.AddVertex("bob", VertexAttribute("type", "Patient"))
+		_ = g.AddVertex("carol", VertexAttribute("type", "Patient"))
+		_ = g.AddVertex("unknown")
+		_ = g.AddEdge("bob", "carol")
+		_ = g.RemoveEdge("bob", "carol")
+		_ = g.RemoveVertex("carol")
+
+		tests := map[string]struct {
+			key            string
+			value          string
+			expectedHashes []string
+		}{
+			"indexed before creation": {
+				key:            "type",
+				value:          "Condition",
+				expectedHashes: []string{"asthma"},
+			},
+			"indexed after creation": {
+				key:            "type",
+				value:          "Patient",
+				expectedHashes: []string{"alice", "bob"},
+			},
+			"unknown value": {
+				key:            "type",
+				value:          "Encounter",
+				expectedHashes: []string{},
+			},
+		}
+
+		for lookupName, lookup := range tests {
+			hashes, err := VerticesByAttribute(g, lookup.key, lookup.value)
+			if err != nil {
+				t.Fatalf("%s: %s: unexpected error: %v", name, lookupName, err)
+			}
+
+			if !reflect.DeepEqual(hashes, lookup.expectedHashes) {
+				t.Errorf("%s: %s: hashes don't match: expected %v, got %v", name, lookupName, lookup.expectedHashes, hashes)
+			}
+		}
+
+		indexes, _ := indexesOf(g)
+		index, _ := indexes.get("type")
+
+		if _, ok := index.keys["carol"]; ok {
+			t.Errorf("%s: removed vertex is still indexed", name)
+		}
+	}
+}
+
+func TestVerticesByAttribute(t *testing.T) {
+	g := New(IntHash, Directed())
+
+	for i := 1; i <= 6; i++ {
+		parity := "odd"
+		if i%2 == 0 {
+			parity = "even"
+		}
+		_ = g.AddVertex(i, VertexAttribute("parity", parity))
+	}
+
+	// Without an index, all vertices are scanned.
+	scanned, err := VerticesByAttribute(g, "parity", "even")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_ = CreateVertexIndex(g, "parity")
+
+	indexed, err := VerticesByAttribute(g, "parity", "even")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []int{2, 4, 6}
+
+	if !reflect.DeepEqual(scanned, expected) {
+		t.Errorf("scanned hashes don't match: expected %v, got %v", expected, scanned)
+	}
+
+	if !reflect.DeepEqual(indexed, expected) {
+		t.Errorf("indexed hashes don't match: expected %v, got %v", expected, indexed)
+	}
+}
+
+func TestCreateVertexIndex_cached(t *testing.T) {
+	g := New(StringHash)
+	cached, _, _ := Cached(g)
+
+	if err := CreateVertexIndex(cached, "type"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_ = g.AddVertex("alice", VertexAttribute("type", "Patient"))
+
+	hashes, _ := VerticesByAttribute(cached, "type", "Patient")
+
+	if expected := []string{"alice"}; !reflect.DeepEqual(hashes, expected) {
+		t.Errorf("hashes don't match: expected %v, got %v", expected, hashes)
+	}
+}
+
+func TestCreateVertexIndex_IndexBy(t *testing.T) {
+	g := New(StringHash, Directed())
+
+	composite := func(_ string, _ string, properties VertexProperties) []string {
+		return []string{properties.Attributes["label"] + "/" + properties.Attributes["type"]}
+	}
+
+	words := func(_ string, _ string, properties VertexProperties) []string {
+		return strings.Fields(strings.ToLower(properties.Attributes["text"]))
+	}
+
+	_ = g.AddVertex("note-1", VertexAttribute("label", "Note"), VertexAttribute("type", "Draft"), VertexAttribute("text", "Graph algorithms"))
+	_ = g.AddVertex("note-2", VertexAttribute("label", "Note"), VertexAttribute("type", "Final"), VertexAttribute("text", "Sorting algorithms"))
+
+	if err := CreateVertexIndex(g, "composite", IndexBy(composite)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The custom index shares its name with the text attribute, which must not
+	// make VerticesByAttribute use the index.
+	if err := CreateVertexIndex(g, "text", IndexBy(words)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_ = g.AddVertex("note-3", VertexAttribute("label", "Note"), VertexAttribute("type", "Draft"), VertexAttribute("text", "Graph databases"))
+	_ = g.RemoveVertex("note-1")
+
+	tests := map[string]struct {
+		index          string
+		key            string
+		expectedHashes []string
+		expectedErr    error
+	}{
+		"composite index": {
+			index:          "composite",
+			key:            "Note/Draft",
+			expectedHashes: []string{"note-3"},
+		},
+		"multiple keys per vertex": {
+			index:          "text",
+			key:            "algorithms",
+			expectedHashes: []string{"note-2"},
+		},
+		"key of added vertex": {
+			index:          "text",
+			key:            "graph",
+			expectedHashes: []string{"note-3"},
+		},
+		"unknown key": {
+			index:          "text",
+			key:            "trees",
+			expectedHashes: []string{},
+		},
+		"unknown index": {
+			index:       "type",
+			key:         "Draft",
+			expectedErr: ErrIndexNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		hashes, err := VerticesByIndex(g, test.index, test.key)
+
+		if !errors.Is(err, test.expectedErr) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+		}
+
+		if test.expectedErr != nil {
+			continue
+		}
+
+		if !reflect.DeepEqual(hashes, test.expectedHashes) {
+			t.Errorf("%s: hashes don't match: expected %v, got %v", name, test.expectedHashes, hashes)
+		}
+	}
+
+	hashes, _ := VerticesByAttribute(g, "text", "Graph databases")
+
+	if expected := []string{"note-3"}; !reflect.DeepEqual(hashes, expected) {
+		t.Errorf("hashes don't match: expected %v, got %v", expected, hashes)
+	}
+}
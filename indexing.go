@@ -0,0 +1,69 @@
+package graph
+
+import "fmt"
+
+// Indexing assigns each vertex of a graph a dense integer index from 0 to n-1,
+// which is required for representing a graph as a matrix or a bitset, or for
+// passing it to libraries that identify vertices by their index.
+type Indexing[K comparable] struct {
+	// Indices maps each vertex hash to its index.
+	Indices map[K]int
+	// Hashes maps each index to its vertex hash.
+	Hashes []K
+}
+
+// Index assigns dense integer indices to the vertices in the graph. The indices
+// are stable: they follow the order of the vertex hashes described in
+// [SortedVertices], so the same set of vertices always yields the same indices.
+//
+//	indexing, _ := graph.Index(g)
+//
+//	matrix := make([][]float64, len(indexing.Hashes))
+//	for i := range matrix {
+//		matrix[i] = make([]float64, len(indexing.Hashes))
+//	}
+//
+//	edges, _ := g.Edges()
+//	for _, edge := range edges {
+//		source, target := indexing.Indices[edge.Source], indexing.Indices[edge.Target]
+//		matrix[source][target] = float64(edge.Properties.Weight)
+//	}
+//
+// The indexing isn't updated when the graph is modified. Adding or removing a
+// vertex shifts the indices of all vertices that come after it.
+func Index[K comparable, T any](g Graph[K, T]) (*Indexing[K], error) {
+	hashes, err := vertexHashes(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vertices: %w", err)
+	}
+
+	sortHashes(hashes)
+
+	indices := make(map[K]int, len(hashes))
+	for i, hash := range hashes {
+		indices[hash] = i
+	}
+
+	return &Indexing[K]{
+		Indices: indices,
+		Hashes:  hashes,
+	}, nil
+}
+
+// Index returns the index of the vertex with the given hash, or false if the
+// vertex hasn't been indexed.
+func (i *Indexing[K]) Index(hash K) (int, bool) {
+	index, ok := i.Indices[hash]
+	return index, ok
+}
+
+// Hash returns the hash of the vertex with the given index, or false if the
+// index is out of range.
+func (i *Indexing[K]) Hash(index int) (K, bool) {
+	if index < 0 || index >= len(i.Hashes) {
+		var hash K
+		return hash, false
+	}
+
+	return i.Hashes[index], true
+}
@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIndex(t *testing.T) {
+	tests := map[string]struct {
+		vertices        []int
+		edges           []Edge[int]
+		expectedHashes  []int
+		expectedIndices map[int]int
+	}{
+		"graph with vertices": {
+			vertices:        []int{10, 2, 33, 4},
+			edges:           []Edge[int]{{Source: 10, Target: 2}, {Source: 33, Target: 4}},
+			expectedHashes:  []int{2, 4, 10, 33},
+			expectedIndices: map[int]int{2: 0, 4: 1, 10: 2, 33: 3},
+		},
+		"empty graph": {
+			vertices:        []int{},
+			expectedHashes:  []int{},
+			expectedIndices: map[int]int{},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash, Directed())
+
+		for _, vertex := range test.vertices {
+			_ = g.AddVertex(vertex)
+		}
+
+		for _, edge := range test.edges {
+			_ = g.AddEdge(edge.Source, edge.Target)
+		}
+
+		indexing, err := Index(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		// An empty graph may yield a nil slice.
+		if len(indexing.Hashes) != len(test.expectedHashes) || len(test.expectedHashes) > 0 && !reflect.DeepEqual(indexing.Hashes, test.expectedHashes) {
+			t.Errorf("%s: hashes expectancy doesn't match: expected %v, got %v", name, test.expectedHashes, indexing.Hashes)
+		}
+
+		if !reflect.DeepEqual(indexing.Indices, test.expectedIndices) {
+			t.Errorf("%s: indices expectancy doesn't match: expected %v, got %v", name, test.expectedIndices, indexing.Indices)
+		}
+
+		for i, hash := range indexing.Hashes {
+			if index, ok := indexing.Index(hash); !ok || index != i {
+				t.Errorf("%s: index of %v doesn't match: expected %v, got %v", name, hash, i, index)
+			}
+			if h, ok := indexing.Hash(i); !ok || h != hash {
+				t.Errorf("%s: hash at %v doesn't match: expected %v, got %v", name, i, hash, h)
+			}
+		}
+
+		if _, ok := indexing.Hash(len(test.vertices)); ok {
+			t.Errorf("%s: expected index %v to be out of range", name, len(test.vertices))
+		}
+
+		if _, ok := indexing.Index(-1); ok {
+			t.Errorf("%s: expected vertex %v not to be indexed", name, -1)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// Package instrumentedstore provides a [graph.Store] wrapper that records the
+// number of calls and the latency of each store operation. This shows what
+// graphs and algorithms are doing to a store backend.
+//
+//	recorder := instrumentedstore.NewRecorder()
+//	store := instrumentedstore.Wrap[string, string](sqlStore, recorder)
+//
+//	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+//
+//	_, _ = graph.ShortestPath(g, "A", "B")
+//
+//	stats := recorder.Stats()["EdgesBySource"]
+//	fmt.Println(stats.Calls, stats.Errors, stats.Duration)
+//
+// Each operation is reported to a [Metrics] implementation along with its
+// latency and error. The operations are named after the store methods, such as
+// AddVertex or ListEdges. [Recorder] keeps the metrics in memory. To export
+// the metrics to a monitoring system, use a [MetricsFunc]. For example, this
+// records the metrics in a Prometheus histogram:
+//
+//	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+//		Name: "graph_store_operation_duration_seconds",
+//	}, []string{"operation", "status"})
+//
+//	metrics := instrumentedstore.MetricsFunc(func(operation string, duration time.Duration, err error) {
+//		status := "ok"
+//		if err != nil {
+//			status = "error"
+//		}
+//		latency.WithLabelValues(operation, status).Observe(duration.Seconds())
+//	})
+//
+// The histogram already provides the number of calls as its count, so there is
+// no need for a separate counter.
+package instrumentedstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dominikbraun/graph"
+)
+
+// Metrics receives the metrics for each store operation. Observe is called
+// after the operation has completed, with the name of the store method, its
+// latency, and the error it returned, if any. Note that some errors such as
+// graph.ErrEdgeNotFound are part of the regular operation of graphs.
+//
+// Observe may be called concurrently and must not access the store.
+type Metrics interface {
+	Observe(operation string, duration time.Duration, err error)
+}
+
+// MetricsFunc is an adapter that allows to use an ordinary function as Metrics.
+type MetricsFunc func(operation string, duration time.Duration, err error)
+
+// Observe calls f(operation, duration, err).
+func (f MetricsFunc) Observe(operation string, duration time.Duration, err error) {
+	f(operation, duration, err)
+}
+
+// Stats contains the metrics of a single store operation.
+type Stats struct {
+	Calls    int64
+	Errors   int64
+	Duration time.Duration
+}
+
+// Recorder is a Metrics implementation that keeps the metrics in memory. It is
+// safe for concurrent use.
+type Recorder struct {
+	lock  sync.Mutex
+	stats map[string]Stats
+}
+
+// NewRecorder creates a new, empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		stats: make(map[string]Stats),
+	}
+}
+
+// Observe adds the call to the metrics of the given operation.
+func (r *Recorder) Observe(operation string, duration time.Duration, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	stats := r.stats[operation]
+	stats.Calls++
+	stats.Duration += duration
+	if err != nil {
+		stats.Errors++
+	}
+
+	r.stats[operation] = stats
+}
+
+// Stats returns a copy of the metrics recorded so far, keyed by operation.
+// Operations that haven't been called aren't contained in the map.
+func (r *Recorder) Stats() map[string]Stats {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	stats := make(map[string]Stats, len(r.stats))
+	for operation, s := range r.stats {
+		stats[operation] = s
+	}
+
+	return stats
+}
+
+// Reset removes all metrics recorded so far.
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.stats = make(map[string]Stats)
+}
+
+// Wrap returns a store that reports each operation on s to the given metrics
+// and then forwards it to s.
+//
+// The returned store implements graph.VertexIterator and graph.NeighborStore if
+// s does, so that graphs and algorithms access the wrapped store the same way
+// as s. It always implements graph.EdgeIterator, which falls back to ListEdges
+// if s doesn't stream its edges. Other optional interfaces aren't forwarded.
+func Wrap[K comparable, T any](s graph.Store[K, T], metrics Metrics) graph.Store[K, T] {
+	base := &store[K, T]{
+		store:   s,
+		metrics: metrics,
+	}
+
+	_, isIterator := s.(graph.VertexIterator[K, T])
+	_, isNeighborStore := s.(graph.NeighborStore[K])
+
+	switch {
+	case isIterator && isNeighborStore:
+		return &iteratingNeighborStore[K, T]{
+			store:          base,
+			vertexIterator: vertexIterator[K, T]{base},
+			neighborLookup: neighborLookup[K, T]{base},
+		}
+	case isIterator:
+		return &iteratingStore[K, T]{
+			store:          base,
+			vertexIterator: vertexIterator[K, T]{base},
+		}
+	case isNeighborStore:
+		return &neighborStore[K, T]{
+			store:          base,
+			neighborLookup: neighborLookup[K, T]{base},
+		}
+	default:
+		return base
+	}
+}
+
+type store[K comparable, T any] struct {
+	store   graph.Store[K, T]
+	metrics Metrics
+}
+
+func (s *store[K, T]) observe(operation string, start time.Time, err error) {
+	s.metrics.Observe(operation, time.Since(start), err)
+}
+
+func (s *store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	start := time.Now()
+	err := s.store.AddVertex(hash, value, properties)
+	s.observe("AddVertex", start, err)
+
+	return err
+}
+
+func (s *store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	start := time.Now()
+	value, properties, err := s.store.Vertex(hash)
+	s.observe("Vertex", start, err)
+
+	return value, properties, err
+}
+
+func (s *store[K, T]) RemoveVertex(hash K) error {
+	start := time.Now()
+	err := s.store.RemoveVertex(hash)
+	s.observe("RemoveVertex", start, err)
+
+	return err
+}
+
+func (s *store[K, T]) ListVertices() ([]K, error) {
+	start := time.Now()
+	hashes, err := s.store.ListVertices()
+	s.observe("ListVertices", start, err)
+
+	return hashes, err
+}
+
+func (s *store[K, T]) VertexCount() (int, error) {
+	start := time.Now()
+	count, err := s.store.VertexCount()
+	s.observe("VertexCount", start, err)
+
+	return count, err
+}
+
+func (s *store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	start := time.Now()
+	err := s.store.AddEdge(sourceHash, targetHash, edge)
+	s.observe("AddEdge", start, err)
+
+	return err
+}
+
+func (s *store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	start := time.Now()
+	err := s.store.UpdateEdge(sourceHash, targetHash, edge)
+	s.observe("UpdateEdge", start, err)
+
+	return err
+}
+
+func (s *store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	start := time.Now()
+	err := s.store.RemoveEdge(sourceHash, targetHash)
+	s.observe("RemoveEdge", start, err)
+
+	return err
+}
+
+func (s *store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	start := time.Now()
+	edge, err := s.store.Edge(sourceHash, targetHash)
+	s.observe("Edge", start, err)
+
+	return edge, err
+}
+
+func (s *store[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	start := time.Now()
+	edges, err := s.store.ListEdges()
+	s.observe("ListEdges", start, err)
+
+	return edges, err
+}
+
+func (s *store[K, T]) EdgeCount() (int, error) {
+	start := time.Now()
+	count, err := s.store.EdgeCount()
+	s.observe("EdgeCount", start, err)
+
+	return count, err
+}
+
+// IterEdges streams the edges of the underlying store. The reported latency
+// includes the time spent in yield.
+func (s *store[K, T]) IterEdges(yield func(edge graph.Edge[K]) bool) error {
+	start := time.Now()
+
+	iterator, ok := s.store.(graph.EdgeIterator[K])
+	if !ok {
+		edges, err := s.store.ListEdges()
+		s.observe("ListEdges", start, err)
+		if err != nil {
+			return err
+		}
+
+		for _, edge := range edges {
+			if !yield(edge) {
+				break
+			}
+		}
+
+		return nil
+	}
+
+	err := iterator.IterEdges(yield)
+	s.observe("IterEdges", start, err)
+
+	return err
+}
+
+type vertexIterator[K comparable, T any] struct {
+	s *store[K, T]
+}
+
+// IterVertices streams the vertices of the underlying store. The reported
+// latency includes the time spent in yield.
+func (v vertexIterator[K, T]) IterVertices(yield func(hash K, value T, properties graph.VertexProperties) bool) error {
+	start := time.Now()
+	err := v.s.store.(graph.VertexIterator[K, T]).IterVertices(yield)
+	v.s.observe("IterVertices", start, err)
+
+	return err
+}
+
+type neighborLookup[K comparable, T any] struct {
+	s *store[K, T]
+}
+
+func (n neighborLookup[K, T]) EdgesBySource(sourceHash K) ([]graph.Edge[K], error) {
+	start := time.Now()
+	edges, err := n.s.store.(graph.NeighborStore[K]).EdgesBySource(sourceHash)
+	n.s.observe("EdgesBySource", start, err)
+
+	return edges, err
+}
+
+func (n neighborLookup[K, T]) EdgesByTarget(targetHash K) ([]graph.Edge[K], error) {
+	start := time.Now()
+	edges, err := n.s.store.(graph.NeighborStore[K]).EdgesByTarget(targetHash)
+	n.s.observe("EdgesByTarget", start, err)
+
+	return edges, err
+}
+
+type iteratingStore[K comparable, T any] struct {
+	*store[K, T]
+	vertexIterator[K, T]
+}
+
+type neighborStore[K comparable, T any] struct {
+	*store[K, T]
+	neighborLookup[K, T]
+}
+
+type iteratingNeighborStore[K comparable, T any] struct {
+	*store[K, T]
+	vertexIterator[K, T]
+	neighborLookup[K, T]
+}
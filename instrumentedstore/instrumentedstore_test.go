@@ -0,0 +1,143 @@
+package instrumentedstore
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/filestore"
+)
+
+// lookupStore adds the NeighborStore methods to a filestore.
+type lookupStore struct {
+	*filestore.Store[string, string]
+}
+
+func (s lookupStore) EdgesBySource(sourceHash string) ([]graph.Edge[string], error) {
+	return s.edges(func(edge graph.Edge[string]) bool { return edge.Source == sourceHash })
+}
+
+func (s lookupStore) EdgesByTarget(targetHash string) ([]graph.Edge[string], error) {
+	return s.edges(func(edge graph.Edge[string]) bool { return edge.Target == targetHash })
+}
+
+func (s lookupStore) edges(match func(graph.Edge[string]) bool) ([]graph.Edge[string], error) {
+	edges, err := s.ListEdges()
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []graph.Edge[string]
+	for _, edge := range edges {
+		if match(edge) {
+			matching = append(matching, edge)
+		}
+	}
+
+	return matching, nil
+}
+
+func openStore(t *testing.T) *filestore.Store[string, string] {
+	store, err := filestore.Open[string, string](filepath.Join(t.TempDir(), "graph.log"))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return store
+}
+
+func TestWrap(t *testing.T) {
+	recorder := NewRecorder()
+	store := Wrap[string, string](openStore(t), recorder)
+
+	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddEdge("A", "B")
+
+	if err := g.AddVertex("A"); !errors.Is(err, graph.ErrVertexAlreadyExists) {
+		t.Fatalf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexAlreadyExists, err)
+	}
+
+	if _, err := g.AdjacencyMap(); err != nil {
+		t.Fatalf("failed to get adjacency map: %v", err)
+	}
+
+	stats := recorder.Stats()
+
+	if stats["AddVertex"].Calls != 3 || stats["AddVertex"].Errors != 1 {
+		t.Errorf("AddVertex stats don't match: expected 3 calls and 1 error, got %+v", stats["AddVertex"])
+	}
+
+	if stats["AddEdge"].Calls != 1 {
+		t.Errorf("AddEdge calls don't match: expected %v, got %v", 1, stats["AddEdge"].Calls)
+	}
+
+	if stats["ListVertices"].Calls != 1 || stats["ListEdges"].Calls != 1 {
+		t.Errorf("expected AdjacencyMap to list vertices and edges once, got %+v", stats)
+	}
+
+	recorder.Reset()
+
+	if len(recorder.Stats()) != 0 {
+		t.Errorf("expected no stats after reset, got %v", recorder.Stats())
+	}
+}
+
+func TestWrap_neighborStore(t *testing.T) {
+	recorder := NewRecorder()
+	store := Wrap[string, string](lookupStore{openStore(t)}, recorder)
+
+	if _, ok := store.(graph.NeighborStore[string]); !ok {
+		t.Fatalf("expected wrapped store to implement NeighborStore")
+	}
+
+	if _, ok := store.(graph.VertexIterator[string, string]); ok {
+		t.Errorf("expected wrapped store not to implement VertexIterator")
+	}
+
+	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddVertex("C")
+	_ = g.AddEdge("A", "B")
+	_ = g.AddEdge("B", "C")
+
+	path, err := graph.ShortestPath(g, "A", "C")
+	if err != nil {
+		t.Fatalf("failed to get shortest path: %v", err)
+	}
+
+	if len(path) != 3 {
+		t.Errorf("path doesn't match: expected %v, got %v", []string{"A", "B", "C"}, path)
+	}
+
+	if recorder.Stats()["EdgesBySource"].Calls == 0 {
+		t.Errorf("expected ShortestPath to call EdgesBySource, got %+v", recorder.Stats())
+	}
+}
+
+func TestMetricsFunc(t *testing.T) {
+	var operations []string
+
+	metrics := MetricsFunc(func(operation string, _ time.Duration, _ error) {
+		operations = append(operations, operation)
+	})
+
+	store := Wrap[string, string](openStore(t), metrics)
+
+	_ = store.AddVertex("A", "A", graph.VertexProperties{})
+	_, _ = store.VertexCount()
+
+	if len(operations) != 2 || operations[0] != "AddVertex" || operations[1] != "VertexCount" {
+		t.Errorf("operations don't match: expected %v, got %v", []string{"AddVertex", "VertexCount"}, operations)
+	}
+}
@@ -0,0 +1,94 @@
+// Package storage contains the encodings shared by the store implementations
+// in this repository, such as graphsql, boltstore, badgerstore, and redistore.
+// Sharing them ensures that all stores encode hashes and edges the same way.
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+)
+
+// EncodeHash encodes the given hash for using it as a key or column value.
+// Strings are used as they are, all other types are encoded as JSON.
+func EncodeHash[K comparable](hash K) (string, error) {
+	if s, ok := any(hash).(string); ok {
+		return s, nil
+	}
+
+	encoded, err := json.Marshal(hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode hash %v: %w", hash, err)
+	}
+
+	return string(encoded), nil
+}
+
+// DecodeHash decodes a hash that has been encoded using EncodeHash.
+func DecodeHash[K comparable](encoded string) (K, error) {
+	var hash K
+
+	if s, ok := any(&hash).(*string); ok {
+		*s = encoded
+		return hash, nil
+	}
+
+	if err := json.Unmarshal([]byte(encoded), &hash); err != nil {
+		return hash, fmt.Errorf("failed to decode hash %s: %w", encoded, err)
+	}
+
+	return hash, nil
+}
+
+// edgeRecord is the JSON representation of the properties of an edge. The
+// source and target hashes are stored by the stores themselves.
+type edgeRecord struct {
+	Weight     int               `json:"weight"`
+	Attributes map[string]string `json:"attributes"`
+	Data       any               `json:"data"`
+}
+
+// EncodeEdge encodes the properties of the given edge as JSON.
+func EncodeEdge[K comparable](edge graph.Edge[K]) ([]byte, error) {
+	record, err := json.Marshal(edgeRecord{
+		Weight:     edge.Properties.Weight,
+		Attributes: edge.Properties.Attributes,
+		Data:       edge.Properties.Data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode edge: %w", err)
+	}
+
+	return record, nil
+}
+
+// DecodeEdge decodes edge properties that have been encoded using EncodeEdge
+// and returns the edge between the given source and target.
+func DecodeEdge[K comparable](source, target K, data []byte) (graph.Edge[K], error) {
+	var record edgeRecord
+
+	if err := json.Unmarshal(data, &record); err != nil {
+		return graph.Edge[K]{}, fmt.Errorf("failed to decode edge: %w", err)
+	}
+
+	return graph.Edge[K]{
+		Source: source,
+		Target: target,
+		Properties: graph.EdgeProperties{
+			Weight:     record.Weight,
+			Attributes: Attributes(record.Attributes),
+			Data:       record.Data,
+		},
+	}, nil
+}
+
+// Attributes returns the given attributes, or an empty map if they are nil.
+// The graph implementations expect attributes to never be nil.
+func Attributes(attributes map[string]string) map[string]string {
+	if attributes == nil {
+		return make(map[string]string)
+	}
+
+	return attributes
+}
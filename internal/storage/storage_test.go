@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestHash(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+
+	tests := map[string]struct {
+		hash     any
+		expected string
+	}{
+		"string": {
+			hash:     "A",
+			expected: "A",
+		},
+		"int": {
+			hash:     10,
+			expected: "10",
+		},
+		"struct": {
+			hash:     point{1, 2},
+			expected: `{"X":1,"Y":2}`,
+		},
+	}
+
+	for name, test := range tests {
+		var (
+			encoded string
+			decoded any
+			err     error
+		)
+
+		switch hash := test.hash.(type) {
+		case string:
+			encoded, _ = EncodeHash(hash)
+			decoded, err = DecodeHash[string](encoded)
+		case int:
+			encoded, _ = EncodeHash(hash)
+			decoded, err = DecodeHash[int](encoded)
+		case point:
+			encoded, _ = EncodeHash(hash)
+			decoded, err = DecodeHash[point](encoded)
+		}
+
+		if encoded != test.expected {
+			t.Errorf("%s: encoded hash doesn't match: expected %v, got %v", name, test.expected, encoded)
+		}
+
+		if err != nil || decoded != test.hash {
+			t.Errorf("%s: decoded hash doesn't match: expected %v, got %v (error: %v)", name, test.hash, decoded, err)
+		}
+	}
+}
+
+func TestEdge(t *testing.T) {
+	edge := graph.Edge[string]{
+		Source: "A",
+		Target: "B",
+		Properties: graph.EdgeProperties{
+			Weight: 3,
+			Data:   "data",
+		},
+	}
+
+	data, err := EncodeEdge(edge)
+	if err != nil {
+		t.Fatalf("failed to encode edge: %v", err)
+	}
+
+	decoded, err := DecodeEdge("A", "B", data)
+	if err != nil {
+		t.Fatalf("failed to decode edge: %v", err)
+	}
+
+	if decoded.Properties.Weight != 3 || decoded.Properties.Data != "data" || decoded.Properties.Attributes == nil {
+		t.Errorf("edge doesn't match: expected %v, got %v", edge, decoded)
+	}
+}
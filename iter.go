@@ -0,0 +1,47 @@
+package graph
+
+import "fmt"
+
+// IterEdges calls yield for each edge of the graph and stops as soon as yield
+// returns false. Unlike Edges, it doesn't collect the edges in a slice: If the
+// store of the graph implements [EdgeIterator], the edges are streamed from the
+// store, so that huge graphs can be exported with constant memory overhead.
+//
+// Like Edges, IterEdges yields each edge of an undirected graph only once. The
+// order of the edges is defined by the store. yield must not modify the graph,
+// since stores may hold locks while iterating.
+func IterEdges[K comparable, T any](g Graph[K, T], yield func(edge Edge[K]) bool) error {
+	store, ok := storeOf(g)
+	if !ok {
+		edges, err := g.Edges()
+		if err != nil {
+			return fmt.Errorf("failed to get edges: %w", err)
+		}
+
+		for _, edge := range edges {
+			if !yield(edge) {
+				break
+			}
+		}
+
+		return nil
+	}
+
+	isDirected := g.Traits().IsDirected
+	isCanonical := canonicalDirection[K]()
+
+	err := iterEdges(store, func(edge Edge[K]) bool {
+		// An undirected graph stores each edge in both directions. Instead of
+		// keeping track of the yielded edges, only the canonical direction is
+		// yielded.
+		if !isDirected && !isCanonical(edge) {
+			return true
+		}
+		return yield(edge)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to iterate edges: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestIterEdges(t *testing.T) {
+	tests := map[string]struct {
+		traits        []func(*Traits)
+		edges         []Edge[int]
+		stopAfter     int
+		expectedCount int
+	}{
+		"directed graph": {
+			traits:        []func(*Traits){Directed()},
+			edges:         []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 1}, {Source: 2, Target: 3}},
+			expectedCount: 3,
+		},
+		"undirected graph": {
+			edges:         []Edge[int]{{Source: 2, Target: 1}, {Source: 2, Target: 3}, {Source: 3, Target: 3}},
+			expectedCount: 3,
+		},
+		"stop early": {
+			traits:        []func(*Traits){Directed()},
+			edges:         []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 3}, {Source: 3, Target: 1}},
+			stopAfter:     2,
+			expectedCount: 2,
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash, test.traits...)
+
+		for i := 1; i <= 3; i++ {
+			_ = g.AddVertex(i)
+		}
+
+		for _, edge := range test.edges {
+			if err := g.AddEdge(edge.Source, edge.Target); err != nil {
+				t.Fatalf("%s: failed to add edge: %v", name, err)
+			}
+		}
+
+		count := 0
+
+		err := IterEdges(g, func(edge Edge[int]) bool {
+			if _, err := g.Edge(edge.Source, edge.Target); err != nil {
+				t.Errorf("%s: unexpected edge (%v, %v)", name, edge.Source, edge.Target)
+			}
+			count++
+			return test.stopAfter == 0 || count < test.stopAfter
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if count != test.expectedCount {
+			t.Errorf("%s: edge count doesn't match: expected %v, got %v", name, test.expectedCount, count)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package layout
+
+import (
+	"math"
+
+	"github.com/dominikbraun/graph"
+)
+
+// ForceDirected computes the positions of all vertices using the algorithm by
+// Fruchterman and Reingold. Vertices repel each other, while edges pull their
+// vertices together, so that the layout converges to a state where connected
+// vertices are close to each other and edges have a similar length:
+//
+//	positions, _ := layout.ForceDirected(g, layout.Size(800, 600))
+//
+//	for hash, position := range positions {
+//		fmt.Printf("%v is at (%.0f, %.0f)\n", hash, position.X, position.Y)
+//	}
+//
+// The runtime of each iteration is quadratic in the number of vertices.
+func ForceDirected[K comparable, T any](g graph.Graph[K, T], options ...func(*config)) (map[K]Position, error) {
+	c, err := newConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	lg, err := newLayoutGraph(g)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(lg.hashes)
+	if n == 0 {
+		return fit(lg, nil, c), nil
+	}
+
+	// The layout is computed in a unit square and scaled afterwards.
+	k := math.Sqrt(1 / float64(n))
+	positions := initialPositions(n, 0.5)
+	displacements := make([]Position, n)
+
+	temperature := 0.1
+
+	for iteration := 0; iteration < c.iterations; iteration++ {
+		for i := range displacements {
+			displacements[i] = Position{}
+		}
+
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				dx, dy, distance := delta(positions[i], positions[j], i, j)
+				force := k * k / distance
+				displacements[i].X += dx / distance * force
+				displacements[i].Y += dy / distance * force
+				displacements[j].X -= dx / distance * force
+				displacements[j].Y -= dy / distance * force
+			}
+		}
+
+		for i, neighbors := range lg.neighbors {
+			for _, j := range neighbors {
+				// Each edge is contained twice, so only handle it once.
+				if j < i {
+					continue
+				}
+				dx, dy, distance := delta(positions[i], positions[j], i, j)
+				force := distance * distance / k
+				displacements[i].X -= dx / distance * force
+				displacements[i].Y -= dy / distance * force
+				displacements[j].X += dx / distance * force
+				displacements[j].Y += dy / distance * force
+			}
+		}
+
+		// The displacement is limited by the temperature, which decreases
+		// linearly so that the layout settles down.
+		for i, d := range displacements {
+			length := math.Hypot(d.X, d.Y)
+			if length == 0 {
+				continue
+			}
+			limited := math.Min(length, temperature)
+			// Like in the original algorithm, the vertices are kept within
+			// the frame, so that disconnected vertices don't drift away.
+			positions[i].X = math.Max(-0.5, math.Min(0.5, positions[i].X+d.X/length*limited))
+			positions[i].Y = math.Max(-0.5, math.Min(0.5, positions[i].Y+d.Y/length*limited))
+		}
+
+		temperature -= 0.1 / float64(c.iterations)
+	}
+
+	return fit(lg, positions, c), nil
+}
+
+// delta returns the difference vector between a and b as well as its length.
+// If both positions are equal, a small deterministic offset based on the
+// vertex indices is returned so that the vertices can be pushed apart.
+func delta(a, b Position, i, j int) (float64, float64, float64) {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	distance := math.Hypot(dx, dy)
+
+	if distance < 1e-9 {
+		angle := float64(i*31+j) * 0.618
+		dx, dy = 1e-9*math.Cos(angle), 1e-9*math.Sin(angle)
+		distance = 1e-9
+	}
+
+	return dx, dy, distance
+}
@@ -0,0 +1,192 @@
+// Package layout computes 2D coordinates for the vertices of a graph, which
+// allows rendering graphs in custom user interfaces without Graphviz. Two
+// algorithms are available: [ForceDirected] implements the force-directed
+// algorithm by Fruchterman and Reingold, and [Stress] implements stress
+// majorization, which preserves graph-theoretic distances more faithfully.
+//
+// Both algorithms are deterministic: For the same graph and options, they
+// always return the same positions. Edges are treated as undirected and edge
+// weights are ignored.
+package layout
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dominikbraun/graph"
+)
+
+// Position is the position of a vertex in a 2D coordinate system. The origin is
+// at the top left corner, like in most user interfaces.
+type Position struct {
+	X float64
+	Y float64
+}
+
+type config struct {
+	iterations int
+	width      float64
+	height     float64
+}
+
+// Iterations is a functional option that sets the maximum number of iterations
+// of the layout algorithm. More iterations yield a better layout but take more
+// time. The default is 300.
+func Iterations(iterations int) func(*config) {
+	return func(c *config) {
+		c.iterations = iterations
+	}
+}
+
+// Size is a functional option that sets the size of the area the graph is laid
+// out in. All positions lie within [0, width] × [0, height]. The layout is
+// scaled uniformly to fit into the area and centered. The default size is 1×1.
+func Size(width, height float64) func(*config) {
+	return func(c *config) {
+		c.width = width
+		c.height = height
+	}
+}
+
+// layoutGraph is a graph with vertices identified by their index, which makes
+// the computations in the algorithms cheap.
+type layoutGraph[K comparable] struct {
+	hashes    []K
+	neighbors [][]int
+}
+
+func newConfig(options []func(*config)) (config, error) {
+	c := config{
+		iterations: 300,
+		width:      1,
+		height:     1,
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	if c.iterations < 0 {
+		return c, fmt.Errorf("number of iterations must not be negative: %d", c.iterations)
+	}
+
+	if c.width <= 0 || c.height <= 0 {
+		return c, fmt.Errorf("size must be positive: %v×%v", c.width, c.height)
+	}
+
+	return c, nil
+}
+
+// newLayoutGraph converts the given graph into a layoutGraph. The vertices are
+// sorted by their hashes formatted using fmt.Sprint for a deterministic result.
+func newLayoutGraph[K comparable, T any](g graph.Graph[K, T]) (layoutGraph[K], error) {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return layoutGraph[K]{}, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	labels := make(map[K]string, len(adjacencyMap))
+	hashes := make([]K, 0, len(adjacencyMap))
+
+	for hash := range adjacencyMap {
+		labels[hash] = fmt.Sprint(hash)
+		hashes = append(hashes, hash)
+	}
+
+	sort.Slice(hashes, func(i, j int) bool {
+		return labels[hashes[i]] < labels[hashes[j]]
+	})
+
+	indices := make(map[K]int, len(hashes))
+	for i, hash := range hashes {
+		indices[hash] = i
+	}
+
+	neighbors := make([]map[int]struct{}, len(hashes))
+	for i := range neighbors {
+		neighbors[i] = make(map[int]struct{})
+	}
+
+	for source, adjacencies := range adjacencyMap {
+		for target := range adjacencies {
+			if source == target {
+				continue
+			}
+			neighbors[indices[source]][indices[target]] = struct{}{}
+			neighbors[indices[target]][indices[source]] = struct{}{}
+		}
+	}
+
+	lg := layoutGraph[K]{
+		hashes:    hashes,
+		neighbors: make([][]int, len(hashes)),
+	}
+
+	for i, set := range neighbors {
+		for j := range set {
+			lg.neighbors[i] = append(lg.neighbors[i], j)
+		}
+		sort.Ints(lg.neighbors[i])
+	}
+
+	return lg, nil
+}
+
+// initialPositions places the vertices on a circle, which is a deterministic
+// starting point for the algorithms.
+func initialPositions(n int, radius float64) []Position {
+	positions := make([]Position, n)
+
+	for i := range positions {
+		angle := 2 * math.Pi * float64(i) / float64(n)
+		positions[i] = Position{
+			X: radius * math.Cos(angle),
+			Y: radius * math.Sin(angle),
+		}
+	}
+
+	return positions
+}
+
+// fit scales and translates the positions uniformly so that they fit into the
+// area of the given config, and returns them keyed by vertex hash.
+func fit[K comparable](lg layoutGraph[K], positions []Position, c config) map[K]Position {
+	result := make(map[K]Position, len(positions))
+
+	if len(positions) == 0 {
+		return result
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+
+	for _, p := range positions {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+
+	scale := math.Inf(1)
+	if maxX > minX {
+		scale = c.width / (maxX - minX)
+	}
+	if maxY > minY {
+		scale = math.Min(scale, c.height/(maxY-minY))
+	}
+	// All vertices are at the same position, e.g. if there is only one.
+	if math.IsInf(scale, 1) {
+		scale = 0
+	}
+
+	offsetX := (c.width - (maxX-minX)*scale) / 2
+	offsetY := (c.height - (maxY-minY)*scale) / 2
+
+	for i, p := range positions {
+		result[lg.hashes[i]] = Position{
+			X: offsetX + (p.X-minX)*scale,
+			Y: offsetY + (p.Y-minY)*scale,
+		}
+	}
+
+	return result
+}
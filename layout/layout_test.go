@@ -0,0 +1,116 @@
+package layout
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestLayouts(t *testing.T) {
+	algorithms := map[string]func(graph.Graph[string, string], ...func(*config)) (map[string]Position, error){
+		"force-directed": ForceDirected[string, string],
+		"stress":         Stress[string, string],
+	}
+
+	for name, algorithm := range algorithms {
+		// A path A-B-C-D-E and an isolated vertex F.
+		g := graph.New(graph.StringHash, graph.Directed())
+
+		for _, vertex := range []string{"A", "B", "C", "D", "E", "F"} {
+			_ = g.AddVertex(vertex)
+		}
+		_ = g.AddEdge("A", "B")
+		_ = g.AddEdge("B", "C")
+		_ = g.AddEdge("C", "D")
+		_ = g.AddEdge("D", "E")
+
+		positions, err := algorithm(g, Size(800, 600))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if len(positions) != 6 {
+			t.Fatalf("%s: number of positions doesn't match: expected %v, got %v", name, 6, len(positions))
+		}
+
+		for hash, p := range positions {
+			if p.X < 0 || p.X > 800 || p.Y < 0 || p.Y > 600 || math.IsNaN(p.X) || math.IsNaN(p.Y) {
+				t.Errorf("%s: position of %v is out of bounds: %v", name, hash, p)
+			}
+		}
+
+		distance := func(a, b string) float64 {
+			return math.Hypot(positions[a].X-positions[b].X, positions[a].Y-positions[b].Y)
+		}
+
+		if distance("A", "B") >= distance("A", "E") {
+			t.Errorf("%s: adjacent vertices are further apart than the ends of the path: %v >= %v", name, distance("A", "B"), distance("A", "E"))
+		}
+
+		again, _ := algorithm(g, Size(800, 600))
+		for hash, p := range positions {
+			if again[hash] != p {
+				t.Errorf("%s: layout isn't deterministic: expected %v for %v, got %v", name, p, hash, again[hash])
+			}
+		}
+	}
+}
+
+func TestLayouts_edgeCases(t *testing.T) {
+	tests := map[string]struct {
+		vertices   []string
+		options    []func(*config)
+		expected   map[string]Position
+		shouldFail bool
+	}{
+		"empty graph": {
+			expected: map[string]Position{},
+		},
+		"single vertex": {
+			vertices: []string{"A"},
+			options:  []func(*config){Size(10, 20)},
+			expected: map[string]Position{"A": {X: 5, Y: 10}},
+		},
+		"invalid size": {
+			options:    []func(*config){Size(0, 10)},
+			shouldFail: true,
+		},
+		"negative iterations": {
+			options:    []func(*config){Iterations(-1)},
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		g := graph.New(graph.StringHash)
+		for _, vertex := range test.vertices {
+			_ = g.AddVertex(vertex)
+		}
+
+		for _, algorithm := range []func(graph.Graph[string, string], ...func(*config)) (map[string]Position, error){
+			ForceDirected[string, string],
+			Stress[string, string],
+		} {
+			positions, err := algorithm(g, test.options...)
+
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+			}
+
+			if test.shouldFail {
+				continue
+			}
+
+			if len(positions) != len(test.expected) {
+				t.Fatalf("%s: number of positions doesn't match: expected %v, got %v", name, len(test.expected), len(positions))
+			}
+
+			for hash, expected := range test.expected {
+				if positions[hash] != expected {
+					t.Errorf("%s: position of %v doesn't match: expected %v, got %v", name, hash, expected, positions[hash])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package layout
+
+import (
+	"math"
+
+	"github.com/dominikbraun/graph"
+)
+
+// Stress computes the positions of all vertices using stress majorization. It
+// places the vertices such that their Euclidean distances match the lengths of
+// the shortest paths between them as closely as possible, which often results
+// in clearer layouts than [ForceDirected], particularly for sparse graphs:
+//
+//	positions, _ := layout.Stress(g, layout.Size(800, 600))
+//
+// The distance between vertices in different components is considered to be
+// one more than the longest shortest path in the graph. The algorithm stops
+// after the configured number of iterations or once the positions don't change
+// notably anymore. It requires memory quadratic in the number of vertices.
+func Stress[K comparable, T any](g graph.Graph[K, T], options ...func(*config)) (map[K]Position, error) {
+	c, err := newConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
+	lg, err := newLayoutGraph(g)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(lg.hashes)
+	if n == 0 {
+		return fit(lg, nil, c), nil
+	}
+
+	distances := shortestDistances(lg)
+	positions := initialPositions(n, float64(n)/(2*math.Pi))
+
+	for iteration := 0; iteration < c.iterations; iteration++ {
+		movement := 0.0
+
+		for i := 0; i < n; i++ {
+			var x, y, weights float64
+
+			for j := 0; j < n; j++ {
+				if i == j {
+					continue
+				}
+
+				d := distances[i][j]
+				w := 1 / (d * d)
+
+				dx, dy, distance := delta(positions[i], positions[j], i, j)
+
+				x += w * (positions[j].X + d*dx/distance)
+				y += w * (positions[j].Y + d*dy/distance)
+				weights += w
+			}
+
+			if weights == 0 {
+				continue
+			}
+
+			next := Position{X: x / weights, Y: y / weights}
+			movement += math.Hypot(next.X-positions[i].X, next.Y-positions[i].Y)
+			positions[i] = next
+		}
+
+		if movement/float64(n) < 1e-4 {
+			break
+		}
+	}
+
+	return fit(lg, positions, c), nil
+}
+
+// shortestDistances computes the lengths of the shortest paths between all
+// pairs of vertices using a breadth-first search from each vertex.
+func shortestDistances[K comparable](lg layoutGraph[K]) [][]float64 {
+	n := len(lg.hashes)
+	distances := make([][]float64, n)
+	longest := 0.0
+
+	for source := range distances {
+		distances[source] = make([]float64, n)
+		for i := range distances[source] {
+			distances[source][i] = -1
+		}
+		distances[source][source] = 0
+
+		queue := []int{source}
+
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+
+			for _, neighbor := range lg.neighbors[current] {
+				if distances[source][neighbor] >= 0 {
+					continue
+				}
+				distances[source][neighbor] = distances[source][current] + 1
+				longest = math.Max(longest, distances[source][neighbor])
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	for _, row := range distances {
+		for i := range row {
+			if row[i] < 0 {
+				row[i] = longest + 1
+			}
+		}
+	}
+
+	return distances
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrLimitExceeded is returned by expensive algorithms like AllPathsBetween if
+// one of the limits passed to them has been exceeded.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// Limits bounds the resources that a single call to an expensive algorithm may
+// consume. A zero value for any of the fields means that there is no limit.
+//
+// Limits are set using functional options like MaxVisited, Timeout, and
+// MaxResults, which are accepted by AllPathsBetween and TransitiveReduction:
+//
+//	paths, err := graph.AllPathsBetween(g, 1, 5, graph.MaxVisited(10000), graph.Timeout(time.Second))
+//	if errors.Is(err, graph.ErrLimitExceeded) {
+//		// paths contains the paths found before the limit has been exceeded.
+//	}
+type Limits struct {
+	MaxVisited int
+	Timeout    time.Duration
+	MaxResults int
+}
+
+// MaxVisited limits the number of times an algorithm may visit a vertex. Some
+// algorithms visit the same vertex more than once, and each visit counts.
+func MaxVisited(n int) func(*Limits) {
+	return func(l *Limits) {
+		l.MaxVisited = n
+	}
+}
+
+// Timeout limits the time an algorithm may run for. The deadline is checked
+// periodically while visiting vertices, so an algorithm may run slightly
+// longer than the given duration.
+func Timeout(d time.Duration) func(*Limits) {
+	return func(l *Limits) {
+		l.Timeout = d
+	}
+}
+
+// MaxResults limits the number of results an algorithm computes, for example
+// the number of paths computed by AllPathsBetween. Once the limit is reached,
+// the algorithm stops and returns the results found so far without an error.
+// MaxResults has no effect on algorithms that compute a single result.
+func MaxResults(n int) func(*Limits) {
+	return func(l *Limits) {
+		l.MaxResults = n
+	}
+}
+
+// deadlineCheckInterval is the number of visits after which a guard checks its
+// deadline. Calling time.Now for each visit would be noticeably slower.
+const deadlineCheckInterval = 256
+
+// guard enforces a set of limits during a single run of an algorithm.
+type guard struct {
+	limits   Limits
+	deadline time.Time
+	visited  int
+}
+
+func newGuard(options []func(*Limits)) *guard {
+	var limits Limits
+
+	for _, option := range options {
+		option(&limits)
+	}
+
+	g := guard{
+		limits: limits,
+	}
+
+	if limits.Timeout > 0 {
+		g.deadline = time.Now().Add(limits.Timeout)
+	}
+
+	return &g
+}
+
+// visit records a vertex visit. It returns an error wrapping ErrLimitExceeded
+// if the maximum number of visits has been exceeded or the deadline has been
+// reached.
+func (g *guard) visit() error {
+	g.visited++
+
+	if g.limits.MaxVisited > 0 && g.visited > g.limits.MaxVisited {
+		return fmt.Errorf("visited more than %d vertices: %w", g.limits.MaxVisited, ErrLimitExceeded)
+	}
+
+	if !g.deadline.IsZero() && g.visited%deadlineCheckInterval == 1 && time.Now().After(g.deadline) {
+		return fmt.Errorf("timeout of %v reached: %w", g.limits.Timeout, ErrLimitExceeded)
+	}
+
+	return nil
+}
+
+// resultsReached reports whether the given number of results satisfies the
+// maximum number of results.
+func (g *guard) resultsReached(results int) bool {
+	return g.limits.MaxResults > 0 && results >= g.limits.MaxResults
+}
@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// completeDAG creates a directed acyclic graph with an edge from each vertex to
+// all vertices with a greater hash. The number of paths between its first and
+// last vertex is 2^(n-2).
+func completeDAG(n int) Graph[int, int] {
+	g := New(IntHash, Directed(), Acyclic())
+
+	for i := 0; i < n; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			_ = g.AddEdge(i, j)
+		}
+	}
+
+	return g
+}
+
+func TestAllPathsBetween_limits(t *testing.T) {
+	tests := map[string]struct {
+		vertices      int
+		limits        []func(*Limits)
+		expectedPaths int
+		maxPaths      int
+		shouldFail    bool
+	}{
+		"no limits": {
+			vertices:      8,
+			expectedPaths: 64,
+		},
+		"limits that aren't exceeded": {
+			vertices:      8,
+			limits:        []func(*Limits){MaxVisited(1000), Timeout(time.Minute), MaxResults(100)},
+			expectedPaths: 64,
+		},
+		"max results": {
+			vertices:      8,
+			limits:        []func(*Limits){MaxResults(10)},
+			expectedPaths: 10,
+		},
+		"max visited": {
+			vertices:   20,
+			limits:     []func(*Limits){MaxVisited(100)},
+			maxPaths:   100,
+			shouldFail: true,
+		},
+		"timeout": {
+			vertices:   30,
+			limits:     []func(*Limits){Timeout(time.Millisecond)},
+			maxPaths:   1 << 28,
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := completeDAG(test.vertices)
+
+			paths, err := AllPathsBetween(g, 0, test.vertices-1, test.limits...)
+
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+			}
+
+			if test.shouldFail {
+				if !errors.Is(err, ErrLimitExceeded) {
+					t.Errorf("%s: error doesn't match: expected %v, got %v", name, ErrLimitExceeded, err)
+				}
+				if len(paths) > test.maxPaths {
+					t.Errorf("%s: expected at most %v paths, got %v", name, test.maxPaths, len(paths))
+				}
+				return
+			}
+
+			if len(paths) != test.expectedPaths {
+				t.Errorf("%s: number of paths doesn't match: expected %v, got %v", name, test.expectedPaths, len(paths))
+			}
+
+			for _, path := range paths {
+				if path[0] != 0 || path[len(path)-1] != test.vertices-1 {
+					t.Errorf("%s: invalid path %v", name, path)
+				}
+			}
+		})
+	}
+}
+
+func TestTransitiveReduction_limits(t *testing.T) {
+	tests := map[string]struct {
+		limits     []func(*Limits)
+		shouldFail bool
+	}{
+		"no limits": {},
+		"limits that aren't exceeded": {
+			limits: []func(*Limits){MaxVisited(10000), Timeout(time.Minute)},
+		},
+		"max visited": {
+			limits:     []func(*Limits){MaxVisited(50)},
+			shouldFail: true,
+		},
+		"timeout": {
+			limits:     []func(*Limits){Timeout(time.Nanosecond)},
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := completeDAG(10)
+
+			reduction, err := TransitiveReduction(g, test.limits...)
+
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+			}
+
+			if test.shouldFail {
+				if !errors.Is(err, ErrLimitExceeded) {
+					t.Errorf("%s: error doesn't match: expected %v, got %v", name, ErrLimitExceeded, err)
+				}
+				return
+			}
+
+			size, _ := reduction.Size()
+			if size != 9 {
+				t.Errorf("%s: size doesn't match: expected %v, got %v", name, 9, size)
+			}
+		})
+	}
+}
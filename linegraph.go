@@ -0,0 +1,112 @@
+package graph
+
+import "fmt"
+
+// EdgeHash identifies an edge by its source and target. It is the vertex hash
+// of the line graphs created by [LineGraph].
+type EdgeHash[K comparable] struct {
+	Source K
+	Target K
+}
+
+// LineGraph creates the line graph of the given graph, which has a vertex for
+// each edge of g. In the line graph of an undirected graph, two vertices are
+// joined by an edge if the corresponding edges share a vertex. In the line
+// graph of a directed graph, there is an edge from (u, v) to (v, w) for each
+// pair of consecutive edges.
+//
+// Line graphs reduce problems about edges to problems about vertices, so that
+// they can be solved using vertex-centric algorithms. For example, a path in
+// the line graph is a sequence of adjacent edges in g:
+//
+//	lineGraph, _ := graph.LineGraph(g)
+//
+//	path, _ := graph.ShortestPath(lineGraph, graph.EdgeHash[string]{Source: "A", Target: "B"}, graph.EdgeHash[string]{Source: "C", Target: "D"})
+//
+// The value of each vertex is the edge itself, including its properties. Its
+// weight and attributes are copied to the vertex properties. For undirected
+// graphs, the source of each edge is the smaller of both vertex hashes, as
+// described in [SortedEdges]. The line graph is directed if g is directed, but
+// has no other traits.
+func LineGraph[K comparable, T any](g Graph[K, T]) (Graph[EdgeHash[K], Edge[K]], error) {
+	isDirected := g.Traits().IsDirected
+
+	edgeHashOf := func(source, target K) EdgeHash[K] {
+		if !isDirected && compareHashes(target, source) < 0 {
+			source, target = target, source
+		}
+		return EdgeHash[K]{Source: source, Target: target}
+	}
+
+	hash := func(edge Edge[K]) EdgeHash[K] {
+		return edgeHashOf(edge.Source, edge.Target)
+	}
+
+	var lineGraph Graph[EdgeHash[K], Edge[K]]
+
+	if isDirected {
+		lineGraph = New(hash, Directed())
+	} else {
+		lineGraph = New(hash)
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	for _, edge := range edges {
+		key := hash(edge)
+		edge.Source, edge.Target = key.Source, key.Target
+		edge.Properties.Attributes = copyAttributes(edge.Properties.Attributes)
+
+		err := lineGraph.AddVertex(edge, VertexWeight(edge.Properties.Weight), VertexAttributes(edge.Properties.Attributes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to add vertex for edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	if isDirected {
+		predecessorMap, err := g.PredecessorMap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get predecessor map: %w", err)
+		}
+
+		for hash, successors := range adjacencyMap {
+			for predecessor := range predecessorMap[hash] {
+				for successor := range successors {
+					source, target := edgeHashOf(predecessor, hash), edgeHashOf(hash, successor)
+					if err := lineGraph.AddEdge(source, target); err != nil {
+						return nil, fmt.Errorf("failed to add edge (%v, %v): %w", source, target, err)
+					}
+				}
+			}
+		}
+
+		return lineGraph, nil
+	}
+
+	for hash, adjacencies := range adjacencyMap {
+		incident := make([]EdgeHash[K], 0, len(adjacencies))
+		for adjacency := range adjacencies {
+			incident = append(incident, edgeHashOf(hash, adjacency))
+		}
+
+		// Two edges share at most one vertex, so each pair of edges is only
+		// joined once.
+		for i := 0; i < len(incident); i++ {
+			for j := i + 1; j < len(incident); j++ {
+				if err := lineGraph.AddEdge(incident[i], incident[j]); err != nil {
+					return nil, fmt.Errorf("failed to add edge (%v, %v): %w", incident[i], incident[j], err)
+				}
+			}
+		}
+	}
+
+	return lineGraph, nil
+}
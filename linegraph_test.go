@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestLineGraph(t *testing.T) {
+	tests := map[string]struct {
+		traits        []func(*Traits)
+		edges         []Edge[string]
+		expectedEdges []Edge[EdgeHash[string]]
+	}{
+		"undirected path": {
+			edges: []Edge[string]{{Source: "B", Target: "A"}, {Source: "B", Target: "C"}, {Source: "C", Target: "D"}},
+			expectedEdges: []Edge[EdgeHash[string]]{
+				{Source: EdgeHash[string]{"A", "B"}, Target: EdgeHash[string]{"B", "C"}},
+				{Source: EdgeHash[string]{"B", "C"}, Target: EdgeHash[string]{"C", "D"}},
+			},
+		},
+		"undirected star": {
+			edges: []Edge[string]{{Source: "A", Target: "B"}, {Source: "A", Target: "C"}, {Source: "A", Target: "D"}},
+			expectedEdges: []Edge[EdgeHash[string]]{
+				{Source: EdgeHash[string]{"A", "B"}, Target: EdgeHash[string]{"A", "C"}},
+				{Source: EdgeHash[string]{"A", "B"}, Target: EdgeHash[string]{"A", "D"}},
+				{Source: EdgeHash[string]{"A", "C"}, Target: EdgeHash[string]{"A", "D"}},
+			},
+		},
+		"directed graph": {
+			traits: []func(*Traits){Directed()},
+			edges:  []Edge[string]{{Source: "A", Target: "B"}, {Source: "B", Target: "C"}, {Source: "C", Target: "A"}, {Source: "D", Target: "B"}},
+			expectedEdges: []Edge[EdgeHash[string]]{
+				{Source: EdgeHash[string]{"A", "B"}, Target: EdgeHash[string]{"B", "C"}},
+				{Source: EdgeHash[string]{"B", "C"}, Target: EdgeHash[string]{"C", "A"}},
+				{Source: EdgeHash[string]{"C", "A"}, Target: EdgeHash[string]{"A", "B"}},
+				{Source: EdgeHash[string]{"D", "B"}, Target: EdgeHash[string]{"B", "C"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(StringHash, test.traits...)
+		for _, vertex := range []string{"A", "B", "C", "D"} {
+			_ = g.AddVertex(vertex)
+		}
+
+		for _, edge := range test.edges {
+			_ = g.AddEdge(edge.Source, edge.Target)
+		}
+
+		lineGraph, err := LineGraph(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if lineGraph.Traits().IsDirected != g.Traits().IsDirected {
+			t.Errorf("%s: directedness doesn't match: expected %v, got %v", name, g.Traits().IsDirected, lineGraph.Traits().IsDirected)
+		}
+
+		if order, _ := lineGraph.Order(); order != len(test.edges) {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, len(test.edges), order)
+		}
+
+		edges, _ := SortedEdges(lineGraph, func(a, b EdgeHash[string]) bool {
+			return a.Source+a.Target < b.Source+b.Target
+		})
+
+		if len(edges) != len(test.expectedEdges) {
+			t.Fatalf("%s: edges don't match: expected %v, got %v", name, test.expectedEdges, edges)
+		}
+
+		for i, edge := range edges {
+			expected := test.expectedEdges[i]
+			if edge.Source != expected.Source || edge.Target != expected.Target {
+				t.Errorf("%s: edge doesn't match: expected (%v, %v), got (%v, %v)", name, expected.Source, expected.Target, edge.Source, edge.Target)
+			}
+		}
+	}
+}
+
+func TestLineGraph_properties(t *testing.T) {
+	g := New(StringHash, Weighted())
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddEdge("B", "A", EdgeWeight(3), EdgeAttribute("label", "road"), EdgeData("data"))
+
+	lineGraph, err := LineGraph(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	edge, properties, err := lineGraph.VertexWithProperties(EdgeHash[string]{Source: "A", Target: "B"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if edge.Source != "A" || edge.Target != "B" {
+		t.Errorf("vertex doesn't match: expected (%v, %v), got (%v, %v)", "A", "B", edge.Source, edge.Target)
+	}
+
+	if edge.Properties.Data != "data" {
+		t.Errorf("data doesn't match: expected %v, got %v", "data", edge.Properties.Data)
+	}
+
+	if properties.Weight != 3 {
+		t.Errorf("weight doesn't match: expected %v, got %v", 3, properties.Weight)
+	}
+
+	if properties.Attributes["label"] != "road" {
+		t.Errorf("attribute doesn't match: expected %v, got %v", "road", properties.Attributes["label"])
+	}
+}
@@ -0,0 +1,166 @@
+// Package ndjson streams the edges of a graph as newline-delimited JSON, with
+// one edge per line. This allows piping huge graphs through standard tools like
+// grep, jq, or split without loading them into memory:
+//
+//	{"source":"A","target":"B","weight":3,"attributes":{"label":"x"}}
+//	{"source":"B","target":"C"}
+//
+// Each line has the schema of an edge in a graphjson document, see
+// graphjson.Edge. Because only edges are written, vertices without edges and
+// vertex properties aren't exported.
+package ndjson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/graphjson"
+)
+
+type config[K comparable, T any] struct {
+	createVertex func(hash K) (T, error)
+	skipExisting bool
+}
+
+func newConfig[K comparable, T any](options ...func(*config[K, T])) config[K, T] {
+	var c config[K, T]
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// CreateVertices is a functional option for [Read] that adds vertices which
+// don't exist in the graph yet. The given function has to return the vertex
+// value for a hash read from the input. Without this option, edges between
+// vertices that don't exist result in an error.
+func CreateVertices[K comparable, T any](create func(hash K) (T, error)) func(*config[K, T]) {
+	return func(c *config[K, T]) {
+		c.createVertex = create
+	}
+}
+
+// SkipExisting is a functional option for [Read] that skips edges which
+// already exist in the graph instead of returning an error.
+func SkipExisting[K comparable, T any]() func(*config[K, T]) {
+	return func(c *config[K, T]) {
+		c.skipExisting = true
+	}
+}
+
+// Write writes the edges of the given graph to w, one edge per line. The edges
+// are streamed using graph.IterEdges, so that stores implementing
+// graph.EdgeIterator don't have to load all edges at once.
+func Write[K comparable, T any](g graph.Graph[K, T], w io.Writer) error {
+	encoder := json.NewEncoder(w)
+
+	var writeErr error
+
+	err := graph.IterEdges(g, func(edge graph.Edge[K]) bool {
+		line := graphjson.Edge[K]{
+			Source:     edge.Source,
+			Target:     edge.Target,
+			Weight:     edge.Properties.Weight,
+			Attributes: edge.Properties.Attributes,
+			Data:       edge.Properties.Data,
+		}
+
+		if err := encoder.Encode(line); err != nil {
+			writeErr = fmt.Errorf("failed to write edge (%v, %v): %w", edge.Source, edge.Target, err)
+			return false
+		}
+
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeErr
+}
+
+// Read reads edges from r, one edge per line, and adds them to the given graph.
+// The input is decoded line by line, so the only memory required is the memory
+// of the graph's store:
+//
+//	g := graph.New(graph.StringHash, graph.Directed())
+//	err := ndjson.Read(os.Stdin, g, ndjson.CreateVertices(func(hash string) (string, error) {
+//		return hash, nil
+//	}))
+//
+// Edge data is decoded into the generic JSON types such as
+// map[string]interface{}. If reading fails, the edges read so far remain in
+// the graph.
+func Read[K comparable, T any](r io.Reader, g graph.Graph[K, T], options ...func(*config[K, T])) error {
+	c := newConfig(options...)
+	decoder := json.NewDecoder(r)
+
+	for line := 1; ; line++ {
+		var edge graphjson.Edge[K]
+
+		if err := decoder.Decode(&edge); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("line %d: failed to decode edge: %w", line, err)
+		}
+
+		if err := addEdge(g, c, edge); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+func addEdge[K comparable, T any](g graph.Graph[K, T], c config[K, T], edge graphjson.Edge[K]) error {
+	if c.createVertex != nil {
+		for _, hash := range []K{edge.Source, edge.Target} {
+			if err := createVertex(g, c, hash); err != nil {
+				return err
+			}
+		}
+	}
+
+	attributes := make(map[string]string, len(edge.Attributes))
+	for key, value := range edge.Attributes {
+		attributes[key] = value
+	}
+
+	err := g.AddEdge(edge.Source, edge.Target,
+		graph.EdgeWeight(edge.Weight),
+		graph.EdgeAttributes(attributes),
+		graph.EdgeData(edge.Data),
+	)
+	if errors.Is(err, graph.ErrEdgeAlreadyExists) && c.skipExisting {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+	}
+
+	return nil
+}
+
+func createVertex[K comparable, T any](g graph.Graph[K, T], c config[K, T], hash K) error {
+	_, err := g.Vertex(hash)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, graph.ErrVertexNotFound) {
+		return fmt.Errorf("failed to get vertex %v: %w", hash, err)
+	}
+
+	value, err := c.createVertex(hash)
+	if err != nil {
+		return fmt.Errorf("failed to create vertex %v: %w", hash, err)
+	}
+
+	if err := g.AddVertex(value); err != nil && !errors.Is(err, graph.ErrVertexAlreadyExists) {
+		return fmt.Errorf("failed to add vertex %v: %w", hash, err)
+	}
+
+	return nil
+}
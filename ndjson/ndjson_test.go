@@ -0,0 +1,114 @@
+package ndjson
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func identity(hash string) (string, error) {
+	return hash, nil
+}
+
+func TestWriteRead(t *testing.T) {
+	tests := map[string]struct {
+		traits []func(*graph.Traits)
+	}{
+		"directed graph": {
+			traits: []func(*graph.Traits){graph.Directed()},
+		},
+		"undirected graph": {
+			traits: []func(*graph.Traits){},
+		},
+	}
+
+	for name, test := range tests {
+		g := graph.New(graph.StringHash, test.traits...)
+
+		_ = g.AddVertex("A")
+		_ = g.AddVertex("B")
+		_ = g.AddVertex("C")
+		_ = g.AddEdge("B", "A", graph.EdgeWeight(3), graph.EdgeAttribute("label", "x"))
+		_ = g.AddEdge("B", "C", graph.EdgeData("data"))
+
+		var buf bytes.Buffer
+
+		if err := Write(g, &buf); err != nil {
+			t.Fatalf("%s: failed to write edges: %v", name, err)
+		}
+
+		if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+			t.Errorf("%s: line count doesn't match: expected %v, got %v", name, 2, lines)
+		}
+
+		h := graph.New(graph.StringHash, test.traits...)
+
+		if err := Read(&buf, h, CreateVertices(identity)); err != nil {
+			t.Fatalf("%s: failed to read edges: %v", name, err)
+		}
+
+		if size, _ := h.Size(); size != 2 {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, 2, size)
+		}
+
+		edge, err := h.Edge("B", "A")
+		if err != nil {
+			t.Fatalf("%s: failed to get edge: %v", name, err)
+		}
+		if edge.Properties.Weight != 3 || edge.Properties.Attributes["label"] != "x" {
+			t.Errorf("%s: edge properties don't match: got %v", name, edge.Properties)
+		}
+
+		edge, _ = h.Edge("B", "C")
+		if edge.Properties.Data != "data" {
+			t.Errorf("%s: edge data doesn't match: expected %v, got %v", name, "data", edge.Properties.Data)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := map[string]struct {
+		input         string
+		options       []func(*config[string, string])
+		expectedSize  int
+		expectedError error
+	}{
+		"missing vertex": {
+			input:         `{"source":"A","target":"B"}`,
+			expectedError: graph.ErrVertexNotFound,
+		},
+		"created vertices": {
+			input:        "{\"source\":\"A\",\"target\":\"B\"}\n{\"source\":\"B\",\"target\":\"C\"}\n",
+			options:      []func(*config[string, string]){CreateVertices(identity)},
+			expectedSize: 2,
+		},
+		"duplicate edge": {
+			input:         "{\"source\":\"A\",\"target\":\"B\"}\n{\"source\":\"A\",\"target\":\"B\"}\n",
+			options:       []func(*config[string, string]){CreateVertices(identity)},
+			expectedSize:  1,
+			expectedError: graph.ErrEdgeAlreadyExists,
+		},
+		"skipped duplicate edge": {
+			input:        "{\"source\":\"A\",\"target\":\"B\"}\n{\"source\":\"A\",\"target\":\"B\"}\n",
+			options:      []func(*config[string, string]){CreateVertices(identity), SkipExisting[string, string]()},
+			expectedSize: 1,
+		},
+	}
+
+	for name, test := range tests {
+		g := graph.New(graph.StringHash, graph.Directed())
+
+		err := Read(strings.NewReader(test.input), g, test.options...)
+
+		if !errors.Is(err, test.expectedError) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedError, err)
+		}
+
+		if size, _ := g.Size(); size != test.expectedSize {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, test.expectedSize, size)
+		}
+	}
+}
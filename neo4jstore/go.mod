@@ -0,0 +1,10 @@
+module github.com/dominikbraun/graph/neo4jstore
+
+go 1.22
+
+require (
+	github.com/dominikbraun/graph v0.23.0
+	github.com/neo4j/neo4j-go-driver/v5 v5.24.0
+)
+
+replace github.com/dominikbraun/graph => ../
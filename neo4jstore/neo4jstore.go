@@ -0,0 +1,682 @@
+// Package neo4jstore provides a [graph.Store] implementation for Neo4j and
+// other databases speaking the Bolt protocol. It allows running the algorithms
+// of the graph library against graphs that already live in a graph database.
+//
+//	driver, _ := neo4j.NewDriverWithContext("neo4j://localhost:7687", neo4j.BasicAuth("neo4j", "password", ""))
+//	store := neo4jstore.New[string, string](driver, neo4jstore.Label("City"), neo4jstore.RelationshipType("ROAD"))
+//
+//	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+//
+// Vertices are mapped to nodes with the configured label and edges are mapped
+// to relationships with the configured type. The vertex hash is stored in the
+// hash property, the vertex value is stored as JSON in the value property, and
+// the weight is stored in the weight property. All other properties are
+// treated as attributes. Likewise, the weight and data of an edge are stored
+// in the weight and data properties of a relationship, and all other
+// relationship properties are treated as attributes.
+//
+// Properties of existing nodes and relationships that aren't strings are
+// converted to attribute strings using fmt.Sprint.
+package neo4jstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dominikbraun/graph"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const (
+	hashProperty   = "hash"
+	valueProperty  = "value"
+	weightProperty = "weight"
+	dataProperty   = "data"
+)
+
+type config struct {
+	label            string
+	relationshipType string
+	database         string
+}
+
+// Label is a functional option for [New] that sets the node label used for
+// vertices. The default label is "Vertex".
+func Label(label string) func(*config) {
+	return func(c *config) {
+		c.label = label
+	}
+}
+
+// RelationshipType is a functional option for [New] that sets the relationship
+// type used for edges. The default relationship type is "EDGE".
+func RelationshipType(relationshipType string) func(*config) {
+	return func(c *config) {
+		c.relationshipType = relationshipType
+	}
+}
+
+// Database is a functional option for [New] that sets the name of the database
+// to use. By default, the default database of the server is used.
+func Database(name string) func(*config) {
+	return func(c *config) {
+		c.database = name
+	}
+}
+
+// Store is a [graph.Store] implementation backed by Neo4j.
+type Store[K comparable, T any] struct {
+	driver   neo4j.DriverWithContext
+	database string
+	queries  queries
+}
+
+// New creates a store that uses the given driver. Closing the driver is up to
+// the caller.
+func New[K comparable, T any](driver neo4j.DriverWithContext, options ...func(*config)) *Store[K, T] {
+	c := config{
+		label:            "Vertex",
+		relationshipType: "EDGE",
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return &Store[K, T]{
+		driver:   driver,
+		database: c.database,
+		queries:  newQueries(c.label, c.relationshipType),
+	}
+}
+
+// SetupConstraint creates a uniqueness constraint for the hash property of the
+// vertex nodes, which also creates an index for looking up vertices by their
+// hash. This requires Neo4j 4.4 or later.
+func (s *Store[K, T]) SetupConstraint() error {
+	_, err := s.execute(neo4j.AccessModeWrite, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(context.Background(), s.queries.setupConstraint, nil)
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(context.Background())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create constraint: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode vertex value: %w", err)
+	}
+
+	params := map[string]any{
+		"hash":       encodeHash(hash),
+		"value":      string(encodedValue),
+		"weight":     properties.Weight,
+		"attributes": attributesToProperties(properties.Attributes),
+	}
+
+	_, err = s.execute(neo4j.AccessModeWrite, func(tx neo4j.ManagedTransaction) (any, error) {
+		ctx := context.Background()
+
+		count, err := single[int64](ctx, tx, s.queries.countVertex, params, "count")
+		if err != nil {
+			return nil, err
+		}
+
+		if count > 0 {
+			return nil, graph.ErrVertexAlreadyExists
+		}
+
+		result, err := tx.Run(ctx, s.queries.createVertex, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return result.Consume(ctx)
+	})
+
+	return err
+}
+
+func (s *Store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	var value T
+
+	params := map[string]any{
+		"hash": encodeHash(hash),
+	}
+
+	records, err := s.collect(neo4j.AccessModeRead, s.queries.vertex, params)
+	if err != nil {
+		return value, graph.VertexProperties{}, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+	}
+
+	if len(records) == 0 {
+		return value, graph.VertexProperties{}, graph.ErrVertexNotFound
+	}
+
+	nodeProperties, _ := records[0].Get("properties")
+	props, _ := nodeProperties.(map[string]any)
+
+	if encodedValue, ok := props[valueProperty].(string); ok {
+		if err := json.Unmarshal([]byte(encodedValue), &value); err != nil {
+			return value, graph.VertexProperties{}, fmt.Errorf("failed to decode vertex value: %w", err)
+		}
+	}
+
+	return value, graph.VertexProperties{
+		Weight:     toInt(props[weightProperty]),
+		Attributes: propertiesToAttributes(props, hashProperty, valueProperty, weightProperty),
+	}, nil
+}
+
+func (s *Store[K, T]) RemoveVertex(hash K) error {
+	params := map[string]any{
+		"hash": encodeHash(hash),
+	}
+
+	_, err := s.execute(neo4j.AccessModeWrite, func(tx neo4j.ManagedTransaction) (any, error) {
+		ctx := context.Background()
+
+		result, err := tx.Run(ctx, s.queries.degree, params)
+		if err != nil {
+			return nil, err
+		}
+
+		records, err := result.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(records) == 0 {
+			return nil, graph.ErrVertexNotFound
+		}
+
+		if degree, _ := records[0].Get("degree"); toInt(degree) > 0 {
+			return nil, graph.ErrVertexHasEdges
+		}
+
+		result, err = tx.Run(ctx, s.queries.deleteVertex, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return result.Consume(ctx)
+	})
+
+	return err
+}
+
+func (s *Store[K, T]) ListVertices() ([]K, error) {
+	records, err := s.collect(neo4j.AccessModeRead, s.queries.listVertices, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vertices: %w", err)
+	}
+
+	hashes := make([]K, 0, len(records))
+
+	for _, record := range records {
+		value, _ := record.Get("hash")
+
+		hash, err := decodeHash[K](value)
+		if err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+func (s *Store[K, T]) VertexCount() (int, error) {
+	count, err := s.execute(neo4j.AccessModeRead, func(tx neo4j.ManagedTransaction) (any, error) {
+		return single[int64](context.Background(), tx, s.queries.vertexCount, nil, "count")
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count vertices: %w", err)
+	}
+
+	return int(count.(int64)), nil
+}
+
+func (s *Store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	params, err := edgeParams(sourceHash, targetHash, edge)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.execute(neo4j.AccessModeWrite, func(tx neo4j.ManagedTransaction) (any, error) {
+		ctx := context.Background()
+
+		result, err := tx.Run(ctx, s.queries.edgeExists, params)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if exists, _ := record.Get("sourceExists"); exists != true {
+			return nil, fmt.Errorf("source vertex %v: %w", sourceHash, graph.ErrVertexNotFound)
+		}
+
+		if exists, _ := record.Get("targetExists"); exists != true {
+			return nil, fmt.Errorf("target vertex %v: %w", targetHash, graph.ErrVertexNotFound)
+		}
+
+		if exists, _ := record.Get("edgeExists"); exists == true {
+			return nil, graph.ErrEdgeAlreadyExists
+		}
+
+		result, err = tx.Run(ctx, s.queries.createEdge, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return result.Consume(ctx)
+	})
+
+	return err
+}
+
+func (s *Store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	params, err := edgeParams(sourceHash, targetHash, edge)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.execute(neo4j.AccessModeWrite, func(tx neo4j.ManagedTransaction) (any, error) {
+		return single[int64](context.Background(), tx, s.queries.updateEdge, params, "count")
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update edge (%v, %v): %w", sourceHash, targetHash, err)
+	}
+
+	if count.(int64) == 0 {
+		return graph.ErrEdgeNotFound
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	params := map[string]any{
+		"source": encodeHash(sourceHash),
+		"target": encodeHash(targetHash),
+	}
+
+	_, err := s.execute(neo4j.AccessModeWrite, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(context.Background(), s.queries.deleteEdge, params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(context.Background())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove edge (%v, %v): %w", sourceHash, targetHash, err)
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	params := map[string]any{
+		"source": encodeHash(sourceHash),
+		"target": encodeHash(targetHash),
+	}
+
+	records, err := s.collect(neo4j.AccessModeRead, s.queries.edge, params)
+	if err != nil {
+		return graph.Edge[K]{}, fmt.Errorf("failed to get edge (%v, %v): %w", sourceHash, targetHash, err)
+	}
+
+	if len(records) == 0 {
+		return graph.Edge[K]{}, graph.ErrEdgeNotFound
+	}
+
+	relationshipProperties, _ := records[0].Get("properties")
+
+	return decodeEdge(sourceHash, targetHash, relationshipProperties)
+}
+
+func (s *Store[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	records, err := s.collect(neo4j.AccessModeRead, s.queries.listEdges, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edges: %w", err)
+	}
+
+	edges := make([]graph.Edge[K], 0, len(records))
+
+	for _, record := range records {
+		source, _ := record.Get("source")
+		target, _ := record.Get("target")
+		relationshipProperties, _ := record.Get("properties")
+
+		sourceHash, err := decodeHash[K](source)
+		if err != nil {
+			return nil, err
+		}
+
+		targetHash, err := decodeHash[K](target)
+		if err != nil {
+			return nil, err
+		}
+
+		edge, err := decodeEdge(sourceHash, targetHash, relationshipProperties)
+		if err != nil {
+			return nil, err
+		}
+
+		edges = append(edges, edge)
+	}
+
+	return edges, nil
+}
+
+func (s *Store[K, T]) EdgeCount() (int, error) {
+	count, err := s.execute(neo4j.AccessModeRead, func(tx neo4j.ManagedTransaction) (any, error) {
+		return single[int64](context.Background(), tx, s.queries.edgeCount, nil, "count")
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count edges: %w", err)
+	}
+
+	return int(count.(int64)), nil
+}
+
+// execute runs the given work in a managed transaction of a new session. The
+// transaction is retried by the driver if a transient error occurs.
+func (s *Store[K, T]) execute(mode neo4j.AccessMode, work neo4j.ManagedTransactionWork) (any, error) {
+	ctx := context.Background()
+
+	session := s.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:   mode,
+		DatabaseName: s.database,
+	})
+	defer session.Close(ctx)
+
+	if mode == neo4j.AccessModeWrite {
+		return session.ExecuteWrite(ctx, work)
+	}
+
+	return session.ExecuteRead(ctx, work)
+}
+
+func (s *Store[K, T]) collect(mode neo4j.AccessMode, query string, params map[string]any) ([]*neo4j.Record, error) {
+	records, err := s.execute(mode, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(context.Background(), query, params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Collect(context.Background())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records.([]*neo4j.Record), nil
+}
+
+func single[V neo4j.RecordValue](ctx context.Context, tx neo4j.ManagedTransaction, query string, params map[string]any, key string) (V, error) {
+	var value V
+
+	result, err := tx.Run(ctx, query, params)
+	if err != nil {
+		return value, err
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return value, err
+	}
+
+	value, isNil, err := neo4j.GetRecordValue[V](record, key)
+	if err != nil {
+		return value, err
+	}
+
+	if isNil {
+		return value, fmt.Errorf("value of %q is null", key)
+	}
+
+	return value, nil
+}
+
+type queries struct {
+	setupConstraint string
+	countVertex     string
+	createVertex    string
+	vertex          string
+	degree          string
+	deleteVertex    string
+	listVertices    string
+	vertexCount     string
+	edgeExists      string
+	createEdge      string
+	updateEdge      string
+	deleteEdge      string
+	edge            string
+	listEdges       string
+	edgeCount       string
+}
+
+// newQueries builds all Cypher queries for the given label and relationship
+// type. Labels and types can't be passed as parameters in Cypher, so they are
+// escaped and embedded into the queries instead.
+func newQueries(label, relationshipType string) queries {
+	l := escapeIdentifier(label)
+	r := escapeIdentifier(relationshipType)
+
+	return queries{
+		setupConstraint: fmt.Sprintf("CREATE CONSTRAINT IF NOT EXISTS FOR (n:%s) REQUIRE n.hash IS UNIQUE", l),
+		countVertex:     fmt.Sprintf("MATCH (n:%s {hash: $hash}) RETURN count(n) AS count", l),
+		createVertex:    fmt.Sprintf("CREATE (n:%s) SET n = $attributes SET n.hash = $hash, n.value = $value, n.weight = $weight", l),
+		vertex:          fmt.Sprintf("MATCH (n:%s {hash: $hash}) RETURN properties(n) AS properties LIMIT 1", l),
+		degree:          fmt.Sprintf("MATCH (n:%s {hash: $hash}) RETURN size([(n)-[:%s]-() | 1]) AS degree LIMIT 1", l, r),
+		deleteVertex:    fmt.Sprintf("MATCH (n:%s {hash: $hash}) DELETE n", l),
+		listVertices:    fmt.Sprintf("MATCH (n:%s) RETURN n.hash AS hash", l),
+		vertexCount:     fmt.Sprintf("MATCH (n:%s) RETURN count(n) AS count", l),
+		edgeExists: fmt.Sprintf("OPTIONAL MATCH (s:%s {hash: $source}) OPTIONAL MATCH (t:%s {hash: $target}) "+
+			"OPTIONAL MATCH (s)-[e:%s]->(t) "+
+			"RETURN s IS NOT NULL AS sourceExists, t IS NOT NULL AS targetExists, e IS NOT NULL AS edgeExists LIMIT 1", l, l, r),
+		createEdge: fmt.Sprintf("MATCH (s:%s {hash: $source}), (t:%s {hash: $target}) "+
+			"CREATE (s)-[e:%s]->(t) SET e = $attributes SET e.weight = $weight, e.data = $data", l, l, r),
+		updateEdge: fmt.Sprintf("MATCH (s:%s {hash: $source})-[e:%s]->(t:%s {hash: $target}) "+
+			"SET e = $attributes SET e.weight = $weight, e.data = $data RETURN count(e) AS count", l, r, l),
+		deleteEdge: fmt.Sprintf("MATCH (s:%s {hash: $source})-[e:%s]->(t:%s {hash: $target}) DELETE e", l, r, l),
+		edge: fmt.Sprintf("MATCH (s:%s {hash: $source})-[e:%s]->(t:%s {hash: $target}) "+
+			"RETURN properties(e) AS properties LIMIT 1", l, r, l),
+		listEdges: fmt.Sprintf("MATCH (s:%s)-[e:%s]->(t:%s) "+
+			"RETURN s.hash AS source, t.hash AS target, properties(e) AS properties", l, r, l),
+		edgeCount: fmt.Sprintf("MATCH (:%s)-[e:%s]->(:%s) RETURN count(e) AS count", l, r, l),
+	}
+}
+
+func escapeIdentifier(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
+
+func edgeParams[K comparable](sourceHash, targetHash K, edge graph.Edge[K]) (map[string]any, error) {
+	var data any
+
+	if edge.Properties.Data != nil {
+		encodedData, err := json.Marshal(edge.Properties.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode edge data: %w", err)
+		}
+		data = string(encodedData)
+	}
+
+	return map[string]any{
+		"source":     encodeHash(sourceHash),
+		"target":     encodeHash(targetHash),
+		"weight":     edge.Properties.Weight,
+		"data":       data,
+		"attributes": attributesToProperties(edge.Properties.Attributes),
+	}, nil
+}
+
+func decodeEdge[K comparable](source, target K, relationshipProperties any) (graph.Edge[K], error) {
+	props, _ := relationshipProperties.(map[string]any)
+
+	var data any
+
+	if encodedData, ok := props[dataProperty].(string); ok {
+		if err := json.Unmarshal([]byte(encodedData), &data); err != nil {
+			return graph.Edge[K]{}, fmt.Errorf("failed to decode edge data: %w", err)
+		}
+	}
+
+	return graph.Edge[K]{
+		Source: source,
+		Target: target,
+		Properties: graph.EdgeProperties{
+			Weight:     toInt(props[weightProperty]),
+			Attributes: propertiesToAttributes(props, weightProperty, dataProperty),
+			Data:       data,
+		},
+	}, nil
+}
+
+func attributesToProperties(attributes map[string]string) map[string]any {
+	properties := make(map[string]any, len(attributes))
+
+	for key, value := range attributes {
+		properties[key] = value
+	}
+
+	return properties
+}
+
+// propertiesToAttributes converts all properties except for the reserved ones
+// to attributes.
+func propertiesToAttributes(properties map[string]any, reserved ...string) map[string]string {
+	attributes := make(map[string]string)
+
+	for key, value := range properties {
+		if isReserved(key, reserved) {
+			continue
+		}
+
+		if s, ok := value.(string); ok {
+			attributes[key] = s
+			continue
+		}
+
+		attributes[key] = fmt.Sprint(value)
+	}
+
+	return attributes
+}
+
+func isReserved(key string, reserved []string) bool {
+	for _, r := range reserved {
+		if key == r {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toInt(value any) int {
+	switch v := value.(type) {
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// encodeHash converts the given hash into a property value. Strings, numbers,
+// and booleans are stored as they are, so that the hashes of existing nodes
+// can be used directly. All other types are stored as JSON strings.
+func encodeHash[K comparable](hash K) any {
+	if isPrimitive[K]() {
+		return primitiveValue(reflect.ValueOf(hash))
+	}
+
+	encoded, err := json.Marshal(hash)
+	if err != nil {
+		return fmt.Sprint(hash)
+	}
+
+	return string(encoded)
+}
+
+// decodeHash converts a property value read from the database back into a
+// hash value. For primitive types, the value is converted via JSON so that,
+// for example, int64 values returned by the driver can be decoded into int.
+func decodeHash[K comparable](value any) (K, error) {
+	var hash K
+
+	if !isPrimitive[K]() {
+		encoded, ok := value.(string)
+		if !ok {
+			return hash, fmt.Errorf("failed to decode hash %v: expected a string", value)
+		}
+		if err := json.Unmarshal([]byte(encoded), &hash); err != nil {
+			return hash, fmt.Errorf("failed to decode hash %v: %w", value, err)
+		}
+		return hash, nil
+	}
+
+	if h, ok := value.(K); ok {
+		return h, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return hash, fmt.Errorf("failed to decode hash %v: %w", value, err)
+	}
+
+	if err := json.Unmarshal(encoded, &hash); err != nil {
+		return hash, fmt.Errorf("failed to decode hash %v: %w", value, err)
+	}
+
+	return hash, nil
+}
+
+// primitiveValue returns the underlying value of a primitive type, so that
+// named types such as `type ID string` can be passed to the driver.
+func primitiveValue(value reflect.Value) any {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int()
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(value.Uint())
+	default:
+		return value.Float()
+	}
+}
+
+func isPrimitive[K comparable]() bool {
+	var hash K
+
+	switch reflect.TypeOf(&hash).Elem().Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
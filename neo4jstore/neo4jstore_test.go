@@ -0,0 +1,219 @@
+package neo4jstore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// newTestStore creates a store connected to the Neo4j instance specified by
+// the NEO4J_URI, NEO4J_USERNAME, and NEO4J_PASSWORD environment variables. If
+// NEO4J_URI isn't set, the test is skipped.
+func newTestStore[K comparable, T any](t *testing.T, label string) *Store[K, T] {
+	uri := os.Getenv("NEO4J_URI")
+	if uri == "" {
+		t.Skip("NEO4J_URI not set")
+	}
+
+	auth := neo4j.BasicAuth(os.Getenv("NEO4J_USERNAME"), os.Getenv("NEO4J_PASSWORD"), "")
+
+	driver, err := neo4j.NewDriverWithContext(uri, auth)
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+
+	cleanup := func() {
+		_, _ = neo4j.ExecuteQuery(context.Background(), driver, "MATCH (n:"+escapeIdentifier(label)+") DETACH DELETE n",
+			nil, neo4j.EagerResultTransformer)
+	}
+
+	cleanup()
+
+	t.Cleanup(func() {
+		cleanup()
+		_ = driver.Close(context.Background())
+	})
+
+	return New[K, T](driver, Label(label))
+}
+
+func TestStore(t *testing.T) {
+	store := newTestStore[string, string](t, "GraphTestVertex")
+
+	properties := graph.VertexProperties{
+		Weight:     4,
+		Attributes: map[string]string{"color": "red"},
+	}
+
+	if err := store.AddVertex("A", "A", properties); err != nil {
+		t.Fatalf("failed to add vertex: %v", err)
+	}
+
+	if err := store.AddVertex("A", "A", properties); !errors.Is(err, graph.ErrVertexAlreadyExists) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexAlreadyExists, err)
+	}
+
+	value, vertexProperties, err := store.Vertex("A")
+	if err != nil {
+		t.Fatalf("failed to get vertex: %v", err)
+	}
+
+	if value != "A" || vertexProperties.Weight != 4 || vertexProperties.Attributes["color"] != "red" {
+		t.Errorf("vertex doesn't match: expected %v %v, got %v %v", "A", properties, value, vertexProperties)
+	}
+
+	_ = store.AddVertex("B", "B", graph.VertexProperties{})
+
+	edge := graph.Edge[string]{
+		Source: "A",
+		Target: "B",
+		Properties: graph.EdgeProperties{
+			Weight:     3,
+			Attributes: map[string]string{"label": "AB"},
+			Data:       "data",
+		},
+	}
+
+	if err := store.AddEdge("A", "C", edge); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexNotFound, err)
+	}
+
+	if err := store.AddEdge("A", "B", edge); err != nil {
+		t.Fatalf("failed to add edge: %v", err)
+	}
+
+	if err := store.AddEdge("A", "B", edge); !errors.Is(err, graph.ErrEdgeAlreadyExists) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrEdgeAlreadyExists, err)
+	}
+
+	storedEdge, err := store.Edge("A", "B")
+	if err != nil {
+		t.Fatalf("failed to get edge: %v", err)
+	}
+
+	if storedEdge.Properties.Weight != 3 || storedEdge.Properties.Attributes["label"] != "AB" || storedEdge.Properties.Data != "data" {
+		t.Errorf("edge properties don't match: expected %v, got %v", edge.Properties, storedEdge.Properties)
+	}
+
+	if err := store.RemoveVertex("A"); !errors.Is(err, graph.ErrVertexHasEdges) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexHasEdges, err)
+	}
+
+	if count, _ := store.EdgeCount(); count != 1 {
+		t.Errorf("edge count doesn't match: expected %v, got %v", 1, count)
+	}
+
+	if err := store.RemoveEdge("A", "B"); err != nil {
+		t.Fatalf("failed to remove edge: %v", err)
+	}
+
+	if err := store.RemoveVertex("A"); err != nil {
+		t.Errorf("failed to remove vertex: %v", err)
+	}
+
+	if count, _ := store.VertexCount(); count != 1 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 1, count)
+	}
+}
+
+func TestNewQueries(t *testing.T) {
+	tests := map[string]struct {
+		label            string
+		relationshipType string
+		expectedLabel    string
+		expectedType     string
+	}{
+		"default names": {
+			label:            "Vertex",
+			relationshipType: "EDGE",
+			expectedLabel:    "(n:`Vertex`",
+			expectedType:     "[:`EDGE`]",
+		},
+		"names with backticks": {
+			label:            "My`Label",
+			relationshipType: "MY`TYPE",
+			expectedLabel:    "(n:`My``Label`",
+			expectedType:     "[:`MY``TYPE`]",
+		},
+	}
+
+	for name, test := range tests {
+		q := newQueries(test.label, test.relationshipType)
+
+		if !strings.Contains(q.degree, test.expectedLabel) {
+			t.Errorf("%s: label expectancy doesn't match: expected %v in %v", name, test.expectedLabel, q.degree)
+		}
+
+		if !strings.Contains(q.degree, test.expectedType) {
+			t.Errorf("%s: type expectancy doesn't match: expected %v in %v", name, test.expectedType, q.degree)
+		}
+	}
+}
+
+func TestHashEncoding(t *testing.T) {
+	type id string
+
+	type compositeKey struct {
+		Region string
+		Number int
+	}
+
+	if encoded := encodeHash(id("A")); encoded != "A" {
+		t.Errorf("encoded hash doesn't match: expected %v, got %v", "A", encoded)
+	}
+
+	if encoded := encodeHash(42); encoded != int64(42) {
+		t.Errorf("encoded hash doesn't match: expected %v, got %v", int64(42), encoded)
+	}
+
+	// The driver returns all integers as int64.
+	if decoded, err := decodeHash[int](int64(42)); err != nil || decoded != 42 {
+		t.Errorf("decoded hash doesn't match: expected %v, got %v (error: %v)", 42, decoded, err)
+	}
+
+	if decoded, err := decodeHash[id]("A"); err != nil || decoded != "A" {
+		t.Errorf("decoded hash doesn't match: expected %v, got %v (error: %v)", "A", decoded, err)
+	}
+
+	key := compositeKey{Region: "eu", Number: 1}
+
+	decoded, err := decodeHash[compositeKey](encodeHash(key))
+	if err != nil || decoded != key {
+		t.Errorf("decoded hash doesn't match: expected %v, got %v (error: %v)", key, decoded, err)
+	}
+}
+
+func TestPropertiesToAttributes(t *testing.T) {
+	properties := map[string]any{
+		"hash":       "A",
+		"weight":     int64(3),
+		"color":      "red",
+		"population": int64(3645000),
+	}
+
+	attributes := propertiesToAttributes(properties, hashProperty, weightProperty)
+
+	expected := map[string]string{
+		"color":      "red",
+		"population": "3645000",
+	}
+
+	if len(attributes) != len(expected) {
+		t.Fatalf("attributes don't match: expected %v, got %v", expected, attributes)
+	}
+
+	for key, value := range expected {
+		if attributes[key] != value {
+			t.Errorf("attribute %s doesn't match: expected %v, got %v", key, value, attributes[key])
+		}
+	}
+
+	if weight := toInt(properties["weight"]); weight != 3 {
+		t.Errorf("weight doesn't match: expected %v, got %v", 3, weight)
+	}
+}
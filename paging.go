@@ -0,0 +1,249 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// VerticesPage returns at most limit vertex hashes of the graph that follow the
+// given cursor, along with the cursor for the next page. An empty cursor
+// requests the first page, and an empty next cursor denotes the last page:
+//
+//	cursor := ""
+//
+//	for {
+//		hashes, next, _ := graph.VerticesPage(g, cursor, 100)
+//		// Process the page.
+//		if next == "" {
+//			break
+//		}
+//		cursor = next
+//	}
+//
+// If the store of the graph implements [PagingStore], only the requested page
+// is loaded from the store, and the order of the vertices is defined by the
+// store. The default in-memory store only starts keeping track of the order
+// once a page is requested for the first time: The vertices existing at that
+// time are sorted by their hashes, and the vertices added afterwards follow in
+// insertion order. Vertices that are added or removed between two pages don't
+// affect the other vertices.
+//
+// For all other stores, all vertices are loaded and sorted by their hashes for
+// each page. In this case, the cursor is the offset of the next page.
+func VerticesPage[K comparable, T any](g Graph[K, T], cursor string, limit int) ([]K, string, error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("invalid limit %d", limit)
+	}
+
+	if store, ok := storeOf(g); ok {
+		if pagingStore, ok := store.(PagingStore[K]); ok {
+			return pagingStore.ListVerticesPage(cursor, limit)
+		}
+	}
+
+	offset, err := parseOffsetCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	hashes, err := vertexHashes(g)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list vertices: %w", err)
+	}
+
+	sortHashes(hashes)
+
+	hashes, next := page(hashes, offset, limit)
+
+	return hashes, next, nil
+}
+
+// EdgesPage returns at most limit edges of the graph that follow the given
+// cursor, along with the cursor for the next page. It is the edge counterpart
+// to [VerticesPage] and uses the same cursor semantics.
+//
+// Because an undirected graph stores each edge in both directions, EdgesPage
+// skips one of the two directions for these graphs. Unless the default
+// in-memory store is used, a page of an undirected graph may therefore contain
+// fewer than limit edges even if it isn't the last page.
+func EdgesPage[K comparable, T any](g Graph[K, T], cursor string, limit int) ([]Edge[K], string, error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("invalid limit %d", limit)
+	}
+
+	if store, ok := storeOf(g); ok {
+		if pager, ok := store.(canonicalEdgePager[K]); ok && !g.Traits().IsDirected {
+			return pager.listCanonicalEdgesPage(cursor, limit)
+		}
+
+		if pagingStore, ok := store.(PagingStore[K]); ok {
+			edges, next, err := pagingStore.ListEdgesPage(cursor, limit)
+			if err != nil || g.Traits().IsDirected {
+				return edges, next, err
+			}
+			return canonicalEdges(edges), next, nil
+		}
+	}
+
+	offset, err := parseOffsetCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list edges: %w", err)
+	}
+
+	sortEdges(edges, !g.Traits().IsDirected)
+
+	edges, next := page(edges, offset, limit)
+
+	return edges, next, nil
+}
+
+// canonicalEdges drops one direction of each undirected edge, just like the
+// Edges method of undirected graphs does.
+func canonicalEdges[K comparable](edges []Edge[K]) []Edge[K] {
+	canonical := edges[:0]
+	isCanonical := canonicalDirection[K]()
+
+	for _, edge := range edges {
+		if isCanonical(edge) {
+			canonical = append(canonical, edge)
+		}
+	}
+
+	return canonical
+}
+
+// page returns the elements of the given sorted slice that belong to the page
+// starting at the given offset, along with the cursor for the next page.
+func page[E any](elements []E, offset, limit int) ([]E, string) {
+	if offset >= len(elements) {
+		return []E{}, ""
+	}
+
+	end := offset + limit
+	if end >= len(elements) || end < offset {
+		return elements[offset:], ""
+	}
+
+	return elements[offset:end], strconv.Itoa(end)
+}
+
+func parseOffsetCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.Atoi(cursor)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+
+	return offset, nil
+}
+
+func parseSequenceCursor(cursor string) (uint64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+
+	return number, nil
+}
+
+func sequenceCursor(last uint64, more bool) string {
+	if !more {
+		return ""
+	}
+
+	return strconv.FormatUint(last, 10)
+}
+
+// sortEdges sorts the given edges by their source and target hashes. If the
+// edges are undirected, the smaller hash of each edge is used as its source.
+func sortEdges[K comparable](edges []Edge[K], undirected bool) {
+	endpoints := func(edge Edge[K]) (K, K) {
+		if undirected && compareHashes(edge.Target, edge.Source) < 0 {
+			return edge.Target, edge.Source
+		}
+		return edge.Source, edge.Target
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		iSource, iTarget := endpoints(edges[i])
+		jSource, jTarget := endpoints(edges[j])
+
+		if c := compareHashes(iSource, jSource); c != 0 {
+			return c < 0
+		}
+		return compareHashes(iTarget, jTarget) < 0
+	})
+}
+
+// sortHashes sorts the given hashes using compareHashes.
+func sortHashes[K comparable](hashes []K) {
+	sort.Slice(hashes, func(i, j int) bool {
+		return compareHashes(hashes[i], hashes[j]) < 0
+	})
+}
+
+// compareHashes compares two hashes and returns -1, 0, or 1. Hashes of ordered
+// kinds, i.e. strings, integers, and floats, are compared by their values. All
+// other hashes are compared by their string representations.
+func compareHashes[K comparable](a, b K) int {
+	// The most common hash types are compared without reflection, which would
+	// allocate for each comparison.
+	switch x := any(a).(type) {
+	case string:
+		if y, ok := any(b).(string); ok {
+			return compare(x, y)
+		}
+	case int:
+		if y, ok := any(b).(int); ok {
+			return compare(int64(x), int64(y))
+		}
+	}
+
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	// If K is an interface type, the hashes may have different kinds.
+	if x.Kind() != y.Kind() {
+		return compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+
+	switch x.Kind() {
+	case reflect.String:
+		return compare(x.String(), y.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compare(x.Int(), y.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compare(x.Uint(), y.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compare(x.Float(), y.Float())
+	default:
+		return compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+func compare[V string | int64 | uint64 | float64](a, b V) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,280 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestVerticesPage(t *testing.T) {
+	tests := map[string]struct {
+		store Store[int, int]
+		limit int
+		// trackedFrom is the number of vertices added before requesting the
+		// first page, which makes the memory store track the order.
+		trackedFrom int
+		expected    [][]int
+	}{
+		"memory store": {
+			store:    newMemoryStore[int, int](),
+			limit:    2,
+			expected: [][]int{{3, 5}, {1, 4}, {2}},
+		},
+		"memory store with exact pages": {
+			store:    newMemoryStore[int, int](),
+			limit:    5,
+			expected: [][]int{{3, 5, 1, 4, 2}},
+		},
+		"memory store with existing vertices": {
+			store:       newMemoryStore[int, int](),
+			limit:       2,
+			trackedFrom: 3,
+			expected:    [][]int{{1, 3}, {5, 4}, {2}},
+		},
+		"store without paging": {
+			store:    iteratingStore[int, int]{newMemoryStore[int, int]()},
+			limit:    2,
+			expected: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+	}
+
+	for name, test := range tests {
+		g := NewWithStore(IntHash, test.store, Directed())
+
+		for i, vertex := range []int{3, 5, 1, 4, 2} {
+			if i == test.trackedFrom {
+				_, _, _ = VerticesPage(g, "", 1)
+			}
+			_ = g.AddVertex(vertex)
+		}
+
+		var pages [][]int
+		cursor := ""
+
+		for {
+			hashes, next, err := VerticesPage(g, cursor, test.limit)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+
+			pages = append(pages, hashes)
+
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+
+		if !reflect.DeepEqual(pages, test.expected) {
+			t.Errorf("%s: pages don't match: expected %v, got %v", name, test.expected, pages)
+		}
+	}
+}
+
+func TestVerticesPage_concurrentModification(t *testing.T) {
+	g := New(IntHash)
+
+	for i := 1; i <= 6; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	hashes, cursor, _ := VerticesPage(g, "", 3)
+
+	if !reflect.DeepEqual(hashes, []int{1, 2, 3}) {
+		t.Fatalf("page doesn't match: expected %v, got %v", []int{1, 2, 3}, hashes)
+	}
+
+	// Removing vertices from the first page must not shift the next page.
+	_ = g.RemoveVertex(1)
+	_ = g.RemoveVertex(2)
+	_ = g.RemoveVertex(5)
+	_ = g.AddVertex(7)
+
+	hashes, cursor, _ = VerticesPage(g, cursor, 3)
+
+	if !reflect.DeepEqual(hashes, []int{4, 6, 7}) {
+		t.Errorf("page doesn't match: expected %v, got %v", []int{4, 6, 7}, hashes)
+	}
+
+	if cursor != "" {
+		t.Errorf("cursor doesn't match: expected empty cursor, got %q", cursor)
+	}
+
+	if _, _, err := VerticesPage(g, "abc", 3); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrInvalidCursor, err)
+	}
+}
+
+func TestEdgesPage(t *testing.T) {
+	tests := map[string]struct {
+		traits []func(*Traits)
+		store  Store[int, int]
+		limit  int
+		// trackedFrom is the number of edges added before requesting the
+		// first page, which makes the memory store track the order.
+		trackedFrom int
+		expected    [][2]int
+	}{
+		"directed graph": {
+			traits:   []func(*Traits){Directed()},
+			store:    newMemoryStore[int, int](),
+			limit:    3,
+			expected: [][2]int{{2, 3}, {1, 2}, {3, 4}, {1, 3}},
+		},
+		"undirected graph": {
+			store:    newMemoryStore[int, int](),
+			limit:    3,
+			expected: [][2]int{{2, 3}, {1, 2}, {3, 4}, {1, 3}},
+		},
+		"directed graph with existing edges": {
+			traits:      []func(*Traits){Directed()},
+			store:       newMemoryStore[int, int](),
+			limit:       3,
+			trackedFrom: 2,
+			expected:    [][2]int{{1, 2}, {2, 3}, {3, 4}, {1, 3}},
+		},
+		"undirected graph with existing edges": {
+			store:       newMemoryStore[int, int](),
+			limit:       3,
+			trackedFrom: 4,
+			expected:    [][2]int{{1, 2}, {1, 3}, {2, 3}, {3, 4}},
+		},
+		"store without paging": {
+			traits:   []func(*Traits){Directed()},
+			store:    iteratingStore[int, int]{newMemoryStore[int, int]()},
+			limit:    3,
+			expected: [][2]int{{1, 2}, {1, 3}, {2, 3}, {3, 4}},
+		},
+	}
+
+	for name, test := range tests {
+		g := NewWithStore(IntHash, test.store, test.traits...)
+
+		for i := 1; i <= 4; i++ {
+			_ = g.AddVertex(i)
+		}
+
+		for i, edge := range [][2]int{{2, 3}, {1, 2}, {3, 4}, {1, 3}} {
+			if i == test.trackedFrom {
+				_, _, _ = EdgesPage(g, "", 1)
+			}
+			_ = g.AddEdge(edge[0], edge[1])
+		}
+
+		if test.trackedFrom == 4 {
+			_, _, _ = EdgesPage(g, "", 1)
+		}
+
+		var edges [][2]int
+		cursor := ""
+
+		for {
+			page, next, err := EdgesPage(g, cursor, test.limit)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+
+			for _, edge := range page {
+				edges = append(edges, [2]int{edge.Source, edge.Target})
+			}
+
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+
+		if !reflect.DeepEqual(edges, test.expected) {
+			t.Errorf("%s: edges don't match: expected %v, got %v", name, test.expected, edges)
+		}
+	}
+}
+
+func TestCompareHashes(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+
+	if c := compareHashes("a", "b"); c != -1 {
+		t.Errorf("comparison doesn't match: expected %v, got %v", -1, c)
+	}
+
+	if c := compareHashes(10, 9); c != 1 {
+		t.Errorf("comparison doesn't match: expected %v, got %v", 1, c)
+	}
+
+	if c := compareHashes(point{1, 2}, point{1, 2}); c != 0 {
+		t.Errorf("comparison doesn't match: expected %v, got %v", 0, c)
+	}
+}
+
+// labeledHash is a hash whose string form only consists of its label, so that
+// distinct hashes may be considered equal by compareHashes.
+type labeledHash struct {
+	id    int
+	label string
+}
+
+func (h labeledHash) String() string {
+	return h.label
+}
+
+func TestEdgesPage_equalStringForms(t *testing.T) {
+	a1, a2 := labeledHash{id: 1, label: "a"}, labeledHash{id: 2, label: "a"}
+	b1, b2 := labeledHash{id: 3, label: "b"}, labeledHash{id: 4, label: "b"}
+
+	g := New(func(h labeledHash) labeledHash { return h })
+
+	for _, vertex := range []labeledHash{a1, a2, b1, b2} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge(a1, a2)
+	_ = g.AddEdge(a2, b1)
+	_ = g.AddEdge(b1, b2)
+	_ = g.AddEdge(b2, a1)
+
+	expected, _ := g.Edges()
+
+	for _, limit := range []int{1, 2, 3, 10} {
+		var edges []Edge[labeledHash]
+		cursor := ""
+
+		for {
+			page, next, err := EdgesPage(g, cursor, limit)
+			if err != nil {
+				t.Fatalf("limit %d: unexpected error: %v", limit, err)
+			}
+
+			edges = append(edges, page...)
+
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+
+		if len(edges) != len(expected) {
+			t.Errorf("limit %d: number of edges doesn't match: expected %v, got %v", limit, len(expected), len(edges))
+		}
+	}
+}
+
+func TestCanonicalEdges(t *testing.T) {
+	a1, a2 := labeledHash{id: 1, label: "a"}, labeledHash{id: 2, label: "a"}
+	b := labeledHash{id: 3, label: "b"}
+
+	edges := []Edge[labeledHash]{
+		{Source: a1, Target: a2},
+		{Source: a2, Target: a1},
+		{Source: a1, Target: b},
+		{Source: b, Target: a1},
+		{Source: b, Target: b},
+	}
+
+	canonical := canonicalEdges(edges)
+
+	if len(canonical) != 3 {
+		t.Errorf("number of edges doesn't match: expected %v, got %v", 3, len(canonical))
+	}
+}
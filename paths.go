@@ -1,9 +1,11 @@
 package graph
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 )
 
 var ErrTargetNotReachable = errors.New("target vertex not reachable from source")
@@ -12,7 +14,12 @@ var ErrTargetNotReachable = errors.New("target vertex not reachable from source"
 // would introduce a cycle in the graph. CreatesCycle will not create an edge.
 //
 // A potential edge would create a cycle if the target vertex is also a parent
-// of the source vertex. In order to determine this, CreatesCycle runs a DFS.
+// of the source vertex. In order to determine this, CreatesCycle runs a DFS,
+// unless the store of the graph implements [CycleStore].
+//
+// For undirected graphs created with PreventCycles, the connected components
+// are maintained while adding edges, so that CreatesCycle only has to check
+// whether both vertices are in the same component.
 func CreatesCycle[K comparable, T any](g Graph[K, T], source, target K) (bool, error) {
 	if _, err := g.Vertex(source); err != nil {
 		return false, fmt.Errorf("could not get vertex with hash %v: %w", source, err)
@@ -26,13 +33,30 @@ func CreatesCycle[K comparable, T any](g Graph[K, T], source, target K) (bool, e
 		return true, nil
 	}
 
-	predecessorMap, err := g.PredecessorMap()
+	if u, ok := g.(*undirected[K, T]); ok && u.forest != nil {
+		return u.forest.createsCycle(source, target)
+	}
+
+	if c, ok := g.(*cachedGraph[K, T]); ok {
+		return CreatesCycle(c.Graph, source, target)
+	}
+
+	if store, ok := storeOf(g); ok {
+		if cycleStore, ok := store.(CycleStore[K]); ok {
+			return cycleStore.CreatesCycle(source, target)
+		}
+	}
+
+	predecessorsOf, err := predecessorsFunc(g)
 	if err != nil {
-		return false, fmt.Errorf("failed to get predecessor map: %w", err)
+		return false, err
 	}
 
-	stack := newStack[K]()
-	visited := make(map[K]bool)
+	stack := getStack[K]()
+	defer putStack(stack)
+
+	visited := getMap[K, struct{}]()
+	defer putMap(visited)
 
 	stack.push(source)
 
@@ -46,10 +70,13 @@ func CreatesCycle[K comparable, T any](g Graph[K, T], source, target K) (bool, e
 				return true, nil
 			}
 
-			visited[currentHash] = true
+			visited[currentHash] = struct{}{}
 
-			for adjacency := range predecessorMap[currentHash] {
+			err := predecessorsOf(currentHash, func(adjacency K, _ Edge[K]) {
 				stack.push(adjacency)
+			})
+			if err != nil {
+				return false, fmt.Errorf("failed to get predecessors of %v: %w", currentHash, err)
 			}
 		}
 	}
@@ -65,59 +92,127 @@ func CreatesCycle[K comparable, T any](g Graph[K, T], source, target K) (bool, e
 // not reachable from the source, ErrTargetNotReachable will be returned. Should
 // there be multiple shortest paths, and arbitrary one will be returned.
 //
-// ShortestPath has a time complexity of O(|V|+|E|log(|V|)).
-func ShortestPath[K comparable, T any](g Graph[K, T], source, target K) ([]K, error) {
-	weights := make(map[K]float64)
-	visited := make(map[K]bool)
+// ShortestPath has a time complexity of O(|V|+|E|log(|V|)). Vertices are only
+// added to the priority queue once they have been reached, and the search stops
+// as soon as the target vertex has been reached. If the store of the graph
+// implements [NeighborStore], the successors of each visited vertex are queried
+// on demand, so that the search doesn't load the entire graph into memory.
+//
+// Instead of the stored edge weights, the weights can be derived from the edges
+// at query time by passing WeightedBy with a [WeightFunc].
+func ShortestPath[K comparable, T any](g Graph[K, T], source, target K, options ...func(*WeightOptions[K])) ([]K, error) {
+	return ShortestPathCtx(context.Background(), g, source, target, options...)
+}
 
-	weights[source] = 0
-	visited[target] = true
+// ShortestPathCtx works just as [ShortestPath], but accepts a context that is
+// checked before visiting each vertex. Once the context is cancelled or its
+// deadline is exceeded, ShortestPathCtx returns the context's error.
+func ShortestPathCtx[K comparable, T any](ctx context.Context, g Graph[K, T], source, target K, options ...func(*WeightOptions[K])) ([]K, error) {
+	path, _, err := shortestPath(ctx, g, source, target, options)
+	return path, err
+}
 
-	queue := newPriorityQueue[K]()
-	adjacencyMap, err := g.AdjacencyMap()
-	if err != nil {
-		return nil, fmt.Errorf("could not get adjacency map: %w", err)
+// ShortestPathEdges works just as [ShortestPath], but returns the edges forming
+// the shortest path instead of the vertex hashes. The edges include their
+// properties, so that the weight, attributes, and data of each hop are
+// available without looking up each edge using Graph.Edge:
+//
+//	edges, _ := graph.ShortestPathEdges(g, "A", "B")
+//
+//	for _, edge := range edges {
+//		fmt.Printf("%v -> %v (%v)\n", edge.Source, edge.Target, edge.Properties.Weight)
+//	}
+//
+// Each edge starts at the target of the previous edge, even in undirected
+// graphs. If the source and target vertices are the same, the path is empty.
+func ShortestPathEdges[K comparable, T any](g Graph[K, T], source, target K, options ...func(*WeightOptions[K])) ([]Edge[K], error) {
+	return ShortestPathEdgesCtx(context.Background(), g, source, target, options...)
+}
+
+// ShortestPathEdgesCtx works just as [ShortestPathEdges], but accepts a context
+// as described in [ShortestPathCtx].
+func ShortestPathEdgesCtx[K comparable, T any](ctx context.Context, g Graph[K, T], source, target K, options ...func(*WeightOptions[K])) ([]Edge[K], error) {
+	_, edges, err := shortestPath(ctx, g, source, target, options)
+	return edges, err
+}
+
+// shortestPath computes the shortest path between source and target and returns
+// both the vertex hashes and the edges forming the path.
+func shortestPath[K comparable, T any](ctx context.Context, g Graph[K, T], source, target K, options []func(*WeightOptions[K])) ([]K, []Edge[K], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
 	}
 
-	for hash := range adjacencyMap {
-		if hash != source {
-			weights[hash] = math.Inf(1)
-			visited[hash] = false
-		}
+	if _, err := g.Vertex(source); err != nil {
+		return nil, nil, fmt.Errorf("could not get source vertex: %w", err)
+	}
 
-		queue.Push(hash, weights[hash])
+	successorsOf, err := successorsFunc(g)
+	if err != nil {
+		return nil, nil, err
 	}
 
+	// weights only contains the vertices that have been reached so far, and
+	// only these vertices are pushed into the queue.
+	weights := map[K]float64{source: 0}
+
+	queue := newPriorityQueue[K]()
+	queue.Push(source, 0)
+
 	// bestPredecessors stores the cheapest or least-weighted predecessor for
 	// each vertex. Given an edge AC with weight=4 and an edge BC with weight=2,
 	// the cheapest predecessor for C is B.
 	bestPredecessors := make(map[K]K)
 
+	// bestEdges stores the edge from the best predecessor to each vertex.
+	bestEdges := make(map[K]Edge[K])
+
+	// Setting the weight to 1 is required for unweighted graphs whose edge
+	// weights are 0. Otherwise, all paths would have a sum of 0 and a random
+	// path would be returned.
+	edgeWeight := pathWeightFunc(g, options)
+
 	for queue.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
 		vertex, _ := queue.Pop()
-		hasInfiniteWeight := math.IsInf(weights[vertex], 1)
 
-		for adjacency, edge := range adjacencyMap[vertex] {
-			edgeWeight := edge.Properties.Weight
+		// Once the target has been popped, its weight is final.
+		if vertex == target {
+			break
+		}
 
-			// Setting the weight to 1 is required for unweighted graphs whose
-			// edge weights are 0. Otherwise, all paths would have a sum of 0
-			// and a random path would be returned.
-			if !g.Traits().IsWeighted {
-				edgeWeight = 1
-			}
+		// The successors are collected first, so that the weight function
+		// may access the graph.
+		successors, err := collectNeighbors(successorsOf, vertex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not get successors of %v: %w", vertex, err)
+		}
+
+		for _, successor := range successors {
+			adjacency, edge := successor.hash, successor.edge
+			weight := weights[vertex] + edgeWeight(edge)
 
-			weight := weights[vertex] + float64(edgeWeight)
+			currentWeight, reached := weights[adjacency]
 
-			if weight < weights[adjacency] && !hasInfiniteWeight {
+			if !reached {
 				weights[adjacency] = weight
 				bestPredecessors[adjacency] = vertex
+				bestEdges[adjacency] = edge
+				queue.Push(adjacency, weight)
+			} else if weight < currentWeight {
+				weights[adjacency] = weight
+				bestPredecessors[adjacency] = vertex
+				bestEdges[adjacency] = edge
 				queue.UpdatePriority(adjacency, weight)
 			}
 		}
 	}
 
 	path := []K{target}
+	edges := make([]Edge[K], 0)
 	current := target
 
 	for current != source {
@@ -126,20 +221,187 @@ func ShortestPath[K comparable, T any](g Graph[K, T], source, target K) ([]K, er
 		// endless prepending of zero values to the path. Also, the target would
 		// not be reachable from one of the preceding vertices.
 		if _, ok := bestPredecessors[current]; !ok {
-			return nil, ErrTargetNotReachable
+			return nil, nil, ErrTargetNotReachable
 		}
+
+		edge := bestEdges[current]
+		edge.Source, edge.Target = bestPredecessors[current], current
+		edge.Properties.Attributes = copyAttributes(edge.Properties.Attributes)
+		edges = append(edges, edge)
+
 		current = bestPredecessors[current]
 		path = append([]K{current}, path...)
 	}
 
-	return path, nil
+	// The edges have been collected from the target to the source.
+	for i, j := 0, len(edges)-1; i < j; i, j = i+1, j-1 {
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+
+	return path, edges, nil
+}
+
+// VertexDistance is a vertex hash along with the distance of the vertex from a
+// source vertex, as returned by [NearestVertices].
+type VertexDistance[K comparable] struct {
+	Hash     K
+	Distance float64
+}
+
+// NearestVertices returns the k vertices closest to the source vertex whose
+// distance doesn't exceed maxDist, sorted by their distance. The distance of a
+// vertex is the sum of the edge weights of the shortest path from the source,
+// where each edge has a weight of 1 for unweighted graphs. The source itself
+// isn't included:
+//
+//	nearby, _ := graph.NearestVertices(g, "berlin", 5, 300)
+//
+//	for _, vertex := range nearby {
+//		fmt.Printf("%v: %v km\n", vertex.Hash, vertex.Distance)
+//	}
+//
+// The search stops as soon as the k closest vertices have been found, so only
+// the neighborhood of the source is visited instead of computing the complete
+// shortest path tree. Pass math.Inf(1) as maxDist to only limit the number of
+// vertices. Vertices with the same distance are sorted as described in
+// [SortedVertices]. Edge weights must not be negative.
+//
+// Instead of the stored edge weights, the weights can be derived from the edges
+// at query time by passing WeightedBy with a [WeightFunc].
+func NearestVertices[K comparable, T any](g Graph[K, T], source K, k int, maxDist float64, options ...func(*WeightOptions[K])) ([]VertexDistance[K], error) {
+	return NearestVerticesCtx(context.Background(), g, source, k, maxDist, options...)
+}
+
+// NearestVerticesCtx works just as [NearestVertices], but accepts a context
+// that is checked before visiting each vertex.
+func NearestVerticesCtx[K comparable, T any](ctx context.Context, g Graph[K, T], source K, k int, maxDist float64, options ...func(*WeightOptions[K])) ([]VertexDistance[K], error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("k must be positive, got %d", k)
+	}
+
+	if _, err := g.Vertex(source); err != nil {
+		return nil, fmt.Errorf("could not get source vertex: %w", err)
+	}
+
+	successorsOf, err := successorsFunc(g)
+	if err != nil {
+		return nil, err
+	}
+
+	edgeWeight := pathWeightFunc(g, options)
+
+	distances := map[K]float64{source: 0}
+	visited := make(map[K]struct{})
+
+	queue := newPriorityQueue[K]()
+	queue.Push(source, 0)
+
+	nearest := make([]VertexDistance[K], 0, k)
+
+	for queue.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		vertex, _ := queue.Pop()
+		distance := distances[vertex]
+		visited[vertex] = struct{}{}
+
+		if distance > maxDist {
+			break
+		}
+
+		// Vertices with the same distance as the k-th vertex are collected as
+		// well, so that the result doesn't depend on the order of the queue.
+		if len(nearest) >= k && distance > nearest[len(nearest)-1].Distance {
+			break
+		}
+
+		if vertex != source {
+			nearest = append(nearest, VertexDistance[K]{Hash: vertex, Distance: distance})
+		}
+
+		successors, err := collectNeighbors(successorsOf, vertex)
+		if err != nil {
+			return nil, fmt.Errorf("could not get successors of %v: %w", vertex, err)
+		}
+
+		for _, successor := range successors {
+			adjacency := successor.hash
+			if _, ok := visited[adjacency]; ok {
+				continue
+			}
+
+			weight := distance + edgeWeight(successor.edge)
+
+			currentWeight, reached := distances[adjacency]
+
+			if !reached {
+				distances[adjacency] = weight
+				queue.Push(adjacency, weight)
+			} else if weight < currentWeight {
+				distances[adjacency] = weight
+				queue.UpdatePriority(adjacency, weight)
+			}
+		}
+	}
+
+	sort.SliceStable(nearest, func(i, j int) bool {
+		if nearest[i].Distance != nearest[j].Distance {
+			return nearest[i].Distance < nearest[j].Distance
+		}
+		return compareHashes(nearest[i].Hash, nearest[j].Hash) < 0
+	})
+
+	if len(nearest) > k {
+		nearest = nearest[:k]
+	}
+
+	return nearest, nil
+}
+
+// ResolvePath turns a path of vertex hashes, as returned by ShortestPath or
+// AllPathsBetween, into the values of its vertices and the edges joining them,
+// including their properties:
+//
+//	path, _ := graph.ShortestPath(g, "A", "B")
+//	cities, edges, _ := graph.ResolvePath(g, path)
+//
+// The i-th edge joins the i-th and the (i+1)-th vertex, even in undirected
+// graphs. If a vertex doesn't exist or two consecutive vertices aren't joined
+// by an edge, an error wrapping ErrVertexNotFound or ErrEdgeNotFound is
+// returned.
+func ResolvePath[K comparable, T any](g Graph[K, T], path []K) ([]T, []Edge[T], error) {
+	values := make([]T, 0, len(path))
+
+	for _, hash := range path {
+		value, err := g.Vertex(hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+		values = append(values, value)
+	}
+
+	edges := make([]Edge[T], 0, len(path))
+
+	for i := 1; i < len(path); i++ {
+		edge, err := g.Edge(path[i-1], path[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("hop %d from %v to %v: %w", i, path[i-1], path[i], err)
+		}
+		edges = append(edges, edge)
+	}
+
+	return values, edges, nil
 }
 
 type sccState[K comparable] struct {
+	ctx          context.Context
 	adjacencyMap map[K]map[K]Edge[K]
-	components   [][]K
+	emit         func([]K) bool
+	stopped      bool
 	stack        *stack[K]
-	visited      map[K]struct{}
+	visited      visitedSet[K]
 	lowlink      map[K]int
 	index        map[K]int
 	time         int
@@ -151,44 +413,115 @@ type sccState[K comparable] struct {
 //
 // StronglyConnectedComponents can only run on directed graphs.
 func StronglyConnectedComponents[K comparable, T any](g Graph[K, T]) ([][]K, error) {
+	return StronglyConnectedComponentsCtx(context.Background(), g)
+}
+
+// StronglyConnectedComponentsCtx works just as [StronglyConnectedComponents],
+// but accepts a context that is checked before visiting each vertex. Once the
+// context is cancelled or its deadline is exceeded, the context's error is
+// returned.
+func StronglyConnectedComponentsCtx[K comparable, T any](ctx context.Context, g Graph[K, T]) ([][]K, error) {
+	components := make([][]K, 0)
+
+	err := StronglyConnectedComponentsFuncCtx(ctx, g, func(component []K) bool {
+		components = append(components, component)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+// StronglyConnectedComponentsFunc works just as [StronglyConnectedComponents],
+// but passes each component to the given function as soon as it has been
+// found instead of collecting all components. This allows processing the
+// components of huge graphs one by one:
+//
+//	_ = graph.StronglyConnectedComponentsFunc(g, func(component []int) bool {
+//		fmt.Println(component)
+//		return false
+//	})
+//
+// Tarjan's algorithm finds the components in reverse topological order, so a
+// component is always found before the components that have an edge leading
+// to it. Just like with DFS, the search is stopped if the function returns
+// true.
+func StronglyConnectedComponentsFunc[K comparable, T any](g Graph[K, T], visit func([]K) bool) error {
+	return StronglyConnectedComponentsFuncCtx(context.Background(), g, visit)
+}
+
+// StronglyConnectedComponentsFuncCtx works just as
+// [StronglyConnectedComponentsFunc], but accepts a context that is checked
+// before visiting each vertex. Once the context is cancelled or its deadline
+// is exceeded, the context's error is returned.
+func StronglyConnectedComponentsFuncCtx[K comparable, T any](ctx context.Context, g Graph[K, T], visit func([]K) bool) error {
 	if !g.Traits().IsDirected {
-		return nil, errors.New("SCCs can only be detected in directed graphs")
+		return errors.New("SCCs can only be detected in directed graphs")
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
 	adjacencyMap, err := g.AdjacencyMap()
 	if err != nil {
-		return nil, fmt.Errorf("could not get adjacency map: %w", err)
+		return fmt.Errorf("could not get adjacency map: %w", err)
 	}
 
 	state := &sccState[K]{
+		ctx:          ctx,
 		adjacencyMap: adjacencyMap,
-		components:   make([][]K, 0),
-		stack:        newStack[K](),
-		visited:      make(map[K]struct{}),
-		lowlink:      make(map[K]int),
-		index:        make(map[K]int),
+		emit:         visit,
+		stack:        getStack[K](),
+		visited:      newVisitedSet(g),
+		lowlink:      getMap[K, int](),
+		index:        getMap[K, int](),
 	}
 
+	defer func() {
+		putStack(state.stack)
+		state.visited.release()
+		putMap(state.lowlink)
+		putMap(state.index)
+	}()
+
 	for hash := range state.adjacencyMap {
-		if _, ok := state.visited[hash]; !ok {
-			findSCC(hash, state)
+		if state.stopped {
+			break
+		}
+		if !state.visited.contains(hash) {
+			if err := findSCC(hash, state); err != nil {
+				return err
+			}
 		}
 	}
 
-	return state.components, nil
+	return nil
 }
 
-func findSCC[K comparable](vertexHash K, state *sccState[K]) {
+func findSCC[K comparable](vertexHash K, state *sccState[K]) error {
+	if err := state.ctx.Err(); err != nil {
+		return err
+	}
+
 	state.stack.push(vertexHash)
-	state.visited[vertexHash] = struct{}{}
+	state.visited.add(vertexHash)
 	state.index[vertexHash] = state.time
 	state.lowlink[vertexHash] = state.time
 
 	state.time++
 
 	for adjacency := range state.adjacencyMap[vertexHash] {
-		if _, ok := state.visited[adjacency]; !ok {
-			findSCC(adjacency, state)
+		if !state.visited.contains(adjacency) {
+			if err := findSCC(adjacency, state); err != nil {
+				return err
+			}
+
+			if state.stopped {
+				return nil
+			}
 
 			smallestLowlink := math.Min(
 				float64(state.lowlink[vertexHash]),
@@ -223,8 +556,10 @@ func findSCC[K comparable](vertexHash K, state *sccState[K]) {
 			component = append(component, hash)
 		}
 
-		state.components = append(state.components, component)
+		state.stopped = state.emit(component)
 	}
+
+	return nil
 }
 
 // AllPathsBetween computes and returns all paths between two given vertices. A
@@ -233,7 +568,22 @@ func findSCC[K comparable](vertexHash K, state *sccState[K]) {
 //
 // AllPathsBetween utilizes a non-recursive, stack-based implementation. It has
 // an estimated runtime complexity of O(n^2) where n is the number of vertices.
-func AllPathsBetween[K comparable, T any](g Graph[K, T], start, end K) ([][]K, error) {
+//
+// The number of paths can grow exponentially with the size of the graph. To
+// bound the work done by a single call, limits like MaxVisited, Timeout, and
+// MaxResults can be passed. If MaxVisited or Timeout is exceeded, the paths
+// found so far are returned together with an error wrapping ErrLimitExceeded.
+func AllPathsBetween[K comparable, T any](g Graph[K, T], start, end K, limits ...func(*Limits)) ([][]K, error) {
+	return AllPathsBetweenCtx(context.Background(), g, start, end, limits...)
+}
+
+// AllPathsBetweenCtx works just as [AllPathsBetween], but accepts a context that
+// is checked before visiting each vertex. Once the context is cancelled or its
+// deadline is exceeded, AllPathsBetweenCtx returns the paths found so far along
+// with the context's error.
+func AllPathsBetweenCtx[K comparable, T any](ctx context.Context, g Graph[K, T], start, end K, limits ...func(*Limits)) ([][]K, error) {
+	guard := newGuard(limits)
+
 	adjacencyMap, err := g.AdjacencyMap()
 	if err != nil {
 		return nil, err
@@ -251,7 +601,15 @@ func AllPathsBetween[K comparable, T any](g Graph[K, T], start, end K) ([][]K, e
 		return nil
 	}
 
-	buildLayer := func(element K) {
+	buildLayer := func(element K) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := guard.visit(); err != nil {
+			return err
+		}
+
 		mainStack.push(element)
 		newElements := newStack[K]()
 
@@ -268,6 +626,8 @@ func AllPathsBetween[K comparable, T any](g Graph[K, T], start, end K) ([][]K, e
 			newElements.push(e)
 		}
 		viceStack.push(newElements)
+
+		return nil
 	}
 
 	buildStack := func() error {
@@ -279,7 +639,9 @@ func AllPathsBetween[K comparable, T any](g Graph[K, T], start, end K) ([][]K, e
 
 		for !elements.isEmpty() {
 			element, _ := elements.pop()
-			buildLayer(element)
+			if err := buildLayer(element); err != nil {
+				return err
+			}
 			elements, _ = viceStack.top()
 		}
 
@@ -301,10 +663,12 @@ func AllPathsBetween[K comparable, T any](g Graph[K, T], start, end K) ([][]K, e
 		return nil
 	}
 
-	buildLayer(start)
-
 	allPaths := make([][]K, 0)
 
+	if err = buildLayer(start); err != nil {
+		return allPaths, err
+	}
+
 	for !mainStack.isEmpty() {
 		v, _ := mainStack.top()
 		adjs, _ := viceStack.top()
@@ -316,6 +680,10 @@ func AllPathsBetween[K comparable, T any](g Graph[K, T], start, end K) ([][]K, e
 					path = append(path, k)
 				})
 				allPaths = append(allPaths, path)
+
+				if guard.resultsReached(len(allPaths)) {
+					break
+				}
 			}
 
 			err = removeLayer()
@@ -324,6 +692,9 @@ func AllPathsBetween[K comparable, T any](g Graph[K, T], start, end K) ([][]K, e
 			}
 		} else {
 			if err = buildStack(); err != nil {
+				if errors.Is(err, ErrLimitExceeded) || err == ctx.Err() {
+					return allPaths, err
+				}
 				return nil, err
 			}
 		}
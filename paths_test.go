@@ -1,8 +1,12 @@
 package graph
 
 import (
+	"context"
+	"errors"
+	"math"
 	"reflect"
 	"sort"
+	"strconv"
 	"testing"
 )
 
@@ -654,3 +658,583 @@ func TestAllPathsBetween(t *testing.T) {
 		})
 	}
 }
+
+// countingNeighborStore is a store that counts the calls to EdgesBySource.
+type countingNeighborStore[K comparable, T any] struct {
+	Store[K, T]
+	neighbors NeighborStore[K]
+	calls     map[K]int
+}
+
+func (s *countingNeighborStore[K, T]) EdgesBySource(hash K) ([]Edge[K], error) {
+	s.calls[hash]++
+	return s.neighbors.EdgesBySource(hash)
+}
+
+func (s *countingNeighborStore[K, T]) EdgesByTarget(hash K) ([]Edge[K], error) {
+	return s.neighbors.EdgesByTarget(hash)
+}
+
+func TestShortestPath_visitedVertices(t *testing.T) {
+	memoryStore := newMemoryStore[int, int]()
+	store := &countingNeighborStore[int, int]{
+		Store:     memoryStore,
+		neighbors: memoryStore.(NeighborStore[int]),
+		calls:     make(map[int]int),
+	}
+
+	g := NewWithStore(IntHash, Store[int, int](store), Directed())
+
+	// A chain 0 -> 1 -> ... -> 99 with a shortcut 0 -> 50.
+	for i := 0; i < 100; i++ {
+		_ = g.AddVertex(i)
+	}
+	for i := 0; i < 99; i++ {
+		_ = g.AddEdge(i, i+1)
+	}
+	_ = g.AddEdge(0, 50)
+
+	path, err := ShortestPath(g, 0, 52)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []int{0, 50, 51, 52}
+
+	if !slicesAreEqual(path, expected) {
+		t.Errorf("shortest path doesn't match: expected %v, got %v", expected, path)
+	}
+
+	// Only the vertices that aren't further away from the source than the
+	// target are expanded, which are 0, 1, 2, 3, 50, and 51.
+	if len(store.calls) > 6 {
+		t.Errorf("number of expanded vertices doesn't match: expected at most %v, got %v (%v)", 6, len(store.calls), store.calls)
+	}
+
+	for hash, calls := range store.calls {
+		if calls != 1 {
+			t.Errorf("vertex %v has been expanded %v times", hash, calls)
+		}
+	}
+
+	if _, err := ShortestPath(g, 100, 1); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrVertexNotFound, err)
+	}
+}
+
+// countdownContext is a context that is cancelled after its Err method has been
+// called the given number of times. It allows cancelling an algorithm at a
+// particular loop iteration.
+type countdownContext struct {
+	context.Context
+	checks int
+}
+
+func newCountdownContext(checks int) *countdownContext {
+	return &countdownContext{
+		Context: context.Background(),
+		checks:  checks,
+	}
+}
+
+func (c *countdownContext) Err() error {
+	if c.checks <= 0 {
+		return context.Canceled
+	}
+	c.checks--
+	return nil
+}
+
+func TestShortestPathCtx(t *testing.T) {
+	tests := map[string]struct {
+		ctx          context.Context
+		expectedPath []int
+		expectedErr  error
+	}{
+		"no cancellation": {
+			ctx:          context.Background(),
+			expectedPath: []int{1, 2, 3, 4},
+		},
+		"cancelled before the search": {
+			ctx:         newCountdownContext(0),
+			expectedErr: context.Canceled,
+		},
+		"cancelled during the search": {
+			ctx:         newCountdownContext(2),
+			expectedErr: context.Canceled,
+		},
+	}
+
+	for name, test := range tests {
+		graph := New(IntHash, Directed())
+
+		for _, vertex := range []int{1, 2, 3, 4} {
+			_ = graph.AddVertex(vertex)
+		}
+
+		_ = graph.AddEdge(1, 2)
+		_ = graph.AddEdge(2, 3)
+		_ = graph.AddEdge(3, 4)
+
+		path, err := ShortestPathCtx(test.ctx, graph, 1, 4)
+
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+		}
+
+		if !slicesAreEqual(path, test.expectedPath) {
+			t.Errorf("%s: path doesn't match: expected %v, got %v", name, test.expectedPath, path)
+		}
+	}
+}
+
+func TestStronglyConnectedComponentsCtx(t *testing.T) {
+	tests := map[string]struct {
+		ctx                context.Context
+		expectedComponents int
+		expectedErr        error
+	}{
+		"no cancellation": {
+			ctx:                context.Background(),
+			expectedComponents: 2,
+		},
+		"cancelled before the search": {
+			ctx:         newCountdownContext(0),
+			expectedErr: context.Canceled,
+		},
+		"cancelled during the search": {
+			ctx:         newCountdownContext(3),
+			expectedErr: context.Canceled,
+		},
+	}
+
+	for name, test := range tests {
+		graph := New(IntHash, Directed())
+
+		for _, vertex := range []int{1, 2, 3, 4} {
+			_ = graph.AddVertex(vertex)
+		}
+
+		_ = graph.AddEdge(1, 2)
+		_ = graph.AddEdge(2, 1)
+		_ = graph.AddEdge(3, 4)
+		_ = graph.AddEdge(4, 3)
+
+		components, err := StronglyConnectedComponentsCtx(test.ctx, graph)
+
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+		}
+
+		if len(components) != test.expectedComponents {
+			t.Errorf("%s: number of components doesn't match: expected %v, got %v", name, test.expectedComponents, len(components))
+		}
+	}
+}
+
+func TestStronglyConnectedComponentsFunc(t *testing.T) {
+	tests := map[string]struct {
+		stopAfter          int
+		expectedComponents int
+	}{
+		"all components": {
+			stopAfter:          -1,
+			expectedComponents: 3,
+		},
+		"stop after the first component": {
+			stopAfter:          1,
+			expectedComponents: 1,
+		},
+		"stop after the second component": {
+			stopAfter:          2,
+			expectedComponents: 2,
+		},
+	}
+
+	for name, test := range tests {
+		graph := New(IntHash, Directed())
+
+		for _, vertex := range []int{1, 2, 3, 4, 5} {
+			_ = graph.AddVertex(vertex)
+		}
+
+		// The components {1, 2}, {3, 4}, and {5} form a chain.
+		_ = graph.AddEdge(1, 2)
+		_ = graph.AddEdge(2, 1)
+		_ = graph.AddEdge(2, 3)
+		_ = graph.AddEdge(3, 4)
+		_ = graph.AddEdge(4, 3)
+		_ = graph.AddEdge(4, 5)
+
+		componentOf := map[int]int{1: 0, 2: 0, 3: 1, 4: 1, 5: 2}
+		components := make([][]int, 0)
+
+		err := StronglyConnectedComponentsFunc(graph, func(component []int) bool {
+			components = append(components, component)
+			return len(components) == test.stopAfter
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if len(components) != test.expectedComponents {
+			t.Fatalf("%s: number of components doesn't match: expected %v, got %v", name, test.expectedComponents, len(components))
+		}
+
+		// Components are found in reverse topological order, and a component
+		// is only passed once it is complete.
+		if len(components) == 3 {
+			for i, component := range components {
+				expected := 2 - i
+				for _, vertex := range component {
+					if componentOf[vertex] != expected {
+						t.Errorf("%s: unexpected vertex %v in component %v", name, vertex, component)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestStronglyConnectedComponentsFunc_undirected(t *testing.T) {
+	err := StronglyConnectedComponentsFunc(New(IntHash), func([]int) bool { return false })
+	if err == nil {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", true, false)
+	}
+}
+
+func TestAllPathsBetweenCtx(t *testing.T) {
+	g := completeDAG(8)
+
+	paths, err := AllPathsBetweenCtx(newCountdownContext(20), g, 0, 7)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", context.Canceled, err)
+	}
+
+	if len(paths) == 0 || len(paths) >= 64 {
+		t.Errorf("expected a part of the paths to be returned, got %v paths", len(paths))
+	}
+}
+
+func TestShortestPath_weightFunc(t *testing.T) {
+	g := New(StringHash, Directed(), Weighted())
+
+	for _, vertex := range []string{"A", "B", "C", "D"} {
+		_ = g.AddVertex(vertex)
+	}
+
+	// The route via B is cheap but slow, the route via C is fast but costly.
+	_ = g.AddEdge("A", "B", EdgeWeight(1), EdgeAttribute("latency", "50"))
+	_ = g.AddEdge("B", "D", EdgeWeight(1), EdgeAttribute("latency", "50"))
+	_ = g.AddEdge("A", "C", EdgeWeight(10), EdgeAttribute("latency", "0.5"))
+	_ = g.AddEdge("C", "D", EdgeWeight(10), EdgeAttribute("latency", "0.5"))
+
+	latency := func(edge Edge[string]) float64 {
+		l, _ := strconv.ParseFloat(edge.Properties.Attributes["latency"], 64)
+		return l
+	}
+
+	tests := map[string]struct {
+		options      []func(*WeightOptions[string])
+		expectedPath []string
+	}{
+		"stored weights": {
+			expectedPath: []string{"A", "B", "D"},
+		},
+		"weight function": {
+			options:      []func(*WeightOptions[string]){WeightedBy(latency)},
+			expectedPath: []string{"A", "C", "D"},
+		},
+	}
+
+	for name, test := range tests {
+		path, err := ShortestPath(g, "A", "D", test.options...)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if !reflect.DeepEqual(path, test.expectedPath) {
+			t.Errorf("%s: path doesn't match: expected %v, got %v", name, test.expectedPath, path)
+		}
+	}
+}
+
+func TestShortestPath_weightFuncAccessingGraph(t *testing.T) {
+	g := New(IntHash, Directed())
+
+	_ = g.AddVertex(1)
+	_ = g.AddVertex(2)
+	_ = g.AddEdge(1, 2)
+
+	// The weight function modifies the graph, which deadlocks if it is called
+	// while the store holds its lock.
+	weight := func(edge Edge[int]) float64 {
+		_ = g.AddVertex(3)
+		_, _ = g.Vertex(edge.Target)
+		return 1
+	}
+
+	path, err := ShortestPath(g, 1, 2, WeightedBy(weight))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(path, []int{1, 2}) {
+		t.Errorf("path doesn't match: expected %v, got %v", []int{1, 2}, path)
+	}
+
+	nearest, err := NearestVertices(g, 1, 1, math.Inf(1), WeightedBy(weight))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(nearest) != 1 || nearest[0].Hash != 2 {
+		t.Errorf("nearest vertices don't match: expected %v, got %v", 2, nearest)
+	}
+}
+
+func TestShortestPathEdges(t *testing.T) {
+	tests := map[string]struct {
+		traits        []func(*Traits)
+		source        string
+		target        string
+		expectedEdges []Edge[string]
+		expectedErr   error
+	}{
+		"directed graph": {
+			traits: []func(*Traits){Directed(), Weighted()},
+			source: "A",
+			target: "D",
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "B", Properties: EdgeProperties{Weight: 1, Attributes: map[string]string{"line": "1"}, Data: "ab"}},
+				{Source: "B", Target: "D", Properties: EdgeProperties{Weight: 2, Attributes: map[string]string{"line": "2"}}},
+			},
+		},
+		"undirected graph traversed backwards": {
+			traits: []func(*Traits){Weighted()},
+			source: "D",
+			target: "A",
+			expectedEdges: []Edge[string]{
+				{Source: "D", Target: "B", Properties: EdgeProperties{Weight: 2, Attributes: map[string]string{"line": "2"}}},
+				{Source: "B", Target: "A", Properties: EdgeProperties{Weight: 1, Attributes: map[string]string{"line": "1"}, Data: "ab"}},
+			},
+		},
+		"source is target": {
+			traits:        []func(*Traits){Directed(), Weighted()},
+			source:        "A",
+			target:        "A",
+			expectedEdges: []Edge[string]{},
+		},
+		"target not reachable": {
+			traits:      []func(*Traits){Directed(), Weighted()},
+			source:      "D",
+			target:      "A",
+			expectedErr: ErrTargetNotReachable,
+		},
+	}
+
+	for name, test := range tests {
+		g := New(StringHash, test.traits...)
+
+		for _, vertex := range []string{"A", "B", "C", "D"} {
+			_ = g.AddVertex(vertex)
+		}
+
+		_ = g.AddEdge("A", "B", EdgeWeight(1), EdgeAttribute("line", "1"), EdgeData("ab"))
+		_ = g.AddEdge("B", "D", EdgeWeight(2), EdgeAttribute("line", "2"))
+		_ = g.AddEdge("A", "C", EdgeWeight(2), EdgeAttribute("line", "3"))
+		_ = g.AddEdge("C", "D", EdgeWeight(2), EdgeAttribute("line", "4"))
+
+		edges, err := ShortestPathEdges(g, test.source, test.target)
+
+		if !errors.Is(err, test.expectedErr) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+		}
+
+		if test.expectedErr != nil {
+			continue
+		}
+
+		if !reflect.DeepEqual(edges, test.expectedEdges) {
+			t.Errorf("%s: edges don't match: expected %v, got %v", name, test.expectedEdges, edges)
+		}
+	}
+}
+
+func TestNearestVertices(t *testing.T) {
+	tests := map[string]struct {
+		traits        []func(*Traits)
+		source        string
+		k             int
+		maxDist       float64
+		expected      []VertexDistance[string]
+		expectedError bool
+	}{
+		"k closest vertices": {
+			traits:  []func(*Traits){Directed(), Weighted()},
+			source:  "A",
+			k:       2,
+			maxDist: math.Inf(1),
+			expected: []VertexDistance[string]{
+				{Hash: "B", Distance: 1},
+				{Hash: "C", Distance: 2},
+			},
+		},
+		"ties sorted by hash": {
+			traits:  []func(*Traits){Directed(), Weighted()},
+			source:  "A",
+			k:       3,
+			maxDist: math.Inf(1),
+			expected: []VertexDistance[string]{
+				{Hash: "B", Distance: 1},
+				{Hash: "C", Distance: 2},
+				{Hash: "D", Distance: 3},
+			},
+		},
+		"bounded distance": {
+			traits:  []func(*Traits){Directed(), Weighted()},
+			source:  "A",
+			k:       10,
+			maxDist: 2,
+			expected: []VertexDistance[string]{
+				{Hash: "B", Distance: 1},
+				{Hash: "C", Distance: 2},
+			},
+		},
+		"fewer reachable vertices than k": {
+			traits:  []func(*Traits){Directed(), Weighted()},
+			source:  "D",
+			k:       3,
+			maxDist: math.Inf(1),
+			expected: []VertexDistance[string]{
+				{Hash: "F", Distance: 5},
+			},
+		},
+		"unweighted graph": {
+			traits:  []func(*Traits){},
+			source:  "D",
+			k:       3,
+			maxDist: math.Inf(1),
+			expected: []VertexDistance[string]{
+				{Hash: "B", Distance: 1},
+				{Hash: "F", Distance: 1},
+				{Hash: "A", Distance: 2},
+			},
+		},
+		"invalid k": {
+			traits:        []func(*Traits){Directed()},
+			source:        "A",
+			k:             0,
+			expectedError: true,
+		},
+		"missing source": {
+			traits:        []func(*Traits){Directed()},
+			source:        "X",
+			k:             1,
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		g := New(StringHash, test.traits...)
+
+		for _, vertex := range []string{"A", "B", "C", "D", "E", "F"} {
+			_ = g.AddVertex(vertex)
+		}
+
+		_ = g.AddEdge("A", "B", EdgeWeight(1))
+		_ = g.AddEdge("A", "C", EdgeWeight(4))
+		_ = g.AddEdge("B", "C", EdgeWeight(1))
+		_ = g.AddEdge("B", "D", EdgeWeight(2))
+		_ = g.AddEdge("C", "E", EdgeWeight(1))
+		_ = g.AddEdge("D", "F", EdgeWeight(5))
+
+		nearest, err := NearestVertices(g, test.source, test.k, test.maxDist)
+
+		if test.expectedError != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.expectedError, err != nil, err)
+		}
+
+		if test.expectedError {
+			continue
+		}
+
+		if !reflect.DeepEqual(nearest, test.expected) {
+			t.Errorf("%s: nearest vertices don't match: expected %v, got %v", name, test.expected, nearest)
+		}
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	tests := map[string]struct {
+		traits         []func(*Traits)
+		path           []int
+		expectedValues []int
+		expectedEdges  []Edge[int]
+		expectedErr    error
+	}{
+		"directed graph": {
+			traits:         []func(*Traits){Directed()},
+			path:           []int{1, 2, 3},
+			expectedValues: []int{1, 2, 3},
+			expectedEdges: []Edge[int]{
+				{Source: 1, Target: 2, Properties: EdgeProperties{Weight: 4, Attributes: map[string]string{}}},
+				{Source: 2, Target: 3, Properties: EdgeProperties{Attributes: map[string]string{"color": "red"}}},
+			},
+		},
+		"undirected graph traversed backwards": {
+			path:           []int{3, 2, 1},
+			expectedValues: []int{3, 2, 1},
+			expectedEdges: []Edge[int]{
+				{Source: 3, Target: 2, Properties: EdgeProperties{Attributes: map[string]string{"color": "red"}}},
+				{Source: 2, Target: 1, Properties: EdgeProperties{Weight: 4, Attributes: map[string]string{}}},
+			},
+		},
+		"empty path": {
+			traits:         []func(*Traits){Directed()},
+			path:           []int{},
+			expectedValues: []int{},
+			expectedEdges:  []Edge[int]{},
+		},
+		"unconnected hop": {
+			traits:      []func(*Traits){Directed()},
+			path:        []int{1, 3},
+			expectedErr: ErrEdgeNotFound,
+		},
+		"missing vertex": {
+			traits:      []func(*Traits){Directed()},
+			path:        []int{1, 2, 4},
+			expectedErr: ErrVertexNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash, test.traits...)
+
+		for _, vertex := range []int{1, 2, 3} {
+			_ = g.AddVertex(vertex)
+		}
+
+		_ = g.AddEdge(1, 2, EdgeWeight(4))
+		_ = g.AddEdge(2, 3, EdgeAttribute("color", "red"))
+
+		values, edges, err := ResolvePath(g, test.path)
+
+		if !errors.Is(err, test.expectedErr) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+		}
+
+		if test.expectedErr != nil {
+			continue
+		}
+
+		if !reflect.DeepEqual(values, test.expectedValues) {
+			t.Errorf("%s: values don't match: expected %v, got %v", name, test.expectedValues, values)
+		}
+
+		if !reflect.DeepEqual(edges, test.expectedEdges) {
+			t.Errorf("%s: edges don't match: expected %v, got %v", name, test.expectedEdges, edges)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"reflect"
+	"sync"
+)
+
+// maxPooledSize is the maximum number of elements a collection may have held to
+// be put back into its pool. Larger collections are left to the garbage
+// collector so that a single traversal of a huge graph doesn't pin its memory.
+const maxPooledSize = 1 << 16
+
+// pools holds a sync.Pool for each type of internal collection. A sync.Pool
+// can't be declared for a type parameter, so the pools are created lazily and
+// looked up by the reflected collection type.
+var pools sync.Map
+
+// poolOf returns the pool for collections of type C, creating it using the
+// given function if it doesn't exist yet.
+func poolOf[C any](newFunc func() interface{}) *sync.Pool {
+	key := reflect.TypeOf((*C)(nil))
+
+	if pool, ok := pools.Load(key); ok {
+		return pool.(*sync.Pool)
+	}
+
+	pool, _ := pools.LoadOrStore(key, &sync.Pool{New: newFunc})
+
+	return pool.(*sync.Pool)
+}
+
+// getStack returns an empty stack from the pool.
+func getStack[T comparable]() *stack[T] {
+	return poolOf[stack[T]](func() interface{} {
+		return newStack[T]()
+	}).Get().(*stack[T])
+}
+
+// putStack resets the given stack and puts it back into the pool. The stack
+// must not be used afterwards.
+func putStack[T comparable](s *stack[T]) {
+	if cap(s.elements) > maxPooledSize || len(s.registry) > maxPooledSize {
+		return
+	}
+
+	s.reset()
+
+	poolOf[stack[T]](func() interface{} {
+		return newStack[T]()
+	}).Put(s)
+}
+
+// getQueue returns an empty queue from the pool.
+func getQueue[T any]() *queue[T] {
+	return poolOf[queue[T]](func() interface{} {
+		return newQueue[T]()
+	}).Get().(*queue[T])
+}
+
+// putQueue resets the given queue and puts it back into the pool. The queue
+// must not be used afterwards.
+func putQueue[T any](q *queue[T]) {
+	if cap(q.elements) > maxPooledSize {
+		return
+	}
+
+	q.reset()
+
+	poolOf[queue[T]](func() interface{} {
+		return newQueue[T]()
+	}).Put(q)
+}
+
+// getMap returns an empty map from the pool. It is used for visited sets as
+// well as for other per-vertex bookkeeping.
+func getMap[K comparable, V any]() map[K]V {
+	return poolOf[map[K]V](func() interface{} {
+		return make(map[K]V)
+	}).Get().(map[K]V)
+}
+
+// putMap clears the given map and puts it back into the pool. The map must not
+// be used afterwards.
+func putMap[K comparable, V any](m map[K]V) {
+	if len(m) > maxPooledSize {
+		return
+	}
+
+	for key := range m {
+		delete(m, key)
+	}
+
+	poolOf[map[K]V](func() interface{} {
+		return make(map[K]V)
+	}).Put(m)
+}
@@ -0,0 +1,263 @@
+// Package rdf exports graphs as RDF, so that knowledge graphs built with this
+// library can be loaded into triple stores and queried using SPARQL. At this
+// time, rdf supports the Turtle syntax.
+package rdf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/dominikbraun/graph"
+)
+
+const (
+	rdfsLabel   = "http://www.w3.org/2000/01/rdf-schema#label"
+	xsdInteger  = "http://www.w3.org/2001/XMLSchema#integer"
+	defaultBase = "urn:graph:"
+)
+
+type config struct {
+	base               string
+	predicateAttribute string
+	defaultPredicate   string
+	prefixes           map[string]string
+}
+
+func newConfig(options ...func(*config)) config {
+	c := config{
+		base:     defaultBase,
+		prefixes: make(map[string]string),
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	if c.defaultPredicate == "" {
+		c.defaultPredicate = c.base + "edge"
+	}
+
+	return c
+}
+
+// BaseIRI sets the IRI that vertex hashes and attribute keys are appended to in
+// order to form IRIs. The default is "urn:graph:".
+func BaseIRI(iri string) func(*config) {
+	return func(c *config) {
+		c.base = iri
+	}
+}
+
+// PredicateAttribute sets the edge attribute that contains the predicate IRI
+// of an edge, for example "http://xmlns.com/foaf/0.1/knows". Edges without this
+// attribute use the default predicate.
+func PredicateAttribute(key string) func(*config) {
+	return func(c *config) {
+		c.predicateAttribute = key
+	}
+}
+
+// DefaultPredicate sets the predicate IRI for edges without a predicate
+// attribute. The default is the base IRI followed by "edge".
+func DefaultPredicate(iri string) func(*config) {
+	return func(c *config) {
+		c.defaultPredicate = iri
+	}
+}
+
+// Prefix declares a prefix for the given namespace IRI. IRIs within the
+// namespace are abbreviated using the prefix in the output.
+func Prefix(name, namespace string) func(*config) {
+	return func(c *config) {
+		c.prefixes[name] = namespace
+	}
+}
+
+// triple is a statement whose subject is implied by the vertex it belongs to.
+// predicate is an IRI and object is a term in Turtle syntax.
+type triple struct {
+	predicate string
+	object    string
+}
+
+// Turtle writes the given graph as RDF in the Turtle syntax into an io.Writer:
+//
+//	file, _ := os.Create("./my-graph.ttl")
+//	_ = rdf.Turtle(g, file, rdf.PredicateAttribute("predicate"))
+//
+// Each vertex becomes a subject whose IRI is the base IRI followed by the
+// escaped vertex hash, labeled with the hash using rdfs:label. Each attribute
+// of a vertex becomes a string literal, and its key is appended to the base IRI
+// to form the predicate unless the key already is an absolute IRI. The vertex
+// weight is written as integer literal with the predicate "weight" for
+// weighted graphs.
+//
+// Each edge becomes a triple with the source and target vertices as subject and
+// object. Because RDF statements are directed, edges of undirected graphs are
+// written in both directions. Edge weights and attributes other than the
+// predicate attribute can't be expressed in plain RDF and aren't exported.
+func Turtle[K comparable, T any](g graph.Graph[K, T], w io.Writer, options ...func(*config)) error {
+	c := newConfig(options...)
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	subjects := make(map[K]string, len(adjacencyMap))
+	hashes := make([]K, 0, len(adjacencyMap))
+
+	for hash := range adjacencyMap {
+		subjects[hash] = c.base + url.PathEscape(fmt.Sprint(hash))
+		hashes = append(hashes, hash)
+	}
+
+	sort.Slice(hashes, func(i, j int) bool {
+		return subjects[hashes[i]] < subjects[hashes[j]]
+	})
+
+	buf := bufio.NewWriter(w)
+
+	names := make([]string, 0, len(c.prefixes))
+	for name := range c.prefixes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(buf, "@prefix %s: <%s> .\n", name, c.prefixes[name])
+	}
+	if len(names) > 0 {
+		buf.WriteString("\n")
+	}
+
+	for _, hash := range hashes {
+		_, properties, err := g.VertexWithProperties(hash)
+		if err != nil {
+			return fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+
+		triples := []triple{
+			{predicate: rdfsLabel, object: literal(fmt.Sprint(hash))},
+		}
+
+		if g.Traits().IsWeighted {
+			triples = append(triples, triple{
+				predicate: c.base + "weight",
+				object:    fmt.Sprintf("%s^^%s", literal(fmt.Sprint(properties.Weight)), c.term(xsdInteger)),
+			})
+		}
+
+		for key, value := range properties.Attributes {
+			triples = append(triples, triple{predicate: c.attributeIRI(key), object: literal(value)})
+		}
+
+		for target, edge := range adjacencyMap[hash] {
+			predicate := c.defaultPredicate
+			if p, ok := edge.Properties.Attributes[c.predicateAttribute]; ok && c.predicateAttribute != "" {
+				predicate = p
+			}
+			triples = append(triples, triple{predicate: predicate, object: c.term(subjects[target])})
+		}
+
+		for _, t := range triples {
+			if !isValidIRI(t.predicate) {
+				return fmt.Errorf("vertex %v: invalid predicate IRI %q", hash, t.predicate)
+			}
+		}
+
+		sort.Slice(triples, func(i, j int) bool {
+			if triples[i].predicate != triples[j].predicate {
+				return triples[i].predicate < triples[j].predicate
+			}
+			return triples[i].object < triples[j].object
+		})
+
+		buf.WriteString(c.term(subjects[hash]))
+
+		for i, t := range triples {
+			separator := " ;"
+			if i == len(triples)-1 {
+				separator = " ."
+			}
+			fmt.Fprintf(buf, "\n\t%s %s%s", c.term(t.predicate), t.object, separator)
+		}
+
+		buf.WriteString("\n\n")
+	}
+
+	return buf.Flush()
+}
+
+// attributeIRI returns the predicate IRI for the given attribute key.
+func (c config) attributeIRI(key string) string {
+	if u, err := url.Parse(key); err == nil && u.Scheme != "" {
+		return key
+	}
+
+	return c.base + url.PathEscape(key)
+}
+
+// term formats the given IRI as Turtle term, abbreviating it if it is within
+// the namespace of a declared prefix.
+func (c config) term(iri string) string {
+	for name, namespace := range c.prefixes {
+		local := strings.TrimPrefix(iri, namespace)
+		if local != iri && isSimpleLocalName(local) {
+			return name + ":" + local
+		}
+	}
+
+	return "<" + iri + ">"
+}
+
+// isSimpleLocalName reports whether the given string can be used as local name
+// of a prefixed name without escaping.
+func isSimpleLocalName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+		case r == '-' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// isValidIRI reports whether the given IRI can be written as IRIREF.
+func isValidIRI(iri string) bool {
+	if iri == "" {
+		return false
+	}
+
+	for _, r := range iri {
+		if r <= 0x20 || strings.ContainsRune("<>\"{}|^`\\", r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+var literalReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// literal formats the given string as Turtle string literal.
+func literal(s string) string {
+	return `"` + literalReplacer.Replace(s) + `"`
+}
@@ -0,0 +1,95 @@
+package rdf
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestTurtle(t *testing.T) {
+	tests := map[string]struct {
+		traits   []func(*graph.Traits)
+		options  []func(*config)
+		expected string
+	}{
+		"directed graph": {
+			traits: []func(*graph.Traits){graph.Directed()},
+			options: []func(*config){
+				PredicateAttribute("predicate"),
+				Prefix("foaf", "http://xmlns.com/foaf/0.1/"),
+				Prefix("ex", "urn:graph:"),
+			},
+			expected: `@prefix ex: <urn:graph:> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+
+ex:Alice
+	<http://www.w3.org/2000/01/rdf-schema#label> "Alice" ;
+	foaf:knows ex:Bob ;
+	ex:age "42" .
+
+ex:Bob
+	<http://www.w3.org/2000/01/rdf-schema#label> "Bob" .
+
+<urn:graph:Bob%20%22B.%22>
+	<http://www.w3.org/2000/01/rdf-schema#label> "Bob \"B.\"" ;
+	ex:edge ex:Alice .
+
+`,
+		},
+		"undirected weighted graph": {
+			traits: []func(*graph.Traits){graph.Weighted()},
+			options: []func(*config){
+				BaseIRI("http://example.org/"),
+				DefaultPredicate("http://example.org/linked"),
+			},
+			expected: `<http://example.org/Alice>
+	<http://example.org/age> "42" ;
+	<http://example.org/linked> <http://example.org/Bob> ;
+	<http://example.org/weight> "0"^^<http://www.w3.org/2001/XMLSchema#integer> ;
+	<http://www.w3.org/2000/01/rdf-schema#label> "Alice" .
+
+<http://example.org/Bob>
+	<http://example.org/linked> <http://example.org/Alice> ;
+	<http://example.org/weight> "0"^^<http://www.w3.org/2001/XMLSchema#integer> ;
+	<http://www.w3.org/2000/01/rdf-schema#label> "Bob" .
+
+`,
+		},
+	}
+
+	for name, test := range tests {
+		g := graph.New(graph.StringHash, test.traits...)
+
+		_ = g.AddVertex("Alice", graph.VertexAttribute("age", "42"))
+		_ = g.AddVertex("Bob")
+		_ = g.AddEdge("Alice", "Bob", graph.EdgeAttribute("predicate", "http://xmlns.com/foaf/0.1/knows"))
+
+		if g.Traits().IsDirected {
+			_ = g.AddVertex(`Bob "B."`)
+			_ = g.AddEdge(`Bob "B."`, "Alice")
+		}
+
+		var buf bytes.Buffer
+
+		if err := Turtle(g, &buf, test.options...); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if buf.String() != test.expected {
+			t.Errorf("%s: output doesn't match: expected\n%s\ngot\n%s", name, test.expected, buf.String())
+		}
+	}
+}
+
+func TestTurtle_invalidPredicate(t *testing.T) {
+	g := graph.New(graph.StringHash, graph.Directed())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddEdge("A", "B", graph.EdgeAttribute("predicate", "not an IRI"))
+
+	if err := Turtle(g, &bytes.Buffer{}, PredicateAttribute("predicate")); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
@@ -0,0 +1,431 @@
+// Package redistore provides a [graph.Store] implementation that keeps graphs
+// in Redis. Because all state lives in Redis, multiple service instances can
+// share a single live graph.
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	store := redistore.New[string, string](client, redistore.KeyPrefix("my-graph"))
+//
+//	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+//
+// Each vertex is stored as a string key containing a JSON record, and the set
+// of all vertices is kept in a Redis set. The outgoing edges of a vertex are
+// stored in a Redis hash mapping the target hashes to edge records, and the
+// ingoing edges are stored in a Redis set of source hashes, and the number of
+// edges is kept in a counter. Operations that touch all vertices, such as
+// ListEdges, are pipelined.
+//
+// All mutations are executed as Lua scripts, so that checking the graph and
+// modifying it is atomic even if multiple instances share the graph. When using
+// Redis Cluster, the key prefix should contain a hash tag like "{graph}" so that
+// all keys of a graph are stored in the same slot.
+package redistore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/internal/storage"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	addVertexScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1])
+redis.call('SADD', KEYS[2], ARGV[2])
+return 1`)
+
+	removeVertexScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+if redis.call('HLEN', KEYS[3]) > 0 or redis.call('SCARD', KEYS[4]) > 0 then
+	return -1
+end
+redis.call('DEL', KEYS[1])
+redis.call('SREM', KEYS[2], ARGV[1])
+return 1`)
+
+	addEdgeScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return -1
+end
+if redis.call('EXISTS', KEYS[2]) == 0 then
+	return -2
+end
+if redis.call('HSETNX', KEYS[3], ARGV[1], ARGV[2]) == 0 then
+	return 0
+end
+redis.call('SADD', KEYS[4], ARGV[3])
+redis.call('INCRBY', KEYS[5], 1)
+return 1`)
+
+	updateEdgeScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+return 1`)
+
+	removeEdgeScript = redis.NewScript(`
+if redis.call('HDEL', KEYS[1], ARGV[1]) == 1 then
+	redis.call('SREM', KEYS[2], ARGV[2])
+	redis.call('INCRBY', KEYS[3], -1)
+end
+return 1`)
+)
+
+type config struct {
+	prefix string
+}
+
+// KeyPrefix is a functional option for [New] that sets the prefix of all keys
+// used by the store. Stores with different prefixes can share one Redis
+// database. The default prefix is "graph".
+func KeyPrefix(prefix string) func(*config) {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+type vertexRecord[T any] struct {
+	Value      T                  `json:"value"`
+	Weight     int                `json:"weight"`
+	Attributes map[string]string  `json:"attributes"`
+	Weights    map[string]float64 `json:"weights,omitempty"`
+}
+
+// Store is a [graph.Store] implementation backed by Redis.
+type Store[K comparable, T any] struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// New creates a store that uses the given Redis client. Closing the client is
+// up to the caller.
+func New[K comparable, T any](client redis.UniversalClient, options ...func(*config)) *Store[K, T] {
+	c := config{
+		prefix: "graph",
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return &Store[K, T]{
+		client: client,
+		prefix: c.prefix,
+	}
+}
+
+func (s *Store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	ctx := context.Background()
+
+	encodedHash, err := storage.EncodeHash(hash)
+	if err != nil {
+		return err
+	}
+
+	record, err := json.Marshal(vertexRecord[T]{
+		Value:      value,
+		Weight:     properties.Weight,
+		Attributes: properties.Attributes,
+		Weights:    properties.Weights,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode vertex: %w", err)
+	}
+
+	keys := []string{s.vertexKey(encodedHash), s.verticesKey()}
+
+	result, err := addVertexScript.Run(ctx, s.client, keys, record, encodedHash).Int()
+	if err != nil {
+		return fmt.Errorf("failed to add vertex %v: %w", hash, err)
+	}
+	if result == 0 {
+		return graph.ErrVertexAlreadyExists
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	var value T
+
+	encodedHash, err := storage.EncodeHash(hash)
+	if err != nil {
+		return value, graph.VertexProperties{}, err
+	}
+
+	data, err := s.client.Get(context.Background(), s.vertexKey(encodedHash)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return value, graph.VertexProperties{}, graph.ErrVertexNotFound
+	}
+	if err != nil {
+		return value, graph.VertexProperties{}, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+	}
+
+	var record vertexRecord[T]
+
+	if err := json.Unmarshal(data, &record); err != nil {
+		return value, graph.VertexProperties{}, fmt.Errorf("failed to decode vertex: %w", err)
+	}
+
+	return record.Value, graph.VertexProperties{
+		Weight:     record.Weight,
+		Attributes: storage.Attributes(record.Attributes),
+		Weights:    record.Weights,
+	}, nil
+}
+
+func (s *Store[K, T]) RemoveVertex(hash K) error {
+	ctx := context.Background()
+
+	encodedHash, err := storage.EncodeHash(hash)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{
+		s.vertexKey(encodedHash),
+		s.verticesKey(),
+		s.outEdgesKey(encodedHash),
+		s.inEdgesKey(encodedHash),
+	}
+
+	result, err := removeVertexScript.Run(ctx, s.client, keys, encodedHash).Int()
+	if err != nil {
+		return fmt.Errorf("failed to remove vertex %v: %w", hash, err)
+	}
+
+	switch result {
+	case 0:
+		return graph.ErrVertexNotFound
+	case -1:
+		return graph.ErrVertexHasEdges
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) ListVertices() ([]K, error) {
+	encodedHashes, err := s.client.SMembers(context.Background(), s.verticesKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vertices: %w", err)
+	}
+
+	hashes := make([]K, 0, len(encodedHashes))
+
+	for _, encodedHash := range encodedHashes {
+		hash, err := storage.DecodeHash[K](encodedHash)
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+func (s *Store[K, T]) VertexCount() (int, error) {
+	count, err := s.client.SCard(context.Background(), s.verticesKey()).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count vertices: %w", err)
+	}
+
+	return int(count), nil
+}
+
+func (s *Store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	ctx := context.Background()
+
+	source, target, record, err := encodeEdge(sourceHash, targetHash, edge)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{
+		s.vertexKey(source),
+		s.vertexKey(target),
+		s.outEdgesKey(source),
+		s.inEdgesKey(target),
+		s.edgeCountKey(),
+	}
+
+	result, err := addEdgeScript.Run(ctx, s.client, keys, target, record, source).Int()
+	if err != nil {
+		return fmt.Errorf("failed to add edge (%v, %v): %w", sourceHash, targetHash, err)
+	}
+
+	switch result {
+	case -1:
+		return fmt.Errorf("source vertex %v: %w", sourceHash, graph.ErrVertexNotFound)
+	case -2:
+		return fmt.Errorf("target vertex %v: %w", targetHash, graph.ErrVertexNotFound)
+	case 0:
+		return graph.ErrEdgeAlreadyExists
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	ctx := context.Background()
+
+	source, target, record, err := encodeEdge(sourceHash, targetHash, edge)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{s.outEdgesKey(source)}
+
+	result, err := updateEdgeScript.Run(ctx, s.client, keys, target, record).Int()
+	if err != nil {
+		return fmt.Errorf("failed to update edge (%v, %v): %w", sourceHash, targetHash, err)
+	}
+	if result == 0 {
+		return graph.ErrEdgeNotFound
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	ctx := context.Background()
+
+	source, target, _, err := encodeEdge(sourceHash, targetHash, graph.Edge[K]{})
+	if err != nil {
+		return err
+	}
+
+	keys := []string{s.outEdgesKey(source), s.inEdgesKey(target), s.edgeCountKey()}
+
+	if err := removeEdgeScript.Run(ctx, s.client, keys, target, source).Err(); err != nil {
+		return fmt.Errorf("failed to remove edge (%v, %v): %w", sourceHash, targetHash, err)
+	}
+
+	return nil
+}
+
+func (s *Store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	source, target, _, err := encodeEdge(sourceHash, targetHash, graph.Edge[K]{})
+	if err != nil {
+		return graph.Edge[K]{}, err
+	}
+
+	data, err := s.client.HGet(context.Background(), s.outEdgesKey(source), target).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return graph.Edge[K]{}, graph.ErrEdgeNotFound
+	}
+	if err != nil {
+		return graph.Edge[K]{}, fmt.Errorf("failed to get edge (%v, %v): %w", sourceHash, targetHash, err)
+	}
+
+	return storage.DecodeEdge(sourceHash, targetHash, []byte(data))
+}
+
+// ListEdges returns all edges in the graph. The outgoing edges of all vertices
+// are fetched using a single pipeline.
+func (s *Store[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	ctx := context.Background()
+
+	sources, err := s.client.SMembers(ctx, s.verticesKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vertices: %w", err)
+	}
+
+	commands := make([]*redis.MapStringStringCmd, len(sources))
+
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, source := range sources {
+			commands[i] = pipe.HGetAll(ctx, s.outEdgesKey(source))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edges: %w", err)
+	}
+
+	edges := make([]graph.Edge[K], 0)
+
+	for i, source := range sources {
+		sourceHash, err := storage.DecodeHash[K](source)
+		if err != nil {
+			return nil, err
+		}
+
+		for target, data := range commands[i].Val() {
+			targetHash, err := storage.DecodeHash[K](target)
+			if err != nil {
+				return nil, err
+			}
+
+			edge, err := storage.DecodeEdge(sourceHash, targetHash, []byte(data))
+			if err != nil {
+				return nil, err
+			}
+
+			edges = append(edges, edge)
+		}
+	}
+
+	return edges, nil
+}
+
+// EdgeCount returns the number of edges in the graph, which is kept in a
+// counter that is updated along with the edges.
+func (s *Store[K, T]) EdgeCount() (int, error) {
+	count, err := s.client.Get(context.Background(), s.edgeCountKey()).Int()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to count edges: %w", err)
+	}
+
+	return count, nil
+}
+
+func (s *Store[K, T]) verticesKey() string {
+	return s.prefix + ":vertices"
+}
+
+func (s *Store[K, T]) edgeCountKey() string {
+	return s.prefix + ":edge_count"
+}
+
+func (s *Store[K, T]) vertexKey(encodedHash string) string {
+	return s.prefix + ":vertex:" + encodedHash
+}
+
+func (s *Store[K, T]) outEdgesKey(encodedHash string) string {
+	return s.prefix + ":out:" + encodedHash
+}
+
+func (s *Store[K, T]) inEdgesKey(encodedHash string) string {
+	return s.prefix + ":in:" + encodedHash
+}
+
+func encodeEdge[K comparable](sourceHash, targetHash K, edge graph.Edge[K]) (string, string, []byte, error) {
+	source, err := storage.EncodeHash(sourceHash)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	target, err := storage.EncodeHash(targetHash)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	record, err := storage.EncodeEdge(edge)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	return source, target, record, nil
+}
@@ -0,0 +1,184 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoParent is returned by Parent for the root of a rooted graph.
+var ErrNoParent = errors.New("vertex has no parent")
+
+// Parent returns the parent of the given vertex in a rooted graph, i.e. the
+// source of its only ingoing edge. For the root, ErrNoParent is returned.
+//
+// The tree navigation functions Parent, Children, Ancestors, Descendants, and
+// Depth are available for directed graphs with the IsRooted trait. They only
+// query the neighbors of the visited vertices, which is efficient if the store
+// implements [NeighborStore]. If a vertex has more than one parent, the graph
+// isn't a tree and an error is returned.
+func Parent[K comparable, T any](g Graph[K, T], hash K) (K, error) {
+	_, predecessorsOf, err := treeNeighbors(g, hash)
+	if err != nil {
+		var zero K
+		return zero, err
+	}
+
+	return parentOf(predecessorsOf, hash)
+}
+
+// Children returns the children of the given vertex in a rooted graph, sorted
+// as described in [SortedVertices]. See [Parent] for the requirements of the
+// tree navigation functions.
+func Children[K comparable, T any](g Graph[K, T], hash K) ([]K, error) {
+	successorsOf, _, err := treeNeighbors(g, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return childrenOf(successorsOf, hash)
+}
+
+// Ancestors returns the ancestors of the given vertex in a rooted graph,
+// starting with its parent and ending with the root. For the root, the
+// returned slice is empty. See [Parent] for the requirements of the tree
+// navigation functions.
+func Ancestors[K comparable, T any](g Graph[K, T], hash K) ([]K, error) {
+	_, predecessorsOf, err := treeNeighbors(g, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors := make([]K, 0)
+	visited := map[K]struct{}{hash: {}}
+	current := hash
+
+	for {
+		parent, err := parentOf(predecessorsOf, current)
+		if errors.Is(err, ErrNoParent) {
+			return ancestors, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := visited[parent]; ok {
+			return nil, fmt.Errorf("ancestors of %v form a cycle", hash)
+		}
+		visited[parent] = struct{}{}
+
+		ancestors = append(ancestors, parent)
+		current = parent
+	}
+}
+
+// Descendants returns all descendants of the given vertex in a rooted graph in
+// breadth-first order, so that each vertex comes after its parent. Children of
+// the same vertex are sorted as described in [SortedVertices]. See [Parent] for
+// the requirements of the tree navigation functions.
+func Descendants[K comparable, T any](g Graph[K, T], hash K) ([]K, error) {
+	successorsOf, _, err := treeNeighbors(g, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	descendants := make([]K, 0)
+	visited := map[K]struct{}{hash: {}}
+	queue := []K{hash}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		children, err := childrenOf(successorsOf, current)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, child := range children {
+			if _, ok := visited[child]; ok {
+				return nil, fmt.Errorf("descendants of %v don't form a tree: %v is reachable more than once", hash, child)
+			}
+			visited[child] = struct{}{}
+
+			descendants = append(descendants, child)
+			queue = append(queue, child)
+		}
+	}
+
+	return descendants, nil
+}
+
+// Depth returns the number of edges between the root of a rooted graph and the
+// given vertex. The depth of the root is 0. See [Parent] for the requirements
+// of the tree navigation functions.
+func Depth[K comparable, T any](g Graph[K, T], hash K) (int, error) {
+	ancestors, err := Ancestors(g, hash)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(ancestors), nil
+}
+
+// treeNeighbors checks that the graph is a directed rooted graph containing the
+// given vertex and returns the functions for querying its neighbors.
+func treeNeighbors[K comparable, T any](g Graph[K, T], hash K) (neighborFunc[K], neighborFunc[K], error) {
+	if !g.Traits().IsDirected || !g.Traits().IsRooted {
+		return nil, nil, errors.New("tree navigation requires a directed, rooted graph")
+	}
+
+	if _, err := g.Vertex(hash); err != nil {
+		return nil, nil, fmt.Errorf("could not get vertex %v: %w", hash, err)
+	}
+
+	successorsOf, err := successorsFunc(g)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	predecessorsOf, err := predecessorsFunc(g)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return successorsOf, predecessorsOf, nil
+}
+
+func parentOf[K comparable](predecessorsOf neighborFunc[K], hash K) (K, error) {
+	var (
+		zero    K
+		parents []K
+	)
+
+	err := predecessorsOf(hash, func(predecessor K, _ Edge[K]) {
+		parents = append(parents, predecessor)
+	})
+	if err != nil {
+		return zero, fmt.Errorf("could not get predecessors of %v: %w", hash, err)
+	}
+
+	switch len(parents) {
+	case 0:
+		return zero, fmt.Errorf("%v: %w", hash, ErrNoParent)
+	case 1:
+		return parents[0], nil
+	default:
+		sortHashes(parents)
+		return zero, fmt.Errorf("vertex %v has multiple parents %v", hash, parents)
+	}
+}
+
+func childrenOf[K comparable](successorsOf neighborFunc[K], hash K) ([]K, error) {
+	children := make([]K, 0)
+
+	err := successorsOf(hash, func(successor K, _ Edge[K]) {
+		children = append(children, successor)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get successors of %v: %w", hash, err)
+	}
+
+	sortHashes(children)
+
+	return children, nil
+}
@@ -0,0 +1,158 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// orgChart creates the following rooted graph:
+//
+//	ceo -> cto -> dev-1
+//	           -> dev-2
+//	    -> cfo
+func orgChart() Graph[string, string] {
+	g := New(StringHash, Directed(), Tree())
+
+	for _, vertex := range []string{"ceo", "cto", "cfo", "dev-1", "dev-2"} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge("ceo", "cto")
+	_ = g.AddEdge("ceo", "cfo")
+	_ = g.AddEdge("cto", "dev-2")
+	_ = g.AddEdge("cto", "dev-1")
+
+	return g
+}
+
+func TestTreeNavigation(t *testing.T) {
+	g := orgChart()
+
+	tests := map[string]struct {
+		hash                string
+		expectedParent      string
+		expectedParentErr   error
+		expectedChildren    []string
+		expectedAncestors   []string
+		expectedDescendants []string
+		expectedDepth       int
+	}{
+		"root": {
+			hash:                "ceo",
+			expectedParentErr:   ErrNoParent,
+			expectedChildren:    []string{"cfo", "cto"},
+			expectedAncestors:   []string{},
+			expectedDescendants: []string{"cfo", "cto", "dev-1", "dev-2"},
+			expectedDepth:       0,
+		},
+		"inner vertex": {
+			hash:                "cto",
+			expectedParent:      "ceo",
+			expectedChildren:    []string{"dev-1", "dev-2"},
+			expectedAncestors:   []string{"ceo"},
+			expectedDescendants: []string{"dev-1", "dev-2"},
+			expectedDepth:       1,
+		},
+		"leaf": {
+			hash:                "dev-2",
+			expectedParent:      "cto",
+			expectedChildren:    []string{},
+			expectedAncestors:   []string{"cto", "ceo"},
+			expectedDescendants: []string{},
+			expectedDepth:       2,
+		},
+	}
+
+	for name, test := range tests {
+		parent, err := Parent(g, test.hash)
+		if !errors.Is(err, test.expectedParentErr) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedParentErr, err)
+		}
+		if parent != test.expectedParent {
+			t.Errorf("%s: parent doesn't match: expected %v, got %v", name, test.expectedParent, parent)
+		}
+
+		children, _ := Children(g, test.hash)
+		if !reflect.DeepEqual(children, test.expectedChildren) {
+			t.Errorf("%s: children don't match: expected %v, got %v", name, test.expectedChildren, children)
+		}
+
+		ancestors, _ := Ancestors(g, test.hash)
+		if !reflect.DeepEqual(ancestors, test.expectedAncestors) {
+			t.Errorf("%s: ancestors don't match: expected %v, got %v", name, test.expectedAncestors, ancestors)
+		}
+
+		descendants, _ := Descendants(g, test.hash)
+		if !reflect.DeepEqual(descendants, test.expectedDescendants) {
+			t.Errorf("%s: descendants don't match: expected %v, got %v", name, test.expectedDescendants, descendants)
+		}
+
+		depth, _ := Depth(g, test.hash)
+		if depth != test.expectedDepth {
+			t.Errorf("%s: depth doesn't match: expected %v, got %v", name, test.expectedDepth, depth)
+		}
+	}
+}
+
+func TestTreeNavigation_errors(t *testing.T) {
+	tests := map[string]struct {
+		graph       func() Graph[string, string]
+		hash        string
+		expectedErr error
+	}{
+		"graph isn't rooted": {
+			graph: func() Graph[string, string] {
+				g := New(StringHash, Directed())
+				_ = g.AddVertex("A")
+				return g
+			},
+			hash: "A",
+		},
+		"graph isn't directed": {
+			graph: func() Graph[string, string] {
+				g := New(StringHash, Rooted())
+				_ = g.AddVertex("A")
+				return g
+			},
+			hash: "A",
+		},
+		"missing vertex": {
+			graph:       orgChart,
+			hash:        "coo",
+			expectedErr: ErrVertexNotFound,
+		},
+		"multiple parents": {
+			graph: func() Graph[string, string] {
+				g := orgChart()
+				_ = g.AddEdge("cfo", "dev-1")
+				return g
+			},
+			hash: "dev-1",
+		},
+	}
+
+	for name, test := range tests {
+		g := test.graph()
+
+		_, parentErr := Parent(g, test.hash)
+		_, ancestorsErr := Ancestors(g, test.hash)
+		_, depthErr := Depth(g, test.hash)
+
+		for _, err := range []error{parentErr, ancestorsErr, depthErr} {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+				t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+			}
+		}
+	}
+
+	g := orgChart()
+	_ = g.AddEdge("cfo", "dev-1")
+
+	if _, err := Descendants(g, "ceo"); err == nil {
+		t.Errorf("expected an error for a vertex reachable more than once")
+	}
+}
@@ -0,0 +1,350 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+type samplingMethod int
+
+const (
+	randomVertexSampling samplingMethod = iota
+	randomEdgeSampling
+	forestFireSampling
+)
+
+// SamplingStrategy determines how [SampleSubgraph] selects the vertices and
+// edges of a sample. Strategies are created using [RandomVertexSampling],
+// [RandomEdgeSampling], and [ForestFireSampling].
+type SamplingStrategy struct {
+	method          samplingMethod
+	size            int
+	burnProbability float64
+}
+
+// RandomVertexSampling selects n random vertices and keeps all edges between
+// them. Since the edges of a vertex are only kept if its neighbors have been
+// selected as well, the sample is a lot sparser than the original graph.
+func RandomVertexSampling(n int) SamplingStrategy {
+	return SamplingStrategy{
+		method: randomVertexSampling,
+		size:   n,
+	}
+}
+
+// RandomEdgeSampling selects n random edges along with their vertices. The
+// sample is biased towards vertices with a high degree, because these are more
+// likely to be the source or target of a selected edge.
+func RandomEdgeSampling(n int) SamplingStrategy {
+	return SamplingStrategy{
+		method: randomEdgeSampling,
+		size:   n,
+	}
+}
+
+// ForestFireSampling selects n vertices by spreading a "fire" from a random
+// vertex: Each burning vertex sets fire to a random number of its unburned
+// successors, which is geometrically distributed with a mean of p/(1-p). If the
+// fire dies out, it starts again at another random vertex. All edges between
+// the burned vertices are kept.
+//
+// Forest fire sampling was introduced by Leskovec and Faloutsos and preserves
+// properties like the degree distribution and the clustering of the original
+// graph better than random vertex or edge sampling. Typical values for the
+// burn probability are between 0.6 and 0.7.
+func ForestFireSampling(n int, burnProbability float64) SamplingStrategy {
+	return SamplingStrategy{
+		method:          forestFireSampling,
+		size:            n,
+		burnProbability: burnProbability,
+	}
+}
+
+// SampleOptions configures how [SampleSubgraph] makes random choices.
+type SampleOptions struct {
+	Rand     *rand.Rand
+	Weighted bool
+}
+
+// SampleRand makes SampleSubgraph use the given source of randomness. Using a
+// source with a fixed seed yields the same sample for the same graph, which is
+// useful for reproducible tests.
+func SampleRand(r *rand.Rand) func(*SampleOptions) {
+	return func(o *SampleOptions) {
+		o.Rand = r
+	}
+}
+
+// SampleWeighted makes SampleSubgraph select vertices and edges with a
+// probability proportional to their weight instead of uniformly. Vertices and
+// edges with a weight of zero or less are only selected once all others have
+// been selected.
+func SampleWeighted() func(*SampleOptions) {
+	return func(o *SampleOptions) {
+		o.Weighted = true
+	}
+}
+
+// SampleSubgraph returns a random sample of the given graph, which is a new
+// graph with the same traits and a subset of its vertices and edges. Samples
+// are useful for running or testing algorithms whose complexity prohibits
+// running them on the full graph:
+//
+//	sample, _ := graph.SampleSubgraph(g, graph.ForestFireSampling(1000, 0.7))
+//
+// If the graph has fewer vertices or edges than requested, all of them are
+// selected. Unless a source of randomness is passed using [SampleRand], a new
+// source seeded with the current time is used.
+func SampleSubgraph[K comparable, T any](g Graph[K, T], strategy SamplingStrategy, options ...func(*SampleOptions)) (Graph[K, T], error) {
+	if strategy.size < 0 {
+		return nil, fmt.Errorf("sample size must not be negative, got %d", strategy.size)
+	}
+
+	var sampleOptions SampleOptions
+
+	for _, option := range options {
+		option(&sampleOptions)
+	}
+
+	if sampleOptions.Rand == nil {
+		sampleOptions.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	switch strategy.method {
+	case randomVertexSampling:
+		return sampleVertices(g, strategy.size, sampleOptions)
+	case randomEdgeSampling:
+		return sampleEdges(g, strategy.size, sampleOptions)
+	case forestFireSampling:
+		if strategy.burnProbability < 0 || strategy.burnProbability >= 1 {
+			return nil, fmt.Errorf("burn probability must be in [0, 1), got %v", strategy.burnProbability)
+		}
+		return sampleForestFire(g, strategy.size, strategy.burnProbability, sampleOptions)
+	default:
+		return nil, errors.New("unknown sampling strategy")
+	}
+}
+
+func sampleVertices[K comparable, T any](g Graph[K, T], n int, options SampleOptions) (Graph[K, T], error) {
+	hashes, weights, err := sampleCandidates(g, options)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := weightedChoice(options.Rand, len(hashes), weights, n)
+
+	vertices := make([]K, len(selected))
+	for i, index := range selected {
+		vertices[i] = hashes[index]
+	}
+
+	return inducedSubgraph(g, vertices)
+}
+
+func sampleEdges[K comparable, T any](g Graph[K, T], n int, options SampleOptions) (Graph[K, T], error) {
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	// The edges are sorted so that the same source of randomness always
+	// yields the same sample.
+	sortEdgesWith(edges, orDefaultLess[K](nil))
+
+	var weights []float64
+
+	if options.Weighted {
+		weights = make([]float64, len(edges))
+		for i, edge := range edges {
+			weights[i] = storedWeight(edge)
+		}
+	}
+
+	sample := NewLike(g)
+
+	for _, index := range weightedChoice(options.Rand, len(edges), weights, n) {
+		edge := edges[index]
+
+		for _, hash := range []K{edge.Source, edge.Target} {
+			if err := addSampledVertex(g, sample, hash); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := sample.AddEdge(copyEdge(edge)); err != nil {
+			return nil, fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	return sample, nil
+}
+
+func sampleForestFire[K comparable, T any](g Graph[K, T], n int, burnProbability float64, options SampleOptions) (Graph[K, T], error) {
+	hashes, weights, err := sampleCandidates(g, options)
+	if err != nil {
+		return nil, err
+	}
+
+	successorsOf, err := successorsFunc(g)
+	if err != nil {
+		return nil, err
+	}
+
+	if n > len(hashes) {
+		n = len(hashes)
+	}
+
+	// The order in which the seeds are chosen is determined up front. Seeds
+	// that have been burned in the meantime are skipped.
+	seeds := weightedChoice(options.Rand, len(hashes), weights, len(hashes))
+
+	burned := make(map[K]struct{}, n)
+	vertices := make([]K, 0, n)
+
+	for _, seed := range seeds {
+		if len(vertices) == n {
+			break
+		}
+
+		if _, ok := burned[hashes[seed]]; ok {
+			continue
+		}
+
+		burned[hashes[seed]] = struct{}{}
+		vertices = append(vertices, hashes[seed])
+		queue := []K{hashes[seed]}
+
+		for len(queue) > 0 && len(vertices) < n {
+			current := queue[0]
+			queue = queue[1:]
+
+			candidates := make([]Edge[K], 0)
+
+			err := successorsOf(current, func(neighbor K, edge Edge[K]) {
+				if _, ok := burned[neighbor]; !ok {
+					edge.Source, edge.Target = current, neighbor
+					candidates = append(candidates, edge)
+				}
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get successors of %v: %w", current, err)
+			}
+
+			sort.Slice(candidates, func(i, j int) bool {
+				return compareHashes(candidates[i].Target, candidates[j].Target) < 0
+			})
+
+			// The number of vertices to burn is geometrically distributed.
+			burn := 0
+			for options.Rand.Float64() < burnProbability {
+				burn++
+			}
+
+			var candidateWeights []float64
+
+			if options.Weighted {
+				candidateWeights = make([]float64, len(candidates))
+				for i, edge := range candidates {
+					candidateWeights[i] = storedWeight(edge)
+				}
+			}
+
+			for _, index := range weightedChoice(options.Rand, len(candidates), candidateWeights, burn) {
+				if len(vertices) == n {
+					break
+				}
+
+				neighbor := candidates[index].Target
+				burned[neighbor] = struct{}{}
+				vertices = append(vertices, neighbor)
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return inducedSubgraph(g, vertices)
+}
+
+// sampleCandidates returns the sorted vertex hashes of the graph, so that the
+// same source of randomness always yields the same sample. If the sample is
+// weighted, the vertex weights are returned as well.
+func sampleCandidates[K comparable, T any](g Graph[K, T], options SampleOptions) ([]K, []float64, error) {
+	hashes, err := vertexHashes(g)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get vertices: %w", err)
+	}
+
+	sortHashes(hashes)
+
+	if !options.Weighted {
+		return hashes, nil, nil
+	}
+
+	weights := make([]float64, len(hashes))
+
+	for i, hash := range hashes {
+		_, properties, err := g.VertexWithProperties(hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+		weights[i] = float64(properties.Weight)
+	}
+
+	return hashes, weights, nil
+}
+
+func addSampledVertex[K comparable, T any](g, sample Graph[K, T], hash K) error {
+	if _, err := sample.Vertex(hash); err == nil {
+		return nil
+	}
+
+	vertex, properties, err := g.VertexWithProperties(hash)
+	if err != nil {
+		return fmt.Errorf("failed to get vertex %v: %w", hash, err)
+	}
+
+	if err := sample.AddVertex(vertex, copyVertexProperties(properties)); err != nil {
+		return fmt.Errorf("failed to add vertex %v: %w", hash, err)
+	}
+
+	return nil
+}
+
+// weightedChoice selects k of n items without replacement and returns their
+// indices. If weights is nil, all items are equally likely to be selected.
+// Otherwise, the algorithm by Efraimidis and Spirakis is used, which assigns
+// each item the key log(u)/w for a uniform random number u and selects the
+// items with the largest keys.
+func weightedChoice(r *rand.Rand, n int, weights []float64, k int) []int {
+	if k > n {
+		k = n
+	}
+
+	keys := make([]float64, n)
+	indices := make([]int, n)
+
+	for i := range keys {
+		indices[i] = i
+		// 1-u is in (0, 1], so that the logarithm is always finite.
+		u := 1 - r.Float64()
+
+		switch {
+		case weights == nil:
+			keys[i] = u
+		case weights[i] <= 0:
+			keys[i] = math.Inf(-1)
+		default:
+			keys[i] = math.Log(u) / weights[i]
+		}
+	}
+
+	sort.SliceStable(indices, func(i, j int) bool {
+		return keys[indices[i]] > keys[indices[j]]
+	})
+
+	return indices[:k]
+}
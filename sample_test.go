@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// sampleSource creates a directed graph with 50 vertices where each vertex i
+// has edges to i+1 and 2i.
+func sampleSource() Graph[int, int] {
+	g := New(IntHash, Directed())
+
+	for i := 0; i < 50; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	for i := 0; i < 50; i++ {
+		if i+1 < 50 {
+			_ = g.AddEdge(i, i+1)
+		}
+		if 2*i < 50 && i > 0 {
+			_ = g.AddEdge(i, 2*i)
+		}
+	}
+
+	return g
+}
+
+func TestSampleSubgraph(t *testing.T) {
+	tests := map[string]struct {
+		strategy         SamplingStrategy
+		expectedOrder    int
+		expectedSize     int
+		expectedError    bool
+		checkInducedSize bool
+	}{
+		"random vertices": {
+			strategy:         RandomVertexSampling(10),
+			expectedOrder:    10,
+			expectedSize:     -1,
+			checkInducedSize: true,
+		},
+		"random edges": {
+			strategy:      RandomEdgeSampling(10),
+			expectedOrder: -1,
+			expectedSize:  10,
+		},
+		"forest fire": {
+			strategy:         ForestFireSampling(10, 0.7),
+			expectedOrder:    10,
+			expectedSize:     -1,
+			checkInducedSize: true,
+		},
+		"sample larger than graph": {
+			strategy:      RandomVertexSampling(100),
+			expectedOrder: 50,
+			expectedSize:  72,
+		},
+		"negative size": {
+			strategy:      RandomVertexSampling(-1),
+			expectedError: true,
+		},
+		"invalid burn probability": {
+			strategy:      ForestFireSampling(10, 1),
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		g := sampleSource()
+
+		sample, err := SampleSubgraph(g, test.strategy, SampleRand(rand.New(rand.NewSource(1))))
+
+		if test.expectedError != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.expectedError, err != nil, err)
+		}
+
+		if test.expectedError {
+			continue
+		}
+
+		if !sample.Traits().IsDirected {
+			t.Errorf("%s: expected sample to be directed", name)
+		}
+
+		if order, _ := sample.Order(); test.expectedOrder >= 0 && order != test.expectedOrder {
+			t.Errorf("%s: order expectancy doesn't match: expected %v, got %v", name, test.expectedOrder, order)
+		}
+
+		if size, _ := sample.Size(); test.expectedSize >= 0 && size != test.expectedSize {
+			t.Errorf("%s: size expectancy doesn't match: expected %v, got %v", name, test.expectedSize, size)
+		}
+
+		edges, _ := sample.Edges()
+		for _, edge := range edges {
+			if _, err := g.Edge(edge.Source, edge.Target); err != nil {
+				t.Errorf("%s: edge (%v, %v) doesn't exist in the original graph", name, edge.Source, edge.Target)
+			}
+		}
+
+		// Samples of vertices contain all edges between the sampled vertices.
+		if test.checkInducedSize {
+			vertices, _ := vertexHashes(sample)
+			induced, _ := inducedSubgraph(g, vertices)
+			expectedSize, _ := induced.Size()
+
+			if size, _ := sample.Size(); size != expectedSize {
+				t.Errorf("%s: size expectancy doesn't match: expected %v, got %v", name, expectedSize, size)
+			}
+		}
+	}
+}
+
+func TestSampleSubgraph_reproducible(t *testing.T) {
+	strategies := []SamplingStrategy{
+		RandomVertexSampling(10),
+		RandomEdgeSampling(10),
+		ForestFireSampling(10, 0.7),
+	}
+
+	for _, strategy := range strategies {
+		first, _ := SampleSubgraph(sampleSource(), strategy, SampleRand(rand.New(rand.NewSource(42))))
+		second, _ := SampleSubgraph(sampleSource(), strategy, SampleRand(rand.New(rand.NewSource(42))))
+
+		firstEdges, _ := SortedEdges(first, nil)
+		secondEdges, _ := SortedEdges(second, nil)
+
+		if !reflect.DeepEqual(firstEdges, secondEdges) {
+			t.Errorf("expected samples with the same seed to be equal: got %v and %v", firstEdges, secondEdges)
+		}
+	}
+}
+
+func TestSampleSubgraph_weighted(t *testing.T) {
+	g := New(StringHash)
+
+	_ = g.AddVertex("A", VertexWeight(1))
+	_ = g.AddVertex("B", VertexWeight(1))
+	_ = g.AddVertex("C", VertexWeight(0))
+
+	for seed := int64(0); seed < 20; seed++ {
+		sample, err := SampleSubgraph(g, RandomVertexSampling(2), SampleWeighted(), SampleRand(rand.New(rand.NewSource(seed))))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := sample.Vertex("C"); err == nil {
+			t.Errorf("seed %d: expected vertex with a weight of zero not to be sampled", seed)
+		}
+	}
+}
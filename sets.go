@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -34,6 +35,23 @@ func Union[K comparable, T any](g, h Graph[K, T]) (Graph[K, T], error) {
 		}
 	}
 
+	// Since the vertices of h have been added successfully, they are disjoint
+	// from the vertices of g, and so are the edges of both graphs. Unless the
+	// union prevents cycles that might exist in h, the edges of h can be added
+	// without validating them.
+	if adder, ok := union.(trustedEdgeAdder[K]); ok && canAddTrusted(union.Traits(), h.Traits()) {
+		edges, err := h.Edges()
+		if err != nil {
+			return union, fmt.Errorf("failed to get edges: %w", err)
+		}
+
+		if err := adder.addEdgesTrusted(edges); err != nil {
+			return union, fmt.Errorf("failed to add edges: %w", err)
+		}
+
+		return union, nil
+	}
+
 	for _, adjacencies := range adjacencyMap {
 		for _, edge := range adjacencies {
 			if _, sourceOK := addedEdges[edge.Source]; sourceOK {
@@ -59,6 +77,91 @@ func Union[K comparable, T any](g, h Graph[K, T]) (Graph[K, T], error) {
 	return union, nil
 }
 
+// canAddTrusted reports whether the edges of a graph with the traits of h can
+// be added to a graph with the traits of g without validating them. This is not
+// the case if the graphs differ in their directedness or if g prevents cycles
+// that h might contain.
+func canAddTrusted(g, h *Traits) bool {
+	if g.IsDirected != h.IsDirected {
+		return false
+	}
+
+	return !g.PreventCycles || h.PreventCycles
+}
+
+// Complement creates the complement of the given graph, which has the same
+// vertices as g and an edge between two distinct vertices if and only if they
+// aren't joined by an edge in g. For directed graphs, this applies to each
+// direction separately. The complement doesn't contain any self-loops.
+//
+// The vertices keep their values and properties. The complement is directed if
+// g is directed, but has no other traits, since the complement of an acyclic
+// graph usually isn't acyclic. Its edges don't have any properties.
+func Complement[K comparable, T any](g Graph[K, T]) (Graph[K, T], error) {
+	var hash Hash[K, T]
+
+	switch g := g.(type) {
+	case *directed[K, T]:
+		hash = g.hash
+	case *undirected[K, T]:
+		hash = g.hash
+	case *cachedGraph[K, T]:
+		return Complement(g.Graph)
+	default:
+		return nil, errors.New("graph doesn't support complements")
+	}
+
+	isDirected := g.Traits().IsDirected
+
+	var complement Graph[K, T]
+
+	if isDirected {
+		complement = New(hash, Directed())
+	} else {
+		complement = New(hash)
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	hashes := make([]K, 0, len(adjacencyMap))
+
+	for hash := range adjacencyMap {
+		vertex, properties, err := g.VertexWithProperties(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+
+		if err := complement.AddVertex(vertex, copyVertexProperties(properties)); err != nil {
+			return nil, fmt.Errorf("failed to add vertex %v: %w", hash, err)
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	for i, source := range hashes {
+		for j, target := range hashes {
+			// Undirected edges only need to be added for one order of the
+			// vertices, which is why only the pairs with i < j are visited.
+			if i == j || (!isDirected && j < i) {
+				continue
+			}
+
+			if _, ok := adjacencyMap[source][target]; ok {
+				continue
+			}
+
+			if err := complement.AddEdge(source, target); err != nil {
+				return nil, fmt.Errorf("failed to add edge (%v, %v): %w", source, target, err)
+			}
+		}
+	}
+
+	return complement, nil
+}
+
 // unionFind implements a union-find or disjoint set data structure that works
 // with vertex hashes as vertices. It's an internal helper type at the moment,
 // but could perhaps be exposed publicly in the future.
@@ -106,8 +209,8 @@ func (u *unionFind[K]) find(vertex K) K {
 	current := vertex
 
 	for u.parents[current] != root {
-		parent := u.parents[vertex]
-		u.parents[vertex] = root
+		parent := u.parents[current]
+		u.parents[current] = root
 		current = parent
 	}
 
@@ -120,5 +223,47 @@ func copyVertexProperties(source VertexProperties) func(*VertexProperties) {
 			p.Attributes[k] = v
 		}
 		p.Weight = source.Weight
+		p.Weights = copyWeights(source.Weights)
+	}
+}
+
+// inducedSubgraph creates a new graph with the same traits as g that contains
+// the given vertices and all edges of g joining two of these vertices.
+func inducedSubgraph[K comparable, T any](g Graph[K, T], vertices []K) (Graph[K, T], error) {
+	subgraph := NewLike(g)
+
+	included := make(map[K]struct{}, len(vertices))
+
+	for _, hash := range vertices {
+		vertex, properties, err := g.VertexWithProperties(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+
+		if err = subgraph.AddVertex(vertex, copyVertexProperties(properties)); err != nil {
+			return nil, fmt.Errorf("failed to add vertex %v: %w", hash, err)
+		}
+
+		included[hash] = struct{}{}
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
 	}
+
+	for _, edge := range edges {
+		if _, ok := included[edge.Source]; !ok {
+			continue
+		}
+		if _, ok := included[edge.Target]; !ok {
+			continue
+		}
+
+		if err = subgraph.AddEdge(copyEdge(edge)); err != nil {
+			return nil, fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	return subgraph, nil
 }
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -165,6 +166,63 @@ func TestDirectedUnion(t *testing.T) {
 	}
 }
 
+func TestUnion(t *testing.T) {
+	tests := map[string]struct {
+		gTraits       []func(*Traits)
+		hTraits       []func(*Traits)
+		hEdges        []Edge[int]
+		expectedSize  int
+		expectedError error
+	}{
+		"undirected graphs": {
+			hEdges:       []Edge[int]{{Source: 3, Target: 4}, {Source: 4, Target: 5}},
+			expectedSize: 3,
+		},
+		"directed graphs preventing cycles": {
+			gTraits:      []func(*Traits){Directed(), PreventCycles()},
+			hTraits:      []func(*Traits){Directed(), PreventCycles()},
+			hEdges:       []Edge[int]{{Source: 3, Target: 4}, {Source: 4, Target: 5}},
+			expectedSize: 3,
+		},
+		"cycle in graph not preventing cycles": {
+			gTraits:       []func(*Traits){Directed(), PreventCycles()},
+			hTraits:       []func(*Traits){Directed()},
+			hEdges:        []Edge[int]{{Source: 3, Target: 4}, {Source: 4, Target: 5}, {Source: 5, Target: 3}},
+			expectedError: ErrEdgeCreatesCycle,
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash, test.gTraits...)
+		_ = g.AddVertex(1)
+		_ = g.AddVertex(2)
+		_ = g.AddEdge(1, 2)
+
+		h := New(IntHash, test.hTraits...)
+		_ = h.AddVertex(3)
+		_ = h.AddVertex(4)
+		_ = h.AddVertex(5)
+
+		for _, edge := range test.hEdges {
+			_ = h.AddEdge(edge.Source, edge.Target)
+		}
+
+		union, err := Union(g, h)
+
+		if !errors.Is(err, test.expectedError) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedError, err)
+		}
+
+		if test.expectedError != nil {
+			continue
+		}
+
+		if size, _ := union.Size(); size != test.expectedSize {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, test.expectedSize, size)
+		}
+	}
+}
+
 func TestUnionFind_add(t *testing.T) {
 	tests := map[string]struct {
 		vertex         int
@@ -347,3 +405,79 @@ func mapsAreEqual[K comparable](a, b map[K]K) bool {
 
 	return true
 }
+
+func TestComplement(t *testing.T) {
+	tests := map[string]struct {
+		traits        []func(*Traits)
+		edges         []Edge[int]
+		expectedEdges []Edge[int]
+	}{
+		"undirected graph": {
+			edges:         []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 3}},
+			expectedEdges: []Edge[int]{{Source: 1, Target: 3}},
+		},
+		"directed graph": {
+			traits: []func(*Traits){Directed()},
+			edges:  []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 3}},
+			expectedEdges: []Edge[int]{
+				{Source: 1, Target: 3},
+				{Source: 2, Target: 1},
+				{Source: 3, Target: 1},
+				{Source: 3, Target: 2},
+			},
+		},
+		"acyclic graph": {
+			traits: []func(*Traits){Directed(), PreventCycles()},
+			edges:  []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 3}, {Source: 1, Target: 3}},
+			expectedEdges: []Edge[int]{
+				{Source: 2, Target: 1},
+				{Source: 3, Target: 1},
+				{Source: 3, Target: 2},
+			},
+		},
+		"self-loop": {
+			edges:         []Edge[int]{{Source: 1, Target: 1}, {Source: 1, Target: 2}},
+			expectedEdges: []Edge[int]{{Source: 1, Target: 3}, {Source: 2, Target: 3}},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash, test.traits...)
+		_ = g.AddVertex(1, VertexAttribute("color", "red"))
+		_ = g.AddVertex(2)
+		_ = g.AddVertex(3)
+
+		for _, edge := range test.edges {
+			_ = g.AddEdge(edge.Source, edge.Target)
+		}
+
+		complement, err := Complement(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if complement.Traits().IsDirected != g.Traits().IsDirected {
+			t.Errorf("%s: directedness doesn't match: expected %v, got %v", name, g.Traits().IsDirected, complement.Traits().IsDirected)
+		}
+
+		if order, _ := complement.Order(); order != 3 {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, 3, order)
+		}
+
+		if _, properties, _ := complement.VertexWithProperties(1); properties.Attributes["color"] != "red" {
+			t.Errorf("%s: attribute doesn't match: expected %v, got %v", name, "red", properties.Attributes["color"])
+		}
+
+		edges, _ := SortedEdges(complement, nil)
+		if len(edges) != len(test.expectedEdges) {
+			t.Fatalf("%s: edges don't match: expected %v, got %v", name, test.expectedEdges, edges)
+		}
+
+		for i, edge := range edges {
+			expected := test.expectedEdges[i]
+			if edge.Source != expected.Source || edge.Target != expected.Target {
+				t.Errorf("%s: edge doesn't match: expected (%v, %v), got (%v, %v)", name, expected.Source, expected.Target, edge.Source, edge.Target)
+			}
+		}
+	}
+}
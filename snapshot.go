@@ -0,0 +1,319 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SnapshotStore is an optional interface that a [Store] may implement to dump
+// its vertices and edges to a writer and to restore them later. A snapshot has
+// to reflect a consistent point in time, i.e. mutations that happen while the
+// snapshot is written must not be contained partially.
+//
+// Restore should replace the contents of the store with the contents of the
+// snapshot. If the snapshot can't be read, the store should remain unchanged.
+type SnapshotStore interface {
+	Snapshot(w io.Writer) error
+	Restore(r io.Reader) error
+}
+
+// snapshot is the JSON representation of a store. The edges are stored as they
+// are stored in the store, so undirected edges appear in both directions.
+type snapshot[K comparable, T any] struct {
+	Vertices []snapshotVertex[K, T] `json:"vertices"`
+	Edges    []snapshotEdge[K]      `json:"edges"`
+}
+
+type snapshotVertex[K comparable, T any] struct {
+	Hash       K                  `json:"hash"`
+	Value      T                  `json:"value"`
+	Weight     int                `json:"weight,omitempty"`
+	Attributes map[string]string  `json:"attributes,omitempty"`
+	Weights    map[string]float64 `json:"weights,omitempty"`
+}
+
+type snapshotEdge[K comparable] struct {
+	Source     K                 `json:"source"`
+	Target     K                 `json:"target"`
+	Weight     int               `json:"weight,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Data       any               `json:"data,omitempty"`
+}
+
+// Snapshot writes all vertices and edges of the graph to w as JSON. This can be
+// used for backups or for seeding test fixtures with real-world graphs, which
+// can be loaded into another graph using [Restore].
+//
+// If the store of the graph implements [SnapshotStore], the snapshot is created
+// by the store and is consistent. For all other stores, the vertices and edges
+// are read one after another, and concurrent mutations may lead to snapshots
+// that don't reflect a single point in time.
+//
+// The vertex hashes, values, and edge data need to survive a JSON round trip.
+// Edge data is restored into the generic JSON types such as map[string]any.
+func Snapshot[K comparable, T any](g Graph[K, T], w io.Writer) error {
+	store, ok := storeOf(g)
+	if !ok {
+		return errors.New("graph doesn't support snapshots")
+	}
+
+	if snapshotStore, ok := store.(SnapshotStore); ok {
+		return snapshotStore.Snapshot(w)
+	}
+
+	s, err := takeSnapshot(g, store)
+	if err != nil {
+		return err
+	}
+
+	return writeSnapshot(w, s)
+}
+
+// Restore reads a snapshot created by [Snapshot] from r and adds its vertices
+// and edges to the graph. The snapshot has to be created from a graph with the
+// same traits, because the edges are added to the store as they are.
+//
+// If the store of the graph implements [SnapshotStore], the contents of the
+// store are replaced with the snapshot. Otherwise, the vertices and edges are
+// added to the store one by one, which requires the store to be empty.
+func Restore[K comparable, T any](g Graph[K, T], r io.Reader) error {
+	store, ok := storeOf(g)
+	if !ok {
+		return errors.New("graph doesn't support snapshots")
+	}
+
+	if snapshotStore, ok := store.(SnapshotStore); ok {
+		return snapshotStore.Restore(r)
+	}
+
+	s, err := readSnapshot[K, T](r)
+	if err != nil {
+		return err
+	}
+
+	return restoreSnapshot(store, s)
+}
+
+// takeSnapshot reads all vertices and edges of the given graph and its store
+// one after another.
+func takeSnapshot[K comparable, T any](g Graph[K, T], store Store[K, T]) (snapshot[K, T], error) {
+	s := snapshot[K, T]{
+		Vertices: make([]snapshotVertex[K, T], 0),
+		Edges:    make([]snapshotEdge[K], 0),
+	}
+
+	hashes, err := vertexHashes(g)
+	if err != nil {
+		return s, fmt.Errorf("failed to list vertices: %w", err)
+	}
+
+	for _, hash := range hashes {
+		value, properties, err := store.Vertex(hash)
+		if err != nil {
+			return s, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+		s.Vertices = append(s.Vertices, newSnapshotVertex(hash, value, properties))
+	}
+
+	err = iterEdges(store, func(edge Edge[K]) bool {
+		s.Edges = append(s.Edges, newSnapshotEdge(edge))
+		return true
+	})
+	if err != nil {
+		return s, fmt.Errorf("failed to list edges: %w", err)
+	}
+
+	return s, nil
+}
+
+// restoreSnapshot adds the vertices and edges of the given snapshot to the
+// store one by one.
+func restoreSnapshot[K comparable, T any](store Store[K, T], s snapshot[K, T]) error {
+	for _, vertex := range s.Vertices {
+		if err := store.AddVertex(vertex.Hash, vertex.Value, vertex.properties()); err != nil {
+			return fmt.Errorf("failed to add vertex %v: %w", vertex.Hash, err)
+		}
+	}
+
+	for _, edge := range s.Edges {
+		if err := store.AddEdge(edge.Source, edge.Target, edge.edge()); err != nil {
+			return fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	return nil
+}
+
+func newSnapshotVertex[K comparable, T any](hash K, value T, properties VertexProperties) snapshotVertex[K, T] {
+	return snapshotVertex[K, T]{
+		Hash:       hash,
+		Value:      value,
+		Weight:     properties.Weight,
+		Attributes: properties.Attributes,
+		Weights:    properties.Weights,
+	}
+}
+
+func newSnapshotEdge[K comparable](edge Edge[K]) snapshotEdge[K] {
+	return snapshotEdge[K]{
+		Source:     edge.Source,
+		Target:     edge.Target,
+		Weight:     edge.Properties.Weight,
+		Attributes: edge.Properties.Attributes,
+		Data:       edge.Properties.Data,
+	}
+}
+
+func (v snapshotVertex[K, T]) properties() VertexProperties {
+	properties := VertexProperties{
+		Weight:     v.Weight,
+		Attributes: v.Attributes,
+		Weights:    v.Weights,
+	}
+
+	if properties.Attributes == nil {
+		properties.Attributes = make(map[string]string)
+	}
+
+	return properties
+}
+
+func (e snapshotEdge[K]) edge() Edge[K] {
+	edge := Edge[K]{
+		Source: e.Source,
+		Target: e.Target,
+		Properties: EdgeProperties{
+			Weight:     e.Weight,
+			Attributes: e.Attributes,
+			Data:       e.Data,
+		},
+	}
+
+	if edge.Properties.Attributes == nil {
+		edge.Properties.Attributes = make(map[string]string)
+	}
+
+	return edge
+}
+
+func writeSnapshot[K comparable, T any](w io.Writer, s snapshot[K, T]) error {
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func readSnapshot[K comparable, T any](r io.Reader) (snapshot[K, T], error) {
+	var s snapshot[K, T]
+
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return s, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	return s, nil
+}
+
+// Snapshot writes a consistent snapshot of the store to w. The vertices and
+// edges are copied while holding the read lock, and written afterwards.
+func (s *memoryStore[K, T]) Snapshot(w io.Writer) error {
+	s.lock.RLock()
+
+	snap := snapshot[K, T]{
+		Vertices: make([]snapshotVertex[K, T], 0, len(s.vertices)),
+		Edges:    make([]snapshotEdge[K], 0, s.edgeCount),
+	}
+
+	// Vertices and edges are written in the order used for paging, so that the
+	// restored store yields them in the same order. If no page has been
+	// requested yet, they are sorted by their hashes instead.
+	var (
+		hashes []K
+		keys   []tuple[K]
+	)
+
+	if s.vertexOrder != nil {
+		hashes, _, _ = s.vertexOrder.after(0, len(s.vertices))
+		keys, _, _ = s.edgeOrder.after(0, s.edgeCount)
+	} else {
+		hashes = s.sortedVertices()
+		keys = s.sortedEdgeKeys()
+	}
+
+	for _, hash := range hashes {
+		snap.Vertices = append(snap.Vertices, newSnapshotVertex(hash, s.vertices[hash], s.vertexProperties[hash]))
+	}
+
+	for _, key := range keys {
+		snap.Edges = append(snap.Edges, newSnapshotEdge(s.outEdges[key.source][key.target]))
+	}
+
+	s.lock.RUnlock()
+
+	return writeSnapshot(w, snap)
+}
+
+// Restore replaces the contents of the store with the snapshot read from r. The
+// snapshot is read completely before the store is modified.
+func (s *memoryStore[K, T]) Restore(r io.Reader) error {
+	snap, err := readSnapshot[K, T](r)
+	if err != nil {
+		return err
+	}
+
+	restored := newMemoryStore[K, T]().(*memoryStore[K, T])
+
+	// If the order of the store is tracked, the restored store keeps the order
+	// of the snapshot.
+	s.lock.RLock()
+	tracked := s.vertexOrder != nil
+	s.lock.RUnlock()
+
+	if tracked {
+		restored.trackOrder()
+	}
+
+	for _, vertex := range snap.Vertices {
+		if err := restored.AddVertex(vertex.Hash, vertex.Value, vertex.properties()); err != nil {
+			return fmt.Errorf("failed to add vertex %v: %w", vertex.Hash, err)
+		}
+	}
+
+	for _, edge := range snap.Edges {
+		if _, ok := restored.vertices[edge.Source]; !ok {
+			return fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, &VertexNotFoundError[K]{Hash: edge.Source})
+		}
+		if _, ok := restored.vertices[edge.Target]; !ok {
+			return fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, &VertexNotFoundError[K]{Hash: edge.Target})
+		}
+		if err := restored.AddEdge(edge.Source, edge.Target, edge.edge()); err != nil {
+			return fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.readOnly {
+		return ErrGraphFrozen
+	}
+
+	s.vertices = restored.vertices
+	s.vertexProperties = restored.vertexProperties
+	s.outEdges = restored.outEdges
+	s.inEdges = restored.inEdges
+	s.edgeCount = restored.edgeCount
+	s.pairCount = restored.pairCount
+	s.shared = false
+
+	if s.vertexOrder != nil && restored.vertexOrder == nil {
+		restored.trackOrder()
+	}
+
+	s.vertexOrder = restored.vertexOrder
+	s.edgeOrder = restored.edgeOrder
+
+	return nil
+}
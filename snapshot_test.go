@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotAndRestore(t *testing.T) {
+	tests := map[string]struct {
+		traits []func(*Traits)
+		source func() Store[string, string]
+		target func() Store[string, string]
+	}{
+		"directed memory store": {
+			traits: []func(*Traits){Directed()},
+			source: newMemoryStore[string, string],
+			target: newMemoryStore[string, string],
+		},
+		"undirected memory store": {
+			source: newMemoryStore[string, string],
+			target: newMemoryStore[string, string],
+		},
+		"store without snapshots": {
+			traits: []func(*Traits){Directed()},
+			source: func() Store[string, string] {
+				return iteratingStore[string, string]{newMemoryStore[string, string]()}
+			},
+			target: func() Store[string, string] {
+				return iteratingStore[string, string]{newMemoryStore[string, string]()}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		g := NewWithStore(StringHash, test.source(), test.traits...)
+
+		_ = g.AddVertex("A", VertexWeight(2), VertexAttribute("color", "red"), VertexWeightNamed("cpu", 1.5))
+		_ = g.AddVertex("B")
+		_ = g.AddVertex("C")
+		_ = g.AddEdge("A", "B", EdgeWeight(3), EdgeData("data"))
+		_ = g.AddEdge("B", "C")
+
+		var buf bytes.Buffer
+
+		if err := Snapshot(g, &buf); err != nil {
+			t.Fatalf("%s: failed to create snapshot: %v", name, err)
+		}
+
+		restored := NewWithStore(StringHash, test.target(), test.traits...)
+
+		if err := Restore(restored, &buf); err != nil {
+			t.Fatalf("%s: failed to restore snapshot: %v", name, err)
+		}
+
+		if order, _ := restored.Order(); order != 3 {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, 3, order)
+		}
+
+		if size, _ := restored.Size(); size != 2 {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, 2, size)
+		}
+
+		_, properties, err := restored.VertexWithProperties("A")
+		if err != nil || properties.Weight != 2 || properties.Attributes["color"] != "red" || properties.Weights["cpu"] != 1.5 {
+			t.Errorf("%s: vertex properties don't match: got %v (error: %v)", name, properties, err)
+		}
+
+		edge, err := restored.Edge("A", "B")
+		if err != nil || edge.Properties.Weight != 3 || edge.Properties.Data != "data" {
+			t.Errorf("%s: edge properties don't match: got %v (error: %v)", name, edge.Properties, err)
+		}
+
+		if !restored.Traits().IsDirected {
+			if _, err := restored.Edge("C", "B"); err != nil {
+				t.Errorf("%s: expected reversed edge (C, B) to exist: %v", name, err)
+			}
+		}
+	}
+}
+
+func TestMemoryStore_Restore(t *testing.T) {
+	g := New(StringHash, Directed())
+
+	_ = g.AddVertex("A")
+
+	if err := Restore(g, strings.NewReader(`{"vertices": [`)); err == nil {
+		t.Errorf("error expectancy doesn't match: expected error, got %v", err)
+	}
+
+	if order, _ := g.Order(); order != 1 {
+		t.Errorf("order doesn't match: expected %v, got %v", 1, order)
+	}
+
+	snapshot := `{"vertices": [{"hash": "B", "value": "B"}], "edges": [{"source": "B", "target": "C"}]}`
+
+	if err := Restore(g, strings.NewReader(snapshot)); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrVertexNotFound, err)
+	}
+
+	if _, err := g.Vertex("A"); err != nil {
+		t.Errorf("expected store to remain unchanged, got error %v", err)
+	}
+
+	snapshot = `{"vertices": [{"hash": "B", "value": "B"}, {"hash": "C", "value": "C"}], "edges": [{"source": "B", "target": "C"}]}`
+
+	if err := Restore(g, strings.NewReader(snapshot)); err != nil {
+		t.Fatalf("failed to restore snapshot: %v", err)
+	}
+
+	if _, err := g.Vertex("A"); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrVertexNotFound, err)
+	}
+
+	if _, err := g.Edge("B", "C"); err != nil {
+		t.Errorf("failed to get edge: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Adjacencies holds a vertex hash along with the edges leaving that vertex. It
+// is the sorted counterpart of an entry in the adjacency map of a graph.
+type Adjacencies[K comparable] struct {
+	Hash  K
+	Edges []Edge[K]
+}
+
+// SortedVertices returns the hashes of all vertices in the graph, sorted using
+// the given less function. The maps returned by a graph don't have an order,
+// so SortedVertices is handy for displaying or serializing the vertices in a
+// deterministic order.
+//
+// If less is nil, hashes of ordered kinds, i.e. strings, integers, and floats,
+// are sorted by their values. All other hashes are sorted by their string
+// representations.
+func SortedVertices[K comparable, T any](g Graph[K, T], less func(K, K) bool) ([]K, error) {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	hashes := make([]K, 0, len(adjacencyMap))
+	for hash := range adjacencyMap {
+		hashes = append(hashes, hash)
+	}
+
+	sortWith(hashes, orDefaultLess(less))
+
+	return hashes, nil
+}
+
+// SortedEdges returns all edges in the graph, sorted by their source hashes and
+// then by their target hashes using the given less function. For undirected
+// graphs, each edge is returned once with the smaller hash as its source. If
+// less is nil, the hashes are compared as described in [SortedVertices].
+func SortedEdges[K comparable, T any](g Graph[K, T], less func(K, K) bool) ([]Edge[K], error) {
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	less = orDefaultLess(less)
+
+	if !g.Traits().IsDirected {
+		for i, edge := range edges {
+			if less(edge.Target, edge.Source) {
+				edges[i].Source, edges[i].Target = edge.Target, edge.Source
+			}
+		}
+	}
+
+	sortEdgesWith(edges, less)
+
+	return edges, nil
+}
+
+// SortedAdjacencyMap returns the adjacency map of the graph as a slice of
+// [Adjacencies]. The vertices are sorted using the given less function, and the
+// edges of each vertex are sorted by their target hashes. If less is nil, the
+// hashes are compared as described in [SortedVertices].
+//
+//	adjacencies, _ := graph.SortedAdjacencyMap(g, nil)
+//
+//	for _, adjacency := range adjacencies {
+//		for _, edge := range adjacency.Edges {
+//			fmt.Printf("%v -> %v\n", edge.Source, edge.Target)
+//		}
+//	}
+func SortedAdjacencyMap[K comparable, T any](g Graph[K, T], less func(K, K) bool) ([]Adjacencies[K], error) {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	less = orDefaultLess(less)
+
+	adjacencies := make([]Adjacencies[K], 0, len(adjacencyMap))
+
+	for hash, edgeMap := range adjacencyMap {
+		edges := make([]Edge[K], 0, len(edgeMap))
+		for _, edge := range edgeMap {
+			edges = append(edges, edge)
+		}
+
+		sortEdgesWith(edges, less)
+
+		adjacencies = append(adjacencies, Adjacencies[K]{
+			Hash:  hash,
+			Edges: edges,
+		})
+	}
+
+	sort.Slice(adjacencies, func(i, j int) bool {
+		return less(adjacencies[i].Hash, adjacencies[j].Hash)
+	})
+
+	return adjacencies, nil
+}
+
+// orDefaultLess returns the given less function, or a less function based on
+// compareHashes if it is nil.
+func orDefaultLess[K comparable](less func(K, K) bool) func(K, K) bool {
+	if less != nil {
+		return less
+	}
+
+	return func(a, b K) bool {
+		return compareHashes(a, b) < 0
+	}
+}
+
+func sortWith[K comparable](hashes []K, less func(K, K) bool) {
+	sort.Slice(hashes, func(i, j int) bool {
+		return less(hashes[i], hashes[j])
+	})
+}
+
+func sortEdgesWith[K comparable](edges []Edge[K], less func(K, K) bool) {
+	sort.Slice(edges, func(i, j int) bool {
+		if less(edges[i].Source, edges[j].Source) {
+			return true
+		}
+		if less(edges[j].Source, edges[i].Source) {
+			return false
+		}
+		return less(edges[i].Target, edges[j].Target)
+	})
+}
@@ -0,0 +1,183 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortedVertices(t *testing.T) {
+	tests := map[string]struct {
+		vertices         []int
+		less             func(int, int) bool
+		expectedVertices []int
+	}{
+		"default order": {
+			vertices:         []int{3, 10, 1, 2},
+			expectedVertices: []int{1, 2, 3, 10},
+		},
+		"custom order": {
+			vertices: []int{3, 10, 1, 2},
+			less: func(a, b int) bool {
+				return a > b
+			},
+			expectedVertices: []int{10, 3, 2, 1},
+		},
+		"empty graph": {
+			expectedVertices: []int{},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash)
+
+		for _, vertex := range test.vertices {
+			_ = g.AddVertex(vertex)
+		}
+
+		vertices, err := SortedVertices(g, test.less)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if !reflect.DeepEqual(vertices, test.expectedVertices) {
+			t.Errorf("%s: vertices don't match: expected %v, got %v", name, test.expectedVertices, vertices)
+		}
+	}
+}
+
+func TestSortedEdges(t *testing.T) {
+	tests := map[string]struct {
+		traits        []func(*Traits)
+		edges         []Edge[string]
+		less          func(string, string) bool
+		expectedEdges []Edge[string]
+	}{
+		"directed graph": {
+			traits: []func(*Traits){Directed()},
+			edges: []Edge[string]{
+				{Source: "c", Target: "a"},
+				{Source: "a", Target: "c"},
+				{Source: "a", Target: "b"},
+				{Source: "b", Target: "c"},
+			},
+			expectedEdges: []Edge[string]{
+				{Source: "a", Target: "b"},
+				{Source: "a", Target: "c"},
+				{Source: "b", Target: "c"},
+				{Source: "c", Target: "a"},
+			},
+		},
+		"undirected graph": {
+			edges: []Edge[string]{
+				{Source: "c", Target: "a"},
+				{Source: "b", Target: "a"},
+			},
+			expectedEdges: []Edge[string]{
+				{Source: "a", Target: "b"},
+				{Source: "a", Target: "c"},
+			},
+		},
+		"undirected graph with custom order": {
+			edges: []Edge[string]{
+				{Source: "a", Target: "b"},
+				{Source: "a", Target: "c"},
+			},
+			less: func(a, b string) bool {
+				return a > b
+			},
+			expectedEdges: []Edge[string]{
+				{Source: "c", Target: "a"},
+				{Source: "b", Target: "a"},
+			},
+		},
+		"custom order": {
+			traits: []func(*Traits){Directed()},
+			edges: []Edge[string]{
+				{Source: "a", Target: "b"},
+				{Source: "a", Target: "c"},
+				{Source: "b", Target: "c"},
+			},
+			less: func(a, b string) bool {
+				return a > b
+			},
+			expectedEdges: []Edge[string]{
+				{Source: "b", Target: "c"},
+				{Source: "a", Target: "c"},
+				{Source: "a", Target: "b"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(StringHash, test.traits...)
+
+		for _, vertex := range []string{"a", "b", "c"} {
+			_ = g.AddVertex(vertex)
+		}
+
+		for _, edge := range test.edges {
+			_ = g.AddEdge(edge.Source, edge.Target)
+		}
+
+		edges, err := SortedEdges(g, test.less)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if len(edges) != len(test.expectedEdges) {
+			t.Fatalf("%s: number of edges doesn't match: expected %v, got %v", name, len(test.expectedEdges), len(edges))
+		}
+
+		for i, edge := range edges {
+			expected := test.expectedEdges[i]
+			if edge.Source != expected.Source || edge.Target != expected.Target {
+				t.Errorf("%s: edge %d doesn't match: expected (%v, %v), got (%v, %v)", name, i, expected.Source, expected.Target, edge.Source, edge.Target)
+			}
+		}
+	}
+}
+
+func TestSortedAdjacencyMap(t *testing.T) {
+	g := New(IntHash, Directed())
+
+	for _, vertex := range []int{3, 1, 2} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge(1, 3)
+	_ = g.AddEdge(1, 2)
+	_ = g.AddEdge(3, 1)
+
+	adjacencies, err := SortedAdjacencyMap(g, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[int][]int{
+		1: {2, 3},
+		2: {},
+		3: {1},
+	}
+
+	if len(adjacencies) != len(expected) {
+		t.Fatalf("number of vertices doesn't match: expected %v, got %v", len(expected), len(adjacencies))
+	}
+
+	for i, adjacency := range adjacencies {
+		if adjacency.Hash != i+1 {
+			t.Errorf("vertex doesn't match: expected %v, got %v", i+1, adjacency.Hash)
+		}
+
+		targets := make([]int, 0, len(adjacency.Edges))
+		for _, edge := range adjacency.Edges {
+			if edge.Source != adjacency.Hash {
+				t.Errorf("source doesn't match: expected %v, got %v", adjacency.Hash, edge.Source)
+			}
+			targets = append(targets, edge.Target)
+		}
+
+		if !reflect.DeepEqual(targets, expected[adjacency.Hash]) {
+			t.Errorf("targets of %v don't match: expected %v, got %v", adjacency.Hash, expected[adjacency.Hash], targets)
+		}
+	}
+}
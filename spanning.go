@@ -0,0 +1,450 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// IncrementalMST maintains a minimum spanning tree of an undirected graph while
+// the graph is modified. Instead of running Kruskal's algorithm over all edges
+// after each modification, only the affected part of the tree is updated:
+//
+//   - Adding an edge or decreasing its weight replaces the heaviest edge on the
+//     tree path between its vertices if the edge is lighter.
+//   - Removing a tree edge or increasing its weight reconnects the two parts of
+//     the tree using the lightest edge between them.
+//
+// Adding an edge takes O(V) time, and removing a tree edge takes O(V+E) time in
+// the worst case, which is a lot faster than rebuilding the tree for graphs
+// with many edges. For graphs that aren't connected, a minimum spanning forest
+// is maintained.
+//
+// The tree is kept up to date using the hooks of the graph, so it only observes
+// modifications made through the graph instance it has been created for.
+type IncrementalMST[K comparable, T any] struct {
+	lock       sync.Mutex
+	g          Graph[K, T]
+	edgeWeight WeightFunc[K]
+	unregister func()
+	// tree contains each tree edge in both directions, along with all
+	// vertices of the graph.
+	tree map[K]map[K]weightedTreeEdge[K]
+	// err is the first error that occurred while updating the tree in a hook.
+	// Since hooks can't return errors, the tree is rebuilt on the next query.
+	err error
+}
+
+type weightedTreeEdge[K comparable] struct {
+	edge   Edge[K]
+	weight float64
+}
+
+// NewIncrementalMST computes the minimum spanning tree of the given undirected
+// graph and keeps it up to date when the graph is modified:
+//
+//	mst, _ := graph.NewIncrementalMST(network)
+//	defer mst.Close()
+//
+//	_ = network.UpdateEdge("A", "B", graph.EdgeWeight(12))
+//
+//	edges, _ := mst.Edges()
+//
+// Instead of the stored edge weights, the weights can be derived from the edges
+// by passing WeightedBy with a [WeightFunc]. Close stops keeping the tree up to
+// date.
+func NewIncrementalMST[K comparable, T any](g Graph[K, T], options ...func(*WeightOptions[K])) (*IncrementalMST[K, T], error) {
+	if g.Traits().IsDirected {
+		return nil, errors.New("spanning trees can only be determined for undirected graphs")
+	}
+
+	m := &IncrementalMST[K, T]{
+		g:          g,
+		edgeWeight: weightFunc(options, storedWeight[K]),
+	}
+
+	// The hooks are registered before building the tree, so that no
+	// modification gets lost in between. They can't run before the lock is
+	// released.
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	unregister, err := registerHook(g, hookFuncs[K, T]{
+		addVertex:    m.vertexAdded,
+		removeVertex: m.vertexRemoved,
+		addEdge:      m.edgeAdded,
+		updateEdge:   m.edgeUpdated,
+		removeEdge:   m.edgeRemoved,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register hooks: %w", err)
+	}
+
+	if err := m.build(); err != nil {
+		unregister()
+		return nil, err
+	}
+
+	m.unregister = unregister
+
+	return m, nil
+}
+
+// Edges returns the edges of the minimum spanning tree, sorted as described in
+// [SortedEdges].
+func (m *IncrementalMST[K, T]) Edges() ([]Edge[K], error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.rebuildIfFailed(); err != nil {
+		return nil, err
+	}
+
+	edges := make([]Edge[K], 0)
+
+	m.visitTreeEdges(func(e weightedTreeEdge[K]) {
+		edges = append(edges, e.edge)
+	})
+
+	sortEdgesWith(edges, orDefaultLess[K](nil))
+
+	return edges, nil
+}
+
+// Weight returns the total weight of the minimum spanning tree.
+func (m *IncrementalMST[K, T]) Weight() (float64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.rebuildIfFailed(); err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+
+	m.visitTreeEdges(func(e weightedTreeEdge[K]) {
+		total += e.weight
+	})
+
+	return total, nil
+}
+
+// Tree returns the minimum spanning tree as a new graph, just like
+// [MinimumSpanningTree]. The returned graph isn't kept up to date.
+func (m *IncrementalMST[K, T]) Tree() (Graph[K, T], error) {
+	edges, err := m.Edges()
+	if err != nil {
+		return nil, err
+	}
+
+	mst := NewLike(m.g)
+
+	if err := mst.AddVerticesFrom(m.g); err != nil {
+		return nil, fmt.Errorf("failed to add vertices: %w", err)
+	}
+
+	for _, edge := range edges {
+		if err := mst.AddEdge(copyEdge(edge)); err != nil {
+			return nil, fmt.Errorf("failed to add edge (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	return mst, nil
+}
+
+// Close stops keeping the minimum spanning tree up to date.
+func (m *IncrementalMST[K, T]) Close() {
+	m.unregister()
+}
+
+// build computes the minimum spanning tree using Kruskal's algorithm.
+func (m *IncrementalMST[K, T]) build() error {
+	hashes, err := vertexHashes(m.g)
+	if err != nil {
+		return fmt.Errorf("failed to get vertices: %w", err)
+	}
+
+	edges, err := m.g.Edges()
+	if err != nil {
+		return fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	weighted := make([]weightedTreeEdge[K], len(edges))
+	for i, edge := range edges {
+		weighted[i] = weightedTreeEdge[K]{edge: edge, weight: m.edgeWeight(edge)}
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].weight < weighted[j].weight
+	})
+
+	m.tree = make(map[K]map[K]weightedTreeEdge[K], len(hashes))
+	m.err = nil
+
+	for _, hash := range hashes {
+		m.tree[hash] = make(map[K]weightedTreeEdge[K])
+	}
+
+	subtrees := newUnionFind(hashes...)
+
+	for _, e := range weighted {
+		sourceRoot := subtrees.find(e.edge.Source)
+		targetRoot := subtrees.find(e.edge.Target)
+
+		if sourceRoot != targetRoot {
+			subtrees.union(sourceRoot, targetRoot)
+			m.link(e)
+		}
+	}
+
+	return nil
+}
+
+func (m *IncrementalMST[K, T]) rebuildIfFailed() error {
+	if m.err == nil {
+		return nil
+	}
+
+	if err := m.build(); err != nil {
+		return fmt.Errorf("failed to rebuild spanning tree after %v: %w", m.err, err)
+	}
+
+	return nil
+}
+
+func (m *IncrementalMST[K, T]) vertexAdded(hash K, _ T, _ VertexProperties) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.tree[hash]; !ok {
+		m.tree[hash] = make(map[K]weightedTreeEdge[K])
+	}
+}
+
+func (m *IncrementalMST[K, T]) vertexRemoved(hash K) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// A vertex can only be removed once its edges have been removed, so there
+	// are no tree edges left.
+	delete(m.tree, hash)
+}
+
+func (m *IncrementalMST[K, T]) edgeAdded(edge Edge[K]) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.insert(weightedTreeEdge[K]{edge: edge, weight: m.edgeWeight(edge)})
+}
+
+func (m *IncrementalMST[K, T]) edgeUpdated(edge Edge[K]) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	e := weightedTreeEdge[K]{edge: edge, weight: m.edgeWeight(edge)}
+
+	current, ok := m.tree[edge.Source][edge.Target]
+	if !ok {
+		m.insert(e)
+		return
+	}
+
+	m.unlink(edge.Source, edge.Target)
+
+	// A tree edge that became lighter remains part of the tree. If it became
+	// heavier, the lightest edge between both parts of the tree, which may be
+	// the edge itself, reconnects them.
+	if e.weight <= current.weight {
+		m.link(e)
+		return
+	}
+
+	m.reconnect(edge.Source, edge.Target)
+}
+
+func (m *IncrementalMST[K, T]) edgeRemoved(source, target K) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.tree[source][target]; !ok {
+		return
+	}
+
+	m.unlink(source, target)
+	m.reconnect(source, target)
+}
+
+// insert adds the given edge to the tree if it connects two parts of the
+// forest, or if it is lighter than the heaviest edge on the tree path between
+// its vertices, which it replaces.
+func (m *IncrementalMST[K, T]) insert(e weightedTreeEdge[K]) {
+	source, target := e.edge.Source, e.edge.Target
+
+	if source == target || m.err != nil {
+		return
+	}
+
+	path, ok := m.treePath(source, target)
+	if !ok {
+		m.link(e)
+		return
+	}
+
+	heaviest := path[0]
+	for _, candidate := range path[1:] {
+		if candidate.weight > heaviest.weight {
+			heaviest = candidate
+		}
+	}
+
+	if e.weight < heaviest.weight {
+		m.unlink(heaviest.edge.Source, heaviest.edge.Target)
+		m.link(e)
+	}
+}
+
+// reconnect joins the two parts of the tree containing the given vertices
+// using the lightest edge of the graph between them, if there is one.
+func (m *IncrementalMST[K, T]) reconnect(source, target K) {
+	if m.err != nil {
+		return
+	}
+
+	// Only the edges of the smaller part have to be checked.
+	part := m.treeComponent(source)
+	if other := m.treeComponent(target); len(other) < len(part) {
+		part = other
+	}
+
+	successorsOf, err := successorsFunc(m.g)
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	var (
+		lightest weightedTreeEdge[K]
+		found    bool
+	)
+
+	for hash := range part {
+		neighbors, err := collectNeighbors(successorsOf, hash)
+		if err != nil {
+			m.err = fmt.Errorf("could not get edges of %v: %w", hash, err)
+			return
+		}
+
+		for _, neighbor := range neighbors {
+			if _, ok := part[neighbor.hash]; ok {
+				continue
+			}
+			if weight := m.edgeWeight(neighbor.edge); !found || weight < lightest.weight {
+				lightest = weightedTreeEdge[K]{edge: neighbor.edge, weight: weight}
+				found = true
+			}
+		}
+	}
+
+	if found {
+		m.link(lightest)
+	}
+}
+
+// treePath returns the tree edges on the path between the given vertices, or
+// false if they are in different parts of the forest.
+func (m *IncrementalMST[K, T]) treePath(source, target K) ([]weightedTreeEdge[K], bool) {
+	parents := map[K]weightedTreeEdge[K]{}
+	visited := map[K]struct{}{source: {}}
+	queue := []K{source}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == target {
+			break
+		}
+
+		for neighbor, e := range m.tree[current] {
+			if _, ok := visited[neighbor]; ok {
+				continue
+			}
+			visited[neighbor] = struct{}{}
+			parents[neighbor] = e
+			queue = append(queue, neighbor)
+		}
+	}
+
+	if _, ok := visited[target]; !ok {
+		return nil, false
+	}
+
+	path := make([]weightedTreeEdge[K], 0)
+
+	for current := target; current != source; {
+		e := parents[current]
+		path = append(path, e)
+
+		if e.edge.Source == current {
+			current = e.edge.Target
+		} else {
+			current = e.edge.Source
+		}
+	}
+
+	return path, true
+}
+
+// treeComponent returns the vertices in the same part of the forest as the
+// given vertex.
+func (m *IncrementalMST[K, T]) treeComponent(hash K) map[K]struct{} {
+	component := map[K]struct{}{hash: {}}
+	stack := []K{hash}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for neighbor := range m.tree[current] {
+			if _, ok := component[neighbor]; !ok {
+				component[neighbor] = struct{}{}
+				stack = append(stack, neighbor)
+			}
+		}
+	}
+
+	return component
+}
+
+func (m *IncrementalMST[K, T]) link(e weightedTreeEdge[K]) {
+	source, target := e.edge.Source, e.edge.Target
+
+	if _, ok := m.tree[source]; !ok {
+		m.tree[source] = make(map[K]weightedTreeEdge[K])
+	}
+	if _, ok := m.tree[target]; !ok {
+		m.tree[target] = make(map[K]weightedTreeEdge[K])
+	}
+
+	m.tree[source][target] = e
+	m.tree[target][source] = e
+}
+
+func (m *IncrementalMST[K, T]) unlink(source, target K) {
+	delete(m.tree[source], target)
+	delete(m.tree[target], source)
+}
+
+// visitTreeEdges calls visit once for each tree edge.
+func (m *IncrementalMST[K, T]) visitTreeEdges(visit func(weightedTreeEdge[K])) {
+	for hash, adjacencies := range m.tree {
+		for neighbor, e := range adjacencies {
+			// Each edge is stored in both directions, but only visited from
+			// the direction it has been added with.
+			if e.edge.Source == hash && e.edge.Target == neighbor {
+				visit(e)
+			}
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestIncrementalMST(t *testing.T) {
+	tests := map[string]struct {
+		modify        func(g Graph[string, string])
+		expectedEdges []Edge[string]
+	}{
+		"initial tree": {
+			modify: func(g Graph[string, string]) {},
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "B", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+		"added edge replacing the heaviest tree edge": {
+			modify: func(g Graph[string, string]) {
+				_ = g.AddEdge("A", "D", EdgeWeight(2))
+			},
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "A", Target: "D"},
+				{Source: "B", Target: "C"},
+			},
+		},
+		"added edge heavier than the tree path": {
+			modify: func(g Graph[string, string]) {
+				_ = g.AddEdge("A", "D", EdgeWeight(10))
+			},
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "B", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+		"added vertex and edge": {
+			modify: func(g Graph[string, string]) {
+				_ = g.AddVertex("E")
+				_ = g.AddEdge("E", "D", EdgeWeight(7))
+			},
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "B", Target: "C"},
+				{Source: "C", Target: "D"},
+				{Source: "E", Target: "D"},
+			},
+		},
+		"removed tree edge": {
+			modify: func(g Graph[string, string]) {
+				_ = g.RemoveEdge("B", "C")
+			},
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "A", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+		"removed bridge": {
+			modify: func(g Graph[string, string]) {
+				_ = g.RemoveEdge("C", "D")
+			},
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "B", Target: "C"},
+			},
+		},
+		"tree edge that became heavier": {
+			modify: func(g Graph[string, string]) {
+				_ = g.UpdateEdge("A", "B", EdgeWeight(6))
+			},
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "C"},
+				{Source: "B", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+		"non-tree edge that became lighter": {
+			modify: func(g Graph[string, string]) {
+				_ = g.UpdateEdge("A", "C", EdgeWeight(1))
+			},
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "A", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(StringHash, Weighted())
+
+		for _, vertex := range []string{"A", "B", "C", "D"} {
+			_ = g.AddVertex(vertex)
+		}
+
+		_ = g.AddEdge("A", "B", EdgeWeight(1))
+		_ = g.AddEdge("B", "C", EdgeWeight(3))
+		_ = g.AddEdge("A", "C", EdgeWeight(4))
+		_ = g.AddEdge("C", "D", EdgeWeight(5))
+
+		mst, err := NewIncrementalMST(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		test.modify(g)
+
+		edges, err := mst.Edges()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		actual := make([]Edge[string], len(edges))
+		for i, edge := range edges {
+			actual[i] = Edge[string]{Source: edge.Source, Target: edge.Target}
+		}
+
+		if !reflect.DeepEqual(actual, test.expectedEdges) {
+			t.Errorf("%s: edges expectancy doesn't match: expected %v, got %v", name, test.expectedEdges, actual)
+		}
+	}
+}
+
+func TestIncrementalMST_random(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	g := New(IntHash, Weighted())
+	for i := 0; i < 20; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	mst, err := NewIncrementalMST(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer mst.Close()
+
+	for i := 0; i < 500; i++ {
+		source, target := random.Intn(20), random.Intn(20)
+		weight := random.Intn(100)
+
+		switch _, err := g.Edge(source, target); {
+		case source == target:
+			continue
+		case err != nil:
+			_ = g.AddEdge(source, target, EdgeWeight(weight))
+		case random.Intn(2) == 0:
+			_ = g.RemoveEdge(source, target)
+		default:
+			_ = g.UpdateEdge(source, target, EdgeWeight(weight))
+		}
+
+		expected, _ := MinimumSpanningTree(g)
+		expectedWeight := 0.0
+		expectedEdges, _ := expected.Edges()
+		for _, edge := range expectedEdges {
+			expectedWeight += float64(edge.Properties.Weight)
+		}
+
+		actualWeight, err := mst.Weight()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if actualWeight != expectedWeight {
+			t.Fatalf("step %d: weight expectancy doesn't match: expected %v, got %v", i, expectedWeight, actualWeight)
+		}
+
+		edges, _ := mst.Edges()
+		if len(edges) != len(expectedEdges) {
+			t.Fatalf("step %d: edge count expectancy doesn't match: expected %v, got %v", i, len(expectedEdges), len(edges))
+		}
+	}
+}
+
+func TestIncrementalMST_directed(t *testing.T) {
+	if _, err := NewIncrementalMST(New(StringHash, Directed())); err == nil {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", true, false)
+	}
+}
+
+func TestIncrementalMST_Tree(t *testing.T) {
+	g := New(StringHash, Weighted())
+
+	for _, vertex := range []string{"A", "B", "C"} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge("A", "B", EdgeWeight(1))
+	_ = g.AddEdge("B", "C", EdgeWeight(2))
+	_ = g.AddEdge("A", "C", EdgeWeight(3))
+
+	mst, _ := NewIncrementalMST(g)
+	mst.Close()
+
+	// Once closed, the tree isn't updated anymore.
+	_ = g.RemoveEdge("A", "B")
+
+	tree, err := mst.Tree()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := tree.Edge("A", "B"); err != nil {
+		t.Errorf("expected edge (A, B) to be part of the tree: %v", err)
+	}
+
+	if order, _ := tree.Order(); order != 3 {
+		t.Errorf("order expectancy doesn't match: expected %v, got %v", 3, order)
+	}
+}
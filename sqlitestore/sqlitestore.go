@@ -0,0 +1,143 @@
+// Package sqlitestore provides a [graph.Store] implementation that persists
+// graphs in an SQLite database. It doesn't require a database server or cgo,
+// which makes it a good fit for single-binary applications.
+//
+// The store is built on top of the graphsql package and uses the same schema.
+// Opening a database creates the required tables if they don't exist yet:
+//
+//	store, _ := sqlitestore.Open[string, string]("graph.db")
+//	defer store.Close()
+//
+//	g := graph.NewWithStore(graph.StringHash, store, graph.Directed())
+//
+// File-based databases are opened in write-ahead logging (WAL) mode, which
+// allows concurrent readers and makes writes crash-safe. Foreign keys are
+// enforced for all databases.
+package sqlitestore
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/dominikbraun/graph/graphsql"
+
+	// Register the pure-Go SQLite driver.
+	_ "modernc.org/sqlite"
+)
+
+// Memory can be passed to [Open] instead of a file path to create a store
+// that only lives in memory. This is mostly useful for tests.
+const Memory = ":memory:"
+
+type config struct {
+	journalMode string
+	busyTimeout int
+}
+
+// journalModes contains the journal modes supported by SQLite.
+var journalModes = map[string]struct{}{
+	"DELETE":   {},
+	"TRUNCATE": {},
+	"PERSIST":  {},
+	"MEMORY":   {},
+	"WAL":      {},
+	"OFF":      {},
+}
+
+// JournalMode is a functional option for [Open] that sets the SQLite journal
+// mode, which is one of "DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", or
+// "OFF". By default, "WAL" is used. This option has no effect for in-memory
+// databases.
+func JournalMode(mode string) func(*config) {
+	return func(c *config) {
+		c.journalMode = strings.ToUpper(mode)
+	}
+}
+
+// BusyTimeout is a functional option for [Open] that sets the number of
+// milliseconds to wait for a locked database before failing. The default is
+// 5000 milliseconds.
+func BusyTimeout(milliseconds int) func(*config) {
+	return func(c *config) {
+		c.busyTimeout = milliseconds
+	}
+}
+
+// Store is a [graph.Store] implementation backed by an SQLite database. It
+// embeds a [graphsql.Store] and provides all of its methods, including the
+// methods for bulk loading.
+type Store[K comparable, T any] struct {
+	*graphsql.Store[K, T]
+	db *sql.DB
+}
+
+// Open opens the SQLite database at the given path and creates a store that
+// uses this database. If the database file doesn't exist, it will be created.
+// To create an in-memory store, pass [Memory] as path.
+func Open[K comparable, T any](path string, options ...func(*config)) (*Store[K, T], error) {
+	c := config{
+		journalMode: "WAL",
+		busyTimeout: 5000,
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	if _, ok := journalModes[c.journalMode]; !ok {
+		return nil, fmt.Errorf("invalid journal mode %q", c.journalMode)
+	}
+
+	db, err := sql.Open("sqlite", dataSourceName(path, c))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// An in-memory database only exists for the connection that created it.
+	// Therefore, all queries have to share a single connection.
+	if path == Memory {
+		db.SetMaxOpenConns(1)
+	}
+
+	if err := graphsql.SetupTables(db, graphsql.SQLite()); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	sqlStore, err := graphsql.New[K, T](db, graphsql.SQLite())
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store[K, T]{
+		Store: sqlStore,
+		db:    db,
+	}, nil
+}
+
+// Close closes the store along with the underlying database.
+func (s *Store[K, T]) Close() error {
+	if err := s.Store.Close(); err != nil {
+		_ = s.db.Close()
+		return err
+	}
+
+	return s.db.Close()
+}
+
+// dataSourceName builds the SQLite URI for the given path. The path is escaped
+// so that characters like ? or # are treated as part of the file name.
+func dataSourceName(path string, c config) string {
+	query := url.Values{}
+	query.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", c.busyTimeout))
+	query.Add("_pragma", "foreign_keys(1)")
+
+	if path != Memory {
+		query.Add("_pragma", fmt.Sprintf("journal_mode(%s)", c.journalMode))
+	}
+
+	return "file:" + url.PathEscape(path) + "?" + query.Encode()
+}
@@ -0,0 +1,174 @@
+package sqlitestore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+)
+
+func TestOpen(t *testing.T) {
+	tests := map[string]struct {
+		path    func(t *testing.T) string
+		options []func(*config)
+	}{
+		"in-memory database": {
+			path: func(*testing.T) string {
+				return Memory
+			},
+		},
+		"file database": {
+			path: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "graph.db")
+			},
+		},
+		"file database without WAL": {
+			path: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "graph.db")
+			},
+			options: []func(*config){JournalMode("DELETE"), BusyTimeout(100)},
+		},
+	}
+
+	for name, test := range tests {
+		store, err := Open[int, int](test.path(t), test.options...)
+		if err != nil {
+			t.Fatalf("%s: failed to open store: %v", name, err)
+		}
+
+		g := graph.NewWithStore(graph.IntHash, store, graph.Directed())
+
+		for i := 1; i <= 3; i++ {
+			if err := g.AddVertex(i); err != nil {
+				t.Fatalf("%s: failed to add vertex: %v", name, err)
+			}
+		}
+
+		_ = g.AddEdge(1, 2)
+		_ = g.AddEdge(2, 3)
+
+		if err := g.AddEdge(1, 2); !errors.Is(err, graph.ErrEdgeAlreadyExists) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, graph.ErrEdgeAlreadyExists, err)
+		}
+
+		order, err := graph.TopologicalSort(g)
+		if err != nil {
+			t.Fatalf("%s: failed to sort graph: %v", name, err)
+		}
+
+		if len(order) != 3 || order[0] != 1 || order[2] != 3 {
+			t.Errorf("%s: topological order doesn't match: expected %v, got %v", name, []int{1, 2, 3}, order)
+		}
+
+		if err := store.Close(); err != nil {
+			t.Errorf("%s: failed to close store: %v", name, err)
+		}
+	}
+}
+
+func TestOpen_persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.db")
+
+	store, err := Open[string, string](path)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	g := graph.NewWithStore(graph.StringHash, store)
+
+	_ = g.AddVertex("A", graph.VertexAttribute("color", "red"))
+	_ = g.AddVertex("B")
+	_ = g.AddEdge("A", "B", graph.EdgeWeight(3))
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("failed to close store: %v", err)
+	}
+
+	store, err = Open[string, string](path)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer store.Close()
+
+	g = graph.NewWithStore(graph.StringHash, store)
+
+	_, properties, err := g.VertexWithProperties("A")
+	if err != nil {
+		t.Fatalf("failed to get vertex: %v", err)
+	}
+
+	if properties.Attributes["color"] != "red" {
+		t.Errorf("vertex attributes don't match: expected %v, got %v", "red", properties.Attributes["color"])
+	}
+
+	edge, err := g.Edge("B", "A")
+	if err != nil {
+		t.Fatalf("failed to get edge: %v", err)
+	}
+
+	if edge.Properties.Weight != 3 {
+		t.Errorf("edge weight doesn't match: expected %v, got %v", 3, edge.Properties.Weight)
+	}
+}
+
+func TestOpen_specialPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "my graph?#.db")
+
+	store, err := Open[string, string](path)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	_ = store.AddVertex("A", "A", graph.VertexProperties{})
+	_ = store.Close()
+
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected database file %q to exist: %v", path, err)
+	}
+}
+
+func TestOpen_journalMode(t *testing.T) {
+	if _, err := Open[string, string](Memory, JournalMode("WAL); DROP TABLE vertices")); err == nil {
+		t.Errorf("error expectancy doesn't match: expected error, got %v", err)
+	}
+
+	store, err := Open[string, string](filepath.Join(t.TempDir(), "graph.db"), JournalMode("truncate"))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	_ = store.Close()
+}
+
+func TestDataSourceName(t *testing.T) {
+	tests := map[string]struct {
+		path     string
+		config   config
+		expected string
+	}{
+		"in-memory database": {
+			path:     Memory,
+			config:   config{journalMode: "WAL", busyTimeout: 5000},
+			expected: "file::memory:?_pragma=busy_timeout%285000%29&_pragma=foreign_keys%281%29",
+		},
+		"file database": {
+			path:     "graph.db",
+			config:   config{journalMode: "WAL", busyTimeout: 100},
+			expected: "file:graph.db?_pragma=busy_timeout%28100%29&_pragma=foreign_keys%281%29&_pragma=journal_mode%28WAL%29",
+		},
+		"path with reserved characters": {
+			path:     "graphs/my graph?.db",
+			config:   config{journalMode: "WAL", busyTimeout: 100},
+			expected: "file:graphs%2Fmy%20graph%3F.db?_pragma=busy_timeout%28100%29&_pragma=foreign_keys%281%29&_pragma=journal_mode%28WAL%29",
+		},
+	}
+
+	for name, test := range tests {
+		if dsn := dataSourceName(test.path, test.config); dsn != test.expected {
+			t.Errorf("%s: data source name doesn't match: expected %v, got %v", name, test.expected, dsn)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package graph
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -68,6 +70,448 @@ type Store[K comparable, T any] interface {
 	EdgeCount() (int, error)
 }
 
+// VertexIterator is an optional interface that a [Store] may implement to
+// stream its vertices instead of returning them in a slice. This is useful for
+// stores backed by database cursors, where loading all vertices at once would
+// be expensive.
+//
+// Graphs use the iterators to build the results of AdjacencyMap, PredecessorMap,
+// and Edges. Algorithms like DFS or ShortestPath, which operate on these
+// results, don't use the iterators directly.
+//
+// IterVertices should call yield for each vertex in the graph and stop as soon
+// as yield returns false. The yield function must not modify the store.
+type VertexIterator[K comparable, T any] interface {
+	IterVertices(yield func(hash K, value T, properties VertexProperties) bool) error
+}
+
+// EdgeIterator is an optional interface that a [Store] may implement to stream
+// its edges instead of returning them in a slice. It is the edge counterpart
+// to [VertexIterator].
+//
+// IterEdges should call yield for each edge in the graph and stop as soon as
+// yield returns false. The yield function must not modify the store.
+type EdgeIterator[K comparable] interface {
+	IterEdges(yield func(edge Edge[K]) bool) error
+}
+
+// iterVertexHashes calls yield for the hash of each vertex in the store. If the
+// store implements VertexIterator, the vertices are streamed. Otherwise, they
+// are obtained using ListVertices.
+func iterVertexHashes[K comparable, T any](store Store[K, T], yield func(hash K) bool) error {
+	if iterator, ok := store.(VertexIterator[K, T]); ok {
+		return iterator.IterVertices(func(hash K, _ T, _ VertexProperties) bool {
+			return yield(hash)
+		})
+	}
+
+	hashes, err := store.ListVertices()
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		if !yield(hash) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// iterEdges calls yield for each edge in the store. If the store implements
+// EdgeIterator, the edges are streamed. Otherwise, they are obtained using
+// ListEdges.
+func iterEdges[K comparable, T any](store Store[K, T], yield func(edge Edge[K]) bool) error {
+	if iterator, ok := store.(EdgeIterator[K]); ok {
+		return iterator.IterEdges(yield)
+	}
+
+	edges, err := store.ListEdges()
+	if err != nil {
+		return err
+	}
+
+	for _, edge := range edges {
+		if !yield(edge) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// NeighborStore is an optional interface that a [Store] may implement to look
+// up the edges of a single vertex. Algorithms that only need the neighbors of
+// the vertices they visit, such as DFS or ShortestPath, use these methods
+// instead of building an adjacency map of the entire graph.
+type NeighborStore[K comparable] interface {
+	// EdgesBySource should return all edges whose source is the vertex with
+	// the given hash. If the vertex doesn't exist, ErrVertexNotFound should be
+	// returned.
+	EdgesBySource(sourceHash K) ([]Edge[K], error)
+
+	// EdgesByTarget should return all edges whose target is the vertex with
+	// the given hash. If the vertex doesn't exist, ErrVertexNotFound should be
+	// returned.
+	EdgesByTarget(targetHash K) ([]Edge[K], error)
+}
+
+// PagingStore is an optional interface that a [Store] may implement to return
+// its vertices and edges in pages. This allows UIs and remote stores to page
+// through huge graphs instead of loading all vertices or edges at once.
+//
+// Pages are addressed by opaque cursors: An empty cursor requests the first
+// page, and each page comes with the cursor for the next page. An empty next
+// cursor denotes the last page. The order of the vertices and edges is up to
+// the store, but iterating over all pages should yield each vertex or edge
+// that exists during the entire iteration exactly once. If the cursor can't be
+// parsed, ErrInvalidCursor should be returned.
+type PagingStore[K comparable] interface {
+	// ListVerticesPage should return at most limit vertices that follow the
+	// given cursor, along with the cursor for the next page.
+	ListVerticesPage(cursor string, limit int) ([]K, string, error)
+
+	// ListEdgesPage should return at most limit edges that follow the given
+	// cursor, along with the cursor for the next page.
+	ListEdgesPage(cursor string, limit int) ([]Edge[K], string, error)
+}
+
+// canonicalEdgePager is implemented by stores that can page through the edges
+// of an undirected graph while skipping one direction of each edge themselves,
+// such as the memoryStore. Unlike filtering the pages of ListEdgesPage, this
+// also works for both directions of an edge ending up on different pages.
+type canonicalEdgePager[K comparable] interface {
+	listCanonicalEdgesPage(cursor string, limit int) ([]Edge[K], string, error)
+}
+
+// CycleStore is an optional interface that a [Store] may implement to check
+// whether adding an edge would create a cycle itself, for example using a
+// recursive database query. [CreatesCycle] uses this method instead of running
+// a DFS over the graph, which also speeds up adding edges to graphs created
+// with PreventCycles.
+//
+// CreatesCycle should report whether the source vertex is reachable from the
+// target vertex, i.e. whether an edge from source to target would close a
+// cycle. Both vertices are guaranteed to exist and to be distinct.
+type CycleStore[K comparable] interface {
+	CreatesCycle(source, target K) (bool, error)
+}
+
+// BulkStore is an optional interface that a [Store] may implement to add many
+// edges at once, for example within a single database transaction. AddEdgesFrom
+// uses AddEdges to add all edges of the other graph at once.
+//
+// AddEdges should add all given edges. If possible, none of the edges should be
+// added if an error occurs. The vertices of the edges are guaranteed to exist,
+// and the edges are guaranteed not to exist yet.
+type BulkStore[K comparable] interface {
+	AddEdges(edges []Edge[K]) error
+}
+
+// CascadeStore is an optional interface that a [Store] may implement to remove
+// a vertex along with all of its edges at once, for example within a single
+// database transaction. [RemoveVertexWithEdges] uses this method instead of
+// removing each edge separately.
+//
+// RemoveVertexWithEdges should remove the vertex with the given hash and all
+// edges joining it, and return the removed edges. If the vertex doesn't exist,
+// ErrVertexNotFound should be returned. If possible, nothing should be removed
+// if an error occurs.
+type CascadeStore[K comparable] interface {
+	RemoveVertexWithEdges(hash K) ([]Edge[K], error)
+}
+
+// bulkEdges validates the given edges the same way AddEdge does and returns
+// copies of them that can be passed to BulkStore.AddEdges. If bothDirections
+// is true, each edge is checked and returned in both directions, which is how
+// undirected graphs store their edges.
+func bulkEdges[K comparable, T any](store Store[K, T], edges []Edge[K], bothDirections bool) ([]Edge[K], error) {
+	bulk := make([]Edge[K], 0, len(edges))
+	added := make(map[tuple[K]]struct{}, len(edges))
+
+	exists := func(source, target K) (bool, error) {
+		if _, ok := added[tuple[K]{source, target}]; ok {
+			return true, nil
+		}
+		_, err := store.Edge(source, target)
+		if errors.Is(err, ErrEdgeNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	for _, edge := range edges {
+		if _, _, err := store.Vertex(edge.Source); err != nil {
+			return nil, fmt.Errorf("failed to add (%v, %v): source vertex %v: %w", edge.Source, edge.Target, edge.Source, err)
+		}
+
+		if _, _, err := store.Vertex(edge.Target); err != nil {
+			return nil, fmt.Errorf("failed to add (%v, %v): target vertex %v: %w", edge.Source, edge.Target, edge.Target, err)
+		}
+
+		ok, err := exists(edge.Source, edge.Target)
+		if !ok && err == nil && bothDirections {
+			ok, err = exists(edge.Target, edge.Source)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to add (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+		if ok {
+			return nil, &EdgeAlreadyExistsError[K]{Source: edge.Source, Target: edge.Target}
+		}
+
+		bulk = appendEdgeCopy(bulk, edge, bothDirections)
+
+		added[tuple[K]{edge.Source, edge.Target}] = struct{}{}
+		if bothDirections {
+			added[tuple[K]{edge.Target, edge.Source}] = struct{}{}
+		}
+	}
+
+	return bulk, nil
+}
+
+// trustedEdges returns copies of the given edges that can be passed to
+// BulkStore.AddEdges without validating them. If bothDirections is true, each
+// edge is returned in both directions.
+func trustedEdges[K comparable](edges []Edge[K], bothDirections bool) []Edge[K] {
+	capacity := len(edges)
+	if bothDirections {
+		capacity *= 2
+	}
+
+	copied := make([]Edge[K], 0, capacity)
+
+	for _, edge := range edges {
+		copied = appendEdgeCopy(copied, edge, bothDirections)
+	}
+
+	return copied
+}
+
+// appendEdgeCopy appends a copy of the given edge to edges. If bothDirections
+// is true, the reversed edge is appended as well.
+func appendEdgeCopy[K comparable](edges []Edge[K], edge Edge[K], bothDirections bool) []Edge[K] {
+	source, target, copyProperties := copyEdge(edge)
+	copied := Edge[K]{
+		Source: source,
+		Target: target,
+		Properties: EdgeProperties{
+			Attributes: make(map[string]string),
+		},
+	}
+	copyProperties(&copied.Properties)
+
+	edges = append(edges, copied)
+
+	if bothDirections {
+		reversed := copied
+		reversed.Source, reversed.Target = target, source
+		edges = append(edges, reversed)
+	}
+
+	return edges
+}
+
+// trustedEdgeAdder is implemented by graphs that can add edges without
+// validating them, see directed.addEdgesTrusted.
+type trustedEdgeAdder[K comparable] interface {
+	addEdgesTrusted(edges []Edge[K]) error
+}
+
+// neighborFunc calls yield for each edge joining the vertex with the given hash
+// and one of its neighbors. If the vertex doesn't exist, ErrVertexNotFound is
+// returned. The yield function must not access the graph.
+type neighborFunc[K comparable] func(hash K, yield func(neighbor K, edge Edge[K])) error
+
+// neighborEdge is a neighbor of a vertex along with the edge joining both.
+type neighborEdge[K comparable] struct {
+	hash K
+	edge Edge[K]
+}
+
+// collectNeighbors returns the neighbors passed to yield by neighborsOf. Unlike
+// within yield, the graph may be accessed while processing them, which is
+// required when calling a user-provided function such as a WeightFunc.
+func collectNeighbors[K comparable](neighborsOf neighborFunc[K], hash K) ([]neighborEdge[K], error) {
+	neighbors := make([]neighborEdge[K], 0)
+
+	err := neighborsOf(hash, func(neighbor K, edge Edge[K]) {
+		neighbors = append(neighbors, neighborEdge[K]{hash: neighbor, edge: edge})
+	})
+
+	return neighbors, err
+}
+
+// pairCounter is implemented by stores that keep track of the number of vertex
+// pairs joined by an edge, such as the memoryStore. Undirected graphs use it to
+// compute their size, which also counts self-loops correctly.
+type pairCounter interface {
+	edgePairCount() (int, error)
+}
+
+// edgeVisitor is implemented by stores that can pass the edges of a vertex to a
+// function without copying them, such as the memoryStore.
+type edgeVisitor[K comparable] interface {
+	visitOutEdges(sourceHash K, yield func(target K, edge Edge[K])) error
+	visitInEdges(targetHash K, yield func(source K, edge Edge[K])) error
+}
+
+// successorsFunc returns a neighborFunc for the outgoing edges of a vertex. If the
+// store of the graph implements NeighborStore, the edges are queried on demand.
+// Otherwise, the adjacency map of the graph is computed once. For graphs
+// created by Cached, the cached adjacency map is used without copying it.
+func successorsFunc[K comparable, T any](g Graph[K, T]) (neighborFunc[K], error) {
+	if store, ok := storeOf(g); ok {
+		if visitor, ok := store.(edgeVisitor[K]); ok {
+			return visitor.visitOutEdges, nil
+		}
+	}
+
+	if c, ok := g.(*cachedGraph[K, T]); ok {
+		adjacencyMap, err := c.sharedAdjacencyMap()
+		if err != nil {
+			return nil, fmt.Errorf("could not get adjacency map: %w", err)
+		}
+
+		return func(hash K, yield func(K, Edge[K])) error {
+			return visitEdges(adjacencyMap, hash, yield)
+		}, nil
+	}
+
+	if neighborStore, ok := neighborStoreOf(g); ok {
+		return func(hash K, yield func(K, Edge[K])) error {
+			edges, err := neighborStore.EdgesBySource(hash)
+			if err != nil {
+				return err
+			}
+			for _, edge := range edges {
+				yield(edge.Target, edge)
+			}
+			return nil
+		}, nil
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("could not get adjacency map: %w", err)
+	}
+
+	return func(hash K, yield func(K, Edge[K])) error {
+		return visitEdges(adjacencyMap, hash, yield)
+	}, nil
+}
+
+// predecessorsFunc returns a neighborFunc for the ingoing edges of a vertex. It is
+// the counterpart to successorsFunc and uses the predecessor map as a fallback.
+func predecessorsFunc[K comparable, T any](g Graph[K, T]) (neighborFunc[K], error) {
+	if store, ok := storeOf(g); ok {
+		if visitor, ok := store.(edgeVisitor[K]); ok {
+			return visitor.visitInEdges, nil
+		}
+	}
+
+	if c, ok := g.(*cachedGraph[K, T]); ok {
+		predecessorMap, err := c.sharedPredecessorMap()
+		if err != nil {
+			return nil, fmt.Errorf("could not get predecessor map: %w", err)
+		}
+
+		return func(hash K, yield func(K, Edge[K])) error {
+			return visitEdges(predecessorMap, hash, yield)
+		}, nil
+	}
+
+	if neighborStore, ok := neighborStoreOf(g); ok {
+		return func(hash K, yield func(K, Edge[K])) error {
+			edges, err := neighborStore.EdgesByTarget(hash)
+			if err != nil {
+				return err
+			}
+			for _, edge := range edges {
+				yield(edge.Source, edge)
+			}
+			return nil
+		}, nil
+	}
+
+	predecessorMap, err := g.PredecessorMap()
+	if err != nil {
+		return nil, fmt.Errorf("could not get predecessor map: %w", err)
+	}
+
+	return func(hash K, yield func(K, Edge[K])) error {
+		return visitEdges(predecessorMap, hash, yield)
+	}, nil
+}
+
+func visitEdges[K comparable](m map[K]map[K]Edge[K], hash K, yield func(K, Edge[K])) error {
+	edges, ok := m[hash]
+	if !ok {
+		return &VertexNotFoundError[K]{Hash: hash}
+	}
+
+	for neighbor, edge := range edges {
+		yield(neighbor, edge)
+	}
+
+	return nil
+}
+
+// neighborStoreOf returns the store of the given graph if it implements
+// NeighborStore.
+func neighborStoreOf[K comparable, T any](g Graph[K, T]) (NeighborStore[K], bool) {
+	store, ok := storeOf(g)
+	if !ok {
+		return nil, false
+	}
+
+	neighborStore, ok := store.(NeighborStore[K])
+
+	return neighborStore, ok
+}
+
+// storeOf returns the store of the given graph if it is one of the graph
+// implementations of this library.
+func storeOf[K comparable, T any](g Graph[K, T]) (Store[K, T], bool) {
+	switch g := g.(type) {
+	case *directed[K, T]:
+		return g.store, true
+	case *undirected[K, T]:
+		return g.store, true
+	case *cachedGraph[K, T]:
+		return storeOf(g.Graph)
+	default:
+		return nil, false
+	}
+}
+
+// vertexHashes returns the hashes of all vertices in the graph. If possible,
+// they are obtained from the store directly instead of the adjacency map.
+func vertexHashes[K comparable, T any](g Graph[K, T]) ([]K, error) {
+	if store, ok := storeOf(g); ok {
+		var hashes []K
+		err := iterVertexHashes(store, func(hash K) bool {
+			hashes = append(hashes, hash)
+			return true
+		})
+		return hashes, err
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]K, 0, len(adjacencyMap))
+	for hash := range adjacencyMap {
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
 type memoryStore[K comparable, T any] struct {
 	lock             sync.RWMutex
 	vertices         map[K]T
@@ -77,15 +521,62 @@ type memoryStore[K comparable, T any] struct {
 	// these edges themselves are stored in maps whose keys are the hashes of the target vertices.
 	outEdges map[K]map[K]Edge[K] // source -> target
 	inEdges  map[K]map[K]Edge[K] // target -> source
+
+	// edgeCount is the number of stored edges. pairCount is the number of
+	// vertex pairs joined by at least one edge, regardless of the direction,
+	// which is the number of edges of an undirected graph that stores each
+	// edge in both directions. Both are only modified by insertEdge and
+	// deleteEdge, so that they always match the stored edges.
 	edgeCount int
+	pairCount int
+
+	// vertexOrder and edgeOrder keep track of the insertion order of vertices
+	// and edges, which is used as a stable order for paging. They are nil
+	// until a page is requested for the first time, so that stores that are
+	// never paged don't have to maintain them. See trackOrder.
+	vertexOrder *sequence[K]
+	edgeOrder   *sequence[tuple[K]]
+
+	// shared indicates that the maps and sequences above are shared with
+	// another store created by fork. They must be copied before modifying them.
+	shared bool
+
+	// readOnly indicates that the store is a view created by View, which
+	// can't be modified.
+	readOnly bool
+
+	// degree is the expected number of edges per vertex, which is used to
+	// presize the maps in outEdges and inEdges.
+	degree int
 }
 
 func newMemoryStore[K comparable, T any]() Store[K, T] {
+	return NewMemoryStoreWithCapacity[K, T](0, 0)
+}
+
+// NewMemoryStoreWithCapacity creates the in-memory store used by [New] with
+// enough space for the given number of vertices and edges. Presizing the store
+// avoids the cost of growing it incrementally when building large graphs whose
+// size is known in advance:
+//
+//	store := graph.NewMemoryStoreWithCapacity[int, int](1000000, 10000000)
+//	g := graph.NewWithStore(graph.IntHash, store, graph.Directed())
+//
+// Note that undirected graphs store each edge twice, so edges should be twice
+// the number of edges in an undirected graph. [NewWithCapacity] takes care of
+// this. The store may grow beyond the given capacity.
+func NewMemoryStoreWithCapacity[K comparable, T any](vertices, edges int) Store[K, T] {
+	degree := 0
+	if vertices > 0 {
+		degree = (edges + vertices - 1) / vertices
+	}
+
 	return &memoryStore[K, T]{
-		vertices:         make(map[K]T),
-		vertexProperties: make(map[K]VertexProperties),
-		outEdges:         make(map[K]map[K]Edge[K]),
-		inEdges:          make(map[K]map[K]Edge[K]),
+		vertices:         make(map[K]T, vertices),
+		vertexProperties: make(map[K]VertexProperties, vertices),
+		outEdges:         make(map[K]map[K]Edge[K], vertices),
+		inEdges:          make(map[K]map[K]Edge[K], vertices),
+		degree:           degree,
 	}
 }
 
@@ -93,16 +584,125 @@ func (s *memoryStore[K, T]) AddVertex(k K, t T, p VertexProperties) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	if err := s.detach(); err != nil {
+		return err
+	}
+
 	if _, ok := s.vertices[k]; ok {
-		return ErrVertexAlreadyExists
+		return &VertexAlreadyExistsError[K]{Hash: k}
 	}
 
 	s.vertices[k] = t
 	s.vertexProperties[k] = p
 
+	if s.vertexOrder != nil {
+		s.vertexOrder.add(k)
+	}
+
 	return nil
 }
 
+// fork returns a store that shares all vertices and edges with s. Forking is an
+// O(1) operation: Both stores are marked as shared, and each of them copies the
+// vertices and edges when it is modified for the first time.
+func (s *memoryStore[K, T]) fork() *memoryStore[K, T] {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.shared = true
+
+	return &memoryStore[K, T]{
+		vertices:         s.vertices,
+		vertexProperties: s.vertexProperties,
+		outEdges:         s.outEdges,
+		inEdges:          s.inEdges,
+		edgeCount:        s.edgeCount,
+		pairCount:        s.pairCount,
+		vertexOrder:      s.vertexOrder,
+		edgeOrder:        s.edgeOrder,
+		shared:           true,
+		degree:           s.degree,
+	}
+}
+
+// detach copies the vertices and edges if they are shared with another store,
+// so that they can be modified. If the store is a read-only view, detach
+// returns ErrGraphFrozen instead. The caller must hold the write lock.
+func (s *memoryStore[K, T]) detach() error {
+	if s.readOnly {
+		return ErrGraphFrozen
+	}
+
+	if !s.shared {
+		return nil
+	}
+
+	vertices := make(map[K]T, len(s.vertices))
+	vertexProperties := make(map[K]VertexProperties, len(s.vertexProperties))
+
+	for hash, value := range s.vertices {
+		vertices[hash] = value
+	}
+
+	for hash, properties := range s.vertexProperties {
+		properties.Attributes = copyAttributes(properties.Attributes)
+		properties.Weights = copyWeights(properties.Weights)
+		vertexProperties[hash] = properties
+	}
+
+	s.vertices = vertices
+	s.vertexProperties = vertexProperties
+	s.outEdges = copyEdgeMap(s.outEdges)
+	s.inEdges = copyEdgeMap(s.inEdges)
+	if s.vertexOrder != nil {
+		s.vertexOrder = s.vertexOrder.clone()
+		s.edgeOrder = s.edgeOrder.clone()
+	}
+	s.shared = false
+
+	return nil
+}
+
+func copyEdgeMap[K comparable](m map[K]map[K]Edge[K]) map[K]map[K]Edge[K] {
+	c := make(map[K]map[K]Edge[K], len(m))
+
+	for hash, edges := range m {
+		c[hash] = make(map[K]Edge[K], len(edges))
+		for adjacency, edge := range edges {
+			edge.Properties.Attributes = copyAttributes(edge.Properties.Attributes)
+			c[hash][adjacency] = edge
+		}
+	}
+
+	return c
+}
+
+func copyAttributes(attributes map[string]string) map[string]string {
+	if attributes == nil {
+		return nil
+	}
+
+	c := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		c[key] = value
+	}
+
+	return c
+}
+
+func copyWeights(weights map[string]float64) map[string]float64 {
+	if weights == nil {
+		return nil
+	}
+
+	c := make(map[string]float64, len(weights))
+	for name, weight := range weights {
+		c[name] = weight
+	}
+
+	return c
+}
+
 func (s *memoryStore[K, T]) ListVertices() ([]K, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -128,7 +728,7 @@ func (s *memoryStore[K, T]) Vertex(k K) (T, VertexProperties, error) {
 
 	v, ok := s.vertices[k]
 	if !ok {
-		return v, VertexProperties{}, ErrVertexNotFound
+		return v, VertexProperties{}, &VertexNotFoundError[K]{Hash: k}
 	}
 
 	p := s.vertexProperties[k]
@@ -137,23 +737,27 @@ func (s *memoryStore[K, T]) Vertex(k K) (T, VertexProperties, error) {
 }
 
 func (s *memoryStore[K, T]) RemoveVertex(k K) error {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.detach(); err != nil {
+		return err
+	}
 
 	if _, ok := s.vertices[k]; !ok {
-		return ErrVertexNotFound
+		return &VertexNotFoundError[K]{Hash: k}
 	}
 
 	if edges, ok := s.inEdges[k]; ok {
 		if len(edges) > 0 {
-			return ErrVertexHasEdges
+			return &VertexHasEdgesError[K]{Hash: k}
 		}
 		delete(s.inEdges, k)
 	}
 
 	if edges, ok := s.outEdges[k]; ok {
 		if len(edges) > 0 {
-			return ErrVertexHasEdges
+			return &VertexHasEdgesError[K]{Hash: k}
 		}
 		delete(s.outEdges, k)
 	}
@@ -161,42 +765,153 @@ func (s *memoryStore[K, T]) RemoveVertex(k K) error {
 	delete(s.vertices, k)
 	delete(s.vertexProperties, k)
 
+	if s.vertexOrder != nil {
+		s.vertexOrder.remove(k)
+	}
+
 	return nil
 }
 
+// RemoveVertexWithEdges removes the vertex with the given hash and all of its
+// edges while holding the lock, so that other goroutines never observe the
+// vertex with only some of its edges.
+func (s *memoryStore[K, T]) RemoveVertexWithEdges(k K) ([]Edge[K], error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.detach(); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.vertices[k]; !ok {
+		return nil, &VertexNotFoundError[K]{Hash: k}
+	}
+
+	removed := make([]Edge[K], 0, len(s.outEdges[k])+len(s.inEdges[k]))
+
+	for target := range s.outEdges[k] {
+		if edge, ok := s.deleteEdge(k, target); ok {
+			removed = append(removed, edge)
+		}
+	}
+
+	for source := range s.inEdges[k] {
+		// A self-loop has already been removed as an outgoing edge.
+		if edge, ok := s.deleteEdge(source, k); ok {
+			removed = append(removed, edge)
+		}
+	}
+
+	delete(s.outEdges, k)
+	delete(s.inEdges, k)
+	delete(s.vertices, k)
+	delete(s.vertexProperties, k)
+
+	if s.vertexOrder != nil {
+		s.vertexOrder.remove(k)
+	}
+
+	return removed, nil
+}
+
 func (s *memoryStore[K, T]) AddEdge(sourceHash, targetHash K, edge Edge[K]) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	if err := s.detach(); err != nil {
+		return err
+	}
+
+	s.insertEdge(sourceHash, targetHash, edge)
+
+	return nil
+}
+
+// insertEdge stores the given edge and updates the edge counts. If the edge
+// already exists, it is replaced and the counts remain unchanged. The caller
+// must hold the write lock and must have detached the store.
+func (s *memoryStore[K, T]) insertEdge(sourceHash, targetHash K, edge Edge[K]) {
 	if _, ok := s.outEdges[sourceHash]; !ok {
-		s.outEdges[sourceHash] = make(map[K]Edge[K])
+		s.outEdges[sourceHash] = make(map[K]Edge[K], s.degree)
 	}
 
+	_, exists := s.outEdges[sourceHash][targetHash]
+
 	s.outEdges[sourceHash][targetHash] = edge
 
 	if _, ok := s.inEdges[targetHash]; !ok {
-		s.inEdges[targetHash] = make(map[K]Edge[K])
+		s.inEdges[targetHash] = make(map[K]Edge[K], s.degree)
 	}
 
 	s.inEdges[targetHash][sourceHash] = edge
 
+	if exists {
+		return
+	}
+
 	s.edgeCount++
+	if !s.hasReverseEdge(sourceHash, targetHash) {
+		s.pairCount++
+	}
 
-	return nil
+	if s.edgeOrder != nil {
+		s.edgeOrder.add(tuple[K]{source: sourceHash, target: targetHash})
+	}
+}
+
+// deleteEdge removes the edge between the given vertices and updates the edge
+// counts. It returns the removed edge and whether the edge existed. The caller
+// must hold the write lock and must have detached the store.
+func (s *memoryStore[K, T]) deleteEdge(sourceHash, targetHash K) (Edge[K], bool) {
+	edge, ok := s.outEdges[sourceHash][targetHash]
+	if !ok {
+		return Edge[K]{}, false
+	}
+
+	delete(s.inEdges[targetHash], sourceHash)
+	delete(s.outEdges[sourceHash], targetHash)
+
+	s.edgeCount--
+	if !s.hasReverseEdge(sourceHash, targetHash) {
+		s.pairCount--
+	}
+
+	if s.edgeOrder != nil {
+		s.edgeOrder.remove(tuple[K]{source: sourceHash, target: targetHash})
+	}
+
+	return edge, true
+}
+
+// hasReverseEdge reports whether the edge from target to source counts towards
+// the pair joining source and target. This isn't the case for self-loops,
+// which form a pair on their own.
+func (s *memoryStore[K, T]) hasReverseEdge(sourceHash, targetHash K) bool {
+	if sourceHash == targetHash {
+		return false
+	}
+
+	_, ok := s.outEdges[targetHash][sourceHash]
+
+	return ok
 }
 
 func (s *memoryStore[K, T]) UpdateEdge(sourceHash, targetHash K, edge Edge[K]) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	if err := s.detach(); err != nil {
+		return err
+	}
+
 	targetEdges, ok := s.outEdges[sourceHash]
 	if !ok {
-		return ErrEdgeNotFound
+		return &EdgeNotFoundError[K]{Source: sourceHash, Target: targetHash}
 	}
 
 	_, ok = targetEdges[targetHash]
 	if !ok {
-		return ErrEdgeNotFound
+		return &EdgeNotFoundError[K]{Source: sourceHash, Target: targetHash}
 	}
 
 	s.outEdges[sourceHash][targetHash] = edge
@@ -209,10 +924,11 @@ func (s *memoryStore[K, T]) RemoveEdge(sourceHash, targetHash K) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	delete(s.inEdges[targetHash], sourceHash)
-	delete(s.outEdges[sourceHash], targetHash)
+	if err := s.detach(); err != nil {
+		return err
+	}
 
-	s.edgeCount--
+	s.deleteEdge(sourceHash, targetHash)
 
 	return nil
 }
@@ -223,12 +939,12 @@ func (s *memoryStore[K, T]) Edge(sourceHash, targetHash K) (Edge[K], error) {
 
 	sourceEdges, ok := s.outEdges[sourceHash]
 	if !ok {
-		return Edge[K]{}, ErrEdgeNotFound
+		return Edge[K]{}, &EdgeNotFoundError[K]{Source: sourceHash, Target: targetHash}
 	}
 
 	edge, ok := sourceEdges[targetHash]
 	if !ok {
-		return Edge[K]{}, ErrEdgeNotFound
+		return Edge[K]{}, &EdgeNotFoundError[K]{Source: sourceHash, Target: targetHash}
 	}
 
 	return edge, nil
@@ -241,6 +957,15 @@ func (s *memoryStore[K, T]) EdgeCount() (int, error) {
 	return s.edgeCount, nil
 }
 
+// edgePairCount returns the number of vertex pairs joined by an edge in either
+// direction. It implements pairCounter.
+func (s *memoryStore[K, T]) edgePairCount() (int, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.pairCount, nil
+}
+
 func (s *memoryStore[K, T]) ListEdges() ([]Edge[K], error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -254,21 +979,260 @@ func (s *memoryStore[K, T]) ListEdges() ([]Edge[K], error) {
 	return res, nil
 }
 
-// CreatesCycle is a fastpath version of [CreatesCycle] that avoids calling
-// [PredecessorMap], which generates large amounts of garbage to collect.
-//
-// Because CreatesCycle doesn't need to modify the PredecessorMap, we can use
-// inEdges instead to compute the same thing without creating any copies.
+func (s *memoryStore[K, T]) IterVertices(yield func(hash K, value T, properties VertexProperties) bool) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for k, v := range s.vertices {
+		if !yield(k, v, s.vertexProperties[k]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *memoryStore[K, T]) IterEdges(yield func(edge Edge[K]) bool) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, edges := range s.outEdges {
+		for _, edge := range edges {
+			if !yield(edge) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *memoryStore[K, T]) EdgesBySource(sourceHash K) ([]Edge[K], error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if _, ok := s.vertices[sourceHash]; !ok {
+		return nil, &VertexNotFoundError[K]{Hash: sourceHash}
+	}
+
+	edges := make([]Edge[K], 0, len(s.outEdges[sourceHash]))
+	for _, edge := range s.outEdges[sourceHash] {
+		edges = append(edges, edge)
+	}
+
+	return edges, nil
+}
+
+func (s *memoryStore[K, T]) EdgesByTarget(targetHash K) ([]Edge[K], error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if _, ok := s.vertices[targetHash]; !ok {
+		return nil, &VertexNotFoundError[K]{Hash: targetHash}
+	}
+
+	edges := make([]Edge[K], 0, len(s.inEdges[targetHash]))
+	for _, edge := range s.inEdges[targetHash] {
+		edges = append(edges, edge)
+	}
+
+	return edges, nil
+}
+
+// visitOutEdges passes the outgoing edges of the given vertex to yield while
+// holding the read lock. Unlike EdgesBySource, it doesn't allocate a slice.
+func (s *memoryStore[K, T]) visitOutEdges(sourceHash K, yield func(K, Edge[K])) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if _, ok := s.vertices[sourceHash]; !ok {
+		return &VertexNotFoundError[K]{Hash: sourceHash}
+	}
+
+	for target, edge := range s.outEdges[sourceHash] {
+		yield(target, edge)
+	}
+
+	return nil
+}
+
+// visitInEdges is the counterpart to visitOutEdges for the ingoing edges.
+func (s *memoryStore[K, T]) visitInEdges(targetHash K, yield func(K, Edge[K])) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if _, ok := s.vertices[targetHash]; !ok {
+		return &VertexNotFoundError[K]{Hash: targetHash}
+	}
+
+	for source, edge := range s.inEdges[targetHash] {
+		yield(source, edge)
+	}
+
+	return nil
+}
+
+// ListVerticesPage returns a page of the vertices in insertion order. The
+// cursor is the sequence number of the last vertex of the previous page.
+func (s *memoryStore[K, T]) ListVerticesPage(cursor string, limit int) ([]K, string, error) {
+	after, err := parseSequenceCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.trackOrder()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	hashes, last, more := s.vertexOrder.after(after, limit)
+
+	return hashes, sequenceCursor(last, more), nil
+}
+
+// ListEdgesPage returns a page of the edges in insertion order. The cursor is
+// the sequence number of the last edge of the previous page.
+func (s *memoryStore[K, T]) ListEdgesPage(cursor string, limit int) ([]Edge[K], string, error) {
+	after, err := parseSequenceCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.trackOrder()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	keys, last, more := s.edgeOrder.after(after, limit)
+
+	edges := make([]Edge[K], len(keys))
+	for i, key := range keys {
+		edges[i] = s.outEdges[key.source][key.target]
+	}
+
+	return edges, sequenceCursor(last, more), nil
+}
+
+// trackOrder starts keeping track of the order of vertices and edges unless
+// this is already the case. The vertices and edges that already exist are
+// sorted by their hashes, and all vertices and edges added afterwards are
+// appended in insertion order. Once the order is tracked, it is tracked for the
+// lifetime of the store.
+func (s *memoryStore[K, T]) trackOrder() {
+	s.lock.RLock()
+	tracked := s.vertexOrder != nil
+	s.lock.RUnlock()
+
+	if tracked {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Another goroutine may have started tracking the order in the meantime.
+	if s.vertexOrder != nil {
+		return
+	}
+
+	hashes := s.sortedVertices()
+	s.vertexOrder = newSequenceWithCapacity[K](len(hashes))
+
+	for _, hash := range hashes {
+		s.vertexOrder.add(hash)
+	}
+
+	keys := s.sortedEdgeKeys()
+	s.edgeOrder = newSequenceWithCapacity[tuple[K]](len(keys))
+
+	for _, key := range keys {
+		s.edgeOrder.add(key)
+	}
+}
+
+// sortedVertices returns the vertex hashes sorted using compareHashes. The
+// caller must hold the lock.
+func (s *memoryStore[K, T]) sortedVertices() []K {
+	hashes := make([]K, 0, len(s.vertices))
+
+	for hash := range s.vertices {
+		hashes = append(hashes, hash)
+	}
+
+	sortHashes(hashes)
+
+	return hashes
+}
+
+// sortedEdgeKeys returns the source and target hashes of all edges, sorted by
+// their source and then by their target hash. The caller must hold the lock.
+func (s *memoryStore[K, T]) sortedEdgeKeys() []tuple[K] {
+	keys := make([]tuple[K], 0, s.edgeCount)
+
+	for source, edges := range s.outEdges {
+		for target := range edges {
+			keys = append(keys, tuple[K]{source: source, target: target})
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if c := compareHashes(keys[i].source, keys[j].source); c != 0 {
+			return c < 0
+		}
+		return compareHashes(keys[i].target, keys[j].target) < 0
+	})
+
+	return keys
+}
+
+// listCanonicalEdgesPage implements canonicalEdgePager. It keeps the same
+// direction of each edge as canonicalDirection. If both hashes are considered
+// equal, the direction that has been added first is kept, which doesn't depend
+// on the page boundaries.
+func (s *memoryStore[K, T]) listCanonicalEdgesPage(cursor string, limit int) ([]Edge[K], string, error) {
+	after, err := parseSequenceCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.trackOrder()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	keys, last, more := s.edgeOrder.afterFunc(after, limit, func(key tuple[K]) bool {
+		switch compareHashes(key.source, key.target) {
+		case -1:
+			return true
+		case 1:
+			return false
+		}
+
+		reverse, ok := s.edgeOrder.numbers[tuple[K]{source: key.target, target: key.source}]
+
+		return !ok || key.source == key.target || s.edgeOrder.numbers[key] < reverse
+	})
+
+	edges := make([]Edge[K], len(keys))
+	for i, key := range keys {
+		edges[i] = s.outEdges[key.source][key.target]
+	}
+
+	return edges, sequenceCursor(last, more), nil
+}
+
+// CreatesCycle implements [CycleStore]. It walks the inEdges directly instead
+// of building a PredecessorMap, which generates large amounts of garbage.
 func (s *memoryStore[K, T]) CreatesCycle(source, target K) (bool, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
 	if _, ok := s.vertices[source]; !ok {
-		return false, fmt.Errorf("could not get vertex with hash %v", source)
+		return false, &VertexNotFoundError[K]{Hash: source}
 	}
 
 	if _, ok := s.vertices[target]; !ok {
-		return false, fmt.Errorf("could not get vertex with hash %v", target)
+		return false, &VertexNotFoundError[K]{Hash: target}
 	}
 
 	if source == target {
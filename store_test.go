@@ -60,3 +60,563 @@ func TestRemoveEdge(t *testing.T) {
 		}
 	})
 }
+
+// iteratingStore is a store that only supports streaming its vertices and
+// edges. Calling ListVertices or ListEdges results in a panic.
+type iteratingStore[K comparable, T any] struct {
+	Store[K, T]
+}
+
+func (s iteratingStore[K, T]) ListVertices() ([]K, error) {
+	panic("ListVertices must not be called")
+}
+
+func (s iteratingStore[K, T]) ListEdges() ([]Edge[K], error) {
+	panic("ListEdges must not be called")
+}
+
+func (s iteratingStore[K, T]) IterVertices(yield func(K, T, VertexProperties) bool) error {
+	return s.Store.(VertexIterator[K, T]).IterVertices(yield)
+}
+
+func (s iteratingStore[K, T]) IterEdges(yield func(Edge[K]) bool) error {
+	return s.Store.(EdgeIterator[K]).IterEdges(yield)
+}
+
+func TestIteratingStore(t *testing.T) {
+	tests := map[string]struct {
+		traits                 []func(*Traits)
+		expectedAdjacencyMap   map[int]map[int]Edge[int]
+		expectedPredecessorMap map[int]map[int]Edge[int]
+		expectedEdges          int
+	}{
+		"directed graph": {
+			traits: []func(*Traits){Directed()},
+			expectedAdjacencyMap: map[int]map[int]Edge[int]{
+				1: {2: {Source: 1, Target: 2}},
+				2: {3: {Source: 2, Target: 3}},
+				3: {},
+			},
+			expectedPredecessorMap: map[int]map[int]Edge[int]{
+				1: {},
+				2: {1: {Source: 1, Target: 2}},
+				3: {2: {Source: 2, Target: 3}},
+			},
+			expectedEdges: 2,
+		},
+		"undirected graph": {
+			expectedAdjacencyMap: map[int]map[int]Edge[int]{
+				1: {2: {Source: 1, Target: 2}},
+				2: {1: {Source: 2, Target: 1}, 3: {Source: 2, Target: 3}},
+				3: {2: {Source: 3, Target: 2}},
+			},
+			expectedPredecessorMap: map[int]map[int]Edge[int]{
+				1: {2: {Source: 1, Target: 2}},
+				2: {1: {Source: 2, Target: 1}, 3: {Source: 2, Target: 3}},
+				3: {2: {Source: 3, Target: 2}},
+			},
+			expectedEdges: 2,
+		},
+	}
+
+	edgesAreEqual := func(a, b Edge[int]) bool {
+		return a.Source == b.Source && a.Target == b.Target
+	}
+
+	for name, test := range tests {
+		store := iteratingStore[int, int]{newMemoryStore[int, int]()}
+		g := NewWithStore(IntHash, Store[int, int](store), test.traits...)
+
+		for i := 1; i <= 3; i++ {
+			_ = g.AddVertex(i)
+		}
+
+		_ = g.AddEdge(1, 2)
+		_ = g.AddEdge(2, 3)
+
+		adjacencyMap, err := g.AdjacencyMap()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if !adjacencyMapsAreEqual(test.expectedAdjacencyMap, adjacencyMap, edgesAreEqual) {
+			t.Errorf("%s: adjacency map expectancy doesn't match: expected %v, got %v", name, test.expectedAdjacencyMap, adjacencyMap)
+		}
+
+		predecessorMap, err := g.PredecessorMap()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if !adjacencyMapsAreEqual(test.expectedPredecessorMap, predecessorMap, edgesAreEqual) {
+			t.Errorf("%s: predecessor map expectancy doesn't match: expected %v, got %v", name, test.expectedPredecessorMap, predecessorMap)
+		}
+
+		edges, err := g.Edges()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if len(edges) != test.expectedEdges {
+			t.Errorf("%s: number of edges doesn't match: expected %v, got %v", name, test.expectedEdges, len(edges))
+		}
+	}
+}
+
+func TestMemoryStore_EdgesBySource(t *testing.T) {
+	store := newMemoryStore[int, int]().(*memoryStore[int, int])
+
+	for i := 1; i <= 3; i++ {
+		_ = store.AddVertex(i, i, VertexProperties{})
+	}
+
+	_ = store.AddEdge(1, 2, Edge[int]{Source: 1, Target: 2})
+	_ = store.AddEdge(1, 3, Edge[int]{Source: 1, Target: 3})
+
+	edges, err := store.EdgesBySource(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(edges) != 2 {
+		t.Errorf("number of edges doesn't match: expected %v, got %v", 2, len(edges))
+	}
+
+	edges, err = store.EdgesByTarget(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(edges) != 1 || edges[0].Source != 1 {
+		t.Errorf("edges don't match: expected %v, got %v", []Edge[int]{{Source: 1, Target: 3}}, edges)
+	}
+
+	if _, err := store.EdgesBySource(4); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrVertexNotFound, err)
+	}
+
+	if _, err := store.EdgesByTarget(4); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrVertexNotFound, err)
+	}
+}
+
+func TestMemoryStore_EdgeCount(t *testing.T) {
+	store := newMemoryStore[int, int]().(*memoryStore[int, int])
+
+	for i := 1; i <= 3; i++ {
+		_ = store.AddVertex(i, i, VertexProperties{})
+	}
+
+	// The steps build on each other, so they run in order.
+	steps := []struct {
+		name              string
+		modify            func()
+		expectedEdgeCount int
+		expectedPairCount int
+	}{
+		{
+			name:              "add edge",
+			modify:            func() { _ = store.AddEdge(1, 2, Edge[int]{Source: 1, Target: 2}) },
+			expectedEdgeCount: 1,
+			expectedPairCount: 1,
+		},
+		{
+			name:              "add existing edge",
+			modify:            func() { _ = store.AddEdge(1, 2, Edge[int]{Source: 1, Target: 2}) },
+			expectedEdgeCount: 1,
+			expectedPairCount: 1,
+		},
+		{
+			name:              "add reversed edge",
+			modify:            func() { _ = store.AddEdge(2, 1, Edge[int]{Source: 2, Target: 1}) },
+			expectedEdgeCount: 2,
+			expectedPairCount: 1,
+		},
+		{
+			name:              "add self-loop",
+			modify:            func() { _ = store.AddEdge(3, 3, Edge[int]{Source: 3, Target: 3}) },
+			expectedEdgeCount: 3,
+			expectedPairCount: 2,
+		},
+		{
+			name:              "remove missing edge",
+			modify:            func() { _ = store.RemoveEdge(1, 3) },
+			expectedEdgeCount: 3,
+			expectedPairCount: 2,
+		},
+		{
+			name:              "remove edge",
+			modify:            func() { _ = store.RemoveEdge(1, 2) },
+			expectedEdgeCount: 2,
+			expectedPairCount: 2,
+		},
+		{
+			name:              "remove vertex with edges",
+			modify:            func() { _, _ = store.RemoveVertexWithEdges(1) },
+			expectedEdgeCount: 1,
+			expectedPairCount: 1,
+		},
+	}
+
+	for _, step := range steps {
+		step.modify()
+
+		if count, _ := store.EdgeCount(); count != step.expectedEdgeCount {
+			t.Errorf("%s: edge count doesn't match: expected %v, got %v", step.name, step.expectedEdgeCount, count)
+		}
+
+		if count, _ := store.edgePairCount(); count != step.expectedPairCount {
+			t.Errorf("%s: pair count doesn't match: expected %v, got %v", step.name, step.expectedPairCount, count)
+		}
+	}
+}
+
+func TestUndirected_Size(t *testing.T) {
+	g := New(IntHash)
+
+	for i := 1; i <= 3; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	_ = g.AddEdge(1, 2)
+	_ = g.AddEdge(2, 3)
+	_ = g.AddEdge(3, 3)
+
+	if size, _ := g.Size(); size != 3 {
+		t.Errorf("size doesn't match: expected %v, got %v", 3, size)
+	}
+
+	_ = g.RemoveEdge(3, 3)
+	_ = g.RemoveEdge(2, 1)
+
+	if size, _ := g.Size(); size != 1 {
+		t.Errorf("size doesn't match: expected %v, got %v", 1, size)
+	}
+
+	frozen, _ := Freeze(g)
+
+	if size, _ := frozen.Size(); size != 1 {
+		t.Errorf("size of frozen graph doesn't match: expected %v, got %v", 1, size)
+	}
+}
+
+func TestMemoryStore_fork(t *testing.T) {
+	store := newMemoryStore[int, int]().(*memoryStore[int, int])
+
+	for i := 1; i <= 3; i++ {
+		_ = store.AddVertex(i, i, VertexProperties{Attributes: map[string]string{"color": "red"}})
+	}
+
+	_ = store.AddEdge(1, 2, Edge[int]{Source: 1, Target: 2, Properties: EdgeProperties{Attributes: map[string]string{}}})
+
+	fork := store.fork()
+
+	if fork.vertices == nil || !fork.shared || !store.shared {
+		t.Fatalf("expected both stores to be shared")
+	}
+
+	// Modifying the fork must not modify the original store.
+	_ = fork.RemoveEdge(1, 2)
+	_ = fork.AddVertex(4, 4, VertexProperties{})
+
+	if count, _ := store.EdgeCount(); count != 1 {
+		t.Errorf("edge count doesn't match: expected %v, got %v", 1, count)
+	}
+
+	if _, _, err := store.Vertex(4); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrVertexNotFound, err)
+	}
+
+	// Modifying the original store must not modify the fork either.
+	_ = store.AddEdge(2, 3, Edge[int]{Source: 2, Target: 3})
+
+	if _, err := fork.Edge(2, 3); !errors.Is(err, ErrEdgeNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrEdgeNotFound, err)
+	}
+
+	_, properties, _ := store.Vertex(1)
+	properties.Attributes["color"] = "blue"
+
+	if _, properties, _ := fork.Vertex(1); properties.Attributes["color"] != "red" {
+		t.Errorf("attribute doesn't match: expected %v, got %v", "red", properties.Attributes["color"])
+	}
+
+	if count, _ := fork.VertexCount(); count != 4 {
+		t.Errorf("vertex count doesn't match: expected %v, got %v", 4, count)
+	}
+}
+
+func TestNewMemoryStoreWithCapacity(t *testing.T) {
+	store := NewMemoryStoreWithCapacity[int, int](2, 3).(*memoryStore[int, int])
+
+	if store.degree != 2 {
+		t.Errorf("degree doesn't match: expected %v, got %v", 2, store.degree)
+	}
+
+	g := NewWithStore(IntHash, Store[int, int](store), Directed())
+
+	// The store must grow beyond its capacity.
+	for i := 0; i < 10; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	for i := 1; i < 10; i++ {
+		_ = g.AddEdge(0, i)
+	}
+
+	if order, _ := g.Order(); order != 10 {
+		t.Errorf("order doesn't match: expected %v, got %v", 10, order)
+	}
+
+	if size, _ := g.Size(); size != 9 {
+		t.Errorf("size doesn't match: expected %v, got %v", 9, size)
+	}
+
+	if store := NewMemoryStoreWithCapacity[int, int](0, 0).(*memoryStore[int, int]); store.degree != 0 {
+		t.Errorf("degree doesn't match: expected %v, got %v", 0, store.degree)
+	}
+}
+
+func BenchmarkMemoryStore_AddEdge(b *testing.B) {
+	const vertices, edges = 10000, 100000
+
+	build := func(g Graph[int, int]) {
+		for i := 0; i < vertices; i++ {
+			_ = g.AddVertex(i)
+		}
+		for i := 0; i < edges; i++ {
+			_ = g.AddEdge(i%vertices, (i*7+i/vertices+1)%vertices)
+		}
+	}
+
+	b.Run("without capacity", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			build(New(IntHash, Directed()))
+		}
+	})
+
+	b.Run("with capacity", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			build(NewWithCapacity(IntHash, vertices, edges, Directed()))
+		}
+	})
+}
+
+// bulkStore is a store that implements BulkStore and records the edges added
+// in bulk. Adding single edges results in a panic.
+type bulkStore[K comparable, T any] struct {
+	Store[K, T]
+	bulks [][]Edge[K]
+}
+
+func (s *bulkStore[K, T]) AddEdge(K, K, Edge[K]) error {
+	panic("AddEdge must not be called")
+}
+
+func (s *bulkStore[K, T]) AddEdges(edges []Edge[K]) error {
+	s.bulks = append(s.bulks, edges)
+
+	for _, edge := range edges {
+		if err := s.Store.AddEdge(edge.Source, edge.Target, edge); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestBulkStore(t *testing.T) {
+	tests := map[string]struct {
+		traits        []func(*Traits)
+		expectedBulk  int
+		expectedEvent int
+	}{
+		"directed graph": {
+			traits:        []func(*Traits){Directed()},
+			expectedBulk:  2,
+			expectedEvent: 2,
+		},
+		"undirected graph": {
+			expectedBulk:  4,
+			expectedEvent: 2,
+		},
+	}
+
+	for name, test := range tests {
+		source := New(IntHash, test.traits...)
+
+		for i := 1; i <= 3; i++ {
+			_ = source.AddVertex(i)
+		}
+
+		_ = source.AddEdge(1, 2, EdgeWeight(5), EdgeAttribute("color", "red"))
+		_ = source.AddEdge(2, 3)
+
+		store := &bulkStore[int, int]{Store: newMemoryStore[int, int]()}
+		g := NewWithStore(IntHash, Store[int, int](store), test.traits...)
+
+		var events int
+		_, _ = OnAddEdge(g, func(Edge[int]) {
+			events++
+		})
+
+		if err := g.AddVerticesFrom(source); err != nil {
+			t.Fatalf("%s: failed to add vertices: %v", name, err)
+		}
+
+		if err := g.AddEdgesFrom(source); err != nil {
+			t.Fatalf("%s: failed to add edges: %v", name, err)
+		}
+
+		if len(store.bulks) != 1 || len(store.bulks[0]) != test.expectedBulk {
+			t.Errorf("%s: bulks don't match: expected 1 bulk of %v edges, got %v", name, test.expectedBulk, store.bulks)
+		}
+
+		if events != test.expectedEvent {
+			t.Errorf("%s: events don't match: expected %v, got %v", name, test.expectedEvent, events)
+		}
+
+		edge, err := g.Edge(1, 2)
+		if err != nil || edge.Properties.Weight != 5 || edge.Properties.Attributes["color"] != "red" {
+			t.Errorf("%s: edge doesn't match: got %v (error: %v)", name, edge, err)
+		}
+
+		if err := g.AddEdgesFrom(source); !errors.Is(err, ErrEdgeAlreadyExists) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrEdgeAlreadyExists, err)
+		}
+
+		if len(store.bulks) != 1 {
+			t.Errorf("%s: expected no bulk for existing edges, got %v bulks", name, len(store.bulks))
+		}
+	}
+}
+
+// lookupCountingStore is a store that counts the lookups of vertices and edges
+// and hides the optional interfaces of the wrapped store.
+type lookupCountingStore[K comparable, T any] struct {
+	Store[K, T]
+	lookups int
+}
+
+func (s *lookupCountingStore[K, T]) Vertex(hash K) (T, VertexProperties, error) {
+	s.lookups++
+	return s.Store.Vertex(hash)
+}
+
+func (s *lookupCountingStore[K, T]) Edge(sourceHash, targetHash K) (Edge[K], error) {
+	s.lookups++
+	return s.Store.Edge(sourceHash, targetHash)
+}
+
+func TestAddEdgesTrusted(t *testing.T) {
+	tests := map[string]struct {
+		traits       []func(*Traits)
+		bulk         bool
+		expectedBulk int
+	}{
+		"directed graph": {
+			traits: []func(*Traits){Directed()},
+		},
+		"directed graph with BulkStore": {
+			traits:       []func(*Traits){Directed()},
+			bulk:         true,
+			expectedBulk: 2,
+		},
+		"undirected graph": {},
+		"undirected graph with BulkStore": {
+			bulk:         true,
+			expectedBulk: 4,
+		},
+	}
+
+	for name, test := range tests {
+		source := New(IntHash, test.traits...)
+
+		for i := 1; i <= 3; i++ {
+			_ = source.AddVertex(i)
+		}
+
+		_ = source.AddEdge(1, 2, EdgeWeight(5), EdgeAttribute("color", "red"))
+		_ = source.AddEdge(2, 3)
+
+		edges, _ := source.Edges()
+
+		counting := &lookupCountingStore[int, int]{Store: newMemoryStore[int, int]()}
+		bulk := &bulkStore[int, int]{Store: counting}
+
+		var store Store[int, int] = counting
+		if test.bulk {
+			store = bulk
+		}
+
+		g := NewWithStore(IntHash, store, test.traits...)
+		_ = g.AddVerticesFrom(source)
+
+		var events int
+		_, _ = OnAddEdge(g, func(Edge[int]) {
+			events++
+		})
+
+		counting.lookups = 0
+
+		if err := g.(trustedEdgeAdder[int]).addEdgesTrusted(edges); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if counting.lookups != 0 {
+			t.Errorf("%s: expected no lookups, got %v", name, counting.lookups)
+		}
+
+		if test.bulk && (len(bulk.bulks) != 1 || len(bulk.bulks[0]) != test.expectedBulk) {
+			t.Errorf("%s: bulks don't match: expected 1 bulk of %v edges, got %v", name, test.expectedBulk, bulk.bulks)
+		}
+
+		if events != 2 {
+			t.Errorf("%s: events don't match: expected %v, got %v", name, 2, events)
+		}
+
+		if size, _ := g.Size(); size != 2 {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, 2, size)
+		}
+
+		edge, err := g.Edge(2, 1)
+		if test.traits == nil && (err != nil || edge.Properties.Weight != 5 || edge.Properties.Attributes["color"] != "red") {
+			t.Errorf("%s: edge doesn't match: got %v (error: %v)", name, edge, err)
+		}
+
+		// The added edges must not share their attributes with the source.
+		edge, _ = g.Edge(1, 2)
+		edge.Properties.Attributes["color"] = "blue"
+
+		if sourceEdge, _ := source.Edge(1, 2); sourceEdge.Properties.Attributes["color"] != "red" {
+			t.Errorf("%s: expected attributes of the source edge to remain unchanged", name)
+		}
+	}
+}
+
+// cycleStore is a store that implements CycleStore and reports a cycle for
+// every edge.
+type cycleStore[K comparable, T any] struct {
+	Store[K, T]
+}
+
+func (s cycleStore[K, T]) CreatesCycle(K, K) (bool, error) {
+	return true, nil
+}
+
+func TestCycleStore(t *testing.T) {
+	g := NewWithStore(IntHash, Store[int, int](cycleStore[int, int]{newMemoryStore[int, int]()}), Directed(), PreventCycles())
+
+	_ = g.AddVertex(1)
+	_ = g.AddVertex(2)
+
+	if err := g.AddEdge(1, 2); !errors.Is(err, ErrEdgeCreatesCycle) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrEdgeCreatesCycle, err)
+	}
+
+	if _, err := CreatesCycle(g, 1, 3); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrVertexNotFound, err)
+	}
+}
@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// summaryTopVertices is the number of vertices with the highest degrees that
+// are listed in a summary.
+const summaryTopVertices = 5
+
+// VertexDegree is a vertex hash along with the degree of the vertex. For
+// directed graphs, the degree is the sum of the in-degree and the out-degree.
+type VertexDegree[K comparable] struct {
+	Hash   K
+	Degree int
+}
+
+// GraphSummary is a report of the key figures of a graph as returned by
+// [Summary]. Components is the number of connected components, where the
+// direction of edges is ignored for directed graphs. IsDAG reports whether the
+// graph is directed and doesn't contain any cycles, regardless of its traits.
+type GraphSummary[K comparable] struct {
+	Traits      Traits
+	Order       int
+	Size        int
+	Components  int
+	MinDegree   int
+	MaxDegree   int
+	MeanDegree  float64
+	TopVertices []VertexDegree[K]
+	IsDAG       bool
+}
+
+// Summary computes a summary of the graph, which is intended for logging and
+// for quickly inspecting a loaded graph:
+//
+//	summary, _ := graph.Summary(g)
+//	fmt.Println(summary)
+//
+// This prints a report like the following:
+//
+//	directed graph with 4 vertices and 4 edges
+//	traits: directed, weighted
+//	components: 1
+//	degrees: min 1, max 3, mean 2.00
+//	top vertices: B (3), C (2), D (2), A (1)
+//	DAG: yes
+//
+// TopVertices contains up to five vertices with the highest degrees, sorted by
+// their degrees in descending order. Vertices with the same degree are sorted
+// as described in [SortedVertices].
+func Summary[K comparable, T any](g Graph[K, T]) (*GraphSummary[K], error) {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
+	}
+
+	size, err := g.Size()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get size: %w", err)
+	}
+
+	traits := g.Traits()
+
+	summary := &GraphSummary[K]{
+		Traits: *traits,
+		Order:  len(adjacencyMap),
+		Size:   size,
+	}
+
+	hashes := make([]K, 0, len(adjacencyMap))
+	for hash := range adjacencyMap {
+		hashes = append(hashes, hash)
+	}
+
+	sortHashes(hashes)
+
+	degrees := make(map[K]int, len(hashes))
+	successors := make(map[K][]K, len(hashes))
+	predecessors := make(map[K][]K, len(hashes))
+	components := newUnionFind(hashes...)
+
+	for _, hash := range hashes {
+		for adjacency := range adjacencyMap[hash] {
+			degrees[hash]++
+			components.union(hash, adjacency)
+
+			if traits.IsDirected {
+				degrees[adjacency]++
+				successors[hash] = append(successors[hash], adjacency)
+				predecessors[adjacency] = append(predecessors[adjacency], hash)
+			}
+		}
+	}
+
+	roots := make(map[K]struct{})
+	for _, hash := range hashes {
+		roots[components.find(hash)] = struct{}{}
+	}
+	summary.Components = len(roots)
+
+	if traits.IsDirected {
+		summary.IsDAG = len(cyclicVertices(hashes, successors, predecessors)) == 0
+	}
+
+	if len(hashes) == 0 {
+		return summary, nil
+	}
+
+	topVertices := make([]VertexDegree[K], 0, len(hashes))
+	total := 0
+
+	summary.MinDegree = degrees[hashes[0]]
+
+	for _, hash := range hashes {
+		degree := degrees[hash]
+		total += degree
+
+		if degree < summary.MinDegree {
+			summary.MinDegree = degree
+		}
+		if degree > summary.MaxDegree {
+			summary.MaxDegree = degree
+		}
+
+		topVertices = append(topVertices, VertexDegree[K]{Hash: hash, Degree: degree})
+	}
+
+	summary.MeanDegree = float64(total) / float64(len(hashes))
+
+	// The hashes are already sorted, so a stable sort keeps that order for
+	// vertices with the same degree.
+	sort.SliceStable(topVertices, func(i, j int) bool {
+		return topVertices[i].Degree > topVertices[j].Degree
+	})
+
+	if len(topVertices) > summaryTopVertices {
+		topVertices = topVertices[:summaryTopVertices]
+	}
+
+	summary.TopVertices = topVertices
+
+	return summary, nil
+}
+
+// String returns a human-readable report of the summary as shown in [Summary].
+func (s *GraphSummary[K]) String() string {
+	var builder strings.Builder
+
+	kind := "undirected"
+	if s.Traits.IsDirected {
+		kind = "directed"
+	}
+
+	fmt.Fprintf(&builder, "%s graph with %d vertices and %d edges\n", kind, s.Order, s.Size)
+
+	var traits []string
+
+	for _, trait := range []struct {
+		name  string
+		isSet bool
+	}{
+		{"directed", s.Traits.IsDirected},
+		{"acyclic", s.Traits.IsAcyclic},
+		{"weighted", s.Traits.IsWeighted},
+		{"rooted", s.Traits.IsRooted},
+		{"prevent cycles", s.Traits.PreventCycles},
+	} {
+		if trait.isSet {
+			traits = append(traits, trait.name)
+		}
+	}
+
+	if len(traits) == 0 {
+		traits = append(traits, "none")
+	}
+
+	fmt.Fprintf(&builder, "traits: %s\n", strings.Join(traits, ", "))
+	fmt.Fprintf(&builder, "components: %d\n", s.Components)
+	fmt.Fprintf(&builder, "degrees: min %d, max %d, mean %.2f\n", s.MinDegree, s.MaxDegree, s.MeanDegree)
+
+	topVertices := make([]string, 0, len(s.TopVertices))
+	for _, vertex := range s.TopVertices {
+		topVertices = append(topVertices, fmt.Sprintf("%v (%d)", vertex.Hash, vertex.Degree))
+	}
+
+	if len(topVertices) == 0 {
+		topVertices = append(topVertices, "none")
+	}
+
+	fmt.Fprintf(&builder, "top vertices: %s\n", strings.Join(topVertices, ", "))
+
+	isDAG := "no"
+	if s.IsDAG {
+		isDAG = "yes"
+	}
+
+	fmt.Fprintf(&builder, "DAG: %s", isDAG)
+
+	return builder.String()
+}
@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	tests := map[string]struct {
+		traits          []func(*Traits)
+		vertices        []int
+		edges           []Edge[int]
+		expectedSummary GraphSummary[int]
+	}{
+		"directed acyclic graph": {
+			traits:   []func(*Traits){Directed()},
+			vertices: []int{1, 2, 3, 4, 5},
+			edges:    []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 3}, {Source: 2, Target: 4}, {Source: 3, Target: 4}},
+			expectedSummary: GraphSummary[int]{
+				Traits:      Traits{IsDirected: true},
+				Order:       5,
+				Size:        4,
+				Components:  2,
+				MinDegree:   0,
+				MaxDegree:   3,
+				MeanDegree:  1.6,
+				TopVertices: []VertexDegree[int]{{2, 3}, {3, 2}, {4, 2}, {1, 1}, {5, 0}},
+				IsDAG:       true,
+			},
+		},
+		"directed cyclic graph": {
+			traits:   []func(*Traits){Directed()},
+			vertices: []int{1, 2},
+			edges:    []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 1}},
+			expectedSummary: GraphSummary[int]{
+				Traits:      Traits{IsDirected: true},
+				Order:       2,
+				Size:        2,
+				Components:  1,
+				MinDegree:   2,
+				MaxDegree:   2,
+				MeanDegree:  2,
+				TopVertices: []VertexDegree[int]{{1, 2}, {2, 2}},
+			},
+		},
+		"undirected graph": {
+			vertices: []int{1, 2, 3, 4, 5, 6, 7},
+			edges:    []Edge[int]{{Source: 1, Target: 2}, {Source: 1, Target: 3}, {Source: 1, Target: 4}, {Source: 5, Target: 6}},
+			expectedSummary: GraphSummary[int]{
+				Order:       7,
+				Size:        4,
+				Components:  3,
+				MinDegree:   0,
+				MaxDegree:   3,
+				MeanDegree:  8.0 / 7.0,
+				TopVertices: []VertexDegree[int]{{1, 3}, {2, 1}, {3, 1}, {4, 1}, {5, 1}},
+			},
+		},
+		"empty graph": {
+			traits:          []func(*Traits){Directed()},
+			expectedSummary: GraphSummary[int]{Traits: Traits{IsDirected: true}, IsDAG: true},
+		},
+	}
+
+	for name, test := range tests {
+		g := New(IntHash, test.traits...)
+
+		for _, vertex := range test.vertices {
+			_ = g.AddVertex(vertex)
+		}
+
+		for _, edge := range test.edges {
+			_ = g.AddEdge(edge.Source, edge.Target)
+		}
+
+		summary, err := Summary(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if !reflect.DeepEqual(*summary, test.expectedSummary) {
+			t.Errorf("%s: summary doesn't match: expected %+v, got %+v", name, test.expectedSummary, *summary)
+		}
+	}
+}
+
+func TestGraphSummary_String(t *testing.T) {
+	g := New(StringHash, Directed(), Weighted())
+
+	for _, vertex := range []string{"A", "B", "C", "D"} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge("A", "B")
+	_ = g.AddEdge("B", "C")
+	_ = g.AddEdge("B", "D")
+	_ = g.AddEdge("C", "D")
+
+	summary, _ := Summary(g)
+
+	expected := `directed graph with 4 vertices and 4 edges
+traits: directed, weighted
+components: 1
+degrees: min 1, max 3, mean 2.00
+top vertices: B (3), C (2), D (2), A (1)
+DAG: yes`
+
+	if summary.String() != expected {
+		t.Errorf("report doesn't match: expected\n%v\ngot\n%v", expected, summary.String())
+	}
+}
@@ -0,0 +1,253 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// EdgeValidFromAttribute is the edge attribute holding the start of the
+	// validity interval set using [EdgeValidBetween].
+	EdgeValidFromAttribute = "valid_from"
+	// EdgeValidToAttribute is the edge attribute holding the end of the
+	// validity interval set using [EdgeValidBetween].
+	EdgeValidToAttribute = "valid_to"
+)
+
+// EdgeValidBetween returns a function that limits the validity of an edge to
+// the interval [from, to), i.e. the edge is valid from the given start up to,
+// but not including, the given end. A zero time leaves the interval open on
+// the respective side. This is a functional option for the
+// [graph.Graph.AddEdge] and [graph.Graph.UpdateEdge] methods:
+//
+//	_ = g.AddEdge("alice", "bob", graph.EdgeValidBetween(monday, tuesday))
+//
+// The interval is stored in the EdgeValidFromAttribute and EdgeValidToAttribute
+// attributes formatted as RFC 3339, so that it is kept by all stores. Edges
+// without these attributes are valid at any time. Use [At] and [Between] to
+// only see the edges valid at a given time or within a time window.
+func EdgeValidBetween(from, to time.Time) func(*EdgeProperties) {
+	return func(e *EdgeProperties) {
+		delete(e.Attributes, EdgeValidFromAttribute)
+		delete(e.Attributes, EdgeValidToAttribute)
+
+		if !from.IsZero() {
+			e.Attributes[EdgeValidFromAttribute] = from.Format(time.RFC3339Nano)
+		}
+		if !to.IsZero() {
+			e.Attributes[EdgeValidToAttribute] = to.Format(time.RFC3339Nano)
+		}
+	}
+}
+
+// EdgeValidity returns the validity interval of an edge set using
+// [EdgeValidBetween]. A zero time denotes an open side of the interval.
+func EdgeValidity(properties EdgeProperties) (time.Time, time.Time, error) {
+	var from, to time.Time
+
+	if value, ok := properties.Attributes[EdgeValidFromAttribute]; ok {
+		var err error
+		if from, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			return from, to, fmt.Errorf("invalid %s attribute: %w", EdgeValidFromAttribute, err)
+		}
+	}
+
+	if value, ok := properties.Attributes[EdgeValidToAttribute]; ok {
+		var err error
+		if to, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			return from, to, fmt.Errorf("invalid %s attribute: %w", EdgeValidToAttribute, err)
+		}
+	}
+
+	return from, to, nil
+}
+
+// At returns a view of the graph that only contains the edges valid at the
+// given time, along with all vertices. Since the view is a regular graph, all
+// algorithms respect the validity of the edges:
+//
+//	snapshot, _ := graph.At(g, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC))
+//	path, _ := graph.ShortestPath(snapshot, "alice", "carol")
+//
+// Creating the view is an O(1) operation. The view doesn't copy the vertices
+// and edges, but filters the edges of g whenever they are read, so that
+// modifications of g are visible in the view. Attempting to add, update, or
+// remove vertices or edges of the view returns ErrGraphFrozen.
+func At[K comparable, T any](g Graph[K, T], t time.Time) (Graph[K, T], error) {
+	return temporalView(g, func(from, to time.Time) bool {
+		return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+	})
+}
+
+// Between returns a view of the graph that only contains the edges valid at
+// any time within the window [from, to), along with all vertices. A zero time
+// leaves the window open on the respective side. See [At] for details on the
+// view.
+func Between[K comparable, T any](g Graph[K, T], from, to time.Time) (Graph[K, T], error) {
+	return temporalView(g, func(validFrom, validTo time.Time) bool {
+		return (validTo.IsZero() || from.IsZero() || validTo.After(from)) &&
+			(validFrom.IsZero() || to.IsZero() || validFrom.Before(to))
+	})
+}
+
+func temporalView[K comparable, T any](g Graph[K, T], isValid func(from, to time.Time) bool) (Graph[K, T], error) {
+	var hash Hash[K, T]
+
+	switch g := g.(type) {
+	case *directed[K, T]:
+		hash = g.hash
+	case *undirected[K, T]:
+		hash = g.hash
+	case *cachedGraph[K, T]:
+		return temporalView(g.Graph, isValid)
+	default:
+		return nil, errors.New("graph doesn't support temporal views")
+	}
+
+	store, _ := storeOf(g)
+
+	view := &temporalStore[K, T]{
+		store:   store,
+		isValid: isValid,
+	}
+
+	traits := *g.Traits()
+	withTraits := func(t *Traits) {
+		*t = traits
+	}
+
+	// Algorithms query the neighbors of a vertex on demand if the store
+	// implements NeighborStore, so it is only implemented if the wrapped store
+	// implements it, too.
+	if _, ok := store.(NeighborStore[K]); ok {
+		return NewWithStore(hash, Store[K, T](&temporalNeighborStore[K, T]{view}), withTraits), nil
+	}
+
+	return NewWithStore(hash, Store[K, T](view), withTraits), nil
+}
+
+// temporalStore is a read-only store that hides all edges of the wrapped store
+// whose validity interval isn't accepted by isValid.
+type temporalStore[K comparable, T any] struct {
+	store   Store[K, T]
+	isValid func(from, to time.Time) bool
+}
+
+func (s *temporalStore[K, T]) AddVertex(K, T, VertexProperties) error {
+	return ErrGraphFrozen
+}
+
+func (s *temporalStore[K, T]) Vertex(hash K) (T, VertexProperties, error) {
+	return s.store.Vertex(hash)
+}
+
+func (s *temporalStore[K, T]) RemoveVertex(K) error {
+	return ErrGraphFrozen
+}
+
+func (s *temporalStore[K, T]) ListVertices() ([]K, error) {
+	return s.store.ListVertices()
+}
+
+func (s *temporalStore[K, T]) VertexCount() (int, error) {
+	return s.store.VertexCount()
+}
+
+func (s *temporalStore[K, T]) AddEdge(K, K, Edge[K]) error {
+	return ErrGraphFrozen
+}
+
+func (s *temporalStore[K, T]) UpdateEdge(K, K, Edge[K]) error {
+	return ErrGraphFrozen
+}
+
+func (s *temporalStore[K, T]) RemoveEdge(K, K) error {
+	return ErrGraphFrozen
+}
+
+func (s *temporalStore[K, T]) Edge(sourceHash, targetHash K) (Edge[K], error) {
+	edge, err := s.store.Edge(sourceHash, targetHash)
+	if err != nil {
+		return edge, err
+	}
+
+	valid, err := s.valid(edge)
+	if err != nil {
+		return Edge[K]{}, err
+	}
+	if !valid {
+		return Edge[K]{}, ErrEdgeNotFound
+	}
+
+	return edge, nil
+}
+
+func (s *temporalStore[K, T]) ListEdges() ([]Edge[K], error) {
+	edges, err := s.store.ListEdges()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.filter(edges)
+}
+
+func (s *temporalStore[K, T]) EdgeCount() (int, error) {
+	edges, err := s.ListEdges()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(edges), nil
+}
+
+// filter returns the valid edges. The given slice is left untouched, because it
+// may be owned by the wrapped store.
+func (s *temporalStore[K, T]) filter(edges []Edge[K]) ([]Edge[K], error) {
+	filtered := make([]Edge[K], 0, len(edges))
+
+	for _, edge := range edges {
+		valid, err := s.valid(edge)
+		if err != nil {
+			return nil, err
+		}
+		if valid {
+			filtered = append(filtered, edge)
+		}
+	}
+
+	return filtered, nil
+}
+
+func (s *temporalStore[K, T]) valid(edge Edge[K]) (bool, error) {
+	from, to, err := EdgeValidity(edge.Properties)
+	if err != nil {
+		return false, fmt.Errorf("edge (%v, %v): %w", edge.Source, edge.Target, err)
+	}
+
+	return s.isValid(from, to), nil
+}
+
+// temporalNeighborStore is a temporalStore that implements NeighborStore by
+// filtering the edges returned by the wrapped store.
+type temporalNeighborStore[K comparable, T any] struct {
+	*temporalStore[K, T]
+}
+
+func (n *temporalNeighborStore[K, T]) EdgesBySource(sourceHash K) ([]Edge[K], error) {
+	edges, err := n.store.(NeighborStore[K]).EdgesBySource(sourceHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.filter(edges)
+}
+
+func (n *temporalNeighborStore[K, T]) EdgesByTarget(targetHash K) ([]Edge[K], error) {
+	edges, err := n.store.(NeighborStore[K]).EdgesByTarget(targetHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.filter(edges)
+}
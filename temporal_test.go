@@ -0,0 +1,228 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// day returns midnight of the given day in May 2023.
+func day(d int) time.Time {
+	return time.Date(2023, 5, d, 0, 0, 0, 0, time.UTC)
+}
+
+// interactionGraph creates a graph with the following edges and validities:
+//
+//	A -> B: [May 1, May 3)
+//	B -> C: [May 2, open)
+//	A -> C: [open, May 2)
+//	C -> D: always
+func interactionGraph(options ...func(*Traits)) Graph[string, string] {
+	g := New(StringHash, options...)
+
+	for _, vertex := range []string{"A", "B", "C", "D"} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge("A", "B", EdgeValidBetween(day(1), day(3)))
+	_ = g.AddEdge("B", "C", EdgeValidBetween(day(2), time.Time{}))
+	_ = g.AddEdge("A", "C", EdgeValidBetween(time.Time{}, day(2)))
+	_ = g.AddEdge("C", "D")
+
+	return g
+}
+
+func TestAt(t *testing.T) {
+	tests := map[string]struct {
+		time          time.Time
+		expectedEdges []Edge[string]
+	}{
+		"before all intervals": {
+			time: day(0),
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+		"start of an interval": {
+			time: day(1),
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "A", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+		"end of an interval": {
+			time: day(2),
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "B", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+		"after all intervals": {
+			time: day(10),
+			expectedEdges: []Edge[string]{
+				{Source: "B", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		g := interactionGraph(Directed())
+
+		view, err := At(g, test.time)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		assertTemporalEdges(t, name, view, test.expectedEdges)
+
+		order, _ := view.Order()
+		if order != 4 {
+			t.Errorf("%s: order expectancy doesn't match: expected %v, got %v", name, 4, order)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := map[string]struct {
+		from          time.Time
+		to            time.Time
+		expectedEdges []Edge[string]
+	}{
+		"window ending at the start of an interval": {
+			from: day(0),
+			to:   day(1),
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+		"window overlapping multiple intervals": {
+			from: day(1),
+			to:   day(5),
+			expectedEdges: []Edge[string]{
+				{Source: "A", Target: "B"},
+				{Source: "A", Target: "C"},
+				{Source: "B", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+		"window starting at the end of an interval": {
+			from: day(3),
+			to:   time.Time{},
+			expectedEdges: []Edge[string]{
+				{Source: "B", Target: "C"},
+				{Source: "C", Target: "D"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		g := interactionGraph()
+
+		view, err := Between(g, test.from, test.to)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		assertTemporalEdges(t, name, view, test.expectedEdges)
+	}
+}
+
+func TestAt_algorithms(t *testing.T) {
+	g := interactionGraph(Directed())
+
+	before, _ := At(g, day(1))
+	after, _ := At(g, day(2))
+
+	if path, _ := ShortestPath(before, "A", "D"); len(path) != 3 || path[1] != "C" {
+		t.Errorf("path expectancy doesn't match: expected %v, got %v", []string{"A", "C", "D"}, path)
+	}
+
+	if path, _ := ShortestPath(after, "A", "D"); len(path) != 4 || path[1] != "B" {
+		t.Errorf("path expectancy doesn't match: expected %v, got %v", []string{"A", "B", "C", "D"}, path)
+	}
+
+	// The view reflects modifications of the original graph.
+	_ = g.RemoveEdge("C", "D")
+	if _, err := ShortestPath(after, "A", "D"); !errors.Is(err, ErrTargetNotReachable) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrTargetNotReachable, err)
+	}
+
+	if err := after.AddEdge("A", "D"); !errors.Is(err, ErrGraphFrozen) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", ErrGraphFrozen, err)
+	}
+}
+
+func TestEdgeValidity(t *testing.T) {
+	tests := map[string]struct {
+		attributes    map[string]string
+		expectedFrom  time.Time
+		expectedTo    time.Time
+		expectedError bool
+	}{
+		"no validity": {
+			attributes: map[string]string{},
+		},
+		"closed interval": {
+			attributes:   map[string]string{EdgeValidFromAttribute: "2023-05-01T00:00:00Z", EdgeValidToAttribute: "2023-05-03T00:00:00Z"},
+			expectedFrom: day(1),
+			expectedTo:   day(3),
+		},
+		"invalid time": {
+			attributes:    map[string]string{EdgeValidToAttribute: "tomorrow"},
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		from, to, err := EdgeValidity(EdgeProperties{Attributes: test.attributes})
+
+		if test.expectedError != (err != nil) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.expectedError, err != nil, err)
+		}
+
+		if test.expectedError {
+			continue
+		}
+
+		if !from.Equal(test.expectedFrom) || !to.Equal(test.expectedTo) {
+			t.Errorf("%s: interval expectancy doesn't match: expected [%v, %v), got [%v, %v)", name, test.expectedFrom, test.expectedTo, from, to)
+		}
+	}
+}
+
+func assertTemporalEdges(t *testing.T, name string, g Graph[string, string], expected []Edge[string]) {
+	t.Helper()
+
+	edges, err := g.Edges()
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", name, err)
+	}
+
+	actual := make([]string, 0, len(edges))
+	for _, edge := range edges {
+		// Undirected graphs return one direction of each edge.
+		if !g.Traits().IsDirected && edge.Source > edge.Target {
+			edge.Source, edge.Target = edge.Target, edge.Source
+		}
+		actual = append(actual, edge.Source+edge.Target)
+	}
+
+	expectedEdges := make([]string, 0, len(expected))
+	for _, edge := range expected {
+		expectedEdges = append(expectedEdges, edge.Source+edge.Target)
+	}
+
+	if !slicesAreEqual(actual, expectedEdges) {
+		t.Errorf("%s: edges expectancy doesn't match: expected %v, got %v", name, expectedEdges, actual)
+	}
+
+	size, _ := g.Size()
+	if size != len(expected) {
+		t.Errorf("%s: size expectancy doesn't match: expected %v, got %v", name, len(expected), size)
+	}
+}
@@ -1,6 +1,10 @@
 package graph
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // DFS performs a depth-first search on the graph, starting from the given vertex. The visit
 // function will be invoked with the hash of the vertex currently visited. If it returns false, DFS
@@ -33,32 +37,66 @@ import "fmt"
 //
 // DFS is non-recursive and maintains a stack instead.
 func DFS[K comparable, T any](g Graph[K, T], start K, visit func(K) bool) error {
-	adjacencyMap, err := g.AdjacencyMap()
+	return DFSCtx(context.Background(), g, start, visit)
+}
+
+// DFSCtx works just as DFS, but accepts a context that is checked between the visits of two
+// vertices. Once the context is cancelled or its deadline is exceeded, the traversal stops and
+// DFSCtx returns the context's error.
+//
+// This allows traversals over slow stores or huge graphs to respect request deadlines:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//
+//	err := graph.DFSCtx(ctx, g, 1, func(value int) bool {
+//		fmt.Println(value)
+//		return false
+//	})
+func DFSCtx[K comparable, T any](ctx context.Context, g Graph[K, T], start K, visit func(K) bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	successorsOf, err := successorsFunc(g)
 	if err != nil {
-		return fmt.Errorf("could not get adjacency map: %w", err)
+		return err
 	}
 
-	if _, ok := adjacencyMap[start]; !ok {
-		return fmt.Errorf("could not find start vertex with hash %v", start)
+	if err := successorsOf(start, func(K, Edge[K]) {}); err != nil {
+		if errors.Is(err, ErrVertexNotFound) {
+			return fmt.Errorf("could not find start vertex with hash %v", start)
+		}
+		return fmt.Errorf("could not get successors of %v: %w", start, err)
 	}
 
-	stack := newStack[K]()
-	visited := make(map[K]bool)
+	stack := getStack[K]()
+	defer putStack(stack)
+
+	visited := newVisitedSet(g)
+	defer visited.release()
 
 	stack.push(start)
 
 	for !stack.isEmpty() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		currentHash, _ := stack.pop()
 
-		if _, ok := visited[currentHash]; !ok {
+		if !visited.contains(currentHash) {
 			// Stop traversing the graph if the visit function returns true.
 			if stop := visit(currentHash); stop {
 				break
 			}
-			visited[currentHash] = true
+			visited.add(currentHash)
 
-			for adjacency := range adjacencyMap[currentHash] {
+			err := successorsOf(currentHash, func(adjacency K, _ Edge[K]) {
 				stack.push(adjacency)
+			})
+			if err != nil {
+				return fmt.Errorf("could not get successors of %v: %w", currentHash, err)
 			}
 		}
 	}
@@ -100,7 +138,17 @@ func BFS[K comparable, T any](g Graph[K, T], start K, visit func(K) bool) error
 	ignoreDepth := func(vertex K, _ int) bool {
 		return visit(vertex)
 	}
-	return BFSWithDepth(g, start, ignoreDepth)
+	return bfsWithDepth(context.Background(), g, start, ignoreDepth)
+}
+
+// BFSCtx works just as BFS, but accepts a context that is checked between the visits of two
+// vertices. Once the context is cancelled or its deadline is exceeded, the traversal stops and
+// BFSCtx returns the context's error.
+func BFSCtx[K comparable, T any](ctx context.Context, g Graph[K, T], start K, visit func(K) bool) error {
+	ignoreDepth := func(vertex K, _ int) bool {
+		return visit(vertex)
+	}
+	return bfsWithDepth(ctx, g, start, ignoreDepth)
 }
 
 // BFSWithDepth works just as BFS and performs a breadth-first search on the graph, but its
@@ -115,6 +163,14 @@ func BFS[K comparable, T any](g Graph[K, T], start K, visit func(K) bool) error
 // With the visit function from the example, the BFS traversal will stop once a depth greater
 // than 3 is reached.
 func BFSWithDepth[K comparable, T any](g Graph[K, T], start K, visit func(K, int) bool) error {
+	return bfsWithDepth(context.Background(), g, start, visit)
+}
+
+func bfsWithDepth[K comparable, T any](ctx context.Context, g Graph[K, T], start K, visit func(K, int) bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	adjacencyMap, err := g.AdjacencyMap()
 	if err != nil {
 		return fmt.Errorf("could not get adjacency map: %w", err)
@@ -124,17 +180,22 @@ func BFSWithDepth[K comparable, T any](g Graph[K, T], start K, visit func(K, int
 		return fmt.Errorf("could not find start vertex with hash %v", start)
 	}
 
-	queue := make([]K, 0)
-	visited := make(map[K]bool)
+	queue := getQueue[K]()
+	defer putQueue(queue)
 
-	visited[start] = true
-	queue = append(queue, start)
+	visited := newVisitedSet(g)
+	defer visited.release()
+
+	visited.add(start)
+	queue.push(start)
 	depth := 0
 
-	for len(queue) > 0 {
-		currentHash := queue[0]
+	for !queue.isEmpty() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-		queue = queue[1:]
+		currentHash, _ := queue.pop()
 		depth++
 
 		// Stop traversing the graph if the visit function returns true.
@@ -143,13 +204,404 @@ func BFSWithDepth[K comparable, T any](g Graph[K, T], start K, visit func(K, int
 		}
 
 		for adjacency := range adjacencyMap[currentHash] {
-			if _, ok := visited[adjacency]; !ok {
-				visited[adjacency] = true
+			if !visited.contains(adjacency) {
+				visited.add(adjacency)
+				queue.push(adjacency)
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// BFSMultiSource works just as BFS and performs a breadth-first search on the graph, but starts
+// from multiple vertices simultaneously. All source vertices form the initial frontier, so every
+// other vertex is visited in order of its distance to the nearest source vertex.
+//
+// This is the standard way to compute something like "the distance to the nearest hospital" for
+// each vertex in a graph, without running a separate BFS for each hospital:
+//
+//	_ = graph.BFSMultiSource(g, []int{1, 4}, func(value int) bool {
+//		fmt.Println(value)
+//		return false
+//	})
+//
+// If the visit function returns true, the traversal will be stopped. Duplicate source vertices
+// are only visited once.
+func BFSMultiSource[K comparable, T any](g Graph[K, T], sources []K, visit func(K) bool) error {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return fmt.Errorf("could not get adjacency map: %w", err)
+	}
+
+	queue := make([]K, 0, len(sources))
+
+	visited := newVisitedSet(g)
+	defer visited.release()
+
+	for _, source := range sources {
+		if _, ok := adjacencyMap[source]; !ok {
+			return fmt.Errorf("could not find source vertex with hash %v", source)
+		}
+
+		if !visited.contains(source) {
+			visited.add(source)
+			queue = append(queue, source)
+		}
+	}
+
+	for len(queue) > 0 {
+		currentHash := queue[0]
+		queue = queue[1:]
+
+		// Stop traversing the graph if the visit function returns true.
+		if stop := visit(currentHash); stop {
+			break
+		}
+
+		for adjacency := range adjacencyMap[currentHash] {
+			if !visited.contains(adjacency) {
+				visited.add(adjacency)
 				queue = append(queue, adjacency)
 			}
 		}
+	}
+
+	return nil
+}
+
+// Neighborhood returns the hashes of all vertices that are reachable from the start vertex within
+// at most k hops, including the start vertex itself. For k = 0, only the start vertex is returned.
+// In a directed graph, only outgoing edges are followed.
+//
+// This is the typical "show me the context around this vertex" query:
+//
+//	neighborhood, _ := graph.Neighborhood(g, "A", 2)
+//
+// The vertices are returned in BFS-order, so vertices closer to the start vertex appear first. To
+// obtain the neighborhood as a graph of its own, use [NeighborhoodSubgraph].
+func Neighborhood[K comparable, T any](g Graph[K, T], start K, k int) ([]K, error) {
+	if k < 0 {
+		return nil, fmt.Errorf("number of hops must not be negative, got %d", k)
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("could not get adjacency map: %w", err)
+	}
+
+	if _, ok := adjacencyMap[start]; !ok {
+		return nil, fmt.Errorf("could not find start vertex with hash %v", start)
+	}
+
+	neighborhood := make([]K, 0)
+
+	for _, layer := range bfsLayers(adjacencyMap, start, k) {
+		neighborhood = append(neighborhood, layer...)
+	}
+
+	return neighborhood, nil
+}
+
+// NeighborhoodSubgraph works just as [Neighborhood], but returns the k-hop neighborhood of the
+// start vertex as a new graph. This graph has the same traits as the given graph and contains
+// the vertices of the neighborhood along with all edges joining them.
+func NeighborhoodSubgraph[K comparable, T any](g Graph[K, T], start K, k int) (Graph[K, T], error) {
+	neighborhood, err := Neighborhood(g, start, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return inducedSubgraph(g, neighborhood)
+}
+
+// PriorityTraversal performs a best-first traversal of the graph, starting from the given vertex.
+// Instead of expanding the frontier in FIFO or LIFO order, it always visits the frontier vertex
+// with the lowest priority value next. The priority of a vertex is determined by the priority
+// function, which is called once for each vertex entering the frontier.
+//
+// PriorityTraversal is a building block for greedy searches and A*-like explorations. This example
+// always proceeds with the vertex that has the smallest hash value:
+//
+//	_ = graph.PriorityTraversal(g, 1, func(value int) float64 {
+//		return float64(value)
+//	}, func(value int) bool {
+//		fmt.Println(value)
+//		return false
+//	})
+//
+// Just like with DFS and BFS, the traversal will be stopped if the visit function returns true.
+func PriorityTraversal[K comparable, T any](g Graph[K, T], start K, priority func(K) float64, visit func(K) bool) error {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return fmt.Errorf("could not get adjacency map: %w", err)
+	}
 
+	if _, ok := adjacencyMap[start]; !ok {
+		return fmt.Errorf("could not find start vertex with hash %v", start)
+	}
+
+	queue := newPriorityQueue[K]()
+	visited := make(map[K]bool)
+
+	queue.Push(start, priority(start))
+
+	for queue.Len() > 0 {
+		currentHash, _ := queue.Pop()
+		visited[currentHash] = true
+
+		// Stop traversing the graph if the visit function returns true.
+		if stop := visit(currentHash); stop {
+			break
+		}
+
+		for adjacency := range adjacencyMap[currentHash] {
+			if _, ok := visited[adjacency]; ok {
+				continue
+			}
+			if _, ok := queue.cache[adjacency]; ok {
+				continue
+			}
+			queue.Push(adjacency, priority(adjacency))
+		}
+	}
+
+	return nil
+}
+
+// DFSOrder performs a depth-first search on the graph just like DFS and returns the hashes of all
+// visited vertices in the order they have been visited:
+//
+//	order, _ := graph.DFSOrder(g, 1)
+//
+// This is a shorthand for calling DFS with a visit function that appends each vertex to a slice.
+func DFSOrder[K comparable, T any](g Graph[K, T], start K) ([]K, error) {
+	order := make([]K, 0)
+
+	err := DFS(g, start, func(hash K) bool {
+		order = append(order, hash)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// BFSOrder performs a breadth-first search on the graph just like BFS and returns the hashes of
+// all visited vertices in the order they have been visited:
+//
+//	order, _ := graph.BFSOrder(g, 1)
+//
+// This is a shorthand for calling BFS with a visit function that appends each vertex to a slice.
+func BFSOrder[K comparable, T any](g Graph[K, T], start K) ([]K, error) {
+	order := make([]K, 0)
+
+	err := BFS(g, start, func(hash K) bool {
+		order = append(order, hash)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// EdgeDFS performs an edge-centric depth-first search on the graph, starting from the given
+// vertex. Instead of vertices, it visits each edge reachable from the start vertex exactly once.
+// In an undirected graph, an edge is only visited once, even though it can be traversed in both
+// directions. The visited edge is oriented in the direction it has been traversed in.
+//
+// The visit function will be invoked with the edge currently visited. If it returns true, the
+// traversal will be stopped:
+//
+//	_ = graph.EdgeDFS(g, 1, func(edge graph.Edge[int]) bool {
+//		fmt.Println(edge.Source, edge.Target)
+//		return false
+//	})
+//
+// Like DFS, EdgeDFS is non-recursive and maintains a stack instead.
+func EdgeDFS[K comparable, T any](g Graph[K, T], start K, visit func(Edge[K]) bool) error {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return fmt.Errorf("could not get adjacency map: %w", err)
+	}
+
+	if _, ok := adjacencyMap[start]; !ok {
+		return fmt.Errorf("could not find start vertex with hash %v", start)
+	}
+
+	// Each frame holds the outgoing edges of a vertex along with the index of
+	// the next edge to inspect, so that the traversal can resume where it left
+	// off once all edges of a deeper vertex have been visited.
+	type frame struct {
+		edges []Edge[K]
+		next  int
+	}
+
+	outgoingEdges := func(hash K) []Edge[K] {
+		edges := make([]Edge[K], 0, len(adjacencyMap[hash]))
+		for _, edge := range adjacencyMap[hash] {
+			edges = append(edges, edge)
+		}
+		return edges
+	}
+
+	isDirected := g.Traits().IsDirected
+	visitedVertices := map[K]bool{start: true}
+	visitedEdges := make(map[tuple[K]]struct{})
+
+	stack := []*frame{{edges: outgoingEdges(start)}}
+
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+
+		if top.next == len(top.edges) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		edge := top.edges[top.next]
+		top.next++
+
+		if !markEdgeVisited(visitedEdges, edge, isDirected) {
+			continue
+		}
+
+		// Stop traversing the graph if the visit function returns true.
+		if stop := visit(edge); stop {
+			break
+		}
+
+		if _, ok := visitedVertices[edge.Target]; !ok {
+			visitedVertices[edge.Target] = true
+			stack = append(stack, &frame{edges: outgoingEdges(edge.Target)})
+		}
 	}
 
 	return nil
 }
+
+// EdgeBFS performs an edge-centric breadth-first search on the graph, starting from the given
+// vertex. It works just as [EdgeDFS], but visits the edges in breadth-first order: All edges of a
+// vertex are visited before proceeding with the edges of its adjacent vertices.
+func EdgeBFS[K comparable, T any](g Graph[K, T], start K, visit func(Edge[K]) bool) error {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return fmt.Errorf("could not get adjacency map: %w", err)
+	}
+
+	if _, ok := adjacencyMap[start]; !ok {
+		return fmt.Errorf("could not find start vertex with hash %v", start)
+	}
+
+	isDirected := g.Traits().IsDirected
+	visitedVertices := map[K]bool{start: true}
+	visitedEdges := make(map[tuple[K]]struct{})
+
+	queue := []K{start}
+
+	for len(queue) > 0 {
+		currentHash := queue[0]
+		queue = queue[1:]
+
+		for adjacency, edge := range adjacencyMap[currentHash] {
+			if !markEdgeVisited(visitedEdges, edge, isDirected) {
+				continue
+			}
+
+			// Stop traversing the graph if the visit function returns true.
+			if stop := visit(edge); stop {
+				return nil
+			}
+
+			if _, ok := visitedVertices[adjacency]; !ok {
+				visitedVertices[adjacency] = true
+				queue = append(queue, adjacency)
+			}
+		}
+	}
+
+	return nil
+}
+
+// markEdgeVisited marks the given edge as visited and reports whether it has
+// been unvisited before. In an undirected graph, the reversed edge is marked
+// as well, so that each edge is only visited in one direction.
+func markEdgeVisited[K comparable](visited map[tuple[K]]struct{}, edge Edge[K], isDirected bool) bool {
+	key := tuple[K]{source: edge.Source, target: edge.Target}
+
+	if _, ok := visited[key]; ok {
+		return false
+	}
+
+	visited[key] = struct{}{}
+
+	if !isDirected {
+		visited[tuple[K]{source: edge.Target, target: edge.Source}] = struct{}{}
+	}
+
+	return true
+}
+
+// BFSLayers performs a breadth-first search on the graph, starting from the given vertex, and
+// returns the visited vertices grouped by their depth level. The first layer only contains the
+// start vertex, the second layer contains its adjacent vertices, and so on. In case the graph is
+// disconnected, only the vertices joined with the starting vertex are included.
+//
+// Unlike BFS with a visit function, BFSLayers exposes the entire wavefront of each level, which
+// is required for staged processing such as rolling out changes over a dependency graph:
+//
+//	layers, _ := graph.BFSLayers(g, 1)
+//
+//	for depth, layer := range layers {
+//		fmt.Printf("depth %d: %v\n", depth, layer)
+//	}
+//
+// The order of the vertices within a layer is not deterministic.
+func BFSLayers[K comparable, T any](g Graph[K, T], start K) ([][]K, error) {
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("could not get adjacency map: %w", err)
+	}
+
+	if _, ok := adjacencyMap[start]; !ok {
+		return nil, fmt.Errorf("could not find start vertex with hash %v", start)
+	}
+
+	return bfsLayers(adjacencyMap, start, -1), nil
+}
+
+// bfsLayers computes the BFS layers starting from the given vertex, up to at
+// most maxHops hops away from the start vertex. If maxHops is negative, there
+// is no limit and all reachable vertices are included.
+func bfsLayers[K comparable](adjacencyMap map[K]map[K]Edge[K], start K, maxHops int) [][]K {
+	visited := map[K]bool{start: true}
+	frontier := []K{start}
+	layers := [][]K{frontier}
+
+	for hops := 0; hops != maxHops; hops++ {
+		next := make([]K, 0)
+
+		for _, currentHash := range frontier {
+			for adjacency := range adjacencyMap[currentHash] {
+				if _, ok := visited[adjacency]; !ok {
+					visited[adjacency] = true
+					next = append(next, adjacency)
+				}
+			}
+		}
+
+		if len(next) == 0 {
+			break
+		}
+
+		layers = append(layers, next)
+		frontier = next
+	}
+
+	return layers
+}
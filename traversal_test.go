@@ -1,6 +1,8 @@
 package graph
 
 import (
+	"context"
+	"errors"
 	"log"
 	"testing"
 )
@@ -413,3 +415,917 @@ func TestUndirectedBFS(t *testing.T) {
 		}
 	}
 }
+
+func TestBFSMultiSource(t *testing.T) {
+	tests := map[string]struct {
+		isDirected     bool
+		vertices       []int
+		edges          []Edge[int]
+		sources        []int
+		expectedVisits [][]int
+		stopAtVertex   int
+		shouldFail     bool
+	}{
+		"directed graph with two sources": {
+			isDirected: true,
+			vertices:   []int{1, 2, 3, 4, 5, 6},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 4, Target: 5},
+				{Source: 5, Target: 6},
+			},
+			sources:        []int{1, 4},
+			expectedVisits: [][]int{{1, 4}, {2, 5}, {3, 6}},
+			stopAtVertex:   -1,
+		},
+		"undirected graph with two sources": {
+			vertices: []int{1, 2, 3, 4, 5},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 3, Target: 4},
+				{Source: 4, Target: 5},
+			},
+			sources:        []int{1, 5},
+			expectedVisits: [][]int{{1, 5}, {2, 4}, {3}},
+			stopAtVertex:   -1,
+		},
+		"duplicate sources are visited once": {
+			isDirected: true,
+			vertices:   []int{1, 2},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+			},
+			sources:        []int{1, 1},
+			expectedVisits: [][]int{{1}, {2}},
+			stopAtVertex:   -1,
+		},
+		"stop at source vertex": {
+			isDirected: true,
+			vertices:   []int{1, 2, 3},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 3, Target: 2},
+			},
+			sources:        []int{1, 3},
+			expectedVisits: [][]int{{1}},
+			stopAtVertex:   1,
+		},
+		"non-existent source": {
+			isDirected: true,
+			vertices:   []int{1, 2},
+			sources:    []int{1, 3},
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		var graph Graph[int, int]
+		if test.isDirected {
+			graph = New(IntHash, Directed())
+		} else {
+			graph = New(IntHash)
+		}
+
+		for _, vertex := range test.vertices {
+			_ = graph.AddVertex(vertex)
+		}
+
+		for _, edge := range test.edges {
+			if err := graph.AddEdge(edge.Source, edge.Target); err != nil {
+				t.Fatalf("%s: failed to add edge: %s", name, err.Error())
+			}
+		}
+
+		visits := make([]int, 0)
+
+		visit := func(value int) bool {
+			visits = append(visits, value)
+			return value == test.stopAtVertex
+		}
+
+		err := BFSMultiSource(graph, test.sources, visit)
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		if test.shouldFail {
+			continue
+		}
+
+		// Vertices within the same distance to the nearest source may be
+		// visited in any order, but all of them have to be visited before
+		// any vertex that is further away.
+		for _, level := range test.expectedVisits {
+			if len(visits) < len(level) {
+				t.Fatalf("%s: expected level %v to be visited, got %v", name, level, visits)
+			}
+
+			current := visits[:len(level)]
+			visits = visits[len(level):]
+
+			for _, expectedVisit := range level {
+				var found bool
+				for _, visit := range current {
+					if visit == expectedVisit {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("%s: expected vertex %v to be visited in level %v, got %v", name, expectedVisit, level, current)
+				}
+			}
+		}
+
+		if len(visits) != 0 {
+			t.Errorf("%s: unexpected visits: %v", name, visits)
+		}
+	}
+}
+
+func TestNeighborhood(t *testing.T) {
+	tests := map[string]struct {
+		isDirected bool
+		vertices   []int
+		edges      []Edge[int]
+		start      int
+		k          int
+		expected   []int
+		shouldFail bool
+	}{
+		"directed graph within 1 hop": {
+			isDirected: true,
+			vertices:   []int{1, 2, 3, 4},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 4, Target: 1},
+			},
+			start:    1,
+			k:        1,
+			expected: []int{1, 2},
+		},
+		"undirected graph within 1 hop": {
+			vertices: []int{1, 2, 3, 4},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 4, Target: 1},
+			},
+			start:    1,
+			k:        1,
+			expected: []int{1, 2, 4},
+		},
+		"directed graph within 2 hops": {
+			isDirected: true,
+			vertices:   []int{1, 2, 3, 4, 5},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 3, Target: 4},
+				{Source: 1, Target: 5},
+			},
+			start:    1,
+			k:        2,
+			expected: []int{1, 2, 3, 5},
+		},
+		"zero hops": {
+			isDirected: true,
+			vertices:   []int{1, 2},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+			},
+			start:    1,
+			k:        0,
+			expected: []int{1},
+		},
+		"negative hops": {
+			vertices:   []int{1},
+			start:      1,
+			k:          -1,
+			shouldFail: true,
+		},
+		"non-existent start vertex": {
+			vertices:   []int{1},
+			start:      2,
+			k:          1,
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		var graph Graph[int, int]
+		if test.isDirected {
+			graph = New(IntHash, Directed())
+		} else {
+			graph = New(IntHash)
+		}
+
+		for _, vertex := range test.vertices {
+			_ = graph.AddVertex(vertex)
+		}
+
+		for _, edge := range test.edges {
+			if err := graph.AddEdge(edge.Source, edge.Target); err != nil {
+				t.Fatalf("%s: failed to add edge: %s", name, err.Error())
+			}
+		}
+
+		neighborhood, err := Neighborhood(graph, test.start, test.k)
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		if test.shouldFail {
+			continue
+		}
+
+		if neighborhood[0] != test.start {
+			t.Errorf("%s: expected start vertex %v to come first, got %v", name, test.start, neighborhood)
+		}
+
+		if !slicesAreEqual(neighborhood, test.expected) {
+			t.Errorf("%s: neighborhood expectancy doesn't match: expected %v, got %v", name, test.expected, neighborhood)
+		}
+	}
+}
+
+func TestNeighborhoodSubgraph(t *testing.T) {
+	tests := map[string]struct {
+		isDirected    bool
+		vertices      []int
+		edges         []Edge[int]
+		start         int
+		k             int
+		expectedOrder int
+		expectedSize  int
+	}{
+		"directed graph within 1 hop": {
+			isDirected: true,
+			vertices:   []int{1, 2, 3, 4},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 2, Target: 1},
+				{Source: 4, Target: 1},
+			},
+			start:         1,
+			k:             1,
+			expectedOrder: 2,
+			expectedSize:  2,
+		},
+		"undirected triangle within 1 hop": {
+			vertices: []int{1, 2, 3, 4},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 3, Target: 1},
+				{Source: 3, Target: 4},
+			},
+			start:         1,
+			k:             1,
+			expectedOrder: 3,
+			expectedSize:  3,
+		},
+	}
+
+	for name, test := range tests {
+		var graph Graph[int, int]
+		if test.isDirected {
+			graph = New(IntHash, Directed())
+		} else {
+			graph = New(IntHash)
+		}
+
+		for _, vertex := range test.vertices {
+			_ = graph.AddVertex(vertex, VertexWeight(vertex))
+		}
+
+		for _, edge := range test.edges {
+			if err := graph.AddEdge(edge.Source, edge.Target); err != nil {
+				t.Fatalf("%s: failed to add edge: %s", name, err.Error())
+			}
+		}
+
+		subgraph, err := NeighborhoodSubgraph(graph, test.start, test.k)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if !traitsAreEqual(subgraph.Traits(), graph.Traits()) {
+			t.Errorf("%s: traits expectancy doesn't match: expected %v, got %v", name, graph.Traits(), subgraph.Traits())
+		}
+
+		order, _ := subgraph.Order()
+		if order != test.expectedOrder {
+			t.Errorf("%s: order expectancy doesn't match: expected %v, got %v", name, test.expectedOrder, order)
+		}
+
+		size, _ := subgraph.Size()
+		if size != test.expectedSize {
+			t.Errorf("%s: size expectancy doesn't match: expected %v, got %v", name, test.expectedSize, size)
+		}
+
+		_, properties, err := subgraph.VertexWithProperties(test.start)
+		if err != nil {
+			t.Fatalf("%s: failed to get start vertex: %v", name, err)
+		}
+
+		if properties.Weight != test.start {
+			t.Errorf("%s: vertex properties haven't been copied: expected weight %v, got %v", name, test.start, properties.Weight)
+		}
+	}
+}
+
+func TestDFSCtx(t *testing.T) {
+	tests := map[string]struct {
+		cancelAtVertex int
+		expectedVisits int
+		expectedErr    error
+	}{
+		"traverse entire graph": {
+			cancelAtVertex: -1,
+			expectedVisits: 4,
+		},
+		"cancel after first vertex": {
+			cancelAtVertex: 1,
+			expectedVisits: 1,
+			expectedErr:    context.Canceled,
+		},
+	}
+
+	for name, test := range tests {
+		graph := New(IntHash, Directed())
+
+		for _, vertex := range []int{1, 2, 3, 4} {
+			_ = graph.AddVertex(vertex)
+		}
+
+		_ = graph.AddEdge(1, 2)
+		_ = graph.AddEdge(2, 3)
+		_ = graph.AddEdge(3, 4)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		visits := 0
+
+		err := DFSCtx(ctx, graph, 1, func(value int) bool {
+			visits++
+			if value == test.cancelAtVertex {
+				cancel()
+			}
+			return false
+		})
+
+		cancel()
+
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+		}
+
+		if visits != test.expectedVisits {
+			t.Errorf("%s: number of visits doesn't match: expected %v, got %v", name, test.expectedVisits, visits)
+		}
+	}
+}
+
+// failingNeighborStore is a store whose EdgesBySource method always fails.
+type failingNeighborStore[K comparable, T any] struct {
+	Store[K, T]
+	err error
+}
+
+func (s failingNeighborStore[K, T]) EdgesBySource(K) ([]Edge[K], error) {
+	return nil, s.err
+}
+
+func (s failingNeighborStore[K, T]) EdgesByTarget(K) ([]Edge[K], error) {
+	return nil, s.err
+}
+
+func TestDFSCtx_storeError(t *testing.T) {
+	storeErr := errors.New("connection lost")
+
+	graph := NewWithStore(IntHash, Store[int, int](failingNeighborStore[int, int]{
+		Store: newMemoryStore[int, int](),
+		err:   storeErr,
+	}), Directed())
+
+	_ = graph.AddVertex(1)
+
+	err := DFSCtx(context.Background(), graph, 1, func(int) bool {
+		return false
+	})
+
+	if !errors.Is(err, storeErr) {
+		t.Errorf("error expectancy doesn't match: expected %v, got %v", storeErr, err)
+	}
+}
+
+func BenchmarkDFS(b *testing.B) {
+	graph := New(IntHash, Directed())
+
+	for i := 0; i < 1000; i++ {
+		_ = graph.AddVertex(i)
+	}
+
+	for i := 0; i < 1000; i++ {
+		_ = graph.AddEdge(i, (i+1)%1000)
+		_ = graph.AddEdge(i, (i*7)%1000)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = DFS(graph, 0, func(int) bool {
+			return false
+		})
+	}
+}
+
+func TestBFSCtx(t *testing.T) {
+	tests := map[string]struct {
+		cancelAtVertex int
+		expectedVisits int
+		expectedErr    error
+	}{
+		"traverse entire graph": {
+			cancelAtVertex: -1,
+			expectedVisits: 4,
+		},
+		"cancel after first vertex": {
+			cancelAtVertex: 1,
+			expectedVisits: 1,
+			expectedErr:    context.Canceled,
+		},
+	}
+
+	for name, test := range tests {
+		graph := New(IntHash)
+
+		for _, vertex := range []int{1, 2, 3, 4} {
+			_ = graph.AddVertex(vertex)
+		}
+
+		_ = graph.AddEdge(1, 2)
+		_ = graph.AddEdge(1, 3)
+		_ = graph.AddEdge(3, 4)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		visits := 0
+
+		err := BFSCtx(ctx, graph, 1, func(value int) bool {
+			visits++
+			if value == test.cancelAtVertex {
+				cancel()
+			}
+			return false
+		})
+
+		cancel()
+
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+		}
+
+		if visits != test.expectedVisits {
+			t.Errorf("%s: number of visits doesn't match: expected %v, got %v", name, test.expectedVisits, visits)
+		}
+	}
+}
+
+func TestPriorityTraversal(t *testing.T) {
+	tests := map[string]struct {
+		isDirected     bool
+		vertices       []int
+		edges          []Edge[int]
+		start          int
+		priority       func(int) float64
+		expectedVisits []int
+		stopAtVertex   int
+		shouldFail     bool
+	}{
+		"directed graph with ascending priority": {
+			isDirected: true,
+			vertices:   []int{1, 2, 3, 4, 5},
+			edges: []Edge[int]{
+				{Source: 1, Target: 5},
+				{Source: 1, Target: 3},
+				{Source: 3, Target: 2},
+				{Source: 5, Target: 4},
+			},
+			start: 1,
+			priority: func(value int) float64 {
+				return float64(value)
+			},
+			expectedVisits: []int{1, 3, 2, 5, 4},
+			stopAtVertex:   -1,
+		},
+		"undirected graph with descending priority": {
+			vertices: []int{1, 2, 3, 4, 5},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 1, Target: 3},
+				{Source: 2, Target: 5},
+				{Source: 3, Target: 4},
+			},
+			start: 1,
+			priority: func(value int) float64 {
+				return -float64(value)
+			},
+			expectedVisits: []int{1, 3, 4, 2, 5},
+			stopAtVertex:   -1,
+		},
+		"stop at vertex 3": {
+			isDirected: true,
+			vertices:   []int{1, 2, 3, 4},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 1, Target: 3},
+				{Source: 3, Target: 4},
+			},
+			start: 1,
+			priority: func(value int) float64 {
+				return -float64(value)
+			},
+			expectedVisits: []int{1, 3},
+			stopAtVertex:   3,
+		},
+		"non-existent start vertex": {
+			vertices: []int{1},
+			start:    2,
+			priority: func(value int) float64 {
+				return 0
+			},
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		var graph Graph[int, int]
+		if test.isDirected {
+			graph = New(IntHash, Directed())
+		} else {
+			graph = New(IntHash)
+		}
+
+		for _, vertex := range test.vertices {
+			_ = graph.AddVertex(vertex)
+		}
+
+		for _, edge := range test.edges {
+			if err := graph.AddEdge(edge.Source, edge.Target); err != nil {
+				t.Fatalf("%s: failed to add edge: %s", name, err.Error())
+			}
+		}
+
+		visits := make([]int, 0)
+
+		err := PriorityTraversal(graph, test.start, test.priority, func(value int) bool {
+			visits = append(visits, value)
+			return value == test.stopAtVertex
+		})
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		if test.shouldFail {
+			continue
+		}
+
+		if len(visits) != len(test.expectedVisits) {
+			t.Fatalf("%s: visits expectancy doesn't match: expected %v, got %v", name, test.expectedVisits, visits)
+		}
+
+		for i := range visits {
+			if visits[i] != test.expectedVisits[i] {
+				t.Errorf("%s: visits expectancy doesn't match: expected %v, got %v", name, test.expectedVisits, visits)
+				break
+			}
+		}
+	}
+}
+
+func TestDFSOrder(t *testing.T) {
+	tests := map[string]struct {
+		vertices   []int
+		edges      []Edge[int]
+		start      int
+		expected   []int
+		shouldFail bool
+	}{
+		"directed path": {
+			vertices: []int{1, 2, 3, 4},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 3, Target: 4},
+			},
+			start:    1,
+			expected: []int{1, 2, 3, 4},
+		},
+		"disconnected graph": {
+			vertices: []int{1, 2, 3},
+			edges: []Edge[int]{
+				{Source: 2, Target: 3},
+			},
+			start:    2,
+			expected: []int{2, 3},
+		},
+		"non-existent start vertex": {
+			vertices:   []int{1},
+			start:      2,
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		graph := New(IntHash, Directed())
+
+		for _, vertex := range test.vertices {
+			_ = graph.AddVertex(vertex)
+		}
+
+		for _, edge := range test.edges {
+			if err := graph.AddEdge(edge.Source, edge.Target); err != nil {
+				t.Fatalf("%s: failed to add edge: %s", name, err.Error())
+			}
+		}
+
+		order, err := DFSOrder(graph, test.start)
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		if len(order) != len(test.expected) {
+			t.Fatalf("%s: order expectancy doesn't match: expected %v, got %v", name, test.expected, order)
+		}
+
+		for i := range order {
+			if order[i] != test.expected[i] {
+				t.Errorf("%s: order expectancy doesn't match: expected %v, got %v", name, test.expected, order)
+				break
+			}
+		}
+	}
+}
+
+func TestBFSOrder(t *testing.T) {
+	tests := map[string]struct {
+		vertices   []int
+		edges      []Edge[int]
+		start      int
+		expected   [][]int
+		shouldFail bool
+	}{
+		"undirected tree": {
+			vertices: []int{1, 2, 3, 4, 5},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 1, Target: 3},
+				{Source: 2, Target: 4},
+				{Source: 3, Target: 5},
+			},
+			start:    1,
+			expected: [][]int{{1}, {2, 3}, {4, 5}},
+		},
+		"non-existent start vertex": {
+			vertices:   []int{1},
+			start:      2,
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		graph := New(IntHash)
+
+		for _, vertex := range test.vertices {
+			_ = graph.AddVertex(vertex)
+		}
+
+		for _, edge := range test.edges {
+			if err := graph.AddEdge(edge.Source, edge.Target); err != nil {
+				t.Fatalf("%s: failed to add edge: %s", name, err.Error())
+			}
+		}
+
+		order, err := BFSOrder(graph, test.start)
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		// The order of vertices with the same depth is non-deterministic, so
+		// only compare the vertices within each depth level.
+		for _, level := range test.expected {
+			if len(order) < len(level) {
+				t.Fatalf("%s: expected level %v to be visited, got %v", name, level, order)
+			}
+
+			if !slicesAreEqual(order[:len(level)], level) {
+				t.Errorf("%s: level expectancy doesn't match: expected %v, got %v", name, level, order[:len(level)])
+			}
+
+			order = order[len(level):]
+		}
+	}
+}
+
+func TestEdgeDFSAndEdgeBFS(t *testing.T) {
+	tests := map[string]struct {
+		isDirected    bool
+		vertices      []int
+		edges         []Edge[int]
+		start         int
+		expectedEdges int
+		stopAfter     int
+		shouldFail    bool
+	}{
+		"directed triangle": {
+			isDirected: true,
+			vertices:   []int{1, 2, 3},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 3, Target: 1},
+			},
+			start:         1,
+			expectedEdges: 3,
+		},
+		"undirected triangle": {
+			vertices: []int{1, 2, 3},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 3, Target: 1},
+			},
+			start:         1,
+			expectedEdges: 3,
+		},
+		"directed graph with unreachable edges": {
+			isDirected: true,
+			vertices:   []int{1, 2, 3, 4},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 1, Target: 3},
+				{Source: 4, Target: 1},
+			},
+			start:         1,
+			expectedEdges: 2,
+		},
+		"stop after two edges": {
+			vertices: []int{1, 2, 3, 4},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 3, Target: 4},
+			},
+			start:         1,
+			expectedEdges: 2,
+			stopAfter:     2,
+		},
+		"non-existent start vertex": {
+			vertices:   []int{1},
+			start:      2,
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		for _, traversal := range []func(Graph[int, int], int, func(Edge[int]) bool) error{
+			EdgeDFS[int, int],
+			EdgeBFS[int, int],
+		} {
+			var graph Graph[int, int]
+			if test.isDirected {
+				graph = New(IntHash, Directed())
+			} else {
+				graph = New(IntHash)
+			}
+
+			for _, vertex := range test.vertices {
+				_ = graph.AddVertex(vertex)
+			}
+
+			for _, edge := range test.edges {
+				if err := graph.AddEdge(edge.Source, edge.Target); err != nil {
+					t.Fatalf("%s: failed to add edge: %s", name, err.Error())
+				}
+			}
+
+			visited := make(map[[2]int]struct{})
+
+			err := traversal(graph, test.start, func(edge Edge[int]) bool {
+				key := [2]int{edge.Source, edge.Target}
+				reversed := [2]int{edge.Target, edge.Source}
+
+				if _, ok := visited[key]; ok {
+					t.Errorf("%s: edge %v has been visited twice", name, key)
+				}
+				if _, ok := visited[reversed]; ok && !test.isDirected {
+					t.Errorf("%s: undirected edge %v has been visited twice", name, key)
+				}
+
+				visited[key] = struct{}{}
+
+				return len(visited) == test.stopAfter
+			})
+
+			if test.shouldFail != (err != nil) {
+				t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+			}
+
+			if len(visited) != test.expectedEdges {
+				t.Errorf("%s: number of visited edges doesn't match: expected %v, got %v", name, test.expectedEdges, len(visited))
+			}
+		}
+	}
+}
+
+func TestBFSLayers(t *testing.T) {
+	tests := map[string]struct {
+		isDirected bool
+		vertices   []int
+		edges      []Edge[int]
+		start      int
+		expected   [][]int
+		shouldFail bool
+	}{
+		"directed graph": {
+			isDirected: true,
+			vertices:   []int{1, 2, 3, 4, 5, 6},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 1, Target: 3},
+				{Source: 2, Target: 4},
+				{Source: 3, Target: 4},
+				{Source: 4, Target: 5},
+				{Source: 6, Target: 1},
+			},
+			start:    1,
+			expected: [][]int{{1}, {2, 3}, {4}, {5}},
+		},
+		"undirected graph": {
+			vertices: []int{1, 2, 3, 4},
+			edges: []Edge[int]{
+				{Source: 1, Target: 2},
+				{Source: 2, Target: 3},
+				{Source: 4, Target: 1},
+			},
+			start:    1,
+			expected: [][]int{{1}, {2, 4}, {3}},
+		},
+		"single vertex": {
+			vertices: []int{1},
+			start:    1,
+			expected: [][]int{{1}},
+		},
+		"non-existent start vertex": {
+			vertices:   []int{1},
+			start:      2,
+			shouldFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		var graph Graph[int, int]
+		if test.isDirected {
+			graph = New(IntHash, Directed())
+		} else {
+			graph = New(IntHash)
+		}
+
+		for _, vertex := range test.vertices {
+			_ = graph.AddVertex(vertex)
+		}
+
+		for _, edge := range test.edges {
+			if err := graph.AddEdge(edge.Source, edge.Target); err != nil {
+				t.Fatalf("%s: failed to add edge: %s", name, err.Error())
+			}
+		}
+
+		layers, err := BFSLayers(graph, test.start)
+
+		if test.shouldFail != (err != nil) {
+			t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v (error: %v)", name, test.shouldFail, err != nil, err)
+		}
+
+		if len(layers) != len(test.expected) {
+			t.Fatalf("%s: layers expectancy doesn't match: expected %v, got %v", name, test.expected, layers)
+		}
+
+		for i := range layers {
+			if !slicesAreEqual(layers[i], test.expected[i]) {
+				t.Errorf("%s: layer %d expectancy doesn't match: expected %v, got %v", name, i, test.expected[i], layers[i])
+			}
+		}
+	}
+}
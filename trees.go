@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
@@ -9,20 +10,37 @@ import (
 // MinimumSpanningTree returns a minimum spanning tree within the given graph.
 //
 // The MST contains all vertices from the given graph as well as the required
-// edges for building the MST. The original graph remains unchanged.
-func MinimumSpanningTree[K comparable, T any](g Graph[K, T]) (Graph[K, T], error) {
-	return spanningTree(g, false)
+// edges for building the MST. The original graph remains unchanged. Instead of
+// the stored edge weights, the weights can be derived from the edges at query
+// time by passing WeightedBy with a [WeightFunc].
+func MinimumSpanningTree[K comparable, T any](g Graph[K, T], options ...func(*WeightOptions[K])) (Graph[K, T], error) {
+	return spanningTree(context.Background(), g, false, options)
+}
+
+// MinimumSpanningTreeCtx works just as [MinimumSpanningTree], but accepts a
+// context that is checked before adding each vertex and each candidate edge.
+// Once the context is cancelled or its deadline is exceeded, the context's
+// error is returned.
+func MinimumSpanningTreeCtx[K comparable, T any](ctx context.Context, g Graph[K, T], options ...func(*WeightOptions[K])) (Graph[K, T], error) {
+	return spanningTree(ctx, g, false, options)
 }
 
 // MaximumSpanningTree returns a minimum spanning tree within the given graph.
 //
 // The MST contains all vertices from the given graph as well as the required
-// edges for building the MST. The original graph remains unchanged.
-func MaximumSpanningTree[K comparable, T any](g Graph[K, T]) (Graph[K, T], error) {
-	return spanningTree(g, true)
+// edges for building the MST. The original graph remains unchanged. Just like
+// with MinimumSpanningTree, a [WeightFunc] can be passed using WeightedBy.
+func MaximumSpanningTree[K comparable, T any](g Graph[K, T], options ...func(*WeightOptions[K])) (Graph[K, T], error) {
+	return spanningTree(context.Background(), g, true, options)
+}
+
+// MaximumSpanningTreeCtx works just as [MaximumSpanningTree], but accepts a
+// context that is checked before adding each vertex and each candidate edge.
+func MaximumSpanningTreeCtx[K comparable, T any](ctx context.Context, g Graph[K, T], options ...func(*WeightOptions[K])) (Graph[K, T], error) {
+	return spanningTree(ctx, g, true, options)
 }
 
-func spanningTree[K comparable, T any](g Graph[K, T], maximum bool) (Graph[K, T], error) {
+func spanningTree[K comparable, T any](ctx context.Context, g Graph[K, T], maximum bool, options []func(*WeightOptions[K])) (Graph[K, T], error) {
 	if g.Traits().IsDirected {
 		return nil, errors.New("spanning trees can only be determined for undirected graphs")
 	}
@@ -32,12 +50,25 @@ func spanningTree[K comparable, T any](g Graph[K, T], maximum bool) (Graph[K, T]
 		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
 	}
 
-	edges := make([]Edge[K], 0)
+	edgeWeight := weightFunc(options, storedWeight[K])
+
+	// The weight of each edge is computed once, since the weight function may
+	// be expensive.
+	type weightedEdge struct {
+		edge   Edge[K]
+		weight float64
+	}
+
+	edges := make([]weightedEdge, 0)
 	subtrees := newUnionFind[K]()
 
 	mst := NewLike(g)
 
 	for v, adjacencies := range adjacencyMap {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		vertex, properties, err := g.VertexWithProperties(v) //nolint:govet
 		if err != nil {
 			return nil, fmt.Errorf("failed to get vertex %v: %w", v, err)
@@ -51,21 +82,30 @@ func spanningTree[K comparable, T any](g Graph[K, T], maximum bool) (Graph[K, T]
 		subtrees.add(v)
 
 		for _, edge := range adjacencies {
-			edges = append(edges, edge)
+			edges = append(edges, weightedEdge{
+				edge:   edge,
+				weight: edgeWeight(edge),
+			})
 		}
 	}
 
 	if maximum {
 		sort.Slice(edges, func(i, j int) bool {
-			return edges[i].Properties.Weight > edges[j].Properties.Weight
+			return edges[i].weight > edges[j].weight
 		})
 	} else {
 		sort.Slice(edges, func(i, j int) bool {
-			return edges[i].Properties.Weight < edges[j].Properties.Weight
+			return edges[i].weight < edges[j].weight
 		})
 	}
 
-	for _, edge := range edges {
+	for _, weighted := range edges {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		edge := weighted.edge
+
 		sourceRoot := subtrees.find(edge.Source)
 		targetRoot := subtrees.find(edge.Target)
 
@@ -1,6 +1,8 @@
 package graph
 
 import (
+	"context"
+	"errors"
 	"testing"
 )
 
@@ -213,3 +215,106 @@ func TestUndirectedMaximumSpanningTree(t *testing.T) {
 		})
 	}
 }
+
+func TestMinimumSpanningTreeCtx(t *testing.T) {
+	tests := map[string]struct {
+		ctx         context.Context
+		expectedErr error
+	}{
+		"no cancellation": {
+			ctx: context.Background(),
+		},
+		"cancelled while adding vertices": {
+			ctx:         newCountdownContext(1),
+			expectedErr: context.Canceled,
+		},
+		"cancelled while adding edges": {
+			ctx:         newCountdownContext(4),
+			expectedErr: context.Canceled,
+		},
+	}
+
+	for name, test := range tests {
+		graph := New(IntHash)
+
+		for _, vertex := range []int{1, 2, 3} {
+			_ = graph.AddVertex(vertex)
+		}
+
+		_ = graph.AddEdge(1, 2, EdgeWeight(1))
+		_ = graph.AddEdge(2, 3, EdgeWeight(2))
+		_ = graph.AddEdge(1, 3, EdgeWeight(3))
+
+		mst, err := MinimumSpanningTreeCtx(test.ctx, graph)
+
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+		}
+
+		if test.expectedErr != nil {
+			continue
+		}
+
+		size, _ := mst.Size()
+		if size != 2 {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, 2, size)
+		}
+	}
+}
+
+func TestSpanningTree_weightFunc(t *testing.T) {
+	g := New(IntHash)
+
+	for _, vertex := range []int{1, 2, 3} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge(1, 2, EdgeWeight(1), EdgeData(30.0))
+	_ = g.AddEdge(2, 3, EdgeWeight(2), EdgeData(20.0))
+	_ = g.AddEdge(1, 3, EdgeWeight(3), EdgeData(10.0))
+
+	cost := func(edge Edge[int]) float64 {
+		return edge.Properties.Data.(float64)
+	}
+
+	tests := map[string]struct {
+		spanningTree   func() (Graph[int, int], error)
+		expectedMissed [2]int
+	}{
+		"minimum spanning tree with stored weights": {
+			spanningTree: func() (Graph[int, int], error) {
+				return MinimumSpanningTree(g)
+			},
+			expectedMissed: [2]int{1, 3},
+		},
+		"minimum spanning tree with weight function": {
+			spanningTree: func() (Graph[int, int], error) {
+				return MinimumSpanningTree(g, WeightedBy(cost))
+			},
+			expectedMissed: [2]int{1, 2},
+		},
+		"maximum spanning tree with weight function": {
+			spanningTree: func() (Graph[int, int], error) {
+				return MaximumSpanningTree(g, WeightedBy(cost))
+			},
+			expectedMissed: [2]int{1, 3},
+		},
+	}
+
+	for name, test := range tests {
+		tree, err := test.spanningTree()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		size, _ := tree.Size()
+		if size != 2 {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, 2, size)
+		}
+
+		missed := test.expectedMissed
+		if _, err := tree.Edge(missed[0], missed[1]); !errors.Is(err, ErrEdgeNotFound) {
+			t.Errorf("%s: expected edge (%v, %v) not to be part of the tree, got error %v", name, missed[0], missed[1], err)
+		}
+	}
+}
@@ -9,14 +9,30 @@ type undirected[K comparable, T any] struct {
 	hash   Hash[K, T]
 	traits *Traits
 	store  Store[K, T]
+	hooks  *hooks[K, T]
+
+	indexes   *vertexIndexes[K, T]
+	subgraphs *subgraphs[K, T]
+
+	// forest keeps track of the connected components if cycles are prevented.
+	forest *forest[K, T]
 }
 
 func newUndirected[K comparable, T any](hash Hash[K, T], traits *Traits, store Store[K, T]) *undirected[K, T] {
-	return &undirected[K, T]{
-		hash:   hash,
-		traits: traits,
-		store:  store,
+	u := &undirected[K, T]{
+		hash:      hash,
+		traits:    traits,
+		store:     store,
+		hooks:     newHooks[K, T](),
+		indexes:   newVertexIndexes[K, T](),
+		subgraphs: newSubgraphs[K, T](),
+	}
+
+	if traits.PreventCycles {
+		u.forest = newForest(store, u.hooks)
 	}
+
+	return u
 }
 
 func (u *undirected[K, T]) Traits() *Traits {
@@ -35,39 +51,51 @@ func (u *undirected[K, T]) AddVertex(value T, options ...func(*VertexProperties)
 		option(&prop)
 	}
 
-	return u.store.AddVertex(hash, value, prop)
+	if err := u.store.AddVertex(hash, value, prop); err != nil {
+		return vertexError(hash, err)
+	}
+
+	u.hooks.vertexAdded(hash, value, prop)
+
+	return nil
 }
 
 func (u *undirected[K, T]) Vertex(hash K) (T, error) {
 	vertex, _, err := u.store.Vertex(hash)
-	return vertex, err
+	return vertex, vertexError(hash, err)
 }
 
 func (u *undirected[K, T]) VertexWithProperties(hash K) (T, VertexProperties, error) {
 	vertex, prop, err := u.store.Vertex(hash)
 	if err != nil {
-		return vertex, VertexProperties{}, err
+		return vertex, VertexProperties{}, vertexError(hash, err)
 	}
 
 	return vertex, prop, nil
 }
 
 func (u *undirected[K, T]) RemoveVertex(hash K) error {
-	return u.store.RemoveVertex(hash)
+	if err := u.store.RemoveVertex(hash); err != nil {
+		return vertexError(hash, err)
+	}
+
+	u.hooks.vertexRemoved(hash)
+
+	return nil
 }
 
 func (u *undirected[K, T]) AddEdge(sourceHash, targetHash K, options ...func(*EdgeProperties)) error {
 	if _, _, err := u.store.Vertex(sourceHash); err != nil {
-		return fmt.Errorf("could not find source vertex with hash %v: %w", sourceHash, err)
+		return fmt.Errorf("could not find source vertex with hash %v: %w", sourceHash, vertexError(sourceHash, err))
 	}
 
 	if _, _, err := u.store.Vertex(targetHash); err != nil {
-		return fmt.Errorf("could not find target vertex with hash %v: %w", targetHash, err)
+		return fmt.Errorf("could not find target vertex with hash %v: %w", targetHash, vertexError(targetHash, err))
 	}
 
 	//nolint:govet // False positive.
 	if _, err := u.Edge(sourceHash, targetHash); !errors.Is(err, ErrEdgeNotFound) {
-		return ErrEdgeAlreadyExists
+		return &EdgeAlreadyExistsError[K]{Source: sourceHash, Target: targetHash}
 	}
 
 	// If the user opted in to preventing cycles, run a cycle check.
@@ -77,7 +105,7 @@ func (u *undirected[K, T]) AddEdge(sourceHash, targetHash K, options ...func(*Ed
 			return fmt.Errorf("check for cycles: %w", err)
 		}
 		if createsCycle {
-			return ErrEdgeCreatesCycle
+			return &EdgeCreatesCycleError[K]{Source: sourceHash, Target: targetHash}
 		}
 	}
 
@@ -94,9 +122,11 @@ func (u *undirected[K, T]) AddEdge(sourceHash, targetHash K, options ...func(*Ed
 	}
 
 	if err := u.addEdge(sourceHash, targetHash, edge); err != nil {
-		return fmt.Errorf("failed to add edge: %w", err)
+		return fmt.Errorf("failed to add edge: %w", edgeError(sourceHash, targetHash, err))
 	}
 
+	u.hooks.edgeAdded(edge)
+
 	return nil
 }
 
@@ -106,6 +136,28 @@ func (u *undirected[K, T]) AddEdgesFrom(g Graph[K, T]) error {
 		return fmt.Errorf("failed to get edges: %w", err)
 	}
 
+	// If the store supports adding all edges at once, validate them upfront
+	// and add them in bulk. This isn't possible if cycles have to be prevented,
+	// because each edge could create a cycle with the edges added before.
+	if bulkStore, ok := u.store.(BulkStore[K]); ok && !u.traits.PreventCycles {
+		bulk, err := bulkEdges(u.store, edges, true)
+		if err != nil {
+			return err
+		}
+
+		if err := bulkStore.AddEdges(bulk); err != nil {
+			return fmt.Errorf("failed to add edges: %w", err)
+		}
+
+		// The bulk contains each edge in both directions, but hooks are only
+		// called once per edge.
+		for i := 0; i < len(bulk); i += 2 {
+			u.hooks.edgeAdded(bulk[i])
+		}
+
+		return nil
+	}
+
 	for _, edge := range edges {
 		if err := u.AddEdge(copyEdge(edge)); err != nil {
 			return fmt.Errorf("failed to add (%v, %v): %w", edge.Source, edge.Target, err)
@@ -115,6 +167,36 @@ func (u *undirected[K, T]) AddEdgesFrom(g Graph[K, T]) error {
 	return nil
 }
 
+// addEdgesTrusted works just as directed.addEdgesTrusted. Each of the given
+// edges must only appear in one direction.
+func (u *undirected[K, T]) addEdgesTrusted(edges []Edge[K]) error {
+	if bulkStore, ok := u.store.(BulkStore[K]); ok {
+		bulk := trustedEdges(edges, true)
+
+		if err := bulkStore.AddEdges(bulk); err != nil {
+			return fmt.Errorf("failed to add edges: %w", err)
+		}
+
+		// The bulk contains each edge in both directions, but hooks are only
+		// called once per edge.
+		for i := 0; i < len(bulk); i += 2 {
+			u.hooks.edgeAdded(bulk[i])
+		}
+
+		return nil
+	}
+
+	for _, edge := range trustedEdges(edges, false) {
+		if err := u.addEdge(edge.Source, edge.Target, edge); err != nil {
+			return fmt.Errorf("failed to add (%v, %v): %w", edge.Source, edge.Target, err)
+		}
+
+		u.hooks.edgeAdded(edge)
+	}
+
+	return nil
+}
+
 func (u *undirected[K, T]) AddVerticesFrom(g Graph[K, T]) error {
 	adjacencyMap, err := g.AdjacencyMap()
 	if err != nil {
@@ -144,18 +226,21 @@ func (u *undirected[K, T]) Edge(sourceHash, targetHash K) (Edge[T], error) {
 		edge, err = u.store.Edge(targetHash, sourceHash)
 	}
 
+	if errors.Is(err, ErrEdgeNotFound) {
+		return Edge[T]{}, &EdgeNotFoundError[K]{Source: sourceHash, Target: targetHash}
+	}
 	if err != nil {
 		return Edge[T]{}, err
 	}
 
 	sourceVertex, _, err := u.store.Vertex(sourceHash)
 	if err != nil {
-		return Edge[T]{}, err
+		return Edge[T]{}, vertexError(sourceHash, err)
 	}
 
 	targetVertex, _, err := u.store.Vertex(targetHash)
 	if err != nil {
-		return Edge[T]{}, err
+		return Edge[T]{}, vertexError(targetHash, err)
 	}
 
 	return Edge[T]{
@@ -174,43 +259,28 @@ type tuple[K comparable] struct {
 }
 
 func (u *undirected[K, T]) Edges() ([]Edge[K], error) {
-	storedEdges, err := u.store.ListEdges()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get edges: %w", err)
-	}
-
 	// An undirected graph creates each edge twice internally: The edge (A,B) is
 	// stored both as (A,B) and (B,A). The Edges method is supposed to return
 	// one of these two edges, because from an outside perspective, it only is
-	// a single edge.
-	//
-	// To achieve this, Edges keeps track of already-added edges. For each edge,
-	// it also checks if the reversed edge has already been added - e.g., for
-	// an edge (A,B), Edges checks if the edge has been added as (B,A).
-	//
-	// These reversed edges are built as a custom tuple type, which is then used
-	// as a map key for access in O(1) time. It looks scarier than it is.
-	edges := make([]Edge[K], 0, len(storedEdges)/2)
-
-	added := make(map[tuple[K]]struct{})
-
-	for _, storedEdge := range storedEdges {
-		reversedEdge := tuple[K]{
-			source: storedEdge.Target,
-			target: storedEdge.Source,
-		}
-		if _, ok := added[reversedEdge]; ok {
-			continue
-		}
+	// a single edge. To achieve this without keeping track of all returned
+	// edges, Edges only returns the canonical direction of each edge.
+	size, err := u.Size()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get size: %w", err)
+	}
 
-		edges = append(edges, storedEdge)
+	edges := make([]Edge[K], 0, size)
 
-		addedEdge := tuple[K]{
-			source: storedEdge.Source,
-			target: storedEdge.Target,
-		}
+	isCanonical := canonicalDirection[K]()
 
-		added[addedEdge] = struct{}{}
+	err = iterEdges(u.store, func(storedEdge Edge[K]) bool {
+		if isCanonical(storedEdge) {
+			edges = append(edges, storedEdge)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
 	}
 
 	return edges, nil
@@ -219,7 +289,7 @@ func (u *undirected[K, T]) Edges() ([]Edge[K], error) {
 func (u *undirected[K, T]) UpdateEdge(source, target K, options ...func(properties *EdgeProperties)) error {
 	existingEdge, err := u.store.Edge(source, target)
 	if err != nil {
-		return err
+		return edgeError(source, target, err)
 	}
 
 	for _, option := range options {
@@ -227,14 +297,20 @@ func (u *undirected[K, T]) UpdateEdge(source, target K, options ...func(properti
 	}
 
 	if err := u.store.UpdateEdge(source, target, existingEdge); err != nil {
-		return err
+		return edgeError(source, target, err)
 	}
 
 	reversedEdge := existingEdge
 	reversedEdge.Source = existingEdge.Target
 	reversedEdge.Target = existingEdge.Source
 
-	return u.store.UpdateEdge(target, source, reversedEdge)
+	if err := u.store.UpdateEdge(target, source, reversedEdge); err != nil {
+		return edgeError(target, source, err)
+	}
+
+	u.hooks.edgeUpdated(existingEdge)
+
+	return nil
 }
 
 func (u *undirected[K, T]) RemoveEdge(source, target K) error {
@@ -250,30 +326,30 @@ func (u *undirected[K, T]) RemoveEdge(source, target K) error {
 		return fmt.Errorf("failed to remove edge from %v to %v: %w", target, source, err)
 	}
 
+	u.hooks.edgeRemoved(source, target)
+
 	return nil
 }
 
 func (u *undirected[K, T]) AdjacencyMap() (map[K]map[K]Edge[K], error) {
-	vertices, err := u.store.ListVertices()
+	m := make(map[K]map[K]Edge[K])
+
+	err := iterVertexHashes(u.store, func(hash K) bool {
+		m[hash] = make(map[K]Edge[K])
+		return true
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list vertices: %w", err)
 	}
 
-	edges, err := u.store.ListEdges()
+	err = iterEdges(u.store, func(edge Edge[K]) bool {
+		m[edge.Source][edge.Target] = edge
+		return true
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list edges: %w", err)
 	}
 
-	m := make(map[K]map[K]Edge[K], len(vertices))
-
-	for _, vertex := range vertices {
-		m[vertex] = make(map[K]Edge[K])
-	}
-
-	for _, edge := range edges {
-		m[edge.Source][edge.Target] = edge
-	}
-
 	return m, nil
 }
 
@@ -290,16 +366,31 @@ func (u *undirected[K, T]) Clone() (Graph[K, T], error) {
 	}
 
 	clone := &undirected[K, T]{
-		hash:   u.hash,
-		traits: traits,
-		store:  newMemoryStore[K, T](),
+		hash:      u.hash,
+		traits:    traits,
+		store:     newMemoryStore[K, T](),
+		hooks:     newHooks[K, T](),
+		indexes:   newVertexIndexes[K, T](),
+		subgraphs: newSubgraphs[K, T](),
+	}
+
+	if store, ok := u.store.(*memoryStore[K, T]); ok {
+		clone.store = store.fork()
+		return clone, nil
 	}
 
 	if err := clone.AddVerticesFrom(u); err != nil {
 		return nil, fmt.Errorf("failed to add vertices: %w", err)
 	}
 
-	if err := clone.AddEdgesFrom(u); err != nil {
+	// The clone has the same traits and vertices as u but no edges yet, so the
+	// edges of u can be added without validating them.
+	edges, err := u.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	if err := clone.addEdgesTrusted(edges); err != nil {
 		return nil, fmt.Errorf("failed to add edges: %w", err)
 	}
 
@@ -311,6 +402,10 @@ func (u *undirected[K, T]) Order() (int, error) {
 }
 
 func (u *undirected[K, T]) Size() (int, error) {
+	if counter, ok := u.store.(pairCounter); ok {
+		return counter.edgePairCount()
+	}
+
 	edgeCount, err := u.store.EdgeCount()
 
 	// Divide by 2 since every add edge operation on undirected graph is counted
@@ -318,6 +413,39 @@ func (u *undirected[K, T]) Size() (int, error) {
 	return edgeCount / 2, err
 }
 
+// canonicalDirection returns a function that reports whether the given stored
+// edge of an undirected graph is the canonical one of its two directions. The
+// canonical direction is the one whose source hash is smaller than its target
+// hash according to compareHashes.
+//
+// Only if both hashes are considered equal, e.g. because they have the same
+// string representation, the function falls back to keeping track of these
+// edges in a map, so that memory is only allocated for such rare edges.
+func canonicalDirection[K comparable]() func(edge Edge[K]) bool {
+	var ties map[tuple[K]]struct{}
+
+	return func(edge Edge[K]) bool {
+		switch compareHashes(edge.Source, edge.Target) {
+		case -1:
+			return true
+		case 1:
+			return false
+		}
+
+		if ties == nil {
+			ties = make(map[tuple[K]]struct{})
+		}
+
+		if _, ok := ties[tuple[K]{source: edge.Target, target: edge.Source}]; ok {
+			return false
+		}
+
+		ties[tuple[K]{source: edge.Source, target: edge.Target}] = struct{}{}
+
+		return true
+	}
+}
+
 func (u *undirected[K, T]) edgesAreEqual(a, b Edge[T]) bool {
 	aSourceHash := u.hash(a.Source)
 	aTargetHash := u.hash(a.Target)
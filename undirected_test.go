@@ -77,7 +77,7 @@ func TestUndirected_AddVertex(t *testing.T) {
 			}
 		}
 
-		if err != test.finallyExpectedError {
+		if !errors.Is(err, test.finallyExpectedError) {
 			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.finallyExpectedError, err)
 		}
 
@@ -238,7 +238,7 @@ func TestUndirected_Vertex(t *testing.T) {
 
 		vertex, err := graph.Vertex(test.vertex)
 
-		if err != test.expectedError {
+		if !errors.Is(err, test.expectedError) {
 			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedError, err)
 		}
 
@@ -763,12 +763,16 @@ func TestUndirected_Edges(t *testing.T) {
 				t.Fatalf("unexpected error: %v", err.Error())
 			}
 
+			if len(edges) != len(test.expectedEdges) {
+				t.Fatalf("%s: number of edges doesn't match: expected %v, got %v", name, len(test.expectedEdges), len(edges))
+			}
+
 			for _, expectedEdge := range test.expectedEdges {
 				for _, actualEdge := range edges {
-					if actualEdge.Source != expectedEdge.Source || actualEdge.Target != expectedEdge.Target {
+					if !edgesAreEqual(expectedEdge, actualEdge, false) {
 						continue
 					}
-					if !edgesAreEqual(expectedEdge, actualEdge, false) {
+					if actualEdge.Properties.Weight != expectedEdge.Properties.Weight {
 						t.Errorf("%s: expected edge %v, got %v", name, expectedEdge, actualEdge)
 					}
 				}
@@ -777,6 +781,44 @@ func TestUndirected_Edges(t *testing.T) {
 	}
 }
 
+func TestUndirected_EdgesWithEqualStringRepresentations(t *testing.T) {
+	type key struct {
+		first  string
+		second string
+	}
+
+	// Both keys are printed as {a b c}, so compareHashes considers them equal.
+	a, b, c := key{"a b", "c"}, key{"a", "b c"}, key{"d", "e"}
+
+	g := New(func(k key) key { return k })
+
+	for _, vertex := range []key{a, b, c} {
+		_ = g.AddVertex(vertex)
+	}
+
+	_ = g.AddEdge(a, b)
+	_ = g.AddEdge(b, c)
+	_ = g.AddEdge(c, c)
+
+	edges, err := g.Edges()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err.Error())
+	}
+
+	if len(edges) != 3 {
+		t.Fatalf("number of edges doesn't match: expected %v, got %v", 3, len(edges))
+	}
+
+	seen := make(map[tuple[key]]struct{})
+
+	for _, edge := range edges {
+		if _, ok := seen[tuple[key]{source: edge.Target, target: edge.Source}]; ok {
+			t.Errorf("edge %v has been returned in both directions", edge)
+		}
+		seen[tuple[key]{source: edge.Source, target: edge.Target}] = struct{}{}
+	}
+}
+
 func TestUndirected_UpdateEdge(t *testing.T) {
 	tests := map[string]struct {
 		vertices    []int
@@ -903,7 +945,7 @@ func TestUndirected_RemoveEdge(t *testing.T) {
 			}
 			// After removing the edge, verify that it can't be retrieved using
 			// Edge anymore.
-			if _, err := graph.Edge(removeEdge.Source, removeEdge.Target); err != ErrEdgeNotFound {
+			if _, err := graph.Edge(removeEdge.Source, removeEdge.Target); !errors.Is(err, ErrEdgeNotFound) {
 				t.Fatalf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrEdgeNotFound, err)
 			}
 		}
@@ -0,0 +1,358 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ViolationKind is the kind of invariant violated by a graph, as reported by
+// [Validate].
+type ViolationKind int
+
+const (
+	// DanglingEdge means that the source or target of an edge doesn't exist.
+	DanglingEdge ViolationKind = iota
+	// AsymmetricEdge means that an undirected graph stores an edge in only one
+	// direction.
+	AsymmetricEdge
+	// Cycle means that an acyclic graph contains a cycle.
+	Cycle
+	// MissingRoot means that a rooted graph has no vertex without predecessors.
+	MissingRoot
+	// MultipleRoots means that a rooted graph has more than one vertex without
+	// predecessors.
+	MultipleRoots
+	// UnreachableVertices means that some vertices of a rooted graph can't be
+	// reached from its root.
+	UnreachableVertices
+)
+
+// String returns the name of the violation kind, such as "DanglingEdge".
+func (v ViolationKind) String() string {
+	switch v {
+	case DanglingEdge:
+		return "DanglingEdge"
+	case AsymmetricEdge:
+		return "AsymmetricEdge"
+	case Cycle:
+		return "Cycle"
+	case MissingRoot:
+		return "MissingRoot"
+	case MultipleRoots:
+		return "MultipleRoots"
+	case UnreachableVertices:
+		return "UnreachableVertices"
+	default:
+		return "Unknown"
+	}
+}
+
+// Violation describes a single violated invariant. Vertices and Edges contain
+// the vertices and edges involved in the violation, if any.
+type Violation[K comparable] struct {
+	Kind     ViolationKind
+	Message  string
+	Vertices []K
+	Edges    []Edge[K]
+}
+
+// String returns the kind and the message of the violation.
+func (v Violation[K]) String() string {
+	return fmt.Sprintf("%s: %s", v.Kind, v.Message)
+}
+
+// ValidationReport is the result of [Validate]. It lists all violations found
+// in the graph in a deterministic order.
+type ValidationReport[K comparable] struct {
+	Violations []Violation[K]
+}
+
+// Valid reports whether the graph didn't violate any invariant.
+func (r *ValidationReport[K]) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// String returns a human-readable summary of the report with one line for each
+// violation.
+func (r *ValidationReport[K]) String() string {
+	if r.Valid() {
+		return "graph is valid"
+	}
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "graph has %d violation(s):", len(r.Violations))
+
+	for _, violation := range r.Violations {
+		fmt.Fprintf(&builder, "\n  - %s", violation)
+	}
+
+	return builder.String()
+}
+
+func (r *ValidationReport[K]) add(kind ViolationKind, vertices []K, edges []Edge[K], format string, args ...any) {
+	r.Violations = append(r.Violations, Violation[K]{
+		Kind:     kind,
+		Message:  fmt.Sprintf(format, args...),
+		Vertices: vertices,
+		Edges:    edges,
+	})
+}
+
+// Validate checks the graph for violations of its invariants and traits, which
+// is useful after importing a graph or after modifying its store directly,
+// bypassing the checks of the graph:
+//
+//	report, _ := graph.Validate(g)
+//	if !report.Valid() {
+//		fmt.Println(report)
+//	}
+//
+// The following invariants are checked:
+//
+//   - The source and target of each edge exist.
+//   - Undirected graphs store each edge in both directions.
+//   - Acyclic graphs don't contain cycles.
+//   - Rooted directed graphs have exactly one vertex without predecessors, and
+//     all vertices are reachable from it. Rooted undirected graphs are
+//     connected.
+//
+// The checks operate on the store of the graph. For graphs that aren't
+// implemented by this library, the edges returned by Graph.Edges are checked
+// instead, and undirected edges aren't checked for symmetry.
+//
+// Violations are reported in the returned report. An error is only returned if
+// the graph can't be read.
+func Validate[K comparable, T any](g Graph[K, T]) (*ValidationReport[K], error) {
+	hashes, err := vertexHashes(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vertices: %w", err)
+	}
+
+	var edges []Edge[K]
+
+	store, hasStore := storeOf(g)
+	if hasStore {
+		err = iterEdges(store, func(edge Edge[K]) bool {
+			edges = append(edges, edge)
+			return true
+		})
+	} else {
+		edges, err = g.Edges()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
+	}
+
+	sortHashes(hashes)
+	sortEdgesWith(edges, orDefaultLess[K](nil))
+
+	vertices := make(map[K]struct{}, len(hashes))
+	for _, hash := range hashes {
+		vertices[hash] = struct{}{}
+	}
+
+	report := &ValidationReport[K]{}
+
+	// All further checks only consider edges between existing vertices.
+	valid := make([]Edge[K], 0, len(edges))
+
+	for _, edge := range edges {
+		_, sourceExists := vertices[edge.Source]
+		_, targetExists := vertices[edge.Target]
+
+		switch {
+		case !sourceExists && !targetExists:
+			report.add(DanglingEdge, nil, []Edge[K]{edge}, "source and target of edge (%v, %v) don't exist", edge.Source, edge.Target)
+		case !sourceExists:
+			report.add(DanglingEdge, nil, []Edge[K]{edge}, "source of edge (%v, %v) doesn't exist", edge.Source, edge.Target)
+		case !targetExists:
+			report.add(DanglingEdge, nil, []Edge[K]{edge}, "target of edge (%v, %v) doesn't exist", edge.Source, edge.Target)
+		default:
+			valid = append(valid, edge)
+		}
+	}
+
+	traits := g.Traits()
+
+	if traits.IsDirected {
+		validateDirected(report, traits, hashes, valid)
+	} else {
+		validateUndirected(report, traits, hashes, valid, hasStore)
+	}
+
+	return report, nil
+}
+
+func validateDirected[K comparable](report *ValidationReport[K], traits *Traits, hashes []K, edges []Edge[K]) {
+	successors := make(map[K][]K, len(hashes))
+	predecessors := make(map[K][]K, len(hashes))
+
+	for _, edge := range edges {
+		successors[edge.Source] = append(successors[edge.Source], edge.Target)
+		predecessors[edge.Target] = append(predecessors[edge.Target], edge.Source)
+	}
+
+	if traits.IsAcyclic {
+		if cyclic := cyclicVertices(hashes, successors, predecessors); len(cyclic) > 0 {
+			report.add(Cycle, cyclic, nil, "vertices %v are part of or lie between cycles", cyclic)
+		}
+	}
+
+	if !traits.IsRooted || len(hashes) == 0 {
+		return
+	}
+
+	roots := make([]K, 0)
+	for _, hash := range hashes {
+		if len(predecessors[hash]) == 0 {
+			roots = append(roots, hash)
+		}
+	}
+
+	switch {
+	case len(roots) == 0:
+		report.add(MissingRoot, nil, nil, "no vertex is without predecessors")
+	case len(roots) > 1:
+		report.add(MultipleRoots, roots, nil, "vertices %v are without predecessors", roots)
+	default:
+		if unreachable := unreachableVertices(hashes, roots[0], successors); len(unreachable) > 0 {
+			report.add(UnreachableVertices, unreachable, nil, "vertices %v aren't reachable from root %v", unreachable, roots[0])
+		}
+	}
+}
+
+func validateUndirected[K comparable](report *ValidationReport[K], traits *Traits, hashes []K, edges []Edge[K], checkSymmetry bool) {
+	stored := make(map[vertexPair[K]]struct{}, len(edges))
+	for _, edge := range edges {
+		stored[vertexPair[K]{edge.Source, edge.Target}] = struct{}{}
+	}
+
+	neighbors := make(map[K][]K, len(hashes))
+	components := newUnionFind(hashes...)
+	seen := make(map[vertexPair[K]]struct{}, len(edges))
+
+	for _, edge := range edges {
+		if checkSymmetry {
+			if _, ok := stored[vertexPair[K]{edge.Target, edge.Source}]; !ok {
+				report.add(AsymmetricEdge, nil, []Edge[K]{edge}, "edge (%v, %v) has no counterpart (%v, %v)", edge.Source, edge.Target, edge.Target, edge.Source)
+			}
+		}
+
+		// Each edge is considered once, regardless of its direction.
+		key := vertexPair[K]{edge.Source, edge.Target}
+		if compareHashes(edge.Target, edge.Source) < 0 {
+			key = vertexPair[K]{edge.Target, edge.Source}
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		neighbors[edge.Source] = append(neighbors[edge.Source], edge.Target)
+		neighbors[edge.Target] = append(neighbors[edge.Target], edge.Source)
+
+		if !traits.IsAcyclic {
+			continue
+		}
+
+		if components.find(edge.Source) == components.find(edge.Target) {
+			report.add(Cycle, []K{edge.Source, edge.Target}, []Edge[K]{edge}, "edge (%v, %v) closes a cycle", edge.Source, edge.Target)
+			continue
+		}
+
+		components.union(edge.Source, edge.Target)
+	}
+
+	if !traits.IsRooted || len(hashes) == 0 {
+		return
+	}
+
+	if unreachable := unreachableVertices(hashes, hashes[0], neighbors); len(unreachable) > 0 {
+		report.add(UnreachableVertices, unreachable, nil, "vertices %v aren't connected to vertex %v", unreachable, hashes[0])
+	}
+}
+
+// vertexPair is the source and target of an edge.
+type vertexPair[K comparable] struct {
+	source K
+	target K
+}
+
+// cyclicVertices returns the vertices that remain after repeatedly removing all
+// vertices without predecessors and all vertices without successors. These
+// are the vertices on cycles and on paths between cycles.
+func cyclicVertices[K comparable](hashes []K, successors, predecessors map[K][]K) []K {
+	inDegrees := make(map[K]int, len(hashes))
+	outDegrees := make(map[K]int, len(hashes))
+	removed := make(map[K]bool, len(hashes))
+	queue := make([]K, 0)
+
+	for _, hash := range hashes {
+		inDegrees[hash] = len(predecessors[hash])
+		outDegrees[hash] = len(successors[hash])
+
+		if inDegrees[hash] == 0 || outDegrees[hash] == 0 {
+			removed[hash] = true
+			queue = append(queue, hash)
+		}
+	}
+
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+
+		for _, successor := range successors[hash] {
+			inDegrees[successor]--
+			if inDegrees[successor] == 0 && !removed[successor] {
+				removed[successor] = true
+				queue = append(queue, successor)
+			}
+		}
+
+		for _, predecessor := range predecessors[hash] {
+			outDegrees[predecessor]--
+			if outDegrees[predecessor] == 0 && !removed[predecessor] {
+				removed[predecessor] = true
+				queue = append(queue, predecessor)
+			}
+		}
+	}
+
+	cyclic := make([]K, 0)
+	for _, hash := range hashes {
+		if !removed[hash] {
+			cyclic = append(cyclic, hash)
+		}
+	}
+
+	return cyclic
+}
+
+// unreachableVertices returns the vertices that can't be reached from start by
+// following the given neighbors.
+func unreachableVertices[K comparable](hashes []K, start K, neighbors map[K][]K) []K {
+	visited := map[K]bool{start: true}
+	queue := []K{start}
+
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+
+		for _, neighbor := range neighbors[hash] {
+			if !visited[neighbor] {
+				visited[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	unreachable := make([]K, 0)
+	for _, hash := range hashes {
+		if !visited[hash] {
+			unreachable = append(unreachable, hash)
+		}
+	}
+
+	return unreachable
+}
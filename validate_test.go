@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		traits        []func(*Traits)
+		vertices      []int
+		edges         []Edge[int]
+		expectedKinds []ViolationKind
+	}{
+		"valid directed graph": {
+			traits:   []func(*Traits){Directed(), Tree()},
+			vertices: []int{1, 2, 3},
+			edges:    []Edge[int]{{Source: 1, Target: 2}, {Source: 1, Target: 3}},
+		},
+		"valid undirected graph": {
+			traits:   []func(*Traits){Tree()},
+			vertices: []int{1, 2, 3},
+			edges:    []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 1}, {Source: 2, Target: 3}, {Source: 3, Target: 2}},
+		},
+		"dangling edge": {
+			traits:        []func(*Traits){Directed()},
+			vertices:      []int{1},
+			edges:         []Edge[int]{{Source: 1, Target: 2}},
+			expectedKinds: []ViolationKind{DanglingEdge},
+		},
+		"asymmetric edge": {
+			vertices:      []int{1, 2},
+			edges:         []Edge[int]{{Source: 1, Target: 2}},
+			expectedKinds: []ViolationKind{AsymmetricEdge},
+		},
+		"directed cycle": {
+			traits:        []func(*Traits){Directed(), Acyclic()},
+			vertices:      []int{1, 2, 3},
+			edges:         []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 3}, {Source: 3, Target: 2}},
+			expectedKinds: []ViolationKind{Cycle},
+		},
+		"undirected cycle": {
+			traits:        []func(*Traits){Acyclic()},
+			vertices:      []int{1, 2, 3},
+			edges:         []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 1}, {Source: 2, Target: 3}, {Source: 3, Target: 2}, {Source: 3, Target: 1}, {Source: 1, Target: 3}},
+			expectedKinds: []ViolationKind{Cycle},
+		},
+		"cycle without acyclic trait": {
+			traits:   []func(*Traits){Directed()},
+			vertices: []int{1, 2},
+			edges:    []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 1}},
+		},
+		"missing root": {
+			traits:        []func(*Traits){Directed(), Rooted()},
+			vertices:      []int{1, 2},
+			edges:         []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 1}},
+			expectedKinds: []ViolationKind{MissingRoot},
+		},
+		"multiple roots": {
+			traits:        []func(*Traits){Directed(), Rooted()},
+			vertices:      []int{1, 2, 3},
+			edges:         []Edge[int]{{Source: 1, Target: 3}, {Source: 2, Target: 3}},
+			expectedKinds: []ViolationKind{MultipleRoots},
+		},
+		"unreachable vertices": {
+			traits:        []func(*Traits){Directed(), Rooted()},
+			vertices:      []int{1, 2, 3, 4},
+			edges:         []Edge[int]{{Source: 1, Target: 2}, {Source: 3, Target: 4}, {Source: 4, Target: 3}},
+			expectedKinds: []ViolationKind{UnreachableVertices},
+		},
+		"disconnected undirected graph": {
+			traits:        []func(*Traits){Rooted()},
+			vertices:      []int{1, 2, 3},
+			edges:         []Edge[int]{{Source: 1, Target: 2}, {Source: 2, Target: 1}},
+			expectedKinds: []ViolationKind{UnreachableVertices},
+		},
+	}
+
+	for name, test := range tests {
+		// The vertices and edges are added to the store directly, bypassing
+		// the checks of the graph.
+		store := newMemoryStore[int, int]()
+
+		for _, vertex := range test.vertices {
+			_ = store.AddVertex(vertex, vertex, VertexProperties{})
+		}
+
+		for _, edge := range test.edges {
+			if err := store.AddEdge(edge.Source, edge.Target, edge); err != nil {
+				t.Fatalf("%s: failed to add edge: %v", name, err)
+			}
+		}
+
+		g := NewWithStore(IntHash, store, test.traits...)
+
+		report, err := Validate(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		kinds := make([]ViolationKind, 0)
+		for _, violation := range report.Violations {
+			kinds = append(kinds, violation.Kind)
+		}
+
+		if test.expectedKinds == nil {
+			test.expectedKinds = []ViolationKind{}
+		}
+
+		if !reflect.DeepEqual(kinds, test.expectedKinds) {
+			t.Errorf("%s: violations don't match: expected %v, got %v", name, test.expectedKinds, report)
+		}
+
+		if report.Valid() != (len(test.expectedKinds) == 0) {
+			t.Errorf("%s: validity doesn't match: expected %v, got %v", name, len(test.expectedKinds) == 0, report.Valid())
+		}
+	}
+}
+
+func TestValidate_report(t *testing.T) {
+	store := newMemoryStore[int, int]()
+
+	for i := 1; i <= 4; i++ {
+		_ = store.AddVertex(i, i, VertexProperties{})
+	}
+
+	_ = store.AddEdge(1, 2, Edge[int]{Source: 1, Target: 2})
+	_ = store.AddEdge(2, 3, Edge[int]{Source: 2, Target: 3})
+	_ = store.AddEdge(3, 2, Edge[int]{Source: 3, Target: 2})
+	_ = store.AddEdge(3, 4, Edge[int]{Source: 3, Target: 4})
+
+	g := NewWithStore(IntHash, store, Directed(), Acyclic())
+
+	report, _ := Validate(g)
+
+	if len(report.Violations) != 1 {
+		t.Fatalf("number of violations doesn't match: expected %v, got %v", 1, len(report.Violations))
+	}
+
+	if expected := []int{2, 3}; !reflect.DeepEqual(report.Violations[0].Vertices, expected) {
+		t.Errorf("cyclic vertices don't match: expected %v, got %v", expected, report.Violations[0].Vertices)
+	}
+
+	expected := "graph has 1 violation(s):\n  - Cycle: vertices [2 3] are part of or lie between cycles"
+
+	if report.String() != expected {
+		t.Errorf("report doesn't match: expected %q, got %q", expected, report.String())
+	}
+}
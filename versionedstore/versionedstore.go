@@ -0,0 +1,464 @@
+// Package versionedstore provides a [graph.Store] wrapper that records each
+// mutation with a monotonically increasing revision. The recorded history can
+// be used to inspect the graph as it was at an earlier revision and to compute
+// the differences between two revisions, for example to audit the changes to a
+// dependency graph.
+//
+//	store, _ := versionedstore.Wrap[string, string](backingStore)
+//	g := graph.NewWithStore(graph.StringHash, graph.Store[string, string](store), graph.Directed())
+//
+//	_ = g.AddVertex("A")
+//	_ = g.AddVertex("B")
+//	_ = g.AddEdge("A", "B")
+//
+//	old, _ := store.AtRevision(2)
+//	h := graph.NewWithStore(graph.StringHash, old, graph.Directed())
+//
+//	diff, _ := store.Diff(2, store.Revision())
+//	fmt.Println(diff.AddedEdges)
+//
+// The history is kept in memory and grows with every mutation. Reading the
+// graph at a revision replays the history up to that revision, which takes
+// O(n) time for n recorded mutations.
+package versionedstore
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dominikbraun/graph"
+)
+
+var (
+	// ErrReadOnly is returned when attempting to modify a store returned by
+	// [Store.AtRevision].
+	ErrReadOnly = errors.New("store is read-only")
+
+	// ErrRevisionNotFound is returned when requesting a revision that doesn't
+	// exist yet.
+	ErrRevisionNotFound = errors.New("revision not found")
+)
+
+// Operation is the type of mutation recorded by a [Change].
+type Operation int
+
+const (
+	AddVertex Operation = iota
+	RemoveVertex
+	AddEdge
+	UpdateEdge
+	RemoveEdge
+)
+
+// Change is a single recorded mutation. For vertex operations, Hash, Value, and
+// Properties are set. For edge operations, Edge is set. The edge recorded for
+// RemoveEdge only contains the source and target hashes.
+type Change[K comparable, T any] struct {
+	Revision   uint64
+	Operation  Operation
+	Hash       K
+	Value      T
+	Properties graph.VertexProperties
+	Edge       graph.Edge[K]
+}
+
+// Diff contains the differences between two revisions. Vertices and edges that
+// have been removed and added again in between are considered unchanged unless
+// their properties differ.
+type Diff[K comparable] struct {
+	AddedVertices   []K
+	RemovedVertices []K
+	AddedEdges      []graph.Edge[K]
+	RemovedEdges    []graph.Edge[K]
+	UpdatedEdges    []graph.Edge[K]
+}
+
+// Store is a [graph.Store] wrapper that records the history of all mutations.
+type Store[K comparable, T any] struct {
+	lock    sync.RWMutex
+	backing graph.Store[K, T]
+	// base contains the changes that rebuild the contents of the backing store
+	// at the time it has been wrapped. They all have revision 0.
+	base    []Change[K, T]
+	changes []Change[K, T]
+}
+
+// Wrap returns a Store that records all mutations of s. The vertices and edges
+// that s already contains form revision 0.
+func Wrap[K comparable, T any](s graph.Store[K, T]) (*Store[K, T], error) {
+	store := &Store[K, T]{
+		backing: s,
+		base:    make([]Change[K, T], 0),
+		changes: make([]Change[K, T], 0),
+	}
+
+	hashes, err := s.ListVertices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vertices: %w", err)
+	}
+
+	for _, hash := range hashes {
+		value, properties, err := s.Vertex(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vertex %v: %w", hash, err)
+		}
+		store.base = append(store.base, Change[K, T]{
+			Operation:  AddVertex,
+			Hash:       hash,
+			Value:      value,
+			Properties: properties,
+		})
+	}
+
+	edges, err := s.ListEdges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edges: %w", err)
+	}
+
+	for _, edge := range edges {
+		store.base = append(store.base, Change[K, T]{
+			Operation: AddEdge,
+			Edge:      edge,
+		})
+	}
+
+	return store, nil
+}
+
+// Revision returns the current revision, which is the number of mutations
+// recorded so far.
+func (s *Store[K, T]) Revision() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return uint64(len(s.changes))
+}
+
+// History returns the changes with revisions in the range (from, to], in the
+// order they have been made.
+func (s *Store[K, T]) History(from, to uint64) ([]Change[K, T], error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if err := s.checkRange(from, to); err != nil {
+		return nil, err
+	}
+
+	history := make([]Change[K, T], to-from)
+	copy(history, s.changes[from:to])
+
+	return history, nil
+}
+
+// AtRevision returns a read-only store that contains the vertices and edges as
+// they were at the given revision. All mutations of the returned store return
+// ErrReadOnly.
+func (s *Store[K, T]) AtRevision(revision uint64) (graph.Store[K, T], error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if err := s.checkRange(0, revision); err != nil {
+		return nil, err
+	}
+
+	store, err := s.replay(revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return readOnlyStore[K, T]{store}, nil
+}
+
+// Diff computes the differences between the graph at revision from and the
+// graph at revision to.
+func (s *Store[K, T]) Diff(from, to uint64) (Diff[K], error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var diff Diff[K]
+
+	if err := s.checkRange(from, to); err != nil {
+		return diff, err
+	}
+
+	before, err := s.replay(from)
+	if err != nil {
+		return diff, err
+	}
+
+	after, err := s.replay(to)
+	if err != nil {
+		return diff, err
+	}
+
+	beforeVertices, _ := before.ListVertices()
+	afterVertices, _ := after.ListVertices()
+
+	for _, hash := range afterVertices {
+		if _, _, err := before.Vertex(hash); errors.Is(err, graph.ErrVertexNotFound) {
+			diff.AddedVertices = append(diff.AddedVertices, hash)
+		}
+	}
+
+	for _, hash := range beforeVertices {
+		if _, _, err := after.Vertex(hash); errors.Is(err, graph.ErrVertexNotFound) {
+			diff.RemovedVertices = append(diff.RemovedVertices, hash)
+		}
+	}
+
+	beforeEdges, _ := before.ListEdges()
+	afterEdges, _ := after.ListEdges()
+
+	for _, edge := range afterEdges {
+		previous, err := before.Edge(edge.Source, edge.Target)
+		if errors.Is(err, graph.ErrEdgeNotFound) {
+			diff.AddedEdges = append(diff.AddedEdges, edge)
+		} else if !edgePropertiesAreEqual(previous.Properties, edge.Properties) {
+			diff.UpdatedEdges = append(diff.UpdatedEdges, edge)
+		}
+	}
+
+	for _, edge := range beforeEdges {
+		if _, err := after.Edge(edge.Source, edge.Target); errors.Is(err, graph.ErrEdgeNotFound) {
+			diff.RemovedEdges = append(diff.RemovedEdges, edge)
+		}
+	}
+
+	return diff, nil
+}
+
+func (s *Store[K, T]) checkRange(from, to uint64) error {
+	if to > uint64(len(s.changes)) {
+		return fmt.Errorf("revision %v: %w", to, ErrRevisionNotFound)
+	}
+
+	if from > to {
+		return fmt.Errorf("revision %v is greater than revision %v", from, to)
+	}
+
+	return nil
+}
+
+// replay builds an in-memory store containing the base contents and all
+// changes up to the given revision. The caller must hold the read lock.
+func (s *Store[K, T]) replay(revision uint64) (graph.Store[K, T], error) {
+	store := graph.NewMemoryStoreWithCapacity[K, T](0, 0)
+
+	for _, changes := range [][]Change[K, T]{s.base, s.changes[:revision]} {
+		for _, change := range changes {
+			if err := apply(store, change); err != nil {
+				return nil, fmt.Errorf("failed to replay revision %v: %w", change.Revision, err)
+			}
+		}
+	}
+
+	return store, nil
+}
+
+func apply[K comparable, T any](store graph.Store[K, T], change Change[K, T]) error {
+	switch change.Operation {
+	case AddVertex:
+		return store.AddVertex(change.Hash, change.Value, change.Properties)
+	case RemoveVertex:
+		return store.RemoveVertex(change.Hash)
+	case AddEdge:
+		return store.AddEdge(change.Edge.Source, change.Edge.Target, change.Edge)
+	case UpdateEdge:
+		return store.UpdateEdge(change.Edge.Source, change.Edge.Target, change.Edge)
+	case RemoveEdge:
+		return store.RemoveEdge(change.Edge.Source, change.Edge.Target)
+	default:
+		return fmt.Errorf("unknown operation %v", change.Operation)
+	}
+}
+
+// record appends the given change with the next revision. The caller must hold
+// the write lock.
+func (s *Store[K, T]) record(change Change[K, T]) {
+	change.Revision = uint64(len(s.changes)) + 1
+	s.changes = append(s.changes, change)
+}
+
+func (s *Store[K, T]) AddVertex(hash K, value T, properties graph.VertexProperties) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.backing.AddVertex(hash, value, properties); err != nil {
+		return err
+	}
+
+	s.record(Change[K, T]{
+		Operation:  AddVertex,
+		Hash:       hash,
+		Value:      value,
+		Properties: copyVertexProperties(properties),
+	})
+
+	return nil
+}
+
+func (s *Store[K, T]) Vertex(hash K) (T, graph.VertexProperties, error) {
+	return s.backing.Vertex(hash)
+}
+
+func (s *Store[K, T]) RemoveVertex(hash K) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.backing.RemoveVertex(hash); err != nil {
+		return err
+	}
+
+	s.record(Change[K, T]{
+		Operation: RemoveVertex,
+		Hash:      hash,
+	})
+
+	return nil
+}
+
+func (s *Store[K, T]) ListVertices() ([]K, error) {
+	return s.backing.ListVertices()
+}
+
+func (s *Store[K, T]) VertexCount() (int, error) {
+	return s.backing.VertexCount()
+}
+
+func (s *Store[K, T]) AddEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.backing.AddEdge(sourceHash, targetHash, edge); err != nil {
+		return err
+	}
+
+	s.record(Change[K, T]{
+		Operation: AddEdge,
+		Edge:      copyEdge(edge),
+	})
+
+	return nil
+}
+
+func (s *Store[K, T]) UpdateEdge(sourceHash, targetHash K, edge graph.Edge[K]) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.backing.UpdateEdge(sourceHash, targetHash, edge); err != nil {
+		return err
+	}
+
+	s.record(Change[K, T]{
+		Operation: UpdateEdge,
+		Edge:      copyEdge(edge),
+	})
+
+	return nil
+}
+
+func (s *Store[K, T]) RemoveEdge(sourceHash, targetHash K) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.backing.RemoveEdge(sourceHash, targetHash); err != nil {
+		return err
+	}
+
+	s.record(Change[K, T]{
+		Operation: RemoveEdge,
+		Edge: graph.Edge[K]{
+			Source: sourceHash,
+			Target: targetHash,
+		},
+	})
+
+	return nil
+}
+
+func (s *Store[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[K], error) {
+	return s.backing.Edge(sourceHash, targetHash)
+}
+
+func (s *Store[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	return s.backing.ListEdges()
+}
+
+func (s *Store[K, T]) EdgeCount() (int, error) {
+	return s.backing.EdgeCount()
+}
+
+// readOnlyStore is a store that rejects all mutations.
+type readOnlyStore[K comparable, T any] struct {
+	graph.Store[K, T]
+}
+
+func (r readOnlyStore[K, T]) AddVertex(K, T, graph.VertexProperties) error {
+	return ErrReadOnly
+}
+
+func (r readOnlyStore[K, T]) RemoveVertex(K) error {
+	return ErrReadOnly
+}
+
+func (r readOnlyStore[K, T]) AddEdge(K, K, graph.Edge[K]) error {
+	return ErrReadOnly
+}
+
+func (r readOnlyStore[K, T]) UpdateEdge(K, K, graph.Edge[K]) error {
+	return ErrReadOnly
+}
+
+func (r readOnlyStore[K, T]) RemoveEdge(K, K) error {
+	return ErrReadOnly
+}
+
+// copyVertexProperties and copyEdge copy the attributes and weights, so that
+// modifying them after the mutation doesn't modify the history.
+func copyVertexProperties(properties graph.VertexProperties) graph.VertexProperties {
+	properties.Attributes = copyAttributes(properties.Attributes)
+	properties.Weights = copyWeights(properties.Weights)
+	return properties
+}
+
+func copyEdge[K comparable](edge graph.Edge[K]) graph.Edge[K] {
+	edge.Properties.Attributes = copyAttributes(edge.Properties.Attributes)
+	return edge
+}
+
+func copyAttributes(attributes map[string]string) map[string]string {
+	c := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		c[key] = value
+	}
+
+	return c
+}
+
+func copyWeights(weights map[string]float64) map[string]float64 {
+	if weights == nil {
+		return nil
+	}
+
+	c := make(map[string]float64, len(weights))
+	for name, weight := range weights {
+		c[name] = weight
+	}
+
+	return c
+}
+
+func edgePropertiesAreEqual(a, b graph.EdgeProperties) bool {
+	if a.Weight != b.Weight || a.Data != b.Data || len(a.Attributes) != len(b.Attributes) {
+		return false
+	}
+
+	for key, value := range a.Attributes {
+		if other, ok := b.Attributes[key]; !ok || other != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,146 @@
+package versionedstore
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/dominikbraun/graph"
+	"github.com/dominikbraun/graph/filestore"
+)
+
+func newStore(t *testing.T) *filestore.Store[string, string] {
+	store, err := filestore.Open[string, string](filepath.Join(t.TempDir(), "graph.log"))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return store
+}
+
+func TestStore_AtRevision(t *testing.T) {
+	backing := newStore(t)
+	_ = backing.AddVertex("A", "A", graph.VertexProperties{})
+
+	store, err := Wrap[string, string](backing)
+	if err != nil {
+		t.Fatalf("failed to wrap store: %v", err)
+	}
+
+	g := graph.NewWithStore(graph.StringHash, graph.Store[string, string](store), graph.Directed())
+
+	_ = g.AddVertex("B")
+	_ = g.AddEdge("A", "B")
+	_ = g.AddVertex("C")
+	_ = g.RemoveEdge("A", "B")
+
+	// A failed mutation must not be recorded.
+	if err := g.AddVertex("C"); !errors.Is(err, graph.ErrVertexAlreadyExists) {
+		t.Fatalf("error expectancy doesn't match: expected %v, got %v", graph.ErrVertexAlreadyExists, err)
+	}
+
+	if store.Revision() != 4 {
+		t.Fatalf("revision doesn't match: expected %v, got %v", 4, store.Revision())
+	}
+
+	tests := map[string]struct {
+		revision         uint64
+		expectedVertices int
+		expectedEdges    int
+		expectedErr      error
+	}{
+		"revision 0": {revision: 0, expectedVertices: 1, expectedEdges: 0},
+		"revision 2": {revision: 2, expectedVertices: 2, expectedEdges: 1},
+		"revision 4": {revision: 4, expectedVertices: 3, expectedEdges: 0},
+		"revision 5": {revision: 5, expectedErr: ErrRevisionNotFound},
+	}
+
+	for name, test := range tests {
+		view, err := store.AtRevision(test.revision)
+
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, test.expectedErr, err)
+		}
+
+		if test.expectedErr != nil {
+			continue
+		}
+
+		vertexCount, _ := view.VertexCount()
+		edgeCount, _ := view.EdgeCount()
+
+		if vertexCount != test.expectedVertices {
+			t.Errorf("%s: vertex count doesn't match: expected %v, got %v", name, test.expectedVertices, vertexCount)
+		}
+
+		if edgeCount != test.expectedEdges {
+			t.Errorf("%s: edge count doesn't match: expected %v, got %v", name, test.expectedEdges, edgeCount)
+		}
+
+		if err := view.AddVertex("D", "D", graph.VertexProperties{}); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrReadOnly, err)
+		}
+	}
+}
+
+func TestStore_Diff(t *testing.T) {
+	store, err := Wrap[string, string](newStore(t))
+	if err != nil {
+		t.Fatalf("failed to wrap store: %v", err)
+	}
+
+	g := graph.NewWithStore(graph.StringHash, graph.Store[string, string](store), graph.Directed())
+
+	_ = g.AddVertex("A")
+	_ = g.AddVertex("B")
+	_ = g.AddVertex("C")
+	_ = g.AddEdge("A", "B")
+	_ = g.AddEdge("B", "C")
+
+	from := store.Revision()
+
+	_ = g.UpdateEdge("A", "B", graph.EdgeWeight(3))
+	_ = g.RemoveEdge("B", "C")
+	_ = g.RemoveVertex("C")
+	_ = g.AddVertex("D")
+	_ = g.AddEdge("B", "D")
+
+	diff, err := store.Diff(from, store.Revision())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(diff.AddedVertices) != 1 || diff.AddedVertices[0] != "D" {
+		t.Errorf("added vertices don't match: expected %v, got %v", []string{"D"}, diff.AddedVertices)
+	}
+
+	if len(diff.RemovedVertices) != 1 || diff.RemovedVertices[0] != "C" {
+		t.Errorf("removed vertices don't match: expected %v, got %v", []string{"C"}, diff.RemovedVertices)
+	}
+
+	if len(diff.AddedEdges) != 1 || diff.AddedEdges[0].Target != "D" {
+		t.Errorf("added edges don't match: got %v", diff.AddedEdges)
+	}
+
+	if len(diff.RemovedEdges) != 1 || diff.RemovedEdges[0].Target != "C" {
+		t.Errorf("removed edges don't match: got %v", diff.RemovedEdges)
+	}
+
+	if len(diff.UpdatedEdges) != 1 || diff.UpdatedEdges[0].Properties.Weight != 3 {
+		t.Errorf("updated edges don't match: got %v", diff.UpdatedEdges)
+	}
+
+	history, _ := store.History(from, store.Revision())
+
+	if len(history) != 5 || history[0].Operation != UpdateEdge || history[0].Revision != from+1 {
+		t.Errorf("history doesn't match: got %v", history)
+	}
+
+	if _, err := store.Diff(3, 2); err == nil {
+		t.Errorf("expected error for reversed range")
+	}
+}
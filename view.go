@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"errors"
+)
+
+// View returns an immutable view of the graph that reflects a single, consistent
+// point in time. Algorithms can run against the view while other goroutines keep
+// modifying the original graph, without holding a lock of the store for the
+// duration of the algorithm and without observing partial modifications:
+//
+//	view, _ := graph.View(g)
+//
+//	go func() {
+//		_ = g.AddEdge(1, 2)
+//	}()
+//
+//	order, _ := graph.TopologicalSort(view)
+//
+// The view has the same traits as g. Attempting to add, update, or remove
+// vertices or edges of the view returns ErrGraphFrozen.
+//
+// For the default in-memory store, creating a view is an O(1) operation: The
+// view shares all vertices and edges with g, and g copies them once it is
+// modified for the first time after creating the view. The store created by
+// [NewArenaStore] is copied while holding its lock, and the store of a frozen
+// graph is immutable anyway. For all other stores, the view is created using
+// [Freeze], which reads the vertices and edges one after another, so that
+// concurrent modifications may lead to views that don't reflect a single point
+// in time.
+func View[K comparable, T any](g Graph[K, T]) (Graph[K, T], error) {
+	var hash Hash[K, T]
+
+	switch g := g.(type) {
+	case *directed[K, T]:
+		hash = g.hash
+	case *undirected[K, T]:
+		hash = g.hash
+	case *cachedGraph[K, T]:
+		return View(g.Graph)
+	default:
+		return nil, errors.New("graph doesn't support views")
+	}
+
+	store, _ := storeOf(g)
+
+	viewer, ok := store.(storeViewer[K, T])
+	if !ok {
+		return Freeze(g)
+	}
+
+	traits := *g.Traits()
+
+	return NewWithStore(hash, viewer.view(), func(t *Traits) {
+		*t = traits
+	}), nil
+}
+
+// storeViewer is implemented by stores that can create an immutable copy of
+// their contents at a single point in time.
+type storeViewer[K comparable, T any] interface {
+	view() Store[K, T]
+}
+
+// view implements storeViewer by forking the store and marking the fork as
+// read-only.
+func (s *memoryStore[K, T]) view() Store[K, T] {
+	fork := s.fork()
+	fork.readOnly = true
+
+	return fork
+}
+
+// view implements storeViewer. The store is immutable, so it can be shared.
+func (s *csrStore[K, T]) view() Store[K, T] {
+	return s
+}
+
+// view implements storeViewer by copying the store while holding the read lock.
+func (s *arenaStore[K, T]) view() Store[K, T] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	c := &arenaStore[K, T]{
+		index:        make(map[K]int32, len(s.index)),
+		vertices:     make([]arenaVertex[K, T], len(s.vertices)),
+		edgeIndex:    make(map[arenaKey]int32, len(s.edgeIndex)),
+		edges:        make([]arenaEdge[K], len(s.edges)),
+		freeVertices: append([]int32(nil), s.freeVertices...),
+		freeEdges:    append([]int32(nil), s.freeEdges...),
+		vertexCount:  s.vertexCount,
+		edgeCount:    s.edgeCount,
+		pairCount:    s.pairCount,
+		readOnly:     true,
+	}
+
+	for hash, i := range s.index {
+		c.index[hash] = i
+	}
+
+	for key, e := range s.edgeIndex {
+		c.edgeIndex[key] = e
+	}
+
+	copy(c.vertices, s.vertices)
+	for i := range c.vertices {
+		c.vertices[i].properties.Attributes = copyAttributes(c.vertices[i].properties.Attributes)
+		c.vertices[i].properties.Weights = copyWeights(c.vertices[i].properties.Weights)
+	}
+
+	copy(c.edges, s.edges)
+	for i := range c.edges {
+		c.edges[i].edge.Properties.Attributes = copyAttributes(c.edges[i].edge.Properties.Attributes)
+	}
+
+	return c
+}
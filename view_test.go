@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestView(t *testing.T) {
+	tests := map[string]struct {
+		graph func() Graph[int, int]
+	}{
+		"directed graph": {
+			graph: func() Graph[int, int] {
+				return New(IntHash, Directed())
+			},
+		},
+		"undirected graph": {
+			graph: func() Graph[int, int] {
+				return New(IntHash)
+			},
+		},
+		"arena store": {
+			graph: func() Graph[int, int] {
+				return NewWithStore(IntHash, NewArenaStore[int, int](0, 0), Directed())
+			},
+		},
+		"store without views": {
+			graph: func() Graph[int, int] {
+				return NewWithStore(IntHash, Store[int, int](plainStore[int, int]{newMemoryStore[int, int]()}), Directed())
+			},
+		},
+	}
+
+	for name, test := range tests {
+		g := test.graph()
+
+		for i := 1; i <= 3; i++ {
+			_ = g.AddVertex(i)
+		}
+
+		_ = g.AddEdge(1, 2, EdgeAttribute("color", "red"))
+
+		view, err := View(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if view.Traits().IsDirected != g.Traits().IsDirected {
+			t.Errorf("%s: traits don't match: expected %v, got %v", name, g.Traits(), view.Traits())
+		}
+
+		_ = g.AddEdge(2, 3)
+		_ = g.UpdateEdge(1, 2, EdgeAttribute("color", "blue"))
+		_ = g.AddVertex(4)
+
+		if size, _ := view.Size(); size != 1 {
+			t.Errorf("%s: size doesn't match: expected %v, got %v", name, 1, size)
+		}
+
+		if order, _ := view.Order(); order != 3 {
+			t.Errorf("%s: order doesn't match: expected %v, got %v", name, 3, order)
+		}
+
+		edge, err := view.Edge(1, 2)
+		if err != nil || edge.Properties.Attributes["color"] != "red" {
+			t.Errorf("%s: edge doesn't match: got %v (error: %v)", name, edge, err)
+		}
+
+		if err := view.AddVertex(5); !errors.Is(err, ErrGraphFrozen) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrGraphFrozen, err)
+		}
+
+		if err := view.AddEdge(1, 3); !errors.Is(err, ErrGraphFrozen) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrGraphFrozen, err)
+		}
+
+		if err := view.RemoveEdge(1, 2); !errors.Is(err, ErrGraphFrozen) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrGraphFrozen, err)
+		}
+
+		if _, err := RemoveVertexWithEdges(view, 1); !errors.Is(err, ErrGraphFrozen) {
+			t.Errorf("%s: error expectancy doesn't match: expected %v, got %v", name, ErrGraphFrozen, err)
+		}
+
+		// A clone of the view can be modified again.
+		clone, err := view.Clone()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if err := clone.AddEdge(1, 3); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+
+		if size, _ := g.Size(); size != 2 {
+			t.Errorf("%s: size of original graph doesn't match: expected %v, got %v", name, 2, size)
+		}
+	}
+}
+
+func TestView_concurrentWrites(t *testing.T) {
+	g := New(IntHash, Directed())
+
+	for i := 0; i < 100; i++ {
+		_ = g.AddVertex(i)
+	}
+
+	for i := 0; i < 99; i++ {
+		_ = g.AddEdge(i, i+1)
+	}
+
+	view, err := View(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 98; i++ {
+			_ = g.RemoveEdge(i, i+1)
+			_ = g.AddEdge(i+1, i)
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		order, err := TopologicalSort(view)
+		if err != nil || len(order) != 100 || order[0] != 0 {
+			t.Errorf("topological sort of view doesn't match: got %v (error: %v)", order, err)
+		}
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,190 @@
+package graph
+
+import "math"
+
+// denseFactor determines which integer hashes are considered dense: If all
+// hashes are non-negative and smaller than denseFactor times the order of the
+// graph, a visited set only needs a few bits per vertex.
+const denseFactor = 8
+
+// visitedSet keeps track of the vertices that have been visited during a
+// traversal. Once the traversal is done, release has to be called.
+type visitedSet[K comparable] interface {
+	add(hash K)
+	contains(hash K) bool
+	release()
+}
+
+// vertexIndexer is implemented by stores that assign each vertex a dense index
+// in the range [0, vertexIndexLimit), like the store of a frozen graph.
+type vertexIndexer[K comparable] interface {
+	vertexIndex(hash K) (int, bool)
+	vertexIndexLimit() int
+}
+
+// newVisitedSet creates a visited set for the vertices of the given graph. If
+// the store of the graph assigns dense indices to its vertices or if K is an
+// integer type, the visited set is a bitset. Otherwise, it is a map.
+func newVisitedSet[K comparable, T any](g Graph[K, T]) visitedSet[K] {
+	if store, ok := storeOf(g); ok {
+		if indexer, ok := store.(vertexIndexer[K]); ok {
+			return newBitSet(indexer.vertexIndex, indexer.vertexIndexLimit())
+		}
+	}
+
+	if index, ok := integerIndex[K](); ok {
+		if order, err := g.Order(); err == nil {
+			return newBitSet(index, denseFactor*order+64)
+		}
+	}
+
+	return mapSet[K](getMap[K, struct{}]())
+}
+
+// mapSet is a visited set backed by a pooled map.
+type mapSet[K comparable] map[K]struct{}
+
+func (m mapSet[K]) add(hash K) {
+	m[hash] = struct{}{}
+}
+
+func (m mapSet[K]) contains(hash K) bool {
+	_, ok := m[hash]
+	return ok
+}
+
+func (m mapSet[K]) release() {
+	putMap(map[K]struct{}(m))
+}
+
+// bitSet is a visited set that stores a single bit for each vertex index. The
+// bits are allocated lazily up to the given limit. Vertices without an index
+// or with an index beyond the limit are stored in an overflow map instead, so
+// that a few sparse hashes don't blow up the bitset.
+type bitSet[K comparable] struct {
+	words    []uint64
+	index    func(hash K) (int, bool)
+	limit    int
+	overflow map[K]struct{}
+}
+
+func newBitSet[K comparable](index func(hash K) (int, bool), limit int) *bitSet[K] {
+	return &bitSet[K]{
+		index: index,
+		limit: limit,
+	}
+}
+
+func (b *bitSet[K]) add(hash K) {
+	i, ok := b.index(hash)
+	if !ok || i >= b.limit {
+		if b.overflow == nil {
+			b.overflow = make(map[K]struct{})
+		}
+		b.overflow[hash] = struct{}{}
+		return
+	}
+
+	word := i / 64
+
+	if word >= len(b.words) {
+		size := 2 * len(b.words)
+		if size <= word {
+			size = word + 1
+		}
+		if maxSize := (b.limit + 63) / 64; size > maxSize {
+			size = maxSize
+		}
+
+		words := make([]uint64, size)
+		copy(words, b.words)
+		b.words = words
+	}
+
+	b.words[word] |= 1 << (uint(i) % 64)
+}
+
+func (b *bitSet[K]) contains(hash K) bool {
+	i, ok := b.index(hash)
+	if !ok || i >= b.limit {
+		_, ok := b.overflow[hash]
+		return ok
+	}
+
+	word := i / 64
+
+	if word >= len(b.words) {
+		return false
+	}
+
+	return b.words[word]&(1<<(uint(i)%64)) != 0
+}
+
+func (b *bitSet[K]) release() {}
+
+// integerIndex returns a function that uses hashes of an integer type K as
+// their own indices. Negative hashes and hashes that don't fit into an int32
+// have no index. If K isn't one of Go's built-in integer types, integerIndex
+// returns false.
+func integerIndex[K comparable]() (func(hash K) (int, bool), bool) {
+	var zero K
+
+	switch any(zero).(type) {
+	case int:
+		return func(hash K) (int, bool) {
+			return signedIndex(int64(any(hash).(int)))
+		}, true
+	case int8:
+		return func(hash K) (int, bool) {
+			return signedIndex(int64(any(hash).(int8)))
+		}, true
+	case int16:
+		return func(hash K) (int, bool) {
+			return signedIndex(int64(any(hash).(int16)))
+		}, true
+	case int32:
+		return func(hash K) (int, bool) {
+			return signedIndex(int64(any(hash).(int32)))
+		}, true
+	case int64:
+		return func(hash K) (int, bool) {
+			return signedIndex(any(hash).(int64))
+		}, true
+	case uint:
+		return func(hash K) (int, bool) {
+			return unsignedIndex(uint64(any(hash).(uint)))
+		}, true
+	case uint8:
+		return func(hash K) (int, bool) {
+			return unsignedIndex(uint64(any(hash).(uint8)))
+		}, true
+	case uint16:
+		return func(hash K) (int, bool) {
+			return unsignedIndex(uint64(any(hash).(uint16)))
+		}, true
+	case uint32:
+		return func(hash K) (int, bool) {
+			return unsignedIndex(uint64(any(hash).(uint32)))
+		}, true
+	case uint64:
+		return func(hash K) (int, bool) {
+			return unsignedIndex(any(hash).(uint64))
+		}, true
+	}
+
+	return nil, false
+}
+
+func signedIndex(i int64) (int, bool) {
+	if i < 0 || i > math.MaxInt32 {
+		return 0, false
+	}
+	return int(i), true
+}
+
+func unsignedIndex(i uint64) (int, bool) {
+	if i > math.MaxInt32 {
+		return 0, false
+	}
+	return int(i), true
+}
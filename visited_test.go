@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewVisitedSet(t *testing.T) {
+	intGraph := New(IntHash)
+	_ = intGraph.AddVertex(1)
+
+	frozenGraph, _ := Freeze(New(StringHash))
+
+	tests := map[string]struct {
+		visitedSet     func() visitedSet[string]
+		intVisitedSet  func() visitedSet[int]
+		expectedBitSet bool
+	}{
+		"integer hashes": {
+			intVisitedSet: func() visitedSet[int] {
+				return newVisitedSet[int, int](intGraph)
+			},
+			expectedBitSet: true,
+		},
+		"string hashes": {
+			visitedSet: func() visitedSet[string] {
+				return newVisitedSet[string, string](New(StringHash))
+			},
+			expectedBitSet: false,
+		},
+		"frozen graph with string hashes": {
+			visitedSet: func() visitedSet[string] {
+				return newVisitedSet[string, string](frozenGraph)
+			},
+			expectedBitSet: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var isBitSet bool
+
+			if test.intVisitedSet != nil {
+				set := test.intVisitedSet()
+				_, isBitSet = set.(*bitSet[int])
+				set.release()
+			} else {
+				set := test.visitedSet()
+				_, isBitSet = set.(*bitSet[string])
+				set.release()
+			}
+
+			if isBitSet != test.expectedBitSet {
+				t.Errorf("%s: bitset expectancy doesn't match: expected %v, got %v", name, test.expectedBitSet, isBitSet)
+			}
+		})
+	}
+}
+
+func TestBitSet(t *testing.T) {
+	tests := map[string]struct {
+		limit     int
+		added     []int
+		notAdded  []int
+		wordCount int
+	}{
+		"dense hashes": {
+			limit:     1000,
+			added:     []int{0, 1, 63, 64, 999},
+			notAdded:  []int{2, 62, 65, 998},
+			wordCount: 16,
+		},
+		"hashes beyond the limit": {
+			limit:     100,
+			added:     []int{5, 100, 5000, math.MaxInt32 + 1},
+			notAdded:  []int{6, 101, 4999},
+			wordCount: 1,
+		},
+		"negative hashes": {
+			limit:     100,
+			added:     []int{-1, -64, 3},
+			notAdded:  []int{1, -2, -63},
+			wordCount: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			index, _ := integerIndex[int]()
+			set := newBitSet(index, test.limit)
+
+			for _, hash := range test.added {
+				set.add(hash)
+			}
+
+			for _, hash := range test.added {
+				if !set.contains(hash) {
+					t.Errorf("%s: expected %v to be contained", name, hash)
+				}
+			}
+
+			for _, hash := range test.notAdded {
+				if set.contains(hash) {
+					t.Errorf("%s: expected %v not to be contained", name, hash)
+				}
+			}
+
+			if len(set.words) != test.wordCount {
+				t.Errorf("%s: word count doesn't match: expected %v, got %v", name, test.wordCount, len(set.words))
+			}
+		})
+	}
+}
+
+func TestIntegerIndex(t *testing.T) {
+	if _, ok := integerIndex[string](); ok {
+		t.Errorf("expected no integer index for string hashes")
+	}
+
+	type id int
+
+	if _, ok := integerIndex[id](); ok {
+		t.Errorf("expected no integer index for named integer types")
+	}
+
+	uint8Index, _ := integerIndex[uint8]()
+	if i, ok := uint8Index(255); !ok || i != 255 {
+		t.Errorf("index doesn't match: expected %v, got %v", 255, i)
+	}
+
+	uint64Index, _ := integerIndex[uint64]()
+	if _, ok := uint64Index(math.MaxUint64); ok {
+		t.Errorf("expected no index for %v", uint64(math.MaxUint64))
+	}
+}
@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"context"
+	"sync"
+)
+
+// EventType is the type of modification described by a [GraphEvent].
+type EventType int
+
+const (
+	VertexAdded EventType = iota
+	VertexRemoved
+	EdgeAdded
+	EdgeUpdated
+	EdgeRemoved
+)
+
+// String returns the name of the event type, such as "VertexAdded".
+func (e EventType) String() string {
+	switch e {
+	case VertexAdded:
+		return "VertexAdded"
+	case VertexRemoved:
+		return "VertexRemoved"
+	case EdgeAdded:
+		return "EdgeAdded"
+	case EdgeUpdated:
+		return "EdgeUpdated"
+	case EdgeRemoved:
+		return "EdgeRemoved"
+	default:
+		return "Unknown"
+	}
+}
+
+// GraphEvent describes a single modification of a graph.
+//
+// For vertex events, Vertex contains the hash of the vertex and Properties its
+// properties. For edge events, Edge contains the edge. The edge passed along
+// with EdgeRemoved only contains the source and target hashes.
+//
+// The vertex values aren't part of the events. A replica that needs them can
+// look them up using Graph.Vertex, or use [OnAddVertex] to receive them.
+type GraphEvent[K comparable] struct {
+	Type       EventType
+	Vertex     K
+	Properties VertexProperties
+	Edge       Edge[K]
+}
+
+// Watch returns a channel that receives an event for each modification of the
+// graph, which allows UIs or replicas to mirror a live graph without polling
+// AdjacencyMap:
+//
+//	events, _ := graph.Watch(ctx, g)
+//
+//	for event := range events {
+//		fmt.Println(event.Type, event.Vertex, event.Edge)
+//	}
+//
+// The events of a watcher are delivered in the order the modifications have
+// completed. Modifications never block on a slow receiver, the events are
+// buffered until they are received instead. When ctx is cancelled, the watcher
+// is removed and the channel is closed; events that haven't been received by
+// then are discarded.
+//
+// Like hooks, watchers only observe modifications made through the graph.
+func Watch[K comparable, T any](ctx context.Context, g Graph[K, T]) (<-chan GraphEvent[K], error) {
+	w := &watcher[K]{
+		notify: make(chan struct{}, 1),
+	}
+
+	unregister, err := registerHook(g, hookFuncs[K, T]{
+		addVertex: func(hash K, _ T, properties VertexProperties) {
+			w.push(GraphEvent[K]{Type: VertexAdded, Vertex: hash, Properties: properties})
+		},
+		removeVertex: func(hash K) {
+			w.push(GraphEvent[K]{Type: VertexRemoved, Vertex: hash})
+		},
+		addEdge: func(edge Edge[K]) {
+			w.push(GraphEvent[K]{Type: EdgeAdded, Edge: edge})
+		},
+		updateEdge: func(edge Edge[K]) {
+			w.push(GraphEvent[K]{Type: EdgeUpdated, Edge: edge})
+		},
+		removeEdge: func(source, target K) {
+			w.push(GraphEvent[K]{Type: EdgeRemoved, Edge: Edge[K]{Source: source, Target: target}})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan GraphEvent[K])
+
+	go func() {
+		defer close(events)
+		defer unregister()
+
+		w.forward(ctx, events)
+	}()
+
+	return events, nil
+}
+
+// watcher buffers the events of a single call to Watch. The hooks push events
+// into the queue, and forward passes them to the channel.
+type watcher[K comparable] struct {
+	lock   sync.Mutex
+	queue  []GraphEvent[K]
+	notify chan struct{}
+}
+
+func (w *watcher[K]) push(event GraphEvent[K]) {
+	w.lock.Lock()
+	w.queue = append(w.queue, event)
+	w.lock.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher[K]) forward(ctx context.Context, events chan<- GraphEvent[K]) {
+	for {
+		w.lock.Lock()
+		queue := w.queue
+		w.queue = nil
+		w.lock.Unlock()
+
+		for _, event := range queue {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if len(queue) > 0 {
+			continue
+		}
+
+		select {
+		case <-w.notify:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
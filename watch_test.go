@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	g := New(IntHash, Directed())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := Watch(ctx, g)
+	if err != nil {
+		t.Fatalf("failed to watch graph: %v", err)
+	}
+
+	// The modifications happen before the events are received, so the events
+	// need to be buffered.
+	_ = g.AddVertex(1, VertexWeight(3))
+	_ = g.AddVertex(2)
+	_ = g.AddEdge(1, 2)
+	_ = g.AddEdge(1, 3)
+	_ = g.UpdateEdge(1, 2, EdgeWeight(4))
+	_ = g.RemoveEdge(1, 2)
+	_ = g.RemoveVertex(2)
+
+	expected := []GraphEvent[int]{
+		{Type: VertexAdded, Vertex: 1, Properties: VertexProperties{Weight: 3}},
+		{Type: VertexAdded, Vertex: 2},
+		{Type: EdgeAdded, Edge: Edge[int]{Source: 1, Target: 2}},
+		{Type: EdgeUpdated, Edge: Edge[int]{Source: 1, Target: 2, Properties: EdgeProperties{Weight: 4}}},
+		{Type: EdgeRemoved, Edge: Edge[int]{Source: 1, Target: 2}},
+		{Type: VertexRemoved, Vertex: 2},
+	}
+
+	for i, expectedEvent := range expected {
+		select {
+		case event := <-events:
+			if event.Type != expectedEvent.Type || event.Vertex != expectedEvent.Vertex {
+				t.Errorf("event %d doesn't match: expected %v, got %v", i, expectedEvent, event)
+			}
+			if event.Properties.Weight != expectedEvent.Properties.Weight {
+				t.Errorf("event %d: vertex weight doesn't match: expected %v, got %v", i, expectedEvent.Properties.Weight, event.Properties.Weight)
+			}
+			if event.Edge.Source != expectedEvent.Edge.Source || event.Edge.Target != expectedEvent.Edge.Target {
+				t.Errorf("event %d: edge doesn't match: expected %v, got %v", i, expectedEvent.Edge, event.Edge)
+			}
+			if event.Edge.Properties.Weight != expectedEvent.Edge.Properties.Weight {
+				t.Errorf("event %d: edge weight doesn't match: expected %v, got %v", i, expectedEvent.Edge.Properties.Weight, event.Edge.Properties.Weight)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %d (%v)", i, expectedEvent.Type)
+		}
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Errorf("expected channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for channel to be closed")
+	}
+
+	// Modifications after cancelling the context must not block.
+	_ = g.AddVertex(4)
+}
+
+func TestEventType_String(t *testing.T) {
+	tests := map[EventType]string{
+		VertexAdded:    "VertexAdded",
+		VertexRemoved:  "VertexRemoved",
+		EdgeAdded:      "EdgeAdded",
+		EdgeUpdated:    "EdgeUpdated",
+		EdgeRemoved:    "EdgeRemoved",
+		EventType(100): "Unknown",
+	}
+
+	for eventType, expected := range tests {
+		if s := eventType.String(); s != expected {
+			t.Errorf("string doesn't match: expected %v, got %v", expected, s)
+		}
+	}
+}
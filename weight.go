@@ -0,0 +1,64 @@
+package graph
+
+// WeightFunc returns the cost of traversing the given edge. It allows deriving
+// edge weights from attributes or data at query time instead of storing them
+// as the edge weight, so that the same graph can be queried by different
+// metrics, e.g. latency and monetary cost.
+type WeightFunc[K comparable] func(edge Edge[K]) float64
+
+// WeightOptions configures how algorithms like ShortestPath and the spanning
+// tree functions determine the weight of an edge.
+type WeightOptions[K comparable] struct {
+	WeightFunc WeightFunc[K]
+}
+
+// WeightedBy makes an algorithm use the given function to determine the weight
+// of each edge instead of the stored edge weight. The weight function is also
+// used for graphs that haven't been created using the Weighted trait:
+//
+//	latency := func(edge graph.Edge[string]) float64 {
+//		l, _ := strconv.ParseFloat(edge.Properties.Attributes["latency"], 64)
+//		return l
+//	}
+//
+//	path, _ := graph.ShortestPath(g, "A", "B", graph.WeightedBy(latency))
+func WeightedBy[K comparable](weightFunc WeightFunc[K]) func(*WeightOptions[K]) {
+	return func(o *WeightOptions[K]) {
+		o.WeightFunc = weightFunc
+	}
+}
+
+// weightFunc applies the given options and returns the configured weight
+// function, or defaultFunc if no weight function has been configured.
+func weightFunc[K comparable](options []func(*WeightOptions[K]), defaultFunc WeightFunc[K]) WeightFunc[K] {
+	var weightOptions WeightOptions[K]
+
+	for _, option := range options {
+		option(&weightOptions)
+	}
+
+	if weightOptions.WeightFunc != nil {
+		return weightOptions.WeightFunc
+	}
+
+	return defaultFunc
+}
+
+// storedWeight returns the stored weight of the given edge.
+func storedWeight[K comparable](edge Edge[K]) float64 {
+	return float64(edge.Properties.Weight)
+}
+
+// pathWeightFunc applies the given options and returns the weight function for
+// path algorithms. Edges of unweighted graphs have a weight of 1, so that the
+// path with the fewest edges is the shortest one.
+func pathWeightFunc[K comparable, T any](g Graph[K, T], options []func(*WeightOptions[K])) WeightFunc[K] {
+	defaultWeight := storedWeight[K]
+	if !g.Traits().IsWeighted {
+		defaultWeight = func(Edge[K]) float64 {
+			return 1
+		}
+	}
+
+	return weightFunc(options, defaultWeight)
+}